@@ -105,6 +105,13 @@ type String struct {
 	value  string
 	length int
 	data   *array.Binary
+
+	// dict and indices are set when the array is dictionary-encoded.
+	// Each value in the array is the entry in dict at the
+	// corresponding index in indices.
+	dict    *array.Binary
+	indices *array.Int32
+	decoder *stringDecoder
 }
 
 // NewStringFromBinaryArray creates an instance of String from
@@ -124,52 +131,98 @@ func (a *String) DataType() DataType {
 	return StringType
 }
 func (a *String) NullN() int {
+	if a.indices != nil {
+		return a.indices.NullN()
+	}
 	if a.data != nil {
 		return a.data.NullN()
 	}
 	return 0
 }
 func (a *String) NullBitmapBytes() []byte {
+	if a.indices != nil {
+		// The bitmap matches the offset of the data returned by Data.
+		return a.decoder.decode(a).NullBitmapBytes()
+	}
 	if a.data != nil {
 		return a.data.NullBitmapBytes()
 	}
 	return nil
 }
 func (a *String) IsNull(i int) bool {
+	if a.indices != nil {
+		return a.indices.IsNull(i)
+	}
 	if a.data != nil {
 		return a.data.IsNull(i)
 	}
 	return false
 }
 func (a *String) IsValid(i int) bool {
+	if a.indices != nil {
+		return a.indices.IsValid(i)
+	}
 	if a.data != nil {
 		return a.data.IsValid(i)
 	}
 	return true
 }
+
+// Data returns the array data for the array.
+// When the array is dictionary-encoded, the values are decoded
+// into binary array data the first time the data is requested
+// so the data can be read like any other string array.
 func (a *String) Data() arrow.ArrayData {
+	if a.indices != nil {
+		return a.decoder.decode(a).Data()
+	}
 	if a.data != nil {
 		return a.data.Data()
 	}
 	return nil
 }
 func (a *String) Len() int {
+	if a.indices != nil {
+		return a.indices.Len()
+	}
 	if a.data != nil {
 		return a.data.Len()
 	}
 	return a.length
 }
 func (a *String) Retain() {
+	if a.indices != nil {
+		a.indices.Retain()
+		a.dict.Retain()
+		a.decoder.retain()
+		return
+	}
 	if a.data != nil {
 		a.data.Retain()
 	}
 }
 func (a *String) Release() {
+	if a.indices != nil {
+		a.indices.Release()
+		a.dict.Release()
+		a.decoder.release()
+		return
+	}
 	if a.data != nil {
 		a.data.Release()
 	}
 }
 func (a *String) Slice(i, j int) Array {
+	if a.indices != nil {
+		data := array.NewSliceData(a.indices.Data(), int64(i), int64(j))
+		defer data.Release()
+		a.dict.Retain()
+		return &String{
+			dict:    a.dict,
+			indices: array.NewInt32Data(data),
+			decoder: newStringDecoder(a.decoder.mem),
+		}
+	}
 	if a.data != nil {
 		data := array.NewSliceData(a.data.Data(), int64(i), int64(j))
 		defer data.Release()
@@ -183,19 +236,25 @@ func (a *String) Slice(i, j int) Array {
 	}
 }
 func (a *String) Value(i int) string {
+	if a.indices != nil {
+		return a.dict.ValueString(int(a.indices.Value(i)))
+	}
 	if a.data != nil {
 		return a.data.ValueString(i)
 	}
 	return a.value
 }
 func (a *String) ValueLen(i int) int {
+	if a.indices != nil {
+		return a.dict.ValueLen(int(a.indices.Value(i)))
+	}
 	if a.data != nil {
 		return a.data.ValueLen(i)
 	}
 	return len(a.value)
 }
 func (a *String) IsConstant() bool {
-	return a.data == nil
+	return a.data == nil && a.indices == nil
 }
 
 type sliceable interface {
@@ -0,0 +1,198 @@
+package array
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/v7/arrow/array"
+	"github.com/apache/arrow/go/v7/arrow/bitutil"
+	"github.com/apache/arrow/go/v7/arrow/memory"
+)
+
+// IsDictionary returns true if the array is dictionary-encoded.
+//
+// A dictionary-encoded array stores each distinct value once
+// and stores an index into the distinct values for each element.
+// This is used for low cardinality columns, such as tags, where
+// the same value is repeated many times.
+func (a *String) IsDictionary() bool {
+	return a.indices != nil
+}
+
+// DictionaryLen returns the number of distinct values
+// in the dictionary of a dictionary-encoded array.
+// It returns zero if the array is not dictionary-encoded.
+func (a *String) DictionaryLen() int {
+	if a.dict == nil {
+		return 0
+	}
+	return a.dict.Len()
+}
+
+// StringDictionaryBuilder builds a dictionary-encoded String array.
+type StringDictionaryBuilder struct {
+	mem     memory.Allocator
+	index   map[string]int32
+	dict    *array.BinaryBuilder
+	indices *array.Int32Builder
+}
+
+func NewStringDictionaryBuilder(mem memory.Allocator) *StringDictionaryBuilder {
+	return &StringDictionaryBuilder{
+		mem:     mem,
+		index:   make(map[string]int32),
+		dict:    array.NewBinaryBuilder(mem, StringType),
+		indices: array.NewInt32Builder(mem),
+	}
+}
+func (b *StringDictionaryBuilder) Retain() {
+	b.dict.Retain()
+	b.indices.Retain()
+}
+func (b *StringDictionaryBuilder) Release() {
+	b.dict.Release()
+	b.indices.Release()
+}
+func (b *StringDictionaryBuilder) Len() int {
+	return b.indices.Len()
+}
+func (b *StringDictionaryBuilder) Cap() int {
+	return b.indices.Cap()
+}
+func (b *StringDictionaryBuilder) NullN() int {
+	return b.indices.NullN()
+}
+func (b *StringDictionaryBuilder) Append(v string) {
+	idx, ok := b.index[v]
+	if !ok {
+		idx = int32(len(b.index))
+		b.index[v] = idx
+		b.dict.AppendString(v)
+	}
+	b.indices.Append(idx)
+}
+func (b *StringDictionaryBuilder) AppendValues(v []string, valid []bool) {
+	for i, val := range v {
+		if len(valid) != 0 && !valid[i] {
+			b.AppendNull()
+			continue
+		}
+		b.Append(val)
+	}
+}
+func (b *StringDictionaryBuilder) AppendNull() {
+	b.indices.AppendNull()
+}
+func (b *StringDictionaryBuilder) Reserve(n int) {
+	b.indices.Reserve(n)
+}
+func (b *StringDictionaryBuilder) Resize(n int) {
+	b.indices.Resize(n)
+}
+func (b *StringDictionaryBuilder) NewArray() Array {
+	return b.NewStringArray()
+}
+
+// NewStringArray creates a dictionary-encoded String array from the
+// values appended to the builder and resets the builder.
+func (b *StringDictionaryBuilder) NewStringArray() *String {
+	arr := &String{
+		dict:    b.dict.NewBinaryArray(),
+		indices: b.indices.NewInt32Array(),
+		decoder: newStringDecoder(b.mem),
+	}
+	b.index = make(map[string]int32)
+	return arr
+}
+
+// DictionaryTake returns a dictionary-encoded array with the values of
+// the dictionary-encoded array at the given indices.
+// The returned array shares the dictionary with the input array.
+func DictionaryTake(arr *String, indices *Int, mem memory.Allocator) *String {
+	b := array.NewInt32Builder(mem)
+	b.Resize(indices.Len())
+	for i, n := 0, indices.Len(); i < n; i++ {
+		offset := int(indices.Value(i))
+		if arr.indices.IsNull(offset) {
+			b.AppendNull()
+			continue
+		}
+		b.Append(arr.indices.Value(offset))
+	}
+	return newDictionaryString(arr.dict, b, mem)
+}
+
+// DictionaryFilter returns a dictionary-encoded array with the values of
+// the dictionary-encoded array where the bit in the bitset is set.
+// The returned array shares the dictionary with the input array.
+func DictionaryFilter(arr *String, bitset []byte, mem memory.Allocator) *String {
+	n := bitutil.CountSetBits(bitset, 0, len(bitset))
+	b := array.NewInt32Builder(mem)
+	b.Resize(n)
+	for i := 0; i < len(bitset); i++ {
+		if !bitutil.BitIsSet(bitset, i) {
+			continue
+		}
+		if arr.indices.IsNull(i) {
+			b.AppendNull()
+			continue
+		}
+		b.Append(arr.indices.Value(i))
+	}
+	return newDictionaryString(arr.dict, b, mem)
+}
+
+func newDictionaryString(dict *array.Binary, b *array.Int32Builder, mem memory.Allocator) *String {
+	indices := b.NewInt32Array()
+	b.Release()
+	dict.Retain()
+	return &String{
+		dict:    dict,
+		indices: indices,
+		decoder: newStringDecoder(mem),
+	}
+}
+
+// stringDecoder decodes the values of a dictionary-encoded array into
+// a binary array for the callers that read the array data directly.
+// It is shared by the references to the array and the decoded values
+// are released with the last reference.
+type stringDecoder struct {
+	mem  memory.Allocator
+	refs int64
+	once sync.Once
+	data *array.Binary
+}
+
+func newStringDecoder(mem memory.Allocator) *stringDecoder {
+	return &stringDecoder{mem: mem, refs: 1}
+}
+
+func (d *stringDecoder) decode(a *String) *array.Binary {
+	d.once.Do(func() {
+		b := array.NewBinaryBuilder(d.mem, StringType)
+		defer b.Release()
+		n := a.indices.Len()
+		b.Resize(n)
+		for i := 0; i < n; i++ {
+			if a.indices.IsNull(i) {
+				b.AppendNull()
+				continue
+			}
+			b.Append(a.dict.Value(int(a.indices.Value(i))))
+		}
+		d.data = b.NewBinaryArray()
+	})
+	return d.data
+}
+
+func (d *stringDecoder) retain() {
+	atomic.AddInt64(&d.refs, 1)
+}
+
+func (d *stringDecoder) release() {
+	if atomic.AddInt64(&d.refs, -1) == 0 && d.data != nil {
+		d.data.Release()
+		d.data = nil
+	}
+}
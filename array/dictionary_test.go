@@ -0,0 +1,130 @@
+package array_test
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/v7/arrow"
+	arrowarray "github.com/apache/arrow/go/v7/arrow/array"
+	"github.com/apache/arrow/go/v7/arrow/bitutil"
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux/array"
+)
+
+func checkStringValues(t *testing.T, want []interface{}, arr *array.String) {
+	t.Helper()
+	if want, got := len(want), arr.Len(); want != got {
+		t.Fatalf("unexpected length -want/+got:\n\t- %d\n\t+ %d", want, got)
+	}
+	if want, got := countNulls(want), arr.NullN(); want != got {
+		t.Errorf("unexpected null count -want/+got:\n\t- %d\n\t+ %d", want, got)
+	}
+	for i, sz := 0, arr.Len(); i < sz; i++ {
+		if want[i] == nil {
+			if arr.IsValid(i) {
+				t.Errorf("unexpected value at index %d -want/+got:\n\t- %v\n\t+ %v", i, want[i], arr.Value(i))
+			}
+		} else if arr.IsNull(i) {
+			t.Errorf("unexpected value at index %d -want/+got:\n\t- %v\n\t+ %v", i, want[i], nil)
+		} else if want, got := want[i].(string), arr.Value(i); want != got {
+			t.Errorf("unexpected value at index %d -want/+got:\n\t- %v\n\t+ %v", i, want, got)
+		}
+	}
+}
+
+func TestStringDictionaryBuilder(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	b := array.NewStringDictionaryBuilder(mem)
+	for _, v := range []string{"a", "b", "a", "c"} {
+		b.Append(v)
+	}
+	b.AppendNull()
+	b.Append("b")
+	if want, got := 6, b.Len(); want != got {
+		t.Errorf("unexpected builder len -want/+got:\n\t- %d\n\t+ %d", want, got)
+	}
+
+	arr := b.NewStringArray()
+	b.Release()
+	defer arr.Release()
+
+	if !arr.IsDictionary() {
+		t.Fatal("expected array to be dictionary-encoded")
+	}
+	if want, got := 3, arr.DictionaryLen(); want != got {
+		t.Errorf("unexpected dictionary len -want/+got:\n\t- %d\n\t+ %d", want, got)
+	}
+	checkStringValues(t, []interface{}{"a", "b", "a", "c", nil, "b"}, arr)
+
+	// A slice shares the dictionary with the original array.
+	sl := array.Slice(arr, 2, 5).(*array.String)
+	defer sl.Release()
+	if !sl.IsDictionary() {
+		t.Fatal("expected slice to be dictionary-encoded")
+	}
+	checkStringValues(t, []interface{}{"a", "c", nil}, sl)
+}
+
+func TestDictionaryTake(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	b := array.NewStringDictionaryBuilder(mem)
+	b.AppendValues([]string{"a", "b", "", "c"}, []bool{true, true, false, true})
+	arr := b.NewStringArray()
+	b.Release()
+	defer arr.Release()
+
+	ib := array.NewIntBuilder(mem)
+	ib.AppendValues([]int64{3, 2, 0, 3}, nil)
+	indices := ib.NewIntArray()
+	ib.Release()
+	defer indices.Release()
+
+	out := array.DictionaryTake(arr, indices, mem)
+	defer out.Release()
+	if !out.IsDictionary() {
+		t.Fatal("expected result to be dictionary-encoded")
+	}
+	checkStringValues(t, []interface{}{"c", nil, "a", "c"}, out)
+}
+
+func TestStringDictionary_Data(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	b := array.NewStringDictionaryBuilder(mem)
+	b.AppendValues([]string{"a", "b", "", "a", "c"}, []bool{true, true, false, true, true})
+	arr := b.NewStringArray()
+	b.Release()
+	defer arr.Release()
+
+	// The array data is exported as a utf8 array with the decoded
+	// values, the same as an array that is not dictionary-encoded.
+	checkData := func(t *testing.T, want []interface{}, arr *array.String) {
+		t.Helper()
+		data := arr.Data()
+		if want, got := arrow.BinaryTypes.String, data.DataType(); !arrow.TypeEqual(want, got) {
+			t.Fatalf("unexpected data type -want/+got:\n\t- %v\n\t+ %v", want, got)
+		}
+		exported := arrowarray.NewBinaryData(data)
+		defer exported.Release()
+		str := array.NewStringFromBinaryArray(exported)
+		defer str.Release()
+		checkStringValues(t, want, str)
+		if want, got := data.Offset(), 0; want != got {
+			t.Errorf("unexpected data offset -want/+got:\n\t- %d\n\t+ %d", want, got)
+		}
+		for i := 0; i < arr.Len(); i++ {
+			if want, got := want[i] == nil, !bitutil.BitIsSet(arr.NullBitmapBytes(), i); want != got {
+				t.Errorf("unexpected null bitmap at index %d -want/+got:\n\t- %v\n\t+ %v", i, want, got)
+			}
+		}
+	}
+	checkData(t, []interface{}{"a", "b", nil, "a", "c"}, arr)
+
+	sl := array.Slice(arr, 1, 4).(*array.String)
+	defer sl.Release()
+	checkData(t, []interface{}{"b", nil, "a"}, sl)
+}
@@ -0,0 +1,70 @@
+package array
+
+import (
+	"github.com/apache/arrow/go/v7/arrow"
+	"github.com/apache/arrow/go/v7/arrow/array"
+	"github.com/apache/arrow/go/v7/arrow/memory"
+)
+
+// ListOf returns the data type of arrays of list values
+// with elements of the given data type.
+func ListOf(elem DataType) DataType {
+	return arrow.ListOf(elem)
+}
+
+// List is an array of list values.
+//
+// Like Binary, it is the arrow type itself. The elements of all
+// lists are stored in a single arrow array of the element type.
+type List = array.List
+
+type ListBuilder struct {
+	b *array.ListBuilder
+}
+
+func NewListBuilder(mem memory.Allocator, elem DataType) *ListBuilder {
+	return &ListBuilder{
+		b: array.NewListBuilder(mem, elem),
+	}
+}
+func (b *ListBuilder) Retain() {
+	b.b.Retain()
+}
+func (b *ListBuilder) Release() {
+	b.b.Release()
+}
+func (b *ListBuilder) Len() int {
+	return b.b.Len()
+}
+func (b *ListBuilder) Cap() int {
+	return b.b.Cap()
+}
+
+// Append starts a new list value. The elements of the
+// list are appended to the ValueBuilder afterwards.
+func (b *ListBuilder) Append(v bool) {
+	b.b.Append(v)
+}
+func (b *ListBuilder) NullN() int {
+	return b.b.NullN()
+}
+func (b *ListBuilder) AppendNull() {
+	b.b.AppendNull()
+}
+func (b *ListBuilder) Reserve(n int) {
+	b.b.Reserve(n)
+}
+func (b *ListBuilder) Resize(n int) {
+	b.b.Resize(n)
+}
+
+// ValueBuilder returns the arrow builder for the elements of the lists.
+func (b *ListBuilder) ValueBuilder() array.Builder {
+	return b.b.ValueBuilder()
+}
+func (b *ListBuilder) NewArray() Array {
+	return b.NewListArray()
+}
+func (b *ListBuilder) NewListArray() *List {
+	return b.b.NewListArray()
+}
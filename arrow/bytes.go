@@ -0,0 +1,33 @@
+package arrow
+
+import (
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/memory"
+)
+
+func NewBytes(vs [][]byte, alloc memory.Allocator) *array.Binary {
+	b := NewBinaryBuilder(alloc)
+	b.Resize(len(vs))
+	sz := 0
+	for _, v := range vs {
+		sz += len(v)
+	}
+	b.ReserveData(sz)
+	for _, v := range vs {
+		b.Append(v)
+	}
+	a := b.NewBinaryArray()
+	b.Release()
+	return a
+}
+
+func BytesSlice(arr *array.Binary, i, j int) *array.Binary {
+	return Slice(arr, int64(i), int64(j)).(*array.Binary)
+}
+
+func NewBinaryBuilder(a memory.Allocator) *array.BinaryBuilder {
+	if a == nil {
+		a = memory.DefaultAllocator
+	}
+	return array.NewBinaryBuilder(a)
+}
@@ -0,0 +1,158 @@
+package arrow
+
+import (
+	"time"
+
+	arrowarray "github.com/apache/arrow/go/v7/arrow/array"
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+// elemDataType returns the data type of the elements of array columns
+// with elements of the column type. Times and durations are stored
+// as nanoseconds like in the columns of those types.
+func elemDataType(elem flux.ColType) array.DataType {
+	switch elem {
+	case flux.TInt, flux.TTime, flux.TDuration:
+		return array.IntType
+	case flux.TUInt:
+		return array.UintType
+	case flux.TFloat:
+		return array.FloatType
+	case flux.TString:
+		return array.StringType
+	case flux.TBool:
+		return array.BooleanType
+	case flux.TBytes:
+		return array.BinaryType
+	default:
+		panic(errors.Newf(codes.Internal, "invalid array element type: %s", elem))
+	}
+}
+
+// NewListBuilder constructs a builder for an array column
+// with elements of the given column type.
+func NewListBuilder(elem flux.ColType, mem memory.Allocator) *array.ListBuilder {
+	if mem == nil {
+		mem = memory.DefaultAllocator
+	}
+	return array.NewListBuilder(mem, elemDataType(elem))
+}
+
+// AppendArray will append an array value to a compatible builder.
+func AppendArray(b array.Builder, v values.Array) error {
+	vb, ok := b.(*array.ListBuilder)
+	if !ok {
+		return errors.Newf(codes.Internal, "incompatible builder for type %s", v.Type())
+	}
+	vb.Append(true)
+	eb := vb.ValueBuilder()
+	var err error
+	v.Range(func(i int, v values.Value) {
+		if err == nil {
+			err = appendElem(eb, v)
+		}
+	})
+	return err
+}
+
+func appendElem(b arrowarray.Builder, v values.Value) error {
+	if v.IsNull() {
+		b.AppendNull()
+		return nil
+	}
+	switch vb := b.(type) {
+	case *arrowarray.Int64Builder:
+		switch v.Type().Nature() {
+		case semantic.Time:
+			vb.Append(int64(v.Time()))
+			return nil
+		case semantic.Duration:
+			nsecs, err := DurationNsecs(v.Duration())
+			if err != nil {
+				return err
+			}
+			vb.Append(nsecs)
+			return nil
+		case semantic.Int:
+			vb.Append(v.Int())
+			return nil
+		}
+	case *arrowarray.Uint64Builder:
+		if v.Type().Nature() == semantic.UInt {
+			vb.Append(v.UInt())
+			return nil
+		}
+	case *arrowarray.Float64Builder:
+		if v.Type().Nature() == semantic.Float {
+			vb.Append(v.Float())
+			return nil
+		}
+	case *arrowarray.StringBuilder:
+		if v.Type().Nature() == semantic.String {
+			vb.Append(v.Str())
+			return nil
+		}
+	case *arrowarray.BooleanBuilder:
+		if v.Type().Nature() == semantic.Bool {
+			vb.Append(v.Bool())
+			return nil
+		}
+	case *arrowarray.BinaryBuilder:
+		if v.Type().Nature() == semantic.Bytes {
+			vb.Append(v.Bytes())
+			return nil
+		}
+	}
+	return errors.Newf(codes.Internal, "incompatible array element builder for type %s", v.Type())
+}
+
+// ArrayValue returns the array value at index i of an array column
+// with elements of the given column type.
+func ArrayValue(arr *array.List, i int, elem flux.ColType) values.Value {
+	typ := flux.SemanticType(flux.ArrayOf(elem))
+	if arr.IsNull(i) {
+		return values.NewNull(typ)
+	}
+
+	offsets := arr.Offsets()
+	start, stop := int(offsets[i]), int(offsets[i+1])
+	elems := make([]values.Value, 0, stop-start)
+	vs := arr.ListValues()
+	for k := start; k < stop; k++ {
+		elems = append(elems, elemValue(vs, k, elem))
+	}
+	return values.NewArrayWithBacking(typ, elems)
+}
+
+func elemValue(vs array.Array, k int, elem flux.ColType) values.Value {
+	if vs.IsNull(k) {
+		return values.NewNull(flux.SemanticType(elem))
+	}
+	switch elem {
+	case flux.TInt:
+		return values.NewInt(vs.(*arrowarray.Int64).Value(k))
+	case flux.TTime:
+		return values.NewTime(values.Time(vs.(*arrowarray.Int64).Value(k)))
+	case flux.TDuration:
+		return values.NewDuration(values.ConvertDurationNsecs(time.Duration(vs.(*arrowarray.Int64).Value(k))))
+	case flux.TUInt:
+		return values.NewUInt(vs.(*arrowarray.Uint64).Value(k))
+	case flux.TFloat:
+		return values.NewFloat(vs.(*arrowarray.Float64).Value(k))
+	case flux.TString:
+		return values.NewString(vs.(*arrowarray.String).Value(k))
+	case flux.TBool:
+		return values.NewBool(vs.(*arrowarray.Boolean).Value(k))
+	case flux.TBytes:
+		// Copy the value so it remains valid after the array is released.
+		return values.NewBytes(append([]byte(nil), vs.(*arrowarray.Binary).Value(k)...))
+	default:
+		panic(errors.Newf(codes.Internal, "invalid array element type: %s", elem))
+	}
+}
@@ -12,6 +12,20 @@ import (
 // Repeat will construct an arrow array that repeats
 // the value n times.
 func Repeat(colType flux.ColType, v values.Value, n int, mem memory.Allocator) array.Array {
+	if colType.IsArray() {
+		b := NewListBuilder(colType.ElemType(), mem)
+		b.Resize(n)
+		for i := 0; i < n; i++ {
+			if v.IsNull() {
+				b.AppendNull()
+			} else if err := AppendArray(b, v.Array()); err != nil {
+				panic(err)
+			}
+		}
+		arr := b.NewListArray()
+		b.Release()
+		return arr
+	}
 	switch colType {
 	case flux.TInt:
 		var ival int64
@@ -49,6 +63,27 @@ func Repeat(colType flux.ColType, v values.Value, n int, mem memory.Allocator) a
 			tval = v.Time()
 		}
 		return array.IntRepeat(int64(tval), v.IsNull(), n, mem)
+	case flux.TDuration:
+		var dval int64
+		if !v.IsNull() {
+			// Repeat cannot report an error so a duration
+			// with months uses its approximate length.
+			dval = int64(v.Duration().Duration())
+		}
+		return array.IntRepeat(dval, v.IsNull(), n, mem)
+	case flux.TBytes:
+		b := array.NewBinaryBuilder(mem)
+		b.Resize(n)
+		for i := 0; i < n; i++ {
+			if v.IsNull() {
+				b.AppendNull()
+			} else {
+				b.Append(v.Bytes())
+			}
+		}
+		arr := b.NewBinaryArray()
+		b.Release()
+		return arr
 	default:
 		panic(errors.Newf(codes.Internal, "invalid arrow primitive type: %T", colType))
 	}
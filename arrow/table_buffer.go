@@ -50,6 +50,15 @@ func (t *TableBuffer) Strings(j int) *array.String {
 func (t *TableBuffer) Times(j int) *array.Int {
 	return t.Values[j].(*array.Int)
 }
+func (t *TableBuffer) Bytes(j int) *array.Binary {
+	return t.Values[j].(*array.Binary)
+}
+func (t *TableBuffer) Durations(j int) *array.Int {
+	return t.Values[j].(*array.Int)
+}
+func (t *TableBuffer) Arrays(j int) *array.List {
+	return t.Values[j].(*array.List)
+}
 
 func (t *TableBuffer) Retain() {
 	for _, vs := range t.Values {
@@ -114,8 +123,12 @@ func (t *TableBuffer) Validate() error {
 }
 
 func (t *TableBuffer) checkCol(typ flux.ColType, arr array.Array) bool {
+	if typ.IsArray() {
+		_, ok := arr.(*array.List)
+		return ok
+	}
 	switch typ {
-	case flux.TInt, flux.TTime:
+	case flux.TInt, flux.TTime, flux.TDuration:
 		_, ok := arr.(*array.Int)
 		return ok
 	case flux.TUInt:
@@ -130,6 +143,9 @@ func (t *TableBuffer) checkCol(typ flux.ColType, arr array.Array) bool {
 	case flux.TBool:
 		_, ok := arr.(*array.Boolean)
 		return ok
+	case flux.TBytes:
+		_, ok := arr.(*array.Binary)
+		return ok
 	default:
 		return false
 	}
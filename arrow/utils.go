@@ -15,8 +15,11 @@ import (
 // NewBuilder constructs a new builder for the given
 // column type. The allocator passed in must be non-nil.
 func NewBuilder(typ flux.ColType, mem memory.Allocator) array.Builder {
+	if typ.IsArray() {
+		return NewListBuilder(typ.ElemType(), mem)
+	}
 	switch typ {
-	case flux.TInt, flux.TTime:
+	case flux.TInt, flux.TTime, flux.TDuration:
 		return array.NewIntBuilder(mem)
 	case flux.TUInt:
 		return array.NewUintBuilder(mem)
@@ -26,6 +29,8 @@ func NewBuilder(typ flux.ColType, mem memory.Allocator) array.Builder {
 		return array.NewStringBuilder(mem)
 	case flux.TBool:
 		return array.NewBooleanBuilder(mem)
+	case flux.TBytes:
+		return array.NewBinaryBuilder(mem)
 	default:
 		panic(fmt.Errorf("unknown builder for type: %s", typ))
 	}
@@ -55,6 +60,12 @@ func AppendValue(b array.Builder, v values.Value) error {
 		return AppendBool(b, v.Bool())
 	case semantic.Time:
 		return AppendTime(b, v.Time())
+	case semantic.Bytes:
+		return AppendBytes(b, v.Bytes())
+	case semantic.Duration:
+		return AppendDuration(b, v.Duration())
+	case semantic.Array:
+		return AppendArray(b, v.Array())
 	default:
 		panic(fmt.Errorf("unknown builder for type: %s", v.Type()))
 	}
@@ -120,6 +131,41 @@ func AppendTime(b array.Builder, v values.Time) error {
 	return nil
 }
 
+// AppendBytes will append a bytes value to a compatible builder.
+func AppendBytes(b array.Builder, v []byte) error {
+	vb, ok := b.(*array.BinaryBuilder)
+	if !ok {
+		return errors.Newf(codes.Internal, "incompatible builder for type %s", flux.TBytes)
+	}
+	vb.Append(v)
+	return nil
+}
+
+// AppendDuration will append a Duration value to a compatible builder.
+func AppendDuration(b array.Builder, v values.Duration) error {
+	vb, ok := b.(*array.IntBuilder)
+	if !ok {
+		return errors.Newf(codes.Internal, "incompatible builder for type %s", flux.TDuration)
+	}
+	nsecs, err := DurationNsecs(v)
+	if err != nil {
+		return err
+	}
+	vb.Append(nsecs)
+	return nil
+}
+
+// DurationNsecs returns the signed number of nanoseconds that
+// represents the duration in a duration column.
+// A duration with months cannot be stored in a column because
+// the length of a month depends on the time it is added to.
+func DurationNsecs(v values.Duration) (int64, error) {
+	if v.Months() != 0 {
+		return 0, errors.Newf(codes.Invalid, "cannot store duration %v with months in a column", v)
+	}
+	return int64(v.Duration()), nil
+}
+
 // Slice will construct a new slice of the array using the given
 // start and stop index. The returned array must be released.
 //
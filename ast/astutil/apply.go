@@ -0,0 +1,184 @@
+// Package astutil provides helpers to format and rewrite Flux ASTs.
+package astutil
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/influxdata/flux/ast"
+)
+
+// Cursor describes a node encountered during Apply.
+// It is only valid during the call to the ApplyFunc it is passed to.
+type Cursor struct {
+	parent ast.Node
+	name   string
+	node   ast.Node
+	set    func(n ast.Node)
+}
+
+// Node returns the current node.
+func (c *Cursor) Node() ast.Node {
+	return c.node
+}
+
+// Parent returns the parent of the current node
+// or nil if the current node is the root.
+func (c *Cursor) Parent() ast.Node {
+	return c.parent
+}
+
+// Name returns the name of the field of the parent that contains the
+// current node. It is empty if the current node is the root.
+func (c *Cursor) Name() string {
+	return c.name
+}
+
+// Replace replaces the current node with n.
+//
+// The source location and the comments of the current node are copied
+// to n when n does not have its own so errors reported for n point at
+// the original source and formatting the rewritten AST keeps the comments.
+//
+// Replace panics if n cannot be stored in the field of the parent.
+func (c *Cursor) Replace(n ast.Node) {
+	preservePosition(c.node, n)
+	c.set(n)
+	c.node = n
+}
+
+// ApplyFunc is called for each node visited by Apply.
+// If it returns false in the pre-order call, the children
+// of the node are not visited and the post-order call is skipped.
+// If it returns false in the post-order call, Apply stops.
+type ApplyFunc func(c *Cursor) bool
+
+// Apply traverses the AST rooted at root and calls pre before the
+// children of each node are visited and post after them. Either may be nil.
+//
+// The node of the Cursor can be replaced during both calls. When pre
+// replaces a node, the children of the new node are visited.
+// Apply returns the root which may have been replaced.
+func Apply(root ast.Node, pre, post ApplyFunc) ast.Node {
+	a := &application{pre: pre, post: post}
+	c := &Cursor{node: root}
+	c.set = func(n ast.Node) {
+		root = n
+	}
+	a.apply(c)
+	return root
+}
+
+type application struct {
+	pre, post ApplyFunc
+	stopped   bool
+}
+
+func (a *application) apply(c *Cursor) {
+	if a.stopped {
+		return
+	}
+	if a.pre != nil && !a.pre(c) {
+		return
+	}
+
+	v := reflect.ValueOf(c.node)
+	if v.Kind() == reflect.Ptr && !v.IsNil() && v.Elem().Kind() == reflect.Struct {
+		a.applyFields(c.node, v.Elem())
+	}
+
+	if a.stopped {
+		return
+	}
+	if a.post != nil && !a.post(c) {
+		a.stopped = true
+	}
+}
+
+// applyFields visits the nodes in the fields of the struct in field order.
+func (a *application) applyFields(parent ast.Node, v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		if t.Field(i).Anonymous {
+			// The embedded BaseNode does not contain any nodes.
+			continue
+		}
+		f, name := v.Field(i), t.Field(i).Name
+		switch f.Kind() {
+		case reflect.Interface, reflect.Ptr:
+			a.applyValue(parent, name, f)
+		case reflect.Slice:
+			for j := 0; j < f.Len(); j++ {
+				a.applyValue(parent, name, f.Index(j))
+			}
+		}
+	}
+}
+
+// applyValue visits the node stored in the settable value v.
+func (a *application) applyValue(parent ast.Node, name string, v reflect.Value) {
+	if (v.Kind() != reflect.Interface && v.Kind() != reflect.Ptr) || v.IsNil() {
+		return
+	}
+	n, ok := v.Interface().(ast.Node)
+	if !ok || isNilNode(n) {
+		return
+	}
+	a.apply(&Cursor{
+		parent: parent,
+		name:   name,
+		node:   n,
+		set: func(n ast.Node) {
+			nv := reflect.ValueOf(n)
+			if !nv.IsValid() || !nv.Type().AssignableTo(v.Type()) {
+				panic(fmt.Sprintf("cannot replace %s.%s with %T", parent.Type(), name, n))
+			}
+			v.Set(nv)
+		},
+	})
+}
+
+// isNilNode reports whether the node is a nil pointer
+// stored in an interface.
+func isNilNode(n ast.Node) bool {
+	v := reflect.ValueOf(n)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
+
+// preservePosition copies the location and comments of old to n
+// when n does not have its own. The comments are not copied when
+// n contains old since they are still written for old.
+func preservePosition(old, n ast.Node) {
+	if old == nil || n == nil || isNilNode(old) || isNilNode(n) {
+		return
+	}
+	v := reflect.ValueOf(n)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return
+	}
+	f := v.Elem().FieldByName("BaseNode")
+	if !f.IsValid() || !f.CanAddr() {
+		return
+	}
+	base, ok := f.Addr().Interface().(*ast.BaseNode)
+	if !ok {
+		return
+	}
+	if loc := old.Location(); base.Loc == nil && loc.IsValid() {
+		base.Loc = &loc
+	}
+	if len(base.Comments) == 0 && !contains(n, old) {
+		base.Comments = old.CommentList()
+	}
+}
+
+// contains reports whether the AST rooted at root contains the node.
+func contains(root, node ast.Node) bool {
+	found := false
+	ast.Visit(root, func(n ast.Node) {
+		if n == node {
+			found = true
+		}
+	})
+	return found
+}
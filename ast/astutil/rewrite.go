@@ -0,0 +1,129 @@
+package astutil
+
+import (
+	"strings"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/ast/edit"
+)
+
+// Replace calls fn for each node of the AST rooted at root and replaces
+// the node with the returned node when it is not nil. The children of
+// a replaced node are not visited.
+// Replace returns the root which may have been replaced.
+func Replace(root ast.Node, fn func(n ast.Node) ast.Node) ast.Node {
+	return Apply(root, func(c *Cursor) bool {
+		if n := fn(c.Node()); n != nil {
+			c.Replace(n)
+			return false
+		}
+		return true
+	}, nil)
+}
+
+// InjectOption sets the value of the option with the name in the file.
+// The name is either an identifier such as now or a member of an
+// imported package such as universe.now.
+// If the file does not have the option, an option statement is added
+// before the other statements of the file.
+func InjectOption(file *ast.File, name string, value ast.Expression) {
+	if found, _ := edit.Option(file, name, edit.OptionValueFn(value)); found {
+		return
+	}
+
+	var assignment ast.Assignment
+	if pkg, prop, ok := strings.Cut(name, "."); ok {
+		assignment = &ast.MemberAssignment{
+			Member: &ast.MemberExpression{
+				Object:   &ast.Identifier{Name: pkg},
+				Property: &ast.Identifier{Name: prop},
+			},
+			Init: value,
+		}
+	} else {
+		assignment = &ast.VariableAssignment{
+			ID:   &ast.Identifier{Name: name},
+			Init: value,
+		}
+	}
+	stmt := &ast.OptionStatement{Assignment: assignment}
+	file.Body = append([]ast.Statement{stmt}, file.Body...)
+}
+
+// EditCallArgument calls fn with the value of the named argument of
+// every call to the function named callee in the AST rooted at root.
+// The callee is either an identifier such as from or a member of an
+// imported package such as v1.measurements.
+//
+// The value passed to fn is nil if the call does not have the argument.
+// The argument is set to the value returned by fn or it is removed when
+// fn returns nil. EditCallArgument returns the number of calls that
+// were passed to fn.
+func EditCallArgument(root ast.Node, callee, arg string, fn func(value ast.Expression) ast.Expression) int {
+	n := 0
+	ast.Visit(root, func(node ast.Node) {
+		call, ok := node.(*ast.CallExpression)
+		if !ok || calleeName(call.Callee) != callee {
+			return
+		}
+		n++
+		editArgument(call, arg, fn)
+	})
+	return n
+}
+
+func editArgument(call *ast.CallExpression, arg string, fn func(value ast.Expression) ast.Expression) {
+	var obj *ast.ObjectExpression
+	if len(call.Arguments) > 0 {
+		obj, _ = call.Arguments[0].(*ast.ObjectExpression)
+	}
+	if obj == nil {
+		// Calls without arguments do not have an object expression.
+		if value := fn(nil); value != nil {
+			obj = &ast.ObjectExpression{}
+			obj.Properties = append(obj.Properties, &ast.Property{
+				Key:   &ast.Identifier{Name: arg},
+				Value: value,
+			})
+			call.Arguments = []ast.Expression{obj}
+		}
+		return
+	}
+
+	for i, p := range obj.Properties {
+		if p.Key.Key() != arg {
+			continue
+		}
+		value := p.Value
+		if value == nil {
+			// The property uses the shorthand syntax {arg}.
+			value = &ast.Identifier{Name: arg}
+		}
+		if value = fn(value); value == nil {
+			obj.Properties = append(obj.Properties[:i:i], obj.Properties[i+1:]...)
+		} else {
+			preservePosition(p.Value, value)
+			p.Value = value
+		}
+		return
+	}
+	if value := fn(nil); value != nil {
+		obj.Properties = append(obj.Properties, &ast.Property{
+			Key:   &ast.Identifier{Name: arg},
+			Value: value,
+		})
+	}
+}
+
+// calleeName returns the name of the function of a call.
+func calleeName(callee ast.Expression) string {
+	switch c := callee.(type) {
+	case *ast.Identifier:
+		return c.Name
+	case *ast.MemberExpression:
+		if obj, ok := c.Object.(*ast.Identifier); ok {
+			return obj.Name + "." + c.Property.Key()
+		}
+	}
+	return ""
+}
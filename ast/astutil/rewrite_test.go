@@ -0,0 +1,135 @@
+package astutil_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/ast/astutil"
+	"github.com/influxdata/flux/parser"
+)
+
+func parseFile(t *testing.T, src string) *ast.File {
+	t.Helper()
+	pkg := parser.ParseSource(src)
+	if ast.Check(pkg) > 0 {
+		t.Fatalf("unexpected error: %s", ast.GetError(pkg))
+	} else if len(pkg.Files) != 1 {
+		t.Fatalf("expected one file in the package, got %d", len(pkg.Files))
+	}
+	return pkg.Files[0]
+}
+
+func formatFile(t *testing.T, file *ast.File) string {
+	t.Helper()
+	got, err := astutil.Format(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
+
+func TestApply(t *testing.T) {
+	file := parseFile(t, `x = f(a: "a")
+y = g(b: "a")`)
+
+	var fields []string
+	astutil.Apply(file, nil, func(c *astutil.Cursor) bool {
+		if lit, ok := c.Node().(*ast.StringLiteral); ok && lit.Value == "a" {
+			fields = append(fields, c.Parent().Type()+"."+c.Name())
+			c.Replace(&ast.StringLiteral{Value: "b"})
+		}
+		return true
+	})
+
+	if want, got := []string{"Property.Value", "Property.Value"}, fields; !cmp.Equal(want, got) {
+		t.Errorf("unexpected fields -want/+got:\n%s", cmp.Diff(want, got))
+	}
+	if want, got := `x = f(a: "b")
+y = g(b: "b")
+`, formatFile(t, file); want != got {
+		t.Errorf("unexpected formatted file -want/+got:\n\t- %q\n\t+ %q", want, got)
+	}
+}
+
+func TestApply_InvalidReplacement(t *testing.T) {
+	file := parseFile(t, `x = 1`)
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected a panic when replacing an identifier with a literal")
+		}
+	}()
+	astutil.Apply(file, func(c *astutil.Cursor) bool {
+		if _, ok := c.Node().(*ast.Identifier); ok {
+			c.Replace(&ast.IntegerLiteral{Value: 1})
+		}
+		return true
+	}, nil)
+}
+
+func TestReplace(t *testing.T) {
+	file := parseFile(t, `// the answer
+x = 1 + 1`)
+
+	astutil.Replace(file, func(n ast.Node) ast.Node {
+		if _, ok := n.(*ast.BinaryExpression); ok {
+			return &ast.IntegerLiteral{Value: 2}
+		}
+		return nil
+	})
+
+	if want, got := `// the answer
+x = 2
+`, formatFile(t, file); want != got {
+		t.Errorf("unexpected formatted file -want/+got:\n\t- %q\n\t+ %q", want, got)
+	}
+	value := file.Body[0].(*ast.VariableAssignment).Init
+	if want, got := (ast.Position{Line: 2, Column: 5}), value.Location().Start; want != got {
+		t.Errorf("unexpected location of the replaced node -want/+got:\n\t- %v\n\t+ %v", want, got)
+	}
+}
+
+func TestInjectOption(t *testing.T) {
+	file := parseFile(t, `option a = 1`)
+
+	astutil.InjectOption(file, "a", &ast.IntegerLiteral{Value: 2})
+	astutil.InjectOption(file, "b", &ast.IntegerLiteral{Value: 3})
+	astutil.InjectOption(file, "universe.c", &ast.IntegerLiteral{Value: 4})
+
+	if want, got := `option universe.c = 4
+option b = 3
+option a = 2
+`, formatFile(t, file); want != got {
+		t.Errorf("unexpected formatted file -want/+got:\n\t- %q\n\t+ %q", want, got)
+	}
+}
+
+func TestEditCallArgument(t *testing.T) {
+	file := parseFile(t, `a = from(bucket: "a")
+b = from(bucket: "a", host: "h")
+c = from()
+d = v1.measurements(bucket: "a")
+e = to(bucket: "a")`)
+
+	rename := func(value ast.Expression) ast.Expression {
+		return &ast.StringLiteral{Value: "b"}
+	}
+	if want, got := 3, astutil.EditCallArgument(file, "from", "bucket", rename); want != got {
+		t.Errorf("unexpected number of edited calls -want/+got:\n\t- %d\n\t+ %d", want, got)
+	}
+	if want, got := 1, astutil.EditCallArgument(file, "v1.measurements", "bucket", rename); want != got {
+		t.Errorf("unexpected number of edited calls -want/+got:\n\t- %d\n\t+ %d", want, got)
+	}
+	astutil.EditCallArgument(file, "from", "host", func(value ast.Expression) ast.Expression {
+		return nil
+	})
+
+	if want, got := `a = from(bucket: "b")
+b = from(bucket: "b")
+c = from(bucket: "b")
+d = v1.measurements(bucket: "b")
+e = to(bucket: "a")
+`, formatFile(t, file); want != got {
+		t.Errorf("unexpected formatted file -want/+got:\n\t- %q\n\t+ %q", want, got)
+	}
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/parser"
+)
+
+// ParamsOption is the name of the option that holds the parameters.
+const ParamsOption = "params"
+
+// ParseParams parses a list of key=value pairs into a file with an
+// `option params` record that can be used as the extern of a compiler.
+//
+// The type of each value is inferred from its text. Values may be a
+// boolean, an integer, a float, a duration such as -1h, or an RFC3339 time.
+// Any other value is a string. A value enclosed in double quotes is
+// always a string.
+func ParseParams(pairs []string) (*ast.File, error) {
+	obj := &ast.ObjectExpression{}
+	seen := make(map[string]bool, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, errors.Newf(codes.Invalid, "parameter %q must have the form key=value", pair)
+		}
+		if !isIdentifier(key) {
+			return nil, errors.Newf(codes.Invalid, "parameter name %q is not a valid identifier", key)
+		}
+		if seen[key] {
+			return nil, errors.Newf(codes.Invalid, "parameter %q is set more than once", key)
+		}
+		seen[key] = true
+
+		expr, err := paramValue(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, codes.Invalid, "invalid value for parameter %q", key)
+		}
+		obj.Properties = append(obj.Properties, &ast.Property{
+			Key:   &ast.Identifier{Name: key},
+			Value: expr,
+		})
+	}
+	return &ast.File{
+		Package: &ast.PackageClause{
+			Name: &ast.Identifier{Name: "main"},
+		},
+		Body: []ast.Statement{
+			&ast.OptionStatement{
+				Assignment: &ast.VariableAssignment{
+					ID:   &ast.Identifier{Name: ParamsOption},
+					Init: obj,
+				},
+			},
+		},
+	}, nil
+}
+
+// ParamsExtern returns the JSON of the extern file with the parameters
+// or nil if there are no parameters.
+func ParamsExtern(pairs []string) (json.RawMessage, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	file, err := ParseParams(pairs)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(file)
+}
+
+// paramValue returns the expression for the text of a parameter value.
+func paramValue(value string) (ast.Expression, error) {
+	if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		return parser.ParseString(value)
+	}
+	switch value {
+	case "":
+		return &ast.StringLiteral{Value: value}, nil
+	case "true":
+		return &ast.BooleanLiteral{Value: true}, nil
+	case "false":
+		return &ast.BooleanLiteral{Value: false}, nil
+	}
+	if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return &ast.IntegerLiteral{Value: v}, nil
+	}
+	if v, err := strconv.ParseFloat(value, 64); err == nil && !math.IsInf(v, 0) && !math.IsNaN(v) {
+		return &ast.FloatLiteral{Value: v}, nil
+	}
+	if d, err := parser.ParseDuration(strings.TrimPrefix(value, "-")); err == nil && value != "-" {
+		if strings.HasPrefix(value, "-") {
+			return &ast.UnaryExpression{
+				Operator: ast.SubtractionOperator,
+				Argument: d,
+			}, nil
+		}
+		return d, nil
+	}
+	if t, err := parser.ParseTime(value); err == nil {
+		return t, nil
+	}
+	return &ast.StringLiteral{Value: value}, nil
+}
+
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		if r == '_' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r)) {
+			continue
+		}
+		return false
+	}
+	return true
+}
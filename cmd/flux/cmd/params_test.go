@@ -0,0 +1,91 @@
+package cmd_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/influxdata/flux/ast"
+	fluxcmd "github.com/influxdata/flux/cmd/flux/cmd"
+)
+
+func TestParseParams(t *testing.T) {
+	testCases := []struct {
+		name    string
+		pairs   []string
+		want    []*ast.Property
+		wantErr string
+	}{
+		{
+			name:  "inferred types",
+			pairs: []string{"bucket=foo", "start=-1h", "every=5m", "n=10", "ratio=0.5", "debug=true", "at=2020-01-01T00:00:00Z"},
+			want: []*ast.Property{
+				property("bucket", &ast.StringLiteral{Value: "foo"}),
+				property("start", &ast.UnaryExpression{
+					Operator: ast.SubtractionOperator,
+					Argument: &ast.DurationLiteral{Values: []ast.Duration{{Magnitude: 1, Unit: "h"}}},
+				}),
+				property("every", &ast.DurationLiteral{Values: []ast.Duration{{Magnitude: 5, Unit: "m"}}}),
+				property("n", &ast.IntegerLiteral{Value: 10}),
+				property("ratio", &ast.FloatLiteral{Value: 0.5}),
+				property("debug", &ast.BooleanLiteral{Value: true}),
+				property("at", &ast.DateTimeLiteral{Value: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}),
+			},
+		},
+		{
+			name:  "quoted string",
+			pairs: []string{`n="10"`, "empty="},
+			want: []*ast.Property{
+				property("n", &ast.StringLiteral{Value: "10"}),
+				property("empty", &ast.StringLiteral{Value: ""}),
+			},
+		},
+		{
+			name:    "missing value",
+			pairs:   []string{"bucket"},
+			wantErr: `parameter "bucket" must have the form key=value`,
+		},
+		{
+			name:    "invalid name",
+			pairs:   []string{"1bucket=foo"},
+			wantErr: `parameter name "1bucket" is not a valid identifier`,
+		},
+		{
+			name:    "duplicate name",
+			pairs:   []string{"bucket=foo", "bucket=bar"},
+			wantErr: `parameter "bucket" is set more than once`,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			file, err := fluxcmd.ParseParams(tc.pairs)
+			if tc.wantErr != "" {
+				if err == nil {
+					t.Fatalf("expected error %q", tc.wantErr)
+				} else if err.Error() != tc.wantErr {
+					t.Fatalf("unexpected error -want/+got:\n\t- %s\n\t+ %s", tc.wantErr, err)
+				}
+				return
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			stmt := file.Body[0].(*ast.OptionStatement)
+			if got, want := stmt.Assignment.(*ast.VariableAssignment).ID.Name, fluxcmd.ParamsOption; got != want {
+				t.Errorf("unexpected option name -want/+got:\n\t- %s\n\t+ %s", want, got)
+			}
+			got := stmt.Assignment.(*ast.VariableAssignment).Init.(*ast.ObjectExpression).Properties
+			if !cmp.Equal(tc.want, got, cmpopts.IgnoreTypes(ast.BaseNode{})) {
+				t.Errorf("unexpected params -want/+got:\n%s", cmp.Diff(tc.want, got, cmpopts.IgnoreTypes(ast.BaseNode{})))
+			}
+		})
+	}
+}
+
+func property(key string, value ast.Expression) *ast.Property {
+	return &ast.Property{
+		Key:   &ast.Identifier{Name: key},
+		Value: value,
+	}
+}
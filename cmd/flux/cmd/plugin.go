@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"plugin"
+
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+)
+
+// LoadPlugins opens the Go plugins at the paths in order.
+//
+// A plugin is a package built with -buildmode=plugin against the same
+// version of Flux as the command. It registers its package values,
+// procedures, transformations and planner rules from its init functions,
+// which run when the plugin is opened, so the plugins must be loaded
+// before Flux is initialized with fluxinit.FluxInit.
+//
+// The Flux types of the values that a plugin registers are looked up in
+// the standard library that libflux was built with, so the Flux source of
+// a plugin package must be part of that standard library.
+func LoadPlugins(paths []string) error {
+	for _, path := range paths {
+		if _, err := plugin.Open(path); err != nil {
+			return errors.Wrapf(err, codes.Invalid, "failed to load plugin %q", path)
+		}
+	}
+	return nil
+}
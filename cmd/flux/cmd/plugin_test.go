@@ -0,0 +1,29 @@
+package cmd_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	fluxcmd "github.com/influxdata/flux/cmd/flux/cmd"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+)
+
+func TestLoadPlugins(t *testing.T) {
+	if err := fluxcmd.LoadPlugins(nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "missing.so")
+	err := fluxcmd.LoadPlugins([]string{path})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if got, want := errors.Code(err), codes.Invalid; got != want {
+		t.Errorf("unexpected error code -want/+got:\n\t- %v\n\t+ %v", want, got)
+	}
+	if !strings.Contains(err.Error(), path) {
+		t.Errorf("expected the error to name the plugin, got %q", err)
+	}
+}
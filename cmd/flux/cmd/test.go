@@ -28,7 +28,6 @@ import (
 	"github.com/influxdata/flux/dependencies/feature"
 	"github.com/influxdata/flux/dependencies/filesystem"
 	"github.com/influxdata/flux/execute"
-	"github.com/influxdata/flux/execute/executetest"
 	"github.com/influxdata/flux/fluxinit"
 	"github.com/influxdata/flux/internal/errors"
 	"github.com/influxdata/flux/parser"
@@ -38,15 +37,16 @@ import (
 const errorYield = "errorOutput"
 
 type TestFlags struct {
-	testNames     []string
-	testTags      []string
-	paths         []string
-	skipTestCases []string
-	features      string
-	skipUntagged  bool
-	parallel      bool
-	verbosity     int
-	noinit        bool
+	testNames        []string
+	testTags         []string
+	paths            []string
+	skipTestCases    []string
+	features         string
+	featureOverrides []string
+	skipUntagged     bool
+	parallel         bool
+	verbosity        int
+	noinit           bool
 }
 
 type failedTests struct{}
@@ -91,6 +91,7 @@ error running the tests or at least one test failed.
 	testCommand.Flags().StringSliceVar(&flags.testTags, "tags", []string{}, "List of tags. Tests only run if all of their tags are provided.")
 	testCommand.Flags().StringSliceVar(&flags.skipTestCases, "skip", []string{}, "List of test names to skip.")
 	testCommand.Flags().StringVar(&flags.features, "features", "", "JSON object specifying the features to execute with. See internal/feature/flags.yml for a list of the current features")
+	testCommand.Flags().StringArrayVar(&flags.featureOverrides, "feature", nil, "Set a feature flag to execute with as key=value. Can be repeated and overrides the values of --features")
 	testCommand.Flags().BoolVar(&flags.skipUntagged, "skip-untagged", false, "Skip tests with an empty tag set.")
 	testCommand.Flags().BoolVarP(&flags.parallel, "parallel", "", false, "Enables parallel test execution.")
 	testCommand.Flags().CountVarP(&flags.verbosity, "verbose", "v", "verbose (-v, -vv, or -vvv)")
@@ -126,7 +127,7 @@ func runFluxTests(out io.Writer, setup TestSetupFunc, flags TestFlags) (bool, er
 
 	ctx := context.Background()
 
-	ctx, err := WithFeatureFlags(ctx, flags.features)
+	ctx, err := WithFeatureFlags(ctx, flags.features, flags.featureOverrides)
 	if err != nil {
 		return false, err
 	}
@@ -145,14 +146,24 @@ func runFluxTests(out io.Writer, setup TestSetupFunc, flags TestFlags) (bool, er
 	return runner.Finish(), nil
 }
 
-func WithFeatureFlags(ctx context.Context, features string) (context.Context, error) {
-	flagger := executetest.TestFlagger{}
+// WithFeatureFlags returns a context with the feature flags set to the values
+// of the features JSON object, followed by the key=value overrides.
+// Each flag is validated against the registered feature flags.
+func WithFeatureFlags(ctx context.Context, features string, overrides []string) (context.Context, error) {
+	values := make(map[string]interface{})
 	if len(features) != 0 {
-		if err := json.Unmarshal([]byte(features), &flagger); err != nil {
+		if err := json.Unmarshal([]byte(features), &values); err != nil {
 			return nil, errors.Newf(codes.Invalid, "Unable to unmarshal features as json: %s", err)
 		}
 	}
-	return feature.Dependency{Flagger: flagger}.Inject(ctx), nil
+	parsed, err := feature.ParseOverrides(overrides)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range parsed {
+		values[k] = v
+	}
+	return feature.WithOverrides(ctx, values)
 }
 
 // Test wraps the functionality of a single testcase statement,
@@ -2,35 +2,148 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"os"
 
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/codes"
 	"github.com/influxdata/flux/csv"
 	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/errors"
 	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/lineprotocol"
 	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/ndjson"
+	"github.com/influxdata/flux/parser"
 	"github.com/influxdata/flux/runtime"
 )
 
-func executeE(ctx context.Context, script, format string) error {
-	c := lang.FluxCompiler{
-		Query: script,
+// Exit codes of a script that could not be executed.
+const (
+	exitRuntimeError = 1
+	exitCompileError = 2
+	exitEmptyResults = 3
+)
+
+// exitError is an error that exits the command with a specific code.
+type exitError struct {
+	err  error
+	code int
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+// exitCode returns the code the command exits with for the error.
+func exitCode(err error) int {
+	if e, ok := err.(*exitError); ok {
+		return e.code
+	}
+	return exitRuntimeError
+}
+
+func compileError(err error) error {
+	return &exitError{err: err, code: exitCompileError}
+}
+
+// startError classifies an error from starting the script.
+// The script is analyzed when it is started, so the errors of
+// the analysis are compile errors. Any other error, such as an
+// invalid argument of a function, is raised while the script
+// is evaluated and is a runtime error.
+func startError(err error) error {
+	var aerr *runtime.AnalysisError
+	if stderrors.As(err, &aerr) {
+		return compileError(err)
+	}
+	return runtimeError(err)
+}
+
+func runtimeError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &exitError{err: err, code: exitRuntimeError}
+}
+
+// executeE executes the script and writes the results to stdout
+// in the format. Local imports of the script are resolved relative
+// to dir. When requireRows is set, a script that does not produce
+// any rows is an error.
+func executeE(ctx context.Context, script, dir string, extern json.RawMessage, format string, opts table.PrettyOptions, requireRows bool) error {
+	pkg, err := resolveImports(ctx, script, dir)
+	if err != nil {
+		return compileError(err)
+	}
+	var c flux.Compiler = lang.FluxCompiler{
+		Query:  script,
+		Extern: extern,
+	}
+	if pkg != nil {
+		c = lang.ASTCompiler{
+			AST:    pkg,
+			Extern: extern,
+		}
 	}
 	prog, err := c.Compile(ctx, runtime.Default)
 	if err != nil {
-		return err
+		return compileError(err)
 	}
 
 	mem := &memory.ResourceAllocator{}
 	q, err := prog.Start(ctx, mem)
 	if err != nil {
-		return err
+		return startError(err)
 	}
 
-	results := flux.NewResultIteratorFromQuery(q)
+	results := &rowsResultIterator{
+		ResultIterator: flux.NewResultIteratorFromQuery(q),
+	}
 	defer results.Release()
 
+	if err := writeResults(results, format, opts); err != nil {
+		return runtimeError(err)
+	}
+	results.Release()
+	if err := results.Err(); err != nil {
+		return runtimeError(err)
+	}
+	if requireRows && !results.hasRows {
+		return &exitError{err: errors.New(codes.NotFound, "script returned no rows"), code: exitEmptyResults}
+	}
+	return nil
+}
+
+// resolveImports returns the JSON AST of the script with its local
+// imports resolved relative to dir and its third-party imports resolved
+// with the flux.json manifest in dir. It returns nil if the script does
+// not import any local or third-party packages.
+func resolveImports(ctx context.Context, script, dir string) (json.RawMessage, error) {
+	pkg := parser.ParseSource(script)
+	if ast.Check(pkg) > 0 {
+		// Parse errors are reported by the compiler.
+		return nil, nil
+	}
+	if ok, err := lang.ResolveImports(ctx, pkg, dir); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, nil
+	}
+	return json.Marshal(pkg)
+}
+
+// writeResults writes the results to stdout in the format.
+func writeResults(results flux.ResultIterator, format string, opts table.PrettyOptions) error {
+
 	if format == "cli" {
 		for results.More() {
 			res := results.Next()
@@ -49,7 +162,61 @@ func executeE(ctx context.Context, script, format string) error {
 		if err != nil {
 			return err
 		}
+	} else if format == "lp" {
+		encoder := lineprotocol.NewMultiResultEncoder(lineprotocol.DefaultEncoderConfig())
+		_, err := encoder.Encode(os.Stdout, results)
+		if err != nil {
+			return err
+		}
+	} else if format == "table" {
+		for results.More() {
+			res := results.Next()
+			fmt.Println("Result:", res.Name())
+			if err := res.Tables().Do(func(tbl flux.Table) error {
+				if _, err := table.WritePretty(os.Stdout, tbl, opts); err != nil {
+					return err
+				}
+				fmt.Println()
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+	} else if format == "ndjson" {
+		encoder := ndjson.NewMultiResultEncoder()
+		_, err := encoder.Encode(os.Stdout, results)
+		if err != nil {
+			return err
+		}
 	}
-	results.Release()
-	return results.Err()
+	return nil
+}
+
+// rowsResultIterator records whether any of the tables
+// of the results contained rows.
+type rowsResultIterator struct {
+	flux.ResultIterator
+	hasRows bool
+}
+
+func (ri *rowsResultIterator) Next() flux.Result {
+	return &rowsResult{Result: ri.ResultIterator.Next(), ri: ri}
+}
+
+type rowsResult struct {
+	flux.Result
+	ri *rowsResultIterator
+}
+
+func (r *rowsResult) Tables() flux.TableIterator {
+	return r
+}
+
+func (r *rowsResult) Do(f func(flux.Table) error) error {
+	return r.Result.Tables().Do(func(tbl flux.Table) error {
+		if !tbl.Empty() {
+			r.ri.hasRows = true
+		}
+		return f(tbl)
+	})
 }
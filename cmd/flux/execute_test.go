@@ -0,0 +1,59 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/runtime"
+)
+
+func TestExitCode(t *testing.T) {
+	invalid := errors.New(codes.Invalid, "invalid argument")
+	analysis := &errors.Error{
+		Code: codes.Invalid,
+		Err:  &runtime.AnalysisError{Err: errors.New(codes.Invalid, "error @1:5-1:8: expected int but found string")},
+	}
+	for _, tc := range []struct {
+		name string
+		err  error
+		want int
+	}{
+		{
+			name: "compile error",
+			err:  compileError(invalid),
+			want: exitCompileError,
+		},
+		{
+			name: "runtime error",
+			err:  runtimeError(invalid),
+			want: exitRuntimeError,
+		},
+		{
+			name: "analysis error on start",
+			err:  startError(errors.Wrap(analysis, codes.Inherit, "failed to start")),
+			want: exitCompileError,
+		},
+		{
+			name: "invalid argument on start",
+			err:  startError(invalid),
+			want: exitRuntimeError,
+		},
+		{
+			name: "empty results",
+			err:  &exitError{err: errors.New(codes.NotFound, "script returned no rows"), code: exitEmptyResults},
+			want: exitEmptyResults,
+		},
+		{
+			name: "unclassified error",
+			err:  invalid,
+			want: exitRuntimeError,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := exitCode(tc.err); got != tc.want {
+				t.Errorf("unexpected exit code -want/+got:\n\t- %d\n\t+ %d", tc.want, got)
+			}
+		})
+	}
+}
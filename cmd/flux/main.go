@@ -5,11 +5,16 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	fluxcmd "github.com/influxdata/flux/cmd/flux/cmd"
 	"github.com/influxdata/flux/codes"
 	"github.com/influxdata/flux/dependencies"
+	"github.com/influxdata/flux/dependencies/secret"
 	"github.com/influxdata/flux/dependency"
+	"github.com/influxdata/flux/execute/table"
 	"github.com/influxdata/flux/fluxinit"
 	"github.com/influxdata/flux/internal/errors"
 	"github.com/influxdata/flux/repl"
@@ -25,21 +30,71 @@ var flags struct {
 	ExecScript        bool
 	Trace             string
 	Format            string
+	MaxRows           int
+	MaxColumnWidth    int
+	Params            []string
 	Features          string
+	FeatureOverrides  []string
 	EnableSuggestions bool
+	SecretsEnv        bool
+	SecretsFile       string
+	SecretsVaultPath  string
+	Plugins           []string
 }
 
 func runE(cmd *cobra.Command, args []string) error {
+	return executeScript(args, false)
+}
+
+// runScriptE runs a script file like an executable. It is used by
+// scripts that start with a shebang line and returns an error when
+// the script does not produce any rows.
+func runScriptE(cmd *cobra.Command, args []string) error {
+	return executeScript(args, true)
+}
+
+// readScript reads the script from the argument.
+// The argument is the script itself with --exec, - to read the script
+// from stdin or the path of a file.
+func readScript(arg string) (string, error) {
+	if flags.ExecScript {
+		return arg, nil
+	}
+	var (
+		content []byte
+		err     error
+	)
+	if arg == "-" {
+		content, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		content, err = ioutil.ReadFile(arg)
+	}
+	if err != nil {
+		return "", err
+	}
+	return stripShebang(string(content)), nil
+}
+
+// stripShebang comments out the shebang line of a script
+// so it can be parsed while keeping the line numbers intact.
+func stripShebang(script string) string {
+	if strings.HasPrefix(script, "#!") {
+		return "//" + script[2:]
+	}
+	return script
+}
+
+func executeScript(args []string, requireRows bool) error {
 	var script string
+	dir := "."
 	if len(args) > 0 {
-		if flags.ExecScript {
-			script = args[0]
-		} else {
-			content, err := ioutil.ReadFile(args[0])
-			if err != nil {
-				return err
-			}
-			script = string(content)
+		content, err := readScript(args[0])
+		if err != nil {
+			return err
+		}
+		script = content
+		if !flags.ExecScript && args[0] != "-" {
+			dir = filepath.Dir(args[0])
 		}
 	}
 
@@ -56,7 +111,7 @@ func runE(cmd *cobra.Command, args []string) error {
 	ctx, span := injectDependencies(ctx)
 	defer span.Finish()
 
-	ctx, err = fluxcmd.WithFeatureFlags(ctx, flags.Features)
+	ctx, err = fluxcmd.WithFeatureFlags(ctx, flags.Features, flags.FeatureOverrides)
 	if err != nil {
 		return err
 	}
@@ -69,7 +124,14 @@ func runE(cmd *cobra.Command, args []string) error {
 	if len(args) == 0 {
 		return replE(ctx, opts...)
 	}
-	return executeE(ctx, script, flags.Format)
+	extern, err := fluxcmd.ParamsExtern(flags.Params)
+	if err != nil {
+		return err
+	}
+	prettyOpts := table.DefaultPrettyOptions()
+	prettyOpts.MaxRows = flags.MaxRows
+	prettyOpts.MaxColumnWidth = flags.MaxColumnWidth
+	return executeE(ctx, script, dir, extern, flags.Format, prettyOpts, requireRows)
 }
 
 func configureTracing(ctx context.Context) (context.Context, func(), error) {
@@ -106,25 +168,76 @@ func configureTracing(ctx context.Context) (context.Context, func(), error) {
 
 const DefaultInfluxDBHost = "http://localhost:9999"
 
+// secretsCacheTTL is how long secrets are cached before they are loaded again.
+const secretsCacheTTL = time.Minute
+
 func injectDependencies(ctx context.Context) (context.Context, *dependency.Span) {
 	deps := dependencies.NewDefaultDependencies(DefaultInfluxDBHost)
+	if ss := secretService(); ss != nil {
+		deps.Deps.Deps.SecretService = ss
+	}
 	return dependency.Inject(ctx, deps)
 }
 
+// secretService creates the secret service for secret.get()
+// from the secret providers enabled by the flags.
+// Providers are searched in the order: environment, file, Vault.
+func secretService() secret.Service {
+	var chain secret.ChainedSecretService
+	if flags.SecretsEnv {
+		chain = append(chain, secret.EnvironmentSecretService{})
+	}
+	if flags.SecretsFile != "" {
+		chain = append(chain, secret.FileSecretService{Path: flags.SecretsFile})
+	}
+	if flags.SecretsVaultPath != "" {
+		chain = append(chain, secret.VaultSecretService{
+			Address:   os.Getenv("VAULT_ADDR"),
+			Token:     os.Getenv("VAULT_TOKEN"),
+			Namespace: os.Getenv("VAULT_NAMESPACE"),
+			Path:      flags.SecretsVaultPath,
+		})
+	}
+	if len(chain) == 0 {
+		return nil
+	}
+	return secret.NewCachedSecretService(chain, secretsCacheTTL)
+}
+
 func main() {
 	fluxCmd := &cobra.Command{
-		Use:           "flux",
+		Use:           "flux [file | -]",
 		Args:          cobra.MaximumNArgs(1),
 		RunE:          runE,
 		SilenceUsage:  true,
 		SilenceErrors: true,
+		// Plugins register their packages when they are opened,
+		// so they are loaded before any command initializes Flux.
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return fluxcmd.LoadPlugins(flags.Plugins)
+		},
 	}
+	fluxCmd.PersistentFlags().StringArrayVar(&flags.Plugins, "plugin", nil, "Load a Go plugin that registers additional packages and transformations. Can be repeated")
 	fluxCmd.Flags().BoolVarP(&flags.ExecScript, "exec", "e", false, "Interpret file argument as a raw flux script")
 	fluxCmd.Flags().BoolVarP(&flags.EnableSuggestions, "enable-suggestions", "", false, "enable suggestions in the repl")
-	fluxCmd.Flags().StringVar(&flags.Trace, "trace", "", "Trace query execution")
-	fluxCmd.Flags().StringVarP(&flags.Format, "format", "", "cli", "Output format one of: cli,csv. Defaults to cli")
-	fluxCmd.Flag("trace").NoOptDefVal = "jaeger"
-	fluxCmd.Flags().StringVar(&flags.Features, "features", "", "JSON object specifying the features to execute with. See internal/feature/flags.yml for a list of the current features")
+	addExecuteFlags(fluxCmd)
+
+	runCmd := &cobra.Command{
+		Use:   "run <file | ->",
+		Short: "Run a Flux script",
+		Long: `Run a Flux script file like an executable.
+
+A script can be made executable with a shebang line such as:
+
+	#!/usr/bin/env -S flux run
+
+The command exits with code 1 when the script fails while it runs,
+2 when the script cannot be compiled and 3 when it returns no rows.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runScriptE,
+	}
+	addExecuteFlags(runCmd)
+	fluxCmd.AddCommand(runCmd)
 
 	fmtCmd := &cobra.Command{
 		Use:   "fmt",
@@ -144,10 +257,26 @@ func main() {
 		if _, ok := err.(silentError); !ok {
 			fmt.Fprintln(fluxCmd.OutOrStderr(), err)
 		}
-		os.Exit(1)
+		os.Exit(exitCode(err))
 	}
 }
 
+// addExecuteFlags adds the flags used to execute a script to the command.
+func addExecuteFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&flags.Trace, "trace", "", "Trace query execution")
+	cmd.Flags().StringVarP(&flags.Format, "format", "", "cli", "Output format one of: cli,csv,lp,ndjson,table. Defaults to cli")
+	cmd.Flags().StringVar(&flags.Format, "output-format", "cli", "Alias for --format")
+	cmd.Flags().IntVar(&flags.MaxRows, "max-rows", table.DefaultPrettyOptions().MaxRows, "Maximum number of rows written for each table with the table format. Zero writes every row")
+	cmd.Flags().IntVar(&flags.MaxColumnWidth, "max-column-width", table.DefaultPrettyOptions().MaxColumnWidth, "Maximum width of the values written with the table format. Zero never truncates values")
+	cmd.Flag("trace").NoOptDefVal = "jaeger"
+	cmd.Flags().BoolVar(&flags.SecretsEnv, "secrets-env", false, "Load secrets from environment variables")
+	cmd.Flags().StringVar(&flags.SecretsFile, "secrets-file", "", "Load secrets from a JSON file containing an object of string values")
+	cmd.Flags().StringVar(&flags.SecretsVaultPath, "secrets-vault-path", "", "Load secrets from the fields of the Vault secret at this path, using VAULT_ADDR and VAULT_TOKEN")
+	cmd.Flags().StringVar(&flags.Features, "features", "", "JSON object specifying the features to execute with. See internal/feature/flags.yml for a list of the current features")
+	cmd.Flags().StringArrayVar(&flags.Params, "param", nil, "Set a parameter of the script as key=value. The parameters are available as the params option record. Can be repeated")
+	cmd.Flags().StringArrayVar(&flags.FeatureOverrides, "feature", nil, "Set a feature flag to execute with as key=value. Can be repeated and overrides the values of --features")
+}
+
 // silentError indicates the error should not be printed to stderr.
 type silentError interface {
 	Silent()
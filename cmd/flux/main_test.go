@@ -0,0 +1,98 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStripShebang(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		script string
+		want   string
+	}{
+		{
+			name:   "shebang",
+			script: "#!/usr/bin/env flux run\nx = 1\n",
+			want:   "///usr/bin/env flux run\nx = 1\n",
+		},
+		{
+			name:   "no shebang",
+			script: "x = 1\n",
+			want:   "x = 1\n",
+		},
+		{
+			name:   "shebang after the first line",
+			script: "x = 1\n#!/usr/bin/env flux run\n",
+			want:   "x = 1\n#!/usr/bin/env flux run\n",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := stripShebang(tc.script); got != tc.want {
+				t.Errorf("unexpected script -want/+got:\n\t- %q\n\t+ %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestReadScript(t *testing.T) {
+	const (
+		src  = "#!/usr/bin/env flux run\nx = 1\n"
+		want = "///usr/bin/env flux run\nx = 1\n"
+	)
+	filename := filepath.Join(t.TempDir(), "script.flux")
+	if err := os.WriteFile(filename, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("file", func(t *testing.T) {
+		got, err := readScript(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("unexpected script -want/+got:\n\t- %q\n\t+ %q", want, got)
+		}
+	})
+
+	t.Run("stdin", func(t *testing.T) {
+		f, err := os.Open(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() { _ = f.Close() }()
+
+		stdin := os.Stdin
+		os.Stdin = f
+		defer func() { os.Stdin = stdin }()
+
+		got, err := readScript("-")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("unexpected script -want/+got:\n\t- %q\n\t+ %q", want, got)
+		}
+	})
+
+	t.Run("exec", func(t *testing.T) {
+		flags.ExecScript = true
+		defer func() { flags.ExecScript = false }()
+
+		// The script passed with --exec is used as is.
+		got, err := readScript(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != src {
+			t.Errorf("unexpected script -want/+got:\n\t- %q\n\t+ %q", src, got)
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		if _, err := readScript(filepath.Join(t.TempDir(), "missing.flux")); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
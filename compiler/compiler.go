@@ -3,6 +3,7 @@ package compiler
 import (
 	"github.com/influxdata/flux/codes"
 	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/interpreter"
 	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/flux/values"
 )
@@ -751,6 +752,7 @@ func compile(n semantic.Node, subst semantic.Substitutor) (Evaluator, error) {
 		}
 		return &callEvaluator{
 			t:      apply(subst, nil, n.TypeOf()),
+			name:   interpreter.ProfileName(n),
 			callee: callee,
 			args:   args,
 		}, nil
@@ -1202,6 +1202,7 @@ func (e *arrayIndexEvaluator) Eval(ctx context.Context, scope Scope) (values.Val
 
 type callEvaluator struct {
 	t      semantic.MonoType
+	name   string
 	callee Evaluator
 	args   Evaluator
 }
@@ -1228,6 +1229,9 @@ func (e *callEvaluator) Eval(ctx context.Context, scope Scope) (values.Value, er
 		return nil, errors.Newf(codes.Invalid, "attempt to call a value of type %s; expected function", typ)
 	}
 
+	if p := interpreter.GetFunctionProfiler(ctx); p != nil {
+		defer p.StartCall(e.name)()
+	}
 	return f.Function().Call(ctx, args.Object())
 }
 
@@ -0,0 +1,135 @@
+package csv
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+)
+
+const defaultInferRowCount = 100
+
+// columnTypes tracks the datatypes that every sampled value
+// of a column can be decoded as.
+type columnTypes struct {
+	bool, long, double, dateTime bool
+}
+
+func newColumnTypes() columnTypes {
+	return columnTypes{bool: true, long: true, double: true, dateTime: true}
+}
+
+func (t *columnTypes) observe(value string) {
+	if t.bool {
+		t.bool = value == "true" || value == "false"
+	}
+	if t.long {
+		_, err := strconv.ParseInt(value, 10, 64)
+		t.long = err == nil
+	}
+	if t.double {
+		// Require a digit so words like "Inf" or "NaN" stay strings.
+		_, err := strconv.ParseFloat(value, 64)
+		t.double = err == nil && strings.ContainsAny(value, "0123456789")
+	}
+	if t.dateTime {
+		_, err := time.Parse(time.RFC3339Nano, value)
+		t.dateTime = err == nil
+	}
+}
+
+// datatype returns the most specific datatype of the column.
+// Columns without any values are strings.
+func (t columnTypes) datatype(seen bool) string {
+	switch {
+	case !seen:
+		return stringDatatype
+	case t.bool:
+		return boolDatatype
+	case t.long:
+		return intDatatype
+	case t.double:
+		return floatDatatype
+	case t.dateTime:
+		return timeDataTypeWithFmt
+	default:
+		return stringDatatype
+	}
+}
+
+// rawDatatypes determines the datatypes of the columns of CSV data without annotations.
+// Columns in the schema use the datatype of the schema. If type inference is enabled,
+// the datatype of the remaining columns is inferred from a sample of the data rows,
+// otherwise they are strings.
+func rawDatatypes(r *bufferedCSVReader, c ResultDecoderConfig, labels []string) ([]string, error) {
+	datatypes := make([]string, len(labels))
+	for j := range datatypes {
+		datatypes[j] = stringDatatype
+	}
+
+	if c.InferTypes {
+		n := c.InferRowCount
+		if n <= 0 {
+			n = defaultInferRowCount
+		}
+		lines, err := r.Sample(n)
+		if err != nil {
+			return nil, err
+		}
+		types := make([]columnTypes, len(labels))
+		seen := make([]bool, len(labels))
+		for j := range types {
+			types[j] = newColumnTypes()
+		}
+		for _, line := range lines {
+			if len(line) != len(labels) {
+				// The decoder reports the invalid row.
+				continue
+			}
+			for j, value := range line {
+				if value == nullValue {
+					continue
+				}
+				types[j].observe(value)
+				seen[j] = true
+			}
+		}
+		for j := range datatypes {
+			datatypes[j] = types[j].datatype(seen[j])
+		}
+	}
+
+	schemaLabels := make([]string, 0, len(c.Schema))
+	for label := range c.Schema {
+		schemaLabels = append(schemaLabels, label)
+	}
+	sort.Strings(schemaLabels)
+	for _, label := range schemaLabels {
+		datatype := c.Schema[label]
+		j := indexOf(labels, label)
+		if j < 0 {
+			return nil, errors.Newf(codes.Invalid, "schema column %q does not exist", label)
+		}
+		if _, _, err := decodeType(datatype); err != nil {
+			return nil, errors.Wrapf(err, codes.Invalid, "schema column %q has invalid datatype", label)
+		}
+		if datatype == timeDatatype {
+			// Schemas may omit the time format.
+			datatype = timeDataTypeWithFmt
+		}
+		datatypes[j] = datatype
+	}
+	return datatypes, nil
+}
+
+func indexOf(labels []string, label string) int {
+	for j, l := range labels {
+		if l == label {
+			return j
+		}
+	}
+	return -1
+}
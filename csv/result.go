@@ -3,6 +3,7 @@ package csv
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
@@ -42,12 +43,14 @@ const (
 
 	commentPrefix = "#"
 
-	stringDatatype = "string"
-	timeDatatype   = "dateTime"
-	floatDatatype  = "double"
-	boolDatatype   = "boolean"
-	intDatatype    = "long"
-	uintDatatype   = "unsignedLong"
+	stringDatatype   = "string"
+	timeDatatype     = "dateTime"
+	floatDatatype    = "double"
+	boolDatatype     = "boolean"
+	intDatatype      = "long"
+	uintDatatype     = "unsignedLong"
+	bytesDatatype    = "base64Binary"
+	durationDatatype = "duration"
 
 	timeDataTypeWithFmt = "dateTime:RFC3339"
 
@@ -77,6 +80,18 @@ type ResultDecoderConfig struct {
 	NoAnnotations bool
 	// NoHeader indicates that the CSV data will not have a header row.
 	NoHeader bool
+	// InferTypes indicates that the types of the columns of CSV data without annotations
+	// are inferred from the first rows of data instead of decoding every column as a string.
+	// Columns are decoded as booleans, integers, floats or RFC3339 times when every sampled
+	// value can be decoded as that type.
+	InferTypes bool
+	// InferRowCount is the number of rows sampled to infer the column types.
+	// If 0, then a value of 100 will be used.
+	InferRowCount int
+	// Schema maps column labels of CSV data without annotations to the annotated CSV
+	// datatype used to decode the column, for example "long" or "dateTime:RFC3339".
+	// It takes precedence over inferred types.
+	Schema map[string]string
 	// MaxBufferCount is the maximum number of rows that will be buffered when decoding.
 	// If 0, then a value of 1000 will be used.
 	MaxBufferCount int
@@ -213,8 +228,7 @@ func newCSVReader(r io.Reader) *bufferedCSVReader {
 	csvr.FieldsPerRecord = -1
 	csvr.LazyQuotes = true
 	return &bufferedCSVReader{
-		r:    csvr,
-		line: nil,
+		r: csvr,
 	}
 }
 
@@ -460,6 +474,15 @@ func readMetadata(r *bufferedCSVReader, c ResultDecoderConfig) (tableMetadata, e
 		labels = line[recordStartIdx:]
 	}
 
+	if c.NoAnnotations && (c.InferTypes || len(c.Schema) > 0) {
+		// The header row may be reused by the reader when sampling.
+		labels = copyLine(labels)
+		var err error
+		if datatypes, err = rawDatatypes(r, c, labels); err != nil {
+			return tableMetadata{}, err
+		}
+	}
+
 	cols := make([]colMeta, len(labels))
 	defaultValues := make([]values.Value, len(labels))
 	groupValues := make([]bool, len(labels))
@@ -784,6 +807,20 @@ type colMeta struct {
 type ResultEncoder struct {
 	c       ResultEncoderConfig
 	written bool
+
+	// rows and bytes are updated atomically so that
+	// Stats can be called while a result is being encoded.
+	rows  int64
+	bytes int64
+}
+
+// EncoderStats are the totals of the data written by a ResultEncoder.
+type EncoderStats struct {
+	// Rows is the number of data rows written.
+	// Annotation and header rows are not counted.
+	Rows int64
+	// Bytes is the number of bytes written.
+	Bytes int64
 }
 
 // ResultEncoderConfig are options that can be specified on the ResultEncoder.
@@ -795,19 +832,55 @@ type ResultEncoderConfig struct {
 	NoHeader bool
 
 	// Delimiter is the character to delimite columns.
-	// It must not be \r, \n, or the Unicode replacement character (0xFFFD).
+	// It must not be \r, \n, ", or the Unicode replacement character (0xFFFD).
+	// Zero means a comma.
 	Delimiter rune
+
+	// NoCRLF indicates that lines should end with \n instead of \r\n.
+	NoCRLF bool
+
+	// StrictQuoting indicates that every field should be enclosed in double
+	// quotes and line breaks within a field should be written unchanged,
+	// so that the output can be read by strict RFC 4180 parsers.
+	StrictQuoting bool
+
+	// Interleaved indicates that the tables of multiple results should be
+	// written as they are produced instead of one result after the other.
+	// Each table is written with its own schema and the tables of a result
+	// may be separated by the tables of other results.
+	Interleaved bool
+}
+
+// Validate checks that the configuration can be used to encode results.
+func (c ResultEncoderConfig) Validate() error {
+	if c.Delimiter != 0 && !validDelimiter(c.Delimiter) {
+		return errors.Newf(codes.Invalid, "invalid csv delimiter %q", c.Delimiter)
+	}
+	for _, anno := range c.Annotations {
+		switch anno {
+		case datatypeAnnotation, groupAnnotation, defaultAnnotation:
+		default:
+			return errors.Newf(codes.Invalid, "unsupported annotation %q", anno)
+		}
+	}
+	return nil
 }
 
 func (c ResultEncoderConfig) MarshalJSON() ([]byte, error) {
 	request := struct {
-		Header      bool     `json:"header,omitempty"`
-		Delimiter   string   `json:"delimiter"`
-		Annotations []string `json:"annotations,omitempty"`
+		Header        bool     `json:"header,omitempty"`
+		Delimiter     string   `json:"delimiter"`
+		Annotations   []string `json:"annotations,omitempty"`
+		CRLF          bool     `json:"crlf"`
+		StrictQuoting bool     `json:"strictQuoting,omitempty"`
+		Interleaved   bool     `json:"interleaved,omitempty"`
 	}{
-		Delimiter:   string(c.Delimiter),
-		Annotations: c.Annotations,
-		Header:      !c.NoHeader,
+		Delimiter:     string(c.Delimiter),
+		Annotations:   c.Annotations,
+		Header:        !c.NoHeader,
+		CRLF:          !c.NoCRLF,
+		StrictQuoting: c.StrictQuoting,
+		Interleaved:   c.Interleaved,
 	}
 
 	return json.Marshal(request)
@@ -815,9 +888,12 @@ func (c ResultEncoderConfig) MarshalJSON() ([]byte, error) {
 
 func (c *ResultEncoderConfig) UnmarshalJSON(b []byte) error {
 	request := &struct {
-		Header      *bool    `json:"header,omitempty"`
-		Delimiter   string   `json:"delimiter"`
-		Annotations []string `json:"annotations,omitempty"`
+		Header        *bool    `json:"header,omitempty"`
+		Delimiter     string   `json:"delimiter"`
+		Annotations   []string `json:"annotations,omitempty"`
+		CRLF          *bool    `json:"crlf,omitempty"`
+		StrictQuoting bool     `json:"strictQuoting,omitempty"`
+		Interleaved   bool     `json:"interleaved,omitempty"`
 	}{}
 
 	if err := json.Unmarshal(b, request); err != nil {
@@ -834,7 +910,14 @@ func (c *ResultEncoderConfig) UnmarshalJSON(b []byte) error {
 		c.NoHeader = !*request.Header
 	}
 
+	c.NoCRLF = false
+	if request.CRLF != nil {
+		c.NoCRLF = !*request.CRLF
+	}
+
 	c.Annotations = request.Annotations
+	c.StrictQuoting = request.StrictQuoting
+	c.Interleaved = request.Interleaved
 
 	return nil
 }
@@ -852,13 +935,17 @@ func NewResultEncoder(c ResultEncoderConfig) *ResultEncoder {
 	}
 }
 
-func (e *ResultEncoder) csvWriter(w io.Writer) *csv.Writer {
-	writer := csv.NewWriter(w)
-	if e.c.Delimiter != 0 {
-		writer.Comma = e.c.Delimiter
+// Stats returns the totals of the data written by the encoder so far.
+// It is safe to call Stats while a result is being encoded.
+func (e *ResultEncoder) Stats() EncoderStats {
+	return EncoderStats{
+		Rows:  atomic.LoadInt64(&e.rows),
+		Bytes: atomic.LoadInt64(&e.bytes),
 	}
-	writer.UseCRLF = true
-	return writer
+}
+
+func (e *ResultEncoder) csvWriter(w io.Writer) *writer {
+	return newWriter(&statsWriter{w: w, n: &e.bytes}, &e.c)
 }
 
 type csvEncoderError struct {
@@ -884,7 +971,17 @@ func wrapEncodingError(err error) error {
 	return &csvEncoderError{err: err}
 }
 
+// Encode writes the result as annotated CSV.
+//
+// Rows are written directly from the table buffers and the output is
+// flushed after each buffer, so the memory used by the encoder does not
+// depend on the size of the result.
 func (e *ResultEncoder) Encode(w io.Writer, result flux.Result) (int64, error) {
+	writeCounter := &iocounter.Writer{Writer: w}
+	if err := e.c.Validate(); err != nil {
+		return 0, wrapEncodingError(err)
+	}
+
 	tableID := 0
 	tableIDStr := "0"
 	metaCols := []colMeta{
@@ -892,7 +989,6 @@ func (e *ResultEncoder) Encode(w io.Writer, result flux.Result) (int64, error) {
 		{ColMeta: flux.ColMeta{Label: resultLabel, Type: flux.TString}},
 		{ColMeta: flux.ColMeta{Label: tableLabel, Type: flux.TInt}},
 	}
-	writeCounter := &iocounter.Writer{Writer: w}
 	writer := e.csvWriter(writeCounter)
 
 	var lastCols []colMeta
@@ -902,16 +998,8 @@ func (e *ResultEncoder) Encode(w io.Writer, result flux.Result) (int64, error) {
 	resultName := result.Name()
 	err := result.Tables().Do(func(tbl flux.Table) error {
 		e.written = true
-		// Update cols with table cols
-		cols := metaCols
-		for _, c := range tbl.Cols() {
-			cm := colMeta{ColMeta: c}
-			if c.Type == flux.TTime {
-				cm.fmt = time.RFC3339Nano
-			}
-			cols = append(cols, cm)
-		}
-		// pre-allocate row slice
+		cols := e.tableCols(metaCols, tbl)
+		// pre-allocate row slice for the schema rows
 		row := make([]string, len(cols))
 
 		if lastEmpty || tbl.Empty() || schemaChanged(cols, lastCols, tbl.Key().Cols(), lastGroupCols) {
@@ -925,50 +1013,7 @@ func (e *ResultEncoder) Encode(w io.Writer, result flux.Result) (int64, error) {
 			}
 		}
 
-		if execute.ContainsStr(e.c.Annotations, defaultAnnotation) {
-			for j := range cols {
-				switch j {
-				case annotationIdx:
-					row[j] = ""
-				case resultIdx:
-					row[j] = ""
-				case tableIdx:
-					row[j] = tableIDStr
-				default:
-					row[j] = ""
-				}
-			}
-		} else {
-			for j := range cols {
-				switch j {
-				case annotationIdx:
-					row[j] = ""
-				case resultIdx:
-					row[j] = resultName
-				case tableIdx:
-					row[j] = tableIDStr
-				default:
-					row[j] = ""
-				}
-			}
-		}
-
-		if err := tbl.Do(func(cr flux.ColReader) error {
-			record := row[defaultRecordStartIdx:]
-			l := cr.Len()
-			for i := 0; i < l; i++ {
-				for j, c := range cols[defaultRecordStartIdx:] {
-					v, err := encodeValueFrom(i, j, c, cr)
-					if err != nil {
-						return wrapEncodingError(err)
-					}
-					record[j] = v
-				}
-				writer.Write(row)
-			}
-			writer.Flush()
-			return wrapEncodingError(writer.Error())
-		}); err != nil {
+		if err := e.encodeRows(writer, cols, resultName, tableIDStr, tbl); err != nil {
 			return err
 		}
 
@@ -983,7 +1028,86 @@ func (e *ResultEncoder) Encode(w io.Writer, result flux.Result) (int64, error) {
 	return writeCounter.Count(), err
 }
 
+// EncodeTable writes a single table of the named result as annotated CSV.
+// The schema of the table is always written so the output can be read
+// on its own, and id is used as the table column of its records.
+// It is used to interleave the tables of multiple results in one stream.
+func (e *ResultEncoder) EncodeTable(w io.Writer, resultName string, id int, tbl flux.Table) (int64, error) {
+	writeCounter := &iocounter.Writer{Writer: w}
+	if err := e.c.Validate(); err != nil {
+		return 0, wrapEncodingError(err)
+	}
+
+	metaCols := []colMeta{
+		{ColMeta: flux.ColMeta{Label: "", Type: flux.TInvalid}},
+		{ColMeta: flux.ColMeta{Label: resultLabel, Type: flux.TString}},
+		{ColMeta: flux.ColMeta{Label: tableLabel, Type: flux.TInt}},
+	}
+	writer := e.csvWriter(writeCounter)
+
+	e.written = true
+	cols := e.tableCols(metaCols, tbl)
+	tableIDStr := strconv.Itoa(id)
+	row := make([]string, len(cols))
+	if err := writeSchema(writer, &e.c, row, cols, tbl.Empty(), tbl.Key(), resultName, tableIDStr); err != nil {
+		return writeCounter.Count(), wrapEncodingError(err)
+	}
+	if err := e.encodeRows(writer, cols, resultName, tableIDStr, tbl); err != nil {
+		return writeCounter.Count(), err
+	}
+	writer.Flush()
+	return writeCounter.Count(), wrapEncodingError(writer.Error())
+}
+
+// tableCols returns the columns written for the table
+// which are the meta columns followed by the table columns.
+func (e *ResultEncoder) tableCols(metaCols []colMeta, tbl flux.Table) []colMeta {
+	cols := metaCols
+	for _, c := range tbl.Cols() {
+		cm := colMeta{ColMeta: c}
+		if c.Type == flux.TTime {
+			cm.fmt = time.RFC3339Nano
+		}
+		cols = append(cols, cm)
+	}
+	return cols
+}
+
+// encodeRows writes the records of the table.
+// The writer is flushed after each buffer.
+func (e *ResultEncoder) encodeRows(writer *writer, cols []colMeta, resultName, tableIDStr string, tbl flux.Table) error {
+	// The result name is omitted from the records
+	// when it is already written as a default.
+	recordResultName := resultName
+	if execute.ContainsStr(e.c.Annotations, defaultAnnotation) {
+		recordResultName = ""
+	}
+
+	return tbl.Do(func(cr flux.ColReader) error {
+		l := cr.Len()
+		for i := 0; i < l; i++ {
+			writer.WriteField("")
+			writer.WriteField(recordResultName)
+			writer.WriteField(tableIDStr)
+			for j, c := range cols[defaultRecordStartIdx:] {
+				v, err := encodeValueFrom(i, j, c, cr)
+				if err != nil {
+					return wrapEncodingError(err)
+				}
+				writer.WriteField(v)
+			}
+			writer.EndRecord()
+		}
+		writer.Flush()
+		atomic.AddInt64(&e.rows, int64(l))
+		return wrapEncodingError(writer.Error())
+	})
+}
+
 func (e *ResultEncoder) EncodeError(w io.Writer, err error) error {
+	if verr := e.c.Validate(); verr != nil {
+		return wrapEncodingError(verr)
+	}
 	writer := e.csvWriter(w)
 	if e.written {
 		// Write out empty line
@@ -1007,7 +1131,7 @@ func (e *ResultEncoder) EncodeError(w io.Writer, err error) error {
 	return writer.Error()
 }
 
-func writeSchema(writer *csv.Writer, c *ResultEncoderConfig, row []string, cols []colMeta, useKeyDefaults bool, key flux.GroupKey, resultName, tableID string) error {
+func writeSchema(writer *writer, c *ResultEncoderConfig, row []string, cols []colMeta, useKeyDefaults bool, key flux.GroupKey, resultName, tableID string) error {
 	defaults := make([]string, len(row))
 	for j, c := range cols {
 		switch j {
@@ -1051,7 +1175,7 @@ func writeSchema(writer *csv.Writer, c *ResultEncoderConfig, row []string, cols
 	return writer.Error()
 }
 
-func writeAnnotations(writer *csv.Writer, annotations []string, row, defaults []string, cols []colMeta, key flux.GroupKey) error {
+func writeAnnotations(writer *writer, annotations []string, row, defaults []string, cols []colMeta, key flux.GroupKey) error {
 	for _, annotation := range annotations {
 		switch annotation {
 		case datatypeAnnotation:
@@ -1073,7 +1197,7 @@ func writeAnnotations(writer *csv.Writer, annotations []string, row, defaults []
 	return writer.Error()
 }
 
-func writeDatatypes(writer *csv.Writer, row []string, cols []colMeta) error {
+func writeDatatypes(writer *writer, row []string, cols []colMeta) error {
 	for j, c := range cols {
 		if j == annotationIdx {
 			row[j] = commentPrefix + datatypeAnnotation
@@ -1092,6 +1216,10 @@ func writeDatatypes(writer *csv.Writer, row []string, cols []colMeta) error {
 			row[j] = stringDatatype
 		case flux.TTime:
 			row[j] = timeDataTypeWithFmt
+		case flux.TBytes:
+			row[j] = bytesDatatype
+		case flux.TDuration:
+			row[j] = durationDatatype
 		default:
 			return fmt.Errorf("unknown column type %v", c.Type)
 		}
@@ -1099,7 +1227,7 @@ func writeDatatypes(writer *csv.Writer, row []string, cols []colMeta) error {
 	return writer.Write(row)
 }
 
-func writeGroups(writer *csv.Writer, row []string, cols []colMeta, key flux.GroupKey) error {
+func writeGroups(writer *writer, row []string, cols []colMeta, key flux.GroupKey) error {
 	for j, c := range cols {
 		if j == annotationIdx {
 			row[j] = commentPrefix + groupAnnotation
@@ -1114,7 +1242,7 @@ func writeGroups(writer *csv.Writer, row []string, cols []colMeta, key flux.Grou
 	return writer.Write(row)
 }
 
-func writeDefaults(writer *csv.Writer, row, defaults []string) error {
+func writeDefaults(writer *writer, row, defaults []string) error {
 	for j := range defaults {
 		switch j {
 		case annotationIdx:
@@ -1165,6 +1293,18 @@ func decodeValue(value string, c colMeta) (values.Value, error) {
 			return nil, err
 		}
 		val = values.NewTime(v)
+	case flux.TBytes:
+		v, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, err
+		}
+		val = values.NewBytes(v)
+	case flux.TDuration:
+		v, err := values.ParseDuration(value)
+		if err != nil {
+			return nil, err
+		}
+		val = values.NewDuration(v)
 	default:
 		return nil, fmt.Errorf("unsupported type %v", c.Type)
 	}
@@ -1205,6 +1345,18 @@ func decodeValueInto(c colMeta, value string, b array.Builder) error {
 			return err
 		}
 		return arrow.AppendTime(b, t)
+	case flux.TBytes:
+		v, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return err
+		}
+		return arrow.AppendBytes(b, v)
+	case flux.TDuration:
+		v, err := values.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		return arrow.AppendDuration(b, v)
 	default:
 		return fmt.Errorf("unsupported type %v", c.Type)
 	}
@@ -1228,6 +1380,10 @@ func encodeValue(value values.Value, c colMeta) (string, error) {
 		return value.Str(), nil
 	case flux.TTime:
 		return encodeTime(value.Time(), c.fmt), nil
+	case flux.TBytes:
+		return base64.StdEncoding.EncodeToString(value.Bytes()), nil
+	case flux.TDuration:
+		return value.Duration().String(), nil
 	default:
 		return "", fmt.Errorf("unknown type %v", c.Type)
 	}
@@ -1260,6 +1416,14 @@ func encodeValueFrom(i, j int, c colMeta, cr flux.ColReader) (string, error) {
 		if cr.Times(j).IsValid(i) {
 			v = encodeTime(execute.Time(cr.Times(j).Value(i)), c.fmt)
 		}
+	case flux.TBytes:
+		if cr.Bytes(j).IsValid(i) {
+			v = base64.StdEncoding.EncodeToString(cr.Bytes(j).Value(i))
+		}
+	case flux.TDuration:
+		if cr.Durations(j).IsValid(i) {
+			v = values.ConvertDurationNsecs(time.Duration(cr.Durations(j).Value(i))).String()
+		}
 	default:
 		return "", fmt.Errorf("unknown type %v", c.Type)
 	}
@@ -1305,6 +1469,10 @@ func decodeType(datatype string) (t flux.ColType, desc string, err error) {
 		t = flux.TString
 	case timeDatatype:
 		t = flux.TTime
+	case bytesDatatype:
+		t = flux.TBytes
+	case durationDatatype:
+		t = flux.TDuration
 	default:
 		err = fmt.Errorf("unsupported data type %q", typ)
 	}
@@ -1336,23 +1504,36 @@ func schemaChanged(cols, lastCols []colMeta, groupCols, lastGroupCols []flux.Col
 }
 
 func NewMultiResultEncoder(c ResultEncoderConfig) flux.MultiResultEncoder {
+	delimiter := []byte("\r\n")
+	if c.NoCRLF {
+		delimiter = []byte("\n")
+	}
+	if c.Interleaved {
+		return &flux.InterleavedMultiResultEncoder{
+			Delimiter: delimiter,
+			Encoder:   NewResultEncoder(c),
+		}
+	}
 	return &flux.DelimitedMultiResultEncoder{
-		Delimiter: []byte("\r\n"),
+		Delimiter: delimiter,
 		Encoder:   NewResultEncoder(c),
 	}
 }
 
 // bufferedCSVReader allows for unreading a single line of the csv data
+// and for sampling the upcoming lines.
 type bufferedCSVReader struct {
-	r    *csv.Reader
-	line []string
+	r      *csv.Reader
+	lines  [][]string
+	unread bool
 }
 
 // Read returns the next line in the csv stream
 func (b *bufferedCSVReader) Read() ([]string, error) {
-	if len(b.line) > 0 {
-		line := b.line
-		b.line = nil
+	b.unread = false
+	if len(b.lines) > 0 {
+		line := b.lines[0]
+		b.lines = b.lines[1:]
 		return line, nil
 	}
 	return b.r.Read()
@@ -1361,9 +1542,36 @@ func (b *bufferedCSVReader) Read() ([]string, error) {
 // Unread places the provided line back on the buffer.
 // It is invalid to call unread multiple times without calling Read inbetween.
 func (b *bufferedCSVReader) Unread(line []string) error {
-	if len(b.line) > 0 {
+	if b.unread {
 		return errors.New(codes.Internal, "unread was called without reading first")
 	}
-	b.line = line
+	if len(line) > 0 {
+		b.lines = append([][]string{line}, b.lines...)
+		b.unread = true
+	}
 	return nil
 }
+
+// Sample returns up to n of the upcoming lines without consuming them.
+// Fewer lines are returned when the end of the stream is reached.
+func (b *bufferedCSVReader) Sample(n int) ([][]string, error) {
+	// The csv reader reuses the memory of the lines it returns,
+	// so the lines are copied before reading more.
+	for i := range b.lines {
+		b.lines[i] = copyLine(b.lines[i])
+	}
+	for len(b.lines) < n {
+		line, err := b.r.Read()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		b.lines = append(b.lines, copyLine(line))
+	}
+	if len(b.lines) > n {
+		return b.lines[:n], nil
+	}
+	return b.lines, nil
+}
@@ -316,6 +316,81 @@ func TestResultDecoder(t *testing.T) {
 				}},
 			},
 		},
+		{
+			name:          "single table with bytes",
+			encoderConfig: csv.DefaultEncoderConfig(),
+			encoded: toCRLF(`#datatype,string,long,dateTime:RFC3339,base64Binary,base64Binary
+#group,false,false,false,true,false
+#default,_result,,,,
+,result,table,_time,key,_value
+,,0,2018-04-17T00:00:00Z,aGVsbG8=,d29ybGQ=
+,,0,2018-04-17T00:00:01Z,aGVsbG8=,
+,,0,2018-04-17T00:00:02Z,aGVsbG8=,AAE=
+`),
+			result: &executetest.Result{
+				Nm: "_result",
+				Tbls: []*executetest.Table{{
+					KeyCols: []string{"key"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "key", Type: flux.TBytes},
+						{Label: "_value", Type: flux.TBytes},
+					},
+					Data: [][]interface{}{
+						{
+							values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 0, 0, time.UTC)),
+							[]byte("hello"),
+							[]byte("world"),
+						},
+						{
+							values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 1, 0, time.UTC)),
+							[]byte("hello"),
+							nil,
+						},
+						{
+							values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 2, 0, time.UTC)),
+							[]byte("hello"),
+							[]byte{0, 1},
+						},
+					},
+				}},
+			},
+		},
+		{
+			name:          "single table with durations",
+			encoderConfig: csv.DefaultEncoderConfig(),
+			encoded: toCRLF(`#datatype,string,long,dateTime:RFC3339,duration
+#group,false,false,false,false
+#default,_result,,,
+,result,table,_time,_value
+,,0,2018-04-17T00:00:00Z,1h30m
+,,0,2018-04-17T00:00:01Z,
+,,0,2018-04-17T00:00:02Z,-5s
+`),
+			result: &executetest.Result{
+				Nm: "_result",
+				Tbls: []*executetest.Table{{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TDuration},
+					},
+					Data: [][]interface{}{
+						{
+							values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 0, 0, time.UTC)),
+							values.ConvertDurationNsecs(90 * time.Minute),
+						},
+						{
+							values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 1, 0, time.UTC)),
+							nil,
+						},
+						{
+							values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 2, 0, time.UTC)),
+							values.ConvertDurationNsecs(-5 * time.Second),
+						},
+					},
+				}},
+			},
+		},
 		{
 			name:          "single table with null in group key column",
 			encoderConfig: csv.DefaultEncoderConfig(),
@@ -462,6 +537,109 @@ data1,data2,data3
 				Err: errors.New("wrong number of fields"),
 			},
 		},
+		{
+			name: "single table no annotations infer types",
+			decoderConfig: csv.ResultDecoderConfig{
+				NoAnnotations: true,
+				InferTypes:    true,
+			},
+			encoded: toCRLF(`_time,host,_value,count,healthy,empty
+2018-04-17T00:00:00Z,A,42,1,true,
+2018-04-17T00:00:01.5Z,1,43.5,,false,
+`),
+			result: &executetest.Result{
+				Nm: "_result",
+				Tbls: []*executetest.Table{{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "host", Type: flux.TString},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "count", Type: flux.TInt},
+						{Label: "healthy", Type: flux.TBool},
+						{Label: "empty", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{
+							values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 0, 0, time.UTC)),
+							"A",
+							42.0,
+							int64(1),
+							true,
+							nil,
+						},
+						{
+							values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 1, 5e8, time.UTC)),
+							"1",
+							43.5,
+							nil,
+							false,
+							nil,
+						},
+					},
+				}},
+			},
+		},
+		{
+			name: "single table no annotations infer types from sample",
+			decoderConfig: csv.ResultDecoderConfig{
+				NoAnnotations: true,
+				InferTypes:    true,
+				InferRowCount: 1,
+			},
+			encoded: toCRLF(`host,_value
+A,42
+B,43.5
+`),
+			result: &executetest.Result{
+				Nm:  "_result",
+				Err: errors.New(`strconv.ParseInt: parsing "43.5": invalid syntax`),
+			},
+		},
+		{
+			name: "single table no annotations no header schema",
+			decoderConfig: csv.ResultDecoderConfig{
+				NoAnnotations: true,
+				NoHeader:      true,
+				InferTypes:    true,
+				Schema: map[string]string{
+					"col0": "dateTime:2006-01-02",
+					"col2": "unsignedLong",
+				},
+			},
+			encoded: toCRLF(`2018-04-17,A,42
+2018-04-18,B,43
+`),
+			result: &executetest.Result{
+				Nm: "_result",
+				Tbls: []*executetest.Table{{
+					ColMeta: []flux.ColMeta{
+						{Label: "col0", Type: flux.TTime},
+						{Label: "col1", Type: flux.TString},
+						{Label: "col2", Type: flux.TUInt},
+					},
+					Data: [][]interface{}{
+						{values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 0, 0, time.UTC)), "A", uint64(42)},
+						{values.ConvertTime(time.Date(2018, 4, 18, 0, 0, 0, 0, time.UTC)), "B", uint64(43)},
+					},
+				}},
+			},
+		},
+		{
+			name: "single table no annotations schema missing column",
+			decoderConfig: csv.ResultDecoderConfig{
+				NoAnnotations: true,
+				Schema: map[string]string{
+					"count": "long",
+				},
+			},
+			encoded: toCRLF(`host,_value
+A,42
+`),
+			result: &executetest.Result{
+				Nm:  "_result",
+				Err: errors.New(`failed to read metadata: schema column "count" does not exist`),
+			},
+		},
 		{
 			name:          "multiple tables",
 			encoderConfig: csv.DefaultEncoderConfig(),
@@ -1685,6 +1863,144 @@ func TestResultEncoder(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:          "single table with bytes",
+			encoderConfig: csv.DefaultEncoderConfig(),
+			encoded: toCRLF(`#datatype,string,long,dateTime:RFC3339,base64Binary,base64Binary
+#group,false,false,false,true,false
+#default,_result,,,,
+,result,table,_time,key,_value
+,,0,2018-04-17T00:00:00Z,aGVsbG8=,d29ybGQ=
+,,0,2018-04-17T00:00:01Z,aGVsbG8=,
+,,0,2018-04-17T00:00:02Z,aGVsbG8=,AAE=
+`),
+			result: &executetest.Result{
+				Nm: "_result",
+				Tbls: []*executetest.Table{{
+					KeyCols: []string{"key"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "key", Type: flux.TBytes},
+						{Label: "_value", Type: flux.TBytes},
+					},
+					Data: [][]interface{}{
+						{
+							values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 0, 0, time.UTC)),
+							[]byte("hello"),
+							[]byte("world"),
+						},
+						{
+							values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 1, 0, time.UTC)),
+							[]byte("hello"),
+							nil,
+						},
+						{
+							values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 2, 0, time.UTC)),
+							[]byte("hello"),
+							[]byte{0, 1},
+						},
+					},
+				}},
+			},
+		},
+		{
+			name:          "single table with durations",
+			encoderConfig: csv.DefaultEncoderConfig(),
+			encoded: toCRLF(`#datatype,string,long,dateTime:RFC3339,duration
+#group,false,false,false,false
+#default,_result,,,
+,result,table,_time,_value
+,,0,2018-04-17T00:00:00Z,1h30m
+,,0,2018-04-17T00:00:01Z,
+,,0,2018-04-17T00:00:02Z,-5s
+`),
+			result: &executetest.Result{
+				Nm: "_result",
+				Tbls: []*executetest.Table{{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TDuration},
+					},
+					Data: [][]interface{}{
+						{
+							values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 0, 0, time.UTC)),
+							values.ConvertDurationNsecs(90 * time.Minute),
+						},
+						{
+							values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 1, 0, time.UTC)),
+							nil,
+						},
+						{
+							values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 2, 0, time.UTC)),
+							values.ConvertDurationNsecs(-5 * time.Second),
+						},
+					},
+				}},
+			},
+		},
+		{
+			name: "lf line endings and delimiter",
+			encoderConfig: csv.ResultEncoderConfig{
+				Annotations: []string{"datatype"},
+				Delimiter:   ';',
+				NoCRLF:      true,
+			},
+			encoded: []byte(`#datatype;string;long;string;double
+;result;table;host;_value
+;_result;0;"a;b";1.5
+;_result;0;"c
+d";2
+`),
+			result: &executetest.Result{
+				Nm: "_result",
+				Tbls: []*executetest.Table{{
+					KeyCols: []string{"host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "host", Type: flux.TString},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{"a;b", 1.5},
+						{"c\nd", 2.0},
+					},
+				}},
+			},
+		},
+		{
+			name: "strict quoting",
+			encoderConfig: csv.ResultEncoderConfig{
+				Annotations:   []string{"datatype"},
+				StrictQuoting: true,
+			},
+			encoded: []byte("\"#datatype\",\"string\",\"long\",\"string\",\"double\"\r\n" +
+				"\"\",\"result\",\"table\",\"host\",\"_value\"\r\n" +
+				"\"\",\"_result\",\"0\",\"say \"\"hi\"\"\",\"1.5\"\r\n" +
+				"\"\",\"_result\",\"0\",\"c\nd\",\"\"\r\n"),
+			result: &executetest.Result{
+				Nm: "_result",
+				Tbls: []*executetest.Table{{
+					KeyCols: []string{"host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "host", Type: flux.TString},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{`say "hi"`, 1.5},
+						{"c\nd", nil},
+					},
+				}},
+			},
+		},
+		{
+			name: "invalid delimiter",
+			encoderConfig: csv.ResultEncoderConfig{
+				Delimiter: '"',
+			},
+			result: &executetest.Result{
+				Nm: "_result",
+			},
+			err: errors.New(`csv encoder error: invalid csv delimiter '"'`),
+		},
 		{
 			name: "table error",
 			result: &executetest.Result{
@@ -1720,6 +2036,56 @@ func TestResultEncoder(t *testing.T) {
 	}
 }
 
+func TestResultEncoder_Stats(t *testing.T) {
+	result := &executetest.Result{
+		Nm: "_result",
+		Tbls: []*executetest.Table{
+			{
+				KeyCols: []string{"host"},
+				ColMeta: []flux.ColMeta{
+					{Label: "host", Type: flux.TString},
+					{Label: "_value", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{"a", int64(1)},
+					{"a", int64(2)},
+				},
+			},
+			{
+				KeyCols: []string{"host"},
+				ColMeta: []flux.ColMeta{
+					{Label: "host", Type: flux.TString},
+					{Label: "_value", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{"b", int64(3)},
+				},
+			},
+		},
+	}
+
+	encoder := csv.NewResultEncoder(csv.DefaultEncoderConfig())
+	var buf bytes.Buffer
+	n, err := encoder.Encode(&buf, result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := encoder.EncodeError(&buf, errors.New("test error")); err != nil {
+		t.Fatal(err)
+	}
+
+	want := csv.EncoderStats{
+		Rows:  3,
+		Bytes: int64(buf.Len()),
+	}
+	if got := encoder.Stats(); !cmp.Equal(want, got) {
+		t.Errorf("unexpected stats -want/+got:\n%s", cmp.Diff(want, got))
+	}
+	if n >= want.Bytes {
+		t.Errorf("expected the encoded result to be smaller than the total output, got %d >= %d", n, want.Bytes)
+	}
+}
+
 func TestMultiResultEncoder(t *testing.T) {
 	testCases := []struct {
 		name    string
@@ -2218,6 +2584,53 @@ func TestMultiResultEncoder(t *testing.T) {
 ,result,table,_start,_stop,_time,_measurement,result,table,_value
 ,,0,2018-04-17T00:00:00Z,2018-04-17T00:05:00Z,2018-04-17T00:00:00Z,cpu,A,B,42
 
+`),
+		},
+		{
+			name: "interleaved tables",
+			config: csv.ResultEncoderConfig{
+				Annotations: []string{"datatype", "group", "default"},
+				Interleaved: true,
+			},
+			results: flux.NewSliceResultIterator([]flux.Result{&executetest.Result{
+				Nm: "_result",
+				Tbls: []*executetest.Table{
+					{
+						KeyCols: []string{"host"},
+						ColMeta: []flux.ColMeta{
+							{Label: "_time", Type: flux.TTime},
+							{Label: "host", Type: flux.TString},
+							{Label: "_value", Type: flux.TFloat},
+						},
+						Data: [][]interface{}{
+							{values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 0, 0, time.UTC)), "A", 42.0},
+						},
+					},
+					{
+						KeyCols: []string{"host"},
+						ColMeta: []flux.ColMeta{
+							{Label: "_time", Type: flux.TTime},
+							{Label: "host", Type: flux.TString},
+							{Label: "_value", Type: flux.TFloat},
+						},
+						Data: [][]interface{}{
+							{values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 0, 0, time.UTC)), "B", 43.0},
+						},
+					},
+				},
+			}}),
+			encoded: toCRLF(`#datatype,string,long,dateTime:RFC3339,string,double
+#group,false,false,false,true,false
+#default,_result,,,,
+,result,table,_time,host,_value
+,,0,2018-04-17T00:00:00Z,A,42
+
+#datatype,string,long,dateTime:RFC3339,string,double
+#group,false,false,false,true,false
+#default,_result,,,,
+,result,table,_time,host,_value
+,,1,2018-04-17T00:00:00Z,B,43
+
 `),
 		},
 	}
@@ -0,0 +1,180 @@
+package csv
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"sync/atomic"
+	"unicode"
+	"unicode/utf8"
+)
+
+// writer writes CSV records one field at a time so that a record
+// never has to be materialized before it is written.
+//
+// The output is identical to the output of encoding/csv.Writer unless
+// strict quoting is enabled. In strict mode every field is enclosed in
+// double quotes and line breaks within a field are written unchanged.
+type writer struct {
+	w      *bufio.Writer
+	comma  rune
+	crlf   bool
+	strict bool
+
+	// fields is the number of fields written to the current record.
+	fields int
+	err    error
+}
+
+func newWriter(w io.Writer, c *ResultEncoderConfig) *writer {
+	comma := c.Delimiter
+	if comma == 0 {
+		comma = ','
+	}
+	return &writer{
+		w:      bufio.NewWriter(w),
+		comma:  comma,
+		crlf:   !c.NoCRLF,
+		strict: c.StrictQuoting,
+	}
+}
+
+// validDelimiter reports whether r can be used to delimit columns.
+func validDelimiter(r rune) bool {
+	return r != 0 && r != '"' && r != '\r' && r != '\n' && utf8.ValidRune(r) && r != utf8.RuneError
+}
+
+// WriteField writes a single field of the current record.
+func (w *writer) WriteField(field string) {
+	if w.err != nil {
+		return
+	}
+	if w.fields > 0 {
+		w.writeRune(w.comma)
+	}
+	w.fields++
+
+	if !w.strict && !w.fieldNeedsQuotes(field) {
+		w.writeString(field)
+		return
+	}
+
+	special := "\"\r\n"
+	if w.strict {
+		special = `"`
+	}
+	w.writeByte('"')
+	for len(field) > 0 {
+		// Copy verbatim everything before the next special character.
+		i := strings.IndexAny(field, special)
+		if i < 0 {
+			i = len(field)
+		}
+		w.writeString(field[:i])
+		field = field[i:]
+
+		if len(field) > 0 {
+			switch field[0] {
+			case '"':
+				w.writeString(`""`)
+			case '\r':
+				if !w.crlf {
+					w.writeByte('\r')
+				}
+			case '\n':
+				if w.crlf {
+					w.writeString("\r\n")
+				} else {
+					w.writeByte('\n')
+				}
+			}
+			field = field[1:]
+		}
+	}
+	w.writeByte('"')
+}
+
+// EndRecord terminates the current record.
+func (w *writer) EndRecord() {
+	if w.crlf {
+		w.writeString("\r\n")
+	} else {
+		w.writeByte('\n')
+	}
+	w.fields = 0
+}
+
+// Write writes a complete record.
+// A nil record writes an empty line.
+func (w *writer) Write(record []string) error {
+	for _, field := range record {
+		w.WriteField(field)
+	}
+	w.EndRecord()
+	return w.err
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (w *writer) Flush() {
+	if w.err == nil {
+		w.err = w.w.Flush()
+	}
+}
+
+// Error reports the first error that occurred while writing.
+func (w *writer) Error() error {
+	return w.err
+}
+
+// fieldNeedsQuotes reports whether the field must be enclosed in quotes.
+// It mirrors the rules used by encoding/csv.Writer.
+func (w *writer) fieldNeedsQuotes(field string) bool {
+	if field == "" {
+		return false
+	}
+	if field == `\.` {
+		return true
+	}
+	if w.comma < utf8.RuneSelf {
+		for i := 0; i < len(field); i++ {
+			c := field[i]
+			if c == '\n' || c == '\r' || c == '"' || c == byte(w.comma) {
+				return true
+			}
+		}
+	} else if strings.ContainsRune(field, w.comma) || strings.ContainsAny(field, "\"\r\n") {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(field)
+	return unicode.IsSpace(r)
+}
+
+func (w *writer) writeString(s string) {
+	if w.err == nil {
+		_, w.err = w.w.WriteString(s)
+	}
+}
+
+func (w *writer) writeByte(b byte) {
+	if w.err == nil {
+		w.err = w.w.WriteByte(b)
+	}
+}
+
+func (w *writer) writeRune(r rune) {
+	if w.err == nil {
+		_, w.err = w.w.WriteRune(r)
+	}
+}
+
+// statsWriter counts the bytes written to the underlying writer.
+type statsWriter struct {
+	w io.Writer
+	n *int64
+}
+
+func (s *statsWriter) Write(p []byte) (int, error) {
+	n, err := s.w.Write(p)
+	atomic.AddInt64(s.n, int64(n))
+	return n, err
+}
@@ -0,0 +1,59 @@
+package csv
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/andreyvit/diff"
+)
+
+func TestWriter_MatchesEncodingCSV(t *testing.T) {
+	records := [][]string{
+		{"", "plain", "with,comma", `with "quote"`},
+		{" leading space", "line\nbreak", "carriage\r\nreturn", `\.`},
+		nil,
+		{"tab\tseparated", "unicode ✓", ""},
+	}
+	for _, tc := range []struct {
+		name      string
+		delimiter rune
+		noCRLF    bool
+	}{
+		{name: "default"},
+		{name: "lf", noCRLF: true},
+		{name: "tab", delimiter: '\t'},
+		{name: "multibyte", delimiter: '✓'},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var want bytes.Buffer
+			cw := csv.NewWriter(&want)
+			if tc.delimiter != 0 {
+				cw.Comma = tc.delimiter
+			}
+			cw.UseCRLF = !tc.noCRLF
+			if err := cw.WriteAll(records); err != nil {
+				t.Fatal(err)
+			}
+
+			var got bytes.Buffer
+			w := newWriter(&got, &ResultEncoderConfig{
+				Delimiter: tc.delimiter,
+				NoCRLF:    tc.noCRLF,
+			})
+			for _, record := range records {
+				if err := w.Write(record); err != nil {
+					t.Fatal(err)
+				}
+			}
+			w.Flush()
+			if err := w.Error(); err != nil {
+				t.Fatal(err)
+			}
+
+			if g, w := got.String(), want.String(); g != w {
+				t.Errorf("unexpected output -want/+got:\n%s", diff.LineDiff(w, g))
+			}
+		})
+	}
+}
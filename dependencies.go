@@ -3,7 +3,6 @@ package flux
 import (
 	"context"
 	"net"
-	"syscall"
 	"time"
 
 	"github.com/influxdata/flux/codes"
@@ -80,6 +79,16 @@ func (d Deps) Inject(ctx context.Context) context.Context {
 	return ctx
 }
 
+// PackageURLValidator returns the URL validator of the dependencies
+// to use for the URLs of the Flux package with the import path.
+func PackageURLValidator(deps Dependencies, pkg string) (url.Validator, error) {
+	validator, err := deps.URLValidator()
+	if err != nil {
+		return nil, err
+	}
+	return url.ForPackage(validator, pkg), nil
+}
+
 func GetDependencies(ctx context.Context) Dependencies {
 	deps := ctx.Value(dependenciesKey)
 	if deps == nil {
@@ -113,26 +122,16 @@ func NewEmptyDependencies() Deps {
 // within the context.Context.
 func GetDialer(ctx context.Context) (*net.Dialer, error) {
 	deps := GetDependencies(ctx)
-	url, err := deps.URLValidator()
+	validator, err := deps.URLValidator()
 	if err != nil {
 		return nil, err
 	}
 
-	// Control is called after DNS lookup, but before the
+	// The IP is validated after DNS lookup, but before the
 	// network connection is initiated.
-	control := func(network, address string, c syscall.RawConn) error {
-		host, _, err := net.SplitHostPort(address)
-		if err != nil {
-			return err
-		}
-
-		ip := net.ParseIP(host)
-		return url.ValidateIP(ip)
-	}
-
 	return &net.Dialer{
 		Timeout:   30 * time.Second,
 		KeepAlive: 30 * time.Second,
-		Control:   control,
+		Control:   url.DialControl(validator),
 	}, nil
 }
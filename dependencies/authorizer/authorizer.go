@@ -0,0 +1,58 @@
+// Package authorizer defines a hook that inspects the physical plan
+// of a query after planning and before it is executed.
+//
+// Embedders can use it to reject queries that read from certain
+// sources or that exceed a maximum time range. The plan spec may also
+// be modified, for example to annotate the plan nodes, but the plan
+// must remain valid.
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/flux/plan"
+)
+
+type key int
+
+const authorizerKey key = iota
+
+// Authorizer inspects the physical plan of a query before it is executed.
+type Authorizer interface {
+	// AuthorizePlan is called with the physical plan of the query.
+	// Returning an error rejects the query and the error is returned
+	// to the caller. Errors without a code are reported as
+	// permission denied.
+	AuthorizePlan(ctx context.Context, spec *plan.Spec) error
+}
+
+// Func is an adapter that allows a function to be used as an Authorizer.
+type Func func(ctx context.Context, spec *plan.Spec) error
+
+// AuthorizePlan calls f(ctx, spec).
+func (f Func) AuthorizePlan(ctx context.Context, spec *plan.Spec) error {
+	return f(ctx, spec)
+}
+
+// Inject will inject this Authorizer into the dependency chain.
+func Inject(ctx context.Context, authorizer Authorizer) context.Context {
+	return context.WithValue(ctx, authorizerKey, authorizer)
+}
+
+// Dependency will inject the Authorizer into the dependency chain.
+type Dependency struct {
+	Authorizer Authorizer
+}
+
+// Inject will inject the Authorizer into the dependency chain.
+func (d Dependency) Inject(ctx context.Context) context.Context {
+	return Inject(ctx, d.Authorizer)
+}
+
+// GetAuthorizer will return the Authorizer for the current context.
+// If no Authorizer has been injected into the dependencies,
+// this will return nil and all plans are allowed.
+func GetAuthorizer(ctx context.Context) Authorizer {
+	a, _ := ctx.Value(authorizerKey).(Authorizer)
+	return a
+}
@@ -0,0 +1,32 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/flux/dependencies/authorizer"
+	"github.com/influxdata/flux/plan"
+)
+
+func TestGetAuthorizer(t *testing.T) {
+	if got := authorizer.GetAuthorizer(context.Background()); got != nil {
+		t.Fatalf("expected no authorizer, got %T", got)
+	}
+
+	called := false
+	a := authorizer.Func(func(ctx context.Context, spec *plan.Spec) error {
+		called = true
+		return nil
+	})
+	ctx := authorizer.Dependency{Authorizer: a}.Inject(context.Background())
+	got := authorizer.GetAuthorizer(ctx)
+	if got == nil {
+		t.Fatal("expected an authorizer, got none")
+	}
+	if err := got.AuthorizePlan(ctx, &plan.Spec{}); err != nil {
+		t.Fatal(err)
+	}
+	if !called {
+		t.Error("expected the authorizer to be called")
+	}
+}
@@ -0,0 +1,140 @@
+package feature
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/internal/feature"
+	featurepkg "github.com/influxdata/flux/internal/pkg/feature"
+)
+
+// Values is a Flagger that returns the value of each flag in the map
+// and the default value for all other flags.
+type Values map[string]interface{}
+
+func (v Values) FlagValue(ctx context.Context, flag Flag) interface{} {
+	if value, ok := v[flag.Key()]; ok {
+		return value
+	}
+	return flag.Default()
+}
+
+// overrides is a Flagger that returns the overridden value of a flag
+// and the value of the parent Flagger for all other flags.
+type overrides struct {
+	values Values
+	parent Flagger
+}
+
+func (o overrides) FlagValue(ctx context.Context, flag Flag) interface{} {
+	if value, ok := o.values[flag.Key()]; ok {
+		return value
+	}
+	return o.parent.FlagValue(ctx, flag)
+}
+
+// WithOverrides returns a context that overrides the values of the feature flags
+// for executions using that context. Flags that are not overridden keep the value
+// of the Flagger already present in the context.
+//
+// The values are validated with Validate.
+func WithOverrides(ctx context.Context, values map[string]interface{}) (context.Context, error) {
+	validated, err := Validate(values)
+	if err != nil {
+		return nil, err
+	}
+	return Inject(ctx, overrides{
+		values: validated,
+		parent: featurepkg.GetFlagger(ctx),
+	}), nil
+}
+
+// Validate checks that each key is the key of a registered flag and
+// that each value can be used as the value of that flag.
+// It returns the values converted to the type of their flag.
+//
+// Strings are parsed as the type of the flag so values can be
+// read from the command line, and integral floats are accepted
+// for integer flags so values can be read from JSON.
+func Validate(values map[string]interface{}) (Values, error) {
+	validated := make(Values, len(values))
+	for key, value := range values {
+		flag, ok := feature.ByKey(key)
+		if !ok {
+			return nil, errors.Newf(codes.Invalid, "unknown feature flag %q", key)
+		}
+		v, err := convert(flag, value)
+		if err != nil {
+			return nil, err
+		}
+		validated[key] = v
+	}
+	return validated, nil
+}
+
+// ParseOverrides parses feature flag overrides of the form key=value.
+// The values are validated with Validate.
+func ParseOverrides(pairs []string) (Values, error) {
+	values := make(map[string]interface{}, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, errors.Newf(codes.Invalid, "feature flag override %q must have the form key=value", pair)
+		}
+		values[key] = value
+	}
+	return Validate(values)
+}
+
+func convert(flag Flag, value interface{}) (interface{}, error) {
+	switch flag.(type) {
+	case feature.BoolFlag:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			if b, err := strconv.ParseBool(v); err == nil {
+				return b, nil
+			}
+		}
+	case feature.IntFlag:
+		switch v := value.(type) {
+		case int:
+			return v, nil
+		case int64:
+			return int(v), nil
+		case float64:
+			if v == math.Trunc(v) {
+				return int(v), nil
+			}
+		case string:
+			if i, err := strconv.Atoi(v); err == nil {
+				return i, nil
+			}
+		}
+	case feature.FloatFlag:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case int:
+			return float64(v), nil
+		case int64:
+			return float64(v), nil
+		case string:
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				return f, nil
+			}
+		}
+	case feature.StringFlag:
+		if v, ok := value.(string); ok {
+			return v, nil
+		}
+	default:
+		return value, nil
+	}
+	return nil, errors.Newf(codes.Invalid, "invalid value %v for feature flag %q of type %T", value, flag.Key(), flag.Default())
+}
@@ -0,0 +1,103 @@
+package feature_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux/dependencies/feature"
+	ifeature "github.com/influxdata/flux/internal/feature"
+)
+
+func TestParseOverrides(t *testing.T) {
+	testCases := []struct {
+		name    string
+		pairs   []string
+		want    feature.Values
+		wantErr string
+	}{
+		{
+			name:  "typed values",
+			pairs: []string{"vectorizedConst=true", "queryConcurrencyIncrease=4"},
+			want: feature.Values{
+				"vectorizedConst":          true,
+				"queryConcurrencyIncrease": 4,
+			},
+		},
+		{
+			name:    "unknown flag",
+			pairs:   []string{"noSuchFlag=true"},
+			wantErr: `unknown feature flag "noSuchFlag"`,
+		},
+		{
+			name:    "invalid value",
+			pairs:   []string{"queryConcurrencyIncrease=many"},
+			wantErr: `invalid value many for feature flag "queryConcurrencyIncrease" of type int`,
+		},
+		{
+			name:    "missing value",
+			pairs:   []string{"vectorizedConst"},
+			wantErr: `feature flag override "vectorizedConst" must have the form key=value`,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := feature.ParseOverrides(tc.pairs)
+			if tc.wantErr != "" {
+				if err == nil {
+					t.Fatalf("expected error %q", tc.wantErr)
+				} else if err.Error() != tc.wantErr {
+					t.Fatalf("unexpected error -want/+got:\n\t- %s\n\t+ %s", tc.wantErr, err)
+				}
+				return
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected values -want/+got:\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestValidate_JSONNumbers(t *testing.T) {
+	got, err := feature.Validate(map[string]interface{}{
+		"queryConcurrencyIncrease": float64(2),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (feature.Values{"queryConcurrencyIncrease": 2}); !cmp.Equal(want, got) {
+		t.Errorf("unexpected values -want/+got:\n%s", cmp.Diff(want, got))
+	}
+
+	if _, err := feature.Validate(map[string]interface{}{
+		"queryConcurrencyIncrease": 2.5,
+	}); err == nil {
+		t.Error("expected an error for a fractional integer flag value")
+	}
+}
+
+func TestWithOverrides(t *testing.T) {
+	ctx := feature.Inject(context.Background(), feature.Values{
+		"vectorizedConst":          true,
+		"queryConcurrencyIncrease": 2,
+	})
+	ctx, err := feature.WithOverrides(ctx, map[string]interface{}{
+		"queryConcurrencyIncrease": 8,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !ifeature.VectorizedConst().Enabled(ctx) {
+		t.Error("expected the value of the parent flagger for a flag that is not overridden")
+	}
+	if got, want := ifeature.QueryConcurrencyIncrease().Int(ctx), 8; got != want {
+		t.Errorf("unexpected overridden value -want/+got:\n\t- %d\n\t+ %d", want, got)
+	}
+	if got, want := ifeature.ColumnarPivot().Enabled(ctx), ifeature.ColumnarPivot().Default(); got != want {
+		t.Errorf("unexpected default value -want/+got:\n\t- %v\n\t+ %v", want, got)
+	}
+}
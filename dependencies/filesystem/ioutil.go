@@ -2,8 +2,12 @@ package filesystem
 
 import (
 	"context"
+	"io"
 	"io/ioutil"
 	"os"
+
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
 )
 
 // ReadFile will open the file from the service and read
@@ -13,7 +17,7 @@ func ReadFile(ctx context.Context, filename string) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	f, err := fs.Open(filename)
+	f, err := open(ctx, fs, filename)
 	if err != nil {
 		return nil, err
 	}
@@ -27,7 +31,7 @@ func OpenFile(ctx context.Context, filename string) (File, error) {
 	if err != nil {
 		return nil, err
 	}
-	return fs.Open(filename)
+	return open(ctx, fs, filename)
 }
 
 // Stat will retrieve the os.FileInfo for a file.
@@ -36,10 +40,24 @@ func Stat(ctx context.Context, filename string) (os.FileInfo, error) {
 	if err != nil {
 		return nil, err
 	}
-	f, err := fs.Open(filename)
+	f, err := open(ctx, fs, filename)
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = f.Close() }()
 	return f.Stat()
 }
+
+// CreateFile will create or truncate the file using the service.
+// It returns an error if the service does not support writing files.
+func CreateFile(ctx context.Context, filename string) (io.WriteCloser, error) {
+	fs, err := Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	wfs, ok := fs.(WritableService)
+	if !ok {
+		return nil, errors.New(codes.Unimplemented, "filesystem service does not support writing files")
+	}
+	return wfs.Create(filename)
+}
@@ -0,0 +1,141 @@
+package filesystem
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+)
+
+// ContextService is a Service that can open files
+// with the context of the caller.
+// The context gives access to the dependencies of the query,
+// such as the secret service used to load credentials.
+type ContextService interface {
+	Service
+	OpenContext(ctx context.Context, fpath string) (File, error)
+}
+
+// ObjectStore reads objects from the buckets of an object storage service.
+type ObjectStore interface {
+	// Open opens the object with the key in the bucket.
+	Open(ctx context.Context, bucket, key string) (File, error)
+}
+
+// ObjectStoreFS is a Service that opens URLs whose scheme
+// has a registered ObjectStore, such as s3://bucket/key,
+// from the ObjectStore. Every other path is opened by FS.
+type ObjectStoreFS struct {
+	// FS opens the paths that are not object store URLs.
+	// If it is nil, only object store URLs can be opened.
+	FS Service
+
+	// Stores maps URL schemes to the ObjectStore for the scheme.
+	Stores map[string]ObjectStore
+}
+
+// Open opens the file without a caller context.
+// Object stores that load credentials from the secret
+// service require OpenContext.
+func (fs *ObjectStoreFS) Open(fpath string) (File, error) {
+	return fs.OpenContext(context.Background(), fpath)
+}
+
+// OpenContext opens the file with the context of the caller.
+func (fs *ObjectStoreFS) OpenContext(ctx context.Context, fpath string) (File, error) {
+	if store, bucket, key, ok := fs.lookup(fpath); ok {
+		if bucket == "" || key == "" {
+			return nil, errors.Newf(codes.Invalid, "object store url %q must have a bucket and an object key", fpath)
+		}
+		return store.Open(ctx, bucket, key)
+	}
+	if fs.FS == nil {
+		return nil, errors.Newf(codes.Invalid, "path %q is not an object store url", fpath)
+	}
+	return open(ctx, fs.FS, fpath)
+}
+
+// Create creates the file with FS.
+// Writing to object stores is not supported.
+func (fs *ObjectStoreFS) Create(fpath string) (io.WriteCloser, error) {
+	if _, _, _, ok := fs.lookup(fpath); ok {
+		return nil, errors.Newf(codes.Unimplemented, "cannot create %q: writing to object stores is not supported", fpath)
+	}
+	wfs, ok := fs.FS.(WritableService)
+	if !ok {
+		return nil, errors.New(codes.Unimplemented, "filesystem service does not support writing files")
+	}
+	return wfs.Create(fpath)
+}
+
+// lookup finds the ObjectStore for the path and splits the URL into the bucket and key.
+func (fs *ObjectStoreFS) lookup(fpath string) (store ObjectStore, bucket, key string, ok bool) {
+	i := strings.Index(fpath, "://")
+	if i <= 0 {
+		return nil, "", "", false
+	}
+	store, ok = fs.Stores[strings.ToLower(fpath[:i])]
+	if !ok {
+		return nil, "", "", false
+	}
+	u, err := url.Parse(fpath)
+	if err != nil {
+		// Keep the path as written when it is not a valid URL.
+		rest := fpath[i+len("://"):]
+		if j := strings.IndexByte(rest, '/'); j >= 0 {
+			return store, rest[:j], rest[j+1:], true
+		}
+		return store, rest, "", true
+	}
+	return store, u.Host, strings.TrimPrefix(u.Path, "/"), true
+}
+
+// open opens the file with OpenContext if the service supports it.
+func open(ctx context.Context, fs Service, fpath string) (File, error) {
+	if cfs, ok := fs.(ContextService); ok {
+		return cfs.OpenContext(ctx, fpath)
+	}
+	return fs.Open(fpath)
+}
+
+// NewObjectFile creates a File for an object read from an object store.
+// The name is the base name of the object key. A negative size
+// reports the size as unknown.
+func NewObjectFile(rc io.ReadCloser, key string, size int64, modTime time.Time) File {
+	return &objectFile{
+		ReadCloser: rc,
+		info: objectInfo{
+			name:    path.Base(key),
+			size:    size,
+			modTime: modTime,
+		},
+	}
+}
+
+type objectFile struct {
+	io.ReadCloser
+	info objectInfo
+}
+
+func (f *objectFile) Stat() (os.FileInfo, error) {
+	return f.info, nil
+}
+
+type objectInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+}
+
+func (i objectInfo) Name() string       { return i.name }
+func (i objectInfo) Size() int64        { return i.size }
+func (i objectInfo) Mode() os.FileMode  { return 0444 }
+func (i objectInfo) ModTime() time.Time { return i.modTime }
+func (i objectInfo) IsDir() bool        { return false }
+func (i objectInfo) Sys() interface{}   { return nil }
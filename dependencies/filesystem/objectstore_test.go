@@ -0,0 +1,79 @@
+package filesystem_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/dependencies/filesystem"
+	"github.com/influxdata/flux/internal/errors"
+)
+
+type ctxKey struct{}
+
+// testStore returns the bucket, key and a value of the context as the object contents.
+type testStore struct{}
+
+func (testStore) Open(ctx context.Context, bucket, key string) (filesystem.File, error) {
+	v, _ := ctx.Value(ctxKey{}).(string)
+	rc := ioutil.NopCloser(strings.NewReader(bucket + ":" + key + ":" + v))
+	return filesystem.NewObjectFile(rc, key, 42, time.Unix(0, 0)), nil
+}
+
+func TestObjectStoreFS(t *testing.T) {
+	tmpfile, err := ioutil.TempFile("", "flux-objectstorefs-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Remove(tmpfile.Name()) }()
+	if _, err := tmpfile.WriteString("local"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tmpfile.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	fs := &filesystem.ObjectStoreFS{
+		FS:     filesystem.SystemFS,
+		Stores: map[string]filesystem.ObjectStore{"s3": testStore{}},
+	}
+	ctx := filesystem.Inject(context.WithValue(context.Background(), ctxKey{}, "ctx"), fs)
+
+	for fpath, want := range map[string]string{
+		"s3://bucket/dir/data.csv": "bucket:dir/data.csv:ctx",
+		"S3://bucket/data.csv":     "bucket:data.csv:ctx",
+		tmpfile.Name():             "local",
+	} {
+		data, err := filesystem.ReadFile(ctx, fpath)
+		if err != nil {
+			t.Fatalf("%s: %s", fpath, err)
+		}
+		if got := string(data); got != want {
+			t.Errorf("%s: unexpected file contents -want/+got:\n\t- %q\n\t+ %q", fpath, want, got)
+		}
+	}
+
+	info, err := filesystem.Stat(ctx, "s3://bucket/dir/data.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Name() != "data.csv" || info.Size() != 42 || info.IsDir() {
+		t.Errorf("unexpected file info: %s %d %v", info.Name(), info.Size(), info.IsDir())
+	}
+
+	if _, err := filesystem.ReadFile(ctx, "s3://bucket"); errors.Code(err) != codes.Invalid {
+		t.Errorf("expected invalid error for url without key, got %v", err)
+	}
+	if _, err := filesystem.CreateFile(ctx, "s3://bucket/out.csv"); errors.Code(err) != codes.Unimplemented {
+		t.Errorf("expected unimplemented error for create, got %v", err)
+	}
+
+	fs.FS = nil
+	if _, err := filesystem.ReadFile(ctx, tmpfile.Name()); errors.Code(err) != codes.Invalid {
+		t.Errorf("expected invalid error for local path, got %v", err)
+	}
+}
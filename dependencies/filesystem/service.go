@@ -20,6 +20,13 @@ type Service interface {
 	Open(fpath string) (File, error)
 }
 
+// WritableService is a Service that can also create files.
+// Writing to the filesystem is only available when the Service implements it.
+type WritableService interface {
+	Service
+	Create(fpath string) (io.WriteCloser, error)
+}
+
 type key int
 
 const serviceKey key = iota
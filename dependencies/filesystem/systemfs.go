@@ -1,6 +1,7 @@
 package filesystem
 
 import (
+	"io"
 	"os"
 )
 
@@ -17,3 +18,11 @@ func (systemFS) Open(fpath string) (File, error) {
 	}
 	return f, nil
 }
+
+func (systemFS) Create(fpath string) (io.WriteCloser, error) {
+	f, err := os.Create(fpath)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
@@ -2,15 +2,18 @@ package http
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"io"
+	"io/ioutil"
 	"net"
 	"net/http"
-	"syscall"
+	neturl "net/url"
 	"time"
 
 	"github.com/influxdata/flux/codes"
 	"github.com/influxdata/flux/dependencies/url"
 	"github.com/influxdata/flux/internal/errors"
+	"github.com/opentracing/opentracing-go"
 )
 
 // maxResponseBody is the maximum response body we will read before just discarding
@@ -61,40 +64,128 @@ func (l roundTripLimiter) RoundTrip(r *http.Request) (*http.Response, error) {
 	return response, nil
 }
 
+// ClientConfig configures the HTTP client created by NewClient.
+// Zero values use the defaults of NewDefaultClient.
+type ClientConfig struct {
+	// MaxIdleConns is the maximum number of idle connections across all hosts.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost is the maximum number of idle connections to keep per host.
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost limits the number of connections per host.
+	// Zero means no limit.
+	MaxConnsPerHost int
+	// IdleConnTimeout is how long an idle connection is kept in the pool.
+	IdleConnTimeout time.Duration
+	// Timeout limits the time of each request, including reading the response body.
+	// Zero means no timeout.
+	Timeout time.Duration
+
+	// ProxyURL is the URL of the proxy used for all requests.
+	// If it is empty, the proxy is read from the HTTP_PROXY, HTTPS_PROXY
+	// and NO_PROXY environment variables.
+	// When a proxy is used, the URL validator checks the IP of the proxy
+	// when connecting, since the proxy connects to the target host.
+	ProxyURL string
+
+	// CAFile is the path of a PEM encoded bundle of certificate authorities
+	// used instead of the system pool to verify servers.
+	CAFile string
+	// CertFile and KeyFile are the paths of a PEM encoded client certificate
+	// and private key presented to servers that require mutual TLS.
+	CertFile string
+	KeyFile  string
+}
+
 // NewDefaultClient creates a client with sane defaults.
 func NewDefaultClient(urlValidator url.Validator) *http.Client {
-	// Control is called after DNS lookup, but before the network connection is
-	// initiated.
-	control := func(network, address string, c syscall.RawConn) error {
-		host, _, err := net.SplitHostPort(address)
+	return &http.Client{
+		Transport: newTransport(urlValidator, ClientConfig{}),
+	}
+}
+
+// NewClient creates a client with the configuration.
+func NewClient(urlValidator url.Validator, c ClientConfig) (*http.Client, error) {
+	transport := newTransport(urlValidator, c)
+	if c.ProxyURL != "" {
+		proxy, err := neturl.Parse(c.ProxyURL)
 		if err != nil {
-			return err
+			return nil, errors.Wrapf(err, codes.Invalid, "invalid proxy url %q", c.ProxyURL)
 		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
 
-		ip := net.ParseIP(host)
-		return urlValidator.ValidateIP(ip)
+	if c.CAFile != "" || c.CertFile != "" || c.KeyFile != "" {
+		tlsConfig, err := newTLSConfig(c)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsConfig
 	}
+	return &http.Client{
+		Transport: transport,
+		Timeout:   c.Timeout,
+	}, nil
+}
 
+// newTransport creates a transport with the connection pool settings of the configuration.
+func newTransport(urlValidator url.Validator, c ClientConfig) *http.Transport {
+	// The IP is validated after DNS lookup, but before the
+	// network connection is initiated.
 	dialer := &net.Dialer{
 		Timeout:   30 * time.Second,
 		KeepAlive: 30 * time.Second,
-		Control:   control,
+		Control:   url.DialControl(urlValidator),
 		// DualStack is deprecated
 	}
 
 	// These defaults are copied from http.DefaultTransport.
-	return &http.Client{
-		Transport: &http.Transport{
-			Proxy:                 http.ProxyFromEnvironment,
-			DialContext:           dialer.DialContext,
-			MaxIdleConns:          100,
-			IdleConnTimeout:       10 * time.Second,
-			TLSHandshakeTimeout:   10 * time.Second,
-			ExpectContinueTimeout: 1 * time.Second,
-			// Fields below are NOT part of Go's defaults
-			MaxIdleConnsPerHost: 100,
-		},
+	transport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       10 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		// Fields below are NOT part of Go's defaults
+		MaxIdleConnsPerHost: 100,
+		MaxConnsPerHost:     c.MaxConnsPerHost,
+	}
+	if c.MaxIdleConns > 0 {
+		transport.MaxIdleConns = c.MaxIdleConns
+	}
+	if c.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = c.MaxIdleConnsPerHost
+	}
+	if c.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = c.IdleConnTimeout
+	}
+	return transport
+}
+
+func newTLSConfig(c ClientConfig) (*tls.Config, error) {
+	config := &tls.Config{}
+	if c.CAFile != "" {
+		pem, err := ioutil.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, codes.Invalid, "cannot read certificate authorities from %q", c.CAFile)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.Newf(codes.Invalid, "no certificates found in %q", c.CAFile)
+		}
+		config.RootCAs = pool
+	}
+	if c.CertFile != "" || c.KeyFile != "" {
+		if c.CertFile == "" || c.KeyFile == "" {
+			return nil, errors.New(codes.Invalid, "client certificate requires both a certificate and a key file")
+		}
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, codes.Invalid, "cannot load client certificate")
+		}
+		config.Certificates = []tls.Certificate{cert}
 	}
+	return config, nil
 }
 
 // NewLimitedDefaultClient creates a client with a limit on the response body size.
@@ -103,6 +194,27 @@ func NewLimitedDefaultClient(urlValidator url.Validator) *http.Client {
 	return LimitHTTPBody(*cli, maxResponseBody)
 }
 
+// NewLimitedClient creates a client with the configuration
+// and a limit on the response body size.
+func NewLimitedClient(urlValidator url.Validator, c ClientConfig) (*http.Client, error) {
+	cli, err := NewClient(urlValidator, c)
+	if err != nil {
+		return nil, err
+	}
+	return LimitHTTPBody(*cli, maxResponseBody), nil
+}
+
+// InjectSpanContext adds the context of the span in the request context
+// to the request headers so the server can continue the trace.
+// The request is left unchanged when its context has no span.
+func InjectSpanContext(req *http.Request) {
+	span := opentracing.SpanFromContext(req.Context())
+	if span == nil {
+		return
+	}
+	_ = span.Tracer().Inject(span.Context(), opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(req.Header))
+}
+
 func WithTimeout(c Client, t time.Duration) (Client, error) {
 	cli, ok := c.(*http.Client)
 	if !ok {
@@ -114,6 +226,26 @@ func WithTimeout(c Client, t time.Duration) (Client, error) {
 	return &newClient, nil
 }
 func WithTLSConfig(c Client, config *tls.Config) (Client, error) {
+	return withTransport(c, func(t *http.Transport) {
+		t.TLSClientConfig = config
+	})
+}
+
+// WithInsecureSkipVerify returns a client that does not verify the certificates of servers.
+// Unlike WithTLSConfig, it keeps the client certificates configured for the client.
+func WithInsecureSkipVerify(c Client) (Client, error) {
+	return withTransport(c, func(t *http.Transport) {
+		config := &tls.Config{}
+		if t.TLSClientConfig != nil {
+			config = t.TLSClientConfig.Clone()
+		}
+		config.InsecureSkipVerify = true
+		t.TLSClientConfig = config
+	})
+}
+
+// withTransport returns a copy of the client with a copy of its transport changed by fn.
+func withTransport(c Client, fn func(t *http.Transport)) (Client, error) {
 	cli, ok := c.(*http.Client)
 	if !ok {
 		return nil, errors.New(codes.Internal, "cannot set timeout on client")
@@ -126,7 +258,7 @@ func WithTLSConfig(c Client, config *tls.Config) (Client, error) {
 	switch t := newClient.Transport.(type) {
 	case *http.Transport:
 		newTransport := t.Clone()
-		newTransport.TLSClientConfig = config
+		fn(newTransport)
 		newClient.Transport = newTransport
 	case roundTripLimiter:
 		transport, ok := t.RoundTripper.(*http.Transport)
@@ -134,7 +266,7 @@ func WithTLSConfig(c Client, config *tls.Config) (Client, error) {
 			return nil, errors.New(codes.Internal, "roundTripLimiter does not have http a known transport")
 		}
 		newTransport := transport.Clone()
-		newTransport.TLSClientConfig = config
+		fn(newTransport)
 		t.RoundTripper = newTransport
 		newClient.Transport = t
 	default:
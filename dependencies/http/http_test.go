@@ -2,6 +2,7 @@ package http
 
 import (
 	"bytes"
+	"context"
 	"io/ioutil"
 	"log"
 	"net"
@@ -16,6 +17,8 @@ import (
 	"github.com/influxdata/flux/codes"
 	depsUrl "github.com/influxdata/flux/dependencies/url"
 	"github.com/influxdata/flux/internal/errors"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
 )
 
 func TestNewDefaultClient(t *testing.T) {
@@ -155,3 +158,32 @@ func TestInvalidRedirects(t *testing.T) {
 
 	})
 }
+
+func TestInjectSpanContext(t *testing.T) {
+	tracer := mocktracer.New()
+	span := tracer.StartSpan("test")
+	ctx := opentracing.ContextWithSpan(context.Background(), span)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", "http://example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	InjectSpanContext(req)
+
+	got, err := tracer.Extract(opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(req.Header))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := span.Context().(mocktracer.MockSpanContext); got.(mocktracer.MockSpanContext).TraceID != want.TraceID {
+		t.Errorf("unexpected trace id -want/+got:\n\t- %d\n\t+ %d", want.TraceID, got.(mocktracer.MockSpanContext).TraceID)
+	}
+
+	req, err = http.NewRequest("GET", "http://example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	InjectSpanContext(req)
+	if len(req.Header) != 0 {
+		t.Errorf("expected no headers for a request without a span, got %v", req.Header)
+	}
+}
@@ -0,0 +1,188 @@
+package http
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+)
+
+// tokenExpiryDelta is how long before its expiry a token is refreshed,
+// so a cached token does not expire while a request is sent.
+const tokenExpiryDelta = 10 * time.Second
+
+// maxTokenResponse is the maximum size of a token response.
+const maxTokenResponse = 1024 * 1024
+
+// OAuth2Config identifies an OAuth2 client that requests tokens
+// with the client credentials grant.
+type OAuth2Config struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+}
+
+// key identifies the tokens of the client in a TokenCache
+// without keeping the client secret in memory.
+func (c OAuth2Config) key() string {
+	h := sha256.New()
+	for _, s := range append([]string{c.TokenURL, c.ClientID, c.ClientSecret}, c.Scopes...) {
+		_, _ = io.WriteString(h, s)
+		_, _ = h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Token is an OAuth2 access token.
+type Token struct {
+	AccessToken string
+	TokenType   string
+	// Expiry is when the token expires.
+	// It is zero if the token does not expire.
+	Expiry time.Time
+}
+
+// valid reports whether the token can still be used at the time.
+func (t Token) valid(now time.Time) bool {
+	return t.AccessToken != "" && (t.Expiry.IsZero() || now.Add(tokenExpiryDelta).Before(t.Expiry))
+}
+
+// TokenCache caches OAuth2 tokens so queries that authenticate
+// with the same client reuse a token until it expires.
+type TokenCache struct {
+	mu     sync.Mutex
+	tokens map[string]Token
+}
+
+// NewTokenCache creates an empty TokenCache.
+func NewTokenCache() *TokenCache {
+	return &TokenCache{tokens: make(map[string]Token)}
+}
+
+// DefaultTokenCache is the TokenCache used when none has been injected.
+var DefaultTokenCache = NewTokenCache()
+
+// Token returns a valid token for the client. A new token is requested
+// with the HTTP client when the cache does not have a valid token.
+func (c *TokenCache) Token(ctx context.Context, client Client, config OAuth2Config) (Token, error) {
+	k := config.key()
+	c.mu.Lock()
+	token, ok := c.tokens[k]
+	c.mu.Unlock()
+	if ok && token.valid(time.Now()) {
+		return token, nil
+	}
+
+	token, err := requestToken(ctx, client, config)
+	if err != nil {
+		return Token{}, err
+	}
+	c.mu.Lock()
+	c.tokens[k] = token
+	c.mu.Unlock()
+	return token, nil
+}
+
+// requestToken requests a token from the token endpoint with the client credentials grant.
+func requestToken(ctx context.Context, client Client, config OAuth2Config) (Token, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(config.Scopes) > 0 {
+		form.Set("scope", strings.Join(config.Scopes, " "))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Token{}, errors.Wrap(err, codes.Invalid, "invalid oauth2 token url")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(config.ClientID), url.QueryEscape(config.ClientSecret))
+
+	now := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return Token{}, errors.Wrap(err, codes.Unavailable, "oauth2 token request failed")
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxTokenResponse))
+	if err != nil {
+		return Token{}, errors.Wrap(err, codes.Unavailable, "cannot read oauth2 token response")
+	}
+
+	var tr struct {
+		AccessToken      string      `json:"access_token"`
+		TokenType        string      `json:"token_type"`
+		ExpiresIn        json.Number `json:"expires_in"`
+		Error            string      `json:"error"`
+		ErrorDescription string      `json:"error_description"`
+	}
+	if err := json.Unmarshal(body, &tr); err != nil && resp.StatusCode/100 == 2 {
+		return Token{}, errors.Wrap(err, codes.Internal, "invalid oauth2 token response")
+	}
+	if resp.StatusCode/100 != 2 || tr.Error != "" {
+		code := codes.Unavailable
+		switch resp.StatusCode {
+		case http.StatusBadRequest, http.StatusUnauthorized:
+			code = codes.Unauthenticated
+		case http.StatusForbidden:
+			code = codes.PermissionDenied
+		}
+		msg := tr.Error
+		if tr.ErrorDescription != "" {
+			msg += ": " + tr.ErrorDescription
+		}
+		if msg == "" {
+			msg = resp.Status
+		}
+		return Token{}, errors.Newf(code, "oauth2 token request failed: %s", msg)
+	}
+	if tr.AccessToken == "" {
+		return Token{}, errors.New(codes.Internal, "oauth2 token response is missing the access token")
+	}
+
+	token := Token{
+		AccessToken: tr.AccessToken,
+		TokenType:   tr.TokenType,
+	}
+	if token.TokenType == "" || strings.EqualFold(token.TokenType, "bearer") {
+		token.TokenType = "Bearer"
+	}
+	if tr.ExpiresIn != "" {
+		seconds, err := tr.ExpiresIn.Int64()
+		if err != nil {
+			return Token{}, errors.Wrap(err, codes.Internal, "invalid oauth2 token expiry")
+		}
+		if seconds > 0 {
+			token.Expiry = now.Add(time.Duration(seconds) * time.Second)
+		}
+	}
+	return token, nil
+}
+
+type key int
+
+const tokenCacheKey key = iota
+
+// InjectTokenCache injects the TokenCache into the context.
+func InjectTokenCache(ctx context.Context, c *TokenCache) context.Context {
+	return context.WithValue(ctx, tokenCacheKey, c)
+}
+
+// GetTokenCache returns the TokenCache of the context,
+// or the DefaultTokenCache if none has been injected.
+func GetTokenCache(ctx context.Context) *TokenCache {
+	if c, ok := ctx.Value(tokenCacheKey).(*TokenCache); ok {
+		return c
+	}
+	return DefaultTokenCache
+}
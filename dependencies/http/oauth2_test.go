@@ -0,0 +1,92 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+)
+
+func TestTokenCache(t *testing.T) {
+	var requests int
+	expiresIn := "3600"
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		id, secret, _ := r.BasicAuth()
+		if id != "flux" || secret != "s3cr3t" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error": "invalid_client", "error_description": "unknown client"}`))
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		if got := r.PostForm.Get("grant_type"); got != "client_credentials" {
+			t.Errorf("unexpected grant type %q", got)
+		}
+		if got := r.PostForm.Get("scope"); got != "read write" {
+			t.Errorf("unexpected scope %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token": "token", "token_type": "bearer", "expires_in": ` + expiresIn + `}`))
+	}))
+	defer ts.Close()
+
+	cache := NewTokenCache()
+	config := OAuth2Config{
+		TokenURL:     ts.URL,
+		ClientID:     "flux",
+		ClientSecret: "s3cr3t",
+		Scopes:       []string{"read", "write"},
+	}
+	for i := 0; i < 3; i++ {
+		token, err := cache.Token(context.Background(), http.DefaultClient, config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token.AccessToken != "token" || token.TokenType != "Bearer" {
+			t.Errorf("unexpected token %+v", token)
+		}
+		if d := time.Until(token.Expiry); d < 59*time.Minute || d > time.Hour {
+			t.Errorf("unexpected token expiry %s", token.Expiry)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected one token request, got %d", requests)
+	}
+
+	// Tokens that are about to expire are refreshed.
+	expiresIn = "5"
+	cache = NewTokenCache()
+	for i := 0; i < 2; i++ {
+		if _, err := cache.Token(context.Background(), http.DefaultClient, config); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if requests != 3 {
+		t.Errorf("expected three token requests, got %d", requests)
+	}
+
+	config.ClientSecret = "invalid"
+	_, err := cache.Token(context.Background(), http.DefaultClient, config)
+	if want := "oauth2 token request failed: invalid_client: unknown client"; err == nil || err.Error() != want {
+		t.Errorf("unexpected error: want %q, got %v", want, err)
+	}
+	if errors.Code(err) != codes.Unauthenticated {
+		t.Errorf("unexpected error code %v", errors.Code(err))
+	}
+}
+
+func TestGetTokenCache(t *testing.T) {
+	if GetTokenCache(context.Background()) != DefaultTokenCache {
+		t.Error("expected the default token cache")
+	}
+	cache := NewTokenCache()
+	if GetTokenCache(InjectTokenCache(context.Background(), cache)) != cache {
+		t.Error("expected the injected token cache")
+	}
+}
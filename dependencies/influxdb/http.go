@@ -70,16 +70,28 @@ func (h HttpProvider) SeriesCardinalityReaderFor(ctx context.Context, conf Confi
 	}, nil
 }
 
+// SchemaReaderFor is not supported by the HttpProvider.
+// The query endpoint does not expose the schema of a read
+// without reading the data.
+func (h HttpProvider) SchemaReaderFor(ctx context.Context, conf Config, bounds flux.Bounds, predicateSet PredicateSet) (Reader, error) {
+	return nil, errors.New(codes.Unimplemented, "influxdb schema reader is not supported over http")
+}
+
 func (h HttpProvider) WriterFor(ctx context.Context, conf Config) (Writer, error) {
 	httpClient, err := h.clientFor(ctx, conf)
 	if err != nil {
 		return nil, err
 	}
 
-	service := apihttp.NewService(httpClient.Config.Host, "Token "+httpClient.Config.Token, apihttp.DefaultOptions().SetHTTPDoer(httpClient.Client))
+	// Retries are performed by the http client rather than the write api.
+	// The write api only retries a failed batch when the next batch is
+	// written so the last batch would never be retried.
+	doer := newRetryClient(httpClient.Client, conf.WriteOptions)
+	service := apihttp.NewService(httpClient.Config.Host, "Token "+httpClient.Config.Token, apihttp.DefaultOptions().SetHTTPDoer(doer))
 
 	writeOptions := write.DefaultOptions()
 	writeOptions.SetMaxRetries(0)
+	writeOptions.SetUseGZip(conf.WriteOptions.Gzip)
 	writer := api.NewWriteAPI(httpClient.Config.Org.IdOrName(), httpClient.Config.Bucket.IdOrName(), service, writeOptions)
 
 	return newHttpWriter(writer)
@@ -148,14 +160,17 @@ func (h *HttpClient) Query(ctx context.Context, f func(table flux.Table) error,
 	resp, err := h.Client.Do(req)
 	if err != nil {
 		return err
-	} else if resp.StatusCode != 200 {
-		data, err := ioutil.ReadAll(resp.Body)
+	}
+	body := DrainBody(resp.Body)
+	if resp.StatusCode != 200 {
+		defer func() { _ = body.Close() }()
+		data, err := ioutil.ReadAll(body)
 		if err != nil {
 			return errors.Newf(codes.Invalid, "error when reading response body: %s", err)
 		}
 		return h.parseError(data)
 	}
-	return h.processResult(resp.Body, f, mem)
+	return h.processResult(body, f, mem)
 }
 
 // newFile constructs a new ast.File with the default values filled in.
@@ -283,6 +298,7 @@ func (h *HttpClient) processResult(r io.ReadCloser, f func(flux.Table) error, me
 	dec := csv.NewMultiResultDecoder(config)
 	results, err := dec.Decode(r)
 	if err != nil {
+		_ = r.Close()
 		return err
 	}
 	defer results.Release()
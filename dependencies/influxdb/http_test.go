@@ -2,6 +2,7 @@ package influxdb_test
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"fmt"
 	"io"
@@ -301,3 +302,89 @@ func TestHttpWriter_Write_Error(t *testing.T) {
 		}
 	}
 }
+
+func TestHttpWriter_Write_Retry(t *testing.T) {
+	h := influxdb.HttpProvider{
+		DefaultConfig: influxdb.Config{
+			Host:  "http://myhost.com:8085",
+			Token: "mytoken",
+		},
+	}
+	deps := dependenciestest.Default()
+
+	var attempts int
+	roundTripper := &RoundTrip{
+		RequestValidator: func(req *http.Request) error {
+			if val, exp := req.Header.Get("Content-Encoding"), "gzip"; val != exp {
+				return fmt.Errorf("content encoding does not match, expected %s, got %s", exp, val)
+			}
+			return nil
+		},
+		HandlerFn: func(req *http.Request) (*http.Response, error) {
+			attempts++
+			if attempts < 3 {
+				return &http.Response{
+					StatusCode: http.StatusServiceUnavailable,
+					Status:     http.StatusText(http.StatusServiceUnavailable),
+					Body:       ioutil.NopCloser(strings.NewReader(`{"code":"unavailable","message":"try again later"}`)),
+					Header: http.Header{
+						"Content-Type": []string{"application/json"},
+						"Retry-After":  []string{"0"},
+					},
+				}, nil
+			}
+			return &http.Response{
+				StatusCode: http.StatusNoContent,
+				Status:     http.StatusText(http.StatusNoContent),
+				Body:       ioutil.NopCloser(new(bytes.Buffer)),
+				Header:     make(http.Header),
+			}, nil
+		},
+	}
+	deps.Deps.Deps.HTTPClient = &http.Client{
+		Transport: roundTripper,
+	}
+	ctx, span := dependency.Inject(context.Background(), deps)
+	defer span.Finish()
+	writer, err := h.WriterFor(ctx, influxdb.Config{
+		Org:    influxdb.NameOrID{Name: "myorg"},
+		Bucket: influxdb.NameOrID{Name: "mybucket"},
+		WriteOptions: influxdb.WriteOptions{
+			MaxRetries:    2,
+			RetryInterval: time.Millisecond,
+			Gzip:          true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("WriterFor() error = %v", err)
+	}
+
+	err = writer.Write(cpuMetric(95, 1))
+	if closeErr := writer.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if roundTripper.RequestValidatorError != nil {
+		t.Errorf("Query validation error = %v", roundTripper.RequestValidatorError)
+	}
+	if want, got := 3, attempts; want != got {
+		t.Errorf("unexpected number of attempts -want/+got:\n\t- %d\n\t+ %d", want, got)
+	}
+
+	// Each attempt sends the same compressed body.
+	r, err := gzip.NewReader(&roundTripper.Bodies)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Multistream(true)
+	body, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	line := "cpu,host=localhost,id=cpua usage_user=95,log=\"message\" 1510876800000000001\n"
+	if want, got := strings.Repeat(line, 3), string(body); want != got {
+		t.Error(cmp.Diff(want, got))
+	}
+}
@@ -0,0 +1,107 @@
+package influxdb
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"time"
+)
+
+const limiterKey key = iota + 1
+
+// ReadLimits configures the reads from influxdb instances in a query.
+// The limits apply to each query separately.
+type ReadLimits struct {
+	// Timeout limits the duration of each read, including the time
+	// spent processing the tables it produces. Zero means no timeout.
+	Timeout time.Duration
+
+	// MaxConcurrentReads limits the number of reads that run at the
+	// same time. Additional reads wait for a running read to finish.
+	// Zero means no limit.
+	MaxConcurrentReads int
+}
+
+// ReadStats holds the statistics of a single read.
+type ReadStats struct {
+	// WaitDuration is the time spent waiting for other reads
+	// because of the concurrent read limit.
+	WaitDuration time.Duration
+
+	// ReadDuration is the time spent reading.
+	ReadDuration time.Duration
+}
+
+// readLimiter enforces the ReadLimits of a query.
+type readLimiter struct {
+	timeout time.Duration
+	slots   chan struct{}
+}
+
+func newReadLimiter(limits ReadLimits) *readLimiter {
+	l := &readLimiter{timeout: limits.Timeout}
+	if limits.MaxConcurrentReads > 0 {
+		l.slots = make(chan struct{}, limits.MaxConcurrentReads)
+	}
+	return l
+}
+
+// LimitRead will invoke the read function within the read limits of the
+// query and return the statistics of the read. If no limits have been
+// injected into the dependency chain, the read is invoked immediately.
+func LimitRead(ctx context.Context, read func(ctx context.Context) error) (ReadStats, error) {
+	var stats ReadStats
+	l, _ := ctx.Value(limiterKey).(*readLimiter)
+	if l == nil {
+		start := time.Now()
+		err := read(ctx)
+		stats.ReadDuration = time.Since(start)
+		return stats, err
+	}
+
+	start := time.Now()
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+			defer func() { <-l.slots }()
+		case <-ctx.Done():
+			return stats, ctx.Err()
+		}
+	}
+	stats.WaitDuration = time.Since(start)
+
+	if l.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, l.timeout)
+		defer cancel()
+	}
+
+	start = time.Now()
+	err := read(ctx)
+	stats.ReadDuration = time.Since(start)
+	return stats, err
+}
+
+// maxDrainSize is the maximum number of bytes that are read from
+// an unread response body so the connection can be reused.
+// Larger bodies are closed without reading them and the
+// connection is discarded.
+const maxDrainSize = 64 << 10
+
+// drainCloser closes the response body after reading what remains of it.
+// The http client will only return a connection to the pool for the next
+// request when the body was read to the end.
+type drainCloser struct {
+	io.ReadCloser
+}
+
+func (d drainCloser) Close() error {
+	_, _ = io.CopyN(ioutil.Discard, d.ReadCloser, maxDrainSize)
+	return d.ReadCloser.Close()
+}
+
+// DrainBody wraps a response body so that closing it allows
+// the connection to be reused for the next request.
+func DrainBody(body io.ReadCloser) io.ReadCloser {
+	return drainCloser{ReadCloser: body}
+}
@@ -0,0 +1,81 @@
+package influxdb_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux/dependencies/influxdb"
+)
+
+func TestLimitRead_MaxConcurrentReads(t *testing.T) {
+	ctx := influxdb.Dependency{
+		ReadLimits: influxdb.ReadLimits{
+			MaxConcurrentReads: 2,
+		},
+	}.Inject(context.Background())
+
+	var running, peak int32
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := influxdb.LimitRead(ctx, func(ctx context.Context) error {
+				n := atomic.AddInt32(&running, 1)
+				for {
+					p := atomic.LoadInt32(&peak)
+					if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				atomic.AddInt32(&running, -1)
+				return nil
+			})
+			if err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak > 2 {
+		t.Fatalf("expected at most 2 concurrent reads, got %d", peak)
+	}
+}
+
+func TestLimitRead_Timeout(t *testing.T) {
+	ctx := influxdb.Dependency{
+		ReadLimits: influxdb.ReadLimits{
+			Timeout: time.Millisecond,
+		},
+	}.Inject(context.Background())
+
+	_, err := influxdb.LimitRead(ctx, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if err != context.DeadlineExceeded {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestLimitRead_NoLimits(t *testing.T) {
+	var called bool
+	stats, err := influxdb.LimitRead(context.Background(), func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !called {
+		t.Fatal("expected the read to be called")
+	}
+	if stats.WaitDuration != 0 {
+		t.Fatalf("expected no wait duration, got %v", stats.WaitDuration)
+	}
+}
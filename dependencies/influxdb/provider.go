@@ -19,10 +19,14 @@ const readerKey key = iota
 // Dependency will inject the Provider into the dependency chain.
 type Dependency struct {
 	Provider Provider
+
+	// ReadLimits are the limits of the reads in each query.
+	ReadLimits ReadLimits
 }
 
-// Inject will inject the Provider into the dependency chain.
+// Inject will inject the Provider and the read limits into the dependency chain.
 func (d Dependency) Inject(ctx context.Context) context.Context {
+	ctx = context.WithValue(ctx, limiterKey, newReadLimiter(d.ReadLimits))
 	return context.WithValue(ctx, readerKey, d.Provider)
 }
 
@@ -43,6 +47,10 @@ type Config struct {
 	Bucket NameOrID
 	Host   string
 	Token  string
+
+	// WriteOptions configures the Writer returned by WriterFor.
+	// It is ignored by the readers.
+	WriteOptions WriteOptions
 }
 
 // Predicate defines a predicate to filter storage with.
@@ -94,6 +102,12 @@ type Provider interface {
 	// for the SeriesCardinality operation.
 	SeriesCardinalityReaderFor(ctx context.Context, conf Config, bounds flux.Bounds, predicateSet PredicateSet) (Reader, error)
 
+	// SchemaReaderFor will return a Reader that produces the tables
+	// of a read with their group keys and columns but without any rows.
+	// The schema is expected to be read from the metadata of the
+	// influxdb instance rather than by scanning the data.
+	SchemaReaderFor(ctx context.Context, conf Config, bounds flux.Bounds, predicateSet PredicateSet) (Reader, error)
+
 	// WriterFor will construct a Writer using the given configuration parameters.
 	// If the parameters are their zero values, appropriate defaults may be used
 	// or an error may be returned if the implementation does not have a default.
@@ -143,6 +157,10 @@ func (u UnimplementedProvider) SeriesCardinalityReaderFor(ctx context.Context, c
 	return nil, errors.New(codes.Unimplemented, "influxdb series cardinality reader has not been implemented")
 }
 
+func (u UnimplementedProvider) SchemaReaderFor(ctx context.Context, conf Config, bounds flux.Bounds, predicateSet PredicateSet) (Reader, error) {
+	return nil, errors.New(codes.Unimplemented, "influxdb schema reader has not been implemented")
+}
+
 func (u UnimplementedProvider) WriterFor(ctx context.Context, conf Config) (Writer, error) {
 	return nil, errors.New(codes.Unimplemented, "influxdb writer has not been implemented")
 }
@@ -160,6 +178,10 @@ func (u ErrorProvider) SeriesCardinalityReaderFor(ctx context.Context, conf Conf
 	return nil, errors.New(codes.Invalid, "Provider.SeriesCardinalityReaderFor called on an error dependency")
 }
 
+func (u ErrorProvider) SchemaReaderFor(ctx context.Context, conf Config, bounds flux.Bounds, predicateSet PredicateSet) (Reader, error) {
+	return nil, errors.New(codes.Invalid, "Provider.SchemaReaderFor called on an error dependency")
+}
+
 func (u ErrorProvider) WriterFor(ctx context.Context, conf Config) (Writer, error) {
 	return nil, errors.New(codes.Invalid, "Provider.WriterFor called on an error dependency")
 }
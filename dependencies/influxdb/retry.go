@@ -0,0 +1,127 @@
+package influxdb
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	stdhttp "net/http"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/flux/dependencies/http"
+)
+
+const (
+	// DefaultRetryInterval is the delay before the first retry
+	// of a write when no retry interval has been configured.
+	DefaultRetryInterval = time.Second
+
+	// DefaultMaxRetryInterval is the maximum delay between
+	// retries of a write when none has been configured.
+	DefaultMaxRetryInterval = 30 * time.Second
+)
+
+// WriteOptions configures how a Writer sends points to an influxdb instance.
+type WriteOptions struct {
+	// MaxRetries is the number of times a write is retried when
+	// the server responds with 429 Too Many Requests or
+	// 503 Service Unavailable. Zero disables retries.
+	MaxRetries int
+
+	// RetryInterval is the delay before the first retry.
+	// The delay doubles with each retry up to MaxRetryInterval.
+	// A Retry-After header sent by the server takes precedence.
+	RetryInterval time.Duration
+
+	// MaxRetryInterval is the maximum delay between retries.
+	MaxRetryInterval time.Duration
+
+	// Gzip enables gzip compression of the request bodies.
+	Gzip bool
+}
+
+// retryClient is an http.Client that retries requests rejected
+// because the server is overloaded with an exponential backoff.
+type retryClient struct {
+	client http.Client
+	opts   WriteOptions
+}
+
+func newRetryClient(client http.Client, opts WriteOptions) http.Client {
+	if opts.MaxRetries <= 0 {
+		return client
+	}
+	if opts.RetryInterval <= 0 {
+		opts.RetryInterval = DefaultRetryInterval
+	}
+	if opts.MaxRetryInterval <= 0 {
+		opts.MaxRetryInterval = DefaultMaxRetryInterval
+	}
+	return &retryClient{client: client, opts: opts}
+}
+
+func (c *retryClient) Do(req *stdhttp.Request) (*stdhttp.Response, error) {
+	// Read the body so it can be sent again for each retry.
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ctx := req.Context()
+	interval := c.opts.RetryInterval
+	for retries := 0; ; retries++ {
+		if body != nil {
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+		resp, err := c.client.Do(req)
+		if err != nil || retries >= c.opts.MaxRetries || !shouldRetry(resp.StatusCode) {
+			return resp, err
+		}
+
+		delay := retryAfter(resp.Header, interval)
+		if delay > c.opts.MaxRetryInterval {
+			delay = c.opts.MaxRetryInterval
+		}
+		// Discard the body so the connection can be reused.
+		_, _ = ioutil.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+		interval *= 2
+	}
+}
+
+// shouldRetry reports whether a request that received a response
+// with the status code may succeed when it is sent again.
+func shouldRetry(code int) bool {
+	return code == stdhttp.StatusTooManyRequests || code == stdhttp.StatusServiceUnavailable
+}
+
+// retryAfter returns the delay requested by the Retry-After header
+// in seconds or the default delay if there is none.
+func retryAfter(header stdhttp.Header, def time.Duration) time.Duration {
+	if v := header.Get("Retry-After"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+			return time.Duration(secs) * time.Second
+		}
+	}
+	return def
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,66 @@
+// Package metrics defines the registry of metrics reported by the executor.
+//
+// The Gauge and Histogram interfaces are satisfied by prometheus.Gauge
+// and prometheus.Observer so a Registry can be backed by Prometheus
+// collectors registered by the embedding application.
+package metrics
+
+import "context"
+
+type key int
+
+const registryKey key = iota
+
+// Inject will inject this Registry into the dependency chain.
+func Inject(ctx context.Context, registry Registry) context.Context {
+	return context.WithValue(ctx, registryKey, registry)
+}
+
+// Dependency will inject the Registry into the dependency chain.
+type Dependency struct {
+	Registry Registry
+}
+
+// Inject will inject the Registry into the dependency chain.
+func (d Dependency) Inject(ctx context.Context) context.Context {
+	return Inject(ctx, d.Registry)
+}
+
+// GetRegistry will return the Registry for the current context.
+// If no Registry has been injected into the dependencies,
+// this will return nil and no metrics should be reported.
+func GetRegistry(ctx context.Context) Registry {
+	r, _ := ctx.Value(registryKey).(Registry)
+	return r
+}
+
+// Gauge is a metric that can go up and down.
+type Gauge interface {
+	// Add adds the value to the gauge. The value may be negative.
+	Add(v float64)
+}
+
+// Histogram is a metric that samples observations.
+type Histogram interface {
+	// Observe adds a single observation to the histogram.
+	Observe(v float64)
+}
+
+// Registry provides the metrics populated by the executor and the dispatcher.
+// It is shared by all executions so the metrics must be safe for concurrent use.
+type Registry interface {
+	// QueriesInFlight is the number of queries being executed.
+	QueriesInFlight() Gauge
+
+	// NodeDuration is the time in seconds that a node with the
+	// operation type takes to process a single message.
+	NodeDuration(operationType string) Histogram
+
+	// AllocatedBytes is the amount of memory in use by the queries
+	// being executed.
+	AllocatedBytes() Gauge
+
+	// DispatcherQueueDepth is the amount of work scheduled
+	// by transformations that is waiting for a worker.
+	DispatcherQueueDepth() Gauge
+}
@@ -0,0 +1,24 @@
+package metrics_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/flux/dependencies/metrics"
+)
+
+type registry struct {
+	metrics.Registry
+}
+
+func TestGetRegistry(t *testing.T) {
+	if got := metrics.GetRegistry(context.Background()); got != nil {
+		t.Fatalf("expected no registry, got %T", got)
+	}
+
+	r := &registry{}
+	ctx := metrics.Dependency{Registry: r}.Inject(context.Background())
+	if got := metrics.GetRegistry(ctx); got != r {
+		t.Fatalf("unexpected registry -want/+got:\n\t- %v\n\t+ %v", r, got)
+	}
+}
@@ -87,7 +87,7 @@ func (d DefaultDialer) Dial(ctx context.Context, brokers []string, options Optio
 	}
 
 	deps := flux.GetDependencies(ctx)
-	if url, err := deps.URLValidator(); err != nil {
+	if url, err := flux.PackageURLValidator(deps, "experimental/mqtt"); err != nil {
 		return nil, err
 	} else {
 		for _, broker := range opts.Servers {
@@ -0,0 +1,151 @@
+package objectstore
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/dependencies/filesystem"
+	"github.com/influxdata/flux/internal/errors"
+)
+
+// azureVersion is the version of the Blob service REST API used by requests.
+const azureVersion = "2020-10-02"
+
+// Azure reads blobs from Azure Blob Storage.
+// The bucket of an az:// URL is the name of the container.
+//
+// Requests are authorized with the shared key of the storage account
+// or with a shared access signature stored in the named secrets.
+// If both secrets are empty, requests are anonymous and only
+// blobs of public containers can be read.
+type Azure struct {
+	// AccountName is the name of the storage account.
+	AccountName string
+	// Endpoint is the URL of the blob service.
+	// It defaults to https://<AccountName>.blob.core.windows.net.
+	Endpoint string
+
+	// AccountKeySecret is the key of the secret with the
+	// base64 encoded shared key of the storage account.
+	AccountKeySecret string
+	// SASTokenSecret is the key of the secret with a shared access signature.
+	// It is used when AccountKeySecret is empty.
+	SASTokenSecret string
+}
+
+var _ filesystem.ObjectStore = (*Azure)(nil)
+
+func (s *Azure) Open(ctx context.Context, container, blob string) (filesystem.File, error) {
+	if s.AccountName == "" {
+		return nil, errors.New(codes.Invalid, "azure object store requires an account name")
+	}
+	endpoint := s.Endpoint
+	if endpoint == "" {
+		endpoint = "https://" + s.AccountName + ".blob.core.windows.net"
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, codes.Invalid, "invalid azure endpoint %q", endpoint)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + container + "/" + blob
+
+	if s.AccountKeySecret == "" && s.SASTokenSecret != "" {
+		token, err := loadSecret(ctx, s.SASTokenSecret)
+		if err != nil {
+			return nil, err
+		}
+		u.RawQuery = strings.TrimPrefix(token, "?")
+	}
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, codes.Invalid, "invalid azure request")
+	}
+	req.Header.Set("x-ms-version", azureVersion)
+
+	if s.AccountKeySecret != "" {
+		key, err := loadSecret(ctx, s.AccountKeySecret)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.sign(req, key, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+	return get(ctx, req, "azure", blob)
+}
+
+// sign authorizes the request with the shared key of the storage account.
+func (s *Azure) sign(req *http.Request, accountKey string, now time.Time) error {
+	key, err := base64.StdEncoding.DecodeString(accountKey)
+	if err != nil {
+		return errors.Wrap(err, codes.Invalid, "azure account key is not base64 encoded")
+	}
+	req.Header.Set("x-ms-date", now.UTC().Format(http.TimeFormat))
+
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(s.stringToSign(req)))
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	req.Header.Set("Authorization", "SharedKey "+s.AccountName+":"+signature)
+	return nil
+}
+
+// stringToSign builds the string signed by the shared key authorization scheme.
+func (s *Azure) stringToSign(req *http.Request) string {
+	var b strings.Builder
+	b.WriteString(req.Method)
+	b.WriteByte('\n')
+	for _, h := range []string{
+		"Content-Encoding", "Content-Language", "Content-Length", "Content-MD5", "Content-Type", "Date",
+		"If-Modified-Since", "If-Match", "If-None-Match", "If-Unmodified-Since", "Range",
+	} {
+		v := req.Header.Get(h)
+		if h == "Content-Length" && v == "0" {
+			v = ""
+		}
+		b.WriteString(v)
+		b.WriteByte('\n')
+	}
+
+	// Canonicalized headers.
+	var names []string
+	for name := range req.Header {
+		if name := strings.ToLower(name); strings.HasPrefix(name, "x-ms-") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		b.WriteString(name)
+		b.WriteByte(':')
+		b.WriteString(strings.TrimSpace(req.Header.Get(name)))
+		b.WriteByte('\n')
+	}
+
+	// Canonicalized resource.
+	b.WriteByte('/')
+	b.WriteString(s.AccountName)
+	b.WriteString(req.URL.EscapedPath())
+	query := req.URL.Query()
+	params := make([]string, 0, len(query))
+	for name := range query {
+		params = append(params, name)
+	}
+	sort.Strings(params)
+	for _, name := range params {
+		vs := query[name]
+		sort.Strings(vs)
+		b.WriteByte('\n')
+		b.WriteString(strings.ToLower(name))
+		b.WriteByte(':')
+		b.WriteString(strings.Join(vs, ","))
+	}
+	return b.String()
+}
@@ -0,0 +1,90 @@
+package objectstore
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/dependencies/filesystem"
+	"github.com/influxdata/flux/internal/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const gcsReadOnlyScope = "https://www.googleapis.com/auth/devstorage.read_only"
+
+// GCS reads objects from Google Cloud Storage.
+//
+// Requests are authorized with an OAuth2 token for the service account
+// credentials stored in the named secret. If CredentialsSecret is empty,
+// requests are anonymous and only public objects can be read.
+type GCS struct {
+	// Endpoint is the URL of the service.
+	// It defaults to https://storage.googleapis.com.
+	Endpoint string
+
+	// CredentialsSecret is the key of the secret with the
+	// JSON credentials of a service account.
+	CredentialsSecret string
+
+	mu          sync.Mutex
+	credentials string
+	tokens      oauth2.TokenSource
+}
+
+var _ filesystem.ObjectStore = (*GCS)(nil)
+
+func (s *GCS) Open(ctx context.Context, bucket, key string) (filesystem.File, error) {
+	endpoint := s.Endpoint
+	if endpoint == "" {
+		endpoint = "https://storage.googleapis.com"
+	}
+	if _, err := url.Parse(endpoint); err != nil {
+		return nil, errors.Wrapf(err, codes.Invalid, "invalid gcs endpoint %q", endpoint)
+	}
+	// The JSON API requires the slashes of the object name to be escaped.
+	u := strings.TrimSuffix(endpoint, "/") + "/storage/v1/b/" + url.PathEscape(bucket) + "/o/" + url.PathEscape(key) + "?alt=media"
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, codes.Invalid, "invalid gcs request")
+	}
+
+	if s.CredentialsSecret != "" {
+		ts, err := s.tokenSource(ctx)
+		if err != nil {
+			return nil, err
+		}
+		token, err := ts.Token()
+		if err != nil {
+			return nil, errors.Wrap(err, codes.Unauthenticated, "cannot get gcs access token")
+		}
+		token.SetAuthHeader(req)
+	}
+	return get(ctx, req, "gcs", key)
+}
+
+// tokenSource returns a token source for the credentials in the secret.
+// The token source is reused while the credentials do not change
+// so tokens are only refreshed when they expire.
+func (s *GCS) tokenSource(ctx context.Context) (oauth2.TokenSource, error) {
+	creds, err := loadSecret(ctx, s.CredentialsSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tokens != nil && s.credentials == creds {
+		return s.tokens, nil
+	}
+	// The token source outlives the query, so it must not use its context.
+	c, err := google.CredentialsFromJSON(context.Background(), []byte(creds), gcsReadOnlyScope)
+	if err != nil {
+		return nil, errors.Wrap(err, codes.Invalid, "invalid gcs credentials")
+	}
+	s.credentials, s.tokens = creds, c.TokenSource
+	return s.tokens, nil
+}
@@ -0,0 +1,97 @@
+// Package objectstore implements filesystem.ObjectStore backends
+// for Amazon S3, Google Cloud Storage and Azure Blob Storage.
+//
+// The backends send requests with the HTTP client of the query
+// dependencies and load their credentials from the secret service
+// when an object is opened, so the stores can be configured once
+// and the credentials can be managed with the rest of the secrets.
+//
+// A host application enables the backends by using a
+// filesystem.ObjectStoreFS as the filesystem service:
+//
+//	fs := &filesystem.ObjectStoreFS{
+//	    FS: filesystem.SystemFS,
+//	    Stores: map[string]filesystem.ObjectStore{
+//	        "s3": &objectstore.S3{Region: "us-east-1", AccessKeyIDSecret: "AWS_ACCESS_KEY_ID", SecretAccessKeySecret: "AWS_SECRET_ACCESS_KEY"},
+//	        "gs": &objectstore.GCS{CredentialsSecret: "GCS_CREDENTIALS"},
+//	        "az": &objectstore.Azure{AccountName: "myaccount", AccountKeySecret: "AZURE_ACCOUNT_KEY"},
+//	    },
+//	}
+package objectstore
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/dependencies/filesystem"
+	"github.com/influxdata/flux/internal/errors"
+)
+
+// maxErrorBody is the maximum size of an error response that is read
+// to report the error returned by the service.
+const maxErrorBody = 4096
+
+// loadSecret loads the secret with the key from the secret service.
+func loadSecret(ctx context.Context, key string) (string, error) {
+	ss, err := flux.GetDependencies(ctx).SecretService()
+	if err != nil {
+		return "", err
+	}
+	v, err := ss.LoadSecret(ctx, key)
+	if err != nil {
+		return "", errors.Wrapf(err, codes.Inherit, "cannot load object store credentials from secret %q", key)
+	}
+	return v, nil
+}
+
+// get sends the request with the HTTP client of the dependencies
+// and returns the body of a successful response as a file.
+func get(ctx context.Context, req *http.Request, service, key string) (filesystem.File, error) {
+	client, err := flux.GetDependencies(ctx).HTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrapf(err, codes.Unavailable, "%s request failed", service)
+	}
+	if resp.StatusCode/100 != 2 {
+		defer func() { _ = resp.Body.Close() }()
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return nil, statusError(resp.StatusCode, service, key, strings.TrimSpace(string(body)))
+	}
+
+	var modTime time.Time
+	if lm := resp.Header.Get("Last-Modified"); lm != "" {
+		modTime, _ = http.ParseTime(lm)
+	}
+	return filesystem.NewObjectFile(resp.Body, key, resp.ContentLength, modTime), nil
+}
+
+func statusError(status int, service, key, body string) error {
+	code := codes.Unknown
+	switch status {
+	case http.StatusNotFound:
+		return errors.Newf(codes.NotFound, "%s object %q does not exist", service, key)
+	case http.StatusBadRequest:
+		code = codes.Invalid
+	case http.StatusUnauthorized:
+		code = codes.Unauthenticated
+	case http.StatusForbidden:
+		code = codes.PermissionDenied
+	case http.StatusTooManyRequests:
+		code = codes.ResourceExhausted
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		code = codes.Unavailable
+	}
+	if body == "" {
+		return errors.Newf(code, "cannot read %s object %q: %s", service, key, http.StatusText(status))
+	}
+	return errors.Newf(code, "cannot read %s object %q: %s: %s", service, key, http.StatusText(status), body)
+}
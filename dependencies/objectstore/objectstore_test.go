@@ -0,0 +1,184 @@
+package objectstore_test
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/dependencies/filesystem"
+	"github.com/influxdata/flux/dependencies/objectstore"
+	"github.com/influxdata/flux/internal/errors"
+)
+
+type secrets map[string]string
+
+func (s secrets) LoadSecret(ctx context.Context, k string) (string, error) {
+	v, ok := s[k]
+	if !ok {
+		return "", errors.Newf(codes.NotFound, "secret key %q not found", k)
+	}
+	return v, nil
+}
+
+func testContext(stores map[string]filesystem.ObjectStore) context.Context {
+	deps := flux.NewDefaultDependencies()
+	deps.Deps.HTTPClient = http.DefaultClient
+	deps.Deps.SecretService = secrets{
+		"s3-key-id":     "AKID",
+		"s3-secret-key": "SECRET",
+		"az-key":        "a2V5",
+		"az-sas":        "?sv=2020-10-02&sig=abc",
+	}
+	deps.Deps.FilesystemService = &filesystem.ObjectStoreFS{Stores: stores}
+	return deps.Inject(context.Background())
+}
+
+// serve returns a server that checks each request before responding with the body.
+func serve(t *testing.T, check func(r *http.Request)) *httptest.Server {
+	t.Helper()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "missing") {
+			http.Error(w, "no such key", http.StatusNotFound)
+			return
+		}
+		check(r)
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		_, _ = io.WriteString(w, "_value\n1\n")
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func readAll(t *testing.T, ctx context.Context, fpath string) string {
+	t.Helper()
+	f, err := filesystem.OpenFile(ctx, fpath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() != 9 || info.ModTime().Year() != 2006 {
+		t.Errorf("unexpected file info: size %d, modified %s", info.Size(), info.ModTime())
+	}
+	data, err := ioutil.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestS3(t *testing.T) {
+	ts := serve(t, func(r *http.Request) {
+		if got, want := r.URL.EscapedPath(), "/bucket/dir/a%2Bb.csv"; got != want {
+			t.Errorf("unexpected path: want %s, got %s", want, got)
+		}
+		if auth := r.Header.Get("Authorization"); !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/") || !strings.Contains(auth, "/eu-west-1/s3/aws4_request") {
+			t.Errorf("unexpected authorization header %q", auth)
+		}
+		if r.Header.Get("X-Amz-Content-Sha256") == "" {
+			t.Error("missing content hash header")
+		}
+	})
+	ctx := testContext(map[string]filesystem.ObjectStore{
+		"s3": &objectstore.S3{
+			Region:                "eu-west-1",
+			Endpoint:              ts.URL,
+			PathStyle:             true,
+			AccessKeyIDSecret:     "s3-key-id",
+			SecretAccessKeySecret: "s3-secret-key",
+		},
+	})
+	if got := readAll(t, ctx, "s3://bucket/dir/a+b.csv"); got != "_value\n1\n" {
+		t.Errorf("unexpected contents %q", got)
+	}
+	if _, err := filesystem.OpenFile(ctx, "s3://bucket/missing.csv"); errors.Code(err) != codes.NotFound {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestS3_MissingSecret(t *testing.T) {
+	ctx := testContext(map[string]filesystem.ObjectStore{
+		"s3": &objectstore.S3{AccessKeyIDSecret: "s3-key-id", SecretAccessKeySecret: "unknown"},
+	})
+	_, err := filesystem.OpenFile(ctx, "s3://bucket/data.csv")
+	if want := `cannot load object store credentials from secret "unknown": secret key "unknown" not found`; err == nil || err.Error() != want {
+		t.Errorf("unexpected error: want %q, got %v", want, err)
+	}
+}
+
+func TestGCS(t *testing.T) {
+	ts := serve(t, func(r *http.Request) {
+		if got, want := r.URL.EscapedPath(), "/storage/v1/b/bucket/o/dir%2Fdata.csv"; got != want {
+			t.Errorf("unexpected path: want %s, got %s", want, got)
+		}
+		if got := r.URL.Query().Get("alt"); got != "media" {
+			t.Errorf("unexpected alt parameter %q", got)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("unexpected authorization header %q", auth)
+		}
+	})
+	ctx := testContext(map[string]filesystem.ObjectStore{
+		"gs": &objectstore.GCS{Endpoint: ts.URL},
+	})
+	if got := readAll(t, ctx, "gs://bucket/dir/data.csv"); got != "_value\n1\n" {
+		t.Errorf("unexpected contents %q", got)
+	}
+}
+
+func TestAzure(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		store *objectstore.Azure
+		check func(t *testing.T, r *http.Request)
+	}{
+		{
+			name:  "shared key",
+			store: &objectstore.Azure{AccountName: "account", AccountKeySecret: "az-key"},
+			check: func(t *testing.T, r *http.Request) {
+				if auth := r.Header.Get("Authorization"); !strings.HasPrefix(auth, "SharedKey account:") {
+					t.Errorf("unexpected authorization header %q", auth)
+				}
+				if r.Header.Get("x-ms-date") == "" || r.Header.Get("x-ms-version") == "" {
+					t.Error("missing x-ms headers")
+				}
+			},
+		},
+		{
+			name:  "sas token",
+			store: &objectstore.Azure{AccountName: "account", SASTokenSecret: "az-sas"},
+			check: func(t *testing.T, r *http.Request) {
+				if got := r.URL.Query().Get("sig"); got != "abc" {
+					t.Errorf("unexpected signature %q", got)
+				}
+				if auth := r.Header.Get("Authorization"); auth != "" {
+					t.Errorf("unexpected authorization header %q", auth)
+				}
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ts := serve(t, func(r *http.Request) {
+				if got, want := r.URL.Path, "/container/dir/data.csv"; got != want {
+					t.Errorf("unexpected path: want %s, got %s", want, got)
+				}
+				tc.check(t, r)
+			})
+			tc.store.Endpoint = ts.URL
+			ctx := testContext(map[string]filesystem.ObjectStore{"az": tc.store})
+			if got := readAll(t, ctx, "az://container/dir/data.csv"); got != "_value\n1\n" {
+				t.Errorf("unexpected contents %q", got)
+			}
+		})
+	}
+}
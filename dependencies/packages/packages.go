@@ -0,0 +1,192 @@
+// Package packages provides the dependency that loads the source of
+// third-party Flux packages.
+//
+// A script declares the third-party packages it imports in a flux.json
+// manifest next to the script. Each dependency of the manifest pins the
+// version of the package. The packages are loaded with the Loader from
+// the context and are resolved by lang.ResolveImports before the script
+// is compiled.
+package packages
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+)
+
+// ManifestFile is the name of the manifest that lists
+// the third-party packages of a script.
+const ManifestFile = "flux.json"
+
+// Manifest lists the third-party packages that a script imports.
+type Manifest struct {
+	// Registry is the base URL of the registry that serves
+	// the packages that do not set their own URL.
+	Registry string `json:"registry,omitempty"`
+	// Dependencies maps the import path of each package
+	// to the version of the package that is imported.
+	Dependencies map[string]Requirement `json:"dependencies"`
+}
+
+// Requirement pins the version of an imported package.
+type Requirement struct {
+	Version string `json:"version"`
+	// URL is the location of the source of the package, such as
+	// the raw file of a git tag. The string {version} is replaced
+	// with the version. When it is empty, the package is read from
+	// the registry of the manifest.
+	URL string `json:"url,omitempty"`
+}
+
+// versionPattern matches the semantic versions that can be pinned.
+var versionPattern = regexp.MustCompile(`^v[0-9]+\.[0-9]+\.[0-9]+(-[0-9A-Za-z.-]+)?$`)
+
+// ParseManifest decodes and validates a manifest.
+func ParseManifest(data []byte) (*Manifest, error) {
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, errors.Wrapf(err, codes.Invalid, "invalid %s", ManifestFile)
+	}
+	for path, req := range m.Dependencies {
+		if path == "" || strings.HasPrefix(path, ".") || strings.HasPrefix(path, "/") {
+			return nil, errors.Newf(codes.Invalid, "%s: invalid import path %q", ManifestFile, path)
+		}
+		if !versionPattern.MatchString(req.Version) {
+			return nil, errors.Newf(codes.Invalid, "%s: package %q must pin a version such as v1.0.0, got %q", ManifestFile, path, req.Version)
+		}
+		if req.URL == "" && m.Registry == "" {
+			return nil, errors.Newf(codes.Invalid, "%s: package %q has no url and the manifest has no registry", ManifestFile, path)
+		}
+	}
+	return &m, nil
+}
+
+// Module returns the module for the import path.
+// It returns false if the manifest does not list the import path.
+func (m *Manifest) Module(path string) (Module, bool) {
+	if m == nil {
+		return Module{}, false
+	}
+	req, ok := m.Dependencies[path]
+	if !ok {
+		return Module{}, false
+	}
+	url := req.URL
+	if url == "" {
+		url = strings.TrimSuffix(m.Registry, "/") + "/" + path + "/@v/" + req.Version + ".flux"
+	}
+	return Module{
+		Path:    path,
+		Version: req.Version,
+		URL:     strings.ReplaceAll(url, "{version}", req.Version),
+	}, true
+}
+
+// Module is a version of a third-party package.
+type Module struct {
+	Path    string
+	Version string
+	// URL is the location of the source of the module.
+	URL string
+}
+
+func (m Module) String() string {
+	return m.Path + "@" + m.Version
+}
+
+// Loader loads the source of the modules imported by a script.
+type Loader interface {
+	// Load returns the Flux source of the module.
+	Load(ctx context.Context, m Module) ([]byte, error)
+}
+
+type key int
+
+const loaderKey key = iota
+
+// Dependency will inject the Loader into the dependency chain.
+type Dependency struct {
+	Loader Loader
+}
+
+// Inject will inject the Loader into the dependency chain.
+func (d Dependency) Inject(ctx context.Context) context.Context {
+	if d.Loader != nil {
+		ctx = Inject(ctx, d.Loader)
+	}
+	return ctx
+}
+
+// Inject will inject the Loader into the context.
+func Inject(ctx context.Context, l Loader) context.Context {
+	return context.WithValue(ctx, loaderKey, l)
+}
+
+// Get will retrieve the Loader from the context.
+// If no Loader has been injected, the modules are
+// loaded from their URL with the HTTP client of the
+// flux dependencies.
+func Get(ctx context.Context) Loader {
+	if l, ok := ctx.Value(loaderKey).(Loader); ok {
+		return l
+	}
+	return HTTPLoader{}
+}
+
+// maxModuleSize is the largest source of a module that is read.
+const maxModuleSize = 10 * 1024 * 1024
+
+// HTTPLoader loads modules from their URL with the
+// HTTP client of the flux dependencies.
+type HTTPLoader struct{}
+
+func (HTTPLoader) Load(ctx context.Context, m Module) ([]byte, error) {
+	client, err := flux.GetDependencies(ctx).HTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, m.URL, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, codes.Invalid, "invalid url for package %s", m)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, codes.Unavailable, "failed to load package %s", m)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		code := codes.Unavailable
+		if resp.StatusCode == http.StatusNotFound {
+			code = codes.NotFound
+		}
+		return nil, errors.Newf(code, "failed to load package %s: %s", m, resp.Status)
+	}
+	src, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxModuleSize+1))
+	if err != nil {
+		return nil, errors.Wrapf(err, codes.Unavailable, "failed to read package %s", m)
+	} else if len(src) > maxModuleSize {
+		return nil, errors.Newf(codes.ResourceExhausted, "package %s is larger than %d bytes", m, maxModuleSize)
+	}
+	return src, nil
+}
+
+// MapLoader loads modules from memory. The keys
+// are the modules formatted as path@version.
+type MapLoader map[string]string
+
+func (l MapLoader) Load(ctx context.Context, m Module) ([]byte, error) {
+	src, ok := l[m.String()]
+	if !ok {
+		return nil, errors.Newf(codes.NotFound, "package %s not found", m)
+	}
+	return []byte(src), nil
+}
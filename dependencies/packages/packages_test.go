@@ -0,0 +1,153 @@
+package packages_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/dependencies/packages"
+	"github.com/influxdata/flux/internal/errors"
+)
+
+func TestParseManifest(t *testing.T) {
+	m, err := packages.ParseManifest([]byte(`{
+	"registry": "https://registry.example.com/",
+	"dependencies": {
+		"example.com/units": {"version": "v1.2.0"},
+		"github.com/user/lib": {"version": "v0.3.1-rc.1", "url": "https://raw.example.com/user/lib/{version}/lib.flux"}
+	}
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		path string
+		want packages.Module
+	}{
+		{
+			path: "example.com/units",
+			want: packages.Module{
+				Path:    "example.com/units",
+				Version: "v1.2.0",
+				URL:     "https://registry.example.com/example.com/units/@v/v1.2.0.flux",
+			},
+		},
+		{
+			path: "github.com/user/lib",
+			want: packages.Module{
+				Path:    "github.com/user/lib",
+				Version: "v0.3.1-rc.1",
+				URL:     "https://raw.example.com/user/lib/v0.3.1-rc.1/lib.flux",
+			},
+		},
+	} {
+		got, ok := m.Module(tc.path)
+		if !ok {
+			t.Fatalf("expected module for %q", tc.path)
+		}
+		if got != tc.want {
+			t.Errorf("unexpected module -want/+got:\n\t- %v\n\t+ %v", tc.want, got)
+		}
+	}
+	if _, ok := m.Module("strings"); ok {
+		t.Error("unexpected module for strings")
+	}
+}
+
+func TestParseManifest_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "invalid json",
+			data: `{`,
+			want: "invalid flux.json",
+		},
+		{
+			name: "local path",
+			data: `{"registry": "https://registry.example.com", "dependencies": {"./lib": {"version": "v1.0.0"}}}`,
+			want: `invalid import path "./lib"`,
+		},
+		{
+			name: "unpinned",
+			data: `{"registry": "https://registry.example.com", "dependencies": {"example.com/a": {"version": "main"}}}`,
+			want: `package "example.com/a" must pin a version such as v1.0.0, got "main"`,
+		},
+		{
+			name: "no location",
+			data: `{"dependencies": {"example.com/a": {"version": "v1.0.0"}}}`,
+			want: `package "example.com/a" has no url and the manifest has no registry`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := packages.ParseManifest([]byte(tc.data))
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if got := errors.Code(err); got != codes.Invalid {
+				t.Errorf("unexpected error code: %v", got)
+			}
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestHTTPLoader(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/example.com/units/@v/v1.2.0.flux" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = io.WriteString(w, "package units\n")
+	}))
+	defer ts.Close()
+
+	ctx := flux.NewDefaultDependencies().Inject(context.Background())
+	loader := packages.Get(ctx)
+
+	src, err := loader.Load(ctx, packages.Module{
+		Path:    "example.com/units",
+		Version: "v1.2.0",
+		URL:     ts.URL + "/example.com/units/@v/v1.2.0.flux",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := "package units\n", string(src); want != got {
+		t.Errorf("unexpected source -want/+got:\n\t- %q\n\t+ %q", want, got)
+	}
+
+	_, err = loader.Load(ctx, packages.Module{
+		Path:    "example.com/units",
+		Version: "v9.9.9",
+		URL:     ts.URL + "/example.com/units/@v/v9.9.9.flux",
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if got := errors.Code(err); got != codes.NotFound {
+		t.Errorf("unexpected error code: %v", got)
+	}
+}
+
+func TestInject(t *testing.T) {
+	loader := packages.MapLoader{"example.com/a@v1.0.0": "x = 1\n"}
+	ctx := packages.Dependency{Loader: loader}.Inject(context.Background())
+	src, err := packages.Get(ctx).Load(ctx, packages.Module{Path: "example.com/a", Version: "v1.0.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := "x = 1\n", string(src); want != got {
+		t.Errorf("unexpected source -want/+got:\n\t- %q\n\t+ %q", want, got)
+	}
+}
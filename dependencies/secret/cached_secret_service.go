@@ -0,0 +1,65 @@
+package secret
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CachedSecretService caches the secrets found by a Service.
+//
+// Each secret is cached for the TTL so rotated secrets are picked up
+// once the cached value expires. Secrets that are not found and
+// errors are not cached.
+type CachedSecretService struct {
+	Service Service
+	TTL     time.Duration
+
+	mu      sync.Mutex
+	secrets map[string]cachedSecret
+}
+
+type cachedSecret struct {
+	value   string
+	expires time.Time
+}
+
+// NewCachedSecretService creates a CachedSecretService that caches
+// the secrets of the service for the ttl.
+func NewCachedSecretService(s Service, ttl time.Duration) *CachedSecretService {
+	return &CachedSecretService{
+		Service: s,
+		TTL:     ttl,
+	}
+}
+
+func (css *CachedSecretService) LoadSecret(ctx context.Context, k string) (string, error) {
+	now := time.Now()
+	css.mu.Lock()
+	if s, ok := css.secrets[k]; ok && now.Before(s.expires) {
+		css.mu.Unlock()
+		return s.value, nil
+	}
+	css.mu.Unlock()
+
+	v, err := css.Service.LoadSecret(ctx, k)
+	if err != nil {
+		return "", err
+	}
+
+	css.mu.Lock()
+	defer css.mu.Unlock()
+	if css.secrets == nil {
+		css.secrets = make(map[string]cachedSecret)
+	}
+	css.secrets[k] = cachedSecret{value: v, expires: now.Add(css.TTL)}
+	return v, nil
+}
+
+// Flush removes every secret from the cache,
+// for example after the secrets have been rotated.
+func (css *CachedSecretService) Flush() {
+	css.mu.Lock()
+	defer css.mu.Unlock()
+	css.secrets = nil
+}
@@ -0,0 +1,24 @@
+package secret
+
+import (
+	"context"
+
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+)
+
+// ChainedSecretService looks up secrets in each Service in order.
+// The first service that does not report the key as not found
+// provides the secret, so earlier services take precedence.
+type ChainedSecretService []Service
+
+func (css ChainedSecretService) LoadSecret(ctx context.Context, k string) (string, error) {
+	for _, s := range css {
+		v, err := s.LoadSecret(ctx, k)
+		if errors.Code(err) == codes.NotFound {
+			continue
+		}
+		return v, err
+	}
+	return "", errors.Newf(codes.NotFound, "secret key %q not found", k)
+}
@@ -3,12 +3,22 @@ package secret
 import (
 	"context"
 	"os"
+
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
 )
 
 func (ess EnvironmentSecretService) LoadSecret(ctx context.Context, k string) (string, error) {
-	return os.Getenv(k), nil
+	v, ok := os.LookupEnv(ess.Prefix + k)
+	if !ok {
+		return "", errors.Newf(codes.NotFound, "secret key %q not found", k)
+	}
+	return v, nil
 }
 
 // Secret service that retrieve the system environment variables.
+// The secret for a key is the variable named by the key with the Prefix.
+// Unset variables are reported as not found.
 type EnvironmentSecretService struct {
+	Prefix string
 }
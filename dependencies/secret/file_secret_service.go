@@ -0,0 +1,40 @@
+package secret
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+)
+
+// FileSecretService reads secrets from a JSON file
+// that contains an object with the secret values as strings.
+//
+// The file is read for every lookup so changes to the file,
+// such as rotated secrets, are visible immediately.
+// Use a CachedSecretService to avoid reading the file for every lookup.
+type FileSecretService struct {
+	Path string
+}
+
+func (fss FileSecretService) LoadSecret(ctx context.Context, k string) (string, error) {
+	data, err := ioutil.ReadFile(fss.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", errors.Newf(codes.NotFound, "secret key %q not found: secrets file %q does not exist", k, fss.Path)
+		}
+		return "", errors.Wrapf(err, codes.Internal, "cannot read secrets file %q", fss.Path)
+	}
+	var secrets map[string]string
+	if err := json.Unmarshal(data, &secrets); err != nil {
+		return "", errors.Wrapf(err, codes.Invalid, "secrets file %q must contain a JSON object of strings", fss.Path)
+	}
+	v, ok := secrets[k]
+	if !ok {
+		return "", errors.Newf(codes.NotFound, "secret key %q not found", k)
+	}
+	return v, nil
+}
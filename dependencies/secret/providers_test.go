@@ -0,0 +1,214 @@
+package secret_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/dependencies/secret"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/mock"
+)
+
+func TestEnvironmentSecretService(t *testing.T) {
+	t.Setenv("FLUX_TEST_SECRET_token", "abc")
+	ss := secret.EnvironmentSecretService{Prefix: "FLUX_TEST_SECRET_"}
+	if v, err := ss.LoadSecret(context.Background(), "token"); err != nil || v != "abc" {
+		t.Errorf("unexpected secret %q, error %v", v, err)
+	}
+	if _, err := ss.LoadSecret(context.Background(), "missing"); errors.Code(err) != codes.NotFound {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestFileSecretService(t *testing.T) {
+	fpath := filepath.Join(t.TempDir(), "secrets.json")
+	if err := ioutil.WriteFile(fpath, []byte(`{"token": "abc"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	ss := secret.FileSecretService{Path: fpath}
+	if v, err := ss.LoadSecret(context.Background(), "token"); err != nil || v != "abc" {
+		t.Errorf("unexpected secret %q, error %v", v, err)
+	}
+	if _, err := ss.LoadSecret(context.Background(), "missing"); errors.Code(err) != codes.NotFound {
+		t.Errorf("expected not found error, got %v", err)
+	}
+
+	// Rotated secrets are read from the file.
+	if err := ioutil.WriteFile(fpath, []byte(`{"token": "def"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := ss.LoadSecret(context.Background(), "token"); err != nil || v != "def" {
+		t.Errorf("unexpected secret %q, error %v", v, err)
+	}
+
+	if err := ioutil.WriteFile(fpath, []byte(`{"token": 1}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ss.LoadSecret(context.Background(), "token"); errors.Code(err) != codes.Invalid {
+		t.Errorf("expected invalid error, got %v", err)
+	}
+
+	if err := os.Remove(fpath); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ss.LoadSecret(context.Background(), "token"); errors.Code(err) != codes.NotFound {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestVaultSecretService(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/flux":
+			_, _ = w.Write([]byte(`{"data": {"data": {"token": "abc", "port": 1}, "metadata": {"version": 3}}}`))
+		case "/v1/kv/flux":
+			_, _ = w.Write([]byte(`{"data": {"token": "def"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	for _, tc := range []struct {
+		name    string
+		ss      secret.VaultSecretService
+		key     string
+		want    string
+		wantErr codes.Code
+	}{
+		{
+			name: "kv v2",
+			ss:   secret.VaultSecretService{Address: ts.URL, Token: "root", Path: "flux"},
+			key:  "token",
+			want: "abc",
+		},
+		{
+			name: "kv v1",
+			ss:   secret.VaultSecretService{Address: ts.URL, Token: "root", Mount: "kv", Path: "flux", KVVersion: 1},
+			key:  "token",
+			want: "def",
+		},
+		{
+			name:    "missing field",
+			ss:      secret.VaultSecretService{Address: ts.URL, Token: "root", Path: "flux"},
+			key:     "missing",
+			wantErr: codes.NotFound,
+		},
+		{
+			name:    "missing secret",
+			ss:      secret.VaultSecretService{Address: ts.URL, Token: "root", Path: "other"},
+			key:     "token",
+			wantErr: codes.NotFound,
+		},
+		{
+			name:    "not a string",
+			ss:      secret.VaultSecretService{Address: ts.URL, Token: "root", Path: "flux"},
+			key:     "port",
+			wantErr: codes.Invalid,
+		},
+		{
+			name:    "permission denied",
+			ss:      secret.VaultSecretService{Address: ts.URL, Token: "invalid", Path: "flux"},
+			key:     "token",
+			wantErr: codes.PermissionDenied,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := tc.ss.LoadSecret(context.Background(), tc.key)
+			if got := errors.Code(err); err != nil && got != tc.wantErr {
+				t.Fatalf("unexpected error code: want %v, got %v (%v)", tc.wantErr, got, err)
+			} else if err == nil && tc.wantErr != codes.Inherit {
+				t.Fatalf("expected error with code %v", tc.wantErr)
+			}
+			if v != tc.want {
+				t.Errorf("unexpected secret: want %q, got %q", tc.want, v)
+			}
+		})
+	}
+}
+
+func TestChainedSecretService(t *testing.T) {
+	ss := secret.ChainedSecretService{
+		mock.SecretService{"a": "first"},
+		mock.SecretService{"a": "second", "b": "second"},
+	}
+	for k, want := range map[string]string{"a": "first", "b": "second"} {
+		if v, err := ss.LoadSecret(context.Background(), k); err != nil || v != want {
+			t.Errorf("unexpected secret for %s: want %q, got %q, error %v", k, want, v, err)
+		}
+	}
+	if _, err := ss.LoadSecret(context.Background(), "c"); errors.Code(err) != codes.NotFound {
+		t.Errorf("expected not found error, got %v", err)
+	}
+
+	// Errors other than not found stop the lookup.
+	fss := secret.FileSecretService{Path: t.TempDir()}
+	ss = secret.ChainedSecretService{fss, mock.SecretService{"a": "first"}}
+	if _, err := ss.LoadSecret(context.Background(), "a"); err == nil {
+		t.Error("expected error from the first service")
+	}
+}
+
+type countingService struct {
+	secret.Service
+	n int
+}
+
+func (s *countingService) LoadSecret(ctx context.Context, k string) (string, error) {
+	s.n++
+	return s.Service.LoadSecret(ctx, k)
+}
+
+func TestCachedSecretService(t *testing.T) {
+	secrets := mock.SecretService{"a": "first"}
+	counter := &countingService{Service: secrets}
+	ss := secret.NewCachedSecretService(counter, time.Hour)
+
+	for i := 0; i < 3; i++ {
+		if v, err := ss.LoadSecret(context.Background(), "a"); err != nil || v != "first" {
+			t.Fatalf("unexpected secret %q, error %v", v, err)
+		}
+	}
+	if counter.n != 1 {
+		t.Errorf("expected one lookup, got %d", counter.n)
+	}
+
+	// Missing secrets are not cached.
+	for i := 0; i < 2; i++ {
+		if _, err := ss.LoadSecret(context.Background(), "b"); errors.Code(err) != codes.NotFound {
+			t.Fatalf("expected not found error, got %v", err)
+		}
+	}
+	if counter.n != 3 {
+		t.Errorf("expected three lookups, got %d", counter.n)
+	}
+
+	secrets["a"] = "rotated"
+	ss.Flush()
+	if v, err := ss.LoadSecret(context.Background(), "a"); err != nil || v != "rotated" {
+		t.Errorf("unexpected secret %q, error %v", v, err)
+	}
+
+	// Secrets expire after the ttl.
+	ss.TTL = 0
+	ss.Flush()
+	if _, err := ss.LoadSecret(context.Background(), "a"); err != nil {
+		t.Fatal(err)
+	}
+	secrets["a"] = "expired"
+	if v, err := ss.LoadSecret(context.Background(), "a"); err != nil || v != "expired" {
+		t.Errorf("unexpected secret %q, error %v", v, err)
+	}
+}
@@ -0,0 +1,117 @@
+package secret
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/influxdata/flux/codes"
+	fluxhttp "github.com/influxdata/flux/dependencies/http"
+	"github.com/influxdata/flux/internal/errors"
+)
+
+// maxVaultResponse is the maximum size of a response read from Vault.
+const maxVaultResponse = 1024 * 1024
+
+// VaultSecretService reads secrets from the key/value secrets engine of HashiCorp Vault.
+//
+// The secrets are the fields of the Vault secret at Path in the engine mounted at Mount.
+// The secret is read for every lookup, so use a CachedSecretService
+// to limit the requests sent to Vault.
+type VaultSecretService struct {
+	// Address is the URL of the Vault server, such as https://vault:8200.
+	Address string
+	// Token is the Vault token used to authenticate requests.
+	Token string
+	// Namespace is the Vault Enterprise namespace. It is optional.
+	Namespace string
+	// Mount is the path the secrets engine is mounted at.
+	// It defaults to "secret".
+	Mount string
+	// Path is the path of the secret within the secrets engine.
+	Path string
+	// KVVersion is the version of the key/value secrets engine, 1 or 2.
+	// It defaults to 2.
+	KVVersion int
+	// Client sends the requests. It defaults to http.DefaultClient.
+	Client fluxhttp.Client
+}
+
+func (vss VaultSecretService) LoadSecret(ctx context.Context, k string) (string, error) {
+	mount := vss.Mount
+	if mount == "" {
+		mount = "secret"
+	}
+	p := strings.Trim(mount, "/") + "/"
+	if vss.KVVersion != 1 {
+		p += "data/"
+	}
+	p += strings.Trim(vss.Path, "/")
+
+	req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(vss.Address, "/")+"/v1/"+p, nil)
+	if err != nil {
+		return "", errors.Wrap(err, codes.Invalid, "invalid vault request")
+	}
+	req.Header.Set("X-Vault-Token", vss.Token)
+	if vss.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", vss.Namespace)
+	}
+
+	var client fluxhttp.Client = http.DefaultClient
+	if vss.Client != nil {
+		client = vss.Client
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return "", errors.Wrap(err, codes.Unavailable, "vault request failed")
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxVaultResponse))
+	if err != nil {
+		return "", errors.Wrap(err, codes.Unavailable, "cannot read vault response")
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return "", errors.Newf(codes.NotFound, "secret key %q not found: vault secret %q does not exist", k, p)
+	case http.StatusForbidden:
+		return "", errors.Newf(codes.PermissionDenied, "permission denied reading vault secret %q", p)
+	default:
+		return "", errors.Newf(codes.Unavailable, "cannot read vault secret %q: %s: %s", p, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var secret struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return "", errors.Wrap(err, codes.Internal, "invalid vault response")
+	}
+	data := secret.Data
+	if vss.KVVersion != 1 {
+		// Version 2 of the engine wraps the fields with the metadata of the secret.
+		var versioned struct {
+			Data json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(data, &versioned); err != nil {
+			return "", errors.Wrap(err, codes.Internal, "invalid vault response")
+		}
+		data = versioned.Data
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return "", errors.Wrap(err, codes.Internal, "invalid vault response")
+	}
+	v, ok := fields[k]
+	if !ok {
+		return "", errors.Newf(codes.NotFound, "secret key %q not found", k)
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", errors.Newf(codes.Invalid, "vault secret field %q is not a string", k)
+	}
+	return s, nil
+}
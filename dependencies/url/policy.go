@@ -0,0 +1,243 @@
+package url
+
+import (
+	"net"
+	"net/url"
+	"strings"
+	"syscall"
+
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+)
+
+// PackageValidator is a Validator that can use a different
+// validator for the URLs of a specific Flux package.
+type PackageValidator interface {
+	Validator
+	// ForPackage returns the Validator for the package with the import path.
+	ForPackage(pkg string) Validator
+}
+
+// ForPackage returns the Validator to use for the URLs of the
+// Flux package with the import path, such as "sql" or "http".
+// Validators that do not implement PackageValidator are returned as is.
+func ForPackage(v Validator, pkg string) Validator {
+	if pv, ok := v.(PackageValidator); ok {
+		return pv.ForPackage(pkg)
+	}
+	return v
+}
+
+// DialControl returns a function for the Control field of a net.Dialer
+// that validates the IP of every connection.
+//
+// The control function is called after the host has been resolved,
+// so it also rejects hosts that passed validation and later
+// resolve to a forbidden IP, as done by DNS rebinding attacks.
+func DialControl(v Validator) func(network, address string, c syscall.RawConn) error {
+	return func(network, address string, c syscall.RawConn) error {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return err
+		}
+
+		ip := net.ParseIP(host)
+		return v.ValidateIP(ip)
+	}
+}
+
+// PolicyConfig configures a Policy.
+// Empty lists do not restrict URLs.
+type PolicyConfig struct {
+	// AllowedSchemes are the only URL schemes that are allowed, such as "https".
+	AllowedSchemes []string `json:"allowedSchemes,omitempty"`
+	// DeniedSchemes are URL schemes that are not allowed.
+	DeniedSchemes []string `json:"deniedSchemes,omitempty"`
+
+	// AllowedHosts are the only hosts that are allowed.
+	// A host that starts with "*." matches all of its subdomains.
+	AllowedHosts []string `json:"allowedHosts,omitempty"`
+	// DeniedHosts are hosts that are not allowed.
+	// A host that starts with "*." matches all of its subdomains.
+	DeniedHosts []string `json:"deniedHosts,omitempty"`
+
+	// AllowedNetworks are CIDR ranges of the only IPs that are allowed.
+	// IPs in the allowed networks are allowed even if they are private.
+	AllowedNetworks []string `json:"allowedNetworks,omitempty"`
+	// DeniedNetworks are CIDR ranges of IPs that are not allowed.
+	DeniedNetworks []string `json:"deniedNetworks,omitempty"`
+	// DenyPrivate denies the loopback, link local and private IP ranges.
+	DenyPrivate bool `json:"denyPrivate,omitempty"`
+
+	// Packages maps Flux package import paths to the policy used
+	// instead of this policy for the URLs of the package.
+	//
+	// The connections of the shared HTTP client are validated with the
+	// root policy, since the client does not know the calling package.
+	Packages map[string]PolicyConfig `json:"packages,omitempty"`
+}
+
+// Policy is a Validator that validates URLs with the rules of a PolicyConfig.
+//
+// A URL is valid when its scheme and host are allowed and every IP
+// the host resolves to is allowed. Denied entries take precedence
+// over allowed entries.
+type Policy struct {
+	allowedSchemes  []string
+	deniedSchemes   []string
+	allowedHosts    []string
+	deniedHosts     []string
+	allowedNetworks []*net.IPNet
+	deniedNetworks  []*net.IPNet
+	denyPrivate     bool
+	packages        map[string]*Policy
+}
+
+var _ PackageValidator = (*Policy)(nil)
+
+// NewPolicy creates a Policy from the configuration.
+func NewPolicy(c PolicyConfig) (*Policy, error) {
+	p := &Policy{
+		allowedSchemes: lowerAll(c.AllowedSchemes),
+		deniedSchemes:  lowerAll(c.DeniedSchemes),
+		allowedHosts:   lowerAll(c.AllowedHosts),
+		deniedHosts:    lowerAll(c.DeniedHosts),
+		denyPrivate:    c.DenyPrivate,
+	}
+	var err error
+	if p.allowedNetworks, err = parseCIDRs(c.AllowedNetworks); err != nil {
+		return nil, err
+	}
+	if p.deniedNetworks, err = parseCIDRs(c.DeniedNetworks); err != nil {
+		return nil, err
+	}
+	if len(c.Packages) > 0 {
+		p.packages = make(map[string]*Policy, len(c.Packages))
+		for pkg, pc := range c.Packages {
+			if len(pc.Packages) > 0 {
+				return nil, errors.Newf(codes.Invalid, "policy for package %q cannot have package policies", pkg)
+			}
+			pp, err := NewPolicy(pc)
+			if err != nil {
+				return nil, errors.Wrapf(err, codes.Inherit, "invalid policy for package %q", pkg)
+			}
+			p.packages[pkg] = pp
+		}
+	}
+	return p, nil
+}
+
+// ForPackage returns the policy for the package,
+// or this policy if the package does not have its own policy.
+func (p *Policy) ForPackage(pkg string) Validator {
+	if pp, ok := p.packages[pkg]; ok {
+		return pp
+	}
+	return p
+}
+
+func (p *Policy) Validate(u *url.URL) error {
+	scheme := strings.ToLower(u.Scheme)
+	if contains(p.deniedSchemes, scheme) || (len(p.allowedSchemes) > 0 && !contains(p.allowedSchemes, scheme)) {
+		return errors.Newf(codes.Invalid, "url scheme %q is not allowed", u.Scheme)
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if matchHost(p.deniedHosts, host) || (len(p.allowedHosts) > 0 && !matchHost(p.allowedHosts, host)) {
+		return errors.Newf(codes.Invalid, "host %q is not allowed", u.Hostname())
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		return p.ValidateIP(ip)
+	}
+	if !p.restrictsIPs() {
+		return nil
+	}
+	ips, err := net.LookupIP(host)
+	if err != nil {
+		return err
+	}
+	for _, ip := range ips {
+		if err := p.ValidateIP(ip); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Policy) ValidateIP(ip net.IP) error {
+	if p.allowsIP(ip) {
+		return nil
+	}
+	// Intentionally return a vague message that we cannot connect to the host.
+	// Do not explain why.
+	return errors.New(codes.Invalid, "no such host")
+}
+
+func (p *Policy) allowsIP(ip net.IP) bool {
+	if containsIP(p.deniedNetworks, ip) {
+		return false
+	}
+	if len(p.allowedNetworks) > 0 {
+		return containsIP(p.allowedNetworks, ip)
+	}
+	return !p.denyPrivate || !isPrivateIP(ip)
+}
+
+func (p *Policy) restrictsIPs() bool {
+	return p.denyPrivate || len(p.allowedNetworks) > 0 || len(p.deniedNetworks) > 0
+}
+
+// matchHost reports whether the host matches one of the patterns.
+func matchHost(patterns []string, host string) bool {
+	for _, pattern := range patterns {
+		if pattern == host {
+			return true
+		}
+		if strings.HasPrefix(pattern, "*.") && strings.HasSuffix(host, pattern[1:]) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsIP(networks []*net.IPNet, ip net.IP) bool {
+	for _, n := range networks {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func lowerAll(list []string) []string {
+	if len(list) == 0 {
+		return nil
+	}
+	lower := make([]string, len(list))
+	for i, s := range list {
+		lower[i] = strings.ToLower(s)
+	}
+	return lower
+}
+
+func parseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, errors.Wrapf(err, codes.Invalid, "invalid network %q", cidr)
+		}
+		networks = append(networks, n)
+	}
+	return networks, nil
+}
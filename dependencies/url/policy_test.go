@@ -0,0 +1,106 @@
+package url_test
+
+import (
+	"net"
+	nurl "net/url"
+	"testing"
+
+	"github.com/influxdata/flux/dependencies/url"
+)
+
+func TestPolicy(t *testing.T) {
+	p, err := url.NewPolicy(url.PolicyConfig{
+		AllowedSchemes:  []string{"https", "postgres"},
+		DeniedHosts:     []string{"*.internal.example.com", "Metadata"},
+		DeniedNetworks:  []string{"1.1.1.0/24"},
+		AllowedNetworks: []string{"10.1.0.0/16", "8.8.8.0/24"},
+		Packages: map[string]url.PolicyConfig{
+			"sql": {
+				AllowedSchemes: []string{"postgres"},
+				DenyPrivate:    true,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		pkg   string
+		url   string
+		valid bool
+	}{
+		{url: "https://10.1.2.3", valid: true},
+		{url: "HTTPS://8.8.8.8:443/path", valid: true},
+		{url: "http://10.1.2.3", valid: false},
+		{url: "ftp://10.1.2.3", valid: false},
+		{url: "https://10.2.0.1", valid: false},
+		{url: "https://1.1.1.1", valid: false},
+		{url: "https://db.internal.example.com", valid: false},
+		{url: "https://metadata", valid: false},
+		{url: "https://localhost", valid: false},
+		{pkg: "http", url: "https://10.1.2.3", valid: true},
+		{pkg: "sql", url: "postgres://8.8.8.8:5432/db", valid: true},
+		{pkg: "sql", url: "postgres://10.1.2.3:5432/db", valid: false},
+		{pkg: "sql", url: "https://8.8.8.8", valid: false},
+		{pkg: "sql", url: "postgres://127.0.0.1:5432/db", valid: false},
+	}
+	for _, tc := range testCases {
+		u, err := nurl.Parse(tc.url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		v := url.Validator(p)
+		if tc.pkg != "" {
+			v = url.ForPackage(p, tc.pkg)
+		}
+		err = v.Validate(u)
+		if tc.valid && err != nil {
+			t.Errorf("%s %s: expected valid url, got error: %v", tc.pkg, tc.url, err)
+		} else if !tc.valid && err == nil {
+			t.Errorf("%s %s: expected invalid url", tc.pkg, tc.url)
+		}
+	}
+}
+
+func TestPolicy_DenyPrivate(t *testing.T) {
+	p, err := url.NewPolicy(url.PolicyConfig{DenyPrivate: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for ip, valid := range map[string]bool{
+		"127.0.0.1":   false,
+		"192.168.1.1": false,
+		"fe80::1":     false,
+		"1.1.1.1":     true,
+	} {
+		err := p.ValidateIP(net.ParseIP(ip))
+		if valid && err != nil {
+			t.Errorf("%s: expected valid ip, got error: %v", ip, err)
+		} else if !valid && err == nil {
+			t.Errorf("%s: expected invalid ip", ip)
+		}
+	}
+
+	// Hosts that resolve to a private IP are rejected
+	// when the connection is made.
+	control := url.DialControl(p)
+	if err := control("tcp", "127.0.0.1:80", nil); err == nil {
+		t.Error("expected dial to a private ip to fail")
+	}
+	if err := control("tcp", "1.1.1.1:80", nil); err != nil {
+		t.Errorf("unexpected error dialing a public ip: %v", err)
+	}
+}
+
+func TestNewPolicy_Invalid(t *testing.T) {
+	for _, c := range []url.PolicyConfig{
+		{AllowedNetworks: []string{"10.0.0.0"}},
+		{Packages: map[string]url.PolicyConfig{"sql": {DeniedNetworks: []string{"invalid"}}}},
+		{Packages: map[string]url.PolicyConfig{"sql": {Packages: map[string]url.PolicyConfig{"http": {}}}}},
+	} {
+		if _, err := url.NewPolicy(c); err == nil {
+			t.Errorf("expected error for config %+v", c)
+		}
+	}
+}
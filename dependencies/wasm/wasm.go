@@ -0,0 +1,201 @@
+// Package wasm provides the dependency that runs WebAssembly modules for
+// the experimental/wasm package.
+//
+// Flux does not include a WebAssembly implementation. The program that
+// embeds Flux provides a Runtime that wraps the implementation of its choice.
+package wasm
+
+import (
+	"context"
+	"math"
+	"sync"
+
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/dependency"
+	"github.com/influxdata/flux/internal/errors"
+)
+
+// ValueType is the type of a WebAssembly value.
+type ValueType byte
+
+const (
+	ValueTypeI32 ValueType = iota + 1
+	ValueTypeI64
+	ValueTypeF32
+	ValueTypeF64
+)
+
+func (t ValueType) String() string {
+	switch t {
+	case ValueTypeI32:
+		return "i32"
+	case ValueTypeI64:
+		return "i64"
+	case ValueTypeF32:
+		return "f32"
+	case ValueTypeF64:
+		return "f64"
+	default:
+		return "unknown"
+	}
+}
+
+// Runtime compiles and instantiates WebAssembly modules.
+type Runtime interface {
+	// Instantiate compiles the binary encoding of a module
+	// and returns an instance of the module.
+	Instantiate(ctx context.Context, name string, code []byte) (Module, error)
+}
+
+// Module is an instance of a WebAssembly module.
+type Module interface {
+	// Function returns the exported function with the name.
+	Function(name string) (Function, bool)
+
+	// Close releases the resources of the module.
+	Close(ctx context.Context) error
+}
+
+// Function is a function exported by a Module.
+type Function interface {
+	// ParamTypes returns the types of the parameters of the function.
+	ParamTypes() []ValueType
+
+	// ResultTypes returns the types of the results of the function.
+	ResultTypes() []ValueType
+
+	// Call invokes the function. Each parameter and result is a value
+	// of the corresponding type encoded with EncodeI32, EncodeI64,
+	// EncodeF32 or EncodeF64.
+	Call(ctx context.Context, params ...uint64) ([]uint64, error)
+}
+
+// EncodeI32 encodes an i32 value.
+func EncodeI32(v int32) uint64 { return uint64(uint32(v)) }
+
+// EncodeI64 encodes an i64 value.
+func EncodeI64(v int64) uint64 { return uint64(v) }
+
+// EncodeF32 encodes an f32 value.
+func EncodeF32(v float32) uint64 { return uint64(math.Float32bits(v)) }
+
+// EncodeF64 encodes an f64 value.
+func EncodeF64(v float64) uint64 { return math.Float64bits(v) }
+
+// DecodeI32 decodes an i32 value.
+func DecodeI32(v uint64) int32 { return int32(uint32(v)) }
+
+// DecodeI64 decodes an i64 value.
+func DecodeI64(v uint64) int64 { return int64(v) }
+
+// DecodeF32 decodes an f32 value.
+func DecodeF32(v uint64) float32 { return math.Float32frombits(uint32(v)) }
+
+// DecodeF64 decodes an f64 value.
+func DecodeF64(v uint64) float64 { return math.Float64frombits(v) }
+
+type key int
+
+const runtimeKey key = iota
+
+// Dependency will inject the Runtime into the dependency chain.
+type Dependency struct {
+	Runtime Runtime
+}
+
+// Inject will inject the Runtime into the dependency chain.
+func (d Dependency) Inject(ctx context.Context) context.Context {
+	if d.Runtime == nil {
+		return ctx
+	}
+	return Inject(ctx, d.Runtime)
+}
+
+// Inject will inject the Runtime into the context. The modules
+// instantiated by a query are shared by the calls in the query and
+// are closed when the query finishes.
+func Inject(ctx context.Context, runtime Runtime) context.Context {
+	cache := &moduleCache{
+		ctx:     ctx,
+		runtime: runtime,
+		modules: make(map[string]*CachedModule),
+	}
+	dependency.OnFinish(ctx, cache)
+	return context.WithValue(ctx, runtimeKey, cache)
+}
+
+// GetModule returns an instance of the module with the name. The module is
+// instantiated from the code returned by load the first time it is requested
+// in a query.
+func GetModule(ctx context.Context, name string, load func() ([]byte, error)) (*CachedModule, error) {
+	cache, ok := ctx.Value(runtimeKey).(*moduleCache)
+	if !ok {
+		return nil, errors.New(codes.Unimplemented, "no WebAssembly runtime has been provided")
+	}
+	return cache.get(ctx, name, load)
+}
+
+// CachedModule is a Module that is shared by the calls in a query.
+// Calls to its functions are serialized because module instances
+// are not safe to use concurrently.
+type CachedModule struct {
+	mu     sync.Mutex
+	module Module
+}
+
+// Call invokes the exported function with the name.
+func (m *CachedModule) Call(ctx context.Context, name string, fn func(f Function) error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, ok := m.module.Function(name)
+	if !ok {
+		return errors.Newf(codes.NotFound, "WebAssembly module does not export a function named %q", name)
+	}
+	return fn(f)
+}
+
+// moduleCache holds the modules instantiated in a query.
+type moduleCache struct {
+	ctx     context.Context
+	runtime Runtime
+
+	mu      sync.Mutex
+	modules map[string]*CachedModule
+}
+
+func (c *moduleCache) get(ctx context.Context, name string, load func() ([]byte, error)) (*CachedModule, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if m, ok := c.modules[name]; ok {
+		return m, nil
+	}
+
+	code, err := load()
+	if err != nil {
+		return nil, err
+	}
+	module, err := c.runtime.Instantiate(ctx, name, code)
+	if err != nil {
+		return nil, errors.Wrapf(err, codes.Invalid, "failed to instantiate WebAssembly module %q", name)
+	}
+	m := &CachedModule{module: module}
+	c.modules[name] = m
+	return m, nil
+}
+
+// Close closes the modules instantiated in the query.
+func (c *moduleCache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var err error
+	for name, m := range c.modules {
+		if e := m.module.Close(c.ctx); e != nil && err == nil {
+			err = e
+		}
+		delete(c.modules, name)
+	}
+	return err
+}
@@ -0,0 +1,130 @@
+package wasm_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/dependencies/wasm"
+	"github.com/influxdata/flux/dependency"
+	"github.com/influxdata/flux/internal/errors"
+)
+
+type mockRuntime struct {
+	instantiated []string
+	closed       []string
+}
+
+func (r *mockRuntime) Instantiate(ctx context.Context, name string, code []byte) (wasm.Module, error) {
+	r.instantiated = append(r.instantiated, name)
+	return &mockModule{runtime: r, name: name}, nil
+}
+
+type mockModule struct {
+	runtime *mockRuntime
+	name    string
+}
+
+func (m *mockModule) Function(name string) (wasm.Function, bool) {
+	if name != "add" {
+		return nil, false
+	}
+	return addFunction{}, true
+}
+
+func (m *mockModule) Close(ctx context.Context) error {
+	m.runtime.closed = append(m.runtime.closed, m.name)
+	return nil
+}
+
+type addFunction struct{}
+
+func (addFunction) ParamTypes() []wasm.ValueType {
+	return []wasm.ValueType{wasm.ValueTypeI64, wasm.ValueTypeI64}
+}
+
+func (addFunction) ResultTypes() []wasm.ValueType {
+	return []wasm.ValueType{wasm.ValueTypeI64}
+}
+
+func (addFunction) Call(ctx context.Context, params ...uint64) ([]uint64, error) {
+	return []uint64{wasm.EncodeI64(wasm.DecodeI64(params[0]) + wasm.DecodeI64(params[1]))}, nil
+}
+
+func TestGetModule(t *testing.T) {
+	rt := &mockRuntime{}
+	ctx, span := dependency.Inject(context.Background(), wasm.Dependency{Runtime: rt})
+
+	loads := 0
+	load := func() ([]byte, error) {
+		loads++
+		return []byte("code"), nil
+	}
+	for i := 0; i < 3; i++ {
+		m, err := wasm.GetModule(ctx, "add.wasm", load)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got int64
+		if err := m.Call(ctx, "add", func(f wasm.Function) error {
+			res, err := f.Call(ctx, wasm.EncodeI64(2), wasm.EncodeI64(int64(i)))
+			if err != nil {
+				return err
+			}
+			got = wasm.DecodeI64(res[0])
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if want := int64(2 + i); got != want {
+			t.Errorf("unexpected result -want/+got:\n\t- %d\n\t+ %d", want, got)
+		}
+	}
+
+	if loads != 1 {
+		t.Errorf("expected the module to be loaded once, got %d", loads)
+	}
+	if len(rt.instantiated) != 1 {
+		t.Errorf("expected the module to be instantiated once, got %d", len(rt.instantiated))
+	}
+	if len(rt.closed) != 0 {
+		t.Fatalf("module closed before the query finished")
+	}
+	span.Finish()
+	if len(rt.closed) != 1 {
+		t.Errorf("expected the module to be closed when the query finished, got %d", len(rt.closed))
+	}
+}
+
+func TestGetModule_MissingFunction(t *testing.T) {
+	ctx, span := dependency.Inject(context.Background(), wasm.Dependency{Runtime: &mockRuntime{}})
+	defer span.Finish()
+
+	m, err := wasm.GetModule(ctx, "add.wasm", func() ([]byte, error) {
+		return []byte("code"), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = m.Call(ctx, "sub", func(f wasm.Function) error {
+		t.Fatal("unexpected call")
+		return nil
+	})
+	if got, want := errors.Code(err), codes.NotFound; got != want {
+		t.Errorf("unexpected error code -want/+got:\n\t- %v\n\t+ %v", want, got)
+	}
+}
+
+func TestGetModule_NoRuntime(t *testing.T) {
+	ctx, span := dependency.Inject(context.Background(), wasm.Dependency{})
+	defer span.Finish()
+
+	_, err := wasm.GetModule(ctx, "add.wasm", func() ([]byte, error) {
+		t.Fatal("unexpected load")
+		return nil, nil
+	})
+	if got, want := errors.Code(err), codes.Unimplemented; got != want {
+		t.Errorf("unexpected error code -want/+got:\n\t- %v\n\t+ %v", want, got)
+	}
+}
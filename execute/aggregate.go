@@ -14,6 +14,7 @@ import (
 	"github.com/influxdata/flux/internal/feature"
 	fluxmemory "github.com/influxdata/flux/memory"
 	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/values"
 )
 
 // AggregateTransformation implements a transformation that aggregates
@@ -368,6 +369,7 @@ func (s *aggregateParallelState) Close() error {
 type SimpleAggregateConfig struct {
 	plan.DefaultCost
 	Columns []string `json:"columns"`
+	Nulls   NullMode `json:"nulls"`
 }
 
 var DefaultSimpleAggregateConfig = SimpleAggregateConfig{
@@ -391,6 +393,12 @@ func (c *SimpleAggregateConfig) ReadArgs(args flux.Arguments) error {
 	} else {
 		c.Columns = DefaultSimpleAggregateConfig.Columns
 	}
+
+	nulls, err := ReadNullMode(args)
+	if err != nil {
+		return err
+	}
+	c.Nulls = nulls
 	return nil
 }
 
@@ -427,6 +435,7 @@ func NewSimpleAggregateTransformation(ctx context.Context, id DatasetID, agg Sim
 		cache:  cache,
 		agg:    agg,
 		config: config,
+		mem:    alloc,
 	}, d, nil
 }
 
@@ -435,6 +444,7 @@ type simpleAggregateTransformation struct {
 	d     Dataset
 	cache TableBuilderCache
 	agg   SimpleAggregate
+	mem   memory.Allocator
 
 	config SimpleAggregateConfig
 }
@@ -485,6 +495,10 @@ func (t *simpleAggregateTransformation) Process(id DatasetID, tbl flux.Table) er
 			vf = t.agg.NewFloatAgg()
 		case flux.TString:
 			vf = t.agg.NewStringAgg()
+		case flux.TDuration:
+			if agg, ok := t.agg.(DurationAggregate); ok {
+				vf = agg.NewDurationAgg()
+			}
 		}
 		if vf == nil {
 			return errors.Newf(codes.FailedPrecondition, "unsupported aggregate column type %v", c.Type)
@@ -502,27 +516,17 @@ func (t *simpleAggregateTransformation) Process(id DatasetID, tbl flux.Table) er
 		tableColMap[j] = idx
 	}
 
+	hasNull := make([]bool, len(t.config.Columns))
 	if err := tbl.Do(func(cr flux.ColReader) error {
 		for j := range t.config.Columns {
-			vf := aggregates[j]
-
-			tj := tableColMap[j]
-			c := tbl.Cols()[tj]
-
-			switch c.Type {
-			case flux.TBool:
-				vf.(DoBoolAgg).DoBool(cr.Bools(tj))
-			case flux.TInt:
-				vf.(DoIntAgg).DoInt(cr.Ints(tj))
-			case flux.TUInt:
-				vf.(DoUIntAgg).DoUInt(cr.UInts(tj))
-			case flux.TFloat:
-				vf.(DoFloatAgg).DoFloat(cr.Floats(tj))
-			case flux.TString:
-				vf.(DoStringAgg).DoString(cr.Strings(tj))
-			default:
-				return errors.Newf(codes.Invalid, "unsupported aggregate type %v", c.Type)
+			if hasNull[j] && t.config.Nulls == NullModePropagate {
+				continue
+			}
+			ok, err := doAggregate(aggregates[j], table.Values(cr, tableColMap[j]), t.config.Nulls, t.mem)
+			if err != nil {
+				return err
 			}
+			hasNull[j] = hasNull[j] || ok
 		}
 		return nil
 	}); err != nil {
@@ -532,7 +536,7 @@ func (t *simpleAggregateTransformation) Process(id DatasetID, tbl flux.Table) er
 		bj := builderColMap[j]
 
 		// If the value is null, append a null to the column.
-		if vf.IsNull() {
+		if vf.IsNull() || (hasNull[j] && t.config.Nulls == NullModePropagate) {
 			if err := builder.AppendNil(bj); err != nil {
 				return err
 			}
@@ -571,6 +575,11 @@ func (t *simpleAggregateTransformation) Process(id DatasetID, tbl flux.Table) er
 			if err := builder.AppendString(bj, v); err != nil {
 				return err
 			}
+		case flux.TDuration:
+			v := vf.(DurationValueFunc).ValueDuration()
+			if err := builder.AppendDuration(bj, v); err != nil {
+				return err
+			}
 		}
 		if vf, ok := vf.(Closer); ok {
 			if err := vf.Close(); err != nil {
@@ -603,6 +612,9 @@ type aggregateState struct {
 
 	// agg holds the aggregate function and associated state to produce a value.
 	agg ValueFunc
+
+	// hasNull is set when a null value has been read from the input.
+	hasNull bool
 }
 
 func (s *aggregateState) Close() error {
@@ -648,6 +660,10 @@ func (t *simpleAggregateTransformation2) initializeState(chunk table.Chunk, curr
 			vf = t.agg.NewFloatAgg()
 		case flux.TString:
 			vf = t.agg.NewStringAgg()
+		case flux.TDuration:
+			if agg, ok := t.agg.(DurationAggregate); ok {
+				vf = agg.NewDurationAgg()
+			}
 		default:
 			return nil, errors.Newf(codes.FailedPrecondition, "unsupported aggregate column type %v", col.Type)
 		}
@@ -677,24 +693,14 @@ func (t *simpleAggregateTransformation2) Aggregate(chunk table.Chunk, state inte
 			return nil, false, errors.Newf(codes.FailedPrecondition, "aggregate type conflict: %s != %s", c.Type, inType)
 		}
 
-		agg := aggregates[j].agg
-		switch c.Type {
-		case flux.TBool:
-			agg.(DoBoolAgg).DoBool(chunk.Bools(idx))
-		case flux.TInt:
-			agg.(DoIntAgg).DoInt(chunk.Ints(idx))
-		case flux.TUInt:
-			agg.(DoUIntAgg).DoUInt(chunk.Uints(idx))
-		case flux.TFloat:
-			agg.(DoFloatAgg).DoFloat(chunk.Floats(idx))
-		case flux.TString:
-			agg.(DoStringAgg).DoString(chunk.Strings(idx))
-		default:
-			// This error should be impossible because loadState should have
-			// already caught invalid input types and we have already verified
-			// that the input type matches the type for this chunk.
-			return nil, false, errors.Newf(codes.Internal, "aggregate of type %s not supported", c.Type)
+		if aggregates[j].hasNull && t.config.Nulls == NullModePropagate {
+			continue
+		}
+		hasNull, err := doAggregate(aggregates[j].agg, chunk.Values(idx), t.config.Nulls, mem)
+		if err != nil {
+			return nil, false, err
 		}
+		aggregates[j].hasNull = aggregates[j].hasNull || hasNull
 	}
 	return aggregates, true, nil
 }
@@ -719,6 +725,11 @@ func (t *simpleAggregateTransformation2) Compute(key flux.GroupKey, state interf
 	}
 
 	for _, s := range aggregates {
+		if s.hasNull && t.config.Nulls == NullModePropagate {
+			buffer.Values = append(buffer.Values, arrow.Nulls(s.agg.Type(), 1, mem))
+			continue
+		}
+
 		var arr array.Array
 		isNull := s.agg.IsNull()
 		switch s.agg.Type() {
@@ -737,6 +748,12 @@ func (t *simpleAggregateTransformation2) Compute(key flux.GroupKey, state interf
 		case flux.TString:
 			v := s.agg.(StringValueFunc).ValueString()
 			arr = array.StringRepeat(v, 1, mem)
+		case flux.TDuration:
+			v, err := arrow.DurationNsecs(s.agg.(DurationValueFunc).ValueDuration())
+			if err != nil {
+				return err
+			}
+			arr = array.IntRepeat(v, isNull, 1, mem)
 		}
 		buffer.Values = append(buffer.Values, arr)
 	}
@@ -764,6 +781,12 @@ type SimpleAggregate interface {
 	NewStringAgg() DoStringAgg
 }
 
+// DurationAggregate is implemented by a SimpleAggregate
+// that can also aggregate duration columns.
+type DurationAggregate interface {
+	NewDurationAgg() DoDurationAgg
+}
+
 type ValueFunc interface {
 	Type() flux.ColType
 	IsNull() bool
@@ -788,6 +811,10 @@ type DoStringAgg interface {
 	ValueFunc
 	DoString(*array.String)
 }
+type DoDurationAgg interface {
+	ValueFunc
+	DoDuration(*array.Int)
+}
 
 type BoolValueFunc interface {
 	ValueBool() bool
@@ -804,3 +831,6 @@ type UIntValueFunc interface {
 type StringValueFunc interface {
 	ValueString() string
 }
+type DurationValueFunc interface {
+	ValueDuration() values.Duration
+}
@@ -1298,6 +1298,142 @@ func TestSimpleAggregate_Process(t *testing.T) {
 				},
 			}},
 		},
+		{
+			name: "count nulls",
+			config: execute.SimpleAggregateConfig{
+				Columns: []string{execute.DefaultValueColLabel},
+				Nulls:   execute.NullModeDefault,
+			},
+			agg: countAgg,
+			data: []*executetest.Table{{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_start", Type: flux.TTime},
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(100), execute.Time(0), 1.0},
+					{execute.Time(0), execute.Time(100), execute.Time(10), nil},
+					{execute.Time(0), execute.Time(100), execute.Time(20), 2.0},
+					{execute.Time(0), execute.Time(100), execute.Time(30), nil},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_start", Type: flux.TTime},
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "_value", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(100), int64(4)},
+				},
+			}},
+		},
+		{
+			name: "count skip nulls",
+			config: execute.SimpleAggregateConfig{
+				Columns: []string{execute.DefaultValueColLabel},
+				Nulls:   execute.NullModeSkip,
+			},
+			agg: countAgg,
+			data: []*executetest.Table{{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_start", Type: flux.TTime},
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(100), execute.Time(0), 1.0},
+					{execute.Time(0), execute.Time(100), execute.Time(10), nil},
+					{execute.Time(0), execute.Time(100), execute.Time(20), 2.0},
+					{execute.Time(0), execute.Time(100), execute.Time(30), nil},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_start", Type: flux.TTime},
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "_value", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(100), int64(2)},
+				},
+			}},
+		},
+		{
+			name: "count propagate nulls",
+			config: execute.SimpleAggregateConfig{
+				Columns: []string{execute.DefaultValueColLabel},
+				Nulls:   execute.NullModePropagate,
+			},
+			agg: countAgg,
+			data: []*executetest.Table{{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_start", Type: flux.TTime},
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(100), execute.Time(0), 1.0},
+					{execute.Time(0), execute.Time(100), execute.Time(10), nil},
+					{execute.Time(0), execute.Time(100), execute.Time(20), 2.0},
+					{execute.Time(0), execute.Time(100), execute.Time(30), nil},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_start", Type: flux.TTime},
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "_value", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(100), nil},
+				},
+			}},
+		},
+		{
+			name: "sum propagate nulls",
+			config: execute.SimpleAggregateConfig{
+				Columns: []string{execute.DefaultValueColLabel},
+				Nulls:   execute.NullModePropagate,
+			},
+			agg: sumAgg,
+			data: []*executetest.Table{{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_start", Type: flux.TTime},
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(100), execute.Time(0), 1.0},
+					{execute.Time(0), execute.Time(100), execute.Time(10), nil},
+					{execute.Time(0), execute.Time(100), execute.Time(20), 2.0},
+					{execute.Time(0), execute.Time(100), execute.Time(30), nil},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_start", Type: flux.TTime},
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(100), nil},
+				},
+			}},
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -2,6 +2,7 @@ package execute
 
 import (
 	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/values"
 )
 
 const (
@@ -11,6 +12,8 @@ const (
 	float64Size = 8
 	stringSize  = 16
 	timeSize    = 8
+	bytesSize   = 24
+	arraySize   = 16
 )
 
 // Allocator is used to track memory allocations for directly allocated structs.
@@ -190,6 +193,58 @@ func (a *Allocator) GrowStrings(slice []string, n int) []string {
 	return s
 }
 
+// AppendBytes appends bytes values to a slice.
+// Only the slice headers are accounted for.
+func (a *Allocator) AppendBytes(slice [][]byte, vs ...[]byte) [][]byte {
+	if cap(slice)-len(slice) >= len(vs) {
+		return append(slice, vs...)
+	}
+	s := append(slice, vs...)
+	diff := cap(s) - cap(slice)
+	a.account(diff, bytesSize)
+	return s
+}
+
+func (a *Allocator) GrowBytes(slice [][]byte, n int) [][]byte {
+	newCap := len(slice) + n
+	if newCap < cap(slice) {
+		return slice[:newCap]
+	}
+	// grow capacity same way as built-in append
+	newCap = newCap*3/2 + 1
+	s := make([][]byte, len(slice)+n, newCap)
+	copy(s, slice)
+	diff := cap(s) - cap(slice)
+	a.account(diff, bytesSize)
+	return s
+}
+
+// AppendArrays appends array values to a slice.
+func (a *Allocator) AppendArrays(slice []values.Array, vs ...values.Array) []values.Array {
+	if cap(slice)-len(slice) >= len(vs) {
+		return append(slice, vs...)
+	}
+	s := append(slice, vs...)
+	diff := cap(s) - cap(slice)
+	a.account(diff, arraySize)
+	return s
+}
+
+// GrowArrays extends a slice of array values by n nil values.
+func (a *Allocator) GrowArrays(slice []values.Array, n int) []values.Array {
+	newCap := len(slice) + n
+	if newCap < cap(slice) {
+		return slice[:newCap]
+	}
+	// grow capacity same way as built-in append
+	newCap = newCap*3/2 + 1
+	s := make([]values.Array, len(slice)+n, newCap)
+	copy(s, slice)
+	diff := cap(s) - cap(slice)
+	a.account(diff, arraySize)
+	return s
+}
+
 // Times makes a slice of Time values.
 func (a *Allocator) Times(l, c int) []Time {
 	a.account(c, timeSize)
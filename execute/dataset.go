@@ -278,6 +278,8 @@ func (d *TransportDataset) sendMessage(m Message) error {
 }
 
 // Process sends the given Chunk to be processed by the downstream transports.
+// The TransportDataset takes ownership of the Chunk. A transformation that
+// forwards a Chunk it has received must call Retain on it first.
 func (d *TransportDataset) Process(chunk table.Chunk) error {
 	m := &processChunkMsg{
 		srcMessage: srcMessage(d.id),
@@ -295,9 +297,15 @@ func (d *TransportDataset) FlushKey(key flux.GroupKey) error {
 	return d.sendMessage(m)
 }
 
+// Lookup returns the state that was stored for the group key.
+// Transformations use the TransportDataset to keep state for
+// each group key between invocations.
 func (d *TransportDataset) Lookup(key flux.GroupKey) (interface{}, bool) {
 	return d.cache.Lookup(key)
 }
+
+// LookupOrCreate returns the state that was stored for the group key
+// or stores and returns the result of fn if there is no state.
 func (d *TransportDataset) LookupOrCreate(key flux.GroupKey, fn func() interface{}) interface{} {
 	if fn == nil {
 		fn = func() interface{} {
@@ -306,12 +314,18 @@ func (d *TransportDataset) LookupOrCreate(key flux.GroupKey, fn func() interface
 	}
 	return d.cache.LookupOrCreate(key, fn)
 }
+
+// Set stores the state for the group key.
 func (d *TransportDataset) Set(key flux.GroupKey, value interface{}) {
 	d.cache.Set(key, value)
 }
+
+// Delete removes and returns the state stored for the group key.
 func (d *TransportDataset) Delete(key flux.GroupKey) (v interface{}, found bool) {
 	return d.cache.Delete(key)
 }
+
+// Range invokes f with the state stored for each group key.
 func (d *TransportDataset) Range(f func(key flux.GroupKey, value interface{}) error) error {
 	return d.cache.Range(func(key flux.GroupKey, value interface{}) error {
 		return f(key, value)
@@ -16,10 +16,14 @@ import (
 
 type key int
 
-const executionDependenciesKey key = iota
+const (
+	executionDependenciesKey key = iota
+	deterministicExecutionKey
+)
 
 type ExecutionOptions struct {
 	OperatorProfiler *OperatorProfiler
+	FunctionProfiler *FunctionProfiler
 	Profilers        []Profiler
 }
 
@@ -37,11 +41,23 @@ type ExecutionDependencies struct {
 	// execution, and out through the statistics.
 	Metadata metadata.SyncMetadata
 
+	// Cache holds the values reused for the duration of the query.
+	// Allowed to be nil
+	Cache *QueryCache
+
 	ExecutionOptions *ExecutionOptions
 }
 
 func (d ExecutionDependencies) Inject(ctx context.Context) context.Context {
 	ctx = context.WithValue(ctx, executionDependenciesKey, d)
+	// The function profiler is looked up on each call because
+	// it is enabled by an option while the program is evaluated.
+	ctx = interpreter.WithFunctionProfiler(ctx, func() interpreter.FunctionProfiler {
+		if d.ExecutionOptions == nil || d.ExecutionOptions.FunctionProfiler == nil {
+			return nil
+		}
+		return d.ExecutionOptions.FunctionProfiler
+	})
 	return interpreter.Packages{}.Inject(ctx)
 }
 
@@ -87,6 +103,7 @@ func NewExecutionDependencies(allocator memory.Allocator, now *time.Time, logger
 		Now:              now,
 		Logger:           logger,
 		Metadata:         metadata.NewSyncMetadata(),
+		Cache:            &QueryCache{},
 		ExecutionOptions: &ExecutionOptions{},
 	}
 }
@@ -0,0 +1,23 @@
+package execute
+
+import "context"
+
+// WithDeterministicExecution returns a context that executes queries
+// deterministically. The sources are run one after another in the order
+// of the plan, the work is dispatched to a single goroutine in the order
+// it was scheduled and each result produces its tables sorted by group key.
+//
+// The output of a query executed this way does not depend on goroutine
+// scheduling or map iteration order so it can be compared without
+// normalizing the tables and ordering bugs are reproducible.
+// It is intended for tests and is slower than the default execution.
+func WithDeterministicExecution(ctx context.Context) context.Context {
+	return context.WithValue(ctx, deterministicExecutionKey, true)
+}
+
+// IsDeterministicExecution reports whether queries executed
+// with the context are executed deterministically.
+func IsDeterministicExecution(ctx context.Context) bool {
+	deterministic, _ := ctx.Value(deterministicExecutionKey).(bool)
+	return deterministic
+}
@@ -24,6 +24,8 @@ type poolDispatcher struct {
 	work   *ring
 	ready  chan struct{}
 	workMu sync.Mutex
+	// stopped is set under workMu when the workers have stopped.
+	stopped bool
 
 	throughput int
 
@@ -34,7 +36,8 @@ type poolDispatcher struct {
 	err     error
 	errC    chan error
 
-	logger *zap.Logger
+	logger  *zap.Logger
+	metrics *executionMetrics
 }
 
 func newPoolDispatcher(throughput int, logger *zap.Logger) *poolDispatcher {
@@ -55,6 +58,9 @@ func (d *poolDispatcher) Schedule(fn ScheduleFunc) {
 	// Schedule the work and then report to the channel that there
 	// is available work to unblock the worker scheduler thread.
 	d.work.Append(fn)
+	if !d.stopped {
+		d.metrics.queued(1)
+	}
 	select {
 	case d.ready <- struct{}{}:
 		// The ready channel should have a buffer of 1.
@@ -72,6 +78,9 @@ func (d *poolDispatcher) Start(n int, ctx context.Context) {
 			defer d.wg.Done()
 			// Setup panic handling on the worker goroutines
 			defer d.recover()
+			// Transports add the labels of their node while they process
+			// messages and restore the labels from this context afterwards.
+			ctx := withGoroutineLabels(ctx, GoroutineLabel, "dispatcher")
 			d.run(ctx)
 		}()
 	}
@@ -109,6 +118,14 @@ func (d *poolDispatcher) Stop() error {
 	// Wait for the existing workers to finish.
 	d.wg.Wait()
 
+	// Work that was never run is no longer waiting.
+	d.workMu.Lock()
+	if !d.stopped {
+		d.stopped = true
+		d.metrics.queued(-d.work.Len())
+	}
+	d.workMu.Unlock()
+
 	// Grab the error from within a lock.
 	d.mu.Lock()
 	defer d.mu.Unlock()
@@ -146,6 +163,7 @@ func (d *poolDispatcher) doWork(ctx context.Context) {
 		d.workMu.Lock()
 		if next := d.work.Next(); next != nil {
 			fn = next.(ScheduleFunc)
+			d.metrics.queued(-1)
 		}
 		d.workMu.Unlock()
 
@@ -155,6 +173,7 @@ func (d *poolDispatcher) doWork(ctx context.Context) {
 			return
 		}
 		fn(ctx, d.throughput)
+		d.metrics.updateAllocated()
 
 		// Check to see if the context was canceled or
 		// the dispatcher was closed. This allows us to exit
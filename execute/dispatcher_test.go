@@ -2,10 +2,13 @@ package execute
 
 import (
 	"context"
+	"runtime/pprof"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux/dependencies/metrics"
 	"go.uber.org/zap/zaptest"
 )
 
@@ -81,3 +84,81 @@ func TestDispatcher_ScheduleMany(t *testing.T) {
 	cancel()
 	wg.Wait()
 }
+
+type testGauge struct {
+	mu sync.Mutex
+	v  float64
+}
+
+func (g *testGauge) Add(v float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.v += v
+}
+
+func (g *testGauge) value() float64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.v
+}
+
+type testRegistry struct {
+	metrics.Registry
+	queueDepth testGauge
+}
+
+func (r *testRegistry) DispatcherQueueDepth() metrics.Gauge {
+	return &r.queueDepth
+}
+
+func TestDispatcher_QueueDepth(t *testing.T) {
+	registry := &testRegistry{}
+	d := newPoolDispatcher(10, zaptest.NewLogger(t))
+	d.metrics = &executionMetrics{registry: registry}
+
+	var wg sync.WaitGroup
+	wg.Add(10)
+	for i := 0; i < 10; i++ {
+		d.Schedule(func(ctx context.Context, throughput int) {
+			wg.Done()
+		})
+	}
+	if got, want := registry.queueDepth.value(), 10.0; got != want {
+		t.Errorf("unexpected queue depth before start -want/+got:\n\t- %v\n\t+ %v", want, got)
+	}
+
+	d.Start(1, context.Background())
+	wg.Wait()
+	if err := d.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := registry.queueDepth.value(), 0.0; got != want {
+		t.Errorf("unexpected queue depth after stop -want/+got:\n\t- %v\n\t+ %v", want, got)
+	}
+}
+
+func TestDispatcher_Labels(t *testing.T) {
+	ctx := WithQueryID(context.Background(), "q1")
+	d := newPoolDispatcher(10, zaptest.NewLogger(t))
+	d.Start(1, ctx)
+	defer func() { _ = d.Stop() }()
+
+	labels := make(chan map[string]string, 1)
+	d.Schedule(func(ctx context.Context, throughput int) {
+		m := make(map[string]string)
+		pprof.ForLabels(ctx, func(key, value string) bool {
+			m[key] = value
+			return true
+		})
+		labels <- m
+	})
+
+	got := <-labels
+	want := map[string]string{
+		QueryIDLabel:   "q1",
+		GoroutineLabel: "dispatcher",
+	}
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected labels -want/+got:\n%s", cmp.Diff(want, got))
+	}
+}
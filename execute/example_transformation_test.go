@@ -0,0 +1,190 @@
+package execute_test
+
+import (
+	"fmt"
+	"sort"
+
+	arrowmem "github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/memory"
+)
+
+// scaleTransformation multiplies the values of a float column.
+type scaleTransformation struct {
+	column string
+	factor float64
+}
+
+func (t *scaleTransformation) Process(chunk table.Chunk, d *execute.TransportDataset, mem arrowmem.Allocator) error {
+	idx := chunk.Index(t.column)
+	if idx < 0 {
+		return errors.Newf(codes.FailedPrecondition, "no column %q exists", t.column)
+	} else if typ := chunk.Col(idx).Type; typ != flux.TFloat {
+		return errors.Newf(codes.FailedPrecondition, "cannot scale column %q of type %s", t.column, typ)
+	}
+
+	vs := chunk.Floats(idx)
+	b := array.NewFloatBuilder(mem)
+	b.Resize(vs.Len())
+	for i, n := 0, vs.Len(); i < n; i++ {
+		if vs.IsNull(i) {
+			b.AppendNull()
+			continue
+		}
+		b.Append(vs.Value(i) * t.factor)
+	}
+
+	// The other columns are sent unmodified so
+	// they must be retained by the new buffer.
+	buffer := chunk.Buffer()
+	buffer.Values = make([]array.Array, chunk.NCols())
+	for j := range buffer.Values {
+		if j == idx {
+			buffer.Values[j] = b.NewArray()
+			continue
+		}
+		buffer.Values[j] = chunk.Values(j)
+		buffer.Values[j].Retain()
+	}
+	b.Release()
+	return d.Process(table.ChunkFromBuffer(buffer))
+}
+
+func (t *scaleTransformation) Close() error { return nil }
+
+// sumTransformation computes the sum of a float column for each table.
+type sumTransformation struct {
+	column string
+}
+
+func (t *sumTransformation) Aggregate(chunk table.Chunk, state interface{}, mem arrowmem.Allocator) (interface{}, bool, error) {
+	idx := chunk.Index(t.column)
+	if idx < 0 {
+		return nil, false, errors.Newf(codes.FailedPrecondition, "no column %q exists", t.column)
+	} else if typ := chunk.Col(idx).Type; typ != flux.TFloat {
+		return nil, false, errors.Newf(codes.FailedPrecondition, "cannot sum column %q of type %s", t.column, typ)
+	}
+
+	// The state is nil the first time a group key is seen.
+	sum, _ := state.(float64)
+	vs := chunk.Floats(idx)
+	for i, n := 0, vs.Len(); i < n; i++ {
+		if vs.IsValid(i) {
+			sum += vs.Value(i)
+		}
+	}
+	return sum, true, nil
+}
+
+func (t *sumTransformation) Compute(key flux.GroupKey, state interface{}, d *execute.TransportDataset, mem arrowmem.Allocator) error {
+	cols := make([]flux.ColMeta, 0, len(key.Cols())+1)
+	vs := make([]array.Array, 0, len(key.Cols())+1)
+	for j, col := range key.Cols() {
+		cols = append(cols, col)
+		vs = append(vs, arrow.Repeat(col.Type, key.Value(j), 1, mem))
+	}
+
+	b := array.NewFloatBuilder(mem)
+	b.Append(state.(float64))
+	cols = append(cols, flux.ColMeta{Label: t.column, Type: flux.TFloat})
+	vs = append(vs, b.NewArray())
+	b.Release()
+
+	return d.Process(table.ChunkFromBuffer(arrow.TableBuffer{
+		GroupKey: key,
+		Columns:  cols,
+		Values:   vs,
+	}))
+}
+
+func (t *sumTransformation) Close() error { return nil }
+
+func exampleTables() []*executetest.Table {
+	cols := []flux.ColMeta{
+		{Label: "t0", Type: flux.TString},
+		{Label: "_value", Type: flux.TFloat},
+	}
+	return []*executetest.Table{
+		{
+			KeyCols: []string{"t0"},
+			ColMeta: cols,
+			Data: [][]interface{}{
+				{"a", 1.0},
+				{"a", 2.0},
+			},
+		},
+		{
+			KeyCols: []string{"t0"},
+			ColMeta: cols,
+			Data: [][]interface{}{
+				{"b", 5.0},
+			},
+		},
+	}
+}
+
+// runExample sends the tables through the transformation
+// and prints the tables that it produces.
+func runExample(tr execute.Transformation, d execute.Dataset) {
+	store := executetest.NewDataStore()
+	d.AddTransformation(store)
+
+	parentID := executetest.RandomDatasetID()
+	var err error
+	for _, tbl := range exampleTables() {
+		if err = tr.Process(parentID, tbl); err != nil {
+			break
+		}
+	}
+	tr.Finish(parentID, err)
+
+	tables, err := executetest.TablesFromCache(store)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	executetest.NormalizeTables(tables)
+	sort.Sort(executetest.SortedTables(tables))
+	for _, tbl := range tables {
+		fmt.Println(tbl.Key(), tbl.Data)
+	}
+}
+
+func ExampleNewNarrowTransformation() {
+	tr, d, err := execute.NewNarrowTransformation(
+		executetest.RandomDatasetID(),
+		&scaleTransformation{column: "_value", factor: 10},
+		memory.DefaultAllocator,
+	)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	runExample(tr, d)
+	// Output:
+	// {t0=a} [[a 10] [a 20]]
+	// {t0=b} [[b 50]]
+}
+
+func ExampleNewAggregateTransformation() {
+	tr, d, err := execute.NewAggregateTransformation(
+		executetest.RandomDatasetID(),
+		&sumTransformation{column: "_value"},
+		memory.DefaultAllocator,
+	)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	runExample(tr, d)
+	// Output:
+	// {t0=a} [[a 3]]
+	// {t0=b} [[b 5]]
+}
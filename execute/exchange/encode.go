@@ -0,0 +1,354 @@
+package exchange
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+
+	arrowgo "github.com/apache/arrow/go/v7/arrow"
+	arrowarray "github.com/apache/arrow/go/v7/arrow/array"
+	"github.com/apache/arrow/go/v7/arrow/flight"
+	"github.com/apache/arrow/go/v7/arrow/ipc"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/values"
+)
+
+// fluxTypeKey is the key of the field metadata with the flux type
+// of a column. Time columns are sent as int64 columns.
+const fluxTypeKey = "flux.type"
+
+// header is the application metadata of a FlightData message.
+// Each message holds one buffer of a table encoded as an Arrow IPC stream.
+type header struct {
+	// Table is the index of the table in the stream. The buffers
+	// of the same table have the same index and are sent in order.
+	Table int `json:"table"`
+	// Key holds the group key values of the table.
+	Key []keyValue `json:"key"`
+}
+
+// keyValue is a group key value. Values are encoded as strings
+// so integers keep their precision.
+type keyValue struct {
+	Label string  `json:"label"`
+	Value *string `json:"value"`
+}
+
+// encodeTable sends each buffer of the table as a FlightData message.
+// A table without rows is sent as a single empty buffer.
+func encodeTable(idx int, tbl flux.Table, mem memory.Allocator, send func(*flight.FlightData) error) error {
+	h := header{Table: idx}
+	key := tbl.Key()
+	for j, c := range key.Cols() {
+		kv := keyValue{Label: c.Label}
+		if !key.IsNull(j) {
+			s := formatValue(c.Type, key.Value(j))
+			kv.Value = &s
+		}
+		h.Key = append(h.Key, kv)
+	}
+	metadata, err := json.Marshal(h)
+	if err != nil {
+		return err
+	}
+
+	sent := false
+	if err := tbl.Do(func(cr flux.ColReader) error {
+		sent = true
+		body, err := encodeBuffer(cr, mem)
+		if err != nil {
+			return err
+		}
+		return send(&flight.FlightData{AppMetadata: metadata, DataBody: body})
+	}); err != nil {
+		return err
+	}
+	if sent {
+		return nil
+	}
+
+	buf := arrow.EmptyBuffer(key, tbl.Cols())
+	defer buf.Release()
+	body, err := encodeBuffer(&buf, mem)
+	if err != nil {
+		return err
+	}
+	return send(&flight.FlightData{AppMetadata: metadata, DataBody: body})
+}
+
+// encodeBuffer encodes the buffer as an Arrow IPC stream
+// with the schema and a single record.
+func encodeBuffer(cr flux.ColReader, mem memory.Allocator) ([]byte, error) {
+	alloc := arrow.NewAllocator(mem)
+	cols := cr.Cols()
+	fields := make([]arrowgo.Field, len(cols))
+	columns := make([]arrowgo.Array, len(cols))
+	defer func() {
+		for _, c := range columns {
+			if c != nil {
+				c.Release()
+			}
+		}
+	}()
+	for j, c := range cols {
+		fields[j] = arrowgo.Field{
+			Name:     c.Label,
+			Nullable: true,
+			Metadata: arrowgo.NewMetadata([]string{fluxTypeKey}, []string{c.Type.String()}),
+		}
+		switch c.Type {
+		case flux.TString:
+			// Strings are sent as binary so they can be decoded
+			// with array.NewStringFromBinaryArray.
+			vs := cr.Strings(j)
+			b := arrowarray.NewBinaryBuilder(alloc, arrowgo.BinaryTypes.Binary)
+			b.Reserve(vs.Len())
+			for i := 0; i < vs.Len(); i++ {
+				if vs.IsNull(i) {
+					b.AppendNull()
+					continue
+				}
+				b.AppendString(vs.Value(i))
+			}
+			columns[j] = b.NewArray()
+			b.Release()
+		case flux.TInt, flux.TUInt, flux.TFloat, flux.TBool, flux.TTime:
+			arr, ok := table.Values(cr, j).(arrowgo.Array)
+			if !ok {
+				return nil, errors.Newf(codes.Internal, "column %q cannot be encoded", c.Label)
+			}
+			arr.Retain()
+			columns[j] = arr
+		default:
+			return nil, errors.Newf(codes.Unimplemented, "column %q of type %v cannot be sent to a remote process", c.Label, c.Type)
+		}
+		fields[j].Type = columns[j].DataType()
+	}
+
+	schema := arrowgo.NewSchema(fields, nil)
+	rec := arrowarray.NewRecord(schema, columns, int64(cr.Len()))
+	defer rec.Release()
+
+	var buf bytes.Buffer
+	w := ipc.NewWriter(&buf, ipc.WithSchema(schema), ipc.WithAllocator(alloc))
+	if err := w.Write(rec); err != nil {
+		_ = w.Close()
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decoder assembles the tables sent as FlightData messages.
+type decoder struct {
+	mem   memory.Allocator
+	table int
+	tbl   *table.BufferedTable
+}
+
+// decode adds the buffer in the message to the current table.
+// It returns the previous table when the message starts a new table.
+func (d *decoder) decode(data *flight.FlightData) (flux.Table, error) {
+	var h header
+	if err := json.Unmarshal(data.AppMetadata, &h); err != nil {
+		return nil, errors.Wrap(err, codes.Internal, "invalid exchange message")
+	}
+
+	var done flux.Table
+	if d.tbl != nil && h.Table != d.table {
+		done, d.tbl = d.tbl, nil
+	}
+
+	buf, err := d.decodeBuffer(data.DataBody)
+	if err != nil {
+		if done != nil {
+			done.Done()
+		}
+		return nil, err
+	}
+	if d.tbl == nil {
+		key, err := decodeKey(h.Key, buf.Columns)
+		if err != nil {
+			buf.Release()
+			if done != nil {
+				done.Done()
+			}
+			return nil, err
+		}
+		d.table = h.Table
+		d.tbl = &table.BufferedTable{GroupKey: key, Columns: buf.Columns}
+	}
+	buf.GroupKey = d.tbl.GroupKey
+	if buf.Len() > 0 || len(d.tbl.Buffers) == 0 {
+		d.tbl.Buffers = append(d.tbl.Buffers, buf)
+	} else {
+		buf.Release()
+	}
+	return done, nil
+}
+
+// flush returns the table that is being assembled, if any.
+func (d *decoder) flush() flux.Table {
+	if d.tbl == nil {
+		return nil
+	}
+	tbl := d.tbl
+	d.tbl = nil
+	return tbl
+}
+
+// release releases the table that is being assembled.
+func (d *decoder) release() {
+	if tbl := d.flush(); tbl != nil {
+		tbl.Done()
+	}
+}
+
+func (d *decoder) decodeBuffer(body []byte) (*arrow.TableBuffer, error) {
+	r, err := ipc.NewReader(bytes.NewReader(body), ipc.WithAllocator(arrow.NewAllocator(d.mem)))
+	if err != nil {
+		return nil, errors.Wrap(err, codes.Internal, "invalid exchange message")
+	}
+	defer r.Release()
+	if !r.Next() {
+		if err := r.Err(); err != nil {
+			return nil, errors.Wrap(err, codes.Internal, "invalid exchange message")
+		}
+		return nil, errors.New(codes.Internal, "exchange message has no record")
+	}
+	rec := r.Record()
+
+	schema := rec.Schema()
+	cols := make([]flux.ColMeta, len(schema.Fields()))
+	vs := make([]array.Array, 0, len(cols))
+	release := func() {
+		for _, v := range vs {
+			v.Release()
+		}
+	}
+	for j, f := range schema.Fields() {
+		typ := flux.TInvalid
+		if i := f.Metadata.FindKey(fluxTypeKey); i >= 0 {
+			typ = parseColType(f.Metadata.Values()[i])
+		}
+		cols[j] = flux.ColMeta{Label: f.Name, Type: typ}
+
+		col := rec.Column(j)
+		switch typ {
+		case flux.TString:
+			b, ok := col.(*arrowarray.Binary)
+			if !ok {
+				release()
+				return nil, errors.Newf(codes.Internal, "unexpected array %T for column %q", col, f.Name)
+			}
+			vs = append(vs, array.NewStringFromBinaryArray(b))
+		case flux.TInt, flux.TUInt, flux.TFloat, flux.TBool, flux.TTime:
+			col.Retain()
+			vs = append(vs, col.(array.Array))
+		default:
+			release()
+			return nil, errors.Newf(codes.Internal, "unexpected type for column %q", f.Name)
+		}
+	}
+	buf := &arrow.TableBuffer{Columns: cols, Values: vs}
+	if err := buf.Validate(); err != nil {
+		buf.Release()
+		return nil, err
+	}
+	return buf, nil
+}
+
+func parseColType(s string) flux.ColType {
+	for _, typ := range []flux.ColType{flux.TBool, flux.TInt, flux.TUInt, flux.TFloat, flux.TString, flux.TTime, flux.TBytes, flux.TDuration} {
+		if typ.String() == s {
+			return typ
+		}
+	}
+	return flux.TInvalid
+}
+
+// formatValue formats a group key value of the column type.
+func formatValue(typ flux.ColType, v values.Value) string {
+	switch typ {
+	case flux.TBool:
+		return strconv.FormatBool(v.Bool())
+	case flux.TInt:
+		return strconv.FormatInt(v.Int(), 10)
+	case flux.TUInt:
+		return strconv.FormatUint(v.UInt(), 10)
+	case flux.TFloat:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	case flux.TTime:
+		return strconv.FormatInt(int64(v.Time()), 10)
+	case flux.TBytes:
+		return base64.StdEncoding.EncodeToString(v.Bytes())
+	case flux.TDuration:
+		return v.Duration().String()
+	default:
+		return v.Str()
+	}
+}
+
+// parseValue parses a group key value formatted by formatValue.
+func parseValue(typ flux.ColType, s string) (values.Value, error) {
+	switch typ {
+	case flux.TBool:
+		v, err := strconv.ParseBool(s)
+		return values.NewBool(v), err
+	case flux.TInt:
+		v, err := strconv.ParseInt(s, 10, 64)
+		return values.NewInt(v), err
+	case flux.TUInt:
+		v, err := strconv.ParseUint(s, 10, 64)
+		return values.NewUInt(v), err
+	case flux.TFloat:
+		v, err := strconv.ParseFloat(s, 64)
+		return values.NewFloat(v), err
+	case flux.TTime:
+		v, err := strconv.ParseInt(s, 10, 64)
+		return values.NewTime(values.Time(v)), err
+	case flux.TString:
+		return values.NewString(s), nil
+	case flux.TBytes:
+		v, err := base64.StdEncoding.DecodeString(s)
+		return values.NewBytes(v), err
+	case flux.TDuration:
+		v, err := values.ParseDuration(s)
+		return values.NewDuration(v), err
+	default:
+		return nil, errors.Newf(codes.Internal, "unsupported group key type %v", typ)
+	}
+}
+
+func decodeKey(kvs []keyValue, cols []flux.ColMeta) (flux.GroupKey, error) {
+	keyCols := make([]flux.ColMeta, 0, len(kvs))
+	vs := make([]values.Value, 0, len(kvs))
+	for _, kv := range kvs {
+		idx := execute.ColIdx(kv.Label, cols)
+		if idx < 0 {
+			return nil, errors.Newf(codes.Internal, "group key column %q is missing from the table", kv.Label)
+		}
+		c := cols[idx]
+		keyCols = append(keyCols, c)
+		if kv.Value == nil {
+			vs = append(vs, values.NewNull(flux.SemanticType(c.Type)))
+			continue
+		}
+		v, err := parseValue(c.Type, *kv.Value)
+		if err != nil {
+			return nil, errors.Wrapf(err, codes.Internal, "invalid value for group key column %q", kv.Label)
+		}
+		vs = append(vs, v)
+	}
+	return execute.NewGroupKey(keyCols, vs), nil
+}
@@ -0,0 +1,67 @@
+// Package exchange executes the partitions of a plan in remote processes
+// and ships their tables back over Arrow Flight.
+//
+// A process that executes partitions runs a Server. The coordinating
+// process injects an execute.Scheduler into the context of the query.
+// The executor then replaces the parallel part of each parallel merge
+// in the plan with a remote fragment node. Each copy of that node is a
+// source that asks the scheduler for the address of a Server and reads
+// the tables of its partition from the Server with a DoGet request.
+//
+// The fragments are encoded with the protobuf encoding of planpb.
+// The procedure specs of a fragment must be registered with
+// planpb.RegisterProcedureSpec to be executed remotely. The query
+// fails if a parallel merge has a predecessor that cannot be encoded.
+package exchange
+
+import (
+	"encoding/json"
+
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/plan/planpb"
+	"google.golang.org/protobuf/proto"
+)
+
+func init() {
+	execute.RegisterSource(execute.RemoteFragmentKind, createRemoteSource)
+}
+
+// Ticket identifies the partition of a plan fragment to execute.
+type Ticket struct {
+	Fragment  *planpb.Spec
+	Partition int
+}
+
+// ticket is the encoding of a Ticket in the ticket of a DoGet request.
+// The fragment is encoded with protobuf.
+type ticket struct {
+	Fragment  []byte `json:"fragment"`
+	Partition int    `json:"partition"`
+}
+
+// Marshal encodes the ticket.
+func (t *Ticket) Marshal() ([]byte, error) {
+	fragment, err := proto.Marshal(t.Fragment)
+	if err != nil {
+		return nil, errors.Wrap(err, codes.Internal, "failed to encode plan fragment")
+	}
+	return json.Marshal(ticket{Fragment: fragment, Partition: t.Partition})
+}
+
+// UnmarshalTicket decodes a ticket encoded by Marshal.
+func UnmarshalTicket(data []byte) (*Ticket, error) {
+	var t ticket
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, errors.Wrap(err, codes.Invalid, "invalid exchange ticket")
+	}
+	if len(t.Fragment) == 0 {
+		return nil, errors.New(codes.Invalid, "exchange ticket has no fragment")
+	}
+	fragment := &planpb.Spec{}
+	if err := proto.Unmarshal(t.Fragment, fragment); err != nil {
+		return nil, errors.Wrap(err, codes.Invalid, "invalid plan fragment in exchange ticket")
+	}
+	return &Ticket{Fragment: fragment, Partition: t.Partition}, nil
+}
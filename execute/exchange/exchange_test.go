@@ -0,0 +1,280 @@
+package exchange_test
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v7/arrow/flight"
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/exchange"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/plan/planpb"
+	"github.com/influxdata/flux/values"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	partitionSourceKind = "exchangetest.partitions"
+	partitionMergeKind  = "exchangetest.merge"
+)
+
+func init() {
+	execute.RegisterSource(partitionSourceKind, createPartitionSource)
+	planpb.RegisterProcedureSpec(partitionSourceKind,
+		func(spec plan.ProcedureSpec) (proto.Message, error) {
+			data, err := json.Marshal(spec)
+			if err != nil {
+				return nil, err
+			}
+			return &wrapperspb.BytesValue{Value: data}, nil
+		},
+		func(msg proto.Message) (plan.PhysicalProcedureSpec, error) {
+			spec := &partitionSourceSpec{}
+			if err := json.Unmarshal(msg.(*wrapperspb.BytesValue).Value, spec); err != nil {
+				return nil, err
+			}
+			return spec, nil
+		},
+	)
+	execute.RegisterTransformation(partitionMergeKind, createPartitionMerge)
+}
+
+// partitionSourceSpec produces one table for each partition.
+// The table is tagged with the partition and has the values of the partition.
+type partitionSourceSpec struct {
+	plan.DefaultCost
+	Factor int
+	Values [][]int64
+}
+
+func (s *partitionSourceSpec) Kind() plan.ProcedureKind { return partitionSourceKind }
+func (s *partitionSourceSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+func (s *partitionSourceSpec) OutputAttributes() plan.PhysicalAttributes {
+	return plan.PhysicalAttributes{
+		plan.ParallelRunKey: plan.ParallelRunAttribute{Factor: s.Factor},
+	}
+}
+
+type partitionSource struct {
+	spec      *partitionSourceSpec
+	partition int
+	mem       memory.Allocator
+}
+
+func createPartitionSource(s plan.ProcedureSpec, id execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	return execute.CreateSourceFromIterator(&partitionSource{
+		spec:      s.(*partitionSourceSpec),
+		partition: a.ParallelOpts().Group,
+		mem:       a.Allocator(),
+	}, id)
+}
+
+func (s *partitionSource) Do(ctx context.Context, f func(flux.Table) error) error {
+	tag := string(rune('a' + s.partition))
+	key := execute.NewGroupKey(
+		[]flux.ColMeta{{Label: "t0", Type: flux.TString}},
+		[]values.Value{values.NewString(tag)},
+	)
+	cols := []flux.ColMeta{
+		{Label: "t0", Type: flux.TString},
+		{Label: "_time", Type: flux.TTime},
+		{Label: "_value", Type: flux.TInt},
+	}
+	vs := s.spec.Values[s.partition]
+	times := make([]int64, len(vs))
+	for i := range times {
+		times[i] = int64(i)
+	}
+	return f(table.FromBuffer(&arrow.TableBuffer{
+		GroupKey: key,
+		Columns:  cols,
+		Values: []array.Array{
+			array.StringRepeat(tag, len(vs), s.mem),
+			arrow.NewInt(times, s.mem),
+			arrow.NewInt(vs, s.mem),
+		},
+	}))
+}
+
+// partitionMergeSpec merges the partitions of its predecessor.
+type partitionMergeSpec struct {
+	plan.DefaultCost
+	Factor int
+}
+
+func (s *partitionMergeSpec) Kind() plan.ProcedureKind { return partitionMergeKind }
+func (s *partitionMergeSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+func (s *partitionMergeSpec) OutputAttributes() plan.PhysicalAttributes {
+	return plan.PhysicalAttributes{
+		plan.ParallelMergeKey: plan.ParallelMergeAttribute{Factor: s.Factor},
+	}
+}
+
+type partitionMerge struct {
+	d       *execute.PassthroughDataset
+	mu      sync.Mutex
+	parents int
+}
+
+func createPartitionMerge(id execute.DatasetID, mode execute.AccumulationMode, s plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	d := execute.NewPassthroughDataset(id)
+	return &partitionMerge{d: d, parents: len(a.Parents())}, d, nil
+}
+
+func (t *partitionMerge) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+func (t *partitionMerge) Process(id execute.DatasetID, tbl flux.Table) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.d.Process(tbl)
+}
+
+func (t *partitionMerge) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return nil
+}
+
+func (t *partitionMerge) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return nil
+}
+
+func (t *partitionMerge) Finish(id execute.DatasetID, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.parents--; t.parents == 0 || err != nil {
+		t.d.Finish(err)
+	}
+}
+
+type scheduler struct {
+	addr string
+
+	mu         sync.Mutex
+	partitions []int
+}
+
+func (s *scheduler) Schedule(ctx context.Context, fragment *planpb.Spec, partition, factor int) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.partitions = append(s.partitions, partition)
+	return s.addr, nil
+}
+
+func TestRemoteFragment(t *testing.T) {
+	srv := flight.NewFlightServer(nil)
+	if err := srv.Init("localhost:0"); err != nil {
+		t.Fatal(err)
+	}
+	srv.RegisterFlightService((&exchange.Server{Logger: zaptest.NewLogger(t)}).Service())
+	go func() { _ = srv.Serve() }()
+	defer srv.Shutdown()
+
+	from := plan.CreatePhysicalNode("from", &partitionSourceSpec{
+		Factor: 2,
+		Values: [][]int64{{1, 2, 3}, {4, 5}},
+	})
+	merge := plan.CreatePhysicalNode("merge", &partitionMergeSpec{Factor: 2})
+	from.AddSuccessors(merge)
+	merge.AddPredecessors(from)
+
+	spec := plan.NewPlanSpec()
+	spec.Roots[merge] = struct{}{}
+	spec.Now = time.Now()
+	spec.Resources = flux.ResourceManagement{
+		ConcurrencyQuota: 4,
+		MemoryBytesQuota: math.MaxInt64,
+	}
+
+	s := &scheduler{addr: srv.Addr().String()}
+	ctx := execute.InjectScheduler(context.Background(), s)
+	results, _, err := execute.NewExecutor(zaptest.NewLogger(t)).Execute(ctx, spec, memory.DefaultAllocator)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string][]int64)
+	for _, res := range results {
+		if err := res.Tables().Do(func(tbl flux.Table) error {
+			tag := tbl.Key().ValueString(0)
+			return tbl.Do(func(cr flux.ColReader) error {
+				got[tag] = append(got[tag], cr.Ints(2).Int64Values()...)
+				return nil
+			})
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := map[string][]int64{
+		"a": {1, 2, 3},
+		"b": {4, 5},
+	}
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected tables -want/+got:\n%s", cmp.Diff(want, got))
+	}
+
+	sort.Ints(s.partitions)
+	if want, got := []int{0, 1}, s.partitions; !cmp.Equal(want, got) {
+		t.Errorf("unexpected partitions -want/+got:\n%s", cmp.Diff(want, got))
+	}
+}
+
+// localSourceSpec is a partition source without a protobuf encoding.
+type localSourceSpec struct {
+	partitionSourceSpec
+}
+
+func (s *localSourceSpec) Kind() plan.ProcedureKind { return "exchangetest.local" }
+func (s *localSourceSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+func TestRemoteFragment_Unencodable(t *testing.T) {
+	from := plan.CreatePhysicalNode("from", &localSourceSpec{
+		partitionSourceSpec: partitionSourceSpec{Factor: 2},
+	})
+	merge := plan.CreatePhysicalNode("merge", &partitionMergeSpec{Factor: 2})
+	from.AddSuccessors(merge)
+	merge.AddPredecessors(from)
+
+	spec := plan.NewPlanSpec()
+	spec.Roots[merge] = struct{}{}
+	spec.Now = time.Now()
+	spec.Resources = flux.ResourceManagement{
+		ConcurrencyQuota: 4,
+		MemoryBytesQuota: math.MaxInt64,
+	}
+
+	// The query fails instead of quietly running the
+	// fragment locally when it cannot be sent.
+	ctx := execute.InjectScheduler(context.Background(), &scheduler{})
+	_, _, err := execute.NewExecutor(zaptest.NewLogger(t)).Execute(ctx, spec, memory.DefaultAllocator)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if want, got := codes.Unimplemented, flux.ErrorCode(err); want != got {
+		t.Errorf("unexpected error code -want/+got:\n\t- %v\n\t+ %v", want, got)
+	}
+}
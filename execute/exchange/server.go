@@ -0,0 +1,123 @@
+package exchange
+
+import (
+	"context"
+
+	"github.com/apache/arrow/go/v7/arrow/flight"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/plan/planpb"
+	"go.uber.org/zap"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server executes the partitions of plan fragments
+// requested with DoGet and streams their tables.
+type Server struct {
+	// Inject adds the dependencies required to execute a fragment
+	// to the context of a request. It may be nil.
+	Inject func(ctx context.Context) context.Context
+
+	// Logger is used by the executor. It may be nil.
+	Logger *zap.Logger
+
+	// Allocator is used to allocate the memory of each request.
+	// If it is nil, the default allocator is used.
+	Allocator memory.Allocator
+}
+
+// Service returns the Flight service to register with a flight.Server.
+func (s *Server) Service() *flight.FlightServiceService {
+	return &flight.FlightServiceService{
+		DoGet: s.DoGet,
+	}
+}
+
+// DoGet executes the partition of the fragment in the ticket
+// and sends the tables of its result.
+func (s *Server) DoGet(tkt *flight.Ticket, stream flight.FlightService_DoGetServer) error {
+	if err := s.doGet(tkt, stream); err != nil {
+		return toStatus(err)
+	}
+	return nil
+}
+
+func (s *Server) doGet(tkt *flight.Ticket, stream flight.FlightService_DoGetServer) error {
+	ticket, err := UnmarshalTicket(tkt.GetTicket())
+	if err != nil {
+		return err
+	}
+	spec, err := planpb.UnmarshalFragment(ticket.Fragment)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	if s.Inject != nil {
+		ctx = s.Inject(ctx)
+	}
+	ctx = execute.WithPartition(ctx, ticket.Partition)
+
+	logger := s.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	mem := s.Allocator
+	if mem == nil {
+		mem = memory.DefaultAllocator
+	}
+	alloc := &memory.ResourceAllocator{Allocator: mem}
+
+	results, statsCh, err := execute.NewExecutor(logger).Execute(ctx, spec, alloc)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Discard the tables that were not sent and wait
+		// for the execution to finish.
+		cancel()
+		for _, res := range results {
+			_ = res.Tables().Do(func(tbl flux.Table) error {
+				tbl.Done()
+				return nil
+			})
+		}
+		for range statsCh {
+		}
+	}()
+
+	idx := 0
+	for _, res := range results {
+		if err := res.Tables().Do(func(tbl flux.Table) error {
+			defer func() { idx++ }()
+			return encodeTable(idx, tbl, alloc, stream.Send)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toStatus converts the error to a gRPC status error.
+// The flux error codes have the same values as the gRPC codes.
+func toStatus(err error) error {
+	code := errors.Code(err)
+	if code == codes.Inherit {
+		code = codes.Unknown
+	}
+	return status.Error(grpccodes.Code(code), err.Error())
+}
+
+// fromStatus converts a gRPC status error to a flux error.
+func fromStatus(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return errors.Wrap(err, codes.Unavailable, "remote execution failed")
+	}
+	return errors.New(codes.Code(st.Code()), st.Message())
+}
@@ -0,0 +1,137 @@
+package exchange
+
+import (
+	"context"
+	"io"
+
+	"github.com/apache/arrow/go/v7/arrow/flight"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/plan"
+	"google.golang.org/grpc"
+)
+
+// Dialer creates the Flight clients used to read
+// the partitions of fragments from remote processes.
+type Dialer interface {
+	Dial(ctx context.Context, addr string) (flight.Client, error)
+}
+
+// DialerFunc is an adapter that allows a function to be used as a Dialer.
+type DialerFunc func(ctx context.Context, addr string) (flight.Client, error)
+
+// Dial calls f(ctx, addr).
+func (f DialerFunc) Dial(ctx context.Context, addr string) (flight.Client, error) {
+	return f(ctx, addr)
+}
+
+// DefaultDialer connects to remote processes without authentication
+// or transport security.
+var DefaultDialer Dialer = DialerFunc(func(ctx context.Context, addr string) (flight.Client, error) {
+	return flight.NewFlightClient(addr, nil, grpc.WithInsecure())
+})
+
+type dialerKey int
+
+const dialerDependencyKey dialerKey = iota
+
+// Inject will inject the Dialer into the dependency chain.
+func Inject(ctx context.Context, d Dialer) context.Context {
+	return context.WithValue(ctx, dialerDependencyKey, d)
+}
+
+// Dependency will inject the Dialer into the dependency chain.
+type Dependency struct {
+	Dialer Dialer
+}
+
+// Inject will inject the Dialer into the dependency chain.
+func (d Dependency) Inject(ctx context.Context) context.Context {
+	return Inject(ctx, d.Dialer)
+}
+
+// GetDialer will return the Dialer for the current context.
+// If no Dialer has been injected into the dependencies,
+// this will return the DefaultDialer.
+func GetDialer(ctx context.Context) Dialer {
+	if d, ok := ctx.Value(dialerDependencyKey).(Dialer); ok {
+		return d
+	}
+	return DefaultDialer
+}
+
+// remoteSource reads the tables of one partition of a fragment
+// from the remote process chosen by the scheduler.
+type remoteSource struct {
+	spec      *execute.RemoteFragmentProcedureSpec
+	partition int
+	mem       memory.Allocator
+}
+
+func createRemoteSource(s plan.ProcedureSpec, id execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	spec, ok := s.(*execute.RemoteFragmentProcedureSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", s)
+	}
+	src := &remoteSource{
+		spec:      spec,
+		partition: a.ParallelOpts().Group,
+		mem:       a.Allocator(),
+	}
+	return execute.CreateSourceFromIterator(src, id)
+}
+
+func (s *remoteSource) Do(ctx context.Context, f func(flux.Table) error) error {
+	scheduler := execute.GetScheduler(ctx)
+	if scheduler == nil {
+		return errors.New(codes.Internal, "remote fragment requires a scheduler")
+	}
+	addr, err := scheduler.Schedule(ctx, s.spec.Fragment, s.partition, s.spec.Factor)
+	if err != nil {
+		return err
+	}
+
+	client, err := GetDialer(ctx).Dial(ctx, addr)
+	if err != nil {
+		return errors.Wrapf(err, codes.Unavailable, "failed to connect to %s", addr)
+	}
+	defer func() { _ = client.Close() }()
+
+	ticket, err := (&Ticket{Fragment: s.spec.Fragment, Partition: s.partition}).Marshal()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := client.DoGet(ctx, &flight.Ticket{Ticket: ticket})
+	if err != nil {
+		return fromStatus(err)
+	}
+
+	d := &decoder{mem: s.mem}
+	defer d.release()
+	for {
+		data, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return fromStatus(err)
+		}
+		tbl, err := d.decode(data)
+		if err != nil {
+			return err
+		}
+		if tbl != nil {
+			if err := f(tbl); err != nil {
+				return err
+			}
+		}
+	}
+	if tbl := d.flush(); tbl != nil {
+		return f(tbl)
+	}
+	return nil
+}
@@ -43,6 +43,7 @@ var testFlags = map[string]interface{}{
 	"optimizeSetTransformation": true,
 	"removeRedundantSortNodes":  true,
 	"strictNullLogicalOps":      true,
+	"columnarPivot":             true,
 }
 
 type TestFlagger map[string]interface{}
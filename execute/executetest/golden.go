@@ -0,0 +1,195 @@
+package executetest
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/csv"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files compared by executetest.AssertGolden with the actual tables")
+
+// DecodeTables reads the tables of every result within annotated CSV.
+func DecodeTables(r io.Reader) ([]*Table, error) {
+	dec := csv.NewMultiResultDecoder(csv.ResultDecoderConfig{})
+	results, err := dec.Decode(ioutil.NopCloser(r))
+	if err != nil {
+		return nil, err
+	}
+	defer results.Release()
+
+	var tables []*Table
+	for results.More() {
+		res := ConvertResult(results.Next())
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		tables = append(tables, res.Tbls...)
+	}
+	if err := results.Err(); err != nil {
+		return nil, err
+	}
+	return tables, nil
+}
+
+// EncodeTables writes the tables as a single result of annotated CSV.
+func EncodeTables(w io.Writer, tables []*Table) error {
+	enc := csv.NewResultEncoder(csv.DefaultEncoderConfig())
+	_, err := enc.Encode(w, &Result{Nm: "_result", Tbls: tables})
+	return err
+}
+
+// ReadGolden reads the tables from an annotated CSV golden file.
+func ReadGolden(t testing.TB, path string) []*Table {
+	t.Helper()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open golden file: %s", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	tables, err := DecodeTables(f)
+	if err != nil {
+		t.Fatalf("failed to decode golden file %s: %s", path, err)
+	}
+	return tables
+}
+
+// AssertGolden compares the tables with the tables in the golden file at path
+// and reports the rows that differ. When the tests are run with the -update flag,
+// the golden file is rewritten with the tables instead.
+func AssertGolden(t testing.TB, path string, got []*Table, floatOptions ...cmp.Option) {
+	t.Helper()
+
+	if *updateGolden {
+		var buf bytes.Buffer
+		if err := EncodeTables(&buf, got); err != nil {
+			t.Fatalf("failed to encode tables: %s", err)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create golden file directory: %s", err)
+		}
+		if err := ioutil.WriteFile(path, buf.Bytes(), 0644); err != nil {
+			t.Fatalf("failed to write golden file: %s", err)
+		}
+		return
+	}
+
+	want := ReadGolden(t, path)
+	if diff := DiffTables(want, got, floatOptions...); diff != "" {
+		t.Errorf("tables do not match golden file %s (run with -update to rewrite it) -want/+got:\n%s", path, diff)
+	}
+}
+
+// DiffTables compares two sets of tables and returns a description
+// of the differences or an empty string if the tables are equal.
+// The tables are matched by their group keys and the difference of
+// each pair of tables is described by the rows that are only present
+// in one of them. Both sets of tables are normalized and sorted.
+func DiffTables(want, got []*Table, floatOptions ...cmp.Option) string {
+	opts := make([]cmp.Option, 0, len(defaultFloatOptions)+len(floatOptions))
+	if len(floatOptions) > 0 {
+		opts = append(opts, floatOptions...)
+	} else {
+		opts = append(opts, defaultFloatOptions...)
+	}
+
+	NormalizeTables(want)
+	NormalizeTables(got)
+	sort.Sort(SortedTables(want))
+	sort.Sort(SortedTables(got))
+
+	var sb strings.Builder
+	i, j := 0, 0
+	for i < len(want) || j < len(got) {
+		switch {
+		case j == len(got) || (i < len(want) && want[i].Key().Less(got[j].Key())):
+			fmt.Fprintf(&sb, "-table %v: %d rows\n", want[i].Key(), len(want[i].Data))
+			i++
+		case i == len(want) || got[j].Key().Less(want[i].Key()):
+			fmt.Fprintf(&sb, "+table %v: %d rows\n", got[j].Key(), len(got[j].Data))
+			j++
+		default:
+			diffTable(&sb, want[i], got[j], opts)
+			i++
+			j++
+		}
+	}
+	return sb.String()
+}
+
+// diffTable writes the differences between two tables with the same group key.
+func diffTable(sb *strings.Builder, want, got *Table, opts []cmp.Option) {
+	if !cmp.Equal(want.ColMeta, got.ColMeta) {
+		fmt.Fprintf(sb, " table %v:\n-  columns: %s\n+  columns: %s\n", want.Key(), formatCols(want.ColMeta), formatCols(got.ColMeta))
+		return
+	}
+
+	// Compute the longest common subsequence of the rows so the
+	// rows that are only present in one of the tables can be reported.
+	n, m := len(want.Data), len(got.Data)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if cmp.Equal(want.Data[i], got.Data[j], opts...) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	if lcs[0][0] == n && n == m {
+		return
+	}
+
+	fmt.Fprintf(sb, " table %v:\n", want.Key())
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && cmp.Equal(want.Data[i], got.Data[j], opts...):
+			i++
+			j++
+		case j == m || (i < n && lcs[i+1][j] >= lcs[i][j+1]):
+			fmt.Fprintf(sb, "-  row %d: %s\n", i, formatRow(want.ColMeta, want.Data[i]))
+			i++
+		default:
+			fmt.Fprintf(sb, "+  row %d: %s\n", j, formatRow(got.ColMeta, got.Data[j]))
+			j++
+		}
+	}
+}
+
+func formatCols(cols []flux.ColMeta) string {
+	labels := make([]string, len(cols))
+	for j, col := range cols {
+		labels[j] = col.Label + ":" + col.Type.String()
+	}
+	return strings.Join(labels, ",")
+}
+
+func formatRow(cols []flux.ColMeta, row []interface{}) string {
+	values := make([]string, len(row))
+	for j, v := range row {
+		if v == nil {
+			v = "<null>"
+		}
+		values[j] = fmt.Sprintf("%s=%v", cols[j].Label, v)
+	}
+	return strings.Join(values, ",")
+}
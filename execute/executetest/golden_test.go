@@ -0,0 +1,76 @@
+package executetest
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+)
+
+func goldenTestTables() []*Table {
+	cols := []flux.ColMeta{
+		{Label: "_time", Type: flux.TTime},
+		{Label: "t0", Type: flux.TString},
+		{Label: "_value", Type: flux.TFloat},
+	}
+	return []*Table{
+		{
+			KeyCols: []string{"t0"},
+			ColMeta: cols,
+			Data: [][]interface{}{
+				{execute.Time(1 * time.Second), "a", 2.0},
+				{execute.Time(2 * time.Second), "a", 3.0},
+			},
+		},
+		{
+			KeyCols: []string{"t0"},
+			ColMeta: cols,
+			Data: [][]interface{}{
+				{execute.Time(1 * time.Second), "b", 4.0},
+			},
+		},
+	}
+}
+
+func TestAssertGolden(t *testing.T) {
+	AssertGolden(t, "testdata/golden.csv", goldenTestTables())
+}
+
+func TestEncodeTables(t *testing.T) {
+	var buf bytes.Buffer
+	if err := EncodeTables(&buf, goldenTestTables()); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := DecodeTables(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := DiffTables(goldenTestTables(), got); diff != "" {
+		t.Errorf("unexpected tables -want/+got:\n%s", diff)
+	}
+}
+
+func TestDiffTables(t *testing.T) {
+	got := goldenTestTables()
+	got[0].Data = [][]interface{}{
+		{execute.Time(1 * time.Second), "a", 2.0},
+		{execute.Time(3 * time.Second), "a", 3.0},
+	}
+	got[1].Data = [][]interface{}{
+		{execute.Time(1 * time.Second), "c", 4.0},
+	}
+
+	want := "" +
+		" table {t0=a}:\n" +
+		"-  row 1: _time=1970-01-01T00:00:02.000000000Z,t0=a,_value=3\n" +
+		"+  row 1: _time=1970-01-01T00:00:03.000000000Z,t0=a,_value=3\n" +
+		"-table {t0=b}: 1 rows\n" +
+		"+table {t0=c}: 1 rows\n"
+	if diff := cmp.Diff(want, DiffTables(goldenTestTables(), got)); diff != "" {
+		t.Errorf("unexpected diff -want/+got:\n%s", diff)
+	}
+}
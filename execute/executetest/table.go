@@ -159,6 +159,28 @@ func (t *Table) Do(f func(flux.ColReader) error) error {
 			}
 			cols[j] = b.NewIntArray()
 			b.Release()
+		case flux.TDuration:
+			b := arrow.NewIntBuilder(t.Alloc)
+			for i := range t.Data {
+				if v := t.Data[i][j]; v != nil {
+					b.Append(int64(v.(values.Duration).Duration()))
+				} else {
+					b.AppendNull()
+				}
+			}
+			cols[j] = b.NewIntArray()
+			b.Release()
+		case flux.TBytes:
+			b := arrow.NewBinaryBuilder(t.Alloc)
+			for i := range t.Data {
+				if v := t.Data[i][j]; v != nil {
+					b.Append(v.([]byte))
+				} else {
+					b.AppendNull()
+				}
+			}
+			cols[j] = b.NewBinaryArray()
+			b.Release()
 		case flux.TUInt:
 			b := arrow.NewUintBuilder(t.Alloc)
 			for i := range t.Data {
@@ -170,6 +192,21 @@ func (t *Table) Do(f func(flux.ColReader) error) error {
 			}
 			cols[j] = b.NewUintArray()
 			b.Release()
+		default:
+			if col.Type.IsArray() {
+				b := arrow.NewListBuilder(col.Type.ElemType(), t.Alloc)
+				for i := range t.Data {
+					if v := t.Data[i][j]; v != nil {
+						if err := arrow.AppendArray(b, v.(values.Array)); err != nil {
+							panic(err)
+						}
+					} else {
+						b.AppendNull()
+					}
+				}
+				cols[j] = b.NewListArray()
+				b.Release()
+			}
 		}
 	}
 
@@ -231,6 +268,18 @@ func (cr *ColReader) Times(j int) *array.Int {
 	return cr.cols[j].(*array.Int)
 }
 
+func (cr *ColReader) Bytes(j int) *array.Binary {
+	return cr.cols[j].(*array.Binary)
+}
+
+func (cr *ColReader) Durations(j int) *array.Int {
+	return cr.cols[j].(*array.Int)
+}
+
+func (cr *ColReader) Arrays(j int) *array.List {
+	return cr.cols[j].(*array.List)
+}
+
 func (cr *ColReader) Retain() {
 	for _, col := range cr.cols {
 		col.Retain()
@@ -309,6 +358,28 @@ func (t *RowWiseTable) Do(f func(flux.ColReader) error) error {
 			}
 			cols[j] = b.NewIntArray()
 			b.Release()
+		case flux.TDuration:
+			b := arrow.NewIntBuilder(nil)
+			for i := range t.Data {
+				if v := t.Data[i][j]; v != nil {
+					b.Append(int64(v.(values.Duration).Duration()))
+				} else {
+					b.AppendNull()
+				}
+			}
+			cols[j] = b.NewIntArray()
+			b.Release()
+		case flux.TBytes:
+			b := arrow.NewBinaryBuilder(nil)
+			for i := range t.Data {
+				if v := t.Data[i][j]; v != nil {
+					b.Append(v.([]byte))
+				} else {
+					b.AppendNull()
+				}
+			}
+			cols[j] = b.NewBinaryArray()
+			b.Release()
 		case flux.TUInt:
 			b := arrow.NewUintBuilder(nil)
 			for i := range t.Data {
@@ -320,6 +391,21 @@ func (t *RowWiseTable) Do(f func(flux.ColReader) error) error {
 			}
 			cols[j] = b.NewUintArray()
 			b.Release()
+		default:
+			if col.Type.IsArray() {
+				b := arrow.NewListBuilder(col.Type.ElemType(), nil)
+				for i := range t.Data {
+					if v := t.Data[i][j]; v != nil {
+						if err := arrow.AppendArray(b, v.(values.Array)); err != nil {
+							panic(err)
+						}
+					} else {
+						b.AppendNull()
+					}
+				}
+				cols[j] = b.NewListArray()
+				b.Release()
+			}
 		}
 	}
 
@@ -345,8 +431,16 @@ func (t *RowWiseTable) Do(f func(flux.ColReader) error) error {
 				row[j] = arrow.StringSlice(cols[j].(*array.String), i, i+1)
 			case flux.TTime:
 				row[j] = arrow.IntSlice(cols[j].(*array.Int), i, i+1)
+			case flux.TBytes:
+				row[j] = arrow.BytesSlice(cols[j].(*array.Binary), i, i+1)
+			case flux.TDuration:
+				row[j] = arrow.IntSlice(cols[j].(*array.Int), i, i+1)
 			case flux.TUInt:
 				row[j] = arrow.UintSlice(cols[j].(*array.Uint), i, i+1)
+			default:
+				if col.Type.IsArray() {
+					row[j] = arrow.Slice(cols[j], int64(i), int64(i+1))
+				}
 			}
 		}
 		if err := f(&ColReader{
@@ -442,6 +536,28 @@ func (t *ParallelTable) Do(f func(flux.ColReader) error) error {
 			}
 			cols[j] = b.NewIntArray()
 			b.Release()
+		case flux.TDuration:
+			b := arrow.NewIntBuilder(t.Alloc)
+			for i := range t.Data {
+				if v := t.Data[i][j]; v != nil {
+					b.Append(int64(v.(values.Duration).Duration()))
+				} else {
+					b.AppendNull()
+				}
+			}
+			cols[j] = b.NewIntArray()
+			b.Release()
+		case flux.TBytes:
+			b := arrow.NewBinaryBuilder(t.Alloc)
+			for i := range t.Data {
+				if v := t.Data[i][j]; v != nil {
+					b.Append(v.([]byte))
+				} else {
+					b.AppendNull()
+				}
+			}
+			cols[j] = b.NewBinaryArray()
+			b.Release()
 		case flux.TUInt:
 			b := arrow.NewUintBuilder(t.Alloc)
 			for i := range t.Data {
@@ -453,6 +569,21 @@ func (t *ParallelTable) Do(f func(flux.ColReader) error) error {
 			}
 			cols[j] = b.NewUintArray()
 			b.Release()
+		default:
+			if col.Type.IsArray() {
+				b := arrow.NewListBuilder(col.Type.ElemType(), t.Alloc)
+				for i := range t.Data {
+					if v := t.Data[i][j]; v != nil {
+						if err := arrow.AppendArray(b, v.(values.Array)); err != nil {
+							panic(err)
+						}
+					} else {
+						b.AppendNull()
+					}
+				}
+				cols[j] = b.NewListArray()
+				b.Release()
+			}
 		}
 	}
 
@@ -512,7 +643,15 @@ func ConvertTable(tbl flux.Table) (*Table, error) {
 					v = key.ValueString(j)
 				case flux.TTime:
 					v = key.ValueTime(j)
+				case flux.TBytes:
+					v = key.Value(j).Bytes()
+				case flux.TDuration:
+					v = key.ValueDuration(j)
 				default:
+					if c.Type.IsArray() {
+						v = key.Value(j).Array()
+						break
+					}
 					return nil, fmt.Errorf("unsupported column type %v", c.Type)
 				}
 			}
@@ -550,7 +689,21 @@ func ConvertTable(tbl flux.Table) (*Table, error) {
 					if col := cr.Times(j); col.IsValid(i) {
 						row[j] = values.Time(col.Value(i))
 					}
+				case flux.TBytes:
+					if col := cr.Bytes(j); col.IsValid(i) {
+						row[j] = append([]byte(nil), col.Value(i)...)
+					}
+				case flux.TDuration:
+					if col := cr.Durations(j); col.IsValid(i) {
+						row[j] = values.ConvertDurationNsecs(time.Duration(col.Value(i)))
+					}
 				default:
+					if c.Type.IsArray() {
+						if col := cr.Arrays(j); col.IsValid(i) {
+							row[j] = arrow.ArrayValue(col, i, c.Type.ElemType()).Array()
+						}
+						break
+					}
 					panic(fmt.Errorf("unknown column type %s", c.Type))
 				}
 			}
@@ -898,7 +1051,14 @@ func RunTableTests(t *testing.T, tt TableTest) {
 							return cr.Bools(i).Len()
 						case flux.TTime:
 							return cr.Times(i).Len()
+						case flux.TBytes:
+							return cr.Bytes(i).Len()
+						case flux.TDuration:
+							return cr.Durations(i).Len()
 						default:
+							if cr.Cols()[i].Type.IsArray() {
+								return cr.Arrays(i).Len()
+							}
 							panic(fmt.Errorf("unexpected column type: %v", cr.Cols()[i].Type))
 						}
 					}(cr, i)
@@ -955,6 +1115,18 @@ func colReadersEqual(a, b flux.ColReader) bool {
 			if a.Times(i) != b.Times(i) {
 				return false
 			}
+		case flux.TBytes:
+			if a.Bytes(i) != b.Bytes(i) {
+				return false
+			}
+		case flux.TDuration:
+			if a.Durations(i) != b.Durations(i) {
+				return false
+			}
+		default:
+			if a.Cols()[i].Type.IsArray() && a.Arrays(i) != b.Arrays(i) {
+				return false
+			}
 		}
 	}
 	return true
@@ -64,13 +64,19 @@ type executionState struct {
 	resources flux.ResourceManagement
 
 	results map[string]flux.Result
+	limits  *outputLimits
 	sources []Source
 	statsCh chan flux.Statistics
 
 	transports []AsyncTransport
 
 	dispatcher *poolDispatcher
+	metrics    *executionMetrics
 	logger     *zap.Logger
+
+	// deterministic is set when the query is executed
+	// with WithDeterministicExecution.
+	deterministic bool
 }
 
 func (e *executor) Execute(ctx context.Context, p *plan.Spec, a memory.Allocator) (map[string]flux.Result, <-chan flux.Statistics, error) {
@@ -91,10 +97,23 @@ func (e *executor) createExecutionState(ctx context.Context, p *plan.Spec, a mem
 		alloc:     a,
 		resources: p.Resources,
 		results:   make(map[string]flux.Result),
+		limits:    newOutputLimits(p.Resources),
 		// TODO(nathanielc): Have the planner specify the dispatcher throughput
-		dispatcher: newPoolDispatcher(10, e.logger),
-		logger:     e.logger,
+		dispatcher:    newPoolDispatcher(10, e.logger),
+		metrics:       newExecutionMetrics(ctx, a),
+		logger:        e.logger,
+		deterministic: IsDeterministicExecution(ctx),
+	}
+	es.dispatcher.metrics = es.metrics
+
+	// Run the parallel parts of the plan in remote processes
+	// when a scheduler is available.
+	if GetScheduler(ctx) != nil {
+		if err := scheduleRemoteFragments(p); err != nil {
+			return nil, err
+		}
 	}
+
 	v := &createExecutionNodeVisitor{
 		es:    es,
 		nodes: make(map[plan.Node][]Node),
@@ -114,6 +133,7 @@ func (e *executor) createExecutionState(ctx context.Context, p *plan.Spec, a mem
 	if err := es.validate(); err != nil {
 		return nil, errors.Wrap(err, codes.Invalid, "execution state")
 	}
+	es.applyMemoryQuotas()
 
 	return v.es, nil
 }
@@ -188,9 +208,19 @@ func (v *createExecutionNodeVisitor) Visit(node plan.Node) error {
 	// 3. Merge instantiation. There is a single copy of the node, but multiple copies of the
 	//    predecessors. These copies merge into the node.
 
-	copies := 1
+	copies, factor := 1, 1
 	if attr := plan.GetOutputAttribute(ppn, plan.ParallelRunKey); attr != nil {
 		copies = attr.(plan.ParallelRunAttribute).Factor
+		factor = copies
+	}
+
+	// When a single partition of the plan is executed,
+	// only the copy for that partition is made.
+	partition, partitioned := partitionFromContext(v.es.ctx)
+	if partitioned && copies > 1 {
+		copies = 1
+	} else {
+		partitioned = false
 	}
 
 	isParallelMerge := false
@@ -205,10 +235,14 @@ func (v *createExecutionNodeVisitor) Visit(node plan.Node) error {
 	for i := 0; i < copies; i++ {
 		ec[i] = executionContext{
 			es:            v.es,
+			alloc:         v.es.nodeAllocator(node.ID()),
 			parents:       make([]DatasetID, len(node.Predecessors())*predCopies),
 			streamContext: streamContext,
 			parallelOpts:  ParallelOpts{Group: i, Factor: copies},
 		}
+		if partitioned {
+			ec[i].parallelOpts = ParallelOpts{Group: partition, Factor: factor}
+		}
 
 		for pi, pred := range nonYieldPredecessors(node) {
 			for j := 0; j < predCopies; j++ {
@@ -283,7 +317,8 @@ func (v *createExecutionNodeVisitor) Visit(node plan.Node) error {
 				for j := 0; j < predCopies; j++ {
 					// Either i == 0 && j == 0: we are either iterating i, or we are iterating j.
 					executionNode := v.nodes[p][i+j]
-					transport := newConsecutiveTransport(v.es.ctx, v.es.dispatcher, tr, node, v.es.logger, v.es.alloc)
+					transport := newConsecutiveTransport(v.es.ctx, v.es.dispatcher, tr, node, v.es.logger, ec[i].alloc)
+					transport.groupRows = newGroupRowLimit(p.ID(), v.es.resources)
 					v.es.transports = append(v.es.transports, transport)
 					executionNode.AddTransformation(transport)
 				}
@@ -326,6 +361,8 @@ func (v *createExecutionNodeVisitor) generateResult(resultName string, node plan
 		return errors.Newf(codes.Invalid, "tried to produce more than one result with the name %q", resultName)
 	}
 	r := newResult(resultName)
+	r.id, r.limits = skipYields(node).ID(), v.es.limits
+	r.sorted = v.es.deterministic || v.es.p.StableOutput
 	v.es.results[resultName] = r
 	v.nodes[skipYields(node)][idx].AddTransformation(r)
 	return nil
@@ -361,9 +398,47 @@ func (es *executionState) validate() error {
 	if es.resources.ConcurrencyQuota == 0 {
 		return errors.New(codes.Invalid, "execution state must have a non-zero concurrency quota")
 	}
+	if es.resources.MemoryBytesSoftQuota < 0 {
+		return errors.New(codes.Invalid, "execution state must not have a negative soft memory quota")
+	} else if es.resources.MemoryBytesSoftQuota > es.resources.MemoryBytesQuota {
+		return errors.Newf(codes.Invalid, "soft memory quota %d must not be greater than the memory quota %d",
+			es.resources.MemoryBytesSoftQuota, es.resources.MemoryBytesQuota)
+	}
+	if es.resources.MaxOutputRows < 0 || es.resources.MaxOutputTables < 0 || es.resources.MaxGroupRows < 0 {
+		return errors.New(codes.Invalid, "execution state must not have negative output limits")
+	}
 	return nil
 }
 
+// applyMemoryQuotas sets the limits of the allocator from the memory quotas.
+// Limits that were already set on the allocator are kept.
+//
+// The memory quota is a hard limit that fails the query when it is exceeded.
+// The soft memory quota only logs a message when the query goes above it.
+// It does not spill or release any memory.
+func (es *executionState) applyMemoryQuotas() {
+	a, ok := es.alloc.(*memory.ResourceAllocator)
+	if !ok || a == nil {
+		return
+	}
+	if quota := es.resources.MemoryBytesQuota; a.Limit == nil && quota < math.MaxInt64 {
+		a.Limit = &quota
+	}
+	if soft := es.resources.MemoryBytesSoftQuota; a.SoftLimit == nil && soft > 0 {
+		a.SoftLimit = &soft
+		if a.OnSoftLimit == nil {
+			a.OnSoftLimit = es.onSoftLimit
+		}
+	}
+}
+
+func (es *executionState) onSoftLimit(allocated int64) {
+	es.logger.Info("Query memory exceeded the soft limit",
+		zap.Int64("allocated", allocated),
+		zap.Int64("soft_limit", es.resources.MemoryBytesSoftQuota),
+	)
+}
+
 type queryConcurrencyVisitor struct {
 	seen                        map[plan.Node]bool
 	concurrencyQuota            int
@@ -483,12 +558,25 @@ func (es *executionState) chooseDefaultResources(ctx context.Context, p *plan.Sp
 		es.resources.MemoryBytesQuota = math.MaxInt64
 	}
 
-	// Update concurrency quota
-	if es.resources.ConcurrencyQuota == 0 {
+	// Update concurrency quota. A deterministic execution
+	// dispatches all of the work to a single goroutine.
+	if es.deterministic {
+		es.resources.ConcurrencyQuota = 1
+	} else if es.resources.ConcurrencyQuota == 0 {
 		es.resources.ConcurrencyQuota = computeQueryConcurrencyQuota(ctx, p)
 	}
 }
 
+// nodeAllocator returns the allocator for the plan node.
+// The memory allocated by the node is accounted to the node
+// when the allocator of the execution tracks memory by node.
+func (es *executionState) nodeAllocator(id plan.NodeID) memory.Allocator {
+	if a, ok := es.alloc.(*memory.ResourceAllocator); ok && a != nil {
+		return a.ForNode(string(id))
+	}
+	return es.alloc
+}
+
 func (es *executionState) abort(err error) {
 	for _, r := range es.results {
 		r.(*result).abort(err)
@@ -509,39 +597,59 @@ func (es *executionState) do() {
 		fn(&stats)
 	}
 
-	stats.Metadata = make(metadata.Metadata)
-	for _, src := range es.sources {
-		wg.Add(1)
-		go func(src Source) {
-			ctx := es.ctx
-			opName := reflect.TypeOf(src).String()
-
-			// If operator profiling is enabled for this execution, begin profiling
-			profile := flux.TransportProfile{
-				NodeType: opName,
-				Label:    src.Label(),
-			}
-			profileSpan := profile.StartSpan()
+	es.metrics.start()
 
-			if span, spanCtx := opentracing.StartSpanFromContext(ctx, opName, opentracing.Tag{Key: "label", Value: src.Label()}); span != nil {
-				ctx = spanCtx
-				defer span.Finish()
-			}
+	stats.Metadata = make(metadata.Metadata)
+	runSource := func(src Source) {
+		opName := reflect.TypeOf(src).String()
+		ctx := withGoroutineLabels(es.ctx,
+			GoroutineLabel, "source",
+			NodeIDLabel, src.Label(),
+			NodeTypeLabel, opName,
+		)
+
+		// If operator profiling is enabled for this execution, begin profiling
+		profile := flux.TransportProfile{
+			NodeType: opName,
+			Label:    src.Label(),
+		}
+		profileSpan := profile.StartSpan()
 
-			defer wg.Done()
+		if span, spanCtx := opentracing.StartSpanFromContext(ctx, opName, opentracing.Tag{Key: "label", Value: src.Label()}); span != nil {
+			ctx = spanCtx
+			defer span.Finish()
+		}
 
-			// Setup panic handling on the source goroutines
-			defer es.recover()
-			src.Run(ctx)
-			profileSpan.Finish()
+		// Setup panic handling on the source goroutines
+		defer es.recover()
+		src.Run(ctx)
+		profileSpan.Finish()
 
-			updateStats(func(stats *flux.Statistics) {
-				stats.Profiles = append(stats.Profiles, profile)
-				if mdn, ok := src.(MetadataNode); ok {
-					stats.Metadata.AddAll(mdn.Metadata())
-				}
-			})
-		}(src)
+		updateStats(func(stats *flux.Statistics) {
+			stats.Profiles = append(stats.Profiles, profile)
+			if mdn, ok := src.(MetadataNode); ok {
+				stats.Metadata.AddAll(mdn.Metadata())
+			}
+		})
+	}
+	if es.deterministic {
+		// Run the sources one after another so the messages
+		// are scheduled in the same order for every execution.
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, src := range es.sources {
+				runSource(src)
+			}
+		}()
+	} else {
+		for _, src := range es.sources {
+			wg.Add(1)
+			go func(src Source) {
+				defer wg.Done()
+				runSource(src)
+			}(src)
+		}
 	}
 
 	wg.Add(1)
@@ -552,6 +660,7 @@ func (es *executionState) do() {
 	profiles := make([]flux.TransportProfile, 0, len(es.transports))
 	go func() {
 		defer wg.Done()
+		withGoroutineLabels(es.ctx, GoroutineLabel, "executor")
 
 		// Wait for all transports to finish
 		for _, t := range es.transports {
@@ -577,6 +686,7 @@ func (es *executionState) do() {
 	go func() {
 		defer close(es.statsCh)
 		wg.Wait()
+		es.metrics.finish()
 
 		// Merge the transport profiles in with the ones already filled
 		// by the sources.
@@ -594,6 +704,7 @@ type ParallelOpts struct {
 // Need a unique stream context per execution context
 type executionContext struct {
 	es            *executionState
+	alloc         memory.Allocator
 	parents       []DatasetID
 	streamContext streamContext
 	parallelOpts  ParallelOpts
@@ -616,7 +727,7 @@ func (ec executionContext) StreamContext() StreamContext {
 }
 
 func (ec executionContext) Allocator() memory.Allocator {
-	return ec.es.alloc
+	return ec.alloc
 }
 
 func (ec executionContext) Parents() []DatasetID {
@@ -3,6 +3,7 @@ package execute_test
 import (
 	"context"
 	"math"
+	"strings"
 	"testing"
 	"time"
 
@@ -26,6 +27,7 @@ import (
 func init() {
 	execute.RegisterSource(executetest.FromTestKind, executetest.CreateFromSource)
 	execute.RegisterSource(executetest.AllocatingFromTestKind, executetest.CreateAllocatingFromSource)
+	execute.RegisterSource(heldFromTestKind, createHeldFromSource)
 	execute.RegisterTransformation(executetest.ToTestKind, executetest.CreateToTransformation)
 	plan.RegisterProcedureSpecWithSideEffect(executetest.ToTestKind, executetest.NewToProcedure, executetest.ToTestKind)
 }
@@ -686,6 +688,7 @@ func TestExecutor_Execute(t *testing.T) {
 				Err: memory.LimitExceededError{
 					Limit:  64,
 					Wanted: 65,
+					Node:   "allocating-from-test",
 				},
 			},
 		},
@@ -802,3 +805,320 @@ func TestExecutor_Execute(t *testing.T) {
 		})
 	}
 }
+
+func TestExecutor_Limits(t *testing.T) {
+	newTable := func(tag string) *executetest.Table {
+		return &executetest.Table{
+			KeyCols: []string{"t0"},
+			ColMeta: []flux.ColMeta{
+				{Label: "t0", Type: flux.TString},
+				{Label: "_value", Type: flux.TFloat},
+			},
+			Data: [][]interface{}{
+				{tag, 1.0},
+				{tag, 2.0},
+				{tag, 3.0},
+			},
+		}
+	}
+
+	testcases := []struct {
+		name      string
+		tags      []string
+		resources flux.ResourceManagement
+		wantErr   string
+	}{
+		{
+			name: "within limits",
+			resources: flux.ResourceManagement{
+				MaxOutputRows:   6,
+				MaxOutputTables: 2,
+				MaxGroupRows:    3,
+			},
+		},
+		{
+			name:      "max output rows",
+			resources: flux.ResourceManagement{MaxOutputRows: 4},
+			wantErr:   `node "filter" exceeded the maximum of 4 output rows`,
+		},
+		{
+			name:      "max output tables",
+			resources: flux.ResourceManagement{MaxOutputTables: 1},
+			wantErr:   `node "filter" exceeded the maximum of 1 output tables`,
+		},
+		{
+			name:      "max group rows",
+			resources: flux.ResourceManagement{MaxGroupRows: 2},
+			wantErr:   `node "from-test" exceeded the maximum of 2 rows for group key`,
+		},
+		{
+			// The rows are limited for each table so tables
+			// with the same group key are counted separately.
+			name:      "max group rows per table",
+			tags:      []string{"a", "a"},
+			resources: flux.ResourceManagement{MaxGroupRows: 3},
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tags := tc.tags
+			if tags == nil {
+				tags = []string{"a", "b"}
+			}
+			data := make([]*executetest.Table, 0, len(tags))
+			for _, tag := range tags {
+				data = append(data, newTable(tag))
+			}
+			spec := &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("from-test", executetest.NewFromProcedureSpec(data)),
+					plan.CreatePhysicalNode("filter", &universe.FilterProcedureSpec{
+						Fn: interpreter.ResolvedFunction{
+							Fn:    executetest.FunctionExpression(t, "(r) => true"),
+							Scope: runtime.Prelude(),
+						},
+					}),
+					plan.CreatePhysicalNode("yield", executetest.NewYieldProcedureSpec("_result")),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+				},
+				Resources: tc.resources,
+				Now:       time.Now(),
+			}
+			spec.Resources.ConcurrencyQuota = 1
+			spec.Resources.MemoryBytesQuota = math.MaxInt64
+
+			exe := execute.NewExecutor(zaptest.NewLogger(t))
+			ctx, deps := dependency.Inject(context.Background(), executetest.NewTestExecuteDependencies())
+			defer deps.Finish()
+
+			results, _, err := exe.Execute(ctx, plantest.CreatePlanSpec(spec), executetest.UnlimitedAllocator)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, r := range results {
+				if err = r.Tables().Do(func(tbl flux.Table) error {
+					_, err := executetest.ConvertTable(tbl)
+					return err
+				}); err != nil {
+					break
+				}
+			}
+
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected an error %q but got none", tc.wantErr)
+			}
+			if got := err.Error(); !strings.Contains(got, tc.wantErr) {
+				t.Errorf("expected an error containing %q, got %q", tc.wantErr, got)
+			}
+			if want, got := codes.ResourceExhausted, flux.ErrorCode(err); want != got {
+				t.Errorf("unexpected error code -want/+got:\n\t- %v\n\t+ %v", want, got)
+			}
+		})
+	}
+}
+
+func TestExecutor_SortedResults(t *testing.T) {
+	newTable := func(tag string) *executetest.Table {
+		return &executetest.Table{
+			KeyCols: []string{"t0"},
+			ColMeta: []flux.ColMeta{
+				{Label: "t0", Type: flux.TString},
+				{Label: "_value", Type: flux.TFloat},
+			},
+			Data: [][]interface{}{
+				{tag, 1.0},
+				{tag, 2.0},
+			},
+		}
+	}
+
+	spec := &plantest.PlanSpec{
+		Nodes: []plan.Node{
+			plan.CreatePhysicalNode("from-test", executetest.NewFromProcedureSpec(
+				[]*executetest.Table{newTable("c"), newTable("a"), newTable("b")},
+			)),
+			plan.CreatePhysicalNode("filter", &universe.FilterProcedureSpec{
+				Fn: interpreter.ResolvedFunction{
+					Fn:    executetest.FunctionExpression(t, "(r) => true"),
+					Scope: runtime.Prelude(),
+				},
+			}),
+			plan.CreatePhysicalNode("yield", executetest.NewYieldProcedureSpec("_result")),
+		},
+		Edges: [][2]int{
+			{0, 1},
+			{1, 2},
+		},
+		Now: time.Now(),
+	}
+	spec.Resources.MemoryBytesQuota = math.MaxInt64
+
+	testcases := []struct {
+		name          string
+		deterministic bool
+		stableOutput  bool
+	}{
+		{
+			name:          "deterministic execution",
+			deterministic: true,
+		},
+		{
+			name:         "stable output",
+			stableOutput: true,
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			exe := execute.NewExecutor(zaptest.NewLogger(t))
+			ctx, deps := dependency.Inject(context.Background(), executetest.NewTestExecuteDependencies())
+			defer deps.Finish()
+			if tc.deterministic {
+				ctx = execute.WithDeterministicExecution(ctx)
+			}
+
+			p := plantest.CreatePlanSpec(spec.Copy())
+			p.StableOutput = tc.stableOutput
+			results, _, err := exe.Execute(ctx, p, executetest.UnlimitedAllocator)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// The tables are not sorted before they are compared
+			// since they are produced in the order of their group keys.
+			got := executetest.ConvertResult(results["_result"])
+			if got.Err != nil {
+				t.Fatal(got.Err)
+			}
+			want := []*executetest.Table{newTable("a"), newTable("b"), newTable("c")}
+			for _, tbl := range append(want, got.Tbls...) {
+				tbl.Normalize()
+			}
+			if !cmp.Equal(want, got.Tbls) {
+				t.Errorf("unexpected tables -want/+got:\n%s", cmp.Diff(want, got.Tbls))
+			}
+		})
+	}
+}
+
+const heldFromTestKind = "held-from-test"
+
+// heldFromProcedureSpec produces tables that are held open until they
+// have been read, like the tables of csv.from.
+type heldFromProcedureSpec struct {
+	plan.DefaultCost
+	data []*executetest.Table
+}
+
+func (s *heldFromProcedureSpec) Kind() plan.ProcedureKind { return heldFromTestKind }
+func (s *heldFromProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+func createHeldFromSource(s plan.ProcedureSpec, id execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	return execute.CreateSourceFromIterator(&heldFromSource{data: s.(*heldFromProcedureSpec).data}, id)
+}
+
+type heldFromSource struct {
+	data []*executetest.Table
+}
+
+func (s *heldFromSource) Do(ctx context.Context, f func(flux.Table) error) error {
+	for _, data := range s.data {
+		tbl := &heldTable{
+			// Each row is its own buffer so the table
+			// is made of multiple buffers.
+			Table: &executetest.RowWiseTable{Table: data},
+			done:  make(chan struct{}),
+		}
+		if err := f(tbl); err != nil {
+			return err
+		}
+		select {
+		case <-tbl.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// heldTable signals when it has been read.
+type heldTable struct {
+	flux.Table
+	done chan struct{}
+}
+
+func (t *heldTable) Do(f func(flux.ColReader) error) error {
+	defer close(t.done)
+	return t.Table.Do(f)
+}
+
+func TestExecutor_SortedResults_HeldTables(t *testing.T) {
+	newTable := func(tag string) *executetest.Table {
+		return &executetest.Table{
+			KeyCols: []string{"t0"},
+			ColMeta: []flux.ColMeta{
+				{Label: "t0", Type: flux.TString},
+				{Label: "_value", Type: flux.TFloat},
+			},
+			Data: [][]interface{}{
+				{tag, 1.0},
+				{tag, 2.0},
+				{tag, 3.0},
+			},
+		}
+	}
+
+	spec := &plantest.PlanSpec{
+		Nodes: []plan.Node{
+			plan.CreatePhysicalNode("held-from-test", &heldFromProcedureSpec{
+				data: []*executetest.Table{newTable("b"), newTable("a")},
+			}),
+			plan.CreatePhysicalNode("yield", executetest.NewYieldProcedureSpec("_result")),
+		},
+		Edges: [][2]int{
+			{0, 1},
+		},
+		Now: time.Now(),
+	}
+	spec.Resources.MemoryBytesQuota = math.MaxInt64
+
+	exe := execute.NewExecutor(zaptest.NewLogger(t))
+	ctx, deps := dependency.Inject(context.Background(), executetest.NewTestExecuteDependencies())
+	defer deps.Finish()
+	ctx = execute.WithDeterministicExecution(ctx)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	results, _, err := exe.Execute(ctx, plantest.CreatePlanSpec(spec), executetest.UnlimitedAllocator)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The source waits for each table to be read before it
+	// produces the next one, so the result must not wait until
+	// the source finishes to read the tables.
+	got := executetest.ConvertResult(results["_result"])
+	if got.Err != nil {
+		t.Fatal(got.Err)
+	}
+	want := []*executetest.Table{newTable("a"), newTable("b")}
+	for _, tbl := range append(want, got.Tbls...) {
+		tbl.Normalize()
+	}
+	if !cmp.Equal(want, got.Tbls) {
+		t.Errorf("unexpected tables -want/+got:\n%s", cmp.Diff(want, got.Tbls))
+	}
+}
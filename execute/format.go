@@ -1,13 +1,16 @@
 package execute
 
 import (
+	"encoding/hex"
 	"fmt"
 	"io"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/arrow"
 	"github.com/influxdata/flux/values"
 )
 
@@ -82,13 +85,15 @@ func (w *writeToHelper) write(data []byte) {
 }
 
 var minWidthsByType = map[flux.ColType]int{
-	flux.TBool:    12,
-	flux.TInt:     26,
-	flux.TUInt:    27,
-	flux.TFloat:   28,
-	flux.TString:  22,
-	flux.TTime:    len(fixedWidthTimeFmt),
-	flux.TInvalid: 10,
+	flux.TBool:     12,
+	flux.TInt:      26,
+	flux.TUInt:     27,
+	flux.TFloat:    28,
+	flux.TString:   22,
+	flux.TTime:     len(fixedWidthTimeFmt),
+	flux.TBytes:    22,
+	flux.TDuration: 22,
+	flux.TInvalid:  10,
 }
 
 // WriteTo writes the formatted table data to w.
@@ -106,6 +111,9 @@ func (f *Formatter) WriteTo(out io.Writer) (int64, error) {
 		// Column header is "<label>:<type>"
 		l := len(c.Label) + len(c.Type.String()) + 1
 		min := minWidthsByType[c.Type]
+		if c.Type.IsArray() {
+			min = minWidthsByType[flux.TString]
+		}
 		if min > l {
 			l = min
 		}
@@ -231,6 +239,12 @@ func (f *Formatter) writeHeaderSeparator(w *writeToHelper) {
 
 func (f *Formatter) valueBuf(i, j int, typ flux.ColType, cr flux.ColReader) []byte {
 	buf := []byte(f.opts.NullRepresentation)
+	if typ.IsArray() {
+		if cr.Arrays(j).IsValid(i) {
+			buf = []byte(values.DisplayString(arrow.ArrayValue(cr.Arrays(j), i, typ.ElemType())))
+		}
+		return buf
+	}
 	switch typ {
 	case flux.TBool:
 		if cr.Bools(j).IsValid(i) {
@@ -257,6 +271,17 @@ func (f *Formatter) valueBuf(i, j int, typ flux.ColType, cr flux.ColReader) []by
 		if cr.Times(j).IsValid(i) {
 			buf = []byte(values.Time(cr.Times(j).Value(i)).String())
 		}
+	case flux.TBytes:
+		if cr.Bytes(j).IsValid(i) {
+			v := cr.Bytes(j).Value(i)
+			buf = make([]byte, 2+hex.EncodedLen(len(v)))
+			copy(buf, "0x")
+			hex.Encode(buf[2:], v)
+		}
+	case flux.TDuration:
+		if cr.Durations(j).IsValid(i) {
+			buf = []byte(values.ConvertDurationNsecs(time.Duration(cr.Durations(j).Value(i))).String())
+		}
 	}
 	return buf
 }
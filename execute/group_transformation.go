@@ -12,6 +12,8 @@ import (
 // NarrowTransformation will pass the FlushKeyMsg to the Dataset
 // and GroupTransformation will swallow this Message.
 type GroupTransformation interface {
+	// Process will process the table.Chunk and send any output to the TransportDataset.
+	// The output may have a different group key than the chunk.
 	Process(chunk table.Chunk, d *TransportDataset, mem memory.Allocator) error
 
 	Closer
@@ -28,6 +30,8 @@ func (g *groupTransformation) OperationType() string {
 	return OperationType(g.t)
 }
 
+// NewGroupTransformation constructs a Transformation and Dataset
+// using the GroupTransformation implementation.
 func NewGroupTransformation(id DatasetID, t GroupTransformation, mem memory.Allocator) (Transformation, Dataset, error) {
 	g := &groupTransformation{
 		t: t,
@@ -0,0 +1,37 @@
+package execute
+
+import (
+	"context"
+	"runtime/pprof"
+)
+
+// The pprof labels that the executor attaches to the goroutines that
+// execute a query. A CPU profile can be filtered by these labels to find
+// the queries and operations that consume the CPU, such as with
+// `go tool pprof -tagfocus flux_query_id=<id>`.
+const (
+	// QueryIDLabel is the id of the query given to WithQueryID.
+	QueryIDLabel = "flux_query_id"
+	// NodeIDLabel is the id of the plan node that is executing.
+	NodeIDLabel = "flux_node_id"
+	// NodeTypeLabel is the type of the operation that is executing.
+	NodeTypeLabel = "flux_node_type"
+	// GoroutineLabel names the role of the goroutine in the executor.
+	// It is one of "source", "dispatcher" or "executor".
+	GoroutineLabel = "flux_goroutine"
+)
+
+// WithQueryID returns a context that adds the query id as a pprof label
+// to the goroutines that execute a query with the context.
+// An embedder calls it with the id it uses for the query before the query is started.
+func WithQueryID(ctx context.Context, id string) context.Context {
+	return pprof.WithLabels(ctx, pprof.Labels(QueryIDLabel, id))
+}
+
+// withGoroutineLabels adds the labels to the context and sets the
+// labels of the current goroutine to the labels of the returned context.
+func withGoroutineLabels(ctx context.Context, labels ...string) context.Context {
+	ctx = pprof.WithLabels(ctx, pprof.Labels(labels...))
+	pprof.SetGoroutineLabels(ctx)
+	return ctx
+}
@@ -0,0 +1,116 @@
+package execute
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/plan"
+)
+
+// outputLimits enforces the maximum number of rows and tables
+// in the results of a query. It is shared by all of the results.
+type outputLimits struct {
+	maxRows, maxTables int64
+	rows, tables       int64
+}
+
+func newOutputLimits(resources flux.ResourceManagement) *outputLimits {
+	if resources.MaxOutputRows <= 0 && resources.MaxOutputTables <= 0 {
+		return nil
+	}
+	return &outputLimits{
+		maxRows:   resources.MaxOutputRows,
+		maxTables: resources.MaxOutputTables,
+	}
+}
+
+// addTable counts a table produced by the node.
+func (l *outputLimits) addTable(id plan.NodeID) error {
+	if l == nil || l.maxTables <= 0 {
+		return nil
+	}
+	if n := atomic.AddInt64(&l.tables, 1); n > l.maxTables {
+		return errors.Newf(codes.ResourceExhausted, "node %q exceeded the maximum of %d output tables", id, l.maxTables)
+	}
+	return nil
+}
+
+// addRows counts n rows produced by the node.
+func (l *outputLimits) addRows(id plan.NodeID, n int) error {
+	if l == nil || l.maxRows <= 0 {
+		return nil
+	}
+	if rows := atomic.AddInt64(&l.rows, int64(n)); rows > l.maxRows {
+		return errors.Newf(codes.ResourceExhausted, "node %q exceeded the maximum of %d output rows", id, l.maxRows)
+	}
+	return nil
+}
+
+// limitedTable counts the rows of a result table as they are read.
+type limitedTable struct {
+	flux.Table
+	id     plan.NodeID
+	limits *outputLimits
+}
+
+func (t *limitedTable) Do(f func(flux.ColReader) error) error {
+	return t.Table.Do(func(cr flux.ColReader) error {
+		if err := t.limits.addRows(t.id, cr.Len()); err != nil {
+			return err
+		}
+		return f(cr)
+	})
+}
+
+// groupRowLimit enforces the maximum number of rows that a node
+// produces in a single table. It counts the rows of each table that
+// the node sends to one of its successors.
+type groupRowLimit struct {
+	id  plan.NodeID
+	max int64
+
+	mu   sync.Mutex
+	rows *GroupLookup
+}
+
+func newGroupRowLimit(id plan.NodeID, resources flux.ResourceManagement) *groupRowLimit {
+	if resources.MaxGroupRows <= 0 {
+		return nil
+	}
+	return &groupRowLimit{
+		id:   id,
+		max:  resources.MaxGroupRows,
+		rows: NewGroupLookup(),
+	}
+}
+
+// add counts n rows of the table with the group key.
+func (l *groupRowLimit) add(key flux.GroupKey, n int) error {
+	if l == nil || n == 0 {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	rows := l.rows.LookupOrCreate(key, func() interface{} {
+		return new(int64)
+	}).(*int64)
+	if *rows += int64(n); *rows > l.max {
+		return errors.Newf(codes.ResourceExhausted, "node %q exceeded the maximum of %d rows for group key %v", l.id, l.max, key)
+	}
+	return nil
+}
+
+// finish resets the count of the table with the group key
+// once the table has been sent.
+func (l *groupRowLimit) finish(key flux.GroupKey) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rows.Delete(key)
+}
@@ -0,0 +1,90 @@
+package execute
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/influxdata/flux/dependencies/metrics"
+	"github.com/influxdata/flux/memory"
+)
+
+// executionMetrics reports the metrics of a single execution
+// to the metrics registry of the context.
+//
+// A nil *executionMetrics is valid and reports nothing so
+// executions without a registry do not pay for the metrics.
+type executionMetrics struct {
+	registry metrics.Registry
+
+	// alloc reports the memory in use by the execution
+	// when the allocator tracks it.
+	alloc interface {
+		Allocated() int64
+	}
+	// allocated is the memory reported to the registry.
+	allocated int64
+}
+
+func newExecutionMetrics(ctx context.Context, a memory.Allocator) *executionMetrics {
+	registry := metrics.GetRegistry(ctx)
+	if registry == nil {
+		return nil
+	}
+	m := &executionMetrics{registry: registry}
+	if alloc, ok := a.(interface {
+		Allocated() int64
+	}); ok {
+		m.alloc = alloc
+	}
+	return m
+}
+
+// start records that the execution has started.
+func (m *executionMetrics) start() {
+	if m == nil {
+		return
+	}
+	m.registry.QueriesInFlight().Add(1)
+}
+
+// finish records that the execution has finished and
+// removes the memory it was using from the registry.
+func (m *executionMetrics) finish() {
+	if m == nil {
+		return
+	}
+	m.registry.QueriesInFlight().Add(-1)
+	if m.alloc != nil {
+		allocated := atomic.SwapInt64(&m.allocated, 0)
+		m.registry.AllocatedBytes().Add(float64(-allocated))
+	}
+}
+
+// updateAllocated reports the change in the memory in use
+// by the execution since the last update.
+func (m *executionMetrics) updateAllocated() {
+	if m == nil || m.alloc == nil {
+		return
+	}
+	allocated := m.alloc.Allocated()
+	if prev := atomic.SwapInt64(&m.allocated, allocated); prev != allocated {
+		m.registry.AllocatedBytes().Add(float64(allocated - prev))
+	}
+}
+
+// queued records a change in the amount of work waiting in the dispatcher.
+func (m *executionMetrics) queued(n int) {
+	if m == nil || n == 0 {
+		return
+	}
+	m.registry.DispatcherQueueDepth().Add(float64(n))
+}
+
+// nodeDuration returns the histogram of the processing time
+// of a node with the operation type.
+func (m *executionMetrics) nodeDuration(operationType string) metrics.Histogram {
+	if m == nil {
+		return nil
+	}
+	return m.registry.NodeDuration(operationType)
+}
@@ -8,8 +8,15 @@ import (
 
 // NarrowStateTransformation is the same as a NarrowTransformation
 // except that it retains state between processing buffers.
+//
+// The state is kept for each group key. If the state implements Closer,
+// it is closed when the table for its group key ends.
 type NarrowStateTransformation[T any] interface {
 	// Process will process the TableView.
+	//
+	// The state is the value returned by the previous invocation with
+	// the same group key or the zero value for the first invocation.
+	// The new state is kept when the returned boolean is true.
 	Process(chunk table.Chunk, state T, d *TransportDataset, mem memory.Allocator) (T, bool, error)
 
 	Closer
@@ -7,8 +7,19 @@ import (
 
 // NarrowTransformation implements a transformation that processes
 // a table.Chunk and does not modify its group key.
+//
+// It is one of the helpers that transformations outside of this module
+// should be built on, along with NarrowStateTransformation,
+// AggregateTransformation and GroupTransformation. The helpers handle
+// the messages between transformations and the state for each group key
+// so an implementation only transforms the data. See docs/Transformations.md
+// for a guide on choosing between them.
 type NarrowTransformation interface {
 	// Process will process the table.Chunk and send any output to the TransportDataset.
+	//
+	// The chunk is released after Process returns. The implementation
+	// must call Retain on the chunk to send it to the TransportDataset
+	// unmodified.
 	Process(chunk table.Chunk, d *TransportDataset, mem memory.Allocator) error
 
 	Closer
@@ -0,0 +1,100 @@
+package execute
+
+import (
+	"github.com/apache/arrow/go/v7/arrow/bitutil"
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/arrowutil"
+	"github.com/influxdata/flux/internal/errors"
+)
+
+// NullMode controls how a function treats null values in its input.
+type NullMode string
+
+const (
+	// NullModeDefault uses the default null handling of the function.
+	NullModeDefault NullMode = ""
+
+	// NullModeSkip ignores null values in the input.
+	NullModeSkip NullMode = "skip"
+
+	// NullModePropagate produces a null result
+	// when a null value is found in the input.
+	NullModePropagate NullMode = "propagate"
+)
+
+// ReadNullMode reads the nulls argument from the arguments.
+func ReadNullMode(args flux.Arguments) (NullMode, error) {
+	mode, ok, err := args.GetString("nulls")
+	if err != nil {
+		return NullModeDefault, err
+	} else if !ok {
+		return NullModeDefault, nil
+	}
+
+	switch m := NullMode(mode); m {
+	case NullModeSkip, NullModePropagate:
+		return m, nil
+	default:
+		return NullModeDefault, errors.Newf(codes.Invalid, "nulls must be %q or %q, got %q", NullModeSkip, NullModePropagate, mode)
+	}
+}
+
+// doAggregate passes the values in the array to the aggregate
+// function using the null mode. It reports whether the array
+// contained any null values.
+func doAggregate(vf ValueFunc, arr array.Array, mode NullMode, mem memory.Allocator) (bool, error) {
+	hasNull := arr.NullN() > 0
+	if hasNull {
+		switch mode {
+		case NullModePropagate:
+			// The result will be null so there is no reason
+			// to pass the values to the aggregate.
+			return true, nil
+		case NullModeSkip:
+			arr = dropNulls(arr, mem)
+			defer arr.Release()
+		}
+	}
+
+	switch arr := arr.(type) {
+	case *array.Boolean:
+		vf.(DoBoolAgg).DoBool(arr)
+	case *array.Int:
+		// Duration columns are also stored as int arrays.
+		if vf, ok := vf.(DoDurationAgg); ok {
+			vf.DoDuration(arr)
+			break
+		}
+		vf.(DoIntAgg).DoInt(arr)
+	case *array.Uint:
+		vf.(DoUIntAgg).DoUInt(arr)
+	case *array.Float:
+		vf.(DoFloatAgg).DoFloat(arr)
+	case *array.String:
+		vf.(DoStringAgg).DoString(arr)
+	default:
+		return hasNull, errors.Newf(codes.Internal, "aggregate of type %s not supported", arr.DataType())
+	}
+	return hasNull, nil
+}
+
+// dropNulls returns an array with the null values removed.
+// If the array does not contain any null values, the array
+// is retained and returned. The returned array must be released.
+func dropNulls(arr array.Array, mem memory.Allocator) array.Array {
+	if arr.NullN() == 0 {
+		arr.Retain()
+		return arr
+	}
+
+	bitset := memory.NewResizableBuffer(mem)
+	bitset.Resize(arr.Len())
+	defer bitset.Release()
+	for i, n := 0, arr.Len(); i < n; i++ {
+		bitutil.SetBitTo(bitset.Buf(), i, arr.IsValid(i))
+	}
+	return arrowutil.Filter(arr, bitset.Bytes(), mem)
+}
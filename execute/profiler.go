@@ -2,7 +2,10 @@ package execute
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/memory"
@@ -30,6 +33,7 @@ func init() {
 	RegisterProfilerFactories(
 		createQueryProfiler,
 		createOperatorProfiler,
+		createFunctionProfiler,
 	)
 }
 
@@ -283,3 +287,167 @@ func (s *QueryProfiler) getTableBuilder(q flux.Query, alloc memory.Allocator) (*
 	}
 	return b, nil
 }
+
+// FunctionProfiler records the time spent in each function called by a query.
+// It includes the calls made while the script is evaluated and the calls made
+// by functions, such as the fn parameter of map, while the query is executed.
+//
+// The duration of a call includes the calls that it makes to other functions.
+type FunctionProfiler struct {
+	// Allocator is the allocator of the query. When it reports the total
+	// bytes allocated, the bytes allocated during each call are recorded.
+	// Calls that run concurrently are attributed each other's allocations.
+	Allocator memory.Allocator
+
+	mu        sync.Mutex
+	functions map[string]*functionProfile
+}
+
+type functionProfile struct {
+	Count          int64
+	Min            int64
+	Max            int64
+	Sum            int64
+	TotalAllocated int64
+}
+
+func createFunctionProfiler() Profiler {
+	return &FunctionProfiler{}
+}
+
+func (p *FunctionProfiler) Name() string {
+	return "function"
+}
+
+// StartCall implements interpreter.FunctionProfiler.
+func (p *FunctionProfiler) StartCall(name string) func() {
+	allocated := p.totalAllocated()
+	start := time.Now()
+	return func() {
+		p.record(name, time.Since(start), p.totalAllocated()-allocated)
+	}
+}
+
+func (p *FunctionProfiler) totalAllocated() int64 {
+	if a, ok := p.Allocator.(interface{ TotalAllocated() int64 }); ok {
+		return a.TotalAllocated()
+	}
+	return 0
+}
+
+func (p *FunctionProfiler) record(name string, d time.Duration, allocated int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.functions == nil {
+		p.functions = make(map[string]*functionProfile)
+	}
+	fp, ok := p.functions[name]
+	if !ok {
+		fp = &functionProfile{Min: d.Nanoseconds(), Max: d.Nanoseconds()}
+		p.functions[name] = fp
+	}
+	fp.Count++
+	if n := d.Nanoseconds(); n < fp.Min {
+		fp.Min = n
+	} else if n > fp.Max {
+		fp.Max = n
+	}
+	fp.Sum += d.Nanoseconds()
+	fp.TotalAllocated += allocated
+}
+
+func (p *FunctionProfiler) GetResult(q flux.Query, alloc memory.Allocator) (flux.Table, error) {
+	b, err := p.getTableBuilder(alloc)
+	if err != nil {
+		return nil, err
+	}
+	return b.Table()
+}
+
+// GetSortedResult is identical to GetResult, except it calls Sort()
+// on the ColListTableBuilder to make testing easier.
+// sortKeys and desc are passed directly into the Sort() call
+func (p *FunctionProfiler) GetSortedResult(q flux.Query, alloc memory.Allocator, desc bool, sortKeys ...string) (flux.Table, error) {
+	b, err := p.getTableBuilder(alloc)
+	if err != nil {
+		return nil, err
+	}
+	b.Sort(sortKeys, desc)
+	return b.Table()
+}
+
+func (p *FunctionProfiler) getTableBuilder(alloc memory.Allocator) (*ColListTableBuilder, error) {
+	groupKey := NewGroupKey(
+		[]flux.ColMeta{
+			{
+				Label: "_measurement",
+				Type:  flux.TString,
+			},
+		},
+		[]values.Value{
+			values.NewString("profiler/function"),
+		},
+	)
+	b := NewColListTableBuilder(groupKey, alloc)
+	colMeta := []flux.ColMeta{
+		{
+			Label: "_measurement",
+			Type:  flux.TString,
+		},
+		{
+			Label: "Function",
+			Type:  flux.TString,
+		},
+		{
+			Label: "Count",
+			Type:  flux.TInt,
+		},
+		{
+			Label: "MinDuration",
+			Type:  flux.TInt,
+		},
+		{
+			Label: "MaxDuration",
+			Type:  flux.TInt,
+		},
+		{
+			Label: "DurationSum",
+			Type:  flux.TInt,
+		},
+		{
+			Label: "MeanDuration",
+			Type:  flux.TFloat,
+		},
+		{
+			Label: "TotalAllocated",
+			Type:  flux.TInt,
+		},
+	}
+	for _, col := range colMeta {
+		if _, err := b.AddCol(col); err != nil {
+			return nil, err
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	names := make([]string, 0, len(p.functions))
+	for name := range p.functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fp := p.functions[name]
+		b.AppendString(0, "profiler/function")
+		b.AppendString(1, name)
+		b.AppendInt(2, fp.Count)
+		b.AppendInt(3, fp.Min)
+		b.AppendInt(4, fp.Max)
+		b.AppendInt(5, fp.Sum)
+		b.AppendFloat(6, float64(fp.Sum)/float64(fp.Count))
+		b.AppendInt(7, fp.TotalAllocated)
+	}
+	return b, nil
+}
@@ -9,11 +9,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/csv"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/execute/executetest"
 	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/interpreter"
 	"github.com/influxdata/flux/lang"
 	"github.com/influxdata/flux/memory"
 	"github.com/influxdata/flux/metadata"
@@ -150,3 +152,61 @@ func TestQueryProfiler_GetResult(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestFunctionProfiler_GetResult(t *testing.T) {
+	deps := execute.DefaultExecutionDependencies()
+	ctx := deps.Inject(context.Background())
+
+	// The profiler is found through the context
+	// after the option has been evaluated.
+	if p := interpreter.GetFunctionProfiler(ctx); p != nil {
+		t.Fatalf("unexpected function profiler before it was enabled: %v", p)
+	}
+	execOptsConfig := lang.ExecOptsConfig{}
+	execOptsConfig.ConfigureProfiler(ctx, []string{"function"})
+	p := interpreter.GetFunctionProfiler(ctx)
+	if p == nil {
+		t.Fatal("expected a function profiler")
+	}
+
+	for i := 0; i < 3; i++ {
+		done := p.StartCall("strings.toUpper")
+		p.StartCall("map")()
+		done()
+	}
+
+	fp := execute.GetExecutionDependencies(ctx).ExecutionOptions.FunctionProfiler
+	tbl, err := fp.GetResult(&mock.Query{}, &memory.ResourceAllocator{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type row struct {
+		Function string
+		Count    int64
+	}
+	var got []row
+	if err := tbl.Do(func(cr flux.ColReader) error {
+		for i := 0; i < cr.Len(); i++ {
+			got = append(got, row{
+				Function: cr.Strings(1).Value(i),
+				Count:    cr.Ints(2).Value(i),
+			})
+			min, max, sum := cr.Ints(3).Value(i), cr.Ints(4).Value(i), cr.Ints(5).Value(i)
+			if min > max || sum < max {
+				t.Errorf("inconsistent durations for %s: min=%d max=%d sum=%d", got[len(got)-1].Function, min, max, sum)
+			}
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []row{
+		{Function: "map", Count: 3},
+		{Function: "strings.toUpper", Count: 3},
+	}
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected profile -want/+got:\n%s", cmp.Diff(want, got))
+	}
+}
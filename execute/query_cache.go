@@ -0,0 +1,44 @@
+package execute
+
+import (
+	"context"
+	"sync"
+)
+
+// QueryCache holds values that are computed once and reused while a query
+// executes, such as the values a row function derives from arguments that
+// are the same for every row. The cache belongs to the execution
+// dependencies of the query so its values are released with the query.
+type QueryCache struct {
+	mu      sync.Mutex
+	entries map[interface{}]interface{}
+}
+
+// LoadOrCreate returns the value of the key.
+// If the cache does not hold the key, the value is created with fn.
+// A nil cache creates a new value on every call.
+func (c *QueryCache) LoadOrCreate(key interface{}, fn func() interface{}) interface{} {
+	if c == nil {
+		return fn()
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if v, ok := c.entries[key]; ok {
+		return v
+	}
+	if c.entries == nil {
+		c.entries = make(map[interface{}]interface{})
+	}
+	v := fn()
+	c.entries[key] = v
+	return v
+}
+
+// GetQueryCache returns the cache of the query executing with the context.
+// It returns nil if the context has no execution dependencies.
+func GetQueryCache(ctx context.Context) *QueryCache {
+	if !HaveExecutionDependencies(ctx) {
+		return nil
+	}
+	return GetExecutionDependencies(ctx).Cache
+}
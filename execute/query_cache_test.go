@@ -0,0 +1,42 @@
+package execute_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/flux/execute"
+)
+
+func TestQueryCache(t *testing.T) {
+	type key struct{}
+	created := 0
+	create := func() interface{} {
+		created++
+		return created
+	}
+
+	ctx := execute.DefaultExecutionDependencies().Inject(context.Background())
+	cache := execute.GetQueryCache(ctx)
+	if got := cache.LoadOrCreate(key{}, create); got != 1 {
+		t.Fatalf("unexpected value: %v", got)
+	}
+	if got := execute.GetQueryCache(ctx).LoadOrCreate(key{}, create); got != 1 {
+		t.Fatalf("expected the cached value, got %v", got)
+	}
+
+	// Another query does not see the values of the first one.
+	other := execute.DefaultExecutionDependencies().Inject(context.Background())
+	if got := execute.GetQueryCache(other).LoadOrCreate(key{}, create); got != 2 {
+		t.Fatalf("unexpected value from another query: %v", got)
+	}
+
+	// Without execution dependencies nothing is cached.
+	if c := execute.GetQueryCache(context.Background()); c != nil {
+		t.Fatal("expected no cache without execution dependencies")
+	}
+	var none *execute.QueryCache
+	none.LoadOrCreate(key{}, create)
+	if got := none.LoadOrCreate(key{}, create); got != 4 {
+		t.Fatalf("expected a new value from a nil cache, got %v", got)
+	}
+}
@@ -0,0 +1,136 @@
+package execute
+
+import (
+	"context"
+
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/plan/planpb"
+)
+
+// RemoteFragmentKind is the kind of the plan node that reads
+// the partitions of a plan fragment executed by remote processes.
+//
+// The source for this kind is registered by the package that implements
+// the transport, such as execute/exchange.
+const RemoteFragmentKind = "remoteFragment"
+
+// RemoteFragmentProcedureSpec replaces the parallel part of a plan
+// that is executed by remote processes. The node runs in parallel
+// and each copy reads one partition of the fragment.
+//
+// The fragment is encoded with planpb.MarshalFragment.
+type RemoteFragmentProcedureSpec struct {
+	plan.DefaultCost
+	Fragment *planpb.Spec
+	Factor   int
+}
+
+func (s *RemoteFragmentProcedureSpec) Kind() plan.ProcedureKind {
+	return RemoteFragmentKind
+}
+
+func (s *RemoteFragmentProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+func (s *RemoteFragmentProcedureSpec) OutputAttributes() plan.PhysicalAttributes {
+	return plan.PhysicalAttributes{
+		plan.ParallelRunKey: plan.ParallelRunAttribute{Factor: s.Factor},
+	}
+}
+
+// Scheduler assigns the partitions of plan fragments to remote processes.
+//
+// When a Scheduler is injected into the context, the executor runs the
+// parallel part of each parallel merge in the plan remotely. Each partition
+// is executed by the process at the address returned by Schedule.
+type Scheduler interface {
+	// Schedule returns the address of the process that executes
+	// the partition of the fragment.
+	Schedule(ctx context.Context, fragment *planpb.Spec, partition, factor int) (string, error)
+}
+
+type remoteKey int
+
+const (
+	schedulerKey remoteKey = iota
+	partitionKey
+)
+
+// InjectScheduler will inject the Scheduler into the dependency chain.
+func InjectScheduler(ctx context.Context, s Scheduler) context.Context {
+	return context.WithValue(ctx, schedulerKey, s)
+}
+
+// SchedulerDependency will inject the Scheduler into the dependency chain.
+type SchedulerDependency struct {
+	Scheduler Scheduler
+}
+
+// Inject will inject the Scheduler into the dependency chain.
+func (d SchedulerDependency) Inject(ctx context.Context) context.Context {
+	return InjectScheduler(ctx, d.Scheduler)
+}
+
+// GetScheduler will return the Scheduler for the current context.
+// If no Scheduler has been injected into the dependencies,
+// this will return nil and plans are executed locally.
+func GetScheduler(ctx context.Context) Scheduler {
+	s, _ := ctx.Value(schedulerKey).(Scheduler)
+	return s
+}
+
+// WithPartition configures the executor to run a single partition
+// of the parallel nodes in the plan. It is used by the processes
+// that execute a partition of a plan fragment.
+func WithPartition(ctx context.Context, partition int) context.Context {
+	return context.WithValue(ctx, partitionKey, partition)
+}
+
+func partitionFromContext(ctx context.Context) (int, bool) {
+	p, ok := ctx.Value(partitionKey).(int)
+	return p, ok
+}
+
+// scheduleRemoteFragments replaces the parallel predecessor of each parallel
+// merge node in the plan with a node that reads the partitions of the
+// predecessor from remote processes.
+//
+// It returns an error if one of the predecessors cannot be encoded
+// so a plan is never executed locally when remote execution was requested.
+func scheduleRemoteFragments(p *plan.Spec) error {
+	var merges []plan.Node
+	if err := p.TopDownWalk(func(node plan.Node) error {
+		if len(node.Predecessors()) != 1 {
+			return nil
+		}
+		if attr := plan.GetOutputAttribute(node, plan.ParallelMergeKey); attr != nil {
+			merges = append(merges, node)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, merge := range merges {
+		attr := plan.GetOutputAttribute(merge, plan.ParallelMergeKey).(plan.ParallelMergeAttribute)
+		pred := merge.Predecessors()[0]
+		fragment, err := planpb.MarshalFragment(pred, p)
+		if err != nil {
+			return errors.Wrapf(err, codes.Inherit, "cannot execute plan node %q remotely", pred.ID())
+		}
+
+		remote := plan.CreatePhysicalNode(pred.ID(), &RemoteFragmentProcedureSpec{
+			Fragment: fragment,
+			Factor:   attr.Factor,
+		})
+		remote.SetBounds(pred.Bounds())
+		remote.AddSuccessors(merge)
+		merge.ClearPredecessors()
+		merge.AddPredecessors(remote)
+	}
+	return nil
+}
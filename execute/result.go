@@ -1,9 +1,11 @@
 package execute
 
 import (
+	"sort"
 	"sync"
 
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/plan"
 )
 
 // result implements both the Transformation and Result interfaces,
@@ -12,6 +14,17 @@ type result struct {
 	ExecutionNode
 	name string
 
+	// id is the node that produces the result and
+	// limits is set when the output of the query is limited.
+	id     plan.NodeID
+	limits *outputLimits
+
+	// sorted is set when the tables are produced in the order
+	// of their group keys after the result has finished.
+	// The tables are copied and buffered until then.
+	sorted   bool
+	buffered []flux.Table
+
 	mu     sync.Mutex
 	tables chan resultMessage
 
@@ -43,13 +56,39 @@ func (s *result) RetractTable(DatasetID, flux.GroupKey) error {
 }
 
 func (s *result) Process(id DatasetID, tbl flux.Table) error {
-	select {
-	case s.tables <- resultMessage{
+	if s.limits != nil {
+		if err := s.limits.addTable(s.id); err != nil {
+			tbl.Done()
+			return err
+		}
+		tbl = &limitedTable{Table: tbl, id: s.id, limits: s.limits}
+	}
+	if s.sorted {
+		// The table is copied because some producers wait
+		// for their table to be consumed before they finish.
+		buffered, err := CopyTable(tbl)
+		if err != nil {
+			return err
+		}
+		s.buffered = append(s.buffered, buffered)
+		return nil
+	}
+	s.send(resultMessage{
 		table: tbl,
-	}:
+	})
+	return nil
+}
+
+// send sends the message to the reader of the result
+// unless the result has been aborted.
+func (s *result) send(msg resultMessage) {
+	select {
+	case s.tables <- msg:
 	case <-s.aborted:
+		if msg.table != nil {
+			msg.table.Done()
+		}
 	}
-	return nil
 }
 
 func (s *result) Tables() flux.TableIterator {
@@ -85,13 +124,21 @@ func (s *result) UpdateProcessingTime(id DatasetID, t Time) error {
 }
 
 func (s *result) Finish(id DatasetID, err error) {
+	if s.sorted {
+		sort.SliceStable(s.buffered, func(i, j int) bool {
+			return s.buffered[i].Key().Less(s.buffered[j].Key())
+		})
+		for _, tbl := range s.buffered {
+			s.send(resultMessage{
+				table: tbl,
+			})
+		}
+		s.buffered = nil
+	}
 	if err != nil {
-		select {
-		case s.tables <- resultMessage{
+		s.send(resultMessage{
 			err: err,
-		}:
-		case <-s.aborted:
-		}
+		})
 	}
 	close(s.tables)
 }
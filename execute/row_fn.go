@@ -148,6 +148,9 @@ func (f *preparedFn) returnType() semantic.MonoType {
 }
 
 func ConvertToKind(t flux.ColType) semantic.Nature {
+	if t.IsArray() {
+		return semantic.Array
+	}
 	// TODO make this an array lookup.
 	switch t {
 	case flux.TInvalid:
@@ -164,6 +167,10 @@ func ConvertToKind(t flux.ColType) semantic.Nature {
 		return semantic.String
 	case flux.TTime:
 		return semantic.Time
+	case flux.TBytes:
+		return semantic.Bytes
+	case flux.TDuration:
+		return semantic.Duration
 	default:
 		return semantic.Invalid
 	}
@@ -186,6 +193,10 @@ func ConvertFromKind(k semantic.Nature) flux.ColType {
 		return flux.TString
 	case semantic.Time:
 		return flux.TTime
+	case semantic.Bytes:
+		return flux.TBytes
+	case semantic.Duration:
+		return flux.TDuration
 	default:
 		return flux.TInvalid
 	}
@@ -345,6 +356,57 @@ func (f *RowMapPreparedFn) Eval(ctx context.Context, row int, cr flux.ColReader)
 	return v.Object(), nil
 }
 
+type RowFlatMapFn struct {
+	dynamicFn
+}
+
+func NewRowFlatMapFn(fn *semantic.FunctionExpression, scope compiler.Scope) *RowFlatMapFn {
+	return &RowFlatMapFn{
+		dynamicFn: newDynamicFn(fn, scope),
+	}
+}
+
+func (f *RowFlatMapFn) Prepare(cols []flux.ColMeta) (*RowFlatMapPreparedFn, error) {
+	fn, err := f.prepare(cols, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	typ := fn.returnType()
+	if k := typ.Nature(); k != semantic.Array {
+		return nil, errors.Newf(codes.Invalid, "flatMap function must return an array of objects, got %s", k.String())
+	}
+	elemType, err := typ.ElemType()
+	if err != nil {
+		return nil, err
+	} else if k := elemType.Nature(); k != semantic.Object {
+		return nil, errors.Newf(codes.Invalid, "flatMap function must return an array of objects, got an array of %s", k.String())
+	}
+	return &RowFlatMapPreparedFn{
+		rowFn:    rowFn{preparedFn: fn},
+		elemType: elemType,
+	}, nil
+}
+
+type RowFlatMapPreparedFn struct {
+	rowFn
+	elemType semantic.MonoType
+}
+
+// Type returns the type of the objects in the returned array.
+func (f *RowFlatMapPreparedFn) Type() semantic.MonoType {
+	return f.elemType
+}
+
+func (f *RowFlatMapPreparedFn) Eval(ctx context.Context, row int, cr flux.ColReader) (values.Array, error) {
+	v, err := f.eval(ctx, row, cr, nil)
+	if err != nil {
+		return nil, err
+	} else if v.IsNull() {
+		return values.NewArray(semantic.NewArrayType(f.elemType)), nil
+	}
+	return v.Array(), nil
+}
+
 type RowReduceFn struct {
 	dynamicFn
 }
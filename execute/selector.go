@@ -1,9 +1,13 @@
 package execute
 
 import (
+	"time"
+
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/arrow"
 	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute/table"
 	"github.com/influxdata/flux/internal/errors"
 	"github.com/influxdata/flux/memory"
 	"github.com/influxdata/flux/plan"
@@ -21,6 +25,10 @@ type selectorTransformation struct {
 type SelectorConfig struct {
 	plan.DefaultCost
 	Column string `json:"column"`
+
+	// Nulls controls how a row selector handles null values
+	// in the column. Row selectors skip null values by default.
+	Nulls NullMode `json:"nulls"`
 }
 
 var DefaultSelectorConfig = SelectorConfig{
@@ -35,6 +43,12 @@ func (c *SelectorConfig) ReadArgs(args flux.Arguments) error {
 	} else {
 		c.Column = DefaultSelectorConfig.Column
 	}
+
+	nulls, err := ReadNullMode(args)
+	if err != nil {
+		return err
+	}
+	c.Nulls = nulls
 	return nil
 }
 
@@ -207,7 +221,18 @@ func (t *rowSelectorTransformation) Process(id DatasetID, tbl flux.Table) error
 		return errors.Newf(codes.FailedPrecondition, "invalid use of function: %T has no implementation for type %v", t.selector, valueCol.Type)
 	}
 
+	var nullRow *Row
 	if err := tbl.Do(func(cr flux.ColReader) error {
+		if t.config.Nulls == NullModePropagate {
+			if nullRow != nil {
+				return nil
+			} else if i := firstNull(table.Values(cr, valueIdx)); i >= 0 {
+				row := readRowWithNulls(i, cr)
+				nullRow = &row
+				return nil
+			}
+		}
+
 		switch valueCol.Type {
 		case flux.TTime:
 			rower.(DoTimeRowSelector).DoTime(cr.Times(valueIdx), cr)
@@ -229,6 +254,9 @@ func (t *rowSelectorTransformation) Process(id DatasetID, tbl flux.Table) error
 		return err
 	}
 	rows := rower.Rows()
+	if nullRow != nil {
+		rows = []Row{*nullRow}
+	}
 	return t.appendRows(builder, rows)
 }
 
@@ -346,7 +374,41 @@ func ReadRow(i int, cr flux.ColReader) (row Row) {
 			row.Values[j] = cr.Strings(j).Value(i)
 		case flux.TTime:
 			row.Values[j] = values.Time(cr.Times(j).Value(i))
+		case flux.TBytes:
+			row.Values[j] = append([]byte(nil), cr.Bytes(j).Value(i)...)
+		case flux.TDuration:
+			row.Values[j] = values.ConvertDurationNsecs(time.Duration(cr.Durations(j).Value(i)))
+		default:
+			if c.Type.IsArray() {
+				row.Values[j] = arrow.ArrayValue(cr.Arrays(j), i, c.Type.ElemType())
+			}
 		}
 	}
 	return
 }
+
+// readRowWithNulls reads the row at index i like ReadRow,
+// but it reads null values as nil.
+func readRowWithNulls(i int, cr flux.ColReader) Row {
+	row := ReadRow(i, cr)
+	for j := range row.Values {
+		if table.Values(cr, j).IsNull(i) {
+			row.Values[j] = nil
+		}
+	}
+	return row
+}
+
+// firstNull returns the index of the first null value
+// in the array or -1 if there are no null values.
+func firstNull(arr array.Array) int {
+	if arr.NullN() == 0 {
+		return -1
+	}
+	for i, n := 0, arr.Len(); i < n; i++ {
+		if arr.IsNull(i) {
+			return i
+		}
+	}
+	return -1
+}
@@ -522,6 +522,74 @@ func TestMaxRowSelector_Process(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "skip nulls",
+			config: execute.SelectorConfig{
+				Column: "_value",
+				Nulls:  execute.NullModeSkip,
+			},
+			data: []*executetest.Table{{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_start", Type: flux.TTime},
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(100), execute.Time(0), 1.0},
+					{execute.Time(0), execute.Time(100), execute.Time(10), nil},
+					{execute.Time(0), execute.Time(100), execute.Time(20), 5.0},
+					{execute.Time(0), execute.Time(100), execute.Time(30), nil},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_start", Type: flux.TTime},
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(100), execute.Time(20), 5.0},
+				},
+			}},
+		},
+		{
+			name: "propagate nulls",
+			config: execute.SelectorConfig{
+				Column: "_value",
+				Nulls:  execute.NullModePropagate,
+			},
+			data: []*executetest.Table{{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_start", Type: flux.TTime},
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(100), execute.Time(0), 1.0},
+					{execute.Time(0), execute.Time(100), execute.Time(10), nil},
+					{execute.Time(0), execute.Time(100), execute.Time(20), 5.0},
+					{execute.Time(0), execute.Time(100), execute.Time(30), nil},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_start", Type: flux.TTime},
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(100), execute.Time(10), nil},
+				},
+			}},
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -2,8 +2,13 @@ package execute
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/metadata"
+	"github.com/influxdata/flux/plan"
 )
 
 // SourceDecoder is an interface that generalizes the process of retrieving data from an unspecified data source.
@@ -120,3 +125,158 @@ func (s *sourceIterator) Run(ctx context.Context) {
 	})
 	s.ts.Finish(s.id, err)
 }
+
+// Metadata returns the metadata of the SourceIterator if it has any.
+// It is added to the statistics of the query after the source is run.
+func (s *sourceIterator) Metadata() metadata.Metadata {
+	if mdi, ok := s.iterator.(interface{ Metadata() metadata.Metadata }); ok {
+		return mdi.Metadata()
+	}
+	return nil
+}
+
+// TableNextIterator is the interface implemented by a source that produces
+// its tables one at a time. A source only needs to implement Next and
+// SourceFromIterator will turn it into an execute.Source.
+//
+// If the TableNextIterator implements Closer, it is closed after the last
+// table. If it has a Metadata method, the metadata is added to the
+// statistics of the query.
+type TableNextIterator interface {
+	// Next returns the next table of the source or io.EOF
+	// when there are no more tables.
+	//
+	// When Next returns an error with the code codes.Unavailable or
+	// codes.ResourceExhausted, it may be invoked again to retry reading
+	// the same table. The table is not retried once it has been returned.
+	Next(ctx context.Context) (flux.Table, error)
+}
+
+// SourceIteratorOptions configures a Source created by SourceFromIterator.
+type SourceIteratorOptions struct {
+	// MaxRetries is the number of times Next is invoked again after it
+	// returned a retryable error. Zero disables retries.
+	MaxRetries int
+
+	// RetryInterval is the delay before the first retry.
+	// The delay doubles with each retry.
+	RetryInterval time.Duration
+}
+
+// SourceFromIterator takes an implementation of a TableNextIterator and a dataset ID
+// and creates an execute.Source.
+//
+// The source sends each table to its transformations and advances the
+// watermark to the stop time of each table that has the stop column in its
+// group key. It stops reading from the iterator when the context is canceled.
+func SourceFromIterator(iterator TableNextIterator, dsid DatasetID, opts SourceIteratorOptions) Source {
+	return &nextSource{iterator: iterator, id: dsid, opts: opts}
+}
+
+// nextSource implements execute.Source using the TableNextIterator.
+type nextSource struct {
+	ExecutionNode
+	id       DatasetID
+	ts       TransformationSet
+	iterator TableNextIterator
+	opts     SourceIteratorOptions
+}
+
+func (s *nextSource) AddTransformation(t Transformation) {
+	s.ts = append(s.ts, t)
+}
+
+func (s *nextSource) Run(ctx context.Context) {
+	err := s.run(ctx)
+	if c, ok := s.iterator.(Closer); ok {
+		err = Close(err, c)
+	}
+	s.ts.Finish(s.id, err)
+}
+
+func (s *nextSource) run(ctx context.Context) error {
+	var mark Time
+	for {
+		tbl, err := s.next(ctx)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		stop, hasStop := tableStop(tbl)
+		if err := s.ts.Process(s.id, tbl); err != nil {
+			return err
+		}
+		if hasStop && stop > mark {
+			mark = stop
+			if err := s.ts.UpdateWatermark(s.id, mark); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// next reads the next table from the iterator and retries
+// reading it when the iterator returns a retryable error.
+func (s *nextSource) next(ctx context.Context) (flux.Table, error) {
+	interval := s.opts.RetryInterval
+	for retries := 0; ; retries++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		tbl, err := s.iterator.Next(ctx)
+		if err == nil || err == io.EOF || retries >= s.opts.MaxRetries {
+			return tbl, err
+		}
+		if code := flux.ErrorCode(err); code != codes.Unavailable && code != codes.ResourceExhausted {
+			return nil, err
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+		interval *= 2
+	}
+}
+
+// tableStop returns the stop time in the group key of the table.
+func tableStop(tbl flux.Table) (Time, bool) {
+	key := tbl.Key()
+	j := ColIdx(DefaultStopColLabel, key.Cols())
+	if j < 0 || key.Cols()[j].Type != flux.TTime || key.IsNull(j) {
+		return 0, false
+	}
+	return key.ValueTime(j), true
+}
+
+// Metadata returns the metadata of the TableNextIterator if it has any.
+// It is added to the statistics of the query after the source is run.
+func (s *nextSource) Metadata() metadata.Metadata {
+	if mdi, ok := s.iterator.(interface{ Metadata() metadata.Metadata }); ok {
+		return mdi.Metadata()
+	}
+	return nil
+}
+
+// ParallelSource can be embedded in the procedure spec of a source to
+// declare that the executor may run Factor copies of the source. Each copy
+// reads the partition given by the ParallelOpts of its Administration.
+type ParallelSource struct {
+	Factor int
+}
+
+// OutputAttributes implements plan.OutputAttributer.
+func (s ParallelSource) OutputAttributes() plan.PhysicalAttributes {
+	if s.Factor > 1 {
+		return plan.PhysicalAttributes{
+			plan.ParallelRunKey: plan.ParallelRunAttribute{Factor: s.Factor},
+		}
+	}
+	return nil
+}
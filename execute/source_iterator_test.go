@@ -0,0 +1,180 @@
+package execute_test
+
+import (
+	"context"
+	"io"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/internal/errors"
+)
+
+// sliceIterator returns its tables and fails with the
+// errors in order before it returns each table.
+type sliceIterator struct {
+	tables []*executetest.Table
+	errs   []error
+	calls  int
+	closed bool
+}
+
+func (s *sliceIterator) Next(ctx context.Context) (flux.Table, error) {
+	s.calls++
+	if len(s.errs) > 0 {
+		err := s.errs[0]
+		s.errs = s.errs[1:]
+		return nil, err
+	}
+	if len(s.tables) == 0 {
+		return nil, io.EOF
+	}
+	tbl := s.tables[0]
+	s.tables = s.tables[1:]
+	return tbl, nil
+}
+
+func (s *sliceIterator) Close() error {
+	s.closed = true
+	return nil
+}
+
+func sourceIteratorTables() []*executetest.Table {
+	cols := []flux.ColMeta{
+		{Label: "_start", Type: flux.TTime},
+		{Label: "_stop", Type: flux.TTime},
+		{Label: "_time", Type: flux.TTime},
+		{Label: "_value", Type: flux.TFloat},
+	}
+	return []*executetest.Table{
+		{
+			KeyCols: []string{"_start", "_stop"},
+			ColMeta: cols,
+			Data: [][]interface{}{
+				{execute.Time(0), execute.Time(10), execute.Time(1), 1.0},
+			},
+		},
+		{
+			KeyCols: []string{"_start", "_stop"},
+			ColMeta: cols,
+			Data: [][]interface{}{
+				{execute.Time(10), execute.Time(20), execute.Time(11), 2.0},
+			},
+		},
+	}
+}
+
+// watermarkStore records the watermarks that it receives.
+type watermarkStore struct {
+	*executetest.DataStore
+	marks []execute.Time
+}
+
+func (s *watermarkStore) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	s.marks = append(s.marks, mark)
+	return nil
+}
+
+func TestSourceFromIterator(t *testing.T) {
+	unavailable := errors.New(codes.Unavailable, "try again")
+	for _, tt := range []struct {
+		name      string
+		errs      []error
+		opts      execute.SourceIteratorOptions
+		want      []*executetest.Table
+		wantErr   error
+		wantCalls int
+		wantMarks []execute.Time
+	}{
+		{
+			name:      "tables",
+			want:      sourceIteratorTables(),
+			wantCalls: 3,
+			wantMarks: []execute.Time{10, 20},
+		},
+		{
+			name:      "retry",
+			errs:      []error{unavailable, unavailable},
+			opts:      execute.SourceIteratorOptions{MaxRetries: 2, RetryInterval: time.Millisecond},
+			want:      sourceIteratorTables(),
+			wantCalls: 5,
+			wantMarks: []execute.Time{10, 20},
+		},
+		{
+			name:      "too many retries",
+			errs:      []error{unavailable, unavailable},
+			opts:      execute.SourceIteratorOptions{MaxRetries: 1, RetryInterval: time.Millisecond},
+			wantErr:   unavailable,
+			wantCalls: 2,
+		},
+		{
+			name:      "not retryable",
+			errs:      []error{errors.New(codes.Invalid, "bad request")},
+			opts:      execute.SourceIteratorOptions{MaxRetries: 2, RetryInterval: time.Millisecond},
+			wantErr:   errors.New(codes.Invalid, "bad request"),
+			wantCalls: 1,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			it := &sliceIterator{tables: sourceIteratorTables(), errs: tt.errs}
+			src := execute.SourceFromIterator(it, executetest.RandomDatasetID(), tt.opts)
+			store := &watermarkStore{DataStore: executetest.NewDataStore()}
+			src.AddTransformation(store)
+			src.Run(context.Background())
+
+			if got, want := store.Err(), tt.wantErr; want == nil && got != nil {
+				t.Fatalf("unexpected error: %s", got)
+			} else if want != nil && (got == nil || got.Error() != want.Error()) {
+				t.Fatalf("unexpected error -want/+got:\n\t- %v\n\t+ %v", want, got)
+			}
+			if !it.closed {
+				t.Error("expected the iterator to be closed")
+			}
+			if it.calls != tt.wantCalls {
+				t.Errorf("unexpected number of calls to Next -want/+got:\n\t- %d\n\t+ %d", tt.wantCalls, it.calls)
+			}
+			if !cmp.Equal(tt.wantMarks, store.marks) {
+				t.Errorf("unexpected watermarks -want/+got:\n%s", cmp.Diff(tt.wantMarks, store.marks))
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			got, err := executetest.TablesFromCache(store)
+			if err != nil {
+				t.Fatal(err)
+			}
+			executetest.NormalizeTables(got)
+			executetest.NormalizeTables(tt.want)
+			sort.Sort(executetest.SortedTables(got))
+			sort.Sort(executetest.SortedTables(tt.want))
+			if !cmp.Equal(tt.want, got) {
+				t.Errorf("unexpected tables -want/+got:\n%s", cmp.Diff(tt.want, got))
+			}
+		})
+	}
+}
+
+func TestSourceFromIterator_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	it := &sliceIterator{tables: sourceIteratorTables()}
+	src := execute.SourceFromIterator(it, executetest.RandomDatasetID(), execute.SourceIteratorOptions{})
+	store := executetest.NewDataStore()
+	src.AddTransformation(store)
+	src.Run(ctx)
+
+	if got, want := store.Err(), context.Canceled; got != want {
+		t.Fatalf("unexpected error -want/+got:\n\t- %v\n\t+ %v", want, got)
+	}
+	if it.calls != 0 {
+		t.Errorf("expected no calls to Next, got %d", it.calls)
+	}
+}
@@ -1,9 +1,11 @@
 package execute
 
 import (
+	"bytes"
 	"fmt"
 	"sort"
 	"sync/atomic"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/flux"
@@ -178,6 +180,9 @@ func AppendCol(bj, cj int, cr flux.ColReader, builder TableBuilder) error {
 	}
 	c := cr.Cols()[cj]
 
+	if c.Type.IsArray() {
+		return builder.AppendArrays(bj, cr.Arrays(cj))
+	}
 	switch c.Type {
 	case flux.TBool:
 		return builder.AppendBools(bj, cr.Bools(cj))
@@ -191,6 +196,10 @@ func AppendCol(bj, cj int, cr flux.ColReader, builder TableBuilder) error {
 		return builder.AppendStrings(bj, cr.Strings(cj))
 	case flux.TTime:
 		return builder.AppendTimes(bj, cr.Times(cj))
+	case flux.TBytes:
+		return builder.AppendBytes(bj, cr.Bytes(cj))
+	case flux.TDuration:
+		return builder.AppendDurations(bj, cr.Durations(cj))
 	default:
 		PanicUnknownType(c.Type)
 	}
@@ -300,8 +309,18 @@ func TablesEqual(left, right flux.Table, alloc memory.Allocator) (bool, error) {
 			case flux.TTime:
 				eq = cmp.Equal(leftBuffer.cols[j].(*timeColumnBuilder).data,
 					rightBuffer.cols[j].(*timeColumnBuilder).data)
+			case flux.TBytes:
+				eq = cmp.Equal(leftBuffer.cols[j].(*bytesColumnBuilder).data,
+					rightBuffer.cols[j].(*bytesColumnBuilder).data)
+			case flux.TDuration:
+				eq = cmp.Equal(leftBuffer.cols[j].(*durationColumnBuilder).data,
+					rightBuffer.cols[j].(*durationColumnBuilder).data)
 			default:
-				PanicUnknownType(c.Type)
+				if !c.Type.IsArray() {
+					PanicUnknownType(c.Type)
+				}
+				eq = arraysEqual(leftBuffer.cols[j].(*arrayColumnBuilder).data,
+					rightBuffer.cols[j].(*arrayColumnBuilder).data)
 			}
 			if !eq {
 				return false, nil
@@ -407,6 +426,9 @@ func HasCol(label string, cols []flux.ColMeta) bool {
 // ValueForRow retrieves a value from an arrow column reader at the given index.
 func ValueForRow(cr flux.ColReader, i, j int) values.Value {
 	t := cr.Cols()[j].Type
+	if t.IsArray() {
+		return arrow.ArrayValue(cr.Arrays(j), i, t.ElemType())
+	}
 	switch t {
 	case flux.TString:
 		if cr.Strings(j).IsNull(i) {
@@ -438,6 +460,17 @@ func ValueForRow(cr flux.ColReader, i, j int) values.Value {
 			return values.NewNull(semantic.BasicTime)
 		}
 		return values.NewTime(values.Time(cr.Times(j).Value(i)))
+	case flux.TBytes:
+		if cr.Bytes(j).IsNull(i) {
+			return values.NewNull(semantic.BasicBytes)
+		}
+		// Copy the value so it remains valid after the array is released.
+		return values.NewBytes(append([]byte(nil), cr.Bytes(j).Value(i)...))
+	case flux.TDuration:
+		if cr.Durations(j).IsNull(i) {
+			return values.NewNull(semantic.BasicDuration)
+		}
+		return values.NewDuration(values.ConvertDurationNsecs(time.Duration(cr.Durations(j).Value(i))))
 	default:
 		PanicUnknownType(t)
 		return values.InvalidValue
@@ -470,6 +503,9 @@ type TableBuilder interface {
 	AppendFloat(j int, value float64) error
 	AppendString(j int, value string) error
 	AppendTime(j int, value Time) error
+	AppendBytesValue(j int, value []byte) error
+	AppendDuration(j int, value values.Duration) error
+	AppendArray(j int, value values.Array) error
 	AppendValue(j int, value values.Value) error
 	AppendNil(j int) error
 
@@ -482,6 +518,9 @@ type TableBuilder interface {
 	AppendFloats(j int, vs *array.Float) error
 	AppendStrings(j int, vs *array.String) error
 	AppendTimes(j int, vs *array.Int) error
+	AppendBytes(j int, vs *array.Binary) error
+	AppendDurations(j int, vs *array.Int) error
+	AppendArrays(j int, vs *array.List) error
 
 	// TODO(adam): determine if there's a useful API for AppendValues
 	// AppendValues(j int, values []values.Value)
@@ -496,6 +535,9 @@ type TableBuilder interface {
 	GrowFloats(j, n int) error
 	GrowStrings(j, n int) error
 	GrowTimes(j, n int) error
+	GrowBytes(j, n int) error
+	GrowDurations(j, n int) error
+	GrowArrays(j, n int) error
 
 	// LevelColumns will check for columns that are too short and Grow them
 	// so that each column is of uniform size.
@@ -613,8 +655,36 @@ func (b *ColListTableBuilder) AddCol(c flux.ColMeta) (int, error) {
 				return -1, err
 			}
 		}
+	case flux.TBytes:
+		b.cols = append(b.cols, &bytesColumnBuilder{
+			columnBuilderBase: colBase,
+		})
+		if b.NRows() > 0 {
+			if err := b.GrowBytes(newIdx, b.NRows()); err != nil {
+				return -1, err
+			}
+		}
+	case flux.TDuration:
+		b.cols = append(b.cols, &durationColumnBuilder{
+			columnBuilderBase: colBase,
+		})
+		if b.NRows() > 0 {
+			if err := b.GrowDurations(newIdx, b.NRows()); err != nil {
+				return -1, err
+			}
+		}
 	default:
-		PanicUnknownType(c.Type)
+		if !c.Type.IsArray() {
+			PanicUnknownType(c.Type)
+		}
+		b.cols = append(b.cols, &arrayColumnBuilder{
+			columnBuilderBase: colBase,
+		})
+		if b.NRows() > 0 {
+			if err := b.GrowArrays(newIdx, b.NRows()); err != nil {
+				return -1, err
+			}
+		}
 	}
 
 	return newIdx, nil
@@ -686,11 +756,45 @@ func (b *ColListTableBuilder) LevelColumns() error {
 				}
 			}
 
+			if toGrow < 0 {
+				_ = fmt.Errorf("column %s is longer than expected length of table", c.Label)
+			}
+		case flux.TBytes:
+			toGrow := b.NRows() - b.cols[idx].Len()
+			if toGrow > 0 {
+				if err := b.GrowBytes(idx, toGrow); err != nil {
+					return err
+				}
+			}
+
+			if toGrow < 0 {
+				_ = fmt.Errorf("column %s is longer than expected length of table", c.Label)
+			}
+		case flux.TDuration:
+			toGrow := b.NRows() - b.cols[idx].Len()
+			if toGrow > 0 {
+				if err := b.GrowDurations(idx, toGrow); err != nil {
+					return err
+				}
+			}
+
 			if toGrow < 0 {
 				_ = fmt.Errorf("column %s is longer than expected length of table", c.Label)
 			}
 		default:
-			PanicUnknownType(c.Type)
+			if !c.Type.IsArray() {
+				PanicUnknownType(c.Type)
+			}
+			toGrow := b.NRows() - b.cols[idx].Len()
+			if toGrow > 0 {
+				if err := b.GrowArrays(idx, toGrow); err != nil {
+					return err
+				}
+			}
+
+			if toGrow < 0 {
+				_ = fmt.Errorf("column %s is longer than expected length of table", c.Label)
+			}
 		}
 	}
 	return nil
@@ -1023,6 +1127,172 @@ func (b *ColListTableBuilder) GrowTimes(j, n int) error {
 
 }
 
+func (b *ColListTableBuilder) SetBytes(i int, j int, value []byte) error {
+	if err := b.checkCol(j, flux.TBytes); err != nil {
+		return err
+	}
+	b.cols[j].(*bytesColumnBuilder).data[i] = value
+	b.cols[j].SetNil(i, false)
+	return nil
+}
+
+func (b *ColListTableBuilder) AppendBytesValue(j int, value []byte) error {
+	if err := b.checkCol(j, flux.TBytes); err != nil {
+		return err
+	}
+	col := b.cols[j].(*bytesColumnBuilder)
+	col.data = b.alloc.AppendBytes(col.data, value)
+	b.nrows = len(col.data)
+	return nil
+}
+
+func (b *ColListTableBuilder) AppendBytes(j int, vs *array.Binary) error {
+	if err := b.checkCol(j, flux.TBytes); err != nil {
+		return err
+	}
+	col := b.cols[j].(*bytesColumnBuilder)
+	for i := 0; i < vs.Len(); i++ {
+		if vs.IsNull(i) {
+			if err := b.AppendNil(j); err != nil {
+				return err
+			}
+		} else if err := b.AppendBytesValue(j, append([]byte(nil), vs.Value(i)...)); err != nil {
+			return err
+		}
+	}
+	b.nrows = len(col.data)
+	return nil
+}
+
+func (b *ColListTableBuilder) GrowBytes(j, n int) error {
+	if err := b.checkCol(j, flux.TBytes); err != nil {
+		return err
+	}
+	col := b.cols[j].(*bytesColumnBuilder)
+	i := len(col.data)
+	col.data = b.alloc.GrowBytes(col.data, n)
+	b.nrows = len(col.data)
+	for ; i < b.nrows; i++ {
+		if err := b.SetNil(i, j); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *ColListTableBuilder) SetDuration(i int, j int, value values.Duration) error {
+	if err := b.checkCol(j, flux.TDuration); err != nil {
+		return err
+	}
+	nsecs, err := arrow.DurationNsecs(value)
+	if err != nil {
+		return err
+	}
+	b.cols[j].(*durationColumnBuilder).data[i] = nsecs
+	b.cols[j].SetNil(i, false)
+	return nil
+}
+
+func (b *ColListTableBuilder) AppendDuration(j int, value values.Duration) error {
+	if err := b.checkCol(j, flux.TDuration); err != nil {
+		return err
+	}
+	nsecs, err := arrow.DurationNsecs(value)
+	if err != nil {
+		return err
+	}
+	col := b.cols[j].(*durationColumnBuilder)
+	col.data = b.alloc.AppendInts(col.data, nsecs)
+	b.nrows = len(col.data)
+	return nil
+}
+
+func (b *ColListTableBuilder) AppendDurations(j int, vs *array.Int) error {
+	if err := b.checkCol(j, flux.TDuration); err != nil {
+		return err
+	}
+	col := b.cols[j].(*durationColumnBuilder)
+	for i := 0; i < vs.Len(); i++ {
+		if vs.IsNull(i) {
+			if err := b.AppendNil(j); err != nil {
+				return err
+			}
+			continue
+		}
+		col.data = b.alloc.AppendInts(col.data, vs.Value(i))
+		b.nrows = len(col.data)
+	}
+	return nil
+}
+
+func (b *ColListTableBuilder) GrowDurations(j, n int) error {
+	if err := b.checkCol(j, flux.TDuration); err != nil {
+		return err
+	}
+	col := b.cols[j].(*durationColumnBuilder)
+	i := len(col.data)
+	col.data = b.alloc.GrowInts(col.data, n)
+	b.nrows = len(col.data)
+	for ; i < b.nrows; i++ {
+		if err := b.SetNil(i, j); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *ColListTableBuilder) SetArray(i int, j int, value values.Array) error {
+	if err := b.checkArrayCol(j, value); err != nil {
+		return err
+	}
+	b.cols[j].(*arrayColumnBuilder).data[i] = value
+	b.cols[j].SetNil(i, false)
+	return nil
+}
+
+func (b *ColListTableBuilder) AppendArray(j int, value values.Array) error {
+	if err := b.checkArrayCol(j, value); err != nil {
+		return err
+	}
+	col := b.cols[j].(*arrayColumnBuilder)
+	col.data = b.alloc.AppendArrays(col.data, value)
+	b.nrows = len(col.data)
+	return nil
+}
+
+func (b *ColListTableBuilder) AppendArrays(j int, vs *array.List) error {
+	if err := b.checkArrayCol(j, nil); err != nil {
+		return err
+	}
+	elem := b.colMeta[j].Type.ElemType()
+	for i := 0; i < vs.Len(); i++ {
+		if vs.IsNull(i) {
+			if err := b.AppendNil(j); err != nil {
+				return err
+			}
+		} else if err := b.AppendArray(j, arrow.ArrayValue(vs, i, elem).Array()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *ColListTableBuilder) GrowArrays(j, n int) error {
+	if err := b.checkArrayCol(j, nil); err != nil {
+		return err
+	}
+	col := b.cols[j].(*arrayColumnBuilder)
+	i := len(col.data)
+	col.data = b.alloc.GrowArrays(col.data, n)
+	b.nrows = len(col.data)
+	for ; i < b.nrows; i++ {
+		if err := b.SetNil(i, j); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (b *ColListTableBuilder) SetValue(i, j int, v values.Value) error {
 	if v.IsNull() {
 		return b.SetNil(i, j)
@@ -1041,6 +1311,12 @@ func (b *ColListTableBuilder) SetValue(i, j int, v values.Value) error {
 		return b.SetString(i, j, v.Str())
 	case semantic.Time:
 		return b.SetTime(i, j, v.Time())
+	case semantic.Bytes:
+		return b.SetBytes(i, j, v.Bytes())
+	case semantic.Duration:
+		return b.SetDuration(i, j, v.Duration())
+	case semantic.Array:
+		return b.SetArray(i, j, v.Array())
 	default:
 		panic(fmt.Errorf("unexpected value type %v", v.Type()))
 	}
@@ -1064,6 +1340,12 @@ func (b *ColListTableBuilder) AppendValue(j int, v values.Value) error {
 		return b.AppendString(j, v.Str())
 	case semantic.Time:
 		return b.AppendTime(j, v.Time())
+	case semantic.Bytes:
+		return b.AppendBytesValue(j, v.Bytes())
+	case semantic.Duration:
+		return b.AppendDuration(j, v.Duration())
+	case semantic.Array:
+		return b.AppendArray(j, v.Array())
 	default:
 		panic(fmt.Errorf("unexpected value type %v", v.Type()))
 	}
@@ -1111,8 +1393,21 @@ func (b *ColListTableBuilder) AppendNil(j int) error {
 		if err := b.AppendTime(j, 0); err != nil {
 			return err
 		}
+	case flux.TBytes:
+		if err := b.AppendBytesValue(j, nil); err != nil {
+			return err
+		}
+	case flux.TDuration:
+		if err := b.AppendDuration(j, values.Duration{}); err != nil {
+			return err
+		}
 	default:
-		panic(fmt.Errorf("unexpected value type %v", typ))
+		if !typ.IsArray() {
+			panic(fmt.Errorf("unexpected value type %v", typ))
+		}
+		if err := b.AppendArray(j, nil); err != nil {
+			return err
+		}
 	}
 
 	return b.SetNil(b.nrows-1, j)
@@ -1126,6 +1421,26 @@ func (b *ColListTableBuilder) checkCol(j int, typ flux.ColType) error {
 	return nil
 }
 
+// checkArrayCol checks that column j is an array column with
+// the element type of the value. A nil value or an empty array
+// can be stored in any array column.
+func (b *ColListTableBuilder) checkArrayCol(j int, value values.Array) error {
+	if j < 0 || j > len(b.cols) {
+		return fmt.Errorf("column does not exist, index out of bounds: %d", j)
+	}
+	col := b.colMeta[j]
+	if !col.Type.IsArray() {
+		panic(fmt.Errorf("column %s:%s is not an array column", col.Label, col.Type))
+	}
+	if value == nil || value.Len() == 0 {
+		return nil
+	}
+	if typ := flux.ColumnType(value.Type()); typ != col.Type {
+		return errors.Newf(codes.Invalid, "cannot store array of type %v in column %s:%s", value.Type(), col.Label, col.Type)
+	}
+	return nil
+}
+
 func CheckColType(col flux.ColMeta, typ flux.ColType) {
 	if col.Type != typ {
 		panic(fmt.Errorf("column %s:%s is not of type %v", col.Label, col.Type, typ))
@@ -1161,6 +1476,17 @@ func (b *ColListTableBuilder) Times(j int) []values.Time {
 	CheckColType(b.colMeta[j], flux.TTime)
 	return b.cols[j].(*timeColumnBuilder).data
 }
+func (b *ColListTableBuilder) Bytes(j int) [][]byte {
+	CheckColType(b.colMeta[j], flux.TBytes)
+	return b.cols[j].(*bytesColumnBuilder).data
+}
+func (b *ColListTableBuilder) Durations(j int) []int64 {
+	CheckColType(b.colMeta[j], flux.TDuration)
+	return b.cols[j].(*durationColumnBuilder).data
+}
+func (b *ColListTableBuilder) Arrays(j int) []values.Array {
+	return b.cols[j].(*arrayColumnBuilder).data
+}
 
 // GetRow takes a row index and returns the record located at that index in the cache
 func (b *ColListTableBuilder) GetRow(row int) values.Object {
@@ -1183,6 +1509,14 @@ func (b *ColListTableBuilder) GetRow(row int) values.Object {
 					val = values.NewString(b.cols[j].(*stringColumnBuilder).data[row])
 				case flux.TTime:
 					val = values.NewTime(b.cols[j].(*timeColumnBuilder).data[row])
+				case flux.TBytes:
+					val = values.NewBytes(b.cols[j].(*bytesColumnBuilder).data[row])
+				case flux.TDuration:
+					val = values.NewDuration(values.ConvertDurationNsecs(time.Duration(b.cols[j].(*durationColumnBuilder).data[row])))
+				default:
+					if col.Type.IsArray() {
+						val = b.cols[j].(*arrayColumnBuilder).data[row]
+					}
 				}
 			}
 			set(col.Label, val)
@@ -1242,8 +1576,18 @@ func (b *ColListTableBuilder) SliceColumns(start, stop int) error {
 		case flux.TTime:
 			col := b.cols[i].(*timeColumnBuilder)
 			col.data = col.data[start:stop]
+		case flux.TBytes:
+			col := b.cols[i].(*bytesColumnBuilder)
+			col.data = col.data[start:stop]
+		case flux.TDuration:
+			col := b.cols[i].(*durationColumnBuilder)
+			col.data = col.data[start:stop]
 		default:
-			panic(fmt.Errorf("unexpected column type %v", c.Meta().Type))
+			if !c.Meta().Type.IsArray() {
+				panic(fmt.Errorf("unexpected column type %v", c.Meta().Type))
+			}
+			col := b.cols[i].(*arrayColumnBuilder)
+			col.data = col.data[start:stop]
 		}
 		b.nrows = stop - start
 	}
@@ -1347,6 +1691,12 @@ func (t *ColListTable) Do(f func(flux.ColReader) error) error {
 				buffer.Values[i] = col.data
 			case *timeColumn:
 				buffer.Values[i] = col.data
+			case *bytesColumn:
+				buffer.Values[i] = col.data
+			case *durationColumn:
+				buffer.Values[i] = col.data
+			case *arrayColumn:
+				buffer.Values[i] = col.data
 			default:
 				return errors.Newf(codes.Internal, "unknown column type: %T", col)
 			}
@@ -1388,6 +1738,17 @@ func (t *ColListTable) Times(j int) *array.Int {
 	CheckColType(t.colMeta[j], flux.TTime)
 	return t.cols[j].(*timeColumn).data
 }
+func (t *ColListTable) Bytes(j int) *array.Binary {
+	CheckColType(t.colMeta[j], flux.TBytes)
+	return t.cols[j].(*bytesColumn).data
+}
+func (t *ColListTable) Durations(j int) *array.Int {
+	CheckColType(t.colMeta[j], flux.TDuration)
+	return t.cols[j].(*durationColumn).data
+}
+func (t *ColListTable) Arrays(j int) *array.List {
+	return t.cols[j].(*arrayColumn).data
+}
 
 type colListTableSorter struct {
 	cols []int
@@ -1880,7 +2241,21 @@ func (c *stringColumnBuilder) Release() {
 
 func (c *stringColumnBuilder) Copy() column {
 	var data *array.String
-	if len(c.nils) > 0 {
+	if isLowCardinality(c.data) {
+		// Tag-like columns repeat a small number of values
+		// so store each distinct value only once.
+		b := array.NewStringDictionaryBuilder(c.alloc.Allocator)
+		b.Resize(len(c.data))
+		for i, v := range c.data {
+			if len(c.nils) > 0 && c.nils[i] {
+				b.AppendNull()
+				continue
+			}
+			b.Append(v)
+		}
+		data = b.NewStringArray()
+		b.Release()
+	} else if len(c.nils) > 0 {
 		b := arrow.NewStringBuilder(c.alloc.Allocator)
 		b.Reserve(len(c.data))
 		sz := 0
@@ -1910,6 +2285,32 @@ func (c *stringColumnBuilder) Copy() column {
 	return col
 }
 
+// isLowCardinality reports whether the values repeat often enough
+// that dictionary encoding them will use less memory.
+func isLowCardinality(vs []string) bool {
+	const minLen = 16
+	if len(vs) < minLen {
+		return false
+	}
+
+	// Require each distinct value to appear at least
+	// four times on average.
+	limit := len(vs) / 4
+	distinct := make(map[string]struct{}, limit)
+	for _, v := range vs {
+		if _, ok := distinct[v]; ok {
+			continue
+		}
+		if len(distinct) == limit {
+			return false
+		}
+		distinct[v] = struct{}{}
+	}
+	// A single value is already stored compactly
+	// by the string builder.
+	return len(distinct) > 1
+}
+
 func (c *stringColumnBuilder) Len() int {
 	return len(c.data)
 }
@@ -2007,6 +2408,307 @@ func (c *timeColumnBuilder) Swap(i, j int) {
 	c.data[i], c.data[j] = c.data[j], c.data[i]
 }
 
+type bytesColumn struct {
+	flux.ColMeta
+	data *array.Binary
+}
+
+func (c *bytesColumn) Meta() flux.ColMeta {
+	return c.ColMeta
+}
+
+func (c *bytesColumn) Clear() {
+	if c.data != nil {
+		c.data.Release()
+		c.data = nil
+	}
+}
+
+func (c *bytesColumn) Copy() column {
+	c.data.Retain()
+	return &bytesColumn{
+		ColMeta: c.ColMeta,
+		data:    c.data,
+	}
+}
+
+type bytesColumnBuilder struct {
+	columnBuilderBase
+	data [][]byte
+}
+
+func (c *bytesColumnBuilder) Clear() {
+	c.data = c.data[0:0]
+}
+
+func (c *bytesColumnBuilder) Release() {
+	c.alloc.Free(cap(c.data), bytesSize)
+	c.data = nil
+}
+
+func (c *bytesColumnBuilder) Copy() column {
+	var data *array.Binary
+	if len(c.nils) > 0 {
+		b := arrow.NewBinaryBuilder(c.alloc.Allocator)
+		b.Reserve(len(c.data))
+		sz := 0
+		for i, v := range c.data {
+			if c.nils[i] {
+				continue
+			}
+			sz += len(v)
+		}
+		b.ReserveData(sz)
+		for i, v := range c.data {
+			if c.nils[i] {
+				b.AppendNull()
+				continue
+			}
+			b.Append(v)
+		}
+		data = b.NewBinaryArray()
+		b.Release()
+	} else {
+		data = arrow.NewBytes(c.data, c.alloc.Allocator)
+	}
+	return &bytesColumn{
+		ColMeta: c.ColMeta,
+		data:    data,
+	}
+}
+
+func (c *bytesColumnBuilder) Len() int {
+	return len(c.data)
+}
+
+func (c *bytesColumnBuilder) Equal(i, j int) bool {
+	return c.EqualFunc(i, j, func(i, j int) bool {
+		return bytes.Equal(c.data[i], c.data[j])
+	})
+}
+
+func (c *bytesColumnBuilder) Less(i, j int) bool {
+	return c.LessFunc(i, j, func(i, j int) bool {
+		return bytes.Compare(c.data[i], c.data[j]) < 0
+	})
+}
+
+func (c *bytesColumnBuilder) Swap(i, j int) {
+	c.columnBuilderBase.Swap(i, j)
+	c.data[i], c.data[j] = c.data[j], c.data[i]
+}
+
+type durationColumn struct {
+	flux.ColMeta
+	data *array.Int
+}
+
+func (c *durationColumn) Meta() flux.ColMeta {
+	return c.ColMeta
+}
+
+func (c *durationColumn) Clear() {
+	if c.data != nil {
+		c.data.Release()
+		c.data = nil
+	}
+}
+
+func (c *durationColumn) Copy() column {
+	c.data.Retain()
+	return &durationColumn{
+		ColMeta: c.ColMeta,
+		data:    c.data,
+	}
+}
+
+// durationColumnBuilder stores each duration
+// as its signed number of nanoseconds.
+type durationColumnBuilder struct {
+	columnBuilderBase
+	data []int64
+}
+
+func (c *durationColumnBuilder) Clear() {
+	c.data = c.data[0:0]
+}
+
+func (c *durationColumnBuilder) Release() {
+	c.alloc.Free(cap(c.data), int64Size)
+	c.data = nil
+}
+
+func (c *durationColumnBuilder) Copy() column {
+	b := arrow.NewIntBuilder(c.alloc.Allocator)
+	b.Reserve(len(c.data))
+	for i, v := range c.data {
+		if c.nils[i] {
+			b.UnsafeAppendBoolToBitmap(false)
+			continue
+		}
+		b.UnsafeAppend(v)
+	}
+	col := &durationColumn{
+		ColMeta: c.ColMeta,
+		data:    b.NewIntArray(),
+	}
+	b.Release()
+	return col
+}
+
+func (c *durationColumnBuilder) Len() int {
+	return len(c.data)
+}
+
+func (c *durationColumnBuilder) Equal(i, j int) bool {
+	return c.EqualFunc(i, j, func(i, j int) bool {
+		return c.data[i] == c.data[j]
+	})
+}
+
+func (c *durationColumnBuilder) Less(i, j int) bool {
+	return c.LessFunc(i, j, func(i, j int) bool {
+		return c.data[i] < c.data[j]
+	})
+}
+
+func (c *durationColumnBuilder) Swap(i, j int) {
+	c.columnBuilderBase.Swap(i, j)
+	c.data[i], c.data[j] = c.data[j], c.data[i]
+}
+
+type arrayColumn struct {
+	flux.ColMeta
+	data *array.List
+}
+
+func (c *arrayColumn) Meta() flux.ColMeta {
+	return c.ColMeta
+}
+
+func (c *arrayColumn) Clear() {
+	if c.data != nil {
+		c.data.Release()
+		c.data = nil
+	}
+}
+
+func (c *arrayColumn) Copy() column {
+	c.data.Retain()
+	return &arrayColumn{
+		ColMeta: c.ColMeta,
+		data:    c.data,
+	}
+}
+
+// arrayColumnBuilder stores the array values of the column.
+// The value of a nil row is nil.
+type arrayColumnBuilder struct {
+	columnBuilderBase
+	data []values.Array
+}
+
+func (c *arrayColumnBuilder) Clear() {
+	c.data = c.data[0:0]
+}
+
+func (c *arrayColumnBuilder) Release() {
+	c.alloc.Free(cap(c.data), arraySize)
+	c.data = nil
+}
+
+func (c *arrayColumnBuilder) Copy() column {
+	b := arrow.NewListBuilder(c.Type.ElemType(), c.alloc.Allocator)
+	b.Reserve(len(c.data))
+	for i, v := range c.data {
+		if c.nils[i] || v == nil {
+			b.AppendNull()
+			continue
+		}
+		// The values were checked when they were added to the column.
+		if err := arrow.AppendArray(b, v); err != nil {
+			panic(err)
+		}
+	}
+	col := &arrayColumn{
+		ColMeta: c.ColMeta,
+		data:    b.NewListArray(),
+	}
+	b.Release()
+	return col
+}
+
+func (c *arrayColumnBuilder) Len() int {
+	return len(c.data)
+}
+
+func (c *arrayColumnBuilder) Equal(i, j int) bool {
+	return c.EqualFunc(i, j, func(i, j int) bool {
+		return c.data[i].Equal(c.data[j])
+	})
+}
+
+// Less orders arrays by their elements and
+// then by their length like strings are ordered.
+func (c *arrayColumnBuilder) Less(i, j int) bool {
+	return c.LessFunc(i, j, func(i, j int) bool {
+		return arrayLess(c.data[i], c.data[j])
+	})
+}
+
+func (c *arrayColumnBuilder) Swap(i, j int) {
+	c.columnBuilderBase.Swap(i, j)
+	c.data[i], c.data[j] = c.data[j], c.data[i]
+}
+
+func arraysEqual(l, r []values.Array) bool {
+	if len(l) != len(r) {
+		return false
+	}
+	for i := range l {
+		if (l[i] == nil) != (r[i] == nil) {
+			return false
+		} else if l[i] != nil && !l[i].Equal(r[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func arrayLess(l, r values.Array) bool {
+	for i := 0; i < l.Len() && i < r.Len(); i++ {
+		lv, rv := l.Get(i), r.Get(i)
+		if lv.IsNull() || rv.IsNull() {
+			if lv.IsNull() == rv.IsNull() {
+				continue
+			}
+			return lv.IsNull()
+		}
+		if lv.Equal(rv) {
+			continue
+		}
+		switch lv.Type().Nature() {
+		case semantic.Int:
+			return lv.Int() < rv.Int()
+		case semantic.UInt:
+			return lv.UInt() < rv.UInt()
+		case semantic.Float:
+			return lv.Float() < rv.Float()
+		case semantic.String:
+			return lv.Str() < rv.Str()
+		case semantic.Bool:
+			return !lv.Bool()
+		case semantic.Time:
+			return lv.Time() < rv.Time()
+		case semantic.Duration:
+			return lv.Duration().Duration() < rv.Duration().Duration()
+		case semantic.Bytes:
+			return bytes.Compare(lv.Bytes(), rv.Bytes()) < 0
+		}
+	}
+	return l.Len() < r.Len()
+}
+
 type TableBuilderCache interface {
 	// TableBuilder returns an existing or new TableBuilder for the given meta data.
 	// The boolean return value indicates if TableBuilder is new.
@@ -126,6 +126,24 @@ func (v Chunk) Strings(j int) *array.String {
 	return v.Values(j).(*array.String)
 }
 
+// Bytes is a convenience function for retrieving an array
+// as a bytes array.
+func (v Chunk) Bytes(j int) *array.Binary {
+	return v.Values(j).(*array.Binary)
+}
+
+// Durations is a convenience function for retrieving an array
+// as a duration array.
+func (v Chunk) Durations(j int) *array.Int {
+	return v.Values(j).(*array.Int)
+}
+
+// Arrays is a convenience function for retrieving an array
+// as a list array.
+func (v Chunk) Arrays(j int) *array.List {
+	return v.Values(j).(*array.List)
+}
+
 // Retain will retain a reference to this Chunk.
 func (v Chunk) Retain() {
 	v.buf.Retain()
@@ -0,0 +1,268 @@
+package table
+
+import (
+	"sync"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+)
+
+// Cursor reads the tables of a flux.ResultIterator with a pull-based API.
+//
+// The tables are read in order by NextTable and the buffers of the
+// current table by NextBuffer. A table is only decoded when NextBuffer
+// is called for it. Tables that are skipped are discarded without
+// reading their data.
+//
+// The buffers returned by NextBuffer reference the arrow arrays produced
+// by the query without copying them.
+//
+// A Cursor is not thread-safe and all of its methods are expected to be
+// called within the same goroutine.
+type Cursor struct {
+	results flux.ResultIterator
+
+	msgs chan cursorMsg
+	want chan bool
+	done chan struct{}
+
+	// err is written by the reader goroutine before msgs is closed.
+	err error
+
+	closeOnce sync.Once
+	released  bool
+	// exhausted is set when all of the messages have been received.
+	exhausted bool
+
+	state  cursorState
+	result string
+	table  flux.Table
+	next   *cursorMsg
+}
+
+// cursorState is the state of the current table of a Cursor.
+type cursorState int
+
+const (
+	// noTable is the state before the first call to NextTable.
+	noTable cursorState = iota
+	// tableUnread is the state of a table that has not been read.
+	tableUnread
+	// tableReading is the state of a table with buffers being read.
+	tableReading
+	// tableFinished is the state of a table with all of its buffers read.
+	tableFinished
+)
+
+// cursorMsg is sent by the reader goroutine to the Cursor.
+// It holds either the start of a new table or a buffer
+// of the current table.
+type cursorMsg struct {
+	result string
+	table  flux.Table
+	chunk  Chunk
+}
+
+var errCursorReleased = errors.New(codes.Canceled, "cursor was released")
+
+// NewCursor creates a Cursor that reads the tables of the results.
+// The Cursor takes ownership of the results and releases them when
+// the Cursor is released.
+func NewCursor(results flux.ResultIterator) *Cursor {
+	c := &Cursor{
+		results: results,
+		msgs:    make(chan cursorMsg),
+		want:    make(chan bool),
+		done:    make(chan struct{}),
+	}
+	go c.read()
+	return c
+}
+
+// read reads the results and sends their tables and buffers
+// to the Cursor as they are requested.
+func (c *Cursor) read() {
+	defer close(c.msgs)
+
+	for c.results.More() {
+		res := c.results.Next()
+		name := res.Name()
+		if err := res.Tables().Do(func(tbl flux.Table) error {
+			if !c.send(cursorMsg{result: name, table: tbl}) {
+				tbl.Done()
+				return errCursorReleased
+			}
+
+			select {
+			case read := <-c.want:
+				if !read {
+					tbl.Done()
+					return nil
+				}
+			case <-c.done:
+				tbl.Done()
+				return errCursorReleased
+			}
+
+			return tbl.Do(func(cr flux.ColReader) error {
+				chunk := ChunkFromReader(cr)
+				chunk.Retain()
+				if !c.send(cursorMsg{chunk: chunk}) {
+					chunk.Release()
+					return errCursorReleased
+				}
+				return nil
+			})
+		}); err != nil {
+			if err != errCursorReleased {
+				c.err = err
+			}
+			return
+		}
+	}
+	c.err = c.results.Err()
+}
+
+// send sends the message to the Cursor.
+// It returns false if the Cursor was released.
+func (c *Cursor) send(m cursorMsg) bool {
+	select {
+	case c.msgs <- m:
+		return true
+	case <-c.done:
+		return false
+	}
+}
+
+// recv receives the next message from the reader goroutine.
+// It returns false when there are no more messages.
+func (c *Cursor) recv() (cursorMsg, bool) {
+	if c.next != nil {
+		m := *c.next
+		c.next = nil
+		return m, true
+	}
+	m, ok := <-c.msgs
+	if !ok {
+		c.exhausted = true
+	}
+	return m, ok
+}
+
+// NextTable advances the Cursor to the next table.
+// It returns false when there are no more tables
+// or an error occurred. Any buffers of the current table
+// that have not been read are discarded.
+func (c *Cursor) NextTable() bool {
+	if c.released {
+		return false
+	}
+
+	switch c.state {
+	case tableUnread:
+		c.want <- false
+	case tableReading:
+		// Discard the remaining buffers of the table.
+		for {
+			m, ok := c.recv()
+			if !ok {
+				break
+			} else if m.table != nil {
+				c.next = &m
+				break
+			}
+			m.chunk.Release()
+		}
+	}
+
+	m, ok := c.recv()
+	if !ok {
+		c.state, c.table = tableFinished, nil
+		return false
+	}
+	c.state, c.result, c.table = tableUnread, m.result, m.table
+	return true
+}
+
+// Result returns the name of the result of the current table.
+func (c *Cursor) Result() string {
+	return c.result
+}
+
+// Key returns the group key of the current table.
+func (c *Cursor) Key() flux.GroupKey {
+	return c.table.Key()
+}
+
+// Cols returns the columns of the current table.
+func (c *Cursor) Cols() []flux.ColMeta {
+	return c.table.Cols()
+}
+
+// NextBuffer returns the next buffer of the current table.
+// It returns false when there are no more buffers in the table.
+//
+// The caller owns a reference to the returned Chunk and
+// must call Release when it is done with it.
+func (c *Cursor) NextBuffer() (Chunk, bool) {
+	if c.released {
+		return Chunk{}, false
+	}
+
+	switch c.state {
+	case tableUnread:
+		c.want <- true
+		c.state = tableReading
+	case tableReading:
+	default:
+		return Chunk{}, false
+	}
+
+	m, ok := c.recv()
+	if !ok {
+		c.state = tableFinished
+		return Chunk{}, false
+	} else if m.table != nil {
+		// The message is the start of the next table.
+		c.next = &m
+		c.state = tableFinished
+		return Chunk{}, false
+	}
+	return m.chunk, true
+}
+
+// Err reports the first error encountered while reading the results.
+// Err will not report anything unless NextTable has returned false
+// or the Cursor has been released.
+func (c *Cursor) Err() error {
+	if !c.exhausted {
+		return nil
+	}
+	return c.err
+}
+
+// Release discards the remaining tables and releases the results.
+// It must always be called to free resources.
+// It is safe to call Release multiple times.
+func (c *Cursor) Release() {
+	c.closeOnce.Do(func() {
+		c.released = true
+		close(c.done)
+		// Wait for the reader goroutine to stop
+		// and release any buffer it was sending.
+		for m := range c.msgs {
+			if m.table == nil {
+				m.chunk.Release()
+			}
+		}
+		c.exhausted = true
+		c.results.Release()
+	})
+}
+
+// Statistics reports the statistics for the query.
+// The statistics are not complete until Release is called.
+func (c *Cursor) Statistics() flux.Statistics {
+	return c.results.Statistics()
+}
@@ -0,0 +1,151 @@
+package table_test
+
+import (
+	"testing"
+
+	arrowmemory "github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/execute/groupkey"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/values"
+)
+
+type cursorResult struct {
+	name   string
+	tables []flux.Table
+}
+
+func (r *cursorResult) Name() string               { return r.name }
+func (r *cursorResult) Tables() flux.TableIterator { return table.Iterator(r.tables) }
+
+// newCursorTable creates a table with the tag t0 and
+// a buffer with the values of each slice.
+func newCursorTable(alloc memory.Allocator, t0 string, buffers ...[]int64) flux.Table {
+	key := groupkey.New(
+		[]flux.ColMeta{{Label: "t0", Type: flux.TString}},
+		[]values.Value{values.NewString(t0)},
+	)
+	cols := []flux.ColMeta{
+		{Label: "t0", Type: flux.TString},
+		{Label: "_value", Type: flux.TInt},
+	}
+	tbl := &table.BufferedTable{
+		GroupKey: key,
+		Columns:  cols,
+	}
+	for _, vs := range buffers {
+		tbl.Buffers = append(tbl.Buffers, &arrow.TableBuffer{
+			GroupKey: key,
+			Columns:  cols,
+			Values: []array.Array{
+				array.StringRepeat(t0, len(vs), alloc),
+				arrow.NewInt(vs, alloc),
+			},
+		})
+	}
+	return tbl
+}
+
+func TestCursor(t *testing.T) {
+	mem := arrowmemory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+	alloc := memory.NewResourceAllocator(mem)
+
+	results := flux.NewSliceResultIterator([]flux.Result{
+		&cursorResult{
+			name: "a",
+			tables: []flux.Table{
+				newCursorTable(alloc, "t0", []int64{1, 2}, []int64{3}),
+				// This table is skipped without being read.
+				newCursorTable(alloc, "t1", []int64{4}),
+				// Only the first buffer of this table is read.
+				newCursorTable(alloc, "t2", []int64{5}, []int64{6}),
+			},
+		},
+		&cursorResult{
+			name: "b",
+			tables: []flux.Table{
+				newCursorTable(alloc, "t3", []int64{7, 8}),
+			},
+		},
+	})
+
+	type buffer struct {
+		Result string
+		Key    string
+		Values []int64
+	}
+	var got []buffer
+
+	cur := table.NewCursor(results)
+	for i := 0; cur.NextTable(); i++ {
+		if i == 1 {
+			continue
+		}
+		for {
+			chunk, ok := cur.NextBuffer()
+			if !ok {
+				break
+			}
+			got = append(got, buffer{
+				Result: cur.Result(),
+				Key:    cur.Key().Value(0).Str(),
+				Values: chunk.Ints(1).Int64Values(),
+			})
+			chunk.Release()
+			if i == 2 {
+				break
+			}
+		}
+	}
+	if err := cur.Err(); err != nil {
+		t.Fatal(err)
+	}
+	cur.Release()
+
+	want := []buffer{
+		{Result: "a", Key: "t0", Values: []int64{1, 2}},
+		{Result: "a", Key: "t0", Values: []int64{3}},
+		{Result: "a", Key: "t2", Values: []int64{5}},
+		{Result: "b", Key: "t3", Values: []int64{7, 8}},
+	}
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected buffers -want/+got:\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestCursor_Release(t *testing.T) {
+	mem := arrowmemory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+	alloc := memory.NewResourceAllocator(mem)
+
+	results := flux.NewSliceResultIterator([]flux.Result{
+		&cursorResult{
+			name: "_result",
+			tables: []flux.Table{
+				newCursorTable(alloc, "t0", []int64{1}, []int64{2}, []int64{3}),
+			},
+		},
+	})
+
+	cur := table.NewCursor(results)
+	if !cur.NextTable() {
+		t.Fatalf("expected a table: %v", cur.Err())
+	}
+	chunk, ok := cur.NextBuffer()
+	if !ok {
+		t.Fatal("expected a buffer")
+	}
+	chunk.Release()
+
+	// Releasing the cursor discards the remaining data.
+	cur.Release()
+	cur.Release()
+	if cur.NextTable() {
+		t.Error("expected no tables after release")
+	}
+}
@@ -0,0 +1,219 @@
+package table
+
+import (
+	"bufio"
+	"encoding/hex"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/iocounter"
+	"github.com/influxdata/flux/values"
+)
+
+// PrettyOptions configures how a table is rendered by WritePretty.
+type PrettyOptions struct {
+	// MaxColumnWidth is the maximum number of characters written for a value.
+	// Longer values are truncated and end with an ellipsis.
+	// Zero means values are never truncated.
+	MaxColumnWidth int
+
+	// MaxRows is the maximum number of rows written for a table.
+	// The number of rows that were omitted is written after the table.
+	// Zero means all of the rows are written.
+	MaxRows int
+
+	// ShowTypes writes a row with the type of each column below the header.
+	ShowTypes bool
+}
+
+// DefaultPrettyOptions returns the options used to render
+// tables for interactive use.
+func DefaultPrettyOptions() PrettyOptions {
+	return PrettyOptions{
+		MaxColumnWidth: 40,
+		MaxRows:        100,
+		ShowTypes:      true,
+	}
+}
+
+const ellipsis = "..."
+
+// lineBreakReplacer escapes the line breaks in string values
+// since they would split the row.
+var lineBreakReplacer = strings.NewReplacer("\n", `\n`, "\r", `\r`)
+
+// WritePretty writes the table to w as aligned columns with a header.
+// The group key columns are written first and numeric columns are
+// aligned to the right.
+//
+// The rows are buffered to compute the column widths so at most
+// MaxRows rows are held in memory when it is set.
+func WritePretty(w io.Writer, tbl flux.Table, opts PrettyOptions) (int64, error) {
+	wc := &iocounter.Writer{Writer: w}
+	bw := bufio.NewWriter(wc)
+
+	key, cols := tbl.Key(), tbl.Cols()
+	order := prettyColumnOrder(key, cols)
+
+	header := make([]string, len(cols))
+	types := make([]string, len(cols))
+	widths := make([]int, len(cols))
+	for j, c := range cols {
+		header[j] = opts.truncate(c.Label)
+		types[j] = opts.truncate(c.Type.String())
+		widths[j] = utf8.RuneCountInString(header[j])
+		if n := utf8.RuneCountInString(types[j]); opts.ShowTypes && n > widths[j] {
+			widths[j] = n
+		}
+	}
+
+	var rows [][]string
+	omitted := 0
+	if err := tbl.Do(func(cr flux.ColReader) error {
+		for i, l := 0, cr.Len(); i < l; i++ {
+			if opts.MaxRows > 0 && len(rows) >= opts.MaxRows {
+				omitted += l - i
+				return nil
+			}
+			row := make([]string, len(cols))
+			for j, c := range cols {
+				row[j] = opts.truncate(prettyValue(cr, i, j, c.Type))
+				if n := utf8.RuneCountInString(row[j]); n > widths[j] {
+					widths[j] = n
+				}
+			}
+			rows = append(rows, row)
+		}
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	labels := make([]string, len(key.Cols()))
+	for i, c := range key.Cols() {
+		labels[i] = c.Label
+	}
+	_, _ = bw.WriteString("Table: keys: [" + strings.Join(labels, ", ") + "]\n")
+
+	writeRow := func(row []string) {
+		for oj, j := range order {
+			if oj > 0 {
+				_, _ = bw.WriteString("  ")
+			}
+			padding := strings.Repeat(" ", widths[j]-utf8.RuneCountInString(row[j]))
+			if isNumeric(cols[j].Type) {
+				_, _ = bw.WriteString(padding + row[j])
+			} else if oj < len(order)-1 {
+				_, _ = bw.WriteString(row[j] + padding)
+			} else {
+				// Do not write trailing whitespace.
+				_, _ = bw.WriteString(row[j])
+			}
+		}
+		_ = bw.WriteByte('\n')
+	}
+	writeRow(header)
+	if opts.ShowTypes {
+		writeRow(types)
+	}
+	separator := make([]string, len(cols))
+	for j := range cols {
+		separator[j] = strings.Repeat("-", widths[j])
+	}
+	writeRow(separator)
+	for _, row := range rows {
+		writeRow(row)
+	}
+	if omitted > 0 {
+		_, _ = bw.WriteString("... " + strconv.Itoa(omitted) + " more rows\n")
+	}
+
+	err := bw.Flush()
+	return wc.Count(), err
+}
+
+// truncate shortens the value to the maximum column width.
+func (o PrettyOptions) truncate(s string) string {
+	if o.MaxColumnWidth <= 0 || utf8.RuneCountInString(s) <= o.MaxColumnWidth {
+		return s
+	}
+	// The ellipsis is always written even if it is wider than the column.
+	n := o.MaxColumnWidth - len(ellipsis)
+	if n < 0 {
+		n = 0
+	}
+	runes := []rune(s)
+	return string(runes[:n]) + ellipsis
+}
+
+// prettyColumnOrder returns the indices of the columns with the
+// group key columns first and the other columns in table order.
+func prettyColumnOrder(key flux.GroupKey, cols []flux.ColMeta) []int {
+	order := make([]int, 0, len(cols))
+	for j, c := range cols {
+		if key.HasCol(c.Label) {
+			order = append(order, j)
+		}
+	}
+	for j, c := range cols {
+		if !key.HasCol(c.Label) {
+			order = append(order, j)
+		}
+	}
+	return order
+}
+
+func isNumeric(typ flux.ColType) bool {
+	return typ == flux.TInt || typ == flux.TUInt || typ == flux.TFloat
+}
+
+// prettyValue formats the value of the column in row i.
+// Null values are written as an empty string.
+func prettyValue(cr flux.ColReader, i, j int, typ flux.ColType) string {
+	if typ.IsArray() {
+		if vs := cr.Arrays(j); vs.IsValid(i) {
+			return values.DisplayString(arrow.ArrayValue(vs, i, typ.ElemType()))
+		}
+		return ""
+	}
+	switch typ {
+	case flux.TBool:
+		if vs := cr.Bools(j); vs.IsValid(i) {
+			return strconv.FormatBool(vs.Value(i))
+		}
+	case flux.TInt:
+		if vs := cr.Ints(j); vs.IsValid(i) {
+			return strconv.FormatInt(vs.Value(i), 10)
+		}
+	case flux.TUInt:
+		if vs := cr.UInts(j); vs.IsValid(i) {
+			return strconv.FormatUint(vs.Value(i), 10)
+		}
+	case flux.TFloat:
+		if vs := cr.Floats(j); vs.IsValid(i) {
+			return strconv.FormatFloat(vs.Value(i), 'f', -1, 64)
+		}
+	case flux.TString:
+		if vs := cr.Strings(j); vs.IsValid(i) {
+			return lineBreakReplacer.Replace(vs.Value(i))
+		}
+	case flux.TTime:
+		if vs := cr.Times(j); vs.IsValid(i) {
+			return values.Time(vs.Value(i)).String()
+		}
+	case flux.TBytes:
+		if vs := cr.Bytes(j); vs.IsValid(i) {
+			return "0x" + hex.EncodeToString(vs.Value(i))
+		}
+	case flux.TDuration:
+		if vs := cr.Durations(j); vs.IsValid(i) {
+			return values.ConvertDurationNsecs(time.Duration(vs.Value(i))).String()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,87 @@
+package table_test
+
+import (
+	"strings"
+	"testing"
+
+	arrowmemory "github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/memory"
+)
+
+func TestWritePretty(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		t0      string
+		buffers [][]int64
+		opts    func(opts *table.PrettyOptions)
+		want    string
+	}{
+		{
+			name:    "Default",
+			t0:      "a",
+			buffers: [][]int64{{1, 20}, {300}},
+			want: `Table: keys: [t0]
+t0      _value
+string     int
+------  ------
+a            1
+a           20
+a          300
+`,
+		},
+		{
+			name:    "MaxRows",
+			t0:      "a",
+			buffers: [][]int64{{1, 2}, {3}},
+			opts: func(opts *table.PrettyOptions) {
+				opts.MaxRows = 1
+			},
+			want: `Table: keys: [t0]
+t0      _value
+string     int
+------  ------
+a            1
+... 2 more rows
+`,
+		},
+		{
+			name:    "MaxColumnWidth",
+			t0:      "a very long tag value",
+			buffers: [][]int64{{1}},
+			opts: func(opts *table.PrettyOptions) {
+				opts.MaxColumnWidth = 10
+				opts.ShowTypes = false
+			},
+			want: `Table: keys: [t0]
+t0          _value
+----------  ------
+a very ...       1
+`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			mem := arrowmemory.NewCheckedAllocator(memory.DefaultAllocator)
+			defer mem.AssertSize(t, 0)
+			alloc := memory.NewResourceAllocator(mem)
+
+			opts := table.DefaultPrettyOptions()
+			if tt.opts != nil {
+				tt.opts(&opts)
+			}
+
+			var sb strings.Builder
+			n, err := table.WritePretty(&sb, newCursorTable(alloc, tt.t0, tt.buffers...), opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := sb.String(), tt.want; got != want {
+				t.Errorf("unexpected output -want/+got:\n%s", cmp.Diff(want, got))
+			}
+			if got, want := n, int64(sb.Len()); got != want {
+				t.Errorf("unexpected byte count -want/+got:\n\t- %d\n\t+ %d", want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package table
+
+import (
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/internal/arrowutil"
+)
+
+// Slice returns a buffer with the rows in the range [start, stop)
+// of the column reader.
+//
+// The arrays in the returned buffer are views over the arrays
+// in the column reader and no data is copied. The returned buffer
+// holds a reference to the arrays and must be released.
+func Slice(cr flux.ColReader, start, stop int) arrow.TableBuffer {
+	buf := arrow.TableBuffer{
+		GroupKey: cr.Key(),
+		Columns:  cr.Cols(),
+		Values:   make([]array.Array, len(cr.Cols())),
+	}
+	for j := range buf.Values {
+		arr := Values(cr, j)
+		if start == 0 && stop == arr.Len() {
+			arr.Retain()
+			buf.Values[j] = arr
+			continue
+		}
+		buf.Values[j] = arrow.Slice(arr, int64(start), int64(stop))
+	}
+	return buf
+}
+
+// Filter returns a buffer with the rows of the column reader
+// at the indices in the selection vector. The selection vector
+// must be sorted in ascending order.
+//
+// When the selected rows are a contiguous range, this is the same
+// as Slice and no data is copied. Otherwise, the selected rows
+// are copied into new arrays allocated with the allocator.
+// The returned buffer must be released.
+func Filter(cr flux.ColReader, sel []int, mem memory.Allocator) arrow.TableBuffer {
+	if len(sel) == 0 {
+		return Slice(cr, 0, 0)
+	} else if start, stop := sel[0], sel[len(sel)-1]+1; stop-start == len(sel) {
+		return Slice(cr, start, stop)
+	}
+
+	b := arrowutil.NewIntBuilder(mem)
+	b.Resize(len(sel))
+	for _, i := range sel {
+		b.Append(int64(i))
+	}
+	indices := b.NewIntArray()
+	b.Release()
+	defer indices.Release()
+
+	buf := arrow.TableBuffer{
+		GroupKey: cr.Key(),
+		Columns:  cr.Cols(),
+		Values:   make([]array.Array, len(cr.Cols())),
+	}
+	for j := range buf.Values {
+		buf.Values[j] = arrowutil.CopyByIndex(Values(cr, j), indices, mem)
+	}
+	return buf
+}
@@ -0,0 +1,97 @@
+package table_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/values"
+)
+
+func newSliceTestBuffer(alloc memory.Allocator) *arrow.TableBuffer {
+	key := execute.NewGroupKey(
+		[]flux.ColMeta{{Label: "_measurement", Type: flux.TString}},
+		[]values.Value{values.NewString("m0")},
+	)
+	cols := append(key.Cols(),
+		flux.ColMeta{Label: "_time", Type: flux.TTime},
+		flux.ColMeta{Label: "_value", Type: flux.TFloat},
+	)
+	return &arrow.TableBuffer{
+		GroupKey: key,
+		Columns:  cols,
+		Values: []array.Array{
+			arrow.Repeat(flux.TString, values.NewString("m0"), 5, alloc),
+			arrow.NewInt([]int64{0, 1, 2, 3, 4}, alloc),
+			arrow.NewFloat([]float64{4, 8, 7, 2, 9}, alloc),
+		},
+	}
+}
+
+func floatValues(arr *array.Float) []float64 {
+	vs := make([]float64, arr.Len())
+	for i := range vs {
+		vs[i] = arr.Value(i)
+	}
+	return vs
+}
+
+func TestSlice(t *testing.T) {
+	alloc := &memory.ResourceAllocator{}
+	in := newSliceTestBuffer(alloc)
+
+	out := table.Slice(in, 1, 4)
+	if want, got := 3, out.Len(); want != got {
+		t.Fatalf("unexpected length -want/+got:\n\t- %d\n\t+ %d", want, got)
+	}
+	if want, got := []float64{8, 7, 2}, floatValues(out.Floats(2)); !cmp.Equal(want, got) {
+		t.Errorf("unexpected values -want/+got:\n%s", cmp.Diff(want, got))
+	}
+
+	// Slicing does not allocate any new memory for the values.
+	before := alloc.Allocated()
+	in.Release()
+	if got := alloc.Allocated(); got != before {
+		t.Errorf("slice released the shared buffers: %d bytes remain, want %d", got, before)
+	}
+	out.Release()
+	if got := alloc.Allocated(); got != 0 {
+		t.Errorf("memory leak: %d bytes remain", got)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		sel  []int
+		want []float64
+	}{
+		{name: "Empty", sel: nil, want: []float64{}},
+		{name: "Contiguous", sel: []int{2, 3, 4}, want: []float64{7, 2, 9}},
+		{name: "Sparse", sel: []int{0, 2, 4}, want: []float64{4, 7, 9}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			alloc := &memory.ResourceAllocator{}
+			in := newSliceTestBuffer(alloc)
+
+			out := table.Filter(in, tt.sel, alloc)
+			if want, got := len(tt.want), out.Len(); want != got {
+				t.Fatalf("unexpected length -want/+got:\n\t- %d\n\t+ %d", want, got)
+			}
+			if got := floatValues(out.Floats(2)); !cmp.Equal(tt.want, got) {
+				t.Errorf("unexpected values -want/+got:\n%s", cmp.Diff(tt.want, got))
+			}
+
+			in.Release()
+			out.Release()
+			if got := alloc.Allocated(); got != 0 {
+				t.Errorf("memory leak: %d bytes remain", got)
+			}
+		})
+	}
+}
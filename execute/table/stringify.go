@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/arrow"
 	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/flux/values"
 )
@@ -119,6 +120,9 @@ func stringifyRows(sb *strings.Builder, cr flux.ColReader) {
 // valueForRow retrieves a value from an arrow column reader at the given index.
 func valueForRow(cr flux.ColReader, i, j int) values.Value {
 	t := cr.Cols()[j].Type
+	if t.IsArray() {
+		return arrow.ArrayValue(cr.Arrays(j), i, t.ElemType())
+	}
 	switch t {
 	case flux.TString:
 		if cr.Strings(j).IsNull(i) {
@@ -150,6 +154,16 @@ func valueForRow(cr flux.ColReader, i, j int) values.Value {
 			return values.NewNull(semantic.BasicTime)
 		}
 		return values.NewTime(values.Time(cr.Times(j).Value(i)))
+	case flux.TBytes:
+		if cr.Bytes(j).IsNull(i) {
+			return values.NewNull(semantic.BasicBytes)
+		}
+		return values.NewBytes(append([]byte(nil), cr.Bytes(j).Value(i)...))
+	case flux.TDuration:
+		if cr.Durations(j).IsNull(i) {
+			return values.NewNull(semantic.BasicDuration)
+		}
+		return values.NewDuration(values.ConvertDurationNsecs(time.Duration(cr.Durations(j).Value(i))))
 	default:
 		panic(fmt.Errorf("unknown type %v", t))
 	}
@@ -183,6 +197,15 @@ func stringifyValue(sb *strings.Builder, v values.Value) {
 		} else {
 			sb.WriteString(ts.Format(time.RFC3339))
 		}
+	case semantic.Array:
+		sb.WriteString("[")
+		v.Array().Range(func(i int, v values.Value) {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			stringifyValue(sb, v)
+		})
+		sb.WriteString("]")
 	default:
 		sb.WriteString("!(invalid)")
 	}
@@ -22,7 +22,14 @@ func Values(cr flux.ColReader, j int) array.Array {
 		return cr.Bools(j)
 	case flux.TTime:
 		return cr.Times(j)
+	case flux.TBytes:
+		return cr.Bytes(j)
+	case flux.TDuration:
+		return cr.Durations(j)
 	default:
+		if typ.IsArray() {
+			return cr.Arrays(j)
+		}
 		panic(errors.Newf(codes.Internal, "unimplemented column type: %s", typ))
 	}
 }
@@ -4,15 +4,19 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"runtime/pprof"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/apache/arrow/go/v7/arrow/memory"
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/arrow"
 	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/dependencies/metrics"
 	"github.com/influxdata/flux/execute/table"
 	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/internal/feature"
 	"github.com/influxdata/flux/internal/jaeger"
 	"github.com/influxdata/flux/interpreter"
 	"github.com/influxdata/flux/plan"
@@ -65,9 +69,36 @@ type consecutiveTransport struct {
 
 	initSpanOnce sync.Once
 	span         opentracing.Span
+
+	// labels holds the pprof labels of the node that are
+	// set on the dispatcher goroutine while it processes messages.
+	labels context.Context
+
+	// trace is set when the tables and rows processed
+	// by the transformation are recorded in its span.
+	trace           bool
+	tablesProcessed int64
+	rowsProcessed   int64
+
+	// latency records the time spent processing each message
+	// when a metrics registry is present.
+	latency metrics.Histogram
+
+	// groupRows is set when the rows of each table
+	// produced by the predecessor are limited.
+	groupRows *groupRowLimit
 }
 
 func newConsecutiveTransport(ctx context.Context, dispatcher Dispatcher, t Transformation, n plan.Node, logger *zap.Logger, mem memory.Allocator) *consecutiveTransport {
+	var latency metrics.Histogram
+	if registry := metrics.GetRegistry(ctx); registry != nil {
+		latency = registry.NodeDuration(OperationType(t))
+	}
+	labels := pprof.WithLabels(ctx, pprof.Labels(
+		GoroutineLabel, "dispatcher",
+		NodeIDLabel, string(n.ID()),
+		NodeTypeLabel, OperationType(t),
+	))
 	return &consecutiveTransport{
 		ctx:        ctx,
 		dispatcher: dispatcher,
@@ -80,7 +111,10 @@ func newConsecutiveTransport(ctx context.Context, dispatcher Dispatcher, t Trans
 			Label:    string(n.ID()),
 		},
 		stack:    n.CallStack(),
+		labels:   labels,
 		finished: make(chan struct{}),
+		trace:    feature.TraceTransformations().Enabled(ctx),
+		latency:  latency,
 	}
 }
 
@@ -237,11 +271,17 @@ func (t *consecutiveTransport) initSpan(ctx context.Context) {
 
 func (t *consecutiveTransport) finishSpan(err error) {
 	t.span.LogFields(log.Int("messages_processed", int(atomic.LoadInt32(&t.totalMsgs))), log.Error(err))
+	if t.trace {
+		t.span.SetTag("tables_processed", atomic.LoadInt64(&t.tablesProcessed))
+		t.span.SetTag("rows_processed", atomic.LoadInt64(&t.rowsProcessed))
+	}
 	t.span.Finish()
 }
 
 func (t *consecutiveTransport) processMessages(ctx context.Context, throughput int) {
 	t.initSpan(ctx)
+	pprof.SetGoroutineLabels(t.labels)
+	defer pprof.SetGoroutineLabels(ctx)
 
 PROCESS:
 	i := 0
@@ -294,6 +334,25 @@ func (t *consecutiveTransport) processMessage(m Message) (finished bool, err err
 	span := t.profile.StartSpan()
 	defer span.Finish()
 
+	if t.latency != nil {
+		start := time.Now()
+		defer func() {
+			t.latency.Observe(time.Since(start).Seconds())
+		}()
+	}
+
+	if t.trace {
+		t.countMessage(m)
+	}
+	switch m := m.(type) {
+	case ProcessChunkMsg:
+		chunk := m.TableChunk()
+		if err := t.groupRows.add(chunk.Key(), chunk.Len()); err != nil {
+			return false, err
+		}
+	case FlushKeyMsg:
+		t.groupRows.finish(m.Key())
+	}
 	if err := t.t.ProcessMessage(m); err != nil {
 		return false, err
 	}
@@ -301,6 +360,17 @@ func (t *consecutiveTransport) processMessage(m Message) (finished bool, err err
 	return finished, nil
 }
 
+// countMessage records the data sent to the transformation by the message.
+// The rows of a table are counted when the table is read.
+func (t *consecutiveTransport) countMessage(m Message) {
+	switch m := m.(type) {
+	case ProcessMsg:
+		atomic.AddInt64(&t.tablesProcessed, 1)
+	case ProcessChunkMsg:
+		atomic.AddInt64(&t.rowsProcessed, int64(m.TableChunk().Len()))
+	}
+}
+
 // Message is a message sent from one Dataset to another.
 type Message interface {
 	// Type returns the MessageType for this Message.
@@ -551,6 +621,7 @@ func (t *consecutiveTransportTable) Cols() []flux.ColMeta {
 }
 
 func (t *consecutiveTransportTable) Do(f func(flux.ColReader) error) error {
+	defer t.transport.groupRows.finish(t.tbl.Key())
 	return t.tbl.Do(func(cr flux.ColReader) error {
 		if err := t.validate(cr); err != nil {
 			fields := []zap.Field{
@@ -569,6 +640,12 @@ func (t *consecutiveTransportTable) Do(f func(flux.ColReader) error) error {
 			}
 			logger.Info("Invalid column reader received from predecessor", fields...)
 		}
+		if t.transport.trace {
+			atomic.AddInt64(&t.transport.rowsProcessed, int64(cr.Len()))
+		}
+		if err := t.transport.groupRows.add(t.tbl.Key(), cr.Len()); err != nil {
+			return err
+		}
 		return f(cr)
 	})
 }
@@ -289,6 +289,9 @@ func CopyStringsTo(b *array.StringBuilder, arr *array.String) {
 }
 
 func CopyStringsByIndex(arr *array.String, indices *array.Int, mem memory.Allocator) *array.String {
+	if arr.IsDictionary() {
+		return array.DictionaryTake(arr, indices, mem)
+	}
 	b := NewStringBuilder(mem)
 	CopyStringsByIndexTo(b, arr, indices)
 	return b.NewStringArray()
@@ -102,6 +102,9 @@ func FilterBooleans(arr *array.Boolean, bitset []byte, mem memory.Allocator) *ar
 }
 
 func FilterStrings(arr *array.String, bitset []byte, mem memory.Allocator) *array.String {
+	if arr.IsDictionary() {
+		return array.DictionaryFilter(arr, bitset, mem)
+	}
 	n := bitutil.CountSetBits(bitset, 0, len(bitset))
 	b := NewStringBuilder(mem)
 	b.Resize(n)
@@ -0,0 +1,25 @@
+package arrowutil
+
+import "github.com/influxdata/flux/array"
+
+// FloatValueFunc returns a function that reads the value of a numeric
+// array at an index as a float and whether the value is valid.
+// It returns false if the array is not a float, int or uint array.
+func FloatValueFunc(arr array.Array) (func(i int) (float64, bool), bool) {
+	switch vs := arr.(type) {
+	case *array.Float:
+		return func(i int) (float64, bool) {
+			return vs.Value(i), vs.IsValid(i)
+		}, true
+	case *array.Int:
+		return func(i int) (float64, bool) {
+			return float64(vs.Value(i)), vs.IsValid(i)
+		}, true
+	case *array.Uint:
+		return func(i int) (float64, bool) {
+			return float64(vs.Value(i)), vs.IsValid(i)
+		}, true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,36 @@
+package arrowutil_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/internal/arrowutil"
+	"github.com/influxdata/flux/memory"
+)
+
+func TestFloatValueFunc(t *testing.T) {
+	ib := array.NewIntBuilder(memory.DefaultAllocator)
+	ib.Append(2)
+	ib.AppendNull()
+	ints := ib.NewIntArray()
+	defer ints.Release()
+
+	fn, ok := arrowutil.FloatValueFunc(ints)
+	if !ok {
+		t.Fatal("expected an int array to be numeric")
+	}
+	if v, valid := fn(0); v != 2 || !valid {
+		t.Errorf("unexpected value at index 0: %v, %v", v, valid)
+	}
+	if _, valid := fn(1); valid {
+		t.Error("expected a null value at index 1")
+	}
+
+	sb := array.NewStringBuilder(memory.DefaultAllocator)
+	sb.Append("a")
+	strs := sb.NewStringArray()
+	defer strs.Release()
+	if _, ok := arrowutil.FloatValueFunc(strs); ok {
+		t.Error("expected a string array to not be numeric")
+	}
+}
@@ -271,9 +271,13 @@ func (r *ScopeHolder) doQuery(ctx context.Context, spec *operation.Spec) error {
 	if err != nil {
 		return err
 	}
-	defer qry.Done()
+	// The result iterator includes the results of the
+	// profilers enabled by the profiler.enabledProfilers option.
+	results := flux.NewResultIteratorFromQuery(qry)
+	defer results.Release()
 
-	for result := range qry.Results() {
+	for results.More() {
+		result := results.Next()
 		tables := result.Tables()
 		fmt.Println("Result:", result.Name())
 		if err := tables.Do(func(tbl flux.Table) error {
@@ -283,8 +287,8 @@ func (r *ScopeHolder) doQuery(ctx context.Context, spec *operation.Spec) error {
 			return err
 		}
 	}
-	qry.Done()
-	return qry.Err()
+	results.Release()
+	return results.Err()
 }
 
 // LoadQuery returns the Flux query q, except for two special cases:
@@ -1,6 +1,7 @@
 package groupkey
 
 import (
+	"bytes"
 	"fmt"
 	"sort"
 	"strings"
@@ -144,6 +145,15 @@ func (k *groupKey) hash64() (h uint64) {
 			case flux.TTime:
 				arrow.Int64Traits.PutValue(data[:], int64(v.Time()))
 				_, _ = hash.Write(data[:arrow.Int64SizeBytes])
+			case flux.TBytes:
+				_, _ = hash.Write(v.Bytes())
+			case flux.TDuration:
+				arrow.Int64Traits.PutValue(data[:], int64(v.Duration().Duration()))
+				_, _ = hash.Write(data[:arrow.Int64SizeBytes])
+			default:
+				if c.Type.IsArray() {
+					_, _ = hash.WriteString(values.DisplayString(v))
+				}
 			}
 		} else {
 			// Write an invalid byte if there is a null value
@@ -210,6 +220,18 @@ func groupKeyEqual(a *groupKey, other flux.GroupKey, truenulls bool) bool {
 			if a.ValueTime(idx) != b.ValueTime(jdx) {
 				return false
 			}
+		case flux.TBytes:
+			if !bytes.Equal(a.values[idx].Bytes(), b.values[jdx].Bytes()) {
+				return false
+			}
+		case flux.TDuration:
+			if !a.ValueDuration(idx).Equal(b.ValueDuration(jdx)) {
+				return false
+			}
+		default:
+			if a.cols[idx].Type.IsArray() && !a.values[idx].Equal(b.values[jdx]) {
+				return false
+			}
 		}
 	}
 	return true
@@ -280,6 +302,21 @@ func groupKeyLess(a *groupKey, other flux.GroupKey) bool {
 			if av, bv := a.ValueTime(idx), b.ValueTime(jdx); av != bv {
 				return av < bv
 			}
+		case flux.TBytes:
+			if c := bytes.Compare(a.values[idx].Bytes(), b.values[jdx].Bytes()); c != 0 {
+				return c < 0
+			}
+		case flux.TDuration:
+			if av, bv := a.ValueDuration(idx).Duration(), b.ValueDuration(jdx).Duration(); av != bv {
+				return av < bv
+			}
+		default:
+			// Arrays in a group key are ordered by how they are displayed.
+			if a.cols[idx].Type.IsArray() {
+				if av, bv := values.DisplayString(a.values[idx]), values.DisplayString(b.values[jdx]); av != bv {
+					return av < bv
+				}
+			}
 		}
 	}
 
@@ -165,6 +165,46 @@ func TestGroupKey_Equal(t *testing.T) {
 			),
 			want: false,
 		},
+		{
+			name: "Bytes",
+			left: execute.NewGroupKey(
+				[]flux.ColMeta{
+					{Label: "a", Type: flux.TBytes},
+				},
+				[]values.Value{
+					values.NewBytes([]byte("b")),
+				},
+			),
+			right: execute.NewGroupKey(
+				[]flux.ColMeta{
+					{Label: "a", Type: flux.TBytes},
+				},
+				[]values.Value{
+					values.NewBytes([]byte("b")),
+				},
+			),
+			want: true,
+		},
+		{
+			name: "UnequalBytes",
+			left: execute.NewGroupKey(
+				[]flux.ColMeta{
+					{Label: "a", Type: flux.TBytes},
+				},
+				[]values.Value{
+					values.NewBytes([]byte("b")),
+				},
+			),
+			right: execute.NewGroupKey(
+				[]flux.ColMeta{
+					{Label: "a", Type: flux.TBytes},
+				},
+				[]values.Value{
+					values.NewBytes([]byte("c")),
+				},
+			),
+			want: false,
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			if want, got := tt.want, tt.left.Equal(tt.right); want != got {
@@ -332,6 +372,26 @@ func TestGroupKey_Less(t *testing.T) {
 			),
 			want: [2]bool{true, false},
 		},
+		{
+			name: "Bytes_LessThan",
+			left: execute.NewGroupKey(
+				[]flux.ColMeta{
+					{Label: "a", Type: flux.TBytes},
+				},
+				[]values.Value{
+					values.NewBytes([]byte{0x01}),
+				},
+			),
+			right: execute.NewGroupKey(
+				[]flux.ColMeta{
+					{Label: "a", Type: flux.TBytes},
+				},
+				[]values.Value{
+					values.NewBytes([]byte{0x01, 0x00}),
+				},
+			),
+			want: [2]bool{true, false},
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			if want, got := tt.want[0], tt.left.Less(tt.right); want != got {
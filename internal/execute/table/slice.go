@@ -0,0 +1,16 @@
+package table
+
+import (
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/execute/table"
+)
+
+func Slice(cr flux.ColReader, start, stop int) arrow.TableBuffer {
+	return table.Slice(cr, start, stop)
+}
+
+func Filter(cr flux.ColReader, sel []int, mem memory.Allocator) arrow.TableBuffer {
+	return table.Filter(cr, sel, mem)
+}
@@ -209,6 +209,42 @@ func Strictnulllogicalops() BoolFlag {
 	return strictNullLogicalOps
 }
 
+var columnarPivot = feature.MakeBoolFlag(
+	"Columnar Pivot",
+	"columnarPivot",
+	"Jonathan Sternberg",
+	false,
+)
+
+// ColumnarPivot - Enable the implementation of pivot that builds the output columns directly
+func ColumnarPivot() BoolFlag {
+	return columnarPivot
+}
+
+var traceTransformations = feature.MakeBoolFlag(
+	"Trace Transformations",
+	"traceTransformations",
+	"Jonathan Sternberg, Adrian Thurston",
+	false,
+)
+
+// TraceTransformations - Record the number of tables and rows processed by each transformation in its tracing span
+func TraceTransformations() BoolFlag {
+	return traceTransformations
+}
+
+var debugLog = feature.MakeBoolFlag(
+	"Debug Log",
+	"debugLog",
+	"Jonathan Sternberg",
+	false,
+)
+
+// DebugLog - Write a summary of the tables passing through debug.log to the logger
+func DebugLog() BoolFlag {
+	return debugLog
+}
+
 // Inject will inject the Flagger into the context.
 func Inject(ctx context.Context, flagger Flagger) context.Context {
 	return feature.Inject(ctx, flagger)
@@ -231,6 +267,9 @@ var all = []Flag{
 	vectorizedFloat,
 	vectorizedUnaryOps,
 	strictNullLogicalOps,
+	columnarPivot,
+	traceTransformations,
+	debugLog,
 }
 
 var byKey = map[string]Flag{
@@ -250,6 +289,9 @@ var byKey = map[string]Flag{
 	"vectorizedFloat":                  vectorizedFloat,
 	"vectorizedUnaryOps":               vectorizedUnaryOps,
 	"strictNullLogicalOps":             strictNullLogicalOps,
+	"columnarPivot":                    columnarPivot,
+	"traceTransformations":             traceTransformations,
+	"debugLog":                         debugLog,
 }
 
 // Flags returns all feature flags.
@@ -0,0 +1,180 @@
+// Package histogram implements a cumulative histogram of float values
+// that can be merged, queried for quantiles, and serialized.
+package histogram
+
+import (
+	"encoding/binary"
+	"math"
+	"sort"
+
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+)
+
+// Bucket is a bucket of a cumulative histogram.
+type Bucket struct {
+	// UpperBound is the inclusive upper bound of the bucket.
+	UpperBound float64
+	// Count is the number of values less than or equal to the upper bound.
+	Count float64
+}
+
+// Histogram is a cumulative histogram with buckets
+// sorted by their upper bound.
+type Histogram struct {
+	Buckets []Bucket
+}
+
+// FromBuckets creates a histogram from cumulative buckets.
+// The buckets are sorted by their upper bound if they are not already.
+func FromBuckets(buckets []Bucket) *Histogram {
+	if !sort.SliceIsSorted(buckets, func(i, j int) bool {
+		return buckets[i].UpperBound < buckets[j].UpperBound
+	}) {
+		sort.Slice(buckets, func(i, j int) bool {
+			return buckets[i].UpperBound < buckets[j].UpperBound
+		})
+	}
+	return &Histogram{Buckets: buckets}
+}
+
+// FromCounts creates a histogram from sorted upper bounds and the number
+// of values in each bucket that are not in the previous buckets.
+func FromCounts(bounds, counts []float64) *Histogram {
+	h := &Histogram{Buckets: make([]Bucket, len(bounds))}
+	var total float64
+	for i, bound := range bounds {
+		total += counts[i]
+		h.Buckets[i] = Bucket{UpperBound: bound, Count: total}
+	}
+	return h
+}
+
+// Total returns the number of values in the histogram.
+func (h *Histogram) Total() float64 {
+	if len(h.Buckets) == 0 {
+		return 0
+	}
+	return h.Buckets[len(h.Buckets)-1].Count
+}
+
+// Normalize divides each count by the number of values in the
+// histogram so the counts are the fraction of values in each bucket.
+func (h *Histogram) Normalize() {
+	total := h.Total()
+	for i := range h.Buckets {
+		h.Buckets[i].Count /= total
+	}
+}
+
+// Merge adds the counts of another histogram to the histogram.
+// Both histograms must have the same upper bounds.
+func (h *Histogram) Merge(other *Histogram) error {
+	if len(h.Buckets) != len(other.Buckets) {
+		return errors.Newf(codes.Invalid, "cannot merge histograms with %d and %d buckets", len(h.Buckets), len(other.Buckets))
+	}
+	for i, b := range other.Buckets {
+		if h.Buckets[i].UpperBound != b.UpperBound {
+			return errors.Newf(codes.Invalid, "cannot merge histograms with different upper bounds %v and %v", h.Buckets[i].UpperBound, b.UpperBound)
+		}
+	}
+	for i, b := range other.Buckets {
+		h.Buckets[i].Count += b.Count
+	}
+	return nil
+}
+
+// Quantile computes the value at the quantile q by linearly interpolating
+// between the upper bounds of the buckets. The minValue is the lower bound
+// of the first bucket.
+func (h *Histogram) Quantile(q, minValue float64) (float64, error) {
+	cdf := h.Buckets
+	if len(cdf) == 0 {
+		return 0, errors.New(codes.FailedPrecondition, "histogram is empty")
+	}
+	// Find rank index and check counts are monotonic
+	prevCount := 0.0
+	totalCount := cdf[len(cdf)-1].Count
+	rank := q * totalCount
+	rankIdx := -1
+	for i, b := range cdf {
+		if b.Count < prevCount {
+			return 0, errors.New(codes.FailedPrecondition, "histogram records counts are not monotonic")
+		}
+		prevCount = b.Count
+
+		if rank >= b.Count {
+			rankIdx = i
+		}
+	}
+	var (
+		lowerCount,
+		lowerBound,
+		upperCount,
+		upperBound float64
+	)
+	switch rankIdx {
+	case -1:
+		// Quantile is below the lowest upper bound, interpolate using the min value
+		lowerCount = 0
+		lowerBound = minValue
+		upperCount = cdf[0].Count
+		upperBound = cdf[0].UpperBound
+	case len(cdf) - 1:
+		// Quantile is above the highest upper bound, simply return it as it must be finite
+		return cdf[len(cdf)-1].UpperBound, nil
+	default:
+		lowerCount = cdf[rankIdx].Count
+		lowerBound = cdf[rankIdx].UpperBound
+		upperCount = cdf[rankIdx+1].Count
+		upperBound = cdf[rankIdx+1].UpperBound
+	}
+	if rank == lowerCount {
+		// No need to interpolate
+		return lowerBound, nil
+	}
+	if math.IsInf(lowerBound, -1) {
+		// We cannot interpolate with infinity
+		return upperBound, nil
+	}
+	if math.IsInf(upperBound, 1) {
+		// We cannot interpolate with infinity
+		return lowerBound, nil
+	}
+	// Compute quantile using linear interpolation
+	scale := (rank - lowerCount) / (upperCount - lowerCount)
+	return lowerBound + (upperBound-lowerBound)*scale, nil
+}
+
+// MarshalBinary encodes the histogram as the number of buckets
+// followed by the upper bound and count of each bucket.
+func (h *Histogram) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, binary.MaxVarintLen64+16*len(h.Buckets))
+	sz := binary.PutUvarint(buf, uint64(len(h.Buckets)))
+	for _, b := range h.Buckets {
+		binary.LittleEndian.PutUint64(buf[sz:], math.Float64bits(b.UpperBound))
+		binary.LittleEndian.PutUint64(buf[sz+8:], math.Float64bits(b.Count))
+		sz += 16
+	}
+	return buf[:sz], nil
+}
+
+// UnmarshalBinary decodes a histogram encoded by MarshalBinary.
+func (h *Histogram) UnmarshalBinary(data []byte) error {
+	n, sz := binary.Uvarint(data)
+	if sz <= 0 {
+		return errors.New(codes.Invalid, "invalid histogram encoding: missing bucket count")
+	}
+	data = data[sz:]
+	if uint64(len(data)) != 16*n {
+		return errors.Newf(codes.Invalid, "invalid histogram encoding: expected %d bytes for %d buckets, got %d", 16*n, n, len(data))
+	}
+	h.Buckets = make([]Bucket, n)
+	for i := range h.Buckets {
+		h.Buckets[i] = Bucket{
+			UpperBound: math.Float64frombits(binary.LittleEndian.Uint64(data[16*i:])),
+			Count:      math.Float64frombits(binary.LittleEndian.Uint64(data[16*i+8:])),
+		}
+	}
+	return nil
+}
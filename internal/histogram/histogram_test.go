@@ -0,0 +1,124 @@
+package histogram_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/internal/histogram"
+)
+
+func TestFromCounts(t *testing.T) {
+	h := histogram.FromCounts([]float64{1, 2, math.Inf(1)}, []float64{2, 0, 3})
+	want := []histogram.Bucket{
+		{UpperBound: 1, Count: 2},
+		{UpperBound: 2, Count: 2},
+		{UpperBound: math.Inf(1), Count: 5},
+	}
+	if !cmp.Equal(want, h.Buckets) {
+		t.Fatalf("unexpected buckets -want/+got:\n%s", cmp.Diff(want, h.Buckets))
+	}
+
+	h.Normalize()
+	want = []histogram.Bucket{
+		{UpperBound: 1, Count: 0.4},
+		{UpperBound: 2, Count: 0.4},
+		{UpperBound: math.Inf(1), Count: 1},
+	}
+	if !cmp.Equal(want, h.Buckets) {
+		t.Fatalf("unexpected normalized buckets -want/+got:\n%s", cmp.Diff(want, h.Buckets))
+	}
+}
+
+func TestFromBuckets_Unsorted(t *testing.T) {
+	h := histogram.FromBuckets([]histogram.Bucket{
+		{UpperBound: 3, Count: 4},
+		{UpperBound: 1, Count: 1},
+		{UpperBound: 2, Count: 3},
+	})
+	want := []histogram.Bucket{
+		{UpperBound: 1, Count: 1},
+		{UpperBound: 2, Count: 3},
+		{UpperBound: 3, Count: 4},
+	}
+	if !cmp.Equal(want, h.Buckets) {
+		t.Fatalf("unexpected buckets -want/+got:\n%s", cmp.Diff(want, h.Buckets))
+	}
+}
+
+func TestQuantile(t *testing.T) {
+	h := histogram.FromCounts([]float64{1, 2, 3, math.Inf(1)}, []float64{1, 2, 1, 0})
+	for _, tc := range []struct {
+		q, want float64
+	}{
+		{q: 0.1, want: 0.4},
+		{q: 0.25, want: 1},
+		{q: 0.5, want: 1.5},
+		{q: 0.9, want: 2.6},
+		{q: 0.95, want: 2.8},
+	} {
+		got, err := h.Quantile(tc.q, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(got-tc.want) > 1e-9 {
+			t.Errorf("unexpected quantile %v: want %v, got %v", tc.q, tc.want, got)
+		}
+	}
+
+	empty := &histogram.Histogram{}
+	if _, err := empty.Quantile(0.5, 0); !cmp.Equal(errors.New(codes.FailedPrecondition, "histogram is empty"), err) {
+		t.Errorf("unexpected error for empty histogram: %v", err)
+	}
+	decreasing := histogram.FromBuckets([]histogram.Bucket{
+		{UpperBound: 1, Count: 2},
+		{UpperBound: 2, Count: 1},
+	})
+	if _, err := decreasing.Quantile(0.5, 0); !cmp.Equal(errors.New(codes.FailedPrecondition, "histogram records counts are not monotonic"), err) {
+		t.Errorf("unexpected error for decreasing histogram: %v", err)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	bounds := []float64{1, 2, math.Inf(1)}
+	h := histogram.FromCounts(bounds, []float64{1, 2, 3})
+	if err := h.Merge(histogram.FromCounts(bounds, []float64{3, 0, 1})); err != nil {
+		t.Fatal(err)
+	}
+	want := histogram.FromCounts(bounds, []float64{4, 2, 4})
+	if !cmp.Equal(want, h) {
+		t.Fatalf("unexpected merged histogram -want/+got:\n%s", cmp.Diff(want, h))
+	}
+
+	if err := h.Merge(histogram.FromCounts([]float64{1, 5, math.Inf(1)}, []float64{1, 1, 1})); err == nil {
+		t.Error("expected error merging histograms with different upper bounds")
+	}
+	if err := h.Merge(histogram.FromCounts([]float64{1}, []float64{1})); err == nil {
+		t.Error("expected error merging histograms with a different number of buckets")
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	h := histogram.FromCounts([]float64{-1.5, 0, 10, math.Inf(1)}, []float64{1, 0.5, 7, 2})
+	data, err := h.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got histogram.Histogram
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(h, &got) {
+		t.Fatalf("unexpected decoded histogram -want/+got:\n%s", cmp.Diff(h, &got))
+	}
+
+	if err := got.UnmarshalBinary(data[:len(data)-1]); err == nil {
+		t.Error("expected error decoding truncated histogram")
+	}
+	if err := got.UnmarshalBinary(nil); err == nil {
+		t.Error("expected error decoding empty data")
+	}
+}
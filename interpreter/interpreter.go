@@ -788,7 +788,14 @@ func (itrp *Interpreter) doCall(ctx context.Context, call *semantic.CallExpressi
 	// for the currently called function.
 	fname := functionName(call)
 	ctx = withStackEntry(ctx, fname, call.Location())
+	var done func()
+	if p := GetFunctionProfiler(ctx); p != nil {
+		done = p.StartCall(ProfileName(call))
+	}
 	value, err := f.Call(ctx, argObj)
+	if done != nil {
+		done()
+	}
 	if err != nil {
 		// If a function has an underscore as a prefix, consider it
 		// as an internal call and don't add it to the error message.
@@ -1751,6 +1758,7 @@ type contextKey int
 
 const (
 	callStackKey contextKey = iota
+	functionProfilerKey
 )
 
 // StackEntry describes a single entry in the call stack.
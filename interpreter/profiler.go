@@ -0,0 +1,51 @@
+package interpreter
+
+import (
+	"context"
+
+	"github.com/influxdata/flux/semantic"
+)
+
+// FunctionProfiler records the time spent in the functions called by a program.
+type FunctionProfiler interface {
+	// StartCall is invoked before the function with the name is called.
+	// The returned function is invoked when the call returns.
+	StartCall(name string) func()
+}
+
+// WithFunctionProfiler attaches a function that returns the FunctionProfiler
+// for the function calls made with the context.
+//
+// The function is invoked for each call so a profiler may be enabled after
+// the context has been created, such as by the profiler.enabledProfilers option.
+// It returns nil when function calls are not profiled.
+func WithFunctionProfiler(ctx context.Context, fn func() FunctionProfiler) context.Context {
+	return context.WithValue(ctx, functionProfilerKey, fn)
+}
+
+// GetFunctionProfiler returns the FunctionProfiler for the context
+// or nil if function calls are not profiled.
+func GetFunctionProfiler(ctx context.Context) FunctionProfiler {
+	fn, ok := ctx.Value(functionProfilerKey).(func() FunctionProfiler)
+	if !ok {
+		return nil
+	}
+	return fn()
+}
+
+// ProfileName returns the name that a FunctionProfiler records for the
+// function called by the call expression. Functions that are members of a
+// package include the name of the package, such as strings.toUpper.
+func ProfileName(call *semantic.CallExpression) string {
+	switch callee := call.Callee.(type) {
+	case *semantic.IdentifierExpression:
+		return callee.Name.Name()
+	case *semantic.MemberExpression:
+		if obj, ok := callee.Object.(*semantic.IdentifierExpression); ok {
+			return obj.Name.Name() + "." + callee.Property.Name()
+		}
+		return callee.Property.Name()
+	default:
+		return "<anonymous function>"
+	}
+}
@@ -8,8 +8,10 @@ import (
 
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/dependencies/authorizer"
 	"github.com/influxdata/flux/dependency"
 	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
 	"github.com/influxdata/flux/internal/errors"
 	"github.com/influxdata/flux/internal/jaeger"
 	"github.com/influxdata/flux/internal/operation"
@@ -302,6 +304,12 @@ func (p *Program) Start(ctx context.Context, alloc memory.Allocator) (flux.Query
 		q.stats.Metadata.Add("tracing/sampled", sampled)
 	}
 
+	if err := authorizePlan(ctx, p.PlanSpec); err != nil {
+		cancel()
+		s.Finish()
+		return nil, err
+	}
+
 	q.stats.Metadata.Add("flux/query-plan",
 		fmt.Sprintf("%v", plan.Formatted(p.PlanSpec, plan.WithDetails())))
 
@@ -323,6 +331,22 @@ func (p *Program) Start(ctx context.Context, alloc memory.Allocator) (flux.Query
 	return q, nil
 }
 
+// authorizePlan calls the Authorizer injected into the context
+// with the plan before it is executed.
+func authorizePlan(ctx context.Context, ps *plan.Spec) error {
+	a := authorizer.GetAuthorizer(ctx)
+	if a == nil {
+		return nil
+	}
+	if err := a.AuthorizePlan(ctx, ps); err != nil {
+		if errors.Code(err) == codes.Unknown {
+			return errors.Wrap(err, codes.PermissionDenied, "query is not authorized")
+		}
+		return err
+	}
+	return nil
+}
+
 func (p *Program) processResults(ctx context.Context, q *query, resultMap map[string]flux.Result) {
 	defer q.wg.Done()
 	defer close(q.results)
@@ -387,7 +411,10 @@ type ExecOptsConfig struct {
 }
 
 func (eoc *ExecOptsConfig) ConfigureProfiler(ctx context.Context, profilerNames []string) {
-	var tfProfiler *execute.OperatorProfiler
+	var (
+		tfProfiler *execute.OperatorProfiler
+		fnProfiler *execute.FunctionProfiler
+	)
 	dedupeMap := make(map[string]bool)
 	profilers := make([]execute.Profiler, 0)
 	for _, profilerName := range profilerNames {
@@ -409,13 +436,22 @@ func (eoc *ExecOptsConfig) ConfigureProfiler(ctx context.Context, profilerNames
 
 				tfProfiler = tfp
 			}
+			if fp, ok := profiler.(*execute.FunctionProfiler); ok {
+				// The function profiler is found by the interpreter and
+				// compiled functions through the execution dependencies.
+				fnProfiler = fp
+			}
 			profilers = append(profilers, profiler)
 		}
 	}
 
 	if execute.HaveExecutionDependencies(ctx) {
 		deps := execute.GetExecutionDependencies(ctx)
+		if fnProfiler != nil {
+			fnProfiler.Allocator = deps.Allocator
+		}
 		deps.ExecutionOptions.OperatorProfiler = tfProfiler
+		deps.ExecutionOptions.FunctionProfiler = fnProfiler
 		deps.ExecutionOptions.Profilers = profilers
 	}
 }
@@ -483,10 +519,12 @@ func (p *AstProgram) Start(ctx context.Context, alloc memory.Allocator) (flux.Qu
 	ctx = context.WithValue(ctx, plan.NextPlanNodeIDKey, nextPlanNodeID)
 
 	// Evaluation.
+	start := time.Now()
 	sp, scope, err := p.getSpec(ctx, alloc)
 	if err != nil {
 		return nil, err
 	}
+	compiled := time.Now()
 
 	// Planning.
 	s, cctx := opentracing.StartSpanFromContext(ctx, "plan")
@@ -502,6 +540,7 @@ func (p *AstProgram) Start(ctx context.Context, alloc memory.Allocator) (flux.Qu
 	}
 	p.PlanSpec = ps
 	s.Finish()
+	planned := time.Now()
 
 	// Execution.
 	s, cctx = opentracing.StartSpanFromContext(ctx, "start-program")
@@ -512,8 +551,13 @@ func (p *AstProgram) Start(ctx context.Context, alloc memory.Allocator) (flux.Qu
 		return nil, err
 	}
 	return &spanQuery{
-		Query: q,
-		span:  span,
+		Query:           q,
+		span:            span,
+		profilers:       p.Profilers,
+		start:           start,
+		executeStart:    planned,
+		compileDuration: compiled.Sub(start),
+		planDuration:    planned.Sub(compiled),
 	}, nil
 }
 
@@ -538,20 +582,59 @@ func (p *AstProgram) updateOpts(scope values.Scope) error {
 	if lo != nil {
 		p.opts.planOptions.logical = append(p.opts.planOptions.logical, lo)
 	}
-	if po != nil {
-		p.opts.planOptions.physical = append(p.opts.planOptions.physical, po)
-	}
+	p.opts.planOptions.physical = append(p.opts.planOptions.physical, po...)
 	return nil
 }
 
 type spanQuery struct {
 	flux.Query
-	span *dependency.Span
+	span      *dependency.Span
+	profilers []execute.Profiler
+
+	start, executeStart           time.Time
+	compileDuration, planDuration time.Duration
+	executeDuration               time.Duration
 }
 
 func (q *spanQuery) Done() {
 	q.Query.Done()
 	q.span.Finish()
+	if q.executeDuration == 0 {
+		q.executeDuration = time.Since(q.executeStart)
+	}
+}
+
+// Statistics adds the duration of each phase of the program
+// to the statistics of the query.
+func (q *spanQuery) Statistics() flux.Statistics {
+	stats := q.Query.Statistics()
+	stats.CompileDuration = q.compileDuration
+	stats.PlanDuration = q.planDuration
+	if q.executeDuration > 0 {
+		stats.ExecuteDuration = q.executeDuration
+		stats.TotalDuration = q.executeStart.Sub(q.start) + q.executeDuration
+	}
+	return stats
+}
+
+// ProfilerResults returns a result with a table from each profiler
+// enabled by the profiler.enabledProfilers option.
+// It returns nil if no profilers were enabled.
+// The results are not complete until Done is called.
+func (q *spanQuery) ProfilerResults() (flux.ResultIterator, error) {
+	if len(q.profilers) == 0 {
+		return nil, nil
+	}
+	tables := make([]flux.Table, 0, len(q.profilers))
+	for _, p := range q.profilers {
+		tbl, err := p.GetResult(q, memory.DefaultAllocator)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, tbl)
+	}
+	result := table.NewProfilerResult(tables...)
+	return flux.NewSliceResultIterator([]flux.Result{&result}), nil
 }
 
 func getPackageFromScope(pkgName string, scope values.Scope) (values.Package, bool) {
@@ -571,7 +654,7 @@ func getPackageFromScope(pkgName string, scope values.Scope) (values.Package, bo
 	return foundPkg, found
 }
 
-func getPlanOptions(plannerPkg values.Package) (plan.LogicalOption, plan.PhysicalOption, error) {
+func getPlanOptions(plannerPkg values.Package) (plan.LogicalOption, []plan.PhysicalOption, error) {
 	if plannerPkg.Type().Nature() != semantic.Object {
 		// No import for planner, this is useless.
 		return nil, nil, nil
@@ -585,7 +668,11 @@ func getPlanOptions(plannerPkg values.Package) (plan.LogicalOption, plan.Physica
 	if err != nil {
 		return nil, nil, err
 	}
-	return plan.RemoveLogicalRules(ls...), plan.RemovePhysicalRules(ps...), nil
+	physical := []plan.PhysicalOption{plan.RemovePhysicalRules(ps...)}
+	if value, ok := plannerPkg.Object().Get("stableOutput"); ok && value.Type().Nature() == semantic.Bool && value.Bool() {
+		physical = append(physical, plan.StableOutput())
+	}
+	return plan.RemoveLogicalRules(ls...), physical, nil
 }
 
 func getOptionValues(pkg values.Object, optionName string) ([]string, error) {
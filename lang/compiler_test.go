@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
@@ -14,7 +15,9 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/codes"
 	fcsv "github.com/influxdata/flux/csv"
+	"github.com/influxdata/flux/dependencies/authorizer"
 	"github.com/influxdata/flux/dependencies/dependenciestest"
 	"github.com/influxdata/flux/dependency"
 	"github.com/influxdata/flux/execute"
@@ -27,6 +30,7 @@ import (
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/plan/plantest"
 	"github.com/influxdata/flux/runtime"
+	"github.com/influxdata/flux/stdlib/array"
 	"github.com/influxdata/flux/stdlib/csv"
 	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
 	"github.com/influxdata/flux/stdlib/universe"
@@ -486,6 +490,60 @@ csv.from(csv: "
 	}
 }
 
+func TestASTCompiler_ProfilerResults(t *testing.T) {
+	c := &lang.FluxCompiler{
+		Query: `
+import "array"
+import "profiler"
+
+option profiler.enabledProfilers = ["query", "operator"]
+
+array.from(rows: [{_value: 1}, {_value: 2}])
+`,
+	}
+	program, err := c.Compile(context.Background(), runtime.Default)
+	if err != nil {
+		t.Fatalf("unexpected compile error: %s", err)
+	}
+
+	mem := &memory.ResourceAllocator{}
+	qry, err := program.Start(context.Background(), mem)
+	if err != nil {
+		t.Fatalf("unexpected program error: %s", err)
+	}
+
+	results := flux.NewResultIteratorFromQuery(qry)
+	defer results.Release()
+
+	var names []string
+	var measurements []string
+	for results.More() {
+		res := results.Next()
+		names = append(names, res.Name())
+		if err := res.Tables().Do(func(tbl flux.Table) error {
+			if res.Name() == "_profiler" {
+				measurements = append(measurements, tbl.Key().ValueString(0))
+			}
+			return tbl.Do(func(flux.ColReader) error { return nil })
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := results.Err(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if want := []string{"_result", "_profiler"}; !cmp.Equal(want, names) {
+		t.Errorf("unexpected results -want/+got:\n%s", cmp.Diff(want, names))
+	}
+	if want := []string{"profiler/query", "profiler/operator"}; !cmp.Equal(want, measurements) {
+		t.Errorf("unexpected profiler tables -want/+got:\n%s", cmp.Diff(want, measurements))
+	}
+	if stats := results.Statistics(); stats.CompileDuration <= 0 || stats.ExecuteDuration <= 0 {
+		t.Errorf("expected the phase durations to be recorded: %+v", stats)
+	}
+}
+
 func TestCompileOptions(t *testing.T) {
 	src := `import "csv"
 			csv.from(csv: "foo,bar")
@@ -928,6 +986,79 @@ func TestQueryTracing(t *testing.T) {
 	}
 }
 
+func TestAuthorizer(t *testing.T) {
+	// Reject queries that read from arrays.
+	a := authorizer.Func(func(ctx context.Context, spec *plan.Spec) error {
+		return spec.TopDownWalk(func(node plan.Node) error {
+			if node.Kind() == array.FromKind {
+				return errors.New("reading from arrays is not allowed")
+			}
+			return nil
+		})
+	})
+
+	for _, tc := range []struct {
+		name    string
+		query   string
+		wantErr string
+	}{
+		{
+			name: "allowed",
+			query: `
+				import "generate"
+				generate.from(count: 3, fn: (n) => n, start: 2021-01-01T00:00:00Z, stop: 2021-01-02T00:00:00Z)`,
+		},
+		{
+			name: "rejected",
+			query: `
+				import "array"
+				array.from(rows: [{value: 1}])`,
+			wantErr: "query is not authorized: reading from arrays is not allowed",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, deps := dependency.Inject(context.Background(), dependenciestest.Default())
+			defer deps.Finish()
+			ctx = authorizer.Dependency{Authorizer: a}.Inject(ctx)
+
+			c := lang.FluxCompiler{Query: tc.query}
+			prog, err := c.Compile(ctx, runtime.Default)
+			if err != nil {
+				t.Fatal(err)
+			}
+			q, err := prog.Start(ctx, memory.DefaultAllocator)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer q.Done()
+				for r := range q.Results() {
+					if err := r.Tables().Do(func(flux.Table) error {
+						return nil
+					}); err != nil {
+						t.Fatal(err)
+					}
+				}
+				if err := q.Err(); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			if err == nil {
+				q.Done()
+				t.Fatal("expected error, got none")
+			}
+			if got := err.Error(); got != tc.wantErr {
+				t.Errorf("unexpected error -want/+got:\n\t- %s\n\t+ %s", tc.wantErr, got)
+			}
+			if want, got := codes.PermissionDenied, flux.ErrorCode(err); want != got {
+				t.Errorf("unexpected error code -want/+got:\n\t- %v\n\t+ %v", want, got)
+			}
+		})
+	}
+}
+
 func getRootErr(err error) error {
 	if err == nil {
 		return err
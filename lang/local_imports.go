@@ -0,0 +1,324 @@
+package lang
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/dependencies/filesystem"
+	"github.com/influxdata/flux/dependencies/packages"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/parser"
+)
+
+// IsLocalImport reports if the import path refers to a package
+// relative to the importing file such as "./lib/helpers".
+func IsLocalImport(importPath string) bool {
+	return strings.HasPrefix(importPath, "./") || strings.HasPrefix(importPath, "../")
+}
+
+// HasLocalImports reports if any file of the package imports a local package.
+func HasLocalImports(pkg *ast.Package) bool {
+	for _, file := range pkg.Files {
+		for _, imp := range file.Imports {
+			if imp.Path != nil && IsLocalImport(imp.Path.Value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ResolveLocalImports replaces the local imports of the package with
+// the contents of the imported packages.
+//
+// A local import such as import "./lib/helpers" is resolved relative
+// to dir and reads lib/helpers.flux with the filesystem service from
+// the context. The imported file may only contain variable assignments
+// and its own imports. Each variable that does not start with an
+// underscore is a member of the package, which is bound to the name of
+// the import in the importing file.
+func ResolveLocalImports(ctx context.Context, pkg *ast.Package, dir string) error {
+	r := &localImportResolver{ctx: ctx}
+	return r.resolvePackage(pkg, dir)
+}
+
+// ResolveImports replaces the local imports and the imports of
+// third-party packages of the package with the contents of the
+// imported packages. It reports whether any import was replaced.
+//
+// Local imports are resolved like ResolveLocalImports. The third-party
+// packages are the import paths listed in the flux.json manifest in dir.
+// Each of them is loaded at the pinned version with the package loader
+// from the context. The imports of a third-party package are resolved
+// with the same manifest, and a third-party package may not import
+// local packages.
+func ResolveImports(ctx context.Context, pkg *ast.Package, dir string) (bool, error) {
+	manifest, err := readManifest(ctx, dir)
+	if err != nil {
+		return false, err
+	}
+	r := &localImportResolver{
+		ctx:      ctx,
+		manifest: manifest,
+		loader:   packages.Get(ctx),
+	}
+	if !r.hasImports(pkg) {
+		return false, nil
+	}
+	return true, r.resolvePackage(pkg, dir)
+}
+
+// readManifest reads the manifest in dir.
+// It returns nil if dir does not contain a manifest.
+func readManifest(ctx context.Context, dir string) (*packages.Manifest, error) {
+	if _, err := filesystem.Get(ctx); err != nil {
+		// Without a filesystem there is no manifest to read.
+		return nil, nil
+	}
+	data, err := filesystem.ReadFile(ctx, filepath.Join(dir, packages.ManifestFile))
+	if err != nil {
+		if os.IsNotExist(err) || errors.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, codes.Invalid, "cannot read %s", packages.ManifestFile)
+	}
+	return packages.ParseManifest(data)
+}
+
+type localImportResolver struct {
+	ctx context.Context
+	// stack contains the paths of the local packages
+	// that are being resolved to detect import cycles.
+	stack []string
+
+	// manifest lists the third-party packages that are
+	// loaded with loader. It is nil when only local
+	// imports are resolved.
+	manifest *packages.Manifest
+	loader   packages.Loader
+}
+
+func (r *localImportResolver) resolvePackage(pkg *ast.Package, dir string) error {
+	for _, file := range pkg.Files {
+		if err := r.resolveFile(file, dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hasImports reports if any file of the package imports
+// a package that the resolver replaces.
+func (r *localImportResolver) hasImports(pkg *ast.Package) bool {
+	for _, file := range pkg.Files {
+		for _, imp := range file.Imports {
+			if imp.Path != nil && r.resolves(imp.Path.Value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// resolves reports if the resolver replaces the import path.
+func (r *localImportResolver) resolves(importPath string) bool {
+	if IsLocalImport(importPath) {
+		return true
+	}
+	_, ok := r.manifest.Module(importPath)
+	return ok
+}
+
+// resolveFile replaces the local imports of the file with variable
+// assignments at the top of its body.
+func (r *localImportResolver) resolveFile(file *ast.File, dir string) error {
+	var (
+		imports []*ast.ImportDeclaration
+		body    []ast.Statement
+		hoisted []*ast.ImportDeclaration
+	)
+	for _, imp := range file.Imports {
+		if imp.Path == nil || !r.resolves(imp.Path.Value) {
+			imports = append(imports, imp)
+			continue
+		}
+		var (
+			lib *ast.File
+			err error
+		)
+		if IsLocalImport(imp.Path.Value) {
+			lib, err = r.load(imp, dir)
+		} else {
+			lib, err = r.loadModule(imp)
+		}
+		if err != nil {
+			return err
+		}
+		stmt, err := packageAssignment(imp, lib)
+		if err != nil {
+			return err
+		}
+		body = append(body, stmt)
+		hoisted = append(hoisted, lib.Imports...)
+	}
+	if len(body) == 0 {
+		return nil
+	}
+
+	for _, imp := range hoisted {
+		var err error
+		if imports, err = addImport(imports, imp); err != nil {
+			return err
+		}
+	}
+	file.Imports = imports
+	file.Body = append(body, file.Body...)
+	return nil
+}
+
+// load reads and parses the file of the local import and
+// resolves its own local imports.
+func (r *localImportResolver) load(imp *ast.ImportDeclaration, dir string) (*ast.File, error) {
+	if dir == "" {
+		return nil, errors.Newf(codes.Invalid, "%s: a third-party package cannot import the local package %q", position(imp), imp.Path.Value)
+	}
+	filename := filepath.Join(dir, filepath.FromSlash(imp.Path.Value)) + ".flux"
+	if err := r.checkCycle(imp, filename); err != nil {
+		return nil, err
+	}
+
+	src, err := filesystem.ReadFile(r.ctx, filename)
+	if err != nil {
+		return nil, errors.Wrapf(err, codes.Invalid, "%s: cannot import %q", position(imp), imp.Path.Value)
+	}
+	return r.parse(src, filename, filepath.Dir(filename))
+}
+
+// loadModule loads and parses the third-party package of the import
+// and resolves its own imports.
+func (r *localImportResolver) loadModule(imp *ast.ImportDeclaration) (*ast.File, error) {
+	m, _ := r.manifest.Module(imp.Path.Value)
+	if err := r.checkCycle(imp, m.String()); err != nil {
+		return nil, err
+	}
+
+	src, err := r.loader.Load(r.ctx, m)
+	if err != nil {
+		return nil, errors.Wrapf(err, codes.Inherit, "%s: cannot import %q", position(imp), imp.Path.Value)
+	}
+	// Third-party packages have no directory so
+	// they cannot import local packages.
+	return r.parse(src, m.String(), "")
+}
+
+// checkCycle returns an error if the package is already being resolved.
+func (r *localImportResolver) checkCycle(imp *ast.ImportDeclaration, name string) error {
+	for i, p := range r.stack {
+		if p == name {
+			cycle := append(append([]string{}, r.stack[i:]...), name)
+			return errors.Newf(codes.Invalid, "%s: import cycle: %s", position(imp), strings.Join(cycle, " -> "))
+		}
+	}
+	return nil
+}
+
+// parse parses the source of an imported package and resolves its
+// imports. Local imports are resolved relative to dir.
+func (r *localImportResolver) parse(src []byte, name, dir string) (*ast.File, error) {
+	pkg := parser.ParseSourceWithFileName(string(src), name)
+	if err := ast.GetError(pkg); err != nil {
+		return nil, err
+	}
+	if len(pkg.Files) != 1 {
+		return nil, errors.Newf(codes.Internal, "expected a single file for %q", name)
+	}
+	file := pkg.Files[0]
+
+	r.stack = append(r.stack, name)
+	defer func() { r.stack = r.stack[:len(r.stack)-1] }()
+	if err := r.resolveFile(file, dir); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// packageAssignment creates the assignment that binds the members of the
+// imported file to the name of the import. The members are the result
+// of a function that contains the body of the file, so the private
+// variables of the file are not visible in the importing file.
+func packageAssignment(imp *ast.ImportDeclaration, lib *ast.File) (ast.Statement, error) {
+	block := &ast.Block{}
+	members := &ast.ObjectExpression{}
+	for _, stmt := range lib.Body {
+		v, ok := stmt.(*ast.VariableAssignment)
+		if !ok {
+			kind := "package"
+			if IsLocalImport(imp.Path.Value) {
+				kind = "local package"
+			}
+			return nil, errors.Newf(codes.Invalid, "%s: %s %q may only contain variable assignments, found %s",
+				position(stmt), kind, imp.Path.Value, stmt.Type())
+		}
+		block.Body = append(block.Body, v)
+		if !strings.HasPrefix(v.ID.Name, "_") {
+			members.Properties = append(members.Properties, &ast.Property{
+				Key:   &ast.Identifier{Name: v.ID.Name},
+				Value: &ast.Identifier{Name: v.ID.Name},
+			})
+		}
+	}
+	block.Body = append(block.Body, &ast.ReturnStatement{Argument: members})
+
+	return &ast.VariableAssignment{
+		BaseNode: imp.BaseNode,
+		ID:       &ast.Identifier{BaseNode: imp.BaseNode, Name: importName(imp, lib)},
+		Init: &ast.CallExpression{
+			BaseNode: imp.BaseNode,
+			Callee: &ast.ParenExpression{
+				Expression: &ast.FunctionExpression{Body: block},
+			},
+		},
+	}, nil
+}
+
+// importName returns the name an import is bound to in the importing file.
+func importName(imp *ast.ImportDeclaration, lib *ast.File) string {
+	if imp.As != nil {
+		return imp.As.Name
+	}
+	if lib != nil && lib.Package != nil && lib.Package.Name != nil {
+		return lib.Package.Name.Name
+	}
+	return path.Base(imp.Path.Value)
+}
+
+// addImport adds the import of an imported file to the imports of
+// the importing file unless the same import already exists.
+func addImport(imports []*ast.ImportDeclaration, imp *ast.ImportDeclaration) ([]*ast.ImportDeclaration, error) {
+	name := importName(imp, nil)
+	for _, other := range imports {
+		if importName(other, nil) != name {
+			continue
+		}
+		if other.Path.Value != imp.Path.Value {
+			return nil, errors.Newf(codes.Invalid, "%s: import of %q as %q conflicts with the import of %q",
+				position(imp), imp.Path.Value, name, other.Path.Value)
+		}
+		return imports, nil
+	}
+	return append(imports, imp), nil
+}
+
+// position formats the location of the node for error messages.
+func position(n ast.Node) string {
+	loc := n.Location()
+	if loc.File != "" {
+		return loc.File + ":" + loc.Start.String()
+	}
+	return loc.Start.String()
+}
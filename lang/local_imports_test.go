@@ -0,0 +1,266 @@
+package lang_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/dependencies/filesystem"
+	"github.com/influxdata/flux/dependencies/packages"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/parser"
+	"github.com/influxdata/flux/runtime"
+)
+
+func localImportsContext() context.Context {
+	deps := flux.NewDefaultDependencies()
+	deps.Deps.FilesystemService = filesystem.SystemFS
+	return deps.Inject(context.Background())
+}
+
+func writeFluxFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, src := range files {
+		filename := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filename, []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestResolveLocalImports(t *testing.T) {
+	dir := writeFluxFiles(t, map[string]string{
+		"lib/helpers.flux": `package helpers
+
+import "strings"
+import "./math"
+
+_prefix = "x"
+label = (v) => strings.toUpper(v: _prefix + v)
+double = (v) => math.twice(v: v)
+`,
+		"lib/math.flux": `package math
+
+twice = (v) => v * 2
+`,
+	})
+
+	pkg := parser.ParseSource(`import "./lib/helpers"
+import h "./lib/helpers"
+
+x = helpers.label(v: "a")
+y = h.double(v: 2)
+`)
+	if err := lang.ResolveLocalImports(localImportsContext(), pkg, dir); err != nil {
+		t.Fatal(err)
+	}
+	if lang.HasLocalImports(pkg) {
+		t.Fatal("expected local imports to be resolved")
+	}
+
+	file := pkg.Files[0]
+	if len(file.Imports) != 1 || file.Imports[0].Path.Value != "strings" {
+		t.Fatalf("unexpected imports: %v", file.Imports)
+	}
+	var names []string
+	for _, stmt := range file.Body[:2] {
+		names = append(names, stmt.(*ast.VariableAssignment).ID.Name)
+	}
+	if want, got := "helpers,h", strings.Join(names, ","); want != got {
+		t.Fatalf("unexpected package names -want/+got:\n\t- %s\n\t+ %s", want, got)
+	}
+
+	src, err := json.Marshal(pkg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hdl, err := runtime.Default.JSONToHandle(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runtime.AnalyzePackage(context.Background(), hdl); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResolveLocalImports_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{
+			name: "missing",
+			want: `1:1: cannot import "./missing"`,
+		},
+		{
+			name: "cycle",
+			files: map[string]string{
+				"a.flux": "import \"./b\"\n\nx = b.x\n",
+				"b.flux": "import \"./a\"\n\nx = a.x\n",
+			},
+			want: "import cycle: ",
+		},
+		{
+			name: "statement",
+			files: map[string]string{
+				"a.flux": "x = 1\n\nx + 1\n",
+			},
+			want: `a.flux:3:1: local package "./a" may only contain variable assignments, found ExpressionStatement`,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dir := writeFluxFiles(t, tc.files)
+			script := `import "./a"`
+			if tc.name == "missing" {
+				script = `import "./missing"`
+			}
+			pkg := parser.ParseSource(script)
+			err := lang.ResolveLocalImports(localImportsContext(), pkg, dir)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestResolveImports(t *testing.T) {
+	dir := writeFluxFiles(t, map[string]string{
+		"flux.json": `{
+	"registry": "https://registry.example.com",
+	"dependencies": {
+		"example.com/units": {"version": "v1.2.0"},
+		"example.com/convert": {"version": "v0.1.0"}
+	}
+}`,
+		"lib/helpers.flux": `package helpers
+
+import "example.com/units"
+
+kb = (v) => units.kilo(v: v)
+`,
+	})
+	loader := packages.MapLoader{
+		"example.com/units@v1.2.0": `package units
+
+import "example.com/convert"
+
+kilo = (v) => convert.scale(v: v, by: 1000)
+`,
+		"example.com/convert@v0.1.0": `package convert
+
+scale = (v, by) => v * by
+`,
+	}
+	ctx := packages.Inject(localImportsContext(), loader)
+
+	pkg := parser.ParseSource(`import "strings"
+import "example.com/units"
+import "./lib/helpers"
+
+x = units.kilo(v: 2)
+y = helpers.kb(v: 3)
+`)
+	ok, err := lang.ResolveImports(ctx, pkg, dir)
+	if err != nil {
+		t.Fatal(err)
+	} else if !ok {
+		t.Fatal("expected imports to be resolved")
+	}
+
+	file := pkg.Files[0]
+	if len(file.Imports) != 1 || file.Imports[0].Path.Value != "strings" {
+		t.Fatalf("unexpected imports: %v", file.Imports)
+	}
+
+	src, err := json.Marshal(pkg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hdl, err := runtime.Default.JSONToHandle(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runtime.AnalyzePackage(context.Background(), hdl); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResolveImports_NoManifest(t *testing.T) {
+	dir := writeFluxFiles(t, nil)
+	pkg := parser.ParseSource(`import "strings"
+
+strings.toUpper(v: "a")
+`)
+	ok, err := lang.ResolveImports(localImportsContext(), pkg, dir)
+	if err != nil {
+		t.Fatal(err)
+	} else if ok {
+		t.Fatal("expected no imports to be resolved")
+	}
+}
+
+func TestResolveImports_Errors(t *testing.T) {
+	const manifest = `{"registry": "https://registry.example.com", "dependencies": {"example.com/a": {"version": "v1.0.0"}}}`
+	for _, tc := range []struct {
+		name     string
+		manifest string
+		loader   packages.MapLoader
+		want     string
+	}{
+		{
+			name:     "unpinned",
+			manifest: `{"registry": "https://registry.example.com", "dependencies": {"example.com/a": {"version": "latest"}}}`,
+			want:     `package "example.com/a" must pin a version`,
+		},
+		{
+			name:     "missing",
+			manifest: manifest,
+			want:     `1:1: cannot import "example.com/a": package example.com/a@v1.0.0 not found`,
+		},
+		{
+			name:     "local import",
+			manifest: manifest,
+			loader: packages.MapLoader{
+				"example.com/a@v1.0.0": "import \"./b\"\n\nx = b.x\n",
+			},
+			want: `a third-party package cannot import the local package "./b"`,
+		},
+		{
+			name:     "cycle",
+			manifest: manifest,
+			loader: packages.MapLoader{
+				"example.com/a@v1.0.0": "import \"example.com/a\"\n\nx = a.x\n",
+			},
+			want: "import cycle: example.com/a@v1.0.0 -> example.com/a@v1.0.0",
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dir := writeFluxFiles(t, map[string]string{"flux.json": tc.manifest})
+			ctx := packages.Inject(localImportsContext(), tc.loader)
+			pkg := parser.ParseSource(`import "example.com/a"`)
+			_, err := lang.ResolveImports(ctx, pkg, dir)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
@@ -31,6 +31,13 @@ func (q *query) Done() {
 	q.wg.Wait()
 	q.stats.MaxAllocated = q.alloc.MaxAllocated()
 	q.stats.TotalAllocated = q.alloc.TotalAllocated()
+	for _, usage := range q.alloc.NodeUsage() {
+		q.stats.NodeAllocations = append(q.stats.NodeAllocations, flux.NodeAllocation{
+			Label:        usage.Node,
+			Allocated:    usage.Allocated,
+			MaxAllocated: usage.MaxAllocated,
+		})
+	}
 	if q.span != nil {
 		q.span.Finish()
 		q.span = nil
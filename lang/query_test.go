@@ -373,19 +373,21 @@ data
   "filter2"
   // r._field == "id"
   "sort3"
+  "tableCapture4"
 
   "array.from0" -> "range1"
   "range1" -> "filter2"
   "filter2" -> "sort3"
+  "sort3" -> "tableCapture4"
 }
  digraph {
-  "array.from4"
-  "range5"
-  "filter6"
+  "array.from5"
+  "range6"
+  "filter7"
   // r._field == "id"
 
-  "array.from4" -> "range5"
-  "range5" -> "filter6"
+  "array.from5" -> "range6"
+  "range6" -> "filter7"
 }
 ]`,
 		},
@@ -0,0 +1,316 @@
+package lineprotocol
+
+import (
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/values"
+	lp "github.com/influxdata/line-protocol/v2/lineprotocol"
+)
+
+const defaultResultName = "_result"
+
+// ResultDecoderConfig are options that can be specified on the ResultDecoder.
+type ResultDecoderConfig struct {
+	// Allocator is the memory allocator used to build the tables.
+	// If it is nil, the default allocator is used.
+	Allocator memory.Allocator
+
+	// Precision is the precision of the timestamps.
+	// It must be one of 1ns, 1us, 1ms or 1s. Zero means nanoseconds.
+	Precision time.Duration
+
+	// DefaultTime is the timestamp of lines without a timestamp.
+	// If it is zero, lines without a timestamp are rejected.
+	DefaultTime time.Time
+}
+
+// Validate checks that the configuration can be used to decode line protocol.
+func (c ResultDecoderConfig) Validate() error {
+	_, err := EncoderConfig{Precision: c.Precision}.precision()
+	return err
+}
+
+// ResultDecoder decodes line protocol into a single result.
+//
+// Each field of a series is decoded into its own table with the
+// _time, _value, _field and _measurement columns and a column for each tag,
+// like the tables read from InfluxDB. The group key contains the measurement,
+// the field and the tags. Rows are sorted by time.
+type ResultDecoder struct {
+	c ResultDecoderConfig
+}
+
+// NewResultDecoder creates a new result decoder from config.
+func NewResultDecoder(c ResultDecoderConfig) *ResultDecoder {
+	if c.Allocator == nil {
+		c.Allocator = memory.DefaultAllocator
+	}
+	return &ResultDecoder{c: c}
+}
+
+func (d *ResultDecoder) Decode(r io.Reader) (flux.Result, error) {
+	precision, err := EncoderConfig{Precision: d.c.Precision}.precision()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		dec    = lp.NewDecoder(r)
+		series = make(map[string]*seriesBuilder)
+		order  []*seriesBuilder
+		tags   []tag
+		fields []field
+	)
+	for line := 1; dec.Next(); line++ {
+		name, err := dec.Measurement()
+		if err != nil {
+			return nil, wrapDecodeError(err)
+		}
+		measurement := string(name)
+
+		tags = tags[:0]
+		for {
+			k, v, err := dec.NextTag()
+			if err != nil {
+				return nil, wrapDecodeError(err)
+			} else if k == nil {
+				break
+			}
+			tags = append(tags, tag{key: string(k), value: string(v)})
+		}
+		sort.Slice(tags, func(i, j int) bool {
+			return tags[i].key < tags[j].key
+		})
+
+		fields = fields[:0]
+		for {
+			k, v, err := dec.NextField()
+			if err != nil {
+				return nil, wrapDecodeError(err)
+			} else if k == nil {
+				break
+			}
+			fields = append(fields, field{key: string(k), value: v.Interface(), kind: v.Kind()})
+		}
+
+		ts, err := d.readTime(dec, precision)
+		if err != nil {
+			return nil, errors.Wrapf(err, codes.Invalid, "line protocol decoder error: line %d", line)
+		}
+
+		for _, f := range fields {
+			key := seriesKey(measurement, tags, f.key)
+			b, ok := series[key]
+			if !ok {
+				b = newSeriesBuilder(measurement, tags, f)
+				series[key] = b
+				order = append(order, b)
+			} else if b.kind != f.kind {
+				return nil, errors.Newf(codes.Invalid,
+					"line protocol decoder error: field %q of measurement %q has conflicting types %s and %s",
+					f.key, measurement, b.kind, f.kind)
+			}
+			b.append(ts, f.value)
+		}
+	}
+	if err := dec.Err(); err != nil {
+		return nil, errors.Wrap(err, codes.Invalid, "line protocol decoder error")
+	}
+
+	tables := make([]flux.Table, 0, len(order))
+	for _, b := range order {
+		tables = append(tables, b.table(d.c.Allocator))
+	}
+	return &decodedResult{tables: tables}, nil
+}
+
+// readTime reads the timestamp of the current line in nanoseconds.
+func (d *ResultDecoder) readTime(dec *lp.Decoder, precision lp.Precision) (int64, error) {
+	data, err := dec.TimeBytes()
+	if err != nil {
+		return 0, err
+	}
+	if data == nil {
+		if d.c.DefaultTime.IsZero() {
+			return 0, errors.New(codes.Invalid, "missing timestamp")
+		}
+		return d.c.DefaultTime.UnixNano(), nil
+	}
+	ts, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return 0, errors.Newf(codes.Invalid, "invalid timestamp %q", data)
+	}
+	m := int64(precision.Duration())
+	if v := ts * m; v/m == ts {
+		return v, nil
+	}
+	return 0, errors.Newf(codes.Invalid, "timestamp %q out of range", data)
+}
+
+func wrapDecodeError(err error) error {
+	return errors.Wrap(err, codes.Invalid, "line protocol decoder error")
+}
+
+type tag struct {
+	key, value string
+}
+
+type field struct {
+	key   string
+	value interface{}
+	kind  lp.ValueKind
+}
+
+// seriesKey identifies a single field of a series.
+func seriesKey(measurement string, tags []tag, field string) string {
+	var b strings.Builder
+	b.WriteString(measurement)
+	for _, t := range tags {
+		b.WriteByte(0)
+		b.WriteString(t.key)
+		b.WriteByte('=')
+		b.WriteString(t.value)
+	}
+	b.WriteByte(0)
+	b.WriteString(field)
+	return b.String()
+}
+
+// seriesBuilder accumulates the values of a single field of a series.
+type seriesBuilder struct {
+	measurement string
+	tags        []tag
+	field       string
+	kind        lp.ValueKind
+
+	times  []int64
+	values []interface{}
+}
+
+func newSeriesBuilder(measurement string, tags []tag, f field) *seriesBuilder {
+	return &seriesBuilder{
+		measurement: measurement,
+		tags:        append([]tag(nil), tags...),
+		field:       f.key,
+		kind:        f.kind,
+	}
+}
+
+func (b *seriesBuilder) append(ts int64, v interface{}) {
+	b.times = append(b.times, ts)
+	b.values = append(b.values, v)
+}
+
+func (b *seriesBuilder) colType() flux.ColType {
+	switch b.kind {
+	case lp.Float:
+		return flux.TFloat
+	case lp.Int:
+		return flux.TInt
+	case lp.Uint:
+		return flux.TUInt
+	case lp.Bool:
+		return flux.TBool
+	default:
+		return flux.TString
+	}
+}
+
+func (b *seriesBuilder) table(mem memory.Allocator) flux.Table {
+	sort.Stable(b)
+
+	keyCols := make([]flux.ColMeta, 0, len(b.tags)+2)
+	keyValues := make([]values.Value, 0, len(b.tags)+2)
+	keyCols = append(keyCols,
+		flux.ColMeta{Label: DefaultFieldColLabel, Type: flux.TString},
+		flux.ColMeta{Label: DefaultMeasurementColLabel, Type: flux.TString},
+	)
+	keyValues = append(keyValues, values.NewString(b.field), values.NewString(b.measurement))
+	for _, t := range b.tags {
+		keyCols = append(keyCols, flux.ColMeta{Label: t.key, Type: flux.TString})
+		keyValues = append(keyValues, values.NewString(t.value))
+	}
+	key := execute.NewGroupKey(keyCols, keyValues)
+
+	typ := b.colType()
+	cols := make([]flux.ColMeta, 0, len(keyCols)+2)
+	cols = append(cols,
+		flux.ColMeta{Label: execute.DefaultTimeColLabel, Type: flux.TTime},
+		flux.ColMeta{Label: execute.DefaultValueColLabel, Type: typ},
+	)
+	cols = append(cols, keyCols...)
+
+	n := len(b.times)
+	vs := make([]array.Array, 0, len(cols))
+	tb := array.NewIntBuilder(mem)
+	tb.Reserve(n)
+	for _, t := range b.times {
+		tb.Append(t)
+	}
+	vs = append(vs, tb.NewArray())
+	vb := arrow.NewBuilder(typ, mem)
+	vb.Reserve(n)
+	for _, v := range b.values {
+		switch vb := vb.(type) {
+		case *array.FloatBuilder:
+			vb.Append(v.(float64))
+		case *array.IntBuilder:
+			vb.Append(v.(int64))
+		case *array.UintBuilder:
+			vb.Append(v.(uint64))
+		case *array.BooleanBuilder:
+			vb.Append(v.(bool))
+		case *array.StringBuilder:
+			vb.Append(v.(string))
+		}
+	}
+	vs = append(vs, vb.NewArray())
+	for j, c := range keyCols {
+		vs = append(vs, arrow.Repeat(c.Type, keyValues[j], n, mem))
+	}
+	return table.FromBuffer(&arrow.TableBuffer{
+		GroupKey: key,
+		Columns:  cols,
+		Values:   vs,
+	})
+}
+
+func (b *seriesBuilder) Len() int           { return len(b.times) }
+func (b *seriesBuilder) Less(i, j int) bool { return b.times[i] < b.times[j] }
+func (b *seriesBuilder) Swap(i, j int) {
+	b.times[i], b.times[j] = b.times[j], b.times[i]
+	b.values[i], b.values[j] = b.values[j], b.values[i]
+}
+
+type decodedResult struct {
+	tables []flux.Table
+}
+
+func (r *decodedResult) Name() string {
+	return defaultResultName
+}
+
+func (r *decodedResult) Tables() flux.TableIterator {
+	return r
+}
+
+func (r *decodedResult) Do(f func(flux.Table) error) error {
+	for _, tbl := range r.tables {
+		if err := f(tbl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
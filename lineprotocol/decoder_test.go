@@ -0,0 +1,256 @@
+package lineprotocol_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/lineprotocol"
+)
+
+func decode(t *testing.T, config lineprotocol.ResultDecoderConfig, data string) (*executetest.Result, error) {
+	t.Helper()
+	result, err := lineprotocol.NewResultDecoder(config).Decode(strings.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	got := &executetest.Result{
+		Nm: result.Name(),
+	}
+	if err := result.Tables().Do(func(tbl flux.Table) error {
+		cb, err := executetest.ConvertTable(tbl)
+		if err != nil {
+			return err
+		}
+		got.Tbls = append(got.Tbls, cb)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	got.Normalize()
+	return got, nil
+}
+
+func TestResultDecoder(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config lineprotocol.ResultDecoderConfig
+		data   string
+		want   []*executetest.Table
+		err    string
+	}{
+		{
+			name: "field types",
+			data: `# comment
+cpu,region=east,host=a f=1.5,i=2i,u=3u,s="x",b=true 2000000000
+cpu,host=a,region=east f=0.5,i=-1i,u=4u,s="y",b=false 1000000000
+`,
+			want: []*executetest.Table{
+				{
+					KeyCols: []string{"_field", "_measurement", "host", "region"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "_field", Type: flux.TString},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+						{Label: "region", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{ts(1), 0.5, "f", "cpu", "a", "east"},
+						{ts(2), 1.5, "f", "cpu", "a", "east"},
+					},
+				},
+				{
+					KeyCols: []string{"_field", "_measurement", "host", "region"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TInt},
+						{Label: "_field", Type: flux.TString},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+						{Label: "region", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{ts(1), int64(-1), "i", "cpu", "a", "east"},
+						{ts(2), int64(2), "i", "cpu", "a", "east"},
+					},
+				},
+				{
+					KeyCols: []string{"_field", "_measurement", "host", "region"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TUInt},
+						{Label: "_field", Type: flux.TString},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+						{Label: "region", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{ts(1), uint64(4), "u", "cpu", "a", "east"},
+						{ts(2), uint64(3), "u", "cpu", "a", "east"},
+					},
+				},
+				{
+					KeyCols: []string{"_field", "_measurement", "host", "region"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TString},
+						{Label: "_field", Type: flux.TString},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+						{Label: "region", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{ts(1), "y", "s", "cpu", "a", "east"},
+						{ts(2), "x", "s", "cpu", "a", "east"},
+					},
+				},
+				{
+					KeyCols: []string{"_field", "_measurement", "host", "region"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TBool},
+						{Label: "_field", Type: flux.TString},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+						{Label: "region", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{ts(1), false, "b", "cpu", "a", "east"},
+						{ts(2), true, "b", "cpu", "a", "east"},
+					},
+				},
+			},
+		},
+		{
+			name: "series",
+			data: `m,host=a v=1 1000000000
+m,host=b v=2 1000000000
+m v=3 1000000000
+`,
+			want: []*executetest.Table{
+				{
+					KeyCols: []string{"_field", "_measurement", "host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "_field", Type: flux.TString},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{ts(1), 1.0, "v", "m", "a"},
+					},
+				},
+				{
+					KeyCols: []string{"_field", "_measurement", "host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "_field", Type: flux.TString},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{ts(1), 2.0, "v", "m", "b"},
+					},
+				},
+				{
+					KeyCols: []string{"_field", "_measurement"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "_field", Type: flux.TString},
+						{Label: "_measurement", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{ts(1), 3.0, "v", "m"},
+					},
+				},
+			},
+		},
+		{
+			name: "precision and default time",
+			config: lineprotocol.ResultDecoderConfig{
+				Precision:   time.Second,
+				DefaultTime: time.Unix(10, 0),
+			},
+			data: "m v=1i 1\nm v=2i\n",
+			want: []*executetest.Table{{
+				KeyCols: []string{"_field", "_measurement"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TInt},
+					{Label: "_field", Type: flux.TString},
+					{Label: "_measurement", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{ts(1), int64(1), "v", "m"},
+					{ts(10), int64(2), "v", "m"},
+				},
+			}},
+		},
+		{
+			name: "missing timestamp",
+			data: "m v=1i 1\nm v=2i\n",
+			err:  "line protocol decoder error: line 2: missing timestamp",
+		},
+		{
+			name: "conflicting types",
+			data: "m v=1i 1\nm v=2 2\n",
+			err:  `field "v" of measurement "m" has conflicting types int and float`,
+		},
+		{
+			name: "syntax error",
+			data: "m v=\n",
+			err:  "line protocol decoder error",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := decode(t, tc.config, tc.data)
+			if tc.err != "" {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				if !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("unexpected error -want/+got:\n- %s\n+ %s", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := &executetest.Result{Nm: "_result", Tbls: tc.want}
+			want.Normalize()
+			if !cmp.Equal(want, got) {
+				t.Errorf("unexpected results -want/+got:\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestResultDecoder_RoundTrip(t *testing.T) {
+	data := `cpu,host=a,region=east usage=1.5 1000000000
+cpu,host=a,region=east usage=2.5 2000000000
+mem,host=b free=10i 1000000000
+`
+	result, err := lineprotocol.NewResultDecoder(lineprotocol.ResultDecoderConfig{}).Decode(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := lineprotocol.NewResultEncoder(lineprotocol.DefaultEncoderConfig()).Encode(&buf, result); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != data {
+		t.Errorf("unexpected output -want/+got:\n%s", cmp.Diff(data, got))
+	}
+}
@@ -0,0 +1,40 @@
+package lineprotocol
+
+import (
+	"net/http"
+
+	"github.com/influxdata/flux"
+)
+
+const DialectType = "lineprotocol"
+
+// AddDialectMappings adds the line protocol dialect mappings.
+func AddDialectMappings(mappings flux.DialectMappings) error {
+	return mappings.Add(DialectType, func() flux.Dialect {
+		return DefaultDialect()
+	})
+}
+
+// Dialect describes the output format of queries in line protocol.
+type Dialect struct {
+	EncoderConfig
+}
+
+func (d Dialect) SetHeaders(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Transfer-Encoding", "chunked")
+}
+
+func (d Dialect) Encoder() flux.MultiResultEncoder {
+	return NewMultiResultEncoder(d.EncoderConfig)
+}
+
+func (d Dialect) DialectType() flux.DialectType {
+	return DialectType
+}
+
+func DefaultDialect() *Dialect {
+	return &Dialect{
+		EncoderConfig: DefaultEncoderConfig(),
+	}
+}
@@ -0,0 +1,376 @@
+// Package lineprotocol contains the line protocol result encoder.
+package lineprotocol
+
+import (
+	"sort"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+	lp "github.com/influxdata/line-protocol/v2/lineprotocol"
+)
+
+const (
+	DefaultMeasurementColLabel = "_measurement"
+	DefaultFieldColLabel       = "_field"
+)
+
+// EncoderConfig describes how the columns of a table are mapped to line protocol.
+type EncoderConfig struct {
+	// Measurement is the measurement name of every line.
+	// When it is empty, the measurement name is read from the measurement column.
+	Measurement string `json:"measurement,omitempty"`
+
+	// MeasurementColumn is the column that contains the measurement name.
+	// It is never encoded as a tag or a field.
+	MeasurementColumn string `json:"measurementColumn"`
+
+	// TagColumns is the list of columns that are encoded as tags.
+	// When it is nil, the string columns in the group key other than
+	// the measurement and field columns are used.
+	TagColumns []string `json:"tagColumns,omitempty"`
+
+	// FieldColumns is the list of columns that are encoded as fields
+	// named after the column.
+	// When it is nil and a table has the field column, the field key is read
+	// from the field column and the field value from the value column.
+	// Otherwise, every column that is not in the group key and is not
+	// the time column is encoded as a field.
+	FieldColumns []string `json:"fieldColumns,omitempty"`
+
+	// FieldColumn is the column that contains the field key.
+	FieldColumn string `json:"fieldColumn"`
+
+	// ValueColumn is the column that contains the field value.
+	ValueColumn string `json:"valueColumn"`
+
+	// TimeColumn is the column that contains the timestamp.
+	// Lines are written without a timestamp if a table does not have it.
+	TimeColumn string `json:"timeColumn"`
+
+	// Precision is the precision of the encoded timestamps.
+	// It must be one of 1ns, 1us, 1ms or 1s. Zero means nanoseconds.
+	Precision time.Duration `json:"precision,omitempty"`
+}
+
+// DefaultEncoderConfig returns the configuration that encodes tables
+// with the same shape as the data read from InfluxDB.
+func DefaultEncoderConfig() EncoderConfig {
+	return EncoderConfig{
+		MeasurementColumn: DefaultMeasurementColLabel,
+		FieldColumn:       DefaultFieldColLabel,
+		ValueColumn:       execute.DefaultValueColLabel,
+		TimeColumn:        execute.DefaultTimeColLabel,
+	}
+}
+
+// ReadArgs reads the encoder options from the arguments of a flux function.
+// Options that are not specified keep their default value.
+func (c *EncoderConfig) ReadArgs(args flux.Arguments) error {
+	*c = DefaultEncoderConfig()
+
+	var err error
+	if c.Measurement, _, err = args.GetString("measurement"); err != nil {
+		return err
+	}
+	for name, dst := range map[string]*string{
+		"measurementColumn": &c.MeasurementColumn,
+		"fieldColumn":       &c.FieldColumn,
+		"valueColumn":       &c.ValueColumn,
+		"timeColumn":        &c.TimeColumn,
+	} {
+		if v, ok, err := args.GetString(name); err != nil {
+			return err
+		} else if ok {
+			*dst = v
+		}
+	}
+
+	if c.TagColumns, err = getStrings(args, "tagColumns"); err != nil {
+		return err
+	}
+	if c.FieldColumns, err = getStrings(args, "fieldColumns"); err != nil {
+		return err
+	}
+
+	if d, ok, err := args.GetDuration("precision"); err != nil {
+		return err
+	} else if ok {
+		c.Precision = d.Duration()
+	}
+	return c.Validate()
+}
+
+func getStrings(args flux.Arguments, name string) ([]string, error) {
+	arr, ok, err := args.GetArray(name, semantic.String)
+	if err != nil || !ok {
+		return nil, err
+	}
+	strs := make([]string, 0, arr.Len())
+	arr.Range(func(i int, v values.Value) {
+		strs = append(strs, v.Str())
+	})
+	return strs, nil
+}
+
+// Validate checks that the configuration can be used to encode tables.
+func (c EncoderConfig) Validate() error {
+	if c.MeasurementColumn == "" && c.Measurement == "" {
+		return errors.New(codes.Invalid, "one of measurement or measurementColumn must be set")
+	}
+	if _, err := c.precision(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c EncoderConfig) precision() (lp.Precision, error) {
+	switch c.Precision {
+	case 0, time.Nanosecond:
+		return lp.Nanosecond, nil
+	case time.Microsecond:
+		return lp.Microsecond, nil
+	case time.Millisecond:
+		return lp.Millisecond, nil
+	case time.Second:
+		return lp.Second, nil
+	default:
+		return 0, errors.Newf(codes.Invalid, "invalid precision %v: must be one of 1ns, 1us, 1ms or 1s", c.Precision)
+	}
+}
+
+// TableEncoder encodes the rows of tables that share the same schema
+// as lines of line protocol.
+type TableEncoder struct {
+	enc         lp.Encoder
+	cols        []flux.ColMeta
+	measurement string
+
+	measurementIdx int
+	timeIdx        int
+	tagIdxs        []int
+
+	// Each field index is the column that contains the field value.
+	// If fieldKeyIdx is set, the field is named after the value of that
+	// column and fieldIdxs contains only the value column.
+	fieldKeyIdx int
+	fieldIdxs   []int
+}
+
+// NewTableEncoder creates a TableEncoder for tables with the given group key and columns.
+func NewTableEncoder(c EncoderConfig, key flux.GroupKey, cols []flux.ColMeta) (*TableEncoder, error) {
+	precision, err := c.precision()
+	if err != nil {
+		return nil, err
+	}
+	e := &TableEncoder{
+		cols:           cols,
+		measurement:    c.Measurement,
+		measurementIdx: -1,
+		timeIdx:        -1,
+		fieldKeyIdx:    -1,
+	}
+	e.enc.SetPrecision(precision)
+
+	measurementIdx := execute.ColIdx(c.MeasurementColumn, cols)
+	if c.Measurement == "" {
+		if measurementIdx < 0 {
+			return nil, errors.Newf(codes.FailedPrecondition, "table has no measurement column %q", c.MeasurementColumn)
+		}
+		if cols[measurementIdx].Type != flux.TString {
+			return nil, errors.Newf(codes.FailedPrecondition, "measurement column %q must be a string, got %s", c.MeasurementColumn, cols[measurementIdx].Type)
+		}
+		e.measurementIdx = measurementIdx
+	}
+
+	if idx := execute.ColIdx(c.TimeColumn, cols); idx >= 0 {
+		if cols[idx].Type != flux.TTime {
+			return nil, errors.Newf(codes.FailedPrecondition, "time column %q must be a time, got %s", c.TimeColumn, cols[idx].Type)
+		}
+		e.timeIdx = idx
+	}
+
+	if c.FieldColumns == nil {
+		fieldIdx := execute.ColIdx(c.FieldColumn, cols)
+		valueIdx := execute.ColIdx(c.ValueColumn, cols)
+		if fieldIdx >= 0 && valueIdx >= 0 {
+			if cols[fieldIdx].Type != flux.TString {
+				return nil, errors.Newf(codes.FailedPrecondition, "field column %q must be a string, got %s", c.FieldColumn, cols[fieldIdx].Type)
+			}
+			e.fieldKeyIdx = fieldIdx
+			e.fieldIdxs = []int{valueIdx}
+		}
+	}
+
+	// isTag reports whether a column is a tag column when no tag columns are specified.
+	isTag := func(j int, col flux.ColMeta) bool {
+		return col.Type == flux.TString && key.HasCol(col.Label) &&
+			j != measurementIdx && j != e.fieldKeyIdx &&
+			!execute.ContainsStr(c.FieldColumns, col.Label)
+	}
+	for j, col := range cols {
+		if c.TagColumns != nil {
+			if !execute.ContainsStr(c.TagColumns, col.Label) {
+				continue
+			}
+			if col.Type != flux.TString {
+				return nil, errors.Newf(codes.FailedPrecondition, "tag column %q must be a string, got %s", col.Label, col.Type)
+			}
+		} else if !isTag(j, col) {
+			continue
+		}
+		e.tagIdxs = append(e.tagIdxs, j)
+	}
+	// The encoder requires the tags to be sorted by key.
+	sort.Slice(e.tagIdxs, func(i, j int) bool {
+		return cols[e.tagIdxs[i]].Label < cols[e.tagIdxs[j]].Label
+	})
+
+	if e.fieldKeyIdx < 0 {
+		for j, col := range cols {
+			if c.FieldColumns != nil {
+				if !execute.ContainsStr(c.FieldColumns, col.Label) {
+					continue
+				}
+			} else if key.HasCol(col.Label) || j == e.timeIdx || j == measurementIdx || e.isTagIdx(j) {
+				continue
+			}
+			e.fieldIdxs = append(e.fieldIdxs, j)
+		}
+	}
+	if len(e.fieldIdxs) == 0 {
+		return nil, errors.New(codes.FailedPrecondition, "table has no field columns")
+	}
+	for _, j := range e.fieldIdxs {
+		switch cols[j].Type {
+		case flux.TFloat, flux.TInt, flux.TUInt, flux.TString, flux.TBool, flux.TTime:
+		default:
+			return nil, errors.Newf(codes.FailedPrecondition, "unsupported type %s for field column %q", cols[j].Type, cols[j].Label)
+		}
+	}
+	return e, nil
+}
+
+func (e *TableEncoder) isTagIdx(j int) bool {
+	for _, idx := range e.tagIdxs {
+		if idx == j {
+			return true
+		}
+	}
+	return false
+}
+
+// AppendLine appends the line for row i of the column reader to dst
+// and returns the extended buffer.
+// Rows without a measurement name or without any non-null field are skipped.
+func (e *TableEncoder) AppendLine(dst []byte, cr flux.ColReader, i int) ([]byte, error) {
+	measurement := e.measurement
+	if e.measurementIdx >= 0 {
+		vs := cr.Strings(e.measurementIdx)
+		if vs.IsNull(i) || vs.Value(i) == "" {
+			return dst, nil
+		}
+		measurement = vs.Value(i)
+	}
+
+	var fieldKey string
+	if e.fieldKeyIdx >= 0 {
+		vs := cr.Strings(e.fieldKeyIdx)
+		if vs.IsNull(i) || vs.Value(i) == "" {
+			return dst, nil
+		}
+		fieldKey = vs.Value(i)
+	}
+
+	n := len(dst)
+	e.enc.SetBuffer(dst)
+	e.enc.StartLine(measurement)
+	for _, j := range e.tagIdxs {
+		vs := cr.Strings(j)
+		// Tags without a value cannot be represented.
+		if vs.IsNull(i) || vs.Value(i) == "" {
+			continue
+		}
+		e.enc.AddTag(e.cols[j].Label, vs.Value(i))
+	}
+
+	hasField := false
+	for _, j := range e.fieldIdxs {
+		v, ok := fieldValue(cr, j, i)
+		if !ok {
+			continue
+		}
+		key := fieldKey
+		if e.fieldKeyIdx < 0 {
+			key = e.cols[j].Label
+		}
+		e.enc.AddField(key, v)
+		hasField = true
+	}
+	if !hasField {
+		return dst[:n], nil
+	}
+
+	var ts time.Time
+	if e.timeIdx >= 0 {
+		if vs := cr.Times(e.timeIdx); vs.IsValid(i) {
+			ts = time.Unix(0, vs.Value(i)).UTC()
+		}
+	}
+	e.enc.EndLine(ts)
+	if err := e.enc.Err(); err != nil {
+		return dst[:n], errors.Wrap(err, codes.FailedPrecondition, "cannot encode line protocol")
+	}
+	return e.enc.Bytes(), nil
+}
+
+// fieldValue reads a field value from the column reader.
+// It reports false if the value is null or cannot be represented,
+// such as NaN and infinite floats.
+func fieldValue(cr flux.ColReader, j, i int) (lp.Value, bool) {
+	switch cr.Cols()[j].Type {
+	case flux.TFloat:
+		vs := cr.Floats(j)
+		if vs.IsNull(i) {
+			return lp.Value{}, false
+		}
+		return lp.FloatValue(vs.Value(i))
+	case flux.TInt:
+		vs := cr.Ints(j)
+		if vs.IsNull(i) {
+			return lp.Value{}, false
+		}
+		return lp.IntValue(vs.Value(i)), true
+	case flux.TUInt:
+		vs := cr.UInts(j)
+		if vs.IsNull(i) {
+			return lp.Value{}, false
+		}
+		return lp.UintValue(vs.Value(i)), true
+	case flux.TString:
+		vs := cr.Strings(j)
+		if vs.IsNull(i) {
+			return lp.Value{}, false
+		}
+		return lp.StringValue(vs.Value(i))
+	case flux.TBool:
+		vs := cr.Bools(j)
+		if vs.IsNull(i) {
+			return lp.Value{}, false
+		}
+		return lp.BoolValue(vs.Value(i)), true
+	case flux.TTime:
+		// Time fields are written as integer nanoseconds since the epoch.
+		vs := cr.Times(j)
+		if vs.IsNull(i) {
+			return lp.Value{}, false
+		}
+		return lp.IntValue(vs.Value(i)), true
+	default:
+		return lp.Value{}, false
+	}
+}
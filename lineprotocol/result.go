@@ -0,0 +1,103 @@
+package lineprotocol
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/iocounter"
+)
+
+// ResultEncoder encodes a result as line protocol.
+// Each row of a table is written as a single line.
+type ResultEncoder struct {
+	c EncoderConfig
+}
+
+// NewResultEncoder creates a new encoder with the provided configuration.
+func NewResultEncoder(c EncoderConfig) *ResultEncoder {
+	return &ResultEncoder{
+		c: c,
+	}
+}
+
+// NewMultiResultEncoder creates a MultiResultEncoder that encodes each result
+// as line protocol, separated by an empty line.
+func NewMultiResultEncoder(c EncoderConfig) flux.MultiResultEncoder {
+	return &flux.DelimitedMultiResultEncoder{
+		Delimiter: []byte("\n"),
+		Encoder:   NewResultEncoder(c),
+	}
+}
+
+type lpEncoderError struct {
+	err error
+}
+
+func (e *lpEncoderError) Error() string {
+	return fmt.Sprintf("line protocol encoder error: %s", e.err.Error())
+}
+
+func (e *lpEncoderError) IsEncoderError() bool {
+	return true
+}
+
+func (e *lpEncoderError) Unwrap() error {
+	return e.err
+}
+
+func wrapEncodingError(err error) error {
+	if err == nil {
+		return err
+	}
+	return &lpEncoderError{err: err}
+}
+
+func (e *ResultEncoder) Encode(w io.Writer, result flux.Result) (int64, error) {
+	if err := e.c.Validate(); err != nil {
+		return 0, wrapEncodingError(err)
+	}
+
+	writeCounter := &iocounter.Writer{Writer: w}
+	writer := bufio.NewWriter(writeCounter)
+
+	var line []byte
+	err := result.Tables().Do(func(tbl flux.Table) error {
+		if tbl.Empty() {
+			return tbl.Do(func(flux.ColReader) error { return nil })
+		}
+		enc, err := NewTableEncoder(e.c, tbl.Key(), tbl.Cols())
+		if err != nil {
+			return wrapEncodingError(err)
+		}
+		if err := tbl.Do(func(cr flux.ColReader) error {
+			for i, l := 0, cr.Len(); i < l; i++ {
+				line, err = enc.AppendLine(line[:0], cr, i)
+				if err != nil {
+					return wrapEncodingError(err)
+				}
+				if _, err := writer.Write(line); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		return writer.Flush()
+	})
+	if ferr := writer.Flush(); err == nil {
+		err = ferr
+	}
+	return writeCounter.Count(), err
+}
+
+// EncodeError writes the error as a comment line.
+// Line protocol has no representation for errors, but parsers ignore comments.
+func (e *ResultEncoder) EncodeError(w io.Writer, err error) error {
+	msg := strings.ReplaceAll(err.Error(), "\n", " ")
+	_, werr := fmt.Fprintf(w, "# error: %s\n", msg)
+	return werr
+}
@@ -0,0 +1,230 @@
+package lineprotocol_test
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/lineprotocol"
+	"github.com/influxdata/flux/values"
+)
+
+func ts(sec int64) values.Time {
+	return values.ConvertTime(time.Unix(sec, 0).UTC())
+}
+
+func TestResultEncoder(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config func(c *lineprotocol.EncoderConfig)
+		tables []*executetest.Table
+		want   string
+		err    string
+	}{
+		{
+			name: "field and value columns",
+			tables: []*executetest.Table{{
+				KeyCols: []string{"_start", "_stop", "_measurement", "_field", "host", "region"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_start", Type: flux.TTime},
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_measurement", Type: flux.TString},
+					{Label: "_field", Type: flux.TString},
+					{Label: "region", Type: flux.TString},
+					{Label: "host", Type: flux.TString},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{ts(0), ts(10), ts(1), "cpu", "usage", "east", "a", 1.5},
+					{ts(0), ts(10), ts(2), "cpu", "usage", "east", "a", nil},
+					{ts(0), ts(10), ts(3), "cpu", "usage", "east", "a", 2.0},
+				},
+			}},
+			want: `cpu,host=a,region=east usage=1.5 1000000000
+cpu,host=a,region=east usage=2 3000000000
+`,
+		},
+		{
+			name: "pivoted",
+			tables: []*executetest.Table{{
+				KeyCols: []string{"_measurement", "host"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_measurement", Type: flux.TString},
+					{Label: "host", Type: flux.TString},
+					{Label: "f", Type: flux.TFloat},
+					{Label: "i", Type: flux.TInt},
+					{Label: "u", Type: flux.TUInt},
+					{Label: "s", Type: flux.TString},
+					{Label: "b", Type: flux.TBool},
+				},
+				Data: [][]interface{}{
+					{ts(1), "m", "a", 1.0, int64(-2), uint64(3), "x y", true},
+					{ts(2), "m", "a", math.NaN(), nil, nil, nil, nil},
+				},
+			}},
+			want: `m,host=a f=1,i=-2i,u=3u,s="x y",b=true 1000000000
+`,
+		},
+		{
+			name: "explicit columns",
+			config: func(c *lineprotocol.EncoderConfig) {
+				c.Measurement = "weather"
+				c.TagColumns = []string{"location"}
+				c.FieldColumns = []string{"temp"}
+				c.TimeColumn = "t"
+				c.Precision = time.Second
+			},
+			tables: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "t", Type: flux.TTime},
+					{Label: "location", Type: flux.TString},
+					{Label: "temp", Type: flux.TFloat},
+					{Label: "other", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{ts(5), "denver", 10.5, 1.0},
+					{ts(6), "new york", 12.0, 1.0},
+				},
+			}},
+			want: `weather,location=denver temp=10.5 5
+weather,location=new\ york temp=12 6
+`,
+		},
+		{
+			name: "no time column",
+			tables: []*executetest.Table{{
+				KeyCols: []string{"_measurement"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_measurement", Type: flux.TString},
+					{Label: "_value", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{"m", int64(1)},
+				},
+			}},
+			want: "m _value=1i\n",
+		},
+		{
+			name: "multiple tables",
+			tables: []*executetest.Table{
+				{
+					KeyCols: []string{"_measurement", "_field"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "_field", Type: flux.TString},
+						{Label: "_value", Type: flux.TInt},
+					},
+					Data: [][]interface{}{
+						{ts(1), "m", "a", int64(1)},
+					},
+				},
+				{
+					KeyCols: []string{"_measurement", "_field"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "_field", Type: flux.TString},
+						{Label: "_value", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{ts(1), "m", "b", `say "hi"`},
+					},
+				},
+			},
+			want: `m a=1i 1000000000
+m b="say \"hi\"" 1000000000
+`,
+		},
+		{
+			name: "missing measurement column",
+			tables: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{ts(1), 1.0},
+				},
+			}},
+			err: `table has no measurement column "_measurement"`,
+		},
+		{
+			name: "invalid tag column",
+			config: func(c *lineprotocol.EncoderConfig) {
+				c.TagColumns = []string{"_value"}
+			},
+			tables: []*executetest.Table{{
+				KeyCols: []string{"_measurement"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_measurement", Type: flux.TString},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{"m", 1.0},
+				},
+			}},
+			err: `tag column "_value" must be a string, got float`,
+		},
+		{
+			name: "invalid precision",
+			config: func(c *lineprotocol.EncoderConfig) {
+				c.Precision = time.Minute
+			},
+			err: "invalid precision 1m0s: must be one of 1ns, 1us, 1ms or 1s",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			config := lineprotocol.DefaultEncoderConfig()
+			if tc.config != nil {
+				tc.config(&config)
+			}
+			encoder := lineprotocol.NewResultEncoder(config)
+
+			var buf bytes.Buffer
+			n, err := encoder.Encode(&buf, executetest.NewResult(tc.tables))
+			if tc.err != "" {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				if !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("unexpected error -want/+got:\n- %s\n+ %s", tc.err, err)
+				}
+				if e, ok := err.(interface{ IsEncoderError() bool }); !ok || !e.IsEncoderError() {
+					t.Errorf("expected an encoder error, got %T", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tc.want {
+				t.Errorf("unexpected output -want/+got:\n%s", cmp.Diff(tc.want, got))
+			}
+			if n != int64(buf.Len()) {
+				t.Errorf("unexpected byte count: want %d, got %d", buf.Len(), n)
+			}
+		})
+	}
+}
+
+func TestResultEncoder_EncodeError(t *testing.T) {
+	var buf bytes.Buffer
+	encoder := lineprotocol.NewResultEncoder(lineprotocol.DefaultEncoderConfig())
+	if err := encoder.EncodeError(&buf, errors.New("query\nfailed")); err != nil {
+		t.Fatal(err)
+	}
+	if want, got := "# error: query failed\n", buf.String(); want != got {
+		t.Errorf("unexpected output -want/+got:\n%s", cmp.Diff(want, got))
+	}
+}
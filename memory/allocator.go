@@ -3,6 +3,7 @@ package memory
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
 
@@ -27,6 +28,17 @@ type Allocator interface {
 	Account(size int) error
 }
 
+// UnderPressure reports if the Allocator is above its soft limit.
+// Allocators without a soft limit are never under pressure.
+func UnderPressure(a Allocator) bool {
+	if p, ok := a.(interface {
+		UnderPressure() bool
+	}); ok {
+		return p.UnderPressure()
+	}
+	return false
+}
+
 type key int
 
 const allocatorKey key = iota
@@ -65,6 +77,19 @@ type ResourceAllocator struct {
 	// can assign. If this is null, there is no limit.
 	Limit *int64
 
+	// SoftLimit is the amount of memory above which this allocator
+	// is under memory pressure. Allocations that exceed the soft limit
+	// still succeed and no memory is released. The allocator only
+	// calls OnSoftLimit and reports true from UnderPressure.
+	// If this is null, there is no soft limit.
+	SoftLimit *int64
+
+	// OnSoftLimit is called with the allocated memory each time
+	// the allocated memory goes above the soft limit.
+	// It is called by the goroutine performing the allocation
+	// so it should not block.
+	OnSoftLimit func(allocated int64)
+
 	// Manager holds the Manager for this Allocator.
 	// If this Allocator has a limit set and the limit is to be exceeded,
 	// it will attempt to use the Manager to request more memory.
@@ -75,6 +100,9 @@ type ResourceAllocator struct {
 	// allocate and free memory.
 	// If this is unset, the DefaultAllocator is used.
 	Allocator memory.Allocator
+
+	nodesMu sync.Mutex
+	nodes   map[string]*nodeAllocator
 }
 
 func NewResourceAllocator(allocator memory.Allocator) *ResourceAllocator {
@@ -141,6 +169,14 @@ func (a *ResourceAllocator) Allocated() int64 {
 	return atomic.LoadInt64(&a.bytesAllocated)
 }
 
+// UnderPressure reports if the allocated memory is above the soft limit.
+func (a *ResourceAllocator) UnderPressure() bool {
+	if a == nil || a.SoftLimit == nil {
+		return false
+	}
+	return a.Allocated() > *a.SoftLimit
+}
+
 // MaxAllocated reports the maximum amount of allocated memory at any point in the query.
 func (a *ResourceAllocator) MaxAllocated() int64 {
 	return atomic.LoadInt64(&a.maxAllocated)
@@ -205,6 +241,13 @@ func (a *ResourceAllocator) count(size int) error {
 	// will only increment.
 	if size > 0 {
 		atomic.AddInt64(&a.totalAllocated, int64(size))
+
+		// Report when this allocation crossed the soft limit.
+		if a.SoftLimit != nil && a.OnSoftLimit != nil {
+			if soft := *a.SoftLimit; c > soft && c-int64(size) <= soft {
+				a.OnSoftLimit(c)
+			}
+		}
 	}
 
 	// Modify the max allocated if the amount we just allocated is greater.
@@ -250,6 +293,124 @@ func (a *ResourceAllocator) requestMemory(allocated, want int64) error {
 	}, codes.ResourceExhausted)
 }
 
+// ForNode returns an Allocator that allocates memory from this Allocator
+// and also accounts for it as memory used by the plan node with the id.
+// Calling ForNode multiple times with the same id accounts the memory
+// of all of the returned Allocators to the same node.
+//
+// When the limit of this Allocator is exceeded by one of the returned
+// Allocators, the LimitExceededError identifies the node.
+func (a *ResourceAllocator) ForNode(id string) Allocator {
+	a.nodesMu.Lock()
+	defer a.nodesMu.Unlock()
+	if n, ok := a.nodes[id]; ok {
+		return n
+	}
+	if a.nodes == nil {
+		a.nodes = make(map[string]*nodeAllocator)
+	}
+	n := &nodeAllocator{a: a, id: id}
+	a.nodes[id] = n
+	return n
+}
+
+// NodeUsage is the memory used by a plan node.
+type NodeUsage struct {
+	// Node is the id of the plan node.
+	Node string
+	// Allocated is the amount of memory currently allocated by the node.
+	Allocated int64
+	// MaxAllocated is the maximum amount of memory allocated
+	// by the node at any point in the query.
+	MaxAllocated int64
+}
+
+// NodeUsage reports the memory used by the nodes passed to ForNode
+// sorted by node id.
+func (a *ResourceAllocator) NodeUsage() []NodeUsage {
+	a.nodesMu.Lock()
+	defer a.nodesMu.Unlock()
+	usage := make([]NodeUsage, 0, len(a.nodes))
+	for id, n := range a.nodes {
+		usage = append(usage, NodeUsage{
+			Node:         id,
+			Allocated:    atomic.LoadInt64(&n.bytesAllocated),
+			MaxAllocated: atomic.LoadInt64(&n.maxAllocated),
+		})
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		return usage[i].Node < usage[j].Node
+	})
+	return usage
+}
+
+// nodeAllocator allocates memory from a ResourceAllocator
+// and tracks the amount of memory used by a single node.
+type nodeAllocator struct {
+	bytesAllocated int64
+	maxAllocated   int64
+
+	a  *ResourceAllocator
+	id string
+}
+
+func (n *nodeAllocator) Allocate(size int) []byte {
+	if size < 0 {
+		panic(errors.New(codes.Internal, "cannot allocate negative memory"))
+	} else if size == 0 {
+		return nil
+	}
+
+	if err := n.count(size); err != nil {
+		panic(err)
+	}
+	return n.a.allocator().Allocate(size)
+}
+
+func (n *nodeAllocator) Reallocate(size int, b []byte) []byte {
+	if err := n.Account(size - cap(b)); err != nil {
+		panic(err)
+	}
+	return n.a.allocator().Reallocate(size, b)
+}
+
+func (n *nodeAllocator) Account(size int) error {
+	if size == 0 {
+		return nil
+	}
+	return n.count(size)
+}
+
+func (n *nodeAllocator) UnderPressure() bool {
+	return n.a.UnderPressure()
+}
+
+func (n *nodeAllocator) Free(b []byte) {
+	size := len(b)
+	n.a.allocator().Free(b)
+	atomic.AddInt64(&n.a.bytesAllocated, int64(-size))
+	atomic.AddInt64(&n.bytesAllocated, int64(-size))
+}
+
+func (n *nodeAllocator) count(size int) error {
+	if err := n.a.count(size); err != nil {
+		var limitErr LimitExceededError
+		if errors.As(err, &limitErr) {
+			limitErr.Node = n.id
+			return errors.Wrap(limitErr, codes.ResourceExhausted)
+		}
+		return err
+	}
+
+	c := atomic.AddInt64(&n.bytesAllocated, int64(size))
+	for max := atomic.LoadInt64(&n.maxAllocated); c > max; max = atomic.LoadInt64(&n.maxAllocated) {
+		if atomic.CompareAndSwapInt64(&n.maxAllocated, max, c) {
+			break
+		}
+	}
+	return nil
+}
+
 // allocator returns the underlying memory.Allocator that should be used.
 func (a *ResourceAllocator) allocator() memory.Allocator {
 	if a.Allocator == nil {
@@ -286,8 +447,16 @@ type LimitExceededError struct {
 	Limit     int64
 	Allocated int64
 	Wanted    int64
+
+	// Node is the plan node that requested the memory
+	// when the memory was allocated with an Allocator
+	// returned by ForNode.
+	Node string
 }
 
 func (a LimitExceededError) Error() string {
+	if a.Node != "" {
+		return fmt.Sprintf("memory allocation limit reached by %s: limit %d bytes, allocated: %d, wanted: %d", a.Node, a.Limit, a.Allocated, a.Wanted)
+	}
 	return fmt.Sprintf("memory allocation limit reached: limit %d bytes, allocated: %d, wanted: %d", a.Limit, a.Allocated, a.Wanted)
 }
@@ -342,3 +342,77 @@ func TestAllocator_RequestMemory_Concurrently(t *testing.T) {
 		t.Fatalf("unexpected memory left in the manager -want/+got\n\t- %d\n\t+ %d", want, got)
 	}
 }
+
+func TestAllocator_ForNode(t *testing.T) {
+	mem := arrowmemory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	allocator := &memory.ResourceAllocator{
+		Limit:     func(v int64) *int64 { return &v }(128),
+		Allocator: mem,
+	}
+	a, b := allocator.ForNode("a"), allocator.ForNode("b")
+
+	b0 := a.Allocate(64)
+	b1 := b.Allocate(16)
+	b2 := allocator.ForNode("a").Allocate(8)
+	a.Free(b0)
+	assert.Equal(t, int64(24), allocator.Allocated(), "unexpected allocated memory")
+
+	want := []memory.NodeUsage{
+		{Node: "a", Allocated: 8, MaxAllocated: 72},
+		{Node: "b", Allocated: 16, MaxAllocated: 16},
+	}
+	assert.Equal(t, want, allocator.NodeUsage(), "unexpected node usage")
+
+	func() {
+		defer func() {
+			err, _ := recover().(error)
+			if err == nil {
+				t.Fatal("expected a panic")
+			}
+			var limitErr memory.LimitExceededError
+			if !errors.As(err, &limitErr) {
+				t.Fatalf("expected a limit exceeded error, got %v", err)
+			}
+			assert.Equal(t, "b", limitErr.Node, "unexpected node in error")
+			assert.Equal(t, codes.ResourceExhausted, errors.Code(err), "unexpected error code")
+			assert.Equal(t, "memory allocation limit reached by b: limit 128 bytes, allocated: 24, wanted: 112", err.Error(), "unexpected error message")
+		}()
+		_ = b.Allocate(112)
+	}()
+
+	b.Free(b1)
+	a.Free(b2)
+	assert.Equal(t, int64(0), allocator.Allocated(), "unexpected allocated memory")
+}
+
+func TestAllocator_SoftLimit(t *testing.T) {
+	var crossed []int64
+	allocator := &memory.ResourceAllocator{
+		Limit:     func(v int64) *int64 { return &v }(128),
+		SoftLimit: func(v int64) *int64 { return &v }(64),
+		OnSoftLimit: func(allocated int64) {
+			crossed = append(crossed, allocated)
+		},
+	}
+	node := allocator.ForNode("a")
+
+	b0 := allocator.Allocate(48)
+	assert.False(t, memory.UnderPressure(node), "unexpected memory pressure below the soft limit")
+
+	b1 := node.Allocate(32)
+	b2 := allocator.Allocate(8)
+	assert.True(t, memory.UnderPressure(node), "expected memory pressure above the soft limit")
+
+	node.Free(b1)
+	assert.False(t, memory.UnderPressure(allocator), "unexpected memory pressure after releasing memory")
+
+	b1 = allocator.Allocate(32)
+	assert.Equal(t, []int64{80, 88}, crossed, "unexpected soft limit notifications")
+
+	allocator.Free(b0)
+	allocator.Free(b1)
+	allocator.Free(b2)
+	assert.False(t, memory.UnderPressure(memory.DefaultAllocator), "unexpected memory pressure for an allocator without a soft limit")
+}
@@ -0,0 +1,36 @@
+package ndjson
+
+import (
+	"net/http"
+
+	"github.com/influxdata/flux"
+)
+
+const DialectType = "ndjson"
+
+// AddDialectMappings adds the newline-delimited JSON dialect mappings.
+func AddDialectMappings(mappings flux.DialectMappings) error {
+	return mappings.Add(DialectType, func() flux.Dialect {
+		return DefaultDialect()
+	})
+}
+
+// Dialect describes the output format of queries in newline-delimited JSON.
+type Dialect struct{}
+
+func (d Dialect) SetHeaders(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Transfer-Encoding", "chunked")
+}
+
+func (d Dialect) Encoder() flux.MultiResultEncoder {
+	return NewMultiResultEncoder()
+}
+
+func (d Dialect) DialectType() flux.DialectType {
+	return DialectType
+}
+
+func DefaultDialect() *Dialect {
+	return &Dialect{}
+}
@@ -0,0 +1,236 @@
+// Package ndjson contains the newline-delimited JSON result encoder.
+//
+// Every table is written as a schema record followed by one record
+// for each of its rows. Each record is a JSON object on its own line
+// so the output can be read with tools such as jq one line at a time.
+//
+//	{"type":"schema","result":"_result","table":0,"columns":[{"label":"host","type":"string","group":true},{"label":"_value","type":"double","group":false}]}
+//	{"type":"row","result":"_result","table":0,"values":{"host":"a","_value":1.5}}
+//
+// Time values are written as RFC3339 strings, null values as null and
+// floats that are not finite as the strings NaN, +Inf and -Inf.
+package ndjson
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/iocounter"
+)
+
+const (
+	schemaRecord = "schema"
+	rowRecord    = "row"
+	errorRecord  = "error"
+)
+
+// ResultEncoder encodes a result as newline-delimited JSON.
+type ResultEncoder struct{}
+
+// NewResultEncoder creates a new encoder.
+func NewResultEncoder() *ResultEncoder {
+	return &ResultEncoder{}
+}
+
+// NewMultiResultEncoder creates a MultiResultEncoder that encodes each result
+// as newline-delimited JSON. The records of each result contain the name of
+// the result so no delimiter is written between them.
+func NewMultiResultEncoder() flux.MultiResultEncoder {
+	return &flux.DelimitedMultiResultEncoder{
+		Encoder: NewResultEncoder(),
+	}
+}
+
+type ndjsonEncoderError struct {
+	err error
+}
+
+func (e *ndjsonEncoderError) Error() string {
+	return fmt.Sprintf("ndjson encoder error: %s", e.err.Error())
+}
+
+func (e *ndjsonEncoderError) IsEncoderError() bool {
+	return true
+}
+
+func (e *ndjsonEncoderError) Unwrap() error {
+	return e.err
+}
+
+func wrapEncodingError(err error) error {
+	if err == nil {
+		return err
+	}
+	return &ndjsonEncoderError{err: err}
+}
+
+func (e *ResultEncoder) Encode(w io.Writer, result flux.Result) (int64, error) {
+	writeCounter := &iocounter.Writer{Writer: w}
+	writer := bufio.NewWriter(writeCounter)
+
+	resultName := appendString(nil, result.Name())
+	tableID := 0
+	var line []byte
+	err := result.Tables().Do(func(tbl flux.Table) error {
+		prefix := strconv.AppendInt(append(resultName, `,"table":`...), int64(tableID), 10)
+		tableID++
+
+		line = appendSchema(line[:0], prefix, tbl)
+		if _, err := writer.Write(line); err != nil {
+			return err
+		}
+
+		cols := tbl.Cols()
+		labels := make([][]byte, len(cols))
+		for j, c := range cols {
+			labels[j] = append(appendString(nil, c.Label), ':')
+		}
+		if err := tbl.Do(func(cr flux.ColReader) error {
+			for i, l := 0, cr.Len(); i < l; i++ {
+				line = append(line[:0], `{"type":"`+rowRecord+`","result":`...)
+				line = append(line, prefix...)
+				line = append(line, `,"values":{`...)
+				for j, c := range cols {
+					if j > 0 {
+						line = append(line, ',')
+					}
+					line = append(line, labels[j]...)
+					var err error
+					if line, err = appendValue(line, cr, i, j, c.Type); err != nil {
+						return wrapEncodingError(err)
+					}
+				}
+				line = append(line, "}}\n"...)
+				if _, err := writer.Write(line); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		return writer.Flush()
+	})
+	if ferr := writer.Flush(); err == nil {
+		err = ferr
+	}
+	return writeCounter.Count(), err
+}
+
+// EncodeError writes the error as an error record.
+func (e *ResultEncoder) EncodeError(w io.Writer, err error) error {
+	line := append([]byte(`{"type":"`+errorRecord+`","error":`), appendString(nil, err.Error())...)
+	_, werr := w.Write(append(line, "}\n"...))
+	return werr
+}
+
+// appendSchema appends the schema record of the table.
+// The prefix contains the result name and table id.
+func appendSchema(line, prefix []byte, tbl flux.Table) []byte {
+	line = append(line, `{"type":"`+schemaRecord+`","result":`...)
+	line = append(line, prefix...)
+	line = append(line, `,"columns":[`...)
+	key := tbl.Key()
+	for j, c := range tbl.Cols() {
+		if j > 0 {
+			line = append(line, ',')
+		}
+		line = append(line, `{"label":`...)
+		line = appendString(line, c.Label)
+		line = append(line, `,"type":`...)
+		line = appendString(line, typeName(c.Type))
+		line = append(line, `,"group":`...)
+		line = strconv.AppendBool(line, key.HasCol(c.Label))
+		line = append(line, '}')
+	}
+	return append(line, "]}\n"...)
+}
+
+// typeName returns the name of the column type.
+// The names match the datatype annotation of annotated CSV.
+func typeName(typ flux.ColType) string {
+	switch typ {
+	case flux.TBool:
+		return "boolean"
+	case flux.TInt:
+		return "long"
+	case flux.TUInt:
+		return "unsignedLong"
+	case flux.TFloat:
+		return "double"
+	case flux.TString:
+		return "string"
+	case flux.TTime:
+		return "dateTime:RFC3339"
+	default:
+		return typ.String()
+	}
+}
+
+// appendString appends s as a JSON string.
+func appendString(b []byte, s string) []byte {
+	// Marshaling a string cannot fail.
+	enc, _ := json.Marshal(s)
+	return append(b, enc...)
+}
+
+// appendValue appends the value of the column in row i as JSON.
+func appendValue(b []byte, cr flux.ColReader, i, j int, typ flux.ColType) ([]byte, error) {
+	switch typ {
+	case flux.TBool:
+		vs := cr.Bools(j)
+		if vs.IsNull(i) {
+			return append(b, "null"...), nil
+		}
+		return strconv.AppendBool(b, vs.Value(i)), nil
+	case flux.TInt:
+		vs := cr.Ints(j)
+		if vs.IsNull(i) {
+			return append(b, "null"...), nil
+		}
+		return strconv.AppendInt(b, vs.Value(i), 10), nil
+	case flux.TUInt:
+		vs := cr.UInts(j)
+		if vs.IsNull(i) {
+			return append(b, "null"...), nil
+		}
+		return strconv.AppendUint(b, vs.Value(i), 10), nil
+	case flux.TFloat:
+		vs := cr.Floats(j)
+		if vs.IsNull(i) {
+			return append(b, "null"...), nil
+		}
+		v := vs.Value(i)
+		switch {
+		case math.IsNaN(v):
+			return append(b, `"NaN"`...), nil
+		case math.IsInf(v, 1):
+			return append(b, `"+Inf"`...), nil
+		case math.IsInf(v, -1):
+			return append(b, `"-Inf"`...), nil
+		}
+		return strconv.AppendFloat(b, v, 'f', -1, 64), nil
+	case flux.TString:
+		vs := cr.Strings(j)
+		if vs.IsNull(i) {
+			return append(b, "null"...), nil
+		}
+		return appendString(b, vs.Value(i)), nil
+	case flux.TTime:
+		vs := cr.Times(j)
+		if vs.IsNull(i) {
+			return append(b, "null"...), nil
+		}
+		return append(append(append(b, '"'), time.Unix(0, vs.Value(i)).UTC().Format(time.RFC3339Nano)...), '"'), nil
+	default:
+		return b, errors.Newf(codes.Internal, "unknown column type %v", typ)
+	}
+}
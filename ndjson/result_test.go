@@ -0,0 +1,111 @@
+package ndjson_test
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/ndjson"
+	"github.com/influxdata/flux/values"
+)
+
+func ts(sec int64) values.Time {
+	return values.ConvertTime(time.Unix(sec, 0).UTC())
+}
+
+func TestResultEncoder(t *testing.T) {
+	testCases := []struct {
+		name   string
+		tables []*executetest.Table
+		want   string
+	}{
+		{
+			name: "multiple tables",
+			tables: []*executetest.Table{
+				{
+					KeyCols: []string{"host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "host", Type: flux.TString},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{ts(1), "a", 1.5},
+						{ts(2), "a", nil},
+					},
+				},
+				{
+					KeyCols: []string{"host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "host", Type: flux.TString},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{ts(1), "b", 2.0},
+					},
+				},
+			},
+			want: `{"type":"schema","result":"_result","table":0,"columns":[{"label":"_time","type":"dateTime:RFC3339","group":false},{"label":"host","type":"string","group":true},{"label":"_value","type":"double","group":false}]}
+{"type":"row","result":"_result","table":0,"values":{"_time":"1970-01-01T00:00:01Z","host":"a","_value":1.5}}
+{"type":"row","result":"_result","table":0,"values":{"_time":"1970-01-01T00:00:02Z","host":"a","_value":null}}
+{"type":"schema","result":"_result","table":1,"columns":[{"label":"_time","type":"dateTime:RFC3339","group":false},{"label":"host","type":"string","group":true},{"label":"_value","type":"double","group":false}]}
+{"type":"row","result":"_result","table":1,"values":{"_time":"1970-01-01T00:00:01Z","host":"b","_value":2}}
+`,
+		},
+		{
+			name: "value types",
+			tables: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "b", Type: flux.TBool},
+					{Label: "i", Type: flux.TInt},
+					{Label: "u", Type: flux.TUInt},
+					{Label: "f", Type: flux.TFloat},
+					{Label: "s", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{true, int64(-1), uint64(1), math.Inf(1), "quoted \"value\"\n"},
+					{false, int64(2), uint64(2), math.NaN(), ""},
+				},
+			}},
+			want: `{"type":"schema","result":"_result","table":0,"columns":[{"label":"b","type":"boolean","group":false},{"label":"i","type":"long","group":false},{"label":"u","type":"unsignedLong","group":false},{"label":"f","type":"double","group":false},{"label":"s","type":"string","group":false}]}
+{"type":"row","result":"_result","table":0,"values":{"b":true,"i":-1,"u":1,"f":"+Inf","s":"quoted \"value\"\n"}}
+{"type":"row","result":"_result","table":0,"values":{"b":false,"i":2,"u":2,"f":"NaN","s":""}}
+`,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			encoder := ndjson.NewResultEncoder()
+
+			var buf bytes.Buffer
+			n, err := encoder.Encode(&buf, &executetest.Result{Nm: "_result", Tbls: tc.tables})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tc.want {
+				t.Errorf("unexpected output -want/+got:\n%s", cmp.Diff(tc.want, got))
+			}
+			if n != int64(buf.Len()) {
+				t.Errorf("unexpected byte count: want %d, got %d", buf.Len(), n)
+			}
+		})
+	}
+}
+
+func TestResultEncoder_EncodeError(t *testing.T) {
+	var buf bytes.Buffer
+	encoder := ndjson.NewResultEncoder()
+	if err := encoder.EncodeError(&buf, errors.New("query \"failed\"")); err != nil {
+		t.Fatal(err)
+	}
+	if want, got := `{"type":"error","error":"query \"failed\""}`+"\n", buf.String(); want != got {
+		t.Errorf("unexpected output -want/+got:\n%s", cmp.Diff(want, got))
+	}
+}
@@ -238,9 +238,12 @@ func (v *fluxSpecVisitor) visitOperation(o *operation.Node) error {
 
 	v.nodes[o.ID] = logicalNode
 
-	// Add this node to the logical plan by connecting predecessors and successors
+	// Add this node to the logical plan by connecting predecessors and successors.
+	// A yield in the middle of a pipeline is planned as a tee: the successors
+	// of the yield read from the predecessor of the yield so the yield
+	// is always a root of the plan.
 	for _, parent := range v.spec.Parents(o.ID) {
-		logicalParent := v.nodes[parent.ID]
+		logicalParent := teeYield(v.nodes[parent.ID])
 		logicalNode.AddPredecessors(logicalParent)
 		logicalParent.AddSuccessors(logicalNode)
 	}
@@ -254,7 +257,7 @@ func (v *fluxSpecVisitor) visitOperation(o *operation.Node) error {
 	}
 
 	// no children => no successors => root node
-	if len(v.spec.Children(o.ID)) == 0 {
+	if isYield || len(v.spec.Children(o.ID)) == 0 {
 		// N.b. the number of entries in `Roots` is factored into the starting
 		// concurrency quota for the query. As such we must mark all terminal
 		// nodes as roots here.
@@ -264,6 +267,17 @@ func (v *fluxSpecVisitor) visitOperation(o *operation.Node) error {
 	return nil
 }
 
+// teeYield returns the node that the successors of a yield
+// read from. It returns the node itself if it is not a yield.
+func teeYield(node Node) Node {
+	for {
+		if _, ok := node.ProcedureSpec().(YieldProcedureSpec); !ok || len(node.Predecessors()) != 1 {
+			return node
+		}
+		node = node.Predecessors()[0]
+	}
+}
+
 // CreateLogicalNode creates a single logical plan node from a procedure spec.
 // The newly created logical node has no incoming or outgoing edges.
 func CreateLogicalNode(id NodeID, spec ProcedureSpec) *LogicalNode {
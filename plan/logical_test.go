@@ -164,6 +164,24 @@ func TestPlan_LogicalPlanFromSpec(t *testing.T) {
 				Now: now,
 			},
 		},
+		{
+			name:  `yield in the middle of a pipeline`,
+			query: `from(bucket: "my-bucket") |> range(start:-1h) |> yield(name: "raw") |> sum()`,
+			plan: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreateLogicalNode("from0", fromSpec),
+					plan.CreateLogicalNode("range1", rangeSpec),
+					plan.CreateLogicalNode("yield2", standardYield("raw")),
+					plan.CreateLogicalNode("sum3", sumSpec),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+					{1, 3},
+				},
+				Now: now,
+			},
+		},
 		{
 			name:  `Non-yield side effect`,
 			query: `import "kafka" from(bucket: "my-bucket") |> range(start:-1h) |> kafka.to(brokers: ["broker"], topic: "topic")`,
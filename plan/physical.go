@@ -23,6 +23,7 @@ func NewPhysicalPlanner(options ...PhysicalOption) PhysicalPlanner {
 	pp := &physicalPlanner{
 		heuristicPlannerPhysical: newHeuristicPlanner(),
 		heuristicPlannerParallel: newHeuristicPlanner(),
+		heuristicPlannerStable:   newHeuristicPlanner(),
 		defaultMemoryLimit:       math.MaxInt64,
 	}
 
@@ -46,6 +47,12 @@ func NewPhysicalPlanner(options ...PhysicalOption) PhysicalPlanner {
 
 	pp.heuristicPlannerParallel.addRules(rulesParallel...)
 
+	rulesStable := make([]Rule, 0, len(ruleNameToStableOutputRules))
+	for _, v := range ruleNameToStableOutputRules {
+		rulesStable = append(rulesStable, v)
+	}
+	pp.heuristicPlannerStable.addRules(rulesStable...)
+
 	// Options may add or remove rules, so process them after we've
 	// added registered rules.
 	for _, opt := range options {
@@ -66,6 +73,16 @@ func (pp *physicalPlanner) Plan(ctx context.Context, spec *Spec) (*Spec, error)
 		return nil, err
 	}
 
+	// Order the rows of the results after all of the other rules
+	// so the rules see the collation of the final plan.
+	if pp.stableOutput {
+		transformedSpec, err = pp.heuristicPlannerStable.Plan(ctx, transformedSpec)
+		if err != nil {
+			return nil, err
+		}
+		transformedSpec.StableOutput = true
+	}
+
 	// Compute time bounds for nodes in the plan
 	if err := transformedSpec.BottomUpWalk(ComputeBounds); err != nil {
 		return nil, err
@@ -127,8 +144,10 @@ func ValidatePhysicalPlan(plan *Spec) error {
 type physicalPlanner struct {
 	heuristicPlannerPhysical *heuristicPlanner
 	heuristicPlannerParallel *heuristicPlanner
+	heuristicPlannerStable   *heuristicPlanner
 	defaultMemoryLimit       int64
 	disableValidation        bool
+	stableOutput             bool
 }
 
 // PhysicalOption is an option to configure the behavior of the physical plan.
@@ -172,6 +191,7 @@ func RemovePhysicalRules(rules ...string) PhysicalOption {
 	return physicalOption(func(pp *physicalPlanner) {
 		pp.heuristicPlannerPhysical.removeRules(rules...)
 		pp.heuristicPlannerParallel.removeRules(rules...)
+		pp.heuristicPlannerStable.removeRules(rules...)
 	})
 }
 
@@ -182,6 +202,16 @@ func DisableValidation() PhysicalOption {
 	})
 }
 
+// StableOutput guarantees that each result of the plan produces its tables
+// sorted by group key and the rows of each table sorted by time.
+// The rules registered with RegisterStableOutputRules insert the sorts
+// that are needed to order the rows of the results that are not already sorted.
+func StableOutput() PhysicalOption {
+	return physicalOption(func(p *physicalPlanner) {
+		p.stableOutput = true
+	})
+}
+
 // physicalConverterRule rewrites logical nodes that have a ProcedureSpec that implements
 // PhysicalProcedureSpec as a physical node.  For operations that have a 1:1 relationship
 // between their physical and logical operations, this is the default behavior.
@@ -55,6 +55,32 @@ func TestPhysicalIntegrityCheckOption(t *testing.T) {
 	}
 }
 
+func TestPhysicalStableOutputOption(t *testing.T) {
+	spec := &plantest.PlanSpec{
+		Nodes: []plan.Node{
+			plantest.CreatePhysicalMockNode("0"),
+			plantest.CreatePhysicalMockNode("1"),
+		},
+		Edges: [][2]int{
+			{0, 1},
+		},
+	}
+
+	for _, stable := range []bool{false, true} {
+		opts := []plan.PhysicalOption{plan.DisableValidation()}
+		if stable {
+			opts = append(opts, plan.StableOutput())
+		}
+		pp, err := plan.NewPhysicalPlanner(opts...).Plan(context.Background(), plantest.CreatePlanSpec(spec.Copy()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := pp.StableOutput, stable; got != want {
+			t.Errorf("unexpected stable output -want/+got:\n\t- %v\n\t+ %v", want, got)
+		}
+	}
+}
+
 // This spec tracks in each node which parallel-test rules have executed
 const MockParallelKind = "mock-parallel"
 
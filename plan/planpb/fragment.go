@@ -0,0 +1,82 @@
+package planpb
+
+import (
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/plan"
+)
+
+// MarshalFragment encodes the subgraph of the physical plan made of
+// the root and all of its predecessors. A fragment is used to execute
+// part of a plan in another process.
+//
+// It returns an error with the Unimplemented code if one of the nodes
+// has no registered encoding and with the Invalid code if one of the
+// nodes other than root has a successor outside of the fragment.
+func MarshalFragment(root plan.Node, spec *plan.Spec) (*Spec, error) {
+	var nodes []plan.Node
+	if err := plan.WalkPredecessors([]plan.Node{root}, func(node plan.Node) error {
+		nodes = append(nodes, node)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	ordered := topologicalOrder(nodes)
+	in := make(map[plan.Node]bool, len(ordered))
+	for _, n := range ordered {
+		in[n] = true
+	}
+	for _, n := range ordered {
+		if n == root {
+			continue
+		}
+		for _, succ := range n.Successors() {
+			if !in[succ] {
+				return nil, errors.Newf(codes.Invalid, "plan node %q has a successor %q outside of the fragment", n.ID(), succ.ID())
+			}
+		}
+	}
+	return marshalNodes(spec, ordered)
+}
+
+// UnmarshalFragment decodes a fragment encoded by MarshalFragment
+// into a physical plan whose only root is the root of the fragment.
+func UnmarshalFragment(pb *Spec) (*plan.Spec, error) {
+	spec, err := UnmarshalSpec(pb)
+	if err != nil {
+		return nil, err
+	}
+	if len(spec.Roots) != 1 {
+		return nil, errors.Newf(codes.Invalid, "plan fragment must have a single root, found %d", len(spec.Roots))
+	}
+	return spec, nil
+}
+
+// topologicalOrder sorts the nodes so that each node
+// comes after all of its predecessors in the list.
+func topologicalOrder(nodes []plan.Node) []plan.Node {
+	in := make(map[plan.Node]bool, len(nodes))
+	for _, n := range nodes {
+		in[n] = true
+	}
+	visited := make(map[plan.Node]bool, len(nodes))
+	ordered := make([]plan.Node, 0, len(nodes))
+	var visit func(n plan.Node)
+	visit = func(n plan.Node) {
+		if visited[n] {
+			return
+		}
+		visited[n] = true
+		for _, pred := range n.Predecessors() {
+			if in[pred] {
+				visit(pred)
+			}
+		}
+		ordered = append(ordered, n)
+	}
+	for _, n := range nodes {
+		visit(n)
+	}
+	return ordered
+}
@@ -0,0 +1,81 @@
+package planpb_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/plan/planpb"
+)
+
+func TestMarshalFragment(t *testing.T) {
+	a := plan.CreatePhysicalNode("a", &testSpec{N: 1})
+	b := plan.CreatePhysicalNode("b", &testSpec{N: 2})
+	c := plan.CreatePhysicalNode("c", &testSpec{N: 3})
+	a.AddSuccessors(b)
+	b.AddPredecessors(a)
+	b.AddSuccessors(c)
+	c.AddPredecessors(b)
+
+	spec := plan.NewPlanSpec()
+	spec.Roots[c] = struct{}{}
+
+	pb, err := planpb.MarshalFragment(b, spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := planpb.UnmarshalFragment(pb)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []plan.NodeID
+	if err := got.BottomUpWalk(func(node plan.Node) error {
+		ids = append(ids, node.ID())
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if want := []plan.NodeID{"a", "b"}; !cmp.Equal(want, ids) {
+		t.Errorf("unexpected fragment nodes -want/+got:\n%s", cmp.Diff(want, ids))
+	}
+}
+
+func TestMarshalFragment_OutsideSuccessor(t *testing.T) {
+	// The node a is read by both b and c so a fragment
+	// rooted at b would leave c without its input.
+	a := plan.CreatePhysicalNode("a", &testSpec{N: 1})
+	b := plan.CreatePhysicalNode("b", &testSpec{N: 2})
+	c := plan.CreatePhysicalNode("c", &testSpec{N: 3})
+	a.AddSuccessors(b, c)
+	b.AddPredecessors(a)
+	c.AddPredecessors(a)
+
+	spec := plan.NewPlanSpec()
+	spec.Roots[b] = struct{}{}
+	spec.Roots[c] = struct{}{}
+
+	_, err := planpb.MarshalFragment(b, spec)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if want, got := codes.Invalid, errors.Code(err); want != got {
+		t.Errorf("unexpected error code -want/+got:\n\t- %v\n\t+ %v", want, got)
+	}
+}
+
+func TestMarshalFragment_Unimplemented(t *testing.T) {
+	a := plan.CreatePhysicalNode("a", &untypedSpec{})
+	spec := plan.NewPlanSpec()
+	spec.Roots[a] = struct{}{}
+
+	_, err := planpb.MarshalFragment(a, spec)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if want, got := codes.Unimplemented, errors.Code(err); want != got {
+		t.Errorf("unexpected error code -want/+got:\n\t- %v\n\t+ %v", want, got)
+	}
+}
@@ -0,0 +1,1602 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.19.4
+// source: plan/planpb/plan.proto
+
+package planpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Spec is a physical query plan.
+type Spec struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Nodes are the nodes of the plan in topological order.
+	// Each node comes after all of its predecessors.
+	// The roots of the plan are the nodes without successors.
+	Nodes []*Node `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	// Edges connect the nodes of the plan.
+	Edges []*Edge `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
+	// Resources defines how the query consumes the available resources.
+	Resources *Resources `protobuf:"bytes,3,opt,name=resources,proto3" json:"resources,omitempty"`
+	// Now is the time that the query uses as now.
+	Now *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=now,proto3" json:"now,omitempty"`
+}
+
+func (x *Spec) Reset() {
+	*x = Spec{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plan_planpb_plan_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Spec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Spec) ProtoMessage() {}
+
+func (x *Spec) ProtoReflect() protoreflect.Message {
+	mi := &file_plan_planpb_plan_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Spec.ProtoReflect.Descriptor instead.
+func (*Spec) Descriptor() ([]byte, []int) {
+	return file_plan_planpb_plan_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Spec) GetNodes() []*Node {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *Spec) GetEdges() []*Edge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *Spec) GetResources() *Resources {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *Spec) GetNow() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Now
+	}
+	return nil
+}
+
+// Edge connects a predecessor to one of its successors.
+// The order of the edges of a successor is the order of its predecessors.
+type Edge struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Predecessor is the index of the predecessor in the nodes of the plan.
+	Predecessor int32 `protobuf:"varint,1,opt,name=predecessor,proto3" json:"predecessor,omitempty"`
+	// Successor is the index of the successor in the nodes of the plan.
+	Successor int32 `protobuf:"varint,2,opt,name=successor,proto3" json:"successor,omitempty"`
+}
+
+func (x *Edge) Reset() {
+	*x = Edge{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plan_planpb_plan_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Edge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Edge) ProtoMessage() {}
+
+func (x *Edge) ProtoReflect() protoreflect.Message {
+	mi := &file_plan_planpb_plan_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Edge.ProtoReflect.Descriptor instead.
+func (*Edge) Descriptor() ([]byte, []int) {
+	return file_plan_planpb_plan_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Edge) GetPredecessor() int32 {
+	if x != nil {
+		return x.Predecessor
+	}
+	return 0
+}
+
+func (x *Edge) GetSuccessor() int32 {
+	if x != nil {
+		return x.Successor
+	}
+	return 0
+}
+
+// Node is a node of a physical plan.
+type Node struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Id is the unique identifier of the node in the plan.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Kind is the procedure kind of the node.
+	Kind string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	// Spec is the procedure spec of the node encoded
+	// with the message registered for its kind.
+	Spec *anypb.Any `protobuf:"bytes,3,opt,name=spec,proto3" json:"spec,omitempty"`
+	// Bounds are the time bounds of the data produced by the node.
+	// They are not set when the bounds are unknown.
+	Bounds *Bounds `protobuf:"bytes,4,opt,name=bounds,proto3" json:"bounds,omitempty"`
+	// Trigger defines when the node sends its tables to its successors.
+	Trigger *Trigger `protobuf:"bytes,5,opt,name=trigger,proto3" json:"trigger,omitempty"`
+	// OutputAttributes are the physical attributes the node provides.
+	// They are derived from the procedure spec and are only informational.
+	OutputAttributes []*PhysicalAttribute `protobuf:"bytes,6,rep,name=output_attributes,json=outputAttributes,proto3" json:"output_attributes,omitempty"`
+}
+
+func (x *Node) Reset() {
+	*x = Node{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plan_planpb_plan_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Node) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Node) ProtoMessage() {}
+
+func (x *Node) ProtoReflect() protoreflect.Message {
+	mi := &file_plan_planpb_plan_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Node.ProtoReflect.Descriptor instead.
+func (*Node) Descriptor() ([]byte, []int) {
+	return file_plan_planpb_plan_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Node) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Node) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Node) GetSpec() *anypb.Any {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+func (x *Node) GetBounds() *Bounds {
+	if x != nil {
+		return x.Bounds
+	}
+	return nil
+}
+
+func (x *Node) GetTrigger() *Trigger {
+	if x != nil {
+		return x.Trigger
+	}
+	return nil
+}
+
+func (x *Node) GetOutputAttributes() []*PhysicalAttribute {
+	if x != nil {
+		return x.OutputAttributes
+	}
+	return nil
+}
+
+// Bounds are absolute time bounds in nanoseconds since the Unix epoch.
+type Bounds struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start int64 `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	Stop  int64 `protobuf:"varint,2,opt,name=stop,proto3" json:"stop,omitempty"`
+}
+
+func (x *Bounds) Reset() {
+	*x = Bounds{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plan_planpb_plan_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Bounds) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bounds) ProtoMessage() {}
+
+func (x *Bounds) ProtoReflect() protoreflect.Message {
+	mi := &file_plan_planpb_plan_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bounds.ProtoReflect.Descriptor instead.
+func (*Bounds) Descriptor() ([]byte, []int) {
+	return file_plan_planpb_plan_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Bounds) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *Bounds) GetStop() int64 {
+	if x != nil {
+		return x.Stop
+	}
+	return 0
+}
+
+// Resources defines how a query consumes the available resources.
+// A zero value indicates that the resource is not limited.
+type Resources struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConcurrencyQuota     int64 `protobuf:"varint,1,opt,name=concurrency_quota,json=concurrencyQuota,proto3" json:"concurrency_quota,omitempty"`
+	MemoryBytesQuota     int64 `protobuf:"varint,2,opt,name=memory_bytes_quota,json=memoryBytesQuota,proto3" json:"memory_bytes_quota,omitempty"`
+	MemoryBytesSoftQuota int64 `protobuf:"varint,3,opt,name=memory_bytes_soft_quota,json=memoryBytesSoftQuota,proto3" json:"memory_bytes_soft_quota,omitempty"`
+	MaxOutputRows        int64 `protobuf:"varint,4,opt,name=max_output_rows,json=maxOutputRows,proto3" json:"max_output_rows,omitempty"`
+	MaxOutputTables      int64 `protobuf:"varint,5,opt,name=max_output_tables,json=maxOutputTables,proto3" json:"max_output_tables,omitempty"`
+	MaxGroupRows         int64 `protobuf:"varint,6,opt,name=max_group_rows,json=maxGroupRows,proto3" json:"max_group_rows,omitempty"`
+}
+
+func (x *Resources) Reset() {
+	*x = Resources{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plan_planpb_plan_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Resources) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Resources) ProtoMessage() {}
+
+func (x *Resources) ProtoReflect() protoreflect.Message {
+	mi := &file_plan_planpb_plan_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Resources.ProtoReflect.Descriptor instead.
+func (*Resources) Descriptor() ([]byte, []int) {
+	return file_plan_planpb_plan_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Resources) GetConcurrencyQuota() int64 {
+	if x != nil {
+		return x.ConcurrencyQuota
+	}
+	return 0
+}
+
+func (x *Resources) GetMemoryBytesQuota() int64 {
+	if x != nil {
+		return x.MemoryBytesQuota
+	}
+	return 0
+}
+
+func (x *Resources) GetMemoryBytesSoftQuota() int64 {
+	if x != nil {
+		return x.MemoryBytesSoftQuota
+	}
+	return 0
+}
+
+func (x *Resources) GetMaxOutputRows() int64 {
+	if x != nil {
+		return x.MaxOutputRows
+	}
+	return 0
+}
+
+func (x *Resources) GetMaxOutputTables() int64 {
+	if x != nil {
+		return x.MaxOutputTables
+	}
+	return 0
+}
+
+func (x *Resources) GetMaxGroupRows() int64 {
+	if x != nil {
+		return x.MaxGroupRows
+	}
+	return 0
+}
+
+// Duration is a flux duration. The months and the nanoseconds
+// are never negative. The sign of the duration is stored separately.
+type Duration struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Months      int64 `protobuf:"varint,1,opt,name=months,proto3" json:"months,omitempty"`
+	Nanoseconds int64 `protobuf:"varint,2,opt,name=nanoseconds,proto3" json:"nanoseconds,omitempty"`
+	Negative    bool  `protobuf:"varint,3,opt,name=negative,proto3" json:"negative,omitempty"`
+}
+
+func (x *Duration) Reset() {
+	*x = Duration{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plan_planpb_plan_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Duration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Duration) ProtoMessage() {}
+
+func (x *Duration) ProtoReflect() protoreflect.Message {
+	mi := &file_plan_planpb_plan_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Duration.ProtoReflect.Descriptor instead.
+func (*Duration) Descriptor() ([]byte, []int) {
+	return file_plan_planpb_plan_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Duration) GetMonths() int64 {
+	if x != nil {
+		return x.Months
+	}
+	return 0
+}
+
+func (x *Duration) GetNanoseconds() int64 {
+	if x != nil {
+		return x.Nanoseconds
+	}
+	return 0
+}
+
+func (x *Duration) GetNegative() bool {
+	if x != nil {
+		return x.Negative
+	}
+	return false
+}
+
+// Trigger defines how and when a node sends its tables to its successors.
+type Trigger struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Trigger:
+	//	*Trigger_NarrowTransformation
+	//	*Trigger_AfterWatermark
+	//	*Trigger_Repeat
+	//	*Trigger_AfterProcessingTime
+	//	*Trigger_AfterAtLeastCount
+	//	*Trigger_OrFinally
+	Trigger isTrigger_Trigger `protobuf_oneof:"trigger"`
+}
+
+func (x *Trigger) Reset() {
+	*x = Trigger{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plan_planpb_plan_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Trigger) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Trigger) ProtoMessage() {}
+
+func (x *Trigger) ProtoReflect() protoreflect.Message {
+	mi := &file_plan_planpb_plan_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Trigger.ProtoReflect.Descriptor instead.
+func (*Trigger) Descriptor() ([]byte, []int) {
+	return file_plan_planpb_plan_proto_rawDescGZIP(), []int{6}
+}
+
+func (m *Trigger) GetTrigger() isTrigger_Trigger {
+	if m != nil {
+		return m.Trigger
+	}
+	return nil
+}
+
+func (x *Trigger) GetNarrowTransformation() *NarrowTransformationTrigger {
+	if x, ok := x.GetTrigger().(*Trigger_NarrowTransformation); ok {
+		return x.NarrowTransformation
+	}
+	return nil
+}
+
+func (x *Trigger) GetAfterWatermark() *AfterWatermarkTrigger {
+	if x, ok := x.GetTrigger().(*Trigger_AfterWatermark); ok {
+		return x.AfterWatermark
+	}
+	return nil
+}
+
+func (x *Trigger) GetRepeat() *RepeatedTrigger {
+	if x, ok := x.GetTrigger().(*Trigger_Repeat); ok {
+		return x.Repeat
+	}
+	return nil
+}
+
+func (x *Trigger) GetAfterProcessingTime() *AfterProcessingTimeTrigger {
+	if x, ok := x.GetTrigger().(*Trigger_AfterProcessingTime); ok {
+		return x.AfterProcessingTime
+	}
+	return nil
+}
+
+func (x *Trigger) GetAfterAtLeastCount() *AfterAtLeastCountTrigger {
+	if x, ok := x.GetTrigger().(*Trigger_AfterAtLeastCount); ok {
+		return x.AfterAtLeastCount
+	}
+	return nil
+}
+
+func (x *Trigger) GetOrFinally() *OrFinallyTrigger {
+	if x, ok := x.GetTrigger().(*Trigger_OrFinally); ok {
+		return x.OrFinally
+	}
+	return nil
+}
+
+type isTrigger_Trigger interface {
+	isTrigger_Trigger()
+}
+
+type Trigger_NarrowTransformation struct {
+	NarrowTransformation *NarrowTransformationTrigger `protobuf:"bytes,1,opt,name=narrow_transformation,json=narrowTransformation,proto3,oneof"`
+}
+
+type Trigger_AfterWatermark struct {
+	AfterWatermark *AfterWatermarkTrigger `protobuf:"bytes,2,opt,name=after_watermark,json=afterWatermark,proto3,oneof"`
+}
+
+type Trigger_Repeat struct {
+	Repeat *RepeatedTrigger `protobuf:"bytes,3,opt,name=repeat,proto3,oneof"`
+}
+
+type Trigger_AfterProcessingTime struct {
+	AfterProcessingTime *AfterProcessingTimeTrigger `protobuf:"bytes,4,opt,name=after_processing_time,json=afterProcessingTime,proto3,oneof"`
+}
+
+type Trigger_AfterAtLeastCount struct {
+	AfterAtLeastCount *AfterAtLeastCountTrigger `protobuf:"bytes,5,opt,name=after_at_least_count,json=afterAtLeastCount,proto3,oneof"`
+}
+
+type Trigger_OrFinally struct {
+	OrFinally *OrFinallyTrigger `protobuf:"bytes,6,opt,name=or_finally,json=orFinally,proto3,oneof"`
+}
+
+func (*Trigger_NarrowTransformation) isTrigger_Trigger() {}
+
+func (*Trigger_AfterWatermark) isTrigger_Trigger() {}
+
+func (*Trigger_Repeat) isTrigger_Trigger() {}
+
+func (*Trigger_AfterProcessingTime) isTrigger_Trigger() {}
+
+func (*Trigger_AfterAtLeastCount) isTrigger_Trigger() {}
+
+func (*Trigger_OrFinally) isTrigger_Trigger() {}
+
+type NarrowTransformationTrigger struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *NarrowTransformationTrigger) Reset() {
+	*x = NarrowTransformationTrigger{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plan_planpb_plan_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NarrowTransformationTrigger) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NarrowTransformationTrigger) ProtoMessage() {}
+
+func (x *NarrowTransformationTrigger) ProtoReflect() protoreflect.Message {
+	mi := &file_plan_planpb_plan_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NarrowTransformationTrigger.ProtoReflect.Descriptor instead.
+func (*NarrowTransformationTrigger) Descriptor() ([]byte, []int) {
+	return file_plan_planpb_plan_proto_rawDescGZIP(), []int{7}
+}
+
+type AfterWatermarkTrigger struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AllowedLateness *Duration `protobuf:"bytes,1,opt,name=allowed_lateness,json=allowedLateness,proto3" json:"allowed_lateness,omitempty"`
+}
+
+func (x *AfterWatermarkTrigger) Reset() {
+	*x = AfterWatermarkTrigger{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plan_planpb_plan_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AfterWatermarkTrigger) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AfterWatermarkTrigger) ProtoMessage() {}
+
+func (x *AfterWatermarkTrigger) ProtoReflect() protoreflect.Message {
+	mi := &file_plan_planpb_plan_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AfterWatermarkTrigger.ProtoReflect.Descriptor instead.
+func (*AfterWatermarkTrigger) Descriptor() ([]byte, []int) {
+	return file_plan_planpb_plan_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AfterWatermarkTrigger) GetAllowedLateness() *Duration {
+	if x != nil {
+		return x.AllowedLateness
+	}
+	return nil
+}
+
+type RepeatedTrigger struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Trigger *Trigger `protobuf:"bytes,1,opt,name=trigger,proto3" json:"trigger,omitempty"`
+}
+
+func (x *RepeatedTrigger) Reset() {
+	*x = RepeatedTrigger{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plan_planpb_plan_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RepeatedTrigger) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepeatedTrigger) ProtoMessage() {}
+
+func (x *RepeatedTrigger) ProtoReflect() protoreflect.Message {
+	mi := &file_plan_planpb_plan_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepeatedTrigger.ProtoReflect.Descriptor instead.
+func (*RepeatedTrigger) Descriptor() ([]byte, []int) {
+	return file_plan_planpb_plan_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RepeatedTrigger) GetTrigger() *Trigger {
+	if x != nil {
+		return x.Trigger
+	}
+	return nil
+}
+
+type AfterProcessingTimeTrigger struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Duration *Duration `protobuf:"bytes,1,opt,name=duration,proto3" json:"duration,omitempty"`
+}
+
+func (x *AfterProcessingTimeTrigger) Reset() {
+	*x = AfterProcessingTimeTrigger{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plan_planpb_plan_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AfterProcessingTimeTrigger) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AfterProcessingTimeTrigger) ProtoMessage() {}
+
+func (x *AfterProcessingTimeTrigger) ProtoReflect() protoreflect.Message {
+	mi := &file_plan_planpb_plan_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AfterProcessingTimeTrigger.ProtoReflect.Descriptor instead.
+func (*AfterProcessingTimeTrigger) Descriptor() ([]byte, []int) {
+	return file_plan_planpb_plan_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AfterProcessingTimeTrigger) GetDuration() *Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+type AfterAtLeastCountTrigger struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count int64 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *AfterAtLeastCountTrigger) Reset() {
+	*x = AfterAtLeastCountTrigger{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plan_planpb_plan_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AfterAtLeastCountTrigger) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AfterAtLeastCountTrigger) ProtoMessage() {}
+
+func (x *AfterAtLeastCountTrigger) ProtoReflect() protoreflect.Message {
+	mi := &file_plan_planpb_plan_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AfterAtLeastCountTrigger.ProtoReflect.Descriptor instead.
+func (*AfterAtLeastCountTrigger) Descriptor() ([]byte, []int) {
+	return file_plan_planpb_plan_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AfterAtLeastCountTrigger) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type OrFinallyTrigger struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Main    *Trigger `protobuf:"bytes,1,opt,name=main,proto3" json:"main,omitempty"`
+	Finally *Trigger `protobuf:"bytes,2,opt,name=finally,proto3" json:"finally,omitempty"`
+}
+
+func (x *OrFinallyTrigger) Reset() {
+	*x = OrFinallyTrigger{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plan_planpb_plan_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrFinallyTrigger) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrFinallyTrigger) ProtoMessage() {}
+
+func (x *OrFinallyTrigger) ProtoReflect() protoreflect.Message {
+	mi := &file_plan_planpb_plan_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrFinallyTrigger.ProtoReflect.Descriptor instead.
+func (*OrFinallyTrigger) Descriptor() ([]byte, []int) {
+	return file_plan_planpb_plan_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *OrFinallyTrigger) GetMain() *Trigger {
+	if x != nil {
+		return x.Main
+	}
+	return nil
+}
+
+func (x *OrFinallyTrigger) GetFinally() *Trigger {
+	if x != nil {
+		return x.Finally
+	}
+	return nil
+}
+
+// PhysicalAttribute is an attribute of the data produced by a node.
+type PhysicalAttribute struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Attribute:
+	//	*PhysicalAttribute_Collation
+	//	*PhysicalAttribute_ParallelRun
+	//	*PhysicalAttribute_ParallelMerge
+	Attribute isPhysicalAttribute_Attribute `protobuf_oneof:"attribute"`
+}
+
+func (x *PhysicalAttribute) Reset() {
+	*x = PhysicalAttribute{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plan_planpb_plan_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PhysicalAttribute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhysicalAttribute) ProtoMessage() {}
+
+func (x *PhysicalAttribute) ProtoReflect() protoreflect.Message {
+	mi := &file_plan_planpb_plan_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhysicalAttribute.ProtoReflect.Descriptor instead.
+func (*PhysicalAttribute) Descriptor() ([]byte, []int) {
+	return file_plan_planpb_plan_proto_rawDescGZIP(), []int{13}
+}
+
+func (m *PhysicalAttribute) GetAttribute() isPhysicalAttribute_Attribute {
+	if m != nil {
+		return m.Attribute
+	}
+	return nil
+}
+
+func (x *PhysicalAttribute) GetCollation() *CollationAttribute {
+	if x, ok := x.GetAttribute().(*PhysicalAttribute_Collation); ok {
+		return x.Collation
+	}
+	return nil
+}
+
+func (x *PhysicalAttribute) GetParallelRun() *ParallelRunAttribute {
+	if x, ok := x.GetAttribute().(*PhysicalAttribute_ParallelRun); ok {
+		return x.ParallelRun
+	}
+	return nil
+}
+
+func (x *PhysicalAttribute) GetParallelMerge() *ParallelMergeAttribute {
+	if x, ok := x.GetAttribute().(*PhysicalAttribute_ParallelMerge); ok {
+		return x.ParallelMerge
+	}
+	return nil
+}
+
+type isPhysicalAttribute_Attribute interface {
+	isPhysicalAttribute_Attribute()
+}
+
+type PhysicalAttribute_Collation struct {
+	Collation *CollationAttribute `protobuf:"bytes,1,opt,name=collation,proto3,oneof"`
+}
+
+type PhysicalAttribute_ParallelRun struct {
+	ParallelRun *ParallelRunAttribute `protobuf:"bytes,2,opt,name=parallel_run,json=parallelRun,proto3,oneof"`
+}
+
+type PhysicalAttribute_ParallelMerge struct {
+	ParallelMerge *ParallelMergeAttribute `protobuf:"bytes,3,opt,name=parallel_merge,json=parallelMerge,proto3,oneof"`
+}
+
+func (*PhysicalAttribute_Collation) isPhysicalAttribute_Attribute() {}
+
+func (*PhysicalAttribute_ParallelRun) isPhysicalAttribute_Attribute() {}
+
+func (*PhysicalAttribute_ParallelMerge) isPhysicalAttribute_Attribute() {}
+
+// CollationAttribute describes the order of the rows within each table.
+type CollationAttribute struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Columns []string `protobuf:"bytes,1,rep,name=columns,proto3" json:"columns,omitempty"`
+	Desc    bool     `protobuf:"varint,2,opt,name=desc,proto3" json:"desc,omitempty"`
+}
+
+func (x *CollationAttribute) Reset() {
+	*x = CollationAttribute{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plan_planpb_plan_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CollationAttribute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollationAttribute) ProtoMessage() {}
+
+func (x *CollationAttribute) ProtoReflect() protoreflect.Message {
+	mi := &file_plan_planpb_plan_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollationAttribute.ProtoReflect.Descriptor instead.
+func (*CollationAttribute) Descriptor() ([]byte, []int) {
+	return file_plan_planpb_plan_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CollationAttribute) GetColumns() []string {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *CollationAttribute) GetDesc() bool {
+	if x != nil {
+		return x.Desc
+	}
+	return false
+}
+
+// ParallelRunAttribute means the node runs in parallel
+// and produces a subset of the data in each copy.
+type ParallelRunAttribute struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Factor int64 `protobuf:"varint,1,opt,name=factor,proto3" json:"factor,omitempty"`
+}
+
+func (x *ParallelRunAttribute) Reset() {
+	*x = ParallelRunAttribute{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plan_planpb_plan_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ParallelRunAttribute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParallelRunAttribute) ProtoMessage() {}
+
+func (x *ParallelRunAttribute) ProtoReflect() protoreflect.Message {
+	mi := &file_plan_planpb_plan_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParallelRunAttribute.ProtoReflect.Descriptor instead.
+func (*ParallelRunAttribute) Descriptor() ([]byte, []int) {
+	return file_plan_planpb_plan_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ParallelRunAttribute) GetFactor() int64 {
+	if x != nil {
+		return x.Factor
+	}
+	return 0
+}
+
+// ParallelMergeAttribute means the node merges
+// the data produced by nodes that run in parallel.
+type ParallelMergeAttribute struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Factor int64 `protobuf:"varint,1,opt,name=factor,proto3" json:"factor,omitempty"`
+}
+
+func (x *ParallelMergeAttribute) Reset() {
+	*x = ParallelMergeAttribute{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plan_planpb_plan_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ParallelMergeAttribute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParallelMergeAttribute) ProtoMessage() {}
+
+func (x *ParallelMergeAttribute) ProtoReflect() protoreflect.Message {
+	mi := &file_plan_planpb_plan_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParallelMergeAttribute.ProtoReflect.Descriptor instead.
+func (*ParallelMergeAttribute) Descriptor() ([]byte, []int) {
+	return file_plan_planpb_plan_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ParallelMergeAttribute) GetFactor() int64 {
+	if x != nil {
+		return x.Factor
+	}
+	return 0
+}
+
+var File_plan_planpb_plan_proto protoreflect.FileDescriptor
+
+var file_plan_planpb_plan_proto_rawDesc = []byte{
+	0x0a, 0x16, 0x70, 0x6c, 0x61, 0x6e, 0x2f, 0x70, 0x6c, 0x61, 0x6e, 0x70, 0x62, 0x2f, 0x70, 0x6c,
+	0x61, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x66, 0x6c, 0x75, 0x78, 0x2e, 0x70,
+	0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x1a, 0x19, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61, 0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x22, 0xbf, 0x01, 0x0a, 0x04, 0x53, 0x70, 0x65, 0x63, 0x12, 0x28, 0x0a, 0x05, 0x6e,
+	0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x66, 0x6c, 0x75,
+	0x78, 0x2e, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x05,
+	0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x28, 0x0a, 0x05, 0x65, 0x64, 0x67, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x66, 0x6c, 0x75, 0x78, 0x2e, 0x70, 0x6c, 0x61, 0x6e,
+	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x52, 0x05, 0x65, 0x64, 0x67, 0x65, 0x73, 0x12,
+	0x35, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x66, 0x6c, 0x75, 0x78, 0x2e, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x09, 0x72, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x03, 0x6e, 0x6f, 0x77, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x03, 0x6e, 0x6f, 0x77, 0x22, 0x46, 0x0a, 0x04, 0x45, 0x64, 0x67, 0x65, 0x12, 0x20, 0x0a, 0x0b,
+	0x70, 0x72, 0x65, 0x64, 0x65, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0b, 0x70, 0x72, 0x65, 0x64, 0x65, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x12, 0x1c,
+	0x0a, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x22, 0x81, 0x02, 0x0a,
+	0x04, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x28, 0x0a, 0x04, 0x73, 0x70, 0x65,
+	0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x04, 0x73,
+	0x70, 0x65, 0x63, 0x12, 0x2c, 0x0a, 0x06, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x66, 0x6c, 0x75, 0x78, 0x2e, 0x70, 0x6c, 0x61, 0x6e, 0x2e,
+	0x76, 0x31, 0x2e, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x52, 0x06, 0x62, 0x6f, 0x75, 0x6e, 0x64,
+	0x73, 0x12, 0x2f, 0x0a, 0x07, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x66, 0x6c, 0x75, 0x78, 0x2e, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76,
+	0x31, 0x2e, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x52, 0x07, 0x74, 0x72, 0x69, 0x67, 0x67,
+	0x65, 0x72, 0x12, 0x4c, 0x0a, 0x11, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x61, 0x74, 0x74,
+	0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e,
+	0x66, 0x6c, 0x75, 0x78, 0x2e, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x68, 0x79,
+	0x73, 0x69, 0x63, 0x61, 0x6c, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x52, 0x10,
+	0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73,
+	0x22, 0x32, 0x0a, 0x06, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x74, 0x6f, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04,
+	0x73, 0x74, 0x6f, 0x70, 0x22, 0x97, 0x02, 0x0a, 0x09, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
+	0x79, 0x5f, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x63,
+	0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x12,
+	0x2c, 0x0a, 0x12, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f,
+	0x71, 0x75, 0x6f, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x6d, 0x65, 0x6d,
+	0x6f, 0x72, 0x79, 0x42, 0x79, 0x74, 0x65, 0x73, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x12, 0x35, 0x0a,
+	0x17, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x73, 0x6f,
+	0x66, 0x74, 0x5f, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x14,
+	0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x42, 0x79, 0x74, 0x65, 0x73, 0x53, 0x6f, 0x66, 0x74, 0x51,
+	0x75, 0x6f, 0x74, 0x61, 0x12, 0x26, 0x0a, 0x0f, 0x6d, 0x61, 0x78, 0x5f, 0x6f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x5f, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6d,
+	0x61, 0x78, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x6f, 0x77, 0x73, 0x12, 0x2a, 0x0a, 0x11,
+	0x6d, 0x61, 0x78, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65,
+	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x6d, 0x61, 0x78, 0x4f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0c, 0x6d, 0x61, 0x78, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x6f, 0x77, 0x73, 0x22, 0x60,
+	0x0a, 0x08, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x6f,
+	0x6e, 0x74, 0x68, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6d, 0x6f, 0x6e, 0x74,
+	0x68, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x6e, 0x61, 0x6e, 0x6f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6e, 0x61, 0x6e, 0x6f, 0x73, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65,
+	0x22, 0xfb, 0x03, 0x0a, 0x07, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x12, 0x60, 0x0a, 0x15,
+	0x6e, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x66, 0x6c,
+	0x75, 0x78, 0x2e, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x61, 0x72, 0x72, 0x6f,
+	0x77, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54,
+	0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x48, 0x00, 0x52, 0x14, 0x6e, 0x61, 0x72, 0x72, 0x6f, 0x77,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4e,
+	0x0a, 0x0f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x5f, 0x77, 0x61, 0x74, 0x65, 0x72, 0x6d, 0x61, 0x72,
+	0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x66, 0x6c, 0x75, 0x78, 0x2e, 0x70,
+	0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x66, 0x74, 0x65, 0x72, 0x57, 0x61, 0x74, 0x65,
+	0x72, 0x6d, 0x61, 0x72, 0x6b, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x48, 0x00, 0x52, 0x0e,
+	0x61, 0x66, 0x74, 0x65, 0x72, 0x57, 0x61, 0x74, 0x65, 0x72, 0x6d, 0x61, 0x72, 0x6b, 0x12, 0x37,
+	0x0a, 0x06, 0x72, 0x65, 0x70, 0x65, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d,
+	0x2e, 0x66, 0x6c, 0x75, 0x78, 0x2e, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x48, 0x00, 0x52,
+	0x06, 0x72, 0x65, 0x70, 0x65, 0x61, 0x74, 0x12, 0x5e, 0x0a, 0x15, 0x61, 0x66, 0x74, 0x65, 0x72,
+	0x5f, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x66, 0x6c, 0x75, 0x78, 0x2e, 0x70, 0x6c,
+	0x61, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x66, 0x74, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x63, 0x65,
+	0x73, 0x73, 0x69, 0x6e, 0x67, 0x54, 0x69, 0x6d, 0x65, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72,
+	0x48, 0x00, 0x52, 0x13, 0x61, 0x66, 0x74, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73,
+	0x69, 0x6e, 0x67, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x59, 0x0a, 0x14, 0x61, 0x66, 0x74, 0x65, 0x72,
+	0x5f, 0x61, 0x74, 0x5f, 0x6c, 0x65, 0x61, 0x73, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x66, 0x6c, 0x75, 0x78, 0x2e, 0x70, 0x6c, 0x61,
+	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x66, 0x74, 0x65, 0x72, 0x41, 0x74, 0x4c, 0x65, 0x61, 0x73,
+	0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x48, 0x00, 0x52,
+	0x11, 0x61, 0x66, 0x74, 0x65, 0x72, 0x41, 0x74, 0x4c, 0x65, 0x61, 0x73, 0x74, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x3f, 0x0a, 0x0a, 0x6f, 0x72, 0x5f, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x6c, 0x79,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x66, 0x6c, 0x75, 0x78, 0x2e, 0x70, 0x6c,
+	0x61, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x6c, 0x79, 0x54,
+	0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x48, 0x00, 0x52, 0x09, 0x6f, 0x72, 0x46, 0x69, 0x6e, 0x61,
+	0x6c, 0x6c, 0x79, 0x42, 0x09, 0x0a, 0x07, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x22, 0x1d,
+	0x0a, 0x1b, 0x4e, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72,
+	0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x22, 0x5a, 0x0a,
+	0x15, 0x41, 0x66, 0x74, 0x65, 0x72, 0x57, 0x61, 0x74, 0x65, 0x72, 0x6d, 0x61, 0x72, 0x6b, 0x54,
+	0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x12, 0x41, 0x0a, 0x10, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65,
+	0x64, 0x5f, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x66, 0x6c, 0x75, 0x78, 0x2e, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65,
+	0x64, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x65, 0x73, 0x73, 0x22, 0x42, 0x0a, 0x0f, 0x52, 0x65, 0x70,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x12, 0x2f, 0x0a, 0x07,
+	0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x66, 0x6c, 0x75, 0x78, 0x2e, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x69,
+	0x67, 0x67, 0x65, 0x72, 0x52, 0x07, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x22, 0x50, 0x0a,
+	0x1a, 0x41, 0x66, 0x74, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x69, 0x6e, 0x67,
+	0x54, 0x69, 0x6d, 0x65, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x12, 0x32, 0x0a, 0x08, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x66, 0x6c, 0x75, 0x78, 0x2e, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22,
+	0x30, 0x0a, 0x18, 0x41, 0x66, 0x74, 0x65, 0x72, 0x41, 0x74, 0x4c, 0x65, 0x61, 0x73, 0x74, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x22, 0x6e, 0x0a, 0x10, 0x4f, 0x72, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x6c, 0x79, 0x54, 0x72,
+	0x69, 0x67, 0x67, 0x65, 0x72, 0x12, 0x29, 0x0a, 0x04, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x66, 0x6c, 0x75, 0x78, 0x2e, 0x70, 0x6c, 0x61, 0x6e, 0x2e,
+	0x76, 0x31, 0x2e, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x52, 0x04, 0x6d, 0x61, 0x69, 0x6e,
+	0x12, 0x2f, 0x0a, 0x07, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x6c, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x66, 0x6c, 0x75, 0x78, 0x2e, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31,
+	0x2e, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x52, 0x07, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x6c,
+	0x79, 0x22, 0xfa, 0x01, 0x0a, 0x11, 0x50, 0x68, 0x79, 0x73, 0x69, 0x63, 0x61, 0x6c, 0x41, 0x74,
+	0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x12, 0x40, 0x0a, 0x09, 0x63, 0x6f, 0x6c, 0x6c, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x66, 0x6c, 0x75,
+	0x78, 0x2e, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x48, 0x00, 0x52, 0x09,
+	0x63, 0x6f, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x47, 0x0a, 0x0c, 0x70, 0x61, 0x72,
+	0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x5f, 0x72, 0x75, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x22, 0x2e, 0x66, 0x6c, 0x75, 0x78, 0x2e, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x52, 0x75, 0x6e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62,
+	0x75, 0x74, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x70, 0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x52,
+	0x75, 0x6e, 0x12, 0x4d, 0x0a, 0x0e, 0x70, 0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x5f, 0x6d,
+	0x65, 0x72, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x66, 0x6c, 0x75,
+	0x78, 0x2e, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6c, 0x6c,
+	0x65, 0x6c, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65,
+	0x48, 0x00, 0x52, 0x0d, 0x70, 0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x4d, 0x65, 0x72, 0x67,
+	0x65, 0x42, 0x0b, 0x0a, 0x09, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x22, 0x42,
+	0x0a, 0x12, 0x43, 0x6f, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x74, 0x74, 0x72, 0x69,
+	0x62, 0x75, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x64, 0x65, 0x73, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x64, 0x65,
+	0x73, 0x63, 0x22, 0x2e, 0x0a, 0x14, 0x50, 0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x52, 0x75,
+	0x6e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61,
+	0x63, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x66, 0x61, 0x63, 0x74,
+	0x6f, 0x72, 0x22, 0x30, 0x0a, 0x16, 0x50, 0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x4d, 0x65,
+	0x72, 0x67, 0x65, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x66, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x66, 0x61,
+	0x63, 0x74, 0x6f, 0x72, 0x42, 0x28, 0x5a, 0x26, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x78, 0x64, 0x61, 0x74, 0x61, 0x2f, 0x66, 0x6c,
+	0x75, 0x78, 0x2f, 0x70, 0x6c, 0x61, 0x6e, 0x2f, 0x70, 0x6c, 0x61, 0x6e, 0x70, 0x62, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_plan_planpb_plan_proto_rawDescOnce sync.Once
+	file_plan_planpb_plan_proto_rawDescData = file_plan_planpb_plan_proto_rawDesc
+)
+
+func file_plan_planpb_plan_proto_rawDescGZIP() []byte {
+	file_plan_planpb_plan_proto_rawDescOnce.Do(func() {
+		file_plan_planpb_plan_proto_rawDescData = protoimpl.X.CompressGZIP(file_plan_planpb_plan_proto_rawDescData)
+	})
+	return file_plan_planpb_plan_proto_rawDescData
+}
+
+var file_plan_planpb_plan_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_plan_planpb_plan_proto_goTypes = []interface{}{
+	(*Spec)(nil),                        // 0: flux.plan.v1.Spec
+	(*Edge)(nil),                        // 1: flux.plan.v1.Edge
+	(*Node)(nil),                        // 2: flux.plan.v1.Node
+	(*Bounds)(nil),                      // 3: flux.plan.v1.Bounds
+	(*Resources)(nil),                   // 4: flux.plan.v1.Resources
+	(*Duration)(nil),                    // 5: flux.plan.v1.Duration
+	(*Trigger)(nil),                     // 6: flux.plan.v1.Trigger
+	(*NarrowTransformationTrigger)(nil), // 7: flux.plan.v1.NarrowTransformationTrigger
+	(*AfterWatermarkTrigger)(nil),       // 8: flux.plan.v1.AfterWatermarkTrigger
+	(*RepeatedTrigger)(nil),             // 9: flux.plan.v1.RepeatedTrigger
+	(*AfterProcessingTimeTrigger)(nil),  // 10: flux.plan.v1.AfterProcessingTimeTrigger
+	(*AfterAtLeastCountTrigger)(nil),    // 11: flux.plan.v1.AfterAtLeastCountTrigger
+	(*OrFinallyTrigger)(nil),            // 12: flux.plan.v1.OrFinallyTrigger
+	(*PhysicalAttribute)(nil),           // 13: flux.plan.v1.PhysicalAttribute
+	(*CollationAttribute)(nil),          // 14: flux.plan.v1.CollationAttribute
+	(*ParallelRunAttribute)(nil),        // 15: flux.plan.v1.ParallelRunAttribute
+	(*ParallelMergeAttribute)(nil),      // 16: flux.plan.v1.ParallelMergeAttribute
+	(*timestamppb.Timestamp)(nil),       // 17: google.protobuf.Timestamp
+	(*anypb.Any)(nil),                   // 18: google.protobuf.Any
+}
+var file_plan_planpb_plan_proto_depIdxs = []int32{
+	2,  // 0: flux.plan.v1.Spec.nodes:type_name -> flux.plan.v1.Node
+	1,  // 1: flux.plan.v1.Spec.edges:type_name -> flux.plan.v1.Edge
+	4,  // 2: flux.plan.v1.Spec.resources:type_name -> flux.plan.v1.Resources
+	17, // 3: flux.plan.v1.Spec.now:type_name -> google.protobuf.Timestamp
+	18, // 4: flux.plan.v1.Node.spec:type_name -> google.protobuf.Any
+	3,  // 5: flux.plan.v1.Node.bounds:type_name -> flux.plan.v1.Bounds
+	6,  // 6: flux.plan.v1.Node.trigger:type_name -> flux.plan.v1.Trigger
+	13, // 7: flux.plan.v1.Node.output_attributes:type_name -> flux.plan.v1.PhysicalAttribute
+	7,  // 8: flux.plan.v1.Trigger.narrow_transformation:type_name -> flux.plan.v1.NarrowTransformationTrigger
+	8,  // 9: flux.plan.v1.Trigger.after_watermark:type_name -> flux.plan.v1.AfterWatermarkTrigger
+	9,  // 10: flux.plan.v1.Trigger.repeat:type_name -> flux.plan.v1.RepeatedTrigger
+	10, // 11: flux.plan.v1.Trigger.after_processing_time:type_name -> flux.plan.v1.AfterProcessingTimeTrigger
+	11, // 12: flux.plan.v1.Trigger.after_at_least_count:type_name -> flux.plan.v1.AfterAtLeastCountTrigger
+	12, // 13: flux.plan.v1.Trigger.or_finally:type_name -> flux.plan.v1.OrFinallyTrigger
+	5,  // 14: flux.plan.v1.AfterWatermarkTrigger.allowed_lateness:type_name -> flux.plan.v1.Duration
+	6,  // 15: flux.plan.v1.RepeatedTrigger.trigger:type_name -> flux.plan.v1.Trigger
+	5,  // 16: flux.plan.v1.AfterProcessingTimeTrigger.duration:type_name -> flux.plan.v1.Duration
+	6,  // 17: flux.plan.v1.OrFinallyTrigger.main:type_name -> flux.plan.v1.Trigger
+	6,  // 18: flux.plan.v1.OrFinallyTrigger.finally:type_name -> flux.plan.v1.Trigger
+	14, // 19: flux.plan.v1.PhysicalAttribute.collation:type_name -> flux.plan.v1.CollationAttribute
+	15, // 20: flux.plan.v1.PhysicalAttribute.parallel_run:type_name -> flux.plan.v1.ParallelRunAttribute
+	16, // 21: flux.plan.v1.PhysicalAttribute.parallel_merge:type_name -> flux.plan.v1.ParallelMergeAttribute
+	22, // [22:22] is the sub-list for method output_type
+	22, // [22:22] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
+}
+
+func init() { file_plan_planpb_plan_proto_init() }
+func file_plan_planpb_plan_proto_init() {
+	if File_plan_planpb_plan_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_plan_planpb_plan_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Spec); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plan_planpb_plan_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Edge); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plan_planpb_plan_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Node); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plan_planpb_plan_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Bounds); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plan_planpb_plan_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Resources); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plan_planpb_plan_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Duration); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plan_planpb_plan_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Trigger); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plan_planpb_plan_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NarrowTransformationTrigger); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plan_planpb_plan_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AfterWatermarkTrigger); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plan_planpb_plan_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RepeatedTrigger); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plan_planpb_plan_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AfterProcessingTimeTrigger); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plan_planpb_plan_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AfterAtLeastCountTrigger); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plan_planpb_plan_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrFinallyTrigger); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plan_planpb_plan_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PhysicalAttribute); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plan_planpb_plan_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CollationAttribute); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plan_planpb_plan_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ParallelRunAttribute); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plan_planpb_plan_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ParallelMergeAttribute); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_plan_planpb_plan_proto_msgTypes[6].OneofWrappers = []interface{}{
+		(*Trigger_NarrowTransformation)(nil),
+		(*Trigger_AfterWatermark)(nil),
+		(*Trigger_Repeat)(nil),
+		(*Trigger_AfterProcessingTime)(nil),
+		(*Trigger_AfterAtLeastCount)(nil),
+		(*Trigger_OrFinally)(nil),
+	}
+	file_plan_planpb_plan_proto_msgTypes[13].OneofWrappers = []interface{}{
+		(*PhysicalAttribute_Collation)(nil),
+		(*PhysicalAttribute_ParallelRun)(nil),
+		(*PhysicalAttribute_ParallelMerge)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_plan_planpb_plan_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_plan_planpb_plan_proto_goTypes,
+		DependencyIndexes: file_plan_planpb_plan_proto_depIdxs,
+		MessageInfos:      file_plan_planpb_plan_proto_msgTypes,
+	}.Build()
+	File_plan_planpb_plan_proto = out.File
+	file_plan_planpb_plan_proto_rawDesc = nil
+	file_plan_planpb_plan_proto_goTypes = nil
+	file_plan_planpb_plan_proto_depIdxs = nil
+}
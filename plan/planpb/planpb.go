@@ -0,0 +1,252 @@
+// Package planpb defines the protobuf encoding of physical query plans.
+//
+// The messages of the encoding are defined in plan.proto and procedures.proto.
+// The field numbers of the messages must not change so plans encoded by one
+// version of flux can be decoded by another.
+//
+// Each procedure spec is encoded with the message registered for its kind
+// with RegisterProcedureSpec. The packages that define procedures register
+// the encoding of their procedure specs.
+package planpb
+
+//go:generate protoc --proto_path=../.. --go_out=../.. --go_opt=paths=source_relative plan/planpb/plan.proto plan/planpb/procedures.proto
+
+import (
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/values"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// MarshalFunc encodes a procedure spec as a protobuf message.
+type MarshalFunc func(spec plan.ProcedureSpec) (proto.Message, error)
+
+// UnmarshalFunc decodes a procedure spec from a message
+// created by the MarshalFunc of the same kind.
+type UnmarshalFunc func(msg proto.Message) (plan.PhysicalProcedureSpec, error)
+
+type procedureCodec struct {
+	marshal   MarshalFunc
+	unmarshal UnmarshalFunc
+}
+
+var procedureCodecs = make(map[plan.ProcedureKind]procedureCodec)
+
+// RegisterProcedureSpec registers the functions that encode and decode
+// the procedure specs of the kind. The messages returned by marshal
+// must be registered with the protobuf registry, which generated
+// messages are.
+func RegisterProcedureSpec(kind plan.ProcedureKind, marshal MarshalFunc, unmarshal UnmarshalFunc) {
+	if _, ok := procedureCodecs[kind]; ok {
+		panic(fmt.Errorf("duplicate registration for protobuf procedure spec %v", kind))
+	}
+	procedureCodecs[kind] = procedureCodec{
+		marshal:   marshal,
+		unmarshal: unmarshal,
+	}
+}
+
+// Marshal encodes the physical plan.
+func Marshal(spec *plan.Spec) ([]byte, error) {
+	pb, err := MarshalSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(pb)
+}
+
+// Unmarshal decodes a physical plan encoded by Marshal.
+func Unmarshal(data []byte) (*plan.Spec, error) {
+	var pb Spec
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return nil, errors.Wrap(err, codes.Invalid, "invalid protobuf plan")
+	}
+	return UnmarshalSpec(&pb)
+}
+
+// MarshalSpec converts the physical plan to its protobuf message.
+//
+// It returns an error with the Unimplemented code if the procedure spec
+// of one of the nodes has no registered encoding.
+func MarshalSpec(spec *plan.Spec) (*Spec, error) {
+	var nodes []plan.Node
+	if err := spec.BottomUpWalk(func(node plan.Node) error {
+		nodes = append(nodes, node)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return marshalNodes(spec, nodes)
+}
+
+// marshalNodes encodes the nodes with the resources of the spec.
+// Each node must come after all of its predecessors.
+func marshalNodes(spec *plan.Spec, nodes []plan.Node) (*Spec, error) {
+	pb := &Spec{
+		Resources: &Resources{
+			ConcurrencyQuota:     int64(spec.Resources.ConcurrencyQuota),
+			MemoryBytesQuota:     spec.Resources.MemoryBytesQuota,
+			MemoryBytesSoftQuota: spec.Resources.MemoryBytesSoftQuota,
+			MaxOutputRows:        spec.Resources.MaxOutputRows,
+			MaxOutputTables:      spec.Resources.MaxOutputTables,
+			MaxGroupRows:         spec.Resources.MaxGroupRows,
+		},
+		Now: timestamppb.New(spec.Now),
+	}
+	indices := make(map[plan.Node]int32, len(nodes))
+	for _, node := range nodes {
+		n, err := marshalNode(node)
+		if err != nil {
+			return nil, err
+		}
+		idx := int32(len(pb.Nodes))
+		for _, pred := range node.Predecessors() {
+			pb.Edges = append(pb.Edges, &Edge{
+				Predecessor: indices[pred],
+				Successor:   idx,
+			})
+		}
+		indices[node] = idx
+		pb.Nodes = append(pb.Nodes, n)
+	}
+	return pb, nil
+}
+
+func marshalNode(node plan.Node) (*Node, error) {
+	ppn, ok := node.(*plan.PhysicalPlanNode)
+	if !ok {
+		return nil, errors.Newf(codes.Invalid, "plan node %q is not a physical plan node", node.ID())
+	}
+	kind := ppn.Kind()
+	c, ok := procedureCodecs[kind]
+	if !ok {
+		return nil, errors.Newf(codes.Unimplemented, "procedure kind %q has no protobuf encoding", kind)
+	}
+	msg, err := c.marshal(ppn.Spec)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := anypb.New(msg)
+	if err != nil {
+		return nil, errors.Wrapf(err, codes.Internal, "failed to encode plan node %q", ppn.ID())
+	}
+
+	n := &Node{
+		Id:   string(ppn.ID()),
+		Kind: string(kind),
+		Spec: spec,
+	}
+	if b := ppn.Bounds(); b != nil {
+		n.Bounds = &Bounds{
+			Start: int64(b.Start),
+			Stop:  int64(b.Stop),
+		}
+	}
+	if ppn.TriggerSpec != nil {
+		if n.Trigger, err = MarshalTrigger(ppn.TriggerSpec); err != nil {
+			return nil, err
+		}
+	}
+	if oa, ok := ppn.Spec.(plan.OutputAttributer); ok {
+		for _, attr := range oa.OutputAttributes() {
+			a, err := MarshalAttribute(attr)
+			if err != nil {
+				// Attributes are only informational so the
+				// attributes without an encoding are left out.
+				continue
+			}
+			n.OutputAttributes = append(n.OutputAttributes, a)
+		}
+	}
+	return n, nil
+}
+
+// UnmarshalSpec converts the protobuf message to a physical plan.
+//
+// The output attributes of the nodes are ignored. They are derived
+// from the procedure specs of the decoded nodes.
+func UnmarshalSpec(pb *Spec) (*plan.Spec, error) {
+	if len(pb.Nodes) == 0 {
+		return nil, errors.New(codes.Invalid, "protobuf plan has no nodes")
+	}
+	nodes := make([]*plan.PhysicalPlanNode, len(pb.Nodes))
+	for i, n := range pb.Nodes {
+		node, err := unmarshalNode(n)
+		if err != nil {
+			return nil, err
+		}
+		nodes[i] = node
+	}
+	for _, e := range pb.Edges {
+		p, s := int(e.Predecessor), int(e.Successor)
+		if p < 0 || p >= len(nodes) || s < 0 || s >= len(nodes) {
+			return nil, errors.Newf(codes.Invalid, "protobuf plan edge %d -> %d is out of range", p, s)
+		}
+		pred, succ := nodes[p], nodes[s]
+		pred.AddSuccessors(succ)
+		succ.AddPredecessors(pred)
+	}
+
+	spec := plan.NewPlanSpec()
+	if r := pb.Resources; r != nil {
+		spec.Resources = flux.ResourceManagement{
+			ConcurrencyQuota:     int(r.ConcurrencyQuota),
+			MemoryBytesQuota:     r.MemoryBytesQuota,
+			MemoryBytesSoftQuota: r.MemoryBytesSoftQuota,
+			MaxOutputRows:        r.MaxOutputRows,
+			MaxOutputTables:      r.MaxOutputTables,
+			MaxGroupRows:         r.MaxGroupRows,
+		}
+	}
+	if pb.Now != nil {
+		spec.Now = pb.Now.AsTime()
+	}
+	for _, n := range nodes {
+		if len(n.Successors()) == 0 {
+			spec.Roots[n] = struct{}{}
+		}
+	}
+	if err := spec.CheckIntegrity(); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func unmarshalNode(n *Node) (*plan.PhysicalPlanNode, error) {
+	kind := plan.ProcedureKind(n.Kind)
+	c, ok := procedureCodecs[kind]
+	if !ok {
+		return nil, errors.Newf(codes.Unimplemented, "procedure kind %q has no protobuf encoding", kind)
+	}
+	if n.Spec == nil {
+		return nil, errors.Newf(codes.Invalid, "plan node %q has no procedure spec", n.Id)
+	}
+	msg, err := n.Spec.UnmarshalNew()
+	if err != nil {
+		return nil, errors.Wrapf(err, codes.Invalid, "failed to decode plan node %q", n.Id)
+	}
+	spec, err := c.unmarshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	node := plan.CreatePhysicalNode(plan.NodeID(n.Id), spec)
+	if b := n.Bounds; b != nil {
+		node.SetBounds(&plan.Bounds{
+			Start: values.Time(b.Start),
+			Stop:  values.Time(b.Stop),
+		})
+	}
+	if n.Trigger != nil {
+		if node.TriggerSpec, err = UnmarshalTrigger(n.Trigger); err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
+}
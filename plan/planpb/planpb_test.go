@@ -0,0 +1,186 @@
+package planpb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/plan/planpb"
+	"github.com/influxdata/flux/values"
+	"google.golang.org/protobuf/proto"
+)
+
+const testKind = "planpbtest"
+
+func init() {
+	planpb.RegisterProcedureSpec(testKind,
+		func(spec plan.ProcedureSpec) (proto.Message, error) {
+			s := spec.(*testSpec)
+			return &planpb.LimitProcedureSpec{N: s.N}, nil
+		},
+		func(msg proto.Message) (plan.PhysicalProcedureSpec, error) {
+			pb := msg.(*planpb.LimitProcedureSpec)
+			return &testSpec{N: pb.N}, nil
+		},
+	)
+}
+
+type testSpec struct {
+	plan.DefaultCost
+	N int64
+}
+
+func (s *testSpec) Kind() plan.ProcedureKind { return testKind }
+func (s *testSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+func (s *testSpec) OutputAttributes() plan.PhysicalAttributes {
+	return plan.PhysicalAttributes{
+		plan.CollationKey: &plan.CollationAttr{Columns: []string{"_time"}},
+	}
+}
+
+type untypedSpec struct {
+	plan.DefaultCost
+}
+
+func (s *untypedSpec) Kind() plan.ProcedureKind { return "planpbtest.untyped" }
+func (s *untypedSpec) Copy() plan.ProcedureSpec { return &untypedSpec{} }
+
+func TestMarshal(t *testing.T) {
+	a := plan.CreatePhysicalNode("a", &testSpec{N: 1})
+	a.SetBounds(&plan.Bounds{Start: 10, Stop: 20})
+	b := plan.CreatePhysicalNode("b", &testSpec{N: 2})
+	c := plan.CreatePhysicalNode("c", &testSpec{N: 3})
+	c.TriggerSpec = plan.OrFinallyTriggerSpec{
+		Main: plan.RepeatedTriggerSpec{
+			Trigger: plan.AfterProcessingTimeTriggerSpec{Duration: flux.ConvertDuration(time.Second)},
+		},
+		Finally: plan.AfterWatermarkTriggerSpec{},
+	}
+	for _, pred := range []*plan.PhysicalPlanNode{a, b} {
+		pred.AddSuccessors(c)
+		c.AddPredecessors(pred)
+	}
+
+	spec := plan.NewPlanSpec()
+	spec.Roots[c] = struct{}{}
+	spec.Now = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	spec.Resources = flux.ResourceManagement{
+		ConcurrencyQuota: 2,
+		MemoryBytesQuota: 1024,
+		MaxOutputRows:    100,
+	}
+
+	data, err := planpb.Marshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := planpb.Unmarshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got.Roots) != 1 {
+		t.Fatalf("expected a single root, got %d", len(got.Roots))
+	}
+	var root *plan.PhysicalPlanNode
+	for n := range got.Roots {
+		root = n.(*plan.PhysicalPlanNode)
+	}
+	if want, got := plan.NodeID("c"), root.ID(); want != got {
+		t.Errorf("unexpected root -want/+got:\n\t- %s\n\t+ %s", want, got)
+	}
+	if !cmp.Equal(c.Spec, root.Spec) {
+		t.Errorf("unexpected root spec -want/+got:\n%s", cmp.Diff(c.Spec, root.Spec))
+	}
+	if !cmp.Equal(c.TriggerSpec, root.TriggerSpec) {
+		t.Errorf("unexpected trigger spec -want/+got:\n%s", cmp.Diff(c.TriggerSpec, root.TriggerSpec))
+	}
+
+	preds := root.Predecessors()
+	if len(preds) != 2 {
+		t.Fatalf("expected 2 predecessors, got %d", len(preds))
+	}
+	if want, got := []plan.NodeID{"a", "b"}, []plan.NodeID{preds[0].ID(), preds[1].ID()}; !cmp.Equal(want, got) {
+		t.Errorf("unexpected predecessors -want/+got:\n%s", cmp.Diff(want, got))
+	}
+	if want, got := a.Bounds(), preds[0].Bounds(); !cmp.Equal(want, got) {
+		t.Errorf("unexpected bounds -want/+got:\n%s", cmp.Diff(want, got))
+	}
+	if got := preds[1].Bounds(); got != nil {
+		t.Errorf("expected no bounds, got %v", got)
+	}
+
+	if !spec.Now.Equal(got.Now) {
+		t.Errorf("unexpected now -want/+got:\n\t- %v\n\t+ %v", spec.Now, got.Now)
+	}
+	if !cmp.Equal(spec.Resources, got.Resources) {
+		t.Errorf("unexpected resources -want/+got:\n%s", cmp.Diff(spec.Resources, got.Resources))
+	}
+}
+
+func TestMarshalSpec_OutputAttributes(t *testing.T) {
+	spec := plan.NewPlanSpec()
+	spec.Roots[plan.CreatePhysicalNode("a", &testSpec{})] = struct{}{}
+
+	pb, err := planpb.MarshalSpec(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pb.Nodes) != 1 || len(pb.Nodes[0].OutputAttributes) != 1 {
+		t.Fatalf("expected one node with one output attribute, got %v", pb.Nodes)
+	}
+	got, err := planpb.UnmarshalAttribute(pb.Nodes[0].OutputAttributes[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &plan.CollationAttr{Columns: []string{"_time"}}
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected attribute -want/+got:\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestMarshalSpec_Unimplemented(t *testing.T) {
+	spec := plan.NewPlanSpec()
+	spec.Roots[plan.CreatePhysicalNode("a", &untypedSpec{})] = struct{}{}
+
+	_, err := planpb.MarshalSpec(spec)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if want, got := codes.Unimplemented, errors.Code(err); want != got {
+		t.Errorf("unexpected error code -want/+got:\n\t- %v\n\t+ %v", want, got)
+	}
+}
+
+func TestTimeBounds(t *testing.T) {
+	for _, b := range []flux.Bounds{
+		{
+			Start: flux.Time{IsRelative: true, Relative: -time.Hour},
+			Stop:  flux.Now,
+		},
+		{
+			Start: flux.Time{Absolute: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+			Stop:  flux.Time{Absolute: time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)},
+			Now:   time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC),
+		},
+		{},
+	} {
+		if got := planpb.NewTimeBounds(b).AsBounds(); !cmp.Equal(b, got) {
+			t.Errorf("unexpected bounds -want/+got:\n%s", cmp.Diff(b, got))
+		}
+	}
+}
+
+func TestDuration(t *testing.T) {
+	d := values.MakeDuration(5, 2, true)
+	if got := planpb.NewDuration(d).AsDuration(); !d.Equal(got) {
+		t.Errorf("unexpected duration -want/+got:\n\t- %v\n\t+ %v", d, got)
+	}
+}
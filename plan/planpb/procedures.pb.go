@@ -0,0 +1,1158 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.19.4
+// source: plan/planpb/procedures.proto
+
+package planpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// GroupMode is the mode used to group tables.
+type GroupMode int32
+
+const (
+	GroupMode_GROUP_MODE_NONE   GroupMode = 0
+	GroupMode_GROUP_MODE_BY     GroupMode = 1
+	GroupMode_GROUP_MODE_EXCEPT GroupMode = 2
+)
+
+// Enum value maps for GroupMode.
+var (
+	GroupMode_name = map[int32]string{
+		0: "GROUP_MODE_NONE",
+		1: "GROUP_MODE_BY",
+		2: "GROUP_MODE_EXCEPT",
+	}
+	GroupMode_value = map[string]int32{
+		"GROUP_MODE_NONE":   0,
+		"GROUP_MODE_BY":     1,
+		"GROUP_MODE_EXCEPT": 2,
+	}
+)
+
+func (x GroupMode) Enum() *GroupMode {
+	p := new(GroupMode)
+	*p = x
+	return p
+}
+
+func (x GroupMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GroupMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_plan_planpb_procedures_proto_enumTypes[0].Descriptor()
+}
+
+func (GroupMode) Type() protoreflect.EnumType {
+	return &file_plan_planpb_procedures_proto_enumTypes[0]
+}
+
+func (x GroupMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GroupMode.Descriptor instead.
+func (GroupMode) EnumDescriptor() ([]byte, []int) {
+	return file_plan_planpb_procedures_proto_rawDescGZIP(), []int{0}
+}
+
+// Time is a point in time that is either absolute or relative to now.
+// A time with neither value set is the zero time.
+type Time struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Time:
+	//	*Time_Absolute
+	//	*Time_Relative
+	Time isTime_Time `protobuf_oneof:"time"`
+}
+
+func (x *Time) Reset() {
+	*x = Time{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plan_planpb_procedures_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Time) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Time) ProtoMessage() {}
+
+func (x *Time) ProtoReflect() protoreflect.Message {
+	mi := &file_plan_planpb_procedures_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Time.ProtoReflect.Descriptor instead.
+func (*Time) Descriptor() ([]byte, []int) {
+	return file_plan_planpb_procedures_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *Time) GetTime() isTime_Time {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (x *Time) GetAbsolute() *timestamppb.Timestamp {
+	if x, ok := x.GetTime().(*Time_Absolute); ok {
+		return x.Absolute
+	}
+	return nil
+}
+
+func (x *Time) GetRelative() int64 {
+	if x, ok := x.GetTime().(*Time_Relative); ok {
+		return x.Relative
+	}
+	return 0
+}
+
+type isTime_Time interface {
+	isTime_Time()
+}
+
+type Time_Absolute struct {
+	Absolute *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=absolute,proto3,oneof"`
+}
+
+type Time_Relative struct {
+	// Relative is the offset from now in nanoseconds.
+	Relative int64 `protobuf:"varint,2,opt,name=relative,proto3,oneof"`
+}
+
+func (*Time_Absolute) isTime_Time() {}
+
+func (*Time_Relative) isTime_Time() {}
+
+// TimeBounds are the bounds of a range.
+type TimeBounds struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start *Time                  `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	Stop  *Time                  `protobuf:"bytes,2,opt,name=stop,proto3" json:"stop,omitempty"`
+	Now   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=now,proto3" json:"now,omitempty"`
+}
+
+func (x *TimeBounds) Reset() {
+	*x = TimeBounds{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plan_planpb_procedures_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TimeBounds) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeBounds) ProtoMessage() {}
+
+func (x *TimeBounds) ProtoReflect() protoreflect.Message {
+	mi := &file_plan_planpb_procedures_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeBounds.ProtoReflect.Descriptor instead.
+func (*TimeBounds) Descriptor() ([]byte, []int) {
+	return file_plan_planpb_procedures_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TimeBounds) GetStart() *Time {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *TimeBounds) GetStop() *Time {
+	if x != nil {
+		return x.Stop
+	}
+	return nil
+}
+
+func (x *TimeBounds) GetNow() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Now
+	}
+	return nil
+}
+
+// Location is the location used to compute the boundaries of windows.
+type Location struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Offset *Duration `protobuf:"bytes,2,opt,name=offset,proto3" json:"offset,omitempty"`
+}
+
+func (x *Location) Reset() {
+	*x = Location{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plan_planpb_procedures_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Location) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Location) ProtoMessage() {}
+
+func (x *Location) ProtoReflect() protoreflect.Message {
+	mi := &file_plan_planpb_procedures_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Location.ProtoReflect.Descriptor instead.
+func (*Location) Descriptor() ([]byte, []int) {
+	return file_plan_planpb_procedures_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Location) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Location) GetOffset() *Duration {
+	if x != nil {
+		return x.Offset
+	}
+	return nil
+}
+
+// RangeProcedureSpec is the spec of range.
+type RangeProcedureSpec struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Bounds      *TimeBounds `protobuf:"bytes,1,opt,name=bounds,proto3" json:"bounds,omitempty"`
+	TimeColumn  string      `protobuf:"bytes,2,opt,name=time_column,json=timeColumn,proto3" json:"time_column,omitempty"`
+	StartColumn string      `protobuf:"bytes,3,opt,name=start_column,json=startColumn,proto3" json:"start_column,omitempty"`
+	StopColumn  string      `protobuf:"bytes,4,opt,name=stop_column,json=stopColumn,proto3" json:"stop_column,omitempty"`
+}
+
+func (x *RangeProcedureSpec) Reset() {
+	*x = RangeProcedureSpec{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plan_planpb_procedures_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RangeProcedureSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RangeProcedureSpec) ProtoMessage() {}
+
+func (x *RangeProcedureSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_plan_planpb_procedures_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RangeProcedureSpec.ProtoReflect.Descriptor instead.
+func (*RangeProcedureSpec) Descriptor() ([]byte, []int) {
+	return file_plan_planpb_procedures_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RangeProcedureSpec) GetBounds() *TimeBounds {
+	if x != nil {
+		return x.Bounds
+	}
+	return nil
+}
+
+func (x *RangeProcedureSpec) GetTimeColumn() string {
+	if x != nil {
+		return x.TimeColumn
+	}
+	return ""
+}
+
+func (x *RangeProcedureSpec) GetStartColumn() string {
+	if x != nil {
+		return x.StartColumn
+	}
+	return ""
+}
+
+func (x *RangeProcedureSpec) GetStopColumn() string {
+	if x != nil {
+		return x.StopColumn
+	}
+	return ""
+}
+
+// LimitProcedureSpec is the spec of limit and tail.
+type LimitProcedureSpec struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	N      int64 `protobuf:"varint,1,opt,name=n,proto3" json:"n,omitempty"`
+	Offset int64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+}
+
+func (x *LimitProcedureSpec) Reset() {
+	*x = LimitProcedureSpec{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plan_planpb_procedures_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LimitProcedureSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LimitProcedureSpec) ProtoMessage() {}
+
+func (x *LimitProcedureSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_plan_planpb_procedures_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LimitProcedureSpec.ProtoReflect.Descriptor instead.
+func (*LimitProcedureSpec) Descriptor() ([]byte, []int) {
+	return file_plan_planpb_procedures_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LimitProcedureSpec) GetN() int64 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+func (x *LimitProcedureSpec) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+// GroupProcedureSpec is the spec of group.
+type GroupProcedureSpec struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mode    GroupMode `protobuf:"varint,1,opt,name=mode,proto3,enum=flux.plan.v1.GroupMode" json:"mode,omitempty"`
+	Columns []string  `protobuf:"bytes,2,rep,name=columns,proto3" json:"columns,omitempty"`
+}
+
+func (x *GroupProcedureSpec) Reset() {
+	*x = GroupProcedureSpec{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plan_planpb_procedures_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GroupProcedureSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupProcedureSpec) ProtoMessage() {}
+
+func (x *GroupProcedureSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_plan_planpb_procedures_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupProcedureSpec.ProtoReflect.Descriptor instead.
+func (*GroupProcedureSpec) Descriptor() ([]byte, []int) {
+	return file_plan_planpb_procedures_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GroupProcedureSpec) GetMode() GroupMode {
+	if x != nil {
+		return x.Mode
+	}
+	return GroupMode_GROUP_MODE_NONE
+}
+
+func (x *GroupProcedureSpec) GetColumns() []string {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+// SortProcedureSpec is the spec of sort.
+type SortProcedureSpec struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Columns []string `protobuf:"bytes,1,rep,name=columns,proto3" json:"columns,omitempty"`
+	Desc    bool     `protobuf:"varint,2,opt,name=desc,proto3" json:"desc,omitempty"`
+}
+
+func (x *SortProcedureSpec) Reset() {
+	*x = SortProcedureSpec{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plan_planpb_procedures_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SortProcedureSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SortProcedureSpec) ProtoMessage() {}
+
+func (x *SortProcedureSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_plan_planpb_procedures_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SortProcedureSpec.ProtoReflect.Descriptor instead.
+func (*SortProcedureSpec) Descriptor() ([]byte, []int) {
+	return file_plan_planpb_procedures_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SortProcedureSpec) GetColumns() []string {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *SortProcedureSpec) GetDesc() bool {
+	if x != nil {
+		return x.Desc
+	}
+	return false
+}
+
+// YieldProcedureSpec is the spec of yield.
+type YieldProcedureSpec struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *YieldProcedureSpec) Reset() {
+	*x = YieldProcedureSpec{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plan_planpb_procedures_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *YieldProcedureSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*YieldProcedureSpec) ProtoMessage() {}
+
+func (x *YieldProcedureSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_plan_planpb_procedures_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use YieldProcedureSpec.ProtoReflect.Descriptor instead.
+func (*YieldProcedureSpec) Descriptor() ([]byte, []int) {
+	return file_plan_planpb_procedures_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *YieldProcedureSpec) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// WindowProcedureSpec is the spec of window.
+type WindowProcedureSpec struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Every       *Duration `protobuf:"bytes,1,opt,name=every,proto3" json:"every,omitempty"`
+	Period      *Duration `protobuf:"bytes,2,opt,name=period,proto3" json:"period,omitempty"`
+	Offset      *Duration `protobuf:"bytes,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Location    *Location `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	TimeColumn  string    `protobuf:"bytes,5,opt,name=time_column,json=timeColumn,proto3" json:"time_column,omitempty"`
+	StartColumn string    `protobuf:"bytes,6,opt,name=start_column,json=startColumn,proto3" json:"start_column,omitempty"`
+	StopColumn  string    `protobuf:"bytes,7,opt,name=stop_column,json=stopColumn,proto3" json:"stop_column,omitempty"`
+	CreateEmpty bool      `protobuf:"varint,8,opt,name=create_empty,json=createEmpty,proto3" json:"create_empty,omitempty"`
+}
+
+func (x *WindowProcedureSpec) Reset() {
+	*x = WindowProcedureSpec{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plan_planpb_procedures_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WindowProcedureSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WindowProcedureSpec) ProtoMessage() {}
+
+func (x *WindowProcedureSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_plan_planpb_procedures_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WindowProcedureSpec.ProtoReflect.Descriptor instead.
+func (*WindowProcedureSpec) Descriptor() ([]byte, []int) {
+	return file_plan_planpb_procedures_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WindowProcedureSpec) GetEvery() *Duration {
+	if x != nil {
+		return x.Every
+	}
+	return nil
+}
+
+func (x *WindowProcedureSpec) GetPeriod() *Duration {
+	if x != nil {
+		return x.Period
+	}
+	return nil
+}
+
+func (x *WindowProcedureSpec) GetOffset() *Duration {
+	if x != nil {
+		return x.Offset
+	}
+	return nil
+}
+
+func (x *WindowProcedureSpec) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *WindowProcedureSpec) GetTimeColumn() string {
+	if x != nil {
+		return x.TimeColumn
+	}
+	return ""
+}
+
+func (x *WindowProcedureSpec) GetStartColumn() string {
+	if x != nil {
+		return x.StartColumn
+	}
+	return ""
+}
+
+func (x *WindowProcedureSpec) GetStopColumn() string {
+	if x != nil {
+		return x.StopColumn
+	}
+	return ""
+}
+
+func (x *WindowProcedureSpec) GetCreateEmpty() bool {
+	if x != nil {
+		return x.CreateEmpty
+	}
+	return false
+}
+
+// SelectorProcedureSpec is the spec of the selectors
+// first, last, min and max.
+type SelectorProcedureSpec struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Column   string `protobuf:"bytes,1,opt,name=column,proto3" json:"column,omitempty"`
+	NullMode string `protobuf:"bytes,2,opt,name=null_mode,json=nullMode,proto3" json:"null_mode,omitempty"`
+}
+
+func (x *SelectorProcedureSpec) Reset() {
+	*x = SelectorProcedureSpec{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plan_planpb_procedures_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SelectorProcedureSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelectorProcedureSpec) ProtoMessage() {}
+
+func (x *SelectorProcedureSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_plan_planpb_procedures_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelectorProcedureSpec.ProtoReflect.Descriptor instead.
+func (*SelectorProcedureSpec) Descriptor() ([]byte, []int) {
+	return file_plan_planpb_procedures_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SelectorProcedureSpec) GetColumn() string {
+	if x != nil {
+		return x.Column
+	}
+	return ""
+}
+
+func (x *SelectorProcedureSpec) GetNullMode() string {
+	if x != nil {
+		return x.NullMode
+	}
+	return ""
+}
+
+// AggregateProcedureSpec is the spec of the aggregates
+// count, sum and mean.
+type AggregateProcedureSpec struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Columns  []string `protobuf:"bytes,1,rep,name=columns,proto3" json:"columns,omitempty"`
+	NullMode string   `protobuf:"bytes,2,opt,name=null_mode,json=nullMode,proto3" json:"null_mode,omitempty"`
+}
+
+func (x *AggregateProcedureSpec) Reset() {
+	*x = AggregateProcedureSpec{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plan_planpb_procedures_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AggregateProcedureSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregateProcedureSpec) ProtoMessage() {}
+
+func (x *AggregateProcedureSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_plan_planpb_procedures_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregateProcedureSpec.ProtoReflect.Descriptor instead.
+func (*AggregateProcedureSpec) Descriptor() ([]byte, []int) {
+	return file_plan_planpb_procedures_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AggregateProcedureSpec) GetColumns() []string {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *AggregateProcedureSpec) GetNullMode() string {
+	if x != nil {
+		return x.NullMode
+	}
+	return ""
+}
+
+// ColumnProcedureSpec is the spec of distinct and unique.
+type ColumnProcedureSpec struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Column string `protobuf:"bytes,1,opt,name=column,proto3" json:"column,omitempty"`
+}
+
+func (x *ColumnProcedureSpec) Reset() {
+	*x = ColumnProcedureSpec{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plan_planpb_procedures_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ColumnProcedureSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColumnProcedureSpec) ProtoMessage() {}
+
+func (x *ColumnProcedureSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_plan_planpb_procedures_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColumnProcedureSpec.ProtoReflect.Descriptor instead.
+func (*ColumnProcedureSpec) Descriptor() ([]byte, []int) {
+	return file_plan_planpb_procedures_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ColumnProcedureSpec) GetColumn() string {
+	if x != nil {
+		return x.Column
+	}
+	return ""
+}
+
+var File_plan_planpb_procedures_proto protoreflect.FileDescriptor
+
+var file_plan_planpb_procedures_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x70, 0x6c, 0x61, 0x6e, 0x2f, 0x70, 0x6c, 0x61, 0x6e, 0x70, 0x62, 0x2f, 0x70, 0x72,
+	0x6f, 0x63, 0x65, 0x64, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c,
+	0x66, 0x6c, 0x75, 0x78, 0x2e, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x16, 0x70,
+	0x6c, 0x61, 0x6e, 0x2f, 0x70, 0x6c, 0x61, 0x6e, 0x70, 0x62, 0x2f, 0x70, 0x6c, 0x61, 0x6e, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x66, 0x0a, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x38, 0x0a,
+	0x08, 0x61, 0x62, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x48, 0x00, 0x52, 0x08, 0x61,
+	0x62, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x08, 0x72, 0x65, 0x6c, 0x61, 0x74,
+	0x69, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x08, 0x72, 0x65, 0x6c,
+	0x61, 0x74, 0x69, 0x76, 0x65, 0x42, 0x06, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0x8c, 0x01,
+	0x0a, 0x0a, 0x54, 0x69, 0x6d, 0x65, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x12, 0x28, 0x0a, 0x05,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x66, 0x6c,
+	0x75, 0x78, 0x2e, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x52,
+	0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x26, 0x0a, 0x04, 0x73, 0x74, 0x6f, 0x70, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x66, 0x6c, 0x75, 0x78, 0x2e, 0x70, 0x6c, 0x61, 0x6e,
+	0x2e, 0x76, 0x31, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x04, 0x73, 0x74, 0x6f, 0x70, 0x12, 0x2c,
+	0x0a, 0x03, 0x6e, 0x6f, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x03, 0x6e, 0x6f, 0x77, 0x22, 0x4e, 0x0a, 0x08,
+	0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x06,
+	0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x66,
+	0x6c, 0x75, 0x78, 0x2e, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x22, 0xab, 0x01, 0x0a,
+	0x12, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x64, 0x75, 0x72, 0x65, 0x53,
+	0x70, 0x65, 0x63, 0x12, 0x30, 0x0a, 0x06, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x66, 0x6c, 0x75, 0x78, 0x2e, 0x70, 0x6c, 0x61, 0x6e, 0x2e,
+	0x76, 0x31, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x52, 0x06, 0x62,
+	0x6f, 0x75, 0x6e, 0x64, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x63, 0x6f,
+	0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65,
+	0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f,
+	0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x6f,
+	0x70, 0x5f, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x73, 0x74, 0x6f, 0x70, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x22, 0x3a, 0x0a, 0x12, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x64, 0x75, 0x72, 0x65, 0x53, 0x70, 0x65, 0x63,
+	0x12, 0x0c, 0x0a, 0x01, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x01, 0x6e, 0x12, 0x16,
+	0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x22, 0x5b, 0x0a, 0x12, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x50,
+	0x72, 0x6f, 0x63, 0x65, 0x64, 0x75, 0x72, 0x65, 0x53, 0x70, 0x65, 0x63, 0x12, 0x2b, 0x0a, 0x04,
+	0x6d, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x66, 0x6c, 0x75,
+	0x78, 0x2e, 0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d,
+	0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6c,
+	0x75, 0x6d, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6c, 0x75,
+	0x6d, 0x6e, 0x73, 0x22, 0x41, 0x0a, 0x11, 0x53, 0x6f, 0x72, 0x74, 0x50, 0x72, 0x6f, 0x63, 0x65,
+	0x64, 0x75, 0x72, 0x65, 0x53, 0x70, 0x65, 0x63, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6c, 0x75,
+	0x6d, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d,
+	0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x65, 0x73, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x04, 0x64, 0x65, 0x73, 0x63, 0x22, 0x28, 0x0a, 0x12, 0x59, 0x69, 0x65, 0x6c, 0x64, 0x50,
+	0x72, 0x6f, 0x63, 0x65, 0x64, 0x75, 0x72, 0x65, 0x53, 0x70, 0x65, 0x63, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x22, 0xdf, 0x02, 0x0a, 0x13, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x50, 0x72, 0x6f, 0x63, 0x65,
+	0x64, 0x75, 0x72, 0x65, 0x53, 0x70, 0x65, 0x63, 0x12, 0x2c, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x72,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x66, 0x6c, 0x75, 0x78, 0x2e, 0x70,
+	0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x05, 0x65, 0x76, 0x65, 0x72, 0x79, 0x12, 0x2e, 0x0a, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x66, 0x6c, 0x75, 0x78, 0x2e, 0x70, 0x6c,
+	0x61, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06,
+	0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x2e, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x66, 0x6c, 0x75, 0x78, 0x2e, 0x70, 0x6c,
+	0x61, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06,
+	0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x32, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x66, 0x6c, 0x75, 0x78, 0x2e,
+	0x70, 0x6c, 0x61, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x69,
+	0x6d, 0x65, 0x5f, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x74, 0x69, 0x6d, 0x65, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x5f, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x1f,
+	0x0a, 0x0b, 0x73, 0x74, 0x6f, 0x70, 0x5f, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x74, 0x6f, 0x70, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12,
+	0x21, 0x0a, 0x0c, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x22, 0x4c, 0x0a, 0x15, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x50, 0x72,
+	0x6f, 0x63, 0x65, 0x64, 0x75, 0x72, 0x65, 0x53, 0x70, 0x65, 0x63, 0x12, 0x16, 0x0a, 0x06, 0x63,
+	0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x6c,
+	0x75, 0x6d, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x75, 0x6c, 0x6c, 0x5f, 0x6d, 0x6f, 0x64, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x75, 0x6c, 0x6c, 0x4d, 0x6f, 0x64, 0x65,
+	0x22, 0x4f, 0x0a, 0x16, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f,
+	0x63, 0x65, 0x64, 0x75, 0x72, 0x65, 0x53, 0x70, 0x65, 0x63, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f,
+	0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6c,
+	0x75, 0x6d, 0x6e, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x75, 0x6c, 0x6c, 0x5f, 0x6d, 0x6f, 0x64,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x75, 0x6c, 0x6c, 0x4d, 0x6f, 0x64,
+	0x65, 0x22, 0x2d, 0x0a, 0x13, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x50, 0x72, 0x6f, 0x63, 0x65,
+	0x64, 0x75, 0x72, 0x65, 0x53, 0x70, 0x65, 0x63, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x75,
+	0x6d, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e,
+	0x2a, 0x4a, 0x0a, 0x09, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x13, 0x0a,
+	0x0f, 0x47, 0x52, 0x4f, 0x55, 0x50, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x4e, 0x4f, 0x4e, 0x45,
+	0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x47, 0x52, 0x4f, 0x55, 0x50, 0x5f, 0x4d, 0x4f, 0x44, 0x45,
+	0x5f, 0x42, 0x59, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x47, 0x52, 0x4f, 0x55, 0x50, 0x5f, 0x4d,
+	0x4f, 0x44, 0x45, 0x5f, 0x45, 0x58, 0x43, 0x45, 0x50, 0x54, 0x10, 0x02, 0x42, 0x28, 0x5a, 0x26,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x6e, 0x66, 0x6c, 0x75,
+	0x78, 0x64, 0x61, 0x74, 0x61, 0x2f, 0x66, 0x6c, 0x75, 0x78, 0x2f, 0x70, 0x6c, 0x61, 0x6e, 0x2f,
+	0x70, 0x6c, 0x61, 0x6e, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_plan_planpb_procedures_proto_rawDescOnce sync.Once
+	file_plan_planpb_procedures_proto_rawDescData = file_plan_planpb_procedures_proto_rawDesc
+)
+
+func file_plan_planpb_procedures_proto_rawDescGZIP() []byte {
+	file_plan_planpb_procedures_proto_rawDescOnce.Do(func() {
+		file_plan_planpb_procedures_proto_rawDescData = protoimpl.X.CompressGZIP(file_plan_planpb_procedures_proto_rawDescData)
+	})
+	return file_plan_planpb_procedures_proto_rawDescData
+}
+
+var file_plan_planpb_procedures_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_plan_planpb_procedures_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_plan_planpb_procedures_proto_goTypes = []interface{}{
+	(GroupMode)(0),                 // 0: flux.plan.v1.GroupMode
+	(*Time)(nil),                   // 1: flux.plan.v1.Time
+	(*TimeBounds)(nil),             // 2: flux.plan.v1.TimeBounds
+	(*Location)(nil),               // 3: flux.plan.v1.Location
+	(*RangeProcedureSpec)(nil),     // 4: flux.plan.v1.RangeProcedureSpec
+	(*LimitProcedureSpec)(nil),     // 5: flux.plan.v1.LimitProcedureSpec
+	(*GroupProcedureSpec)(nil),     // 6: flux.plan.v1.GroupProcedureSpec
+	(*SortProcedureSpec)(nil),      // 7: flux.plan.v1.SortProcedureSpec
+	(*YieldProcedureSpec)(nil),     // 8: flux.plan.v1.YieldProcedureSpec
+	(*WindowProcedureSpec)(nil),    // 9: flux.plan.v1.WindowProcedureSpec
+	(*SelectorProcedureSpec)(nil),  // 10: flux.plan.v1.SelectorProcedureSpec
+	(*AggregateProcedureSpec)(nil), // 11: flux.plan.v1.AggregateProcedureSpec
+	(*ColumnProcedureSpec)(nil),    // 12: flux.plan.v1.ColumnProcedureSpec
+	(*timestamppb.Timestamp)(nil),  // 13: google.protobuf.Timestamp
+	(*Duration)(nil),               // 14: flux.plan.v1.Duration
+}
+var file_plan_planpb_procedures_proto_depIdxs = []int32{
+	13, // 0: flux.plan.v1.Time.absolute:type_name -> google.protobuf.Timestamp
+	1,  // 1: flux.plan.v1.TimeBounds.start:type_name -> flux.plan.v1.Time
+	1,  // 2: flux.plan.v1.TimeBounds.stop:type_name -> flux.plan.v1.Time
+	13, // 3: flux.plan.v1.TimeBounds.now:type_name -> google.protobuf.Timestamp
+	14, // 4: flux.plan.v1.Location.offset:type_name -> flux.plan.v1.Duration
+	2,  // 5: flux.plan.v1.RangeProcedureSpec.bounds:type_name -> flux.plan.v1.TimeBounds
+	0,  // 6: flux.plan.v1.GroupProcedureSpec.mode:type_name -> flux.plan.v1.GroupMode
+	14, // 7: flux.plan.v1.WindowProcedureSpec.every:type_name -> flux.plan.v1.Duration
+	14, // 8: flux.plan.v1.WindowProcedureSpec.period:type_name -> flux.plan.v1.Duration
+	14, // 9: flux.plan.v1.WindowProcedureSpec.offset:type_name -> flux.plan.v1.Duration
+	3,  // 10: flux.plan.v1.WindowProcedureSpec.location:type_name -> flux.plan.v1.Location
+	11, // [11:11] is the sub-list for method output_type
+	11, // [11:11] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_plan_planpb_procedures_proto_init() }
+func file_plan_planpb_procedures_proto_init() {
+	if File_plan_planpb_procedures_proto != nil {
+		return
+	}
+	file_plan_planpb_plan_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_plan_planpb_procedures_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Time); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plan_planpb_procedures_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TimeBounds); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plan_planpb_procedures_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Location); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plan_planpb_procedures_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RangeProcedureSpec); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plan_planpb_procedures_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LimitProcedureSpec); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plan_planpb_procedures_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GroupProcedureSpec); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plan_planpb_procedures_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SortProcedureSpec); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plan_planpb_procedures_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*YieldProcedureSpec); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plan_planpb_procedures_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WindowProcedureSpec); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plan_planpb_procedures_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SelectorProcedureSpec); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plan_planpb_procedures_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AggregateProcedureSpec); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plan_planpb_procedures_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ColumnProcedureSpec); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_plan_planpb_procedures_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*Time_Absolute)(nil),
+		(*Time_Relative)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_plan_planpb_procedures_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_plan_planpb_procedures_proto_goTypes,
+		DependencyIndexes: file_plan_planpb_procedures_proto_depIdxs,
+		EnumInfos:         file_plan_planpb_procedures_proto_enumTypes,
+		MessageInfos:      file_plan_planpb_procedures_proto_msgTypes,
+	}.Build()
+	File_plan_planpb_procedures_proto = out.File
+	file_plan_planpb_procedures_proto_rawDesc = nil
+	file_plan_planpb_procedures_proto_goTypes = nil
+	file_plan_planpb_procedures_proto_depIdxs = nil
+}
@@ -0,0 +1,245 @@
+package planpb
+
+import (
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/values"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// NewDuration creates the message for the duration.
+func NewDuration(d flux.Duration) *Duration {
+	return &Duration{
+		Months:      d.Months(),
+		Nanoseconds: d.Nanoseconds(),
+		Negative:    d.IsNegative(),
+	}
+}
+
+// AsDuration converts the message to a duration.
+// A nil message is the zero duration.
+func (x *Duration) AsDuration() flux.Duration {
+	if x == nil {
+		return flux.Duration{}
+	}
+	return values.MakeDuration(x.Nanoseconds, x.Months, x.Negative)
+}
+
+// NewTime creates the message for the time.
+func NewTime(t flux.Time) *Time {
+	if t.IsRelative {
+		return &Time{Time: &Time_Relative{Relative: int64(t.Relative)}}
+	}
+	if t.Absolute.IsZero() {
+		return &Time{}
+	}
+	return &Time{Time: &Time_Absolute{Absolute: timestamppb.New(t.Absolute)}}
+}
+
+// AsTime converts the message to a time.
+// A nil message is the zero time.
+func (x *Time) AsTime() flux.Time {
+	switch t := x.GetTime().(type) {
+	case *Time_Relative:
+		return flux.Time{IsRelative: true, Relative: time.Duration(t.Relative)}
+	case *Time_Absolute:
+		return flux.Time{Absolute: t.Absolute.AsTime()}
+	default:
+		return flux.Time{}
+	}
+}
+
+// NewTimeBounds creates the message for the bounds.
+func NewTimeBounds(b flux.Bounds) *TimeBounds {
+	pb := &TimeBounds{
+		Start: NewTime(b.Start),
+		Stop:  NewTime(b.Stop),
+	}
+	if !b.Now.IsZero() {
+		pb.Now = timestamppb.New(b.Now)
+	}
+	return pb
+}
+
+// AsBounds converts the message to bounds.
+func (x *TimeBounds) AsBounds() flux.Bounds {
+	b := flux.Bounds{
+		Start: x.GetStart().AsTime(),
+		Stop:  x.GetStop().AsTime(),
+	}
+	if now := x.GetNow(); now != nil {
+		b.Now = now.AsTime()
+	}
+	return b
+}
+
+// NewLocation creates the message for the location.
+func NewLocation(l plan.Location) *Location {
+	return &Location{
+		Name:   l.Name,
+		Offset: NewDuration(l.Offset),
+	}
+}
+
+// AsLocation converts the message to a location.
+func (x *Location) AsLocation() plan.Location {
+	return plan.Location{
+		Name:   x.GetName(),
+		Offset: x.GetOffset().AsDuration(),
+	}
+}
+
+// NewGroupMode returns the enum value of the group mode.
+func NewGroupMode(mode flux.GroupMode) (GroupMode, error) {
+	switch mode {
+	case flux.GroupModeNone:
+		return GroupMode_GROUP_MODE_NONE, nil
+	case flux.GroupModeBy:
+		return GroupMode_GROUP_MODE_BY, nil
+	case flux.GroupModeExcept:
+		return GroupMode_GROUP_MODE_EXCEPT, nil
+	default:
+		return 0, errors.Newf(codes.Internal, "unknown group mode %d", mode)
+	}
+}
+
+// AsGroupMode converts the enum value to a group mode.
+func (x GroupMode) AsGroupMode() (flux.GroupMode, error) {
+	switch x {
+	case GroupMode_GROUP_MODE_NONE:
+		return flux.GroupModeNone, nil
+	case GroupMode_GROUP_MODE_BY:
+		return flux.GroupModeBy, nil
+	case GroupMode_GROUP_MODE_EXCEPT:
+		return flux.GroupModeExcept, nil
+	default:
+		return 0, errors.Newf(codes.Invalid, "unknown group mode %v", x)
+	}
+}
+
+// MarshalTrigger converts the trigger spec to its protobuf message.
+func MarshalTrigger(t plan.TriggerSpec) (*Trigger, error) {
+	switch t := t.(type) {
+	case plan.NarrowTransformationTriggerSpec:
+		return &Trigger{Trigger: &Trigger_NarrowTransformation{
+			NarrowTransformation: &NarrowTransformationTrigger{},
+		}}, nil
+	case plan.AfterWatermarkTriggerSpec:
+		return &Trigger{Trigger: &Trigger_AfterWatermark{
+			AfterWatermark: &AfterWatermarkTrigger{
+				AllowedLateness: NewDuration(t.AllowedLateness),
+			},
+		}}, nil
+	case plan.RepeatedTriggerSpec:
+		trigger, err := MarshalTrigger(t.Trigger)
+		if err != nil {
+			return nil, err
+		}
+		return &Trigger{Trigger: &Trigger_Repeat{
+			Repeat: &RepeatedTrigger{Trigger: trigger},
+		}}, nil
+	case plan.AfterProcessingTimeTriggerSpec:
+		return &Trigger{Trigger: &Trigger_AfterProcessingTime{
+			AfterProcessingTime: &AfterProcessingTimeTrigger{
+				Duration: NewDuration(t.Duration),
+			},
+		}}, nil
+	case plan.AfterAtLeastCountTriggerSpec:
+		return &Trigger{Trigger: &Trigger_AfterAtLeastCount{
+			AfterAtLeastCount: &AfterAtLeastCountTrigger{Count: int64(t.Count)},
+		}}, nil
+	case plan.OrFinallyTriggerSpec:
+		main, err := MarshalTrigger(t.Main)
+		if err != nil {
+			return nil, err
+		}
+		finally, err := MarshalTrigger(t.Finally)
+		if err != nil {
+			return nil, err
+		}
+		return &Trigger{Trigger: &Trigger_OrFinally{
+			OrFinally: &OrFinallyTrigger{Main: main, Finally: finally},
+		}}, nil
+	default:
+		return nil, errors.Newf(codes.Unimplemented, "trigger spec %T has no protobuf encoding", t)
+	}
+}
+
+// UnmarshalTrigger converts the protobuf message to a trigger spec.
+func UnmarshalTrigger(pb *Trigger) (plan.TriggerSpec, error) {
+	switch t := pb.GetTrigger().(type) {
+	case *Trigger_NarrowTransformation:
+		return plan.NarrowTransformationTriggerSpec{}, nil
+	case *Trigger_AfterWatermark:
+		return plan.AfterWatermarkTriggerSpec{
+			AllowedLateness: t.AfterWatermark.GetAllowedLateness().AsDuration(),
+		}, nil
+	case *Trigger_Repeat:
+		trigger, err := UnmarshalTrigger(t.Repeat.GetTrigger())
+		if err != nil {
+			return nil, err
+		}
+		return plan.RepeatedTriggerSpec{Trigger: trigger}, nil
+	case *Trigger_AfterProcessingTime:
+		return plan.AfterProcessingTimeTriggerSpec{
+			Duration: t.AfterProcessingTime.GetDuration().AsDuration(),
+		}, nil
+	case *Trigger_AfterAtLeastCount:
+		return plan.AfterAtLeastCountTriggerSpec{
+			Count: int(t.AfterAtLeastCount.GetCount()),
+		}, nil
+	case *Trigger_OrFinally:
+		main, err := UnmarshalTrigger(t.OrFinally.GetMain())
+		if err != nil {
+			return nil, err
+		}
+		finally, err := UnmarshalTrigger(t.OrFinally.GetFinally())
+		if err != nil {
+			return nil, err
+		}
+		return plan.OrFinallyTriggerSpec{Main: main, Finally: finally}, nil
+	default:
+		return nil, errors.New(codes.Invalid, "protobuf trigger has no trigger spec")
+	}
+}
+
+// MarshalAttribute converts the physical attribute to its protobuf message.
+func MarshalAttribute(attr plan.PhysicalAttr) (*PhysicalAttribute, error) {
+	switch a := attr.(type) {
+	case *plan.CollationAttr:
+		return &PhysicalAttribute{Attribute: &PhysicalAttribute_Collation{
+			Collation: &CollationAttribute{Columns: a.Columns, Desc: a.Desc},
+		}}, nil
+	case plan.ParallelRunAttribute:
+		return &PhysicalAttribute{Attribute: &PhysicalAttribute_ParallelRun{
+			ParallelRun: &ParallelRunAttribute{Factor: int64(a.Factor)},
+		}}, nil
+	case plan.ParallelMergeAttribute:
+		return &PhysicalAttribute{Attribute: &PhysicalAttribute_ParallelMerge{
+			ParallelMerge: &ParallelMergeAttribute{Factor: int64(a.Factor)},
+		}}, nil
+	default:
+		return nil, errors.Newf(codes.Unimplemented, "physical attribute %q has no protobuf encoding", attr.Key())
+	}
+}
+
+// UnmarshalAttribute converts the protobuf message to a physical attribute.
+func UnmarshalAttribute(pb *PhysicalAttribute) (plan.PhysicalAttr, error) {
+	switch a := pb.GetAttribute().(type) {
+	case *PhysicalAttribute_Collation:
+		return &plan.CollationAttr{
+			Columns: a.Collation.GetColumns(),
+			Desc:    a.Collation.GetDesc(),
+		}, nil
+	case *PhysicalAttribute_ParallelRun:
+		return plan.ParallelRunAttribute{Factor: int(a.ParallelRun.GetFactor())}, nil
+	case *PhysicalAttribute_ParallelMerge:
+		return plan.ParallelMergeAttribute{Factor: int(a.ParallelMerge.GetFactor())}, nil
+	default:
+		return nil, errors.New(codes.Invalid, "protobuf physical attribute has no attribute")
+	}
+}
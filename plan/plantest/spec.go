@@ -25,3 +25,23 @@ func CreateLogicalMockNode(id string) *plan.LogicalNode {
 func CreatePhysicalMockNode(id string) *plan.PhysicalPlanNode {
 	return spec.CreatePhysicalMockNode(id)
 }
+
+type Graph = spec.Graph
+
+// Chain connects each element to the element that follows it.
+// See spec.Chain.
+func Chain(elems ...interface{}) *Graph {
+	return spec.Chain(elems...)
+}
+
+// Parallel combines the elements into a single fragment without
+// connecting them. See spec.Parallel.
+func Parallel(elems ...interface{}) *Graph {
+	return spec.Parallel(elems...)
+}
+
+// WithAttrs creates a physical mock node that provides the output
+// attributes. See spec.WithAttrs.
+func WithAttrs(id string, attrs plan.PhysicalAttributes) *plan.PhysicalPlanNode {
+	return spec.WithAttrs(id, attrs)
+}
@@ -0,0 +1,188 @@
+package spec
+
+import (
+	"fmt"
+
+	"github.com/influxdata/flux/plan"
+)
+
+// Graph is a fragment of a plan that is built with Chain and Parallel.
+// It is an alternative to listing the nodes of a PlanSpec and connecting
+// them with the indexes of the nodes.
+//
+// The plan below reads from two sources, joins them and yields the result:
+//
+//	Chain(
+//		Parallel(
+//			CreatePhysicalMockNode("left"),
+//			CreatePhysicalMockNode("right"),
+//		),
+//		CreatePhysicalMockNode("join"),
+//		CreatePhysicalMockNode("yield"),
+//	).PlanSpec()
+//
+// A node may be used in more than one fragment to connect the fragments.
+type Graph struct {
+	nodes []plan.Node
+	edges [][2]plan.Node
+
+	// heads are the nodes that are connected to the tails
+	// of the previous fragment in a chain and tails are the
+	// nodes that are connected to the heads of the next one.
+	heads []plan.Node
+	tails []plan.Node
+}
+
+// toGraph converts an element of Chain or Parallel to a Graph.
+// An element is either a plan.Node or a *Graph.
+func toGraph(elem interface{}) *Graph {
+	switch e := elem.(type) {
+	case *Graph:
+		return e
+	case plan.Node:
+		return &Graph{
+			nodes: []plan.Node{e},
+			heads: []plan.Node{e},
+			tails: []plan.Node{e},
+		}
+	default:
+		panic(fmt.Errorf("cannot build a plan from %T; elements must be a plan.Node or *Graph", elem))
+	}
+}
+
+// Chain connects each element to the element that follows it.
+// An element is either a plan.Node or a *Graph. When an element
+// has more than one tail, such as the result of Parallel, each
+// of its tails becomes a predecessor of the next element in order.
+func Chain(elems ...interface{}) *Graph {
+	g := new(Graph)
+	for i, elem := range elems {
+		next := toGraph(elem)
+		if i == 0 {
+			g.heads = next.heads
+		} else {
+			for _, tail := range g.tails {
+				for _, head := range next.heads {
+					g.edges = append(g.edges, [2]plan.Node{tail, head})
+				}
+			}
+		}
+		g.nodes = append(g.nodes, next.nodes...)
+		g.edges = append(g.edges, next.edges...)
+		g.tails = next.tails
+	}
+	return g
+}
+
+// Parallel combines the elements into a single fragment without
+// connecting them. The heads and tails of the fragment are the heads
+// and tails of the elements in order.
+func Parallel(elems ...interface{}) *Graph {
+	g := new(Graph)
+	for _, elem := range elems {
+		next := toGraph(elem)
+		g.nodes = append(g.nodes, next.nodes...)
+		g.edges = append(g.edges, next.edges...)
+		g.heads = append(g.heads, next.heads...)
+		g.tails = append(g.tails, next.tails...)
+	}
+	return g
+}
+
+// WithAttrs creates a physical mock node that provides the output attributes.
+func WithAttrs(id string, attrs plan.PhysicalAttributes) *plan.PhysicalPlanNode {
+	return plan.CreatePhysicalNode(plan.NodeID(id), MockProcedureSpec{
+		OutputAttributesFn: func() plan.PhysicalAttributes {
+			return attrs
+		},
+	})
+}
+
+// PlanSpec converts the graph to a PlanSpec.
+// It panics if two nodes have the same id, if two nodes are connected
+// more than once or if the edges form a cycle.
+func (g *Graph) PlanSpec() *PlanSpec {
+	ps := new(PlanSpec)
+	indexes := make(map[plan.Node]int, len(g.nodes))
+	ids := make(map[plan.NodeID]plan.Node, len(g.nodes))
+	for _, node := range g.nodes {
+		if _, ok := indexes[node]; ok {
+			continue
+		}
+		if other, ok := ids[node.ID()]; ok && other != node {
+			panic(fmt.Errorf("found two nodes with id %v", node.ID()))
+		}
+		ids[node.ID()] = node
+		indexes[node] = len(ps.Nodes)
+		ps.Nodes = append(ps.Nodes, node)
+	}
+
+	seen := make(map[[2]int]bool, len(g.edges))
+	for _, edge := range g.edges {
+		e := [2]int{indexes[edge[0]], indexes[edge[1]]}
+		if e[0] == e[1] {
+			panic(fmt.Errorf("found edge from node %v to itself", edge[0].ID()))
+		} else if seen[e] {
+			panic(fmt.Errorf("found duplicate edge from node %v to node %v", edge[0].ID(), edge[1].ID()))
+		}
+		seen[e] = true
+		ps.Edges = append(ps.Edges, e)
+	}
+
+	if cycle := findCycle(ps); cycle != nil {
+		panic(fmt.Errorf("found cycle in plan: %v", cycle))
+	}
+	return ps
+}
+
+// findCycle returns the ids of the nodes of a cycle in the plan
+// or nil if the plan has no cycles.
+func findCycle(ps *PlanSpec) []plan.NodeID {
+	successors := make([][]int, len(ps.Nodes))
+	for _, edge := range ps.Edges {
+		successors[edge[0]] = append(successors[edge[0]], edge[1])
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(ps.Nodes))
+	var path []int
+	var visit func(i int) []plan.NodeID
+	visit = func(i int) []plan.NodeID {
+		state[i] = visiting
+		path = append(path, i)
+		for _, j := range successors[i] {
+			switch state[j] {
+			case visiting:
+				var cycle []plan.NodeID
+				for k := len(path) - 1; k >= 0; k-- {
+					if path[k] == j {
+						for _, n := range path[k:] {
+							cycle = append(cycle, ps.Nodes[n].ID())
+						}
+						break
+					}
+				}
+				return append(cycle, ps.Nodes[j].ID())
+			case unvisited:
+				if cycle := visit(j); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[i] = visited
+		return nil
+	}
+	for i := range ps.Nodes {
+		if state[i] == unvisited {
+			if cycle := visit(i); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
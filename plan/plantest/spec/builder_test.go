@@ -0,0 +1,86 @@
+package spec_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/plan/plantest/spec"
+)
+
+func TestGraph_PlanSpec(t *testing.T) {
+	left := spec.CreatePhysicalMockNode("left")
+	right := spec.CreatePhysicalMockNode("right")
+	join := spec.CreatePhysicalMockNode("join")
+	filter := spec.CreatePhysicalMockNode("filter")
+	yield0 := spec.CreatePhysicalMockNode("yield0")
+	yield1 := spec.CreatePhysicalMockNode("yield1")
+
+	ps := spec.Parallel(
+		spec.Chain(
+			spec.Parallel(left, spec.Chain(right, filter)),
+			join,
+			yield0,
+		),
+		spec.Chain(filter, yield1),
+	).PlanSpec()
+
+	wantNodes := []plan.Node{left, right, filter, join, yield0, yield1}
+	if !cmp.Equal(wantNodes, ps.Nodes, cmp.Comparer(func(a, b plan.Node) bool { return a == b })) {
+		t.Errorf("unexpected nodes: %v", ps.Nodes)
+	}
+	wantEdges := [][2]int{
+		{1, 2},
+		{0, 3},
+		{2, 3},
+		{3, 4},
+		{2, 5},
+	}
+	if !cmp.Equal(wantEdges, ps.Edges) {
+		t.Errorf("unexpected edges -want/+got:\n%s", cmp.Diff(wantEdges, ps.Edges))
+	}
+}
+
+func TestGraph_PlanSpecInvalid(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		graph func() *spec.Graph
+		want  string
+	}{
+		{
+			name: "cycle",
+			graph: func() *spec.Graph {
+				a := spec.CreateLogicalMockNode("a")
+				return spec.Chain(a, spec.CreateLogicalMockNode("b"), spec.CreateLogicalMockNode("c"), a)
+			},
+			want: "found cycle in plan: [a b c a]",
+		},
+		{
+			name: "duplicate id",
+			graph: func() *spec.Graph {
+				return spec.Chain(spec.CreateLogicalMockNode("a"), spec.CreateLogicalMockNode("a"))
+			},
+			want: "found two nodes with id a",
+		},
+		{
+			name: "duplicate edge",
+			graph: func() *spec.Graph {
+				a, b := spec.CreateLogicalMockNode("a"), spec.CreateLogicalMockNode("b")
+				return spec.Parallel(spec.Chain(a, b), spec.Chain(a, b))
+			},
+			want: "found duplicate edge from node a to node b",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				err, _ := recover().(error)
+				if err == nil {
+					t.Fatal("expected panic")
+				} else if got := err.Error(); got != tt.want {
+					t.Errorf("unexpected error -want/+got:\n%s", cmp.Diff(tt.want, got))
+				}
+			}()
+			tt.graph().PlanSpec()
+		})
+	}
+}
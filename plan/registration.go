@@ -73,6 +73,7 @@ func HasSideEffect(spec ProcedureSpec) bool {
 var ruleNameToLogicalRule = make(map[string]Rule)
 var ruleNameToPhysicalRule = make(map[string]Rule)
 var ruleNameToParallelizeRules = make(map[string]Rule)
+var ruleNameToStableOutputRules = make(map[string]Rule)
 
 // RegisterLogicalRules registers the rule created by createFn with the logical plan.
 func RegisterLogicalRules(rules ...Rule) {
@@ -89,6 +90,13 @@ func RegisterParallelizeRules(rules ...Rule) {
 	registerRule(ruleNameToParallelizeRules, rules...)
 }
 
+// RegisterStableOutputRules registers the rules that the physical plan applies
+// to guarantee the order of the tables and rows of the results when it is
+// created with the StableOutput option.
+func RegisterStableOutputRules(rules ...Rule) {
+	registerRule(ruleNameToStableOutputRules, rules...)
+}
+
 func registerRule(ruleMap map[string]Rule, rules ...Rule) {
 	for _, rule := range rules {
 		name := rule.Name()
@@ -103,4 +111,5 @@ func ClearRegisteredRules() {
 	ruleNameToLogicalRule = make(map[string]Rule)
 	ruleNameToPhysicalRule = make(map[string]Rule)
 	ruleNameToParallelizeRules = make(map[string]Rule)
+	ruleNameToStableOutputRules = make(map[string]Rule)
 }
@@ -0,0 +1,20 @@
+package plan
+
+import "context"
+
+// SchemaSource is implemented by the procedure spec of a source
+// that can report the schema of its tables from metadata
+// without reading the data.
+//
+// Transformations that only inspect the schema of their input,
+// such as columns() and keys(), use this to avoid scanning
+// the data of the source.
+type SchemaSource interface {
+	// SchemaSpec returns the procedure spec of a source that
+	// produces the same tables with the same group keys and columns,
+	// but without any rows.
+	//
+	// It returns false if the schema cannot be determined without
+	// reading the data or if the spec already produces only the schema.
+	SchemaSpec(ctx context.Context) (PhysicalProcedureSpec, bool)
+}
@@ -65,6 +65,10 @@ type Spec struct {
 	Roots     map[Node]struct{}
 	Resources flux.ResourceManagement
 	Now       time.Time
+
+	// StableOutput is set when the results must produce
+	// their tables sorted by group key.
+	StableOutput bool
 }
 
 // NewPlanSpec initializes a new query plan
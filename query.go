@@ -55,6 +55,8 @@ type Statistics struct {
 	// TotalAllocated is the total number of bytes allocated.
 	// The number includes memory that was freed and then used again.
 	TotalAllocated int64 `json:"total_allocated"`
+	// NodeAllocations holds the memory used by each plan node.
+	NodeAllocations []NodeAllocation `json:"node_allocations"`
 
 	// Profiles holds the profiles for each transport (source/transformation) in this query.
 	Profiles []TransportProfile `json:"profiles"`
@@ -77,6 +79,9 @@ func (s Statistics) Add(other Statistics) Statistics {
 	profiles := make([]TransportProfile, 0, len(s.Profiles)+len(other.Profiles))
 	profiles = append(profiles, s.Profiles...)
 	profiles = append(profiles, other.Profiles...)
+	nodeAllocations := make([]NodeAllocation, 0, len(s.NodeAllocations)+len(other.NodeAllocations))
+	nodeAllocations = append(nodeAllocations, s.NodeAllocations...)
+	nodeAllocations = append(nodeAllocations, other.NodeAllocations...)
 	return Statistics{
 		TotalDuration:   s.TotalDuration + other.TotalDuration,
 		CompileDuration: s.CompileDuration + other.CompileDuration,
@@ -87,6 +92,7 @@ func (s Statistics) Add(other Statistics) Statistics {
 		Concurrency:     s.Concurrency + other.Concurrency,
 		MaxAllocated:    s.MaxAllocated + other.MaxAllocated,
 		TotalAllocated:  s.TotalAllocated + other.TotalAllocated,
+		NodeAllocations: nodeAllocations,
 		Profiles:        profiles,
 		RuntimeErrors:   errs,
 		Metadata:        md,
@@ -104,11 +110,25 @@ func (s *Statistics) Merge(other Statistics) {
 	s.Concurrency += other.Concurrency
 	s.MaxAllocated += other.MaxAllocated
 	s.TotalAllocated += other.TotalAllocated
+	s.NodeAllocations = append(s.NodeAllocations, other.NodeAllocations...)
 	s.Profiles = append(s.Profiles, other.Profiles...)
 	s.RuntimeErrors = append(s.RuntimeErrors, other.RuntimeErrors...)
 	s.Metadata.AddAll(other.Metadata)
 }
 
+// NodeAllocation holds the memory used by a plan node.
+type NodeAllocation struct {
+	// Label holds the plan node label.
+	Label string `json:"label"`
+
+	// Allocated is the number of bytes the node had allocated
+	// when the query finished.
+	Allocated int64 `json:"allocated"`
+
+	// MaxAllocated is the maximum number of bytes the node allocated.
+	MaxAllocated int64 `json:"max_allocated"`
+}
+
 // TransportProfile holds the profile for transport statistics.
 type TransportProfile struct {
 	// NodeType holds the node type which is a string representation
@@ -265,9 +265,13 @@ func (r *REPL) doQuery(ctx context.Context, spec *operation.Spec) error {
 	if err != nil {
 		return err
 	}
-	defer qry.Done()
+	// The result iterator includes the results of the
+	// profilers enabled by the profiler.enabledProfilers option.
+	results := flux.NewResultIteratorFromQuery(qry)
+	defer results.Release()
 
-	for result := range qry.Results() {
+	for results.More() {
+		result := results.Next()
 		tables := result.Tables()
 		fmt.Println("Result:", result.Name())
 		if err := tables.Do(func(tbl flux.Table) error {
@@ -277,8 +281,8 @@ func (r *REPL) doQuery(ctx context.Context, spec *operation.Spec) error {
 			return err
 		}
 	}
-	qry.Done()
-	return qry.Err()
+	results.Release()
+	return results.Err()
 }
 
 func getFluxFiles(path string) ([]string, error) {
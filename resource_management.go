@@ -9,4 +9,22 @@ type ResourceManagement struct {
 	// There is a small amount of overhead memory being consumed by a query that will not be counted towards this limit.
 	// A zero value indicates unlimited.
 	MemoryBytesQuota int64 `json:"memory_bytes_quota"`
+	// MemoryBytesSoftQuota is the number of bytes of RAM above which the query is under memory pressure.
+	// Exceeding it does not fail the query. The executor only logs a message when the query goes above it.
+	// No memory is spilled or released.
+	// It must not be greater than MemoryBytesQuota.
+	// A zero value indicates no soft limit.
+	MemoryBytesSoftQuota int64 `json:"memory_bytes_soft_quota"`
+	// MaxOutputRows is the number of rows the results of this query may contain.
+	// The query fails with a ResourceExhausted error once the rows read from
+	// the results exceed it.
+	// A zero value indicates unlimited.
+	MaxOutputRows int64 `json:"max_output_rows"`
+	// MaxOutputTables is the number of tables the results of this query may contain.
+	// A zero value indicates unlimited.
+	MaxOutputTables int64 `json:"max_output_tables"`
+	// MaxGroupRows is the number of rows that a node may produce in a single table.
+	// It bounds the rows that the successors of the node buffer for a single series.
+	// A zero value indicates unlimited.
+	MaxGroupRows int64 `json:"max_group_rows"`
 }
@@ -2,8 +2,11 @@ package flux
 
 import (
 	"io"
+	"sync"
 
 	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
 	"github.com/influxdata/flux/iocounter"
 	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/flux/values"
@@ -73,11 +76,13 @@ type BufferedTable interface {
 type ColMeta struct {
 	// Label is the name of the column. The label is unique per table.
 	Label string
-	// Type is the type of the column. Only basic types are allowed.
+	// Type is the type of the column. Only basic types and
+	// arrays of basic types are allowed.
 	Type ColType
 }
 
-// ColType is the type for a column. This covers only basic data types.
+// ColType is the type for a column. This covers the basic data
+// types and arrays with elements of a basic data type.
 type ColType int
 
 const (
@@ -88,8 +93,31 @@ const (
 	TFloat
 	TString
 	TTime
+	TBytes
+	TDuration
 )
 
+// tArray marks the column types of array columns.
+// The remaining bits are the column type of the elements.
+const tArray ColType = 1 << 8
+
+// ArrayOf returns the column type of arrays with elements
+// of the given column type. The elements of an array column
+// cannot be arrays.
+func ArrayOf(elem ColType) ColType {
+	return tArray | elem
+}
+
+// IsArray reports whether the column type is an array column type.
+func (t ColType) IsArray() bool {
+	return t&tArray != 0
+}
+
+// ElemType returns the column type of the elements of an array column type.
+func (t ColType) ElemType() ColType {
+	return t &^ tArray
+}
+
 // ColumnType returns the column type when given a semantic.Type.
 // It returns flux.TInvalid if the Type is not a valid column type.
 func ColumnType(typ semantic.MonoType) ColType {
@@ -106,12 +134,32 @@ func ColumnType(typ semantic.MonoType) ColType {
 		return TString
 	case semantic.Time:
 		return TTime
+	case semantic.Bytes:
+		return TBytes
+	case semantic.Duration:
+		return TDuration
+	case semantic.Array:
+		et, err := typ.ElemType()
+		if err != nil {
+			return TInvalid
+		}
+		if elem := ColumnType(et); elem != TInvalid && !elem.IsArray() {
+			return ArrayOf(elem)
+		}
+		return TInvalid
 	default:
 		return TInvalid
 	}
 }
 
 func SemanticType(typ ColType) semantic.MonoType {
+	if typ.IsArray() {
+		elem := SemanticType(typ.ElemType())
+		if elem.Nature() == semantic.Invalid {
+			return semantic.MonoType{}
+		}
+		return semantic.NewArrayType(elem)
+	}
 	switch typ {
 	case TBool:
 		return semantic.BasicBool
@@ -125,6 +173,10 @@ func SemanticType(typ ColType) semantic.MonoType {
 		return semantic.BasicString
 	case TTime:
 		return semantic.BasicTime
+	case TBytes:
+		return semantic.BasicBytes
+	case TDuration:
+		return semantic.BasicDuration
 	default:
 		return semantic.MonoType{}
 	}
@@ -132,6 +184,9 @@ func SemanticType(typ ColType) semantic.MonoType {
 
 // String returns a string representation of the column type.
 func (t ColType) String() string {
+	if t.IsArray() {
+		return "[" + t.ElemType().String() + "]"
+	}
 	switch t {
 	case TInvalid:
 		return "invalid"
@@ -147,6 +202,10 @@ func (t ColType) String() string {
 		return "string"
 	case TTime:
 		return "time"
+	case TBytes:
+		return "bytes"
+	case TDuration:
+		return "duration"
 	default:
 		return "unknown"
 	}
@@ -170,6 +229,9 @@ type ColReader interface {
 	Floats(j int) *array.Float
 	Strings(j int) *array.String
 	Times(j int) *array.Int
+	Bytes(j int) *array.Binary
+	Durations(j int) *array.Int
+	Arrays(j int) *array.List
 
 	// Retain will retain this buffer to avoid having the
 	// memory consumed by it freed.
@@ -336,3 +398,134 @@ func (e *DelimitedMultiResultEncoder) Encode(w io.Writer, results ResultIterator
 	}
 	return wc.Count(), nil
 }
+
+// TableEncoder encodes a single table of a result.
+// The encoded table must be self-describing so the tables of
+// different results can be written to the same stream in any order.
+type TableEncoder interface {
+	EncodeTable(w io.Writer, resultName string, id int, tbl Table) (int64, error)
+}
+
+// InterleavedMultiResultEncoder encodes multiple results by writing the
+// tables of every result as they are produced. The results are read
+// concurrently so a result that is slow to produce its tables does not
+// delay the tables of the other results.
+//
+// Each table is written as one frame followed by the Delimiter and the
+// tables of different results may be interleaved with each other. The id
+// passed to the Encoder counts the tables of each result separately.
+//
+// Errors are handled in the same way as the DelimitedMultiResultEncoder.
+//
+// If the io.Writer implements flusher, it will be flushed after each delimiter.
+type InterleavedMultiResultEncoder struct {
+	Delimiter []byte
+	Encoder   interface {
+		TableEncoder
+		// EncodeError encodes an error on the writer.
+		EncodeError(w io.Writer, err error) error
+	}
+}
+
+// errEncodingStopped is returned to stop reading a result
+// after another result has failed.
+var errEncodingStopped = errors.New(codes.Canceled, "encoding stopped")
+
+// Encode will encode the results into the writer using the Encoder and separating each table
+// by the Delimiter. If an error occurs while processing the ResultIterator or is returned from
+// the underlying Encoder, Encode will return the error if nothing has yet been written to the
+// Writer. If something has been written to the Writer, then an error will only be returned
+// when the error is an EncoderError.
+func (e *InterleavedMultiResultEncoder) Encode(w io.Writer, results ResultIterator) (int64, error) {
+	wc := &iocounter.Writer{Writer: w}
+
+	var (
+		wg sync.WaitGroup
+		// mu protects the writer and the fields below.
+		mu       sync.Mutex
+		stopped  bool
+		firstErr error
+		writeErr error
+	)
+	stop := func(err error) {
+		if !stopped {
+			stopped = true
+			firstErr = err
+		}
+	}
+
+	encode := func(result Result) {
+		defer wg.Done()
+
+		name, id := result.Name(), 0
+		err := result.Tables().Do(func(tbl Table) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if stopped {
+				tbl.Done()
+				return errEncodingStopped
+			}
+			if _, err := e.Encoder.EncodeTable(wc, name, id, tbl); err != nil {
+				stop(err)
+				return errEncodingStopped
+			}
+			id++
+			if _, err := wc.Write(e.Delimiter); err != nil {
+				writeErr = err
+				stop(err)
+				return errEncodingStopped
+			}
+			// Flush the writer after each table.
+			if f, ok := w.(flusher); ok {
+				f.Flush()
+			}
+			return nil
+		})
+		if err != nil && err != errEncodingStopped {
+			mu.Lock()
+			stop(err)
+			mu.Unlock()
+		}
+	}
+
+	for results.More() {
+		mu.Lock()
+		done := stopped
+		mu.Unlock()
+		if done {
+			break
+		}
+
+		wg.Add(1)
+		go encode(results.Next())
+	}
+	wg.Wait()
+	results.Release()
+
+	if writeErr != nil {
+		return wc.Count(), writeErr
+	}
+	if err := firstErr; err != nil {
+		// If we have an error that's from encoding or if we have not
+		// yet written any data to the writer, return the error.
+		if isEncoderError(err) || wc.Count() == 0 {
+			return wc.Count(), err
+		}
+		// Otherwise, the error happened during query execution and we
+		// are stuck encoding it.
+		err := e.Encoder.EncodeError(wc, err)
+		return wc.Count(), err
+	}
+
+	// If we have any outlying errors in results, encode them
+	// the same way as the errors of the results.
+	if err := results.Err(); err != nil {
+		if wc.Count() == 0 {
+			return 0, err
+		}
+		err := e.Encoder.EncodeError(wc, err)
+		return wc.Count(), err
+	}
+	return wc.Count(), nil
+}
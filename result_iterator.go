@@ -34,7 +34,12 @@ type ResultIterator interface {
 type queryResultIterator struct {
 	query      Query
 	released   bool
+	done       bool
 	nextResult Result
+
+	// profilerResults holds the results of the profilers
+	// that are read after the results of the query.
+	profilerResults ResultIterator
 }
 
 func NewResultIteratorFromQuery(q Query) ResultIterator {
@@ -58,14 +63,41 @@ func (r *queryResultIterator) More() bool {
 		return true
 	}
 
-	nr, ok := <-r.query.Results()
-	if !ok {
-		r.nextResult = nil
-		return false
+	if r.profilerResults == nil {
+		nr, ok := <-r.query.Results()
+		if ok {
+			r.nextResult = nr
+			return true
+		}
+		r.readProfilerResults()
 	}
 
-	r.nextResult = nr
-	return true
+	if r.profilerResults != nil && r.profilerResults.More() {
+		r.nextResult = r.profilerResults.Next()
+		return true
+	}
+	r.nextResult = nil
+	return false
+}
+
+// readProfilerResults finishes the query and reads the results of its
+// profilers once the query has produced all of its results. The profiler
+// results are only read when the query succeeded because the statistics
+// are not complete until the query is done.
+func (r *queryResultIterator) readProfilerResults() {
+	if r.done {
+		return
+	}
+	r.query.Done()
+	r.done = true
+	if r.query.Err() != nil {
+		return
+	}
+	results, err := r.query.ProfilerResults()
+	if err != nil || results == nil {
+		return
+	}
+	r.profilerResults = results
 }
 
 // Next produces the next result.
@@ -77,14 +109,11 @@ func (r *queryResultIterator) Next() Result {
 	}
 	var nr Result
 	if r.nextResult == nil {
-		var ok bool
-		nr, ok = <-r.query.Results()
-		if !ok {
+		if !r.More() {
 			panic("call to Next() when More() is false")
 		}
-	} else {
-		nr = r.nextResult
 	}
+	nr = r.nextResult
 
 	r.nextResult = nil
 	return nr
@@ -92,7 +121,13 @@ func (r *queryResultIterator) Next() Result {
 
 // Release frees resources associated with this iterator.
 func (r *queryResultIterator) Release() {
-	r.query.Done()
+	if r.profilerResults != nil {
+		r.profilerResults.Release()
+	}
+	if !r.done {
+		r.query.Done()
+		r.done = true
+	}
 	r.released = true
 	r.nextResult = nil // a panic will occur if caller attempts to call Next().
 }
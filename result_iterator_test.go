@@ -317,3 +317,49 @@ func TestQueryResultIterator_Statistics(t *testing.T) {
 		t.Fatalf("unexpected stats: -want/got:\n%s\n", diff)
 	}
 }
+
+// profiledQuery returns a profiler result after it is done.
+type profiledQuery struct {
+	*mock.Query
+	done bool
+}
+
+func (q *profiledQuery) Done() {
+	q.done = true
+	q.Query.Done()
+}
+
+func (q *profiledQuery) ProfilerResults() (flux.ResultIterator, error) {
+	if !q.done {
+		return nil, errors.New("profiler results read before the query was done")
+	}
+	result := executetest.NewResult(nil)
+	result.Nm = "_profiler"
+	return flux.NewSliceResultIterator([]flux.Result{result}), nil
+}
+
+func TestQueryResultIterator_ProfilerResults(t *testing.T) {
+	q := &profiledQuery{Query: &mock.Query{}}
+	q.ProduceResults(func(results chan<- flux.Result, canceled <-chan struct{}) {
+		for _, name := range []string{"a", "b"} {
+			result := executetest.NewResult(nil)
+			result.Nm = name
+			results <- result
+		}
+	})
+	ri := flux.NewResultIteratorFromQuery(q)
+	defer ri.Release()
+
+	var got []string
+	for ri.More() {
+		got = append(got, ri.Next().Name())
+	}
+	if err := ri.Err(); err != nil {
+		t.Fatalf("unexpected error in result iterator: %s", err)
+	}
+
+	want := []string{"a", "b", "_profiler"}
+	if !cmp.Equal(want, got) {
+		t.Fatalf("got unexpected results -want/got:\n%s\n", cmp.Diff(want, got))
+	}
+}
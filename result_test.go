@@ -65,6 +65,22 @@ func (enc *ResultLineEncoder) Encode(w io.Writer, result flux.Result) (int64, er
 	return wc.Count(), err
 }
 
+func (enc *ResultLineEncoder) EncodeTable(w io.Writer, resultName string, id int, tbl flux.Table) (int64, error) {
+	wc := &iocounter.Writer{Writer: w}
+	err := tbl.Do(func(cr flux.ColReader) error {
+		for i, n := 0, cr.Len(); i < n; i++ {
+			values := make([]string, len(cr.Cols()))
+			for j, col := range cr.Cols() {
+				v := execute.ValueForRow(cr, i, j)
+				values[j] = fmt.Sprintf("%s=%v", col.Label, v)
+			}
+			_, _ = fmt.Fprintf(wc, "result(%s) table(%d): %s\n", resultName, id, strings.Join(values, " "))
+		}
+		return nil
+	})
+	return wc.Count(), err
+}
+
 func (enc *ResultLineEncoder) EncodeError(w io.Writer, err error) error {
 	_, _ = fmt.Fprintf(w, "error: %s\n", err.Error())
 	return nil
@@ -228,3 +244,170 @@ error: expected error
 		})
 	}
 }
+
+// funcResult is a result that produces its tables with a function.
+type funcResult struct {
+	name string
+	do   func(f func(flux.Table) error) error
+}
+
+func (r *funcResult) Name() string               { return r.name }
+func (r *funcResult) Tables() flux.TableIterator { return r }
+
+func (r *funcResult) Do(f func(flux.Table) error) error {
+	return r.do(f)
+}
+
+func newLineTable(v float64) *executetest.Table {
+	return &executetest.Table{
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TFloat},
+		},
+		Data: [][]interface{}{
+			{execute.Time(0), v},
+		},
+	}
+}
+
+func TestInterleavedMultiResultEncoder_Encode(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		results func() flux.ResultIterator
+		want    string
+		wantErr string
+	}{
+		{
+			name: "SingleResult",
+			results: func() flux.ResultIterator {
+				return flux.NewSliceResultIterator(
+					[]flux.Result{
+						&executetest.Result{
+							Nm:   "success",
+							Tbls: []*executetest.Table{newLineTable(2), newLineTable(3)},
+						},
+					},
+				)
+			},
+			want: `result(success) table(0): _time=1970-01-01T00:00:00.000000000Z _value=2
+
+result(success) table(1): _time=1970-01-01T00:00:00.000000000Z _value=3
+
+`,
+		},
+		{
+			// The first result cannot produce its second table until the
+			// second result has been written so the results must be
+			// encoded concurrently.
+			name: "InterleavedResults",
+			results: func() flux.ResultIterator {
+				firstWritten, secondWritten := make(chan struct{}), make(chan struct{})
+				return flux.NewSliceResultIterator(
+					[]flux.Result{
+						&funcResult{
+							name: "first",
+							do: func(f func(flux.Table) error) error {
+								if err := f(newLineTable(1)); err != nil {
+									return err
+								}
+								close(firstWritten)
+								<-secondWritten
+								return f(newLineTable(3))
+							},
+						},
+						&funcResult{
+							name: "second",
+							do: func(f func(flux.Table) error) error {
+								<-firstWritten
+								if err := f(newLineTable(2)); err != nil {
+									return err
+								}
+								close(secondWritten)
+								return nil
+							},
+						},
+					},
+				)
+			},
+			want: `result(first) table(0): _time=1970-01-01T00:00:00.000000000Z _value=1
+
+result(second) table(0): _time=1970-01-01T00:00:00.000000000Z _value=2
+
+result(first) table(1): _time=1970-01-01T00:00:00.000000000Z _value=3
+
+`,
+		},
+		{
+			name: "QueryError",
+			results: func() flux.ResultIterator {
+				results := make(chan flux.Result)
+				close(results)
+				q := &mock.Query{
+					ResultsCh: results,
+				}
+				q.SetErr(errors.New("expected error"))
+				return flux.NewResultIteratorFromQuery(q)
+			},
+			wantErr: "expected error",
+		},
+		{
+			name: "ResultError",
+			results: func() flux.ResultIterator {
+				return flux.NewSliceResultIterator(
+					[]flux.Result{
+						&executetest.Result{
+							Nm:  "test",
+							Err: errors.New("expected error"),
+						},
+					},
+				)
+			},
+			wantErr: "expected error",
+		},
+		{
+			name: "ResultErrorAfterTable",
+			results: func() flux.ResultIterator {
+				return flux.NewSliceResultIterator(
+					[]flux.Result{
+						&funcResult{
+							name: "error",
+							do: func(f func(flux.Table) error) error {
+								if err := f(newLineTable(2)); err != nil {
+									return err
+								}
+								return errors.New("expected error")
+							},
+						},
+					},
+				)
+			},
+			want: `result(error) table(0): _time=1970-01-01T00:00:00.000000000Z _value=2
+
+error: expected error
+`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			results := tt.results()
+			enc := &flux.InterleavedMultiResultEncoder{
+				Delimiter: []byte("\n"),
+				Encoder:   &ResultLineEncoder{TB: t},
+			}
+
+			var got strings.Builder
+			if _, err := enc.Encode(&got, results); err != nil {
+				if tt.wantErr != "" {
+					if got, want := err.Error(), tt.wantErr; got != want {
+						t.Fatalf("unexpected error -want/+got:\n\t- %v\n\t+ %v", got, want)
+					}
+					return
+				}
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got, want := got.String(), tt.want; got != want {
+				t.Fatalf("unexpected output -want/+got\n%s", diff.LineDiff(want, got))
+			}
+		})
+	}
+}
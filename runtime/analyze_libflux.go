@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/internal/errors"
 	"github.com/influxdata/flux/libflux/go/libflux"
 	"github.com/influxdata/flux/semantic"
 )
@@ -22,7 +23,7 @@ func AnalyzePackage(ctx context.Context, astPkg flux.ASTHandle) (*semantic.Packa
 	options := libflux.NewOptions(ctx)
 	sem, err := libflux.AnalyzeWithOptions(hdl, options)
 	if err != nil {
-		return nil, err
+		return nil, analysisError(err)
 	}
 	defer sem.Free()
 	bs, err := sem.MarshalFB()
@@ -31,3 +32,29 @@ func AnalyzePackage(ctx context.Context, astPkg flux.ASTHandle) (*semantic.Packa
 	}
 	return semantic.DeserializeFromFlatBuffer(bs)
 }
+
+// AnalysisError is the error of a package that is rejected before it
+// is evaluated, such as a type error or an error of a registered
+// Analyzer. Callers use it to tell the errors of the script itself
+// apart from the errors raised while the script is evaluated.
+type AnalysisError struct {
+	Err error
+}
+
+func (e *AnalysisError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *AnalysisError) Unwrap() error {
+	return e.Err
+}
+
+// analysisError marks the error as an AnalysisError.
+// The code and the documentation url of the error are kept.
+func analysisError(err error) error {
+	return &errors.Error{
+		Code:   errors.Code(err),
+		DocURL: errors.DocURL(err),
+		Err:    &AnalysisError{Err: err},
+	}
+}
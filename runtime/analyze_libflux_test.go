@@ -36,6 +36,10 @@ func TestAnalyzeSource(t *testing.T) {
 				if want, got := tc.err.Error(), err.Error(); want != got {
 					t.Fatalf("wanted error %q, got %q", want, got)
 				}
+				var aerr *runtime.AnalysisError
+				if !errors.As(err, &aerr) {
+					t.Fatalf("expected an analysis error, got %T", err)
+				}
 				return
 			}
 			if tc.err != nil {
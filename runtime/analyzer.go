@@ -0,0 +1,52 @@
+package runtime
+
+import (
+	"context"
+	"sync"
+
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/semantic"
+)
+
+// Analyzer inspects a package after type inference and before it is
+// evaluated. Returning an error rejects the package.
+//
+// Analyzers can be used to implement custom policy checks such as
+// requiring every query to call range. The types of the expressions
+// in the package are available through semantic.TypeOf.
+type Analyzer func(ctx context.Context, pkg *semantic.Package) error
+
+// analyzers holds the analyzers registered with a runtime.
+// Analyzers may be registered after the runtime has been finalized
+// so embedders can add them once the standard library is loaded.
+type analyzers struct {
+	mu sync.RWMutex
+	fs []Analyzer
+}
+
+func (a *analyzers) register(f Analyzer) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.fs = append(a.fs, f)
+}
+
+// run calls each analyzer in the order it was registered
+// and returns the first error.
+func (a *analyzers) run(ctx context.Context, pkg *semantic.Package) error {
+	a.mu.RLock()
+	fs := a.fs
+	a.mu.RUnlock()
+
+	for _, f := range fs {
+		if err := f(ctx, pkg); err != nil {
+			if errors.Code(err) == codes.Unknown {
+				// Errors without a code are reported as invalid
+				// queries rather than as internal errors.
+				return errors.Wrap(err, codes.Invalid, "query rejected by analyzer")
+			}
+			return err
+		}
+	}
+	return nil
+}
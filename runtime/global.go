@@ -25,6 +25,12 @@ func ReplacePackageValue(pkgpath, name string, value values.Value) {
 	}
 }
 
+// RegisterAnalyzer adds an analyzer that is run on every package evaluated
+// by the default runtime after type inference. It may be called at any time.
+func RegisterAnalyzer(a Analyzer) {
+	Default.RegisterAnalyzer(a)
+}
+
 // StdLib returns an importer for the Flux standard library.
 func StdLib() interpreter.Importer {
 	return Default.Stdlib()
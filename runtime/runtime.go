@@ -22,6 +22,7 @@ type runtime struct {
 	pkgs      map[string]*semantic.Package
 	builtins  map[string]map[string]values.Value
 	finalized bool
+	analyzers analyzers
 }
 
 func (r *runtime) Parse(flux string) (flux.ASTHandle, error) {
@@ -57,6 +58,12 @@ func (r *runtime) ReplacePackageValue(pkgpath, name string, value values.Value)
 	return r.registerPackageValue(pkgpath, name, value, true)
 }
 
+// RegisterAnalyzer adds an analyzer that is run on every package
+// evaluated by the runtime after type inference.
+func (r *runtime) RegisterAnalyzer(a Analyzer) {
+	r.analyzers.register(a)
+}
+
 func (r *runtime) registerPackageValue(pkgpath, name string, value values.Value, replace bool) error {
 	if r.finalized {
 		return errors.Newf(codes.Internal, "already finalized, cannot register builtin package value")
@@ -103,6 +110,9 @@ func (r *runtime) Eval(ctx context.Context, astPkg flux.ASTHandle, es interprete
 	if err != nil {
 		return nil, nil, err
 	}
+	if err := r.analyzers.run(ctx, semPkg); err != nil {
+		return nil, nil, analysisError(err)
+	}
 
 	// Construct the initial scope for this package.
 	importer := &importer{r: r}
@@ -6,12 +6,15 @@ import (
 	"os"
 	"testing"
 
+	"github.com/influxdata/flux/codes"
 	"github.com/influxdata/flux/dependencies/dependenciestest"
 	"github.com/influxdata/flux/dependency"
 	_ "github.com/influxdata/flux/fluxinit/static"
+	"github.com/influxdata/flux/internal/errors"
 	"github.com/influxdata/flux/interpreter"
 	"github.com/influxdata/flux/parser"
 	"github.com/influxdata/flux/runtime"
+	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/flux/values"
 )
 
@@ -72,6 +75,50 @@ func TestEval_error(t *testing.T) {
 
 }
 
+func TestEval_analyzer(t *testing.T) {
+	// Reject calls to limitTo unless they are passed an integer.
+	runtime.RegisterAnalyzer(func(ctx context.Context, pkg *semantic.Package) error {
+		var err error
+		semantic.Inspect(pkg, func(n semantic.Node) bool {
+			call, ok := n.(*semantic.CallExpression)
+			if !ok || err != nil {
+				return err == nil
+			}
+			if id, ok := call.Callee.(*semantic.IdentifierExpression); !ok || id.Name.Name() != "limitTo" {
+				return true
+			}
+			for _, p := range call.Arguments.Properties {
+				if typ, ok := semantic.TypeOf(p.Value); !ok || typ.Nature() != semantic.Int {
+					err = errors.New(codes.Invalid, "limitTo must be passed an integer")
+				}
+			}
+			return true
+		})
+		return err
+	})
+
+	src := `
+		limitTo = (n) => n
+		x = limitTo(n: 1)`
+	if _, _, err := runtime.Eval(context.Background(), src); err != nil {
+		t.Fatal(err)
+	}
+
+	src = `
+		limitTo = (n) => n
+		x = limitTo(n: "1")`
+	_, _, err := runtime.Eval(context.Background(), src)
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+	if want, got := "limitTo must be passed an integer", err.Error(); want != got {
+		t.Errorf("wanted error %q, got %q", want, got)
+	}
+	if want, got := codes.Invalid, errors.Code(err); want != got {
+		t.Errorf("wanted code %v, got %v", want, got)
+	}
+}
+
 // Example_option demonstrates retrieving an option value from a scope object
 func Example_option() {
 
@@ -5,19 +5,58 @@ import (
 	"github.com/influxdata/flux/internal/errors"
 )
 
+// Walk traverses the semantic graph rooted at node in depth-first order.
+// It calls v.Visit for each node and, if it returns a non-nil visitor w,
+// walks the children of the node with w. v.Done is called for the node
+// once its children have been visited.
 func Walk(v Visitor, node Node) {
 	walk(v, node)
 }
 
+// Visitor is called for each node encountered by Walk.
 type Visitor interface {
+	// Visit is called before the children of the node are visited.
+	// The returned visitor is used to visit the children.
+	// If it is nil, the children are not visited.
 	Visit(node Node) Visitor
+	// Done is called after the children of the node have been visited.
 	Done(node Node)
 }
 
+// CreateVisitor returns a Visitor that calls f for every node.
 func CreateVisitor(f func(Node)) Visitor {
 	return &visitor{f: f}
 }
 
+// Inspect traverses the semantic graph rooted at node in depth-first order
+// and calls f for each node. If f returns false, the children of the node
+// are not visited.
+func Inspect(node Node, f func(Node) bool) {
+	Walk(inspector(f), node)
+}
+
+type inspector func(Node) bool
+
+func (f inspector) Visit(node Node) Visitor {
+	if f(node) {
+		return f
+	}
+	return nil
+}
+
+func (f inspector) Done(node Node) {}
+
+// TypeOf returns the type inferred for the node.
+// It returns false if the node is not an expression.
+// The type is only known once the package has been analyzed.
+func TypeOf(node Node) (MonoType, bool) {
+	e, ok := node.(Expression)
+	if !ok {
+		return MonoType{}, false
+	}
+	return e.TypeOf(), true
+}
+
 type visitor struct {
 	f func(Node)
 }
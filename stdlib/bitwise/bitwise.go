@@ -1,6 +1,10 @@
 package bitwise
 
 import (
+	"math/bits"
+
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
 	"github.com/influxdata/flux/internal/function"
 	"github.com/influxdata/flux/values"
 )
@@ -152,6 +156,107 @@ func srshift(args *function.Arguments) (values.Value, error) {
 	}
 	return values.NewInt(a >> b), nil
 }
+func ulrotate(args *function.Arguments) (values.Value, error) {
+	a, err := args.GetRequiredUInt("a")
+	if err != nil {
+		return nil, err
+	}
+	b, err := args.GetRequiredUInt("b")
+	if err != nil {
+		return nil, err
+	}
+	return values.NewUInt(bits.RotateLeft64(a, int(b%64))), nil
+}
+func urrotate(args *function.Arguments) (values.Value, error) {
+	a, err := args.GetRequiredUInt("a")
+	if err != nil {
+		return nil, err
+	}
+	b, err := args.GetRequiredUInt("b")
+	if err != nil {
+		return nil, err
+	}
+	return values.NewUInt(bits.RotateLeft64(a, -int(b%64))), nil
+}
+func slrotate(args *function.Arguments) (values.Value, error) {
+	a, err := args.GetRequiredInt("a")
+	if err != nil {
+		return nil, err
+	}
+	b, err := args.GetRequiredInt("b")
+	if err != nil {
+		return nil, err
+	}
+	return values.NewInt(int64(bits.RotateLeft64(uint64(a), int(b%64)))), nil
+}
+func srrotate(args *function.Arguments) (values.Value, error) {
+	a, err := args.GetRequiredInt("a")
+	if err != nil {
+		return nil, err
+	}
+	b, err := args.GetRequiredInt("b")
+	if err != nil {
+		return nil, err
+	}
+	return values.NewInt(int64(bits.RotateLeft64(uint64(a), -int(b%64)))), nil
+}
+func upopcount(args *function.Arguments) (values.Value, error) {
+	a, err := args.GetRequiredUInt("a")
+	if err != nil {
+		return nil, err
+	}
+	return values.NewInt(int64(bits.OnesCount64(a))), nil
+}
+func spopcount(args *function.Arguments) (values.Value, error) {
+	a, err := args.GetRequiredInt("a")
+	if err != nil {
+		return nil, err
+	}
+	return values.NewInt(int64(bits.OnesCount64(uint64(a)))), nil
+}
+
+// extractBits returns the len bits of a starting at the bit start,
+// where bit 0 is the least significant bit.
+func extractBits(args *function.Arguments, a uint64) (uint64, error) {
+	start, err := args.GetRequiredInt("start")
+	if err != nil {
+		return 0, err
+	}
+	n, err := args.GetRequiredInt("len")
+	if err != nil {
+		return 0, err
+	}
+	if start < 0 || n <= 0 || start+n > 64 {
+		return 0, errors.Newf(codes.Invalid, "bits %d to %d are out of range, an integer has 64 bits", start, start+n-1)
+	}
+	a >>= uint64(start)
+	if n < 64 {
+		a &= 1<<uint64(n) - 1
+	}
+	return a, nil
+}
+func uextractBits(args *function.Arguments) (values.Value, error) {
+	a, err := args.GetRequiredUInt("a")
+	if err != nil {
+		return nil, err
+	}
+	v, err := extractBits(args, a)
+	if err != nil {
+		return nil, err
+	}
+	return values.NewUInt(v), nil
+}
+func sextractBits(args *function.Arguments) (values.Value, error) {
+	a, err := args.GetRequiredInt("a")
+	if err != nil {
+		return nil, err
+	}
+	v, err := extractBits(args, uint64(a))
+	if err != nil {
+		return nil, err
+	}
+	return values.NewInt(int64(v)), nil
+}
 func init() {
 	b := function.ForPackage("bitwise")
 	b.Register("uand", uand)
@@ -169,4 +274,12 @@ func init() {
 	b.Register("sclear", sclear)
 	b.Register("slshift", slshift)
 	b.Register("srshift", srshift)
+	b.Register("ulrotate", ulrotate)
+	b.Register("urrotate", urrotate)
+	b.Register("slrotate", slrotate)
+	b.Register("srrotate", srrotate)
+	b.Register("upopcount", upopcount)
+	b.Register("spopcount", spopcount)
+	b.Register("uextractBits", uextractBits)
+	b.Register("sextractBits", sextractBits)
 }
@@ -198,6 +198,56 @@ csv.from(csv:data) |> endpoint()`
 	}
 }
 
+func TestServiceNowIncident(t *testing.T) {
+	var got map[string]string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer ts.Close()
+
+	ctx := flux.NewDefaultDependencies().Inject(context.Background())
+	_, scope, err := runtime.Eval(ctx, `
+import "contrib/bonitoo-io/servicenow"
+
+status = servicenow.incident(
+    url: "`+ts.URL+`/api/now/table/incident",
+    username: "admin",
+    password: "12345",
+    shortDescription: "CPU-1 too busy",
+    urgency: "high",
+    impact: "low",
+    assignmentGroup: "Infrastructure",
+    correlationID: "Alert-#1001",
+)
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, ok := scope.Lookup("status")
+	if !ok {
+		t.Fatal("unable to find status in scope")
+	}
+	if status.Int() != http.StatusCreated {
+		t.Errorf("expected status %d, got %d", http.StatusCreated, status.Int())
+	}
+	want := map[string]string{
+		"short_description": "CPU-1 too busy",
+		"description":       "",
+		"urgency":           "1",
+		"impact":            "3",
+		"assignment_group":  "Infrastructure",
+		"category":          "",
+		"caller_id":         "",
+		"correlation_id":    "Alert-#1001",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
 type Server struct {
 	mu       sync.Mutex
 	ts       *httptest.Server
@@ -306,6 +306,36 @@ data = "
 	}
 }
 
+func TestCloseAlert(t *testing.T) {
+	s := NewServer(t)
+	defer s.Close()
+
+	ctx := flux.NewDefaultDependencies().Inject(context.Background())
+	fluxString := `
+import "contrib/sranka/opsgenie"
+
+status = opsgenie.closeAlert(url: "` + s.URL + `", apiKey: "fakeApiKey", alias: "disk usage/a", user: "mu", source: "ms", note: "mn")`
+	_, scope, err := runtime.Eval(ctx, fluxString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, ok := scope.Lookup("status")
+	if !ok {
+		t.Fatal("unable to find status in scope")
+	}
+	if got, want := status.Int(), int64(201); got != want {
+		t.Errorf("got status: %d, expected %d", got, want)
+	}
+	req := s.Request()
+	if want := "/v2/alerts/disk%20usage%2Fa/close?identifierType=alias"; req.URL != want {
+		t.Errorf("got URL: %s, expected %s", req.URL, want)
+	}
+	want := PostData{User: "mu", Source: "ms", Note: "mn"}
+	if !cmp.Equal(want, req.PostData) {
+		t.Errorf("unexpected post data -want/+got\n\n%s\n\n", cmp.Diff(want, req.PostData))
+	}
+}
+
 type Server struct {
 	mu       sync.Mutex
 	ts       *httptest.Server
@@ -370,6 +400,7 @@ type PostData struct {
 	Details     map[string]string   `json:"details"`
 	Entity      string              `json:"entity"`
 	Source      string              `json:"source"`
+	User        string              `json:"user"`
 	Priority    string              `json:"priority"`
 	Note        string              `json:"note"`
 }
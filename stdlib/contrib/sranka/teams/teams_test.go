@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/flux"
 	_ "github.com/influxdata/flux/csv"
 	"github.com/influxdata/flux/dependencies/dependenciestest"
@@ -56,6 +57,62 @@ csv.from(csv:data) |> process()
 	_ = scope
 }
 
+func TestTeamsCard(t *testing.T) {
+	var got map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer ts.Close()
+
+	ctx := flux.NewDefaultDependencies().Inject(context.Background())
+	_, scope, err := runtime.Eval(ctx, `
+import "contrib/sranka/teams"
+
+status = teams.card(
+    url: "`+ts.URL+`",
+    title: "Disk Usage",
+    text: "Disk usage is **crit**.",
+    facts: [{title: "Host", value: "h1"}],
+    links: [{title: "Dashboard", url: "https://example.com"}],
+)
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, ok := scope.Lookup("status")
+	if !ok {
+		t.Fatal("unable to find status in scope")
+	}
+	if status.Int() != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, status.Int())
+	}
+
+	var want map[string]interface{}
+	if err := json.Unmarshal([]byte(`{
+	"type": "message",
+	"attachments": [{
+		"contentType": "application/vnd.microsoft.card.adaptive",
+		"content": {
+			"type": "AdaptiveCard",
+			"version": "1.4",
+			"body": [
+				{"type": "TextBlock", "text": "Disk Usage", "size": "Medium", "weight": "Bolder", "wrap": true},
+				{"type": "TextBlock", "text": "Disk usage is **crit**.", "wrap": true},
+				{"type": "FactSet", "facts": [{"title": "Host", "value": "h1"}]}
+			],
+			"actions": [{"type": "Action.OpenUrl", "title": "Dashboard", "url": "https://example.com"}]
+		}
+	}]
+}`), &want); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected card -want/+got:\n%s", diff)
+	}
+}
+
 type Server struct {
 	mu       sync.Mutex
 	ts       *httptest.Server
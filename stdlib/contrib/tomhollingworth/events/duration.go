@@ -17,12 +17,14 @@ const pkgPath = "contrib/tomhollingworth/events"
 const DurationKind = "duration"
 
 type DurationOpSpec struct {
-	Unit       flux.Duration `json:"unit"`
-	TimeColumn string        `json:"timeColumn"`
-	ColumnName string        `json:"columnName"`
-	StopColumn string        `json:"stopColumn"`
-	Stop       flux.Time     `json:"stop"`
-	IsStop     bool
+	Unit          flux.Duration `json:"unit"`
+	TimeColumn    string        `json:"timeColumn"`
+	ColumnName    string        `json:"columnName"`
+	StopColumn    string        `json:"stopColumn"`
+	Stop          flux.Time     `json:"stop"`
+	IsStop        bool
+	SessionColumn string `json:"sessionColumn"`
+	EndColumn     string `json:"endColumn"`
 }
 
 func init() {
@@ -81,6 +83,18 @@ func createDurationOpSpec(args flux.Arguments, a *flux.Administration) (flux.Ope
 		spec.Stop = flux.Now
 	}
 
+	if sessionCol, ok, err := args.GetString("sessionColumn"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.SessionColumn = sessionCol
+	}
+
+	if endCol, ok, err := args.GetString("endColumn"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.EndColumn = endCol
+	}
+
 	return spec, nil
 }
 
@@ -90,12 +104,14 @@ func (s *DurationOpSpec) Kind() flux.OperationKind {
 
 type DurationProcedureSpec struct {
 	plan.DefaultCost
-	Unit       flux.Duration `json:"unit"`
-	TimeColumn string        `json:"timeColumn"`
-	ColumnName string        `json:"columnName"`
-	StopColumn string        `json:"stopColumn"`
-	Stop       flux.Time     `json:"stop"`
-	IsStop     bool
+	Unit          flux.Duration `json:"unit"`
+	TimeColumn    string        `json:"timeColumn"`
+	ColumnName    string        `json:"columnName"`
+	StopColumn    string        `json:"stopColumn"`
+	Stop          flux.Time     `json:"stop"`
+	IsStop        bool
+	SessionColumn string `json:"sessionColumn"`
+	EndColumn     string `json:"endColumn"`
 }
 
 func newDurationProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
@@ -105,12 +121,14 @@ func newDurationProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.P
 	}
 
 	return &DurationProcedureSpec{
-		Unit:       spec.Unit,
-		TimeColumn: spec.TimeColumn,
-		ColumnName: spec.ColumnName,
-		StopColumn: spec.StopColumn,
-		Stop:       spec.Stop,
-		IsStop:     spec.IsStop,
+		Unit:          spec.Unit,
+		TimeColumn:    spec.TimeColumn,
+		ColumnName:    spec.ColumnName,
+		StopColumn:    spec.StopColumn,
+		Stop:          spec.Stop,
+		IsStop:        spec.IsStop,
+		SessionColumn: spec.SessionColumn,
+		EndColumn:     spec.EndColumn,
 	}, nil
 }
 
@@ -120,12 +138,14 @@ func (s *DurationProcedureSpec) Kind() plan.ProcedureKind {
 
 func (s *DurationProcedureSpec) Copy() plan.ProcedureSpec {
 	return &DurationProcedureSpec{
-		Unit:       s.Unit,
-		TimeColumn: s.TimeColumn,
-		ColumnName: s.ColumnName,
-		StopColumn: s.StopColumn,
-		Stop:       s.Stop,
-		IsStop:     s.IsStop,
+		Unit:          s.Unit,
+		TimeColumn:    s.TimeColumn,
+		ColumnName:    s.ColumnName,
+		StopColumn:    s.StopColumn,
+		Stop:          s.Stop,
+		IsStop:        s.IsStop,
+		SessionColumn: s.SessionColumn,
+		EndColumn:     s.EndColumn,
 	}
 }
 
@@ -145,12 +165,14 @@ type durationTransformation struct {
 	d     execute.Dataset
 	cache execute.TableBuilderCache
 
-	unit       float64
-	timeColumn string
-	columnName string
-	stopColumn string
-	stop       values.Time
-	isStop     bool
+	unit          float64
+	timeColumn    string
+	columnName    string
+	stopColumn    string
+	stop          values.Time
+	isStop        bool
+	sessionColumn string
+	endColumn     string
 }
 
 func NewDurationTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *DurationProcedureSpec) *durationTransformation {
@@ -158,12 +180,14 @@ func NewDurationTransformation(d execute.Dataset, cache execute.TableBuilderCach
 		d:     d,
 		cache: cache,
 
-		unit:       float64(values.Duration(spec.Unit).Duration()),
-		timeColumn: spec.TimeColumn,
-		columnName: spec.ColumnName,
-		stopColumn: spec.StopColumn,
-		stop:       values.ConvertTime(spec.Stop.Absolute),
-		isStop:     spec.IsStop,
+		unit:          float64(values.Duration(spec.Unit).Duration()),
+		timeColumn:    spec.TimeColumn,
+		columnName:    spec.ColumnName,
+		stopColumn:    spec.StopColumn,
+		stop:          values.ConvertTime(spec.Stop.Absolute),
+		isStop:        spec.IsStop,
+		sessionColumn: spec.SessionColumn,
+		endColumn:     spec.EndColumn,
 	}
 }
 
@@ -191,11 +215,6 @@ func (t *durationTransformation) Process(id execute.DatasetID, tbl flux.Table) e
 	cols := tbl.Cols()
 	numCol := 0
 
-	err := execute.AddTableCols(tbl, builder)
-	if err != nil {
-		return err
-	}
-
 	timeIdx := execute.ColIdx(t.timeColumn, cols)
 	if timeIdx < 0 {
 		return errors.Newf(codes.FailedPrecondition, "column %q does not exist", t.timeColumn)
@@ -211,6 +230,15 @@ func (t *durationTransformation) Process(id execute.DatasetID, tbl flux.Table) e
 		}
 	}
 
+	if t.sessionColumn != "" || t.endColumn != "" {
+		return t.processSessions(tbl, builder, timeIdx, stopIdx)
+	}
+
+	err := execute.AddTableCols(tbl, builder)
+	if err != nil {
+		return err
+	}
+
 	timeCol := cols[timeIdx]
 	if timeCol.Type == flux.TTime {
 		if numCol, err = builder.AddCol(flux.ColMeta{
@@ -289,3 +317,110 @@ func (t *durationTransformation) Process(id execute.DatasetID, tbl flux.Table) e
 	}
 	return nil
 }
+
+// processSessions computes the duration of each event in a table that
+// contains overlapping sessions. When there is no end column, an event lasts
+// until the next event in the same session. When there is an end column,
+// an end event closes the oldest open event in its session and any number
+// of events in a session may be open at the same time. End events are not
+// included in the output. Events that are still open when the table ends
+// last until the stop time.
+func (t *durationTransformation) processSessions(tbl flux.Table, builder execute.TableBuilder, timeIdx, stopIdx int) error {
+	cols := tbl.Cols()
+	sessionIdx, endIdx := -1, -1
+	if t.sessionColumn != "" {
+		if sessionIdx = execute.ColIdx(t.sessionColumn, cols); sessionIdx < 0 {
+			return errors.Newf(codes.FailedPrecondition, "column %q does not exist", t.sessionColumn)
+		}
+	}
+	if t.endColumn != "" {
+		if endIdx = execute.ColIdx(t.endColumn, cols); endIdx < 0 {
+			return errors.Newf(codes.FailedPrecondition, "column %q does not exist", t.endColumn)
+		} else if c := cols[endIdx]; c.Type != flux.TBool {
+			return errors.Newf(codes.FailedPrecondition, "end column %q must be of type %s, got %s", c.Label, flux.TBool, c.Type)
+		}
+	}
+
+	for j, c := range cols {
+		if j == endIdx {
+			continue
+		}
+		if _, err := builder.AddCol(c); err != nil {
+			return err
+		}
+	}
+	numCol, err := builder.AddCol(flux.ColMeta{
+		Label: t.columnName,
+		Type:  flux.TInt,
+	})
+	if err != nil {
+		return err
+	}
+	colMap := execute.ColMap([]int{0}, builder, cols)
+
+	var (
+		starts, ends []int64
+		closed       []bool
+		open         = make(map[interface{}][]int)
+		sTime        int64
+	)
+	if t.isStop {
+		sTime = int64(t.stop)
+	}
+
+	if err := tbl.Do(func(cr flux.ColReader) error {
+		l := cr.Len()
+		ts := cr.Times(timeIdx)
+		for i := 0; i < l; i++ {
+			nTime := ts.Value(i)
+			var session interface{}
+			if sessionIdx >= 0 {
+				session = values.Unwrap(execute.ValueForRow(cr, i, sessionIdx))
+			}
+
+			isEnd := endIdx >= 0 && cr.Bools(endIdx).IsValid(i) && cr.Bools(endIdx).Value(i)
+			if isEnd || endIdx < 0 {
+				// Close the oldest open event in the session.
+				if events := open[session]; len(events) > 0 {
+					ends[events[0]], closed[events[0]] = nTime, true
+					open[session] = events[1:]
+				}
+			}
+			if isEnd {
+				continue
+			}
+
+			open[session] = append(open[session], len(starts))
+			starts = append(starts, nTime)
+			ends = append(ends, 0)
+			closed = append(closed, false)
+			for j := range builder.Cols() {
+				if colMap[j] < 0 {
+					continue
+				}
+				if err := builder.AppendValue(j, execute.ValueForRow(cr, i, colMap[j])); err != nil {
+					return err
+				}
+			}
+		}
+
+		if !t.isStop && l > 0 {
+			stopTimes := cr.Times(stopIdx)
+			sTime = stopTimes.Value(l - 1)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for i, start := range starts {
+		end := sTime
+		if closed[i] {
+			end = ends[i]
+		}
+		if err := builder.AppendInt(numCol, int64((float64(end)-float64(start))/t.unit)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -621,6 +621,85 @@ func TestDuration_Process(t *testing.T) {
 				},
 			}},
 		},
+		{
+			name: "concurrent sessions",
+			spec: &events.DurationProcedureSpec{
+				Unit:          flux.ConvertDuration(time.Nanosecond),
+				TimeColumn:    execute.DefaultTimeColLabel,
+				ColumnName:    "duration",
+				StopColumn:    execute.DefaultStopColLabel,
+				SessionColumn: "job",
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "_time", Type: flux.TTime},
+					{Label: "job", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{execute.Time(10), execute.Time(1), "a"},
+					{execute.Time(10), execute.Time(2), "b"},
+					{execute.Time(10), execute.Time(3), "a"},
+					{execute.Time(10), execute.Time(5), "b"},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "_time", Type: flux.TTime},
+					{Label: "job", Type: flux.TString},
+					{Label: "duration", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(10), execute.Time(1), "a", int64(2)},
+					{execute.Time(10), execute.Time(2), "b", int64(3)},
+					{execute.Time(10), execute.Time(3), "a", int64(7)},
+					{execute.Time(10), execute.Time(5), "b", int64(5)},
+				},
+			}},
+		},
+		{
+			name: "end events",
+			spec: &events.DurationProcedureSpec{
+				Unit:          flux.ConvertDuration(time.Nanosecond),
+				TimeColumn:    execute.DefaultTimeColLabel,
+				ColumnName:    "duration",
+				StopColumn:    execute.DefaultStopColLabel,
+				SessionColumn: "job",
+				EndColumn:     "end",
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "_time", Type: flux.TTime},
+					{Label: "job", Type: flux.TString},
+					{Label: "end", Type: flux.TBool},
+				},
+				Data: [][]interface{}{
+					{execute.Time(10), execute.Time(1), "a", false},
+					{execute.Time(10), execute.Time(2), "a", false},
+					{execute.Time(10), execute.Time(3), "b", false},
+					{execute.Time(10), execute.Time(4), "a", true},
+					{execute.Time(10), execute.Time(6), "b", true},
+					{execute.Time(10), execute.Time(7), "a", true},
+					{execute.Time(10), execute.Time(8), "b", false},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "_time", Type: flux.TTime},
+					{Label: "job", Type: flux.TString},
+					{Label: "duration", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(10), execute.Time(1), "a", int64(3)},
+					{execute.Time(10), execute.Time(2), "a", int64(5)},
+					{execute.Time(10), execute.Time(3), "b", int64(3)},
+					{execute.Time(10), execute.Time(8), "b", int64(2)},
+				},
+			}},
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -15,19 +15,23 @@ import (
 	"github.com/influxdata/flux/memory"
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/runtime"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
 )
 
 const FromCSVKind = "fromCSV"
 
 type FromCSVOpSpec struct {
-	CSV  string `json:"csv"`
-	File string `json:"file"`
-	Mode string `json:"mode"`
+	CSV    string            `json:"csv"`
+	File   string            `json:"file"`
+	Mode   string            `json:"mode"`
+	Schema map[string]string `json:"schema,omitempty"`
 }
 
 const (
 	annotationMode = "annotations"
 	rawMode        = "raw"
+	inferMode      = "infer"
 )
 
 func init() {
@@ -69,6 +73,28 @@ func createFromCSVOpSpec(args flux.Arguments, a *flux.Administration) (flux.Oper
 		spec.Mode = annotationMode
 	}
 
+	if schema, ok, err := args.GetObject("schema"); err != nil {
+		return nil, err
+	} else if ok {
+		if spec.Mode != rawMode && spec.Mode != inferMode {
+			return nil, errors.New(codes.Invalid, "schema can only be used with the raw or infer mode")
+		}
+		spec.Schema = make(map[string]string, schema.Len())
+		schema.Range(func(label string, v values.Value) {
+			if err != nil {
+				return
+			}
+			if v.Type().Nature() != semantic.String {
+				err = errors.Newf(codes.Invalid, "schema column %q must be a string datatype, got %v", label, v.Type())
+				return
+			}
+			spec.Schema[label] = v.Str()
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return spec, nil
 }
 
@@ -78,9 +104,10 @@ func (s *FromCSVOpSpec) Kind() flux.OperationKind {
 
 type FromCSVProcedureSpec struct {
 	plan.DefaultCost
-	CSV  string
-	File string
-	Mode string
+	CSV    string
+	File   string
+	Mode   string
+	Schema map[string]string
 }
 
 func newFromCSVProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
@@ -90,9 +117,10 @@ func newFromCSVProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.Pr
 	}
 
 	return &FromCSVProcedureSpec{
-		CSV:  spec.CSV,
-		File: spec.File,
-		Mode: spec.Mode,
+		CSV:    spec.CSV,
+		File:   spec.File,
+		Mode:   spec.Mode,
+		Schema: spec.Schema,
 	}, nil
 }
 
@@ -105,6 +133,12 @@ func (s *FromCSVProcedureSpec) Copy() plan.ProcedureSpec {
 	ns.CSV = s.CSV
 	ns.File = s.File
 	ns.Mode = s.Mode
+	if s.Schema != nil {
+		ns.Schema = make(map[string]string, len(s.Schema))
+		for k, v := range s.Schema {
+			ns.Schema[k] = v
+		}
+	}
 	return ns
 }
 
@@ -136,6 +170,7 @@ func CreateSource(spec *FromCSVProcedureSpec, dsid execute.DatasetID, a execute.
 		getDataStream: getDataStream,
 		alloc:         a.Allocator(),
 		mode:          spec.Mode,
+		schema:        spec.Schema,
 	}
 
 	return &csvSource, nil
@@ -148,6 +183,7 @@ type CSVSource struct {
 	ts            []execute.Transformation
 	alloc         memory.Allocator
 	mode          string
+	schema        map[string]string
 }
 
 func (c *CSVSource) AddTransformation(t execute.Transformation) {
@@ -172,8 +208,12 @@ func (c *CSVSource) Run(ctx context.Context) {
 		switch c.mode {
 		case rawMode:
 			config.NoAnnotations = true
+		case inferMode:
+			config.NoAnnotations = true
+			config.InferTypes = true
 		default:
 		}
+		config.Schema = c.schema
 		decoder := csv.NewMultiResultDecoder(config)
 		var data io.ReadCloser
 		data, err = c.getDataStream()
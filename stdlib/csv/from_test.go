@@ -205,6 +205,32 @@ func TestFromCSV_NewQuery(t *testing.T) {
 			Raw:     `import "csv" csv.from(csv:"telegraf", chicken:"what is this?")`,
 			WantErr: true,
 		},
+		{
+			Name:    "from schema with annotations",
+			Raw:     `import "csv" csv.from(csv: "1,2", schema: {a: "long"})`,
+			WantErr: true,
+		},
+		{
+			Name:    "from schema with invalid type",
+			Raw:     `import "csv" csv.from(csv: "1,2", mode: "raw", schema: {a: 1})`,
+			WantErr: true,
+		},
+		{
+			Name: "fromCSV infer schema",
+			Raw:  `import "csv" csv.from(csv: "a,b", mode: "infer", schema: {a: "long"})`,
+			Want: &operation.Spec{
+				Operations: []*operation.Node{
+					{
+						ID: "fromCSV0",
+						Spec: &csv.FromCSVOpSpec{
+							CSV:    "a,b",
+							Mode:   "infer",
+							Schema: map[string]string{"a": "long"},
+						},
+					},
+				},
+			},
+		},
 		{
 			Name: "fromCSV text",
 			Raw:  `import "csv" csv.from(csv: "1,2") |> range(start:-4h, stop:-2h) |> sum()`,
@@ -0,0 +1,45 @@
+package encoding
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/internal/function"
+	"github.com/influxdata/flux/values"
+)
+
+// encode returns a function that encodes a string with the encoder.
+func encode(enc func([]byte) string) function.Definition {
+	return func(args *function.Arguments) (values.Value, error) {
+		v, err := args.GetRequiredString("v")
+		if err != nil {
+			return nil, err
+		}
+		return values.NewString(enc([]byte(v))), nil
+	}
+}
+
+// decode returns a function that decodes a string with the decoder.
+func decode(name string, dec func(string) ([]byte, error)) function.Definition {
+	return func(args *function.Arguments) (values.Value, error) {
+		v, err := args.GetRequiredString("v")
+		if err != nil {
+			return nil, err
+		}
+		b, err := dec(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, codes.Invalid, "invalid %s string", name)
+		}
+		return values.NewString(string(b)), nil
+	}
+}
+
+func init() {
+	b := function.ForPackage("encoding")
+	b.Register("base64Encode", encode(base64.StdEncoding.EncodeToString))
+	b.Register("base64Decode", decode("base64", base64.StdEncoding.DecodeString))
+	b.Register("hexEncode", encode(hex.EncodeToString))
+	b.Register("hexDecode", decode("hex", hex.DecodeString))
+}
@@ -0,0 +1,226 @@
+package anomaly
+
+import (
+	"math"
+	"sort"
+
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/arrowutil"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/runtime"
+)
+
+const pkgpath = "experimental/anomaly"
+
+const (
+	MADKind         = "anomalyMAD"
+	SHESDKind       = "anomalySHESD"
+	ChangepointKind = "anomalyChangepoint"
+)
+
+const (
+	// ScoreColumn is the column that contains the score of each row.
+	ScoreColumn = "score"
+	// AnomalyColumn is the column that flags anomalous rows.
+	AnomalyColumn = "anomaly"
+	// ChangepointColumn is the column that flags the first row of a new segment.
+	ChangepointColumn = "changepoint"
+)
+
+// madScale makes the median absolute deviation a consistent
+// estimator of the standard deviation for normally distributed data.
+const madScale = 1.4826
+
+func init() {
+	runtime.RegisterPackageValue(pkgpath, "mad", flux.MustValue(flux.FunctionValue("mad", createMADOpSpec, runtime.MustLookupBuiltinType(pkgpath, "mad"))))
+	plan.RegisterProcedureSpec(MADKind, newMADProcedure, MADKind)
+	execute.RegisterTransformation(MADKind, createMADTransformation)
+
+	runtime.RegisterPackageValue(pkgpath, "shesd", flux.MustValue(flux.FunctionValue("shesd", createSHESDOpSpec, runtime.MustLookupBuiltinType(pkgpath, "shesd"))))
+	plan.RegisterProcedureSpec(SHESDKind, newSHESDProcedure, SHESDKind)
+	execute.RegisterTransformation(SHESDKind, createSHESDTransformation)
+
+	runtime.RegisterPackageValue(pkgpath, "changepoint", flux.MustValue(flux.FunctionValue("changepoint", createChangepointOpSpec, runtime.MustLookupBuiltinType(pkgpath, "changepoint"))))
+	plan.RegisterProcedureSpec(ChangepointKind, newChangepointProcedure, ChangepointKind)
+	execute.RegisterTransformation(ChangepointKind, createChangepointTransformation)
+}
+
+func readColumn(args flux.Arguments) (string, error) {
+	if col, ok, err := args.GetString("column"); err != nil {
+		return "", err
+	} else if ok {
+		return col, nil
+	}
+	return execute.DefaultValueColLabel, nil
+}
+
+// detectFunc computes a score and a flag for each of the values.
+type detectFunc func(ys []float64) (scores []float64, flags []bool)
+
+// detectTransformation buffers each table and appends a score
+// and a flag column once the entire table has been read.
+type detectTransformation struct {
+	column    string
+	flagLabel string
+	detect    detectFunc
+}
+
+func newDetectTransformation(id execute.DatasetID, column, flagLabel string, fn detectFunc, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	tr := &detectTransformation{
+		column:    column,
+		flagLabel: flagLabel,
+		detect:    fn,
+	}
+	return execute.NewAggregateTransformation(id, tr, mem)
+}
+
+type detectState struct {
+	chunks []table.Chunk
+}
+
+func (s *detectState) Close() error {
+	for _, chunk := range s.chunks {
+		chunk.Release()
+	}
+	s.chunks = nil
+	return nil
+}
+
+func (t *detectTransformation) Aggregate(chunk table.Chunk, state interface{}, mem memory.Allocator) (interface{}, bool, error) {
+	s, _ := state.(*detectState)
+	if s == nil {
+		s = &detectState{}
+	}
+	chunk.Retain()
+	s.chunks = append(s.chunks, chunk)
+	return s, true, nil
+}
+
+func (t *detectTransformation) Compute(key flux.GroupKey, state interface{}, d *execute.TransportDataset, mem memory.Allocator) error {
+	s := state.(*detectState)
+
+	// Read the non-null values from every chunk in order.
+	values := make([]func(i int) (float64, bool), len(s.chunks))
+	var ys []float64
+	for ci, chunk := range s.chunks {
+		for _, label := range []string{ScoreColumn, t.flagLabel} {
+			if chunk.Index(label) >= 0 {
+				return errors.Newf(codes.FailedPrecondition, "column %q already exists", label)
+			}
+		}
+		if chunk.Len() == 0 {
+			continue
+		}
+
+		j := chunk.Index(t.column)
+		if j < 0 {
+			return errors.Newf(codes.FailedPrecondition, "no column %q exists", t.column)
+		}
+		value, ok := arrowutil.FloatValueFunc(chunk.Values(j))
+		if !ok {
+			col := chunk.Col(j)
+			return errors.Newf(codes.FailedPrecondition, "cannot detect anomalies in column %q of type %s", col.Label, col.Type)
+		}
+		values[ci] = value
+		for i, l := 0, chunk.Len(); i < l; i++ {
+			if y, ok := value(i); ok {
+				ys = append(ys, y)
+			}
+		}
+	}
+
+	var (
+		scores []float64
+		flags  []bool
+	)
+	if len(ys) > 0 {
+		scores, flags = t.detect(ys)
+	}
+
+	n := 0
+	for ci, chunk := range s.chunks {
+		l := chunk.Len()
+		sb := array.NewFloatBuilder(mem)
+		sb.Resize(l)
+		fb := array.NewBooleanBuilder(mem)
+		fb.Resize(l)
+		for i := 0; i < l; i++ {
+			if _, ok := values[ci](i); !ok {
+				sb.AppendNull()
+				fb.Append(false)
+				continue
+			}
+			sb.Append(scores[n])
+			fb.Append(flags[n])
+			n++
+		}
+
+		buffer := chunk.Buffer()
+		out := arrow.TableBuffer{
+			GroupKey: key,
+			Columns: append(append(make([]flux.ColMeta, 0, len(buffer.Columns)+2), buffer.Columns...),
+				flux.ColMeta{Label: ScoreColumn, Type: flux.TFloat},
+				flux.ColMeta{Label: t.flagLabel, Type: flux.TBool},
+			),
+			Values: make([]array.Array, 0, len(buffer.Values)+2),
+		}
+		for _, vs := range buffer.Values {
+			vs.Retain()
+			out.Values = append(out.Values, vs)
+		}
+		out.Values = append(out.Values, sb.NewArray(), fb.NewArray())
+		if err := d.Process(table.ChunkFromBuffer(out)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *detectTransformation) Close() error { return nil }
+
+// median returns the median of the values without modifying them.
+func median(ys []float64) float64 {
+	if len(ys) == 0 {
+		return math.NaN()
+	}
+	sorted := make([]float64, len(ys))
+	copy(sorted, ys)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	return (sorted[mid-1] + sorted[mid]) / 2
+}
+
+// robustScores returns the absolute deviation of each value from
+// the median divided by the scaled median absolute deviation.
+//
+// When the median absolute deviation is zero, values equal to the
+// median have a score of zero and all others have an infinite score.
+func robustScores(ys []float64) []float64 {
+	m := median(ys)
+	scores := make([]float64, len(ys))
+	for i, y := range ys {
+		scores[i] = math.Abs(y - m)
+	}
+	mad := madScale * median(scores)
+	for i, dev := range scores {
+		switch {
+		case dev == 0:
+			scores[i] = 0
+		case mad == 0:
+			scores[i] = math.Inf(1)
+		default:
+			scores[i] = dev / mad
+		}
+	}
+	return scores
+}
@@ -0,0 +1,247 @@
+package anomaly_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/stdlib/experimental/anomaly"
+)
+
+var inputCols = []flux.ColMeta{
+	{Label: "_time", Type: flux.TTime},
+	{Label: "_value", Type: flux.TFloat},
+	{Label: "t0", Type: flux.TString},
+}
+
+// outputCols returns the input columns with the score column
+// and the flag column with the given label appended.
+func outputCols(flagLabel string) []flux.ColMeta {
+	return append(append([]flux.ColMeta{}, inputCols...),
+		flux.ColMeta{Label: anomaly.ScoreColumn, Type: flux.TFloat},
+		flux.ColMeta{Label: flagLabel, Type: flux.TBool},
+	)
+}
+
+// inputTable creates a table with a row every 10 time
+// units for each value. A nil value creates a null.
+func inputTable(values ...interface{}) *executetest.Table {
+	tbl := &executetest.Table{
+		KeyCols: []string{"t0"},
+		ColMeta: inputCols,
+	}
+	for i, v := range values {
+		tbl.Data = append(tbl.Data, []interface{}{execute.Time(i * 10), v, "a"})
+	}
+	return tbl
+}
+
+// outputTable creates the expected table for the values
+// with the scores and flags appended to each row.
+func outputTable(flagLabel string, values, scores []interface{}, flags []bool) *executetest.Table {
+	tbl := &executetest.Table{
+		KeyCols: []string{"t0"},
+		ColMeta: outputCols(flagLabel),
+	}
+	for i, v := range values {
+		tbl.Data = append(tbl.Data, []interface{}{execute.Time(i * 10), v, "a", scores[i], flags[i]})
+	}
+	return tbl
+}
+
+func TestMAD(t *testing.T) {
+	values := []interface{}{10.0, 11.0, 10.0, 12.0, 11.0, nil, 10.0, 50.0, 11.0, 10.0, 12.0}
+	// The median is 11 and the median absolute deviation is 1.
+	d := 1 / 1.4826
+	testCases := []struct {
+		name    string
+		spec    *anomaly.MADProcedureSpec
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "default threshold",
+			spec: &anomaly.MADProcedureSpec{
+				Threshold: 3,
+				Column:    execute.DefaultValueColLabel,
+			},
+			data: []flux.Table{inputTable(values...)},
+			want: []*executetest.Table{outputTable(anomaly.AnomalyColumn, values,
+				[]interface{}{d, 0.0, d, d, 0.0, nil, d, 39 * d, 0.0, d, d},
+				[]bool{false, false, false, false, false, false, false, true, false, false, false},
+			)},
+		},
+		{
+			name: "low threshold",
+			spec: &anomaly.MADProcedureSpec{
+				Threshold: 0.5,
+				Column:    execute.DefaultValueColLabel,
+			},
+			data: []flux.Table{inputTable(values...)},
+			want: []*executetest.Table{outputTable(anomaly.AnomalyColumn, values,
+				[]interface{}{d, 0.0, d, d, 0.0, nil, d, 39 * d, 0.0, d, d},
+				[]bool{true, false, true, true, false, false, true, true, false, true, true},
+			)},
+		},
+		{
+			name: "unsupported type",
+			spec: &anomaly.MADProcedureSpec{
+				Threshold: 3,
+				Column:    "t0",
+			},
+			data:    []flux.Table{inputTable(values...)},
+			wantErr: errors.New(codes.FailedPrecondition, `cannot detect anomalies in column "t0" of type string`),
+		},
+		{
+			name: "score column exists",
+			spec: &anomaly.MADProcedureSpec{
+				Threshold: 3,
+				Column:    execute.DefaultValueColLabel,
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_value", Type: flux.TFloat},
+					{Label: "score", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{1.0, 1.0},
+				},
+			}},
+			wantErr: errors.New(codes.FailedPrecondition, `column "score" already exists`),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper2(
+				t,
+				tc.data,
+				tc.want,
+				tc.wantErr,
+				func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+					tr, d, err := anomaly.NewMADTransformation(id, tc.spec, alloc)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return tr, d
+				},
+			)
+		})
+	}
+}
+
+func TestSHESD(t *testing.T) {
+	d := 1 / 1.4826
+	values := []interface{}{10.0, 11.0, 10.0, 12.0, 11.0, 10.0, 50.0, 11.0, 10.0, 12.0}
+	seasonal := []interface{}{1.0, 5.0, 1.0, 5.0, 1.0, 5.0, 1.0, 5.0, 1.0, 5.0, 20.0, 5.0, 1.0, 5.0, 1.0, 5.0}
+	testCases := []struct {
+		name string
+		spec *anomaly.SHESDProcedureSpec
+		data []flux.Table
+		want []*executetest.Table
+	}{
+		{
+			name: "no period",
+			spec: &anomaly.SHESDProcedureSpec{
+				Alpha:        0.05,
+				MaxAnomalies: 0.2,
+				Column:       execute.DefaultValueColLabel,
+			},
+			data: []flux.Table{inputTable(values...)},
+			want: []*executetest.Table{outputTable(anomaly.AnomalyColumn, values,
+				[]interface{}{d, 0.0, d, d, 0.0, d, 39 * d, 0.0, d, d},
+				[]bool{false, false, false, false, false, false, true, false, false, false},
+			)},
+		},
+		{
+			name: "period",
+			spec: &anomaly.SHESDProcedureSpec{
+				Period:       2,
+				Alpha:        0.05,
+				MaxAnomalies: 0.2,
+				Column:       execute.DefaultValueColLabel,
+			},
+			data: []flux.Table{inputTable(seasonal...)},
+			want: []*executetest.Table{outputTable(anomaly.AnomalyColumn, seasonal,
+				[]interface{}{0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, math.Inf(1), 0.0, 0.0, 0.0, 0.0, 0.0},
+				[]bool{false, false, false, false, false, false, false, false, false, false, true, false, false, false, false, false},
+			)},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper2(
+				t,
+				tc.data,
+				tc.want,
+				nil,
+				func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+					tr, d, err := anomaly.NewSHESDTransformation(id, tc.spec, alloc)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return tr, d
+				},
+			)
+		})
+	}
+}
+
+func TestChangepoint(t *testing.T) {
+	values := []interface{}{1.0, 1.2, 0.9, 1.1, 1.0, 5.0, 5.1, 4.9, 5.2, 5.0, 5.1, 4.8}
+	testCases := []struct {
+		name string
+		spec *anomaly.ChangepointProcedureSpec
+		data []flux.Table
+		want []*executetest.Table
+	}{
+		{
+			name: "mean shift",
+			spec: &anomaly.ChangepointProcedureSpec{
+				Column: execute.DefaultValueColLabel,
+			},
+			data: []flux.Table{inputTable(values...)},
+			want: []*executetest.Table{outputTable(anomaly.ChangepointColumn, values,
+				[]interface{}{0.0, 0.0, 0.0, 0.0, 0.0, 18.954838654689386, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0},
+				[]bool{false, false, false, false, false, true, false, false, false, false, false, false},
+			)},
+		},
+		{
+			name: "constant",
+			spec: &anomaly.ChangepointProcedureSpec{
+				Column: execute.DefaultValueColLabel,
+			},
+			data: []flux.Table{inputTable(2.0, 2.0, 2.0)},
+			want: []*executetest.Table{outputTable(anomaly.ChangepointColumn,
+				[]interface{}{2.0, 2.0, 2.0},
+				[]interface{}{0.0, 0.0, 0.0},
+				[]bool{false, false, false},
+			)},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper2(
+				t,
+				tc.data,
+				tc.want,
+				nil,
+				func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+					tr, d, err := anomaly.NewChangepointTransformation(id, tc.spec, alloc)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return tr, d
+				},
+			)
+		})
+	}
+}
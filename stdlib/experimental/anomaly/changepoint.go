@@ -0,0 +1,195 @@
+package anomaly
+
+import (
+	"math"
+
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/plan"
+)
+
+type ChangepointOpSpec struct {
+	// Penalty is the cost of adding a changepoint.
+	// A zero value uses a penalty of 2 * ln(n).
+	Penalty float64 `json:"penalty"`
+	Column  string  `json:"column"`
+}
+
+func createChangepointOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(ChangepointOpSpec)
+	if penalty, ok, err := args.GetFloat("penalty"); err != nil {
+		return nil, err
+	} else if ok {
+		if penalty <= 0 {
+			return nil, errors.Newf(codes.Invalid, "penalty must be greater than zero, got %v", penalty)
+		}
+		spec.Penalty = penalty
+	}
+
+	col, err := readColumn(args)
+	if err != nil {
+		return nil, err
+	}
+	spec.Column = col
+	return spec, nil
+}
+
+func (s *ChangepointOpSpec) Kind() flux.OperationKind {
+	return ChangepointKind
+}
+
+type ChangepointProcedureSpec struct {
+	plan.DefaultCost
+	Penalty float64
+	Column  string
+}
+
+func newChangepointProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*ChangepointOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+	return &ChangepointProcedureSpec{
+		Penalty: spec.Penalty,
+		Column:  spec.Column,
+	}, nil
+}
+
+func (s *ChangepointProcedureSpec) Kind() plan.ProcedureKind {
+	return ChangepointKind
+}
+
+func (s *ChangepointProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+func createChangepointTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*ChangepointProcedureSpec)
+	if !ok {
+		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
+	}
+	return NewChangepointTransformation(id, s, a.Allocator())
+}
+
+// NewChangepointTransformation constructs a transformation that flags
+// the rows where the mean of the values changes using the pruned exact
+// linear time (PELT) method.
+func NewChangepointTransformation(id execute.DatasetID, spec *ChangepointProcedureSpec, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	return newDetectTransformation(id, spec.Column, ChangepointColumn, func(ys []float64) ([]float64, []bool) {
+		return changepoints(ys, spec.Penalty)
+	}, mem)
+}
+
+// changepoints finds the changes in the mean of the values.
+//
+// The values are standardized with a robust estimate of their standard
+// deviation. The first value of each new segment is flagged and scored
+// with the difference between its mean and the mean of the previous
+// segment in standard deviations. All other values have a score of zero.
+func changepoints(ys []float64, penalty float64) ([]float64, []bool) {
+	n := len(ys)
+	scores := make([]float64, n)
+	flags := make([]bool, n)
+
+	sigma := noiseDeviation(ys)
+	if sigma == 0 {
+		return scores, flags
+	}
+	if penalty == 0 {
+		penalty = 2 * math.Log(float64(n))
+	}
+
+	// Prefix sums of the standardized values and their squares
+	// give the cost of any segment in constant time.
+	s1, s2 := make([]float64, n+1), make([]float64, n+1)
+	for i, y := range ys {
+		z := y / sigma
+		s1[i+1] = s1[i] + z
+		s2[i+1] = s2[i] + z*z
+	}
+	cost := func(start, stop int) float64 {
+		sum := s1[stop] - s1[start]
+		return s2[stop] - s2[start] - sum*sum/float64(stop-start)
+	}
+
+	f := make([]float64, n+1)
+	last := make([]int, n+1)
+	f[0] = -penalty
+	candidates := []int{0}
+	for t := 1; t <= n; t++ {
+		f[t] = math.Inf(1)
+		for _, tau := range candidates {
+			if c := f[tau] + cost(tau, t) + penalty; c < f[t] {
+				f[t], last[t] = c, tau
+			}
+		}
+
+		// Prune candidates that can never be optimal.
+		pruned := candidates[:0]
+		for _, tau := range candidates {
+			if f[tau]+cost(tau, t) <= f[t] {
+				pruned = append(pruned, tau)
+			}
+		}
+		candidates = append(pruned, t)
+	}
+
+	// Walk back through the optimal segmentation.
+	var bounds []int
+	for t := n; t > 0; t = last[t] {
+		bounds = append(bounds, t)
+	}
+	bounds = append(bounds, 0)
+
+	prev := math.NaN()
+	for i := len(bounds) - 1; i > 0; i-- {
+		start, stop := bounds[i], bounds[i-1]
+		mean := (s1[stop] - s1[start]) / float64(stop-start)
+		if start > 0 {
+			scores[start] = math.Abs(mean - prev)
+			flags[start] = true
+		}
+		prev = mean
+	}
+	return scores, flags
+}
+
+// noiseDeviation estimates the standard deviation of the noise in the
+// values from the median absolute deviation of consecutive differences,
+// which is not affected by changes in the mean. When the differences do
+// not vary, the standard deviation of the values is used instead.
+func noiseDeviation(ys []float64) float64 {
+	if len(ys) < 2 {
+		return 0
+	}
+	diffs := make([]float64, len(ys)-1)
+	for i := range diffs {
+		diffs[i] = ys[i+1] - ys[i]
+	}
+	m := median(diffs)
+	for i, d := range diffs {
+		diffs[i] = math.Abs(d - m)
+	}
+	if sigma := madScale * median(diffs) / math.Sqrt2; sigma > 0 {
+		return sigma
+	}
+
+	var mean float64
+	for _, y := range ys {
+		mean += y
+	}
+	mean /= float64(len(ys))
+	var variance float64
+	for _, y := range ys {
+		variance += (y - mean) * (y - mean)
+	}
+	return math.Sqrt(variance / float64(len(ys)))
+}
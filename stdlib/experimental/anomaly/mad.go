@@ -0,0 +1,91 @@
+package anomaly
+
+import (
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/plan"
+)
+
+type MADOpSpec struct {
+	Threshold float64 `json:"threshold"`
+	Column    string  `json:"column"`
+}
+
+func createMADOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(MADOpSpec)
+	if threshold, ok, err := args.GetFloat("threshold"); err != nil {
+		return nil, err
+	} else if ok {
+		if threshold <= 0 {
+			return nil, errors.Newf(codes.Invalid, "threshold must be greater than zero, got %v", threshold)
+		}
+		spec.Threshold = threshold
+	} else {
+		spec.Threshold = 3.0
+	}
+
+	col, err := readColumn(args)
+	if err != nil {
+		return nil, err
+	}
+	spec.Column = col
+	return spec, nil
+}
+
+func (s *MADOpSpec) Kind() flux.OperationKind {
+	return MADKind
+}
+
+type MADProcedureSpec struct {
+	plan.DefaultCost
+	Threshold float64
+	Column    string
+}
+
+func newMADProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*MADOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+	return &MADProcedureSpec{
+		Threshold: spec.Threshold,
+		Column:    spec.Column,
+	}, nil
+}
+
+func (s *MADProcedureSpec) Kind() plan.ProcedureKind {
+	return MADKind
+}
+
+func (s *MADProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+func createMADTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*MADProcedureSpec)
+	if !ok {
+		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
+	}
+	return NewMADTransformation(id, s, a.Allocator())
+}
+
+// NewMADTransformation constructs a transformation that scores each row
+// by its distance from the median in median absolute deviations.
+func NewMADTransformation(id execute.DatasetID, spec *MADProcedureSpec, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	return newDetectTransformation(id, spec.Column, AnomalyColumn, func(ys []float64) ([]float64, []bool) {
+		scores := robustScores(ys)
+		flags := make([]bool, len(scores))
+		for i, score := range scores {
+			flags[i] = score > spec.Threshold
+		}
+		return scores, flags
+	}, mem)
+}
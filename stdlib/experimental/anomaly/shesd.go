@@ -0,0 +1,210 @@
+package anomaly
+
+import (
+	"math"
+
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/plan"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+type SHESDOpSpec struct {
+	Period       int64   `json:"period"`
+	Alpha        float64 `json:"alpha"`
+	MaxAnomalies float64 `json:"maxAnomalies"`
+	Column       string  `json:"column"`
+}
+
+func createSHESDOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(SHESDOpSpec)
+	if period, ok, err := args.GetInt("period"); err != nil {
+		return nil, err
+	} else if ok {
+		if period < 0 {
+			return nil, errors.Newf(codes.Invalid, "period must not be negative, got %d", period)
+		}
+		spec.Period = period
+	}
+
+	if alpha, ok, err := args.GetFloat("alpha"); err != nil {
+		return nil, err
+	} else if ok {
+		if alpha <= 0 || alpha >= 1 {
+			return nil, errors.Newf(codes.Invalid, "alpha must be between 0 and 1, got %v", alpha)
+		}
+		spec.Alpha = alpha
+	} else {
+		spec.Alpha = 0.05
+	}
+
+	if maxAnomalies, ok, err := args.GetFloat("maxAnomalies"); err != nil {
+		return nil, err
+	} else if ok {
+		if maxAnomalies <= 0 || maxAnomalies > 0.5 {
+			return nil, errors.Newf(codes.Invalid, "maxAnomalies must be greater than 0 and at most 0.5, got %v", maxAnomalies)
+		}
+		spec.MaxAnomalies = maxAnomalies
+	} else {
+		spec.MaxAnomalies = 0.1
+	}
+
+	col, err := readColumn(args)
+	if err != nil {
+		return nil, err
+	}
+	spec.Column = col
+	return spec, nil
+}
+
+func (s *SHESDOpSpec) Kind() flux.OperationKind {
+	return SHESDKind
+}
+
+type SHESDProcedureSpec struct {
+	plan.DefaultCost
+	Period       int64
+	Alpha        float64
+	MaxAnomalies float64
+	Column       string
+}
+
+func newSHESDProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*SHESDOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+	return &SHESDProcedureSpec{
+		Period:       spec.Period,
+		Alpha:        spec.Alpha,
+		MaxAnomalies: spec.MaxAnomalies,
+		Column:       spec.Column,
+	}, nil
+}
+
+func (s *SHESDProcedureSpec) Kind() plan.ProcedureKind {
+	return SHESDKind
+}
+
+func (s *SHESDProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+func createSHESDTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*SHESDProcedureSpec)
+	if !ok {
+		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
+	}
+	return NewSHESDTransformation(id, s, a.Allocator())
+}
+
+// NewSHESDTransformation constructs a transformation that flags anomalies
+// using the seasonal hybrid extreme studentized deviate test.
+func NewSHESDTransformation(id execute.DatasetID, spec *SHESDProcedureSpec, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	return newDetectTransformation(id, spec.Column, AnomalyColumn, func(ys []float64) ([]float64, []bool) {
+		residuals := deseasonalize(ys, int(spec.Period))
+		return robustScores(residuals), hybridESD(residuals, spec.Alpha, spec.MaxAnomalies)
+	}, mem)
+}
+
+// deseasonalize removes the seasonal component and the median from the values.
+//
+// The seasonal component of each phase of the period is estimated
+// as the median of the values in that phase.
+func deseasonalize(ys []float64, period int) []float64 {
+	residuals := make([]float64, len(ys))
+	if period > 1 && period < len(ys) {
+		phase := make([]float64, 0, len(ys)/period+1)
+		for p := 0; p < period; p++ {
+			phase = phase[:0]
+			for i := p; i < len(ys); i += period {
+				phase = append(phase, ys[i])
+			}
+			seasonal := median(phase)
+			for i := p; i < len(ys); i += period {
+				residuals[i] = ys[i] - seasonal
+			}
+		}
+	} else {
+		copy(residuals, ys)
+	}
+
+	m := median(residuals)
+	for i := range residuals {
+		residuals[i] -= m
+	}
+	return residuals
+}
+
+// hybridESD runs the generalized extreme studentized deviate test
+// using the median and median absolute deviation in place of the
+// mean and standard deviation and flags the detected anomalies.
+func hybridESD(rs []float64, alpha, maxAnomalies float64) []bool {
+	flags := make([]bool, len(rs))
+	k := int(maxAnomalies * float64(len(rs)))
+
+	remaining := make([]int, len(rs))
+	for i := range remaining {
+		remaining[i] = i
+	}
+	candidates := make([]int, 0, k)
+	detected := 0
+
+	values := make([]float64, 0, len(rs))
+	for i := 1; i <= k; i++ {
+		n := len(remaining)
+		if n < 3 {
+			break
+		}
+
+		values = values[:0]
+		for _, idx := range remaining {
+			values = append(values, rs[idx])
+		}
+		m := median(values)
+		for j, v := range values {
+			values[j] = math.Abs(v - m)
+		}
+		mad := madScale * median(values)
+
+		// Find the most extreme remaining value.
+		maxJ := 0
+		for j, dev := range values {
+			if dev > values[maxJ] {
+				maxJ = j
+			}
+		}
+		if values[maxJ] == 0 {
+			break
+		}
+		// Any deviation is infinitely extreme when the
+		// remaining values are mostly identical.
+		r := math.Inf(1)
+		if mad > 0 {
+			r = values[maxJ] / mad
+		}
+
+		p := 1 - alpha/(2*float64(n))
+		t := distuv.StudentsT{Mu: 0, Sigma: 1, Nu: float64(n - 2)}.Quantile(p)
+		lambda := float64(n-1) * t / math.Sqrt((float64(n-2)+t*t)*float64(n))
+
+		candidates = append(candidates, remaining[maxJ])
+		if r > lambda {
+			detected = i
+		}
+		remaining = append(remaining[:maxJ], remaining[maxJ+1:]...)
+	}
+
+	for _, idx := range candidates[:detected] {
+		flags[idx] = true
+	}
+	return flags
+}
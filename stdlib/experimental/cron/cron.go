@@ -0,0 +1,213 @@
+package cron
+
+import (
+	"context"
+	"time"
+
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/date"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/internal/zoneinfo"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/runtime"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+const pkgpath = "experimental/cron"
+
+func init() {
+	runtime.RegisterPackageValue(pkgpath, "parse", values.NewFunction(
+		"parse",
+		runtime.MustLookupBuiltinType(pkgpath, "parse"),
+		func(ctx context.Context, args values.Object) (values.Value, error) {
+			return interpreter.DoFunctionCallContext(parse, ctx, args)
+		}, false,
+	))
+	runtime.RegisterPackageValue(pkgpath, "_next", values.NewFunction(
+		"_next",
+		runtime.MustLookupBuiltinType(pkgpath, "_next"),
+		func(ctx context.Context, args values.Object) (values.Value, error) {
+			return interpreter.DoFunctionCallContext(next, ctx, args)
+		}, false,
+	))
+	runtime.RegisterPackageValue(pkgpath, "_matches", values.NewFunction(
+		"_matches",
+		runtime.MustLookupBuiltinType(pkgpath, "_matches"),
+		func(ctx context.Context, args values.Object) (values.Value, error) {
+			return interpreter.DoFunctionCallContext(matches, ctx, args)
+		}, false,
+	))
+}
+
+// fieldLabels are the labels of the fields of a schedule record.
+var fieldLabels = [...]string{
+	minuteField.name,
+	hourField.name,
+	monthDayField.name,
+	monthField.name,
+	weekDayField.name,
+}
+
+func (s *Schedule) fields() [5]*uint64 {
+	return [...]*uint64{&s.Minute, &s.Hour, &s.MonthDay, &s.Month, &s.WeekDay}
+}
+
+func parse(ctx context.Context, args interpreter.Arguments) (values.Value, error) {
+	spec, err := args.GetRequiredString("spec")
+	if err != nil {
+		return nil, err
+	}
+	s, err := Parse(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	vs := make(map[string]values.Value, len(fieldLabels))
+	for i, set := range s.fields() {
+		elems := make([]values.Value, 0, 60)
+		for _, v := range Values(*set) {
+			elems = append(elems, values.NewInt(int64(v)))
+		}
+		vs[fieldLabels[i]] = values.NewArrayWithBacking(semantic.NewArrayType(semantic.BasicInt), elems)
+	}
+	return values.NewObjectWithValues(vs), nil
+}
+
+// scheduleFromArgs reads the schedule record produced by parse.
+func scheduleFromArgs(args interpreter.Arguments) (Schedule, error) {
+	obj, err := args.GetRequiredObject("schedule")
+	if err != nil {
+		return Schedule{}, err
+	}
+
+	var s Schedule
+	ranges := [...]field{minuteField, hourField, monthDayField, monthField, weekDayField}
+	for i, set := range s.fields() {
+		f := ranges[i]
+		v, ok := obj.Get(f.name)
+		if !ok {
+			return Schedule{}, errors.Newf(codes.Invalid, "schedule is missing the %q property", f.name)
+		} else if v.Type().Nature() != semantic.Array {
+			return Schedule{}, errors.Newf(codes.Invalid, "schedule property %q is not an array: %v", f.name, v.Type())
+		}
+		var rangeErr error
+		v.Array().Range(func(_ int, elem values.Value) {
+			if rangeErr != nil {
+				return
+			}
+			if elem.Type().Nature() != semantic.Int {
+				rangeErr = errors.Newf(codes.Invalid, "schedule property %q must contain integers, got %v", f.name, elem.Type())
+				return
+			}
+			n := elem.Int()
+			if n < int64(f.min) || n > int64(f.max) {
+				rangeErr = errors.Newf(codes.Invalid, "value %d of schedule property %q is out of range [%d, %d]", n, f.name, f.min, f.max)
+				return
+			}
+			*set |= 1 << uint(n)
+		})
+		if rangeErr != nil {
+			return Schedule{}, rangeErr
+		}
+	}
+	return s, nil
+}
+
+// clock converts between times and the clock times of a location.
+type clock struct {
+	loc    *zoneinfo.Location
+	offset time.Duration
+}
+
+func clockFromArgs(args interpreter.Arguments) (clock, error) {
+	name, offset, err := date.GetLocationFromFluxArgs(args)
+	if err != nil {
+		return clock{}, err
+	}
+	if offset.IsMixed() {
+		return clock{}, errors.New(codes.Invalid, "location offset must not be a mixed duration")
+	}
+	c := clock{offset: offset.Duration()}
+	if name != "UTC" {
+		if c.loc, err = zoneinfo.LoadLocation(name); err != nil {
+			return clock{}, errors.New(codes.Invalid, "invalid location")
+		}
+	}
+	return c, nil
+}
+
+// local returns the clock time of t in the location.
+// The clock time is represented as a time in UTC.
+func (c clock) local(t values.Time) time.Time {
+	ns := int64(t)
+	if c.loc != nil {
+		ns = c.loc.FromLocalClock(ns)
+	}
+	return time.Unix(0, ns).UTC().Add(c.offset)
+}
+
+// time returns the time of the clock time in the location.
+func (c clock) time(local time.Time) values.Time {
+	ns := local.Add(-c.offset).UnixNano()
+	if c.loc != nil {
+		ns = c.loc.ToLocalClock(ns)
+	}
+	return values.Time(ns)
+}
+
+func getTime(args interpreter.Arguments, name string) (values.Time, error) {
+	v, err := args.GetRequired(name)
+	if err != nil {
+		return 0, err
+	}
+	if v.Type().Nature() != semantic.Time {
+		return 0, errors.Newf(codes.Invalid, "parameter %q is not of type time: %v", name, v.Type())
+	}
+	return v.Time(), nil
+}
+
+func next(ctx context.Context, args interpreter.Arguments) (values.Value, error) {
+	s, err := scheduleFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	after, err := getTime(args, "after")
+	if err != nil {
+		return nil, err
+	}
+	c, err := clockFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	local := c.local(after)
+	for {
+		n, ok := s.Next(local)
+		if !ok {
+			return nil, errors.New(codes.Invalid, "cron schedule does not match any time in the next five years")
+		}
+		// Skip clock times that do not exist in the location
+		// because of a daylight saving time transition.
+		if t := c.time(n); t > after && c.local(t).Equal(n) {
+			return values.NewTime(t), nil
+		}
+		local = n
+	}
+}
+
+func matches(ctx context.Context, args interpreter.Arguments) (values.Value, error) {
+	s, err := scheduleFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	t, err := getTime(args, "t")
+	if err != nil {
+		return nil, err
+	}
+	c, err := clockFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	return values.NewBool(s.Matches(c.local(t))), nil
+}
@@ -0,0 +1,247 @@
+package cron
+
+import (
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+)
+
+// field describes the range and names of the values of a cron field.
+type field struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField   = field{name: "minute", min: 0, max: 59}
+	hourField     = field{name: "hour", min: 0, max: 23}
+	monthDayField = field{name: "monthDay", min: 1, max: 31}
+	monthField    = field{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	weekDayField = field{name: "weekDay", min: 0, max: 6, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// all returns the set of all values of the field.
+func (f field) all() uint64 {
+	return bitRange(f.min, f.max, 1)
+}
+
+// macros are the predefined schedules that can be used in place of the five fields.
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// maxYears is the number of years searched for the next time
+// before a schedule is considered to never match.
+const maxYears = 5
+
+// Schedule is a parsed cron expression.
+// Each field is a set of values where bit i is set if the value i matches.
+type Schedule struct {
+	Minute   uint64
+	Hour     uint64
+	MonthDay uint64
+	Month    uint64
+	WeekDay  uint64
+}
+
+// Parse parses a cron expression with the five standard fields:
+// minute, hour, day of month, month and day of week.
+//
+// Each field is either `*` or a comma separated list of values and
+// ranges (for example, `1-5`), optionally followed by a step (for example, `*/15`).
+// Months and days of the week can be given by their three-letter English names
+// and `7` is accepted as Sunday. The macros `@yearly`, `@annually`, `@monthly`,
+// `@weekly`, `@daily`, `@midnight` and `@hourly` are also supported.
+func Parse(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if strings.HasPrefix(spec, "@") {
+		expanded, ok := macros[strings.ToLower(spec)]
+		if !ok {
+			return Schedule{}, errors.Newf(codes.Invalid, "unknown cron macro %q", spec)
+		}
+		spec = expanded
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return Schedule{}, errors.Newf(codes.Invalid, "cron expression %q must have 5 fields, got %d", spec, len(fields))
+	}
+
+	var (
+		s   Schedule
+		err error
+	)
+	if s.Minute, err = parseField(fields[0], minuteField); err != nil {
+		return Schedule{}, err
+	}
+	if s.Hour, err = parseField(fields[1], hourField); err != nil {
+		return Schedule{}, err
+	}
+	if s.MonthDay, err = parseField(fields[2], monthDayField); err != nil {
+		return Schedule{}, err
+	}
+	if s.Month, err = parseField(fields[3], monthField); err != nil {
+		return Schedule{}, err
+	}
+	// Sunday can be written as both 0 and 7.
+	weekDay := weekDayField
+	weekDay.max = 7
+	if s.WeekDay, err = parseField(fields[4], weekDay); err != nil {
+		return Schedule{}, err
+	}
+	if s.WeekDay&(1<<7) != 0 {
+		s.WeekDay = s.WeekDay&^(1<<7) | 1
+	}
+	return s, nil
+}
+
+func parseField(expr string, f field) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(expr, ",") {
+		v, err := parsePart(part, f)
+		if err != nil {
+			return 0, err
+		}
+		set |= v
+	}
+	return set, nil
+}
+
+// parsePart parses a single value, range or `*` of a field with an optional step.
+func parsePart(part string, f field) (uint64, error) {
+	rng, step := part, 1
+	if i := strings.Index(part, "/"); i >= 0 {
+		rng = part[:i]
+		n, err := strconv.Atoi(part[i+1:])
+		if err != nil || n <= 0 {
+			return 0, errors.Newf(codes.Invalid, "invalid step %q in cron %s field", part[i+1:], f.name)
+		}
+		step = n
+	}
+
+	var start, end int
+	switch i := strings.Index(rng, "-"); {
+	case rng == "*":
+		start, end = f.min, f.max
+	case i >= 0:
+		var err error
+		if start, err = f.value(rng[:i]); err != nil {
+			return 0, err
+		}
+		if end, err = f.value(rng[i+1:]); err != nil {
+			return 0, err
+		}
+		if start > end {
+			return 0, errors.Newf(codes.Invalid, "invalid range %q in cron %s field", rng, f.name)
+		}
+	default:
+		v, err := f.value(rng)
+		if err != nil {
+			return 0, err
+		}
+		start, end = v, v
+		// A single value with a step means from the value to the maximum.
+		if step > 1 {
+			end = f.max
+		}
+	}
+	return bitRange(start, end, step), nil
+}
+
+// value parses a number or a name of the field.
+func (f field) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, errors.Newf(codes.Invalid, "invalid value %q in cron %s field", s, f.name)
+	}
+	if v < f.min || v > f.max {
+		return 0, errors.Newf(codes.Invalid, "value %d of cron %s field is out of range [%d, %d]", v, f.name, f.min, f.max)
+	}
+	return v, nil
+}
+
+func bitRange(start, end, step int) uint64 {
+	var set uint64
+	for i := start; i <= end; i += step {
+		set |= 1 << uint(i)
+	}
+	return set
+}
+
+// Values returns the values of a field set in ascending order.
+func Values(set uint64) []int {
+	vs := make([]int, 0, bits.OnesCount64(set))
+	for set != 0 {
+		i := bits.TrailingZeros64(set)
+		vs = append(vs, i)
+		set &^= 1 << uint(i)
+	}
+	return vs
+}
+
+// Matches reports whether the schedule matches the minute of the clock time t.
+//
+// As with the standard cron, when both the day of the month and the
+// day of the week are restricted, a day matches if either of them matches.
+func (s Schedule) Matches(t time.Time) bool {
+	return s.Month&(1<<uint(t.Month())) != 0 &&
+		s.matchesDay(t) &&
+		s.Hour&(1<<uint(t.Hour())) != 0 &&
+		s.Minute&(1<<uint(t.Minute())) != 0
+}
+
+func (s Schedule) matchesDay(t time.Time) bool {
+	monthDay := s.MonthDay&(1<<uint(t.Day())) != 0
+	weekDay := s.WeekDay&(1<<uint(t.Weekday())) != 0
+	if s.MonthDay&monthDayField.all() == monthDayField.all() || s.WeekDay&weekDayField.all() == weekDayField.all() {
+		return monthDay && weekDay
+	}
+	return monthDay || weekDay
+}
+
+// Next returns the first clock time after t that matches the schedule.
+// It returns false if the schedule does not match within the next
+// five years, for example, for February 30.
+func (s Schedule) Next(t time.Time) (time.Time, bool) {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxYears, 0, 0)
+	for t.Before(limit) {
+		if s.Month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.Hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.Minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t, true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,160 @@
+package cron_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux/stdlib/experimental/cron"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		spec    string
+		want    [][]int
+		wantErr string
+	}{
+		{
+			spec: "*/20 9-17 1,15 JAN-mar/2 MON-FRI",
+			want: [][]int{
+				{0, 20, 40},
+				{9, 10, 11, 12, 13, 14, 15, 16, 17},
+				{1, 15},
+				{1, 3},
+				{1, 2, 3, 4, 5},
+			},
+		},
+		{
+			spec: "5/30 0 * * 7",
+			want: [][]int{
+				{5, 35},
+				{0},
+				{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31},
+				{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+				{0},
+			},
+		},
+		{
+			spec: "@weekly",
+			want: [][]int{
+				{0},
+				{0},
+				{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31},
+				{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+				{0},
+			},
+		},
+		{
+			spec:    "* * * *",
+			wantErr: `cron expression "* * * *" must have 5 fields, got 4`,
+		},
+		{
+			spec:    "60 * * * *",
+			wantErr: "value 60 of cron minute field is out of range [0, 59]",
+		},
+		{
+			spec:    "* 17-9 * * *",
+			wantErr: `invalid range "17-9" in cron hour field`,
+		},
+		{
+			spec:    "*/0 * * * *",
+			wantErr: `invalid step "0" in cron minute field`,
+		},
+		{
+			spec:    "@often",
+			wantErr: `unknown cron macro "@often"`,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.spec, func(t *testing.T) {
+			s, err := cron.Parse(tc.spec)
+			if tc.wantErr != "" {
+				if err == nil {
+					t.Fatalf("expected error %q", tc.wantErr)
+				} else if err.Error() != tc.wantErr {
+					t.Fatalf("unexpected error -want/+got:\n\t- %s\n\t+ %s", tc.wantErr, err)
+				}
+				return
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			got := [][]int{
+				cron.Values(s.Minute),
+				cron.Values(s.Hour),
+				cron.Values(s.MonthDay),
+				cron.Values(s.Month),
+				cron.Values(s.WeekDay),
+			}
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected schedule -want/+got:\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	testCases := []struct {
+		spec  string
+		after string
+		want  string
+	}{
+		{spec: "*/15 * * * *", after: "2022-06-01T12:07:30Z", want: "2022-06-01T12:15:00Z"},
+		{spec: "*/15 * * * *", after: "2022-06-01T12:15:00Z", want: "2022-06-01T12:30:00Z"},
+		{spec: "0 9 * * MON", after: "2022-06-01T12:00:00Z", want: "2022-06-06T09:00:00Z"},
+		{spec: "30 23 31 * *", after: "2022-06-01T00:00:00Z", want: "2022-07-31T23:30:00Z"},
+		{spec: "0 0 29 2 *", after: "2022-03-01T00:00:00Z", want: "2024-02-29T00:00:00Z"},
+		// Either the day of month or the day of week matches.
+		{spec: "0 0 13 * FRI", after: "2022-06-01T00:00:00Z", want: "2022-06-03T00:00:00Z"},
+		{spec: "@yearly", after: "2022-06-01T00:00:00Z", want: "2023-01-01T00:00:00Z"},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.spec+" after "+tc.after, func(t *testing.T) {
+			s, err := cron.Parse(tc.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			after, _ := time.Parse(time.RFC3339, tc.after)
+			want, _ := time.Parse(time.RFC3339, tc.want)
+			got, ok := s.Next(after)
+			if !ok {
+				t.Fatal("expected a next time")
+			}
+			if !got.Equal(want) {
+				t.Errorf("unexpected next time -want/+got:\n\t- %v\n\t+ %v", want, got)
+			}
+		})
+	}
+
+	s, err := cron.Parse("0 0 30 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := s.Next(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)); ok {
+		t.Errorf("expected no next time for February 30, got %v", got)
+	}
+}
+
+func TestSchedule_Matches(t *testing.T) {
+	s, err := cron.Parse("* 9-17 * * MON-FRI")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		t    string
+		want bool
+	}{
+		{t: "2022-06-01T09:00:00Z", want: true},
+		{t: "2022-06-01T17:59:59Z", want: true},
+		{t: "2022-06-01T18:00:00Z", want: false},
+		{t: "2022-06-04T12:00:00Z", want: false},
+	}
+	for _, tc := range testCases {
+		tm, _ := time.Parse(time.RFC3339, tc.t)
+		if got := s.Matches(tm); got != tc.want {
+			t.Errorf("unexpected match for %s: want %v, got %v", tc.t, tc.want, got)
+		}
+	}
+}
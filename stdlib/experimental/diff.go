@@ -52,25 +52,40 @@ func createDiffOpSpec(args flux.Arguments, a *flux.Administration) (flux.Operati
 	}
 	a.AddParent(p)
 
-	return &DiffOpSpec{}, nil
+	spec := &DiffOpSpec{Context: -1}
+	if n, ok, err := args.GetInt("context"); err != nil {
+		return nil, err
+	} else if ok {
+		if n < 0 {
+			return nil, errors.New(codes.Invalid, "context must be non-negative")
+		}
+		spec.Context = n
+	}
+	return spec, nil
 }
 
-type DiffOpSpec struct{}
+type DiffOpSpec struct {
+	// Context is the number of unchanged rows to include
+	// before and after each changed row. All rows are included
+	// when it is negative.
+	Context int64 `json:"context"`
+}
 
 func (s *DiffOpSpec) Kind() flux.OperationKind {
 	return DiffKind
 }
 
 func newDiffProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
-	_, ok := qs.(*DiffOpSpec)
+	spec, ok := qs.(*DiffOpSpec)
 	if !ok {
 		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
 	}
-	return &DiffProcedureSpec{}, nil
+	return &DiffProcedureSpec{Context: spec.Context}, nil
 }
 
 type DiffProcedureSpec struct {
 	plan.DefaultCost
+	Context int64
 }
 
 func (s *DiffProcedureSpec) Kind() plan.ProcedureKind {
@@ -105,6 +120,7 @@ type diffTransformation struct {
 
 	inputs        [2]*execute.RandomAccessGroupLookup
 	wantID, gotID execute.DatasetID
+	context       int64
 }
 
 func NewDiffTransformation(id execute.DatasetID, spec *DiffProcedureSpec, wantID, gotID execute.DatasetID, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
@@ -115,8 +131,9 @@ func NewDiffTransformation(id execute.DatasetID, spec *DiffProcedureSpec, wantID
 			execute.NewRandomAccessGroupLookup(),
 			execute.NewRandomAccessGroupLookup(),
 		},
-		wantID: wantID,
-		gotID:  gotID,
+		wantID:  wantID,
+		gotID:   gotID,
+		context: spec.Context,
 	}
 	return execute.NewTransformationFromTransport(tr), tr.d, nil
 }
@@ -458,8 +475,8 @@ func (d *diffTransformation) createDiffSchema(key flux.GroupKey, want, got table
 // If the two tables are identical, this will return false to indicate that no diff
 // was computed. If the tables are not identical, a table chunk with the differences
 // between the two tables will be created. This diff will also include context for the
-// rows that are the same. Presently, this context is for the entire table so all
-// rows will be represented in some way.
+// rows that are the same. The context is the entire table unless the number of
+// context rows around each change is limited.
 func (d *diffTransformation) diff(key flux.GroupKey, want, got table.Chunk) (table.Chunk, bool, error) {
 	// Compute a schema to determine the output columns and map those columns
 	// to their locations in the original tables.
@@ -494,14 +511,20 @@ func (d *diffTransformation) diff(key flux.GroupKey, want, got table.Chunk) (tab
 	// Compute the lcs (longest common subsequence) table.
 	lcs := d.lcs(schema)
 	tracer := diffTrace{
-		lcs:      lcs,
-		schema:   schema,
-		diff:     diff,
-		builders: builders,
+		lcs:    lcs,
+		schema: schema,
 	}
 	// Trace the table from the last element.
 	tracer.trace(len(lcs[0])-1, len(lcs)-1)
 
+	for i, op := range tracer.ops {
+		if !tracer.keep(i, d.context) {
+			continue
+		}
+		diff.Append(op.mark)
+		schema.appendRow(builders, op.which, op.row)
+	}
+
 	// Create the diff table.
 	buf := arrow.TableBuffer{
 		GroupKey: key,
@@ -522,27 +545,54 @@ func (d *diffTransformation) diff(key flux.GroupKey, want, got table.Chunk) (tab
 }
 
 type diffTrace struct {
-	lcs      [][]diffLcsEntry
-	schema   *diffSchema
-	diff     *array.StringBuilder
-	builders []array.Builder
+	lcs    [][]diffLcsEntry
+	schema *diffSchema
+	ops    []diffOp
+}
+
+// diffOp is a row of the diff. The mark is empty for rows that
+// are in both tables, - for rows that are only present in the want
+// table and + for rows that are only present in the got table.
+type diffOp struct {
+	mark  string
+	which int
+	row   int
 }
 
 func (d *diffTrace) trace(row, col int) {
 	parent := d.lcs[col][row].parent
 	if row > 0 && col > 0 && parent == diffParentMatch {
 		d.trace(row-1, col-1)
-		d.diff.Append("")
-		d.schema.appendRow(d.builders, 0, row-1)
+		d.ops = append(d.ops, diffOp{mark: "", which: 0, row: row - 1})
 	} else if row > 0 && (col == 0 || parent == diffParentLeft) {
 		d.trace(row-1, col)
-		d.diff.Append("-")
-		d.schema.appendRow(d.builders, 0, row-1)
+		d.ops = append(d.ops, diffOp{mark: "-", which: 0, row: row - 1})
 	} else if col > 0 && (row == 0 || parent == diffParentTop || parent == diffParentEither) {
 		d.trace(row, col-1)
-		d.diff.Append("+")
-		d.schema.appendRow(d.builders, 1, col-1)
+		d.ops = append(d.ops, diffOp{mark: "+", which: 1, row: col - 1})
+	}
+}
+
+// keep reports if the row at index i of the diff is a change or
+// is within context rows of a change. All rows are kept when
+// context is negative.
+func (d *diffTrace) keep(i int, context int64) bool {
+	if context < 0 || d.ops[i].mark != "" {
+		return true
+	}
+	lo, hi := i-int(context), i+int(context)
+	if lo < 0 {
+		lo = 0
+	}
+	if hi >= len(d.ops) {
+		hi = len(d.ops) - 1
+	}
+	for j := lo; j <= hi; j++ {
+		if d.ops[j].mark != "" {
+			return true
+		}
 	}
+	return false
 }
 
 type diffLcsParent int
@@ -0,0 +1,177 @@
+package excel
+
+import (
+	"context"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/dependencies/filesystem"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/runtime"
+	"github.com/opentracing/opentracing-go"
+)
+
+const (
+	FromExcelKind = "fromExcel"
+
+	pkgpath = "experimental/excel"
+)
+
+func init() {
+	fromExcelSignature := runtime.MustLookupBuiltinType(pkgpath, "from")
+	runtime.RegisterPackageValue(pkgpath, "from", flux.MustValue(flux.FunctionValue(FromExcelKind, createFromExcelOpSpec, fromExcelSignature)))
+	plan.RegisterProcedureSpec(FromExcelKind, newFromExcelProcedure, FromExcelKind)
+	execute.RegisterSource(FromExcelKind, createFromExcelSource)
+}
+
+// FromExcelOpSpec is the flux.OperationSpec for the `excel.from` flux function.
+type FromExcelOpSpec struct {
+	File      string `json:"file"`
+	Sheet     string `json:"sheet,omitempty"`
+	HeaderRow int64  `json:"headerRow"`
+}
+
+func createFromExcelOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	spec := &FromExcelOpSpec{HeaderRow: 1}
+
+	var err error
+	if spec.File, err = args.GetRequiredString("file"); err != nil {
+		return nil, err
+	}
+
+	if sheet, ok, err := args.GetString("sheet"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Sheet = sheet
+	}
+
+	if headerRow, ok, err := args.GetInt("headerRow"); err != nil {
+		return nil, err
+	} else if ok {
+		if headerRow < 0 {
+			return nil, errors.New(codes.Invalid, "headerRow must not be negative")
+		}
+		spec.HeaderRow = headerRow
+	}
+	return spec, nil
+}
+
+func (s *FromExcelOpSpec) Kind() flux.OperationKind {
+	return FromExcelKind
+}
+
+type FromExcelProcedureSpec struct {
+	plan.DefaultCost
+	File      string
+	Sheet     string
+	HeaderRow int64
+}
+
+func newFromExcelProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*FromExcelOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+
+	return &FromExcelProcedureSpec{
+		File:      spec.File,
+		Sheet:     spec.Sheet,
+		HeaderRow: spec.HeaderRow,
+	}, nil
+}
+
+func (s *FromExcelProcedureSpec) Kind() plan.ProcedureKind {
+	return FromExcelKind
+}
+
+func (s *FromExcelProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+func createFromExcelSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	spec, ok := prSpec.(*FromExcelProcedureSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", prSpec)
+	}
+	return CreateSource(spec, dsid, a)
+}
+
+// CreateSource creates a source that reads the sheets of an xlsx workbook.
+func CreateSource(spec *FromExcelProcedureSpec, dsid execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	return &ExcelSource{
+		id:    dsid,
+		spec:  spec,
+		ctx:   a.Context(),
+		alloc: a.Allocator(),
+	}, nil
+}
+
+type ExcelSource struct {
+	execute.ExecutionNode
+	id    execute.DatasetID
+	spec  *FromExcelProcedureSpec
+	ctx   context.Context
+	ts    []execute.Transformation
+	alloc memory.Allocator
+}
+
+func (s *ExcelSource) AddTransformation(t execute.Transformation) {
+	s.ts = append(s.ts, t)
+}
+
+func (s *ExcelSource) Run(ctx context.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx, "excel.from")
+	span.SetTag("file", s.spec.File)
+	defer span.Finish()
+
+	err := s.run()
+	if err != nil {
+		err = errors.Wrap(err, codes.Inherit, "error in excel.from()")
+	}
+	for _, t := range s.ts {
+		t.Finish(s.id, err)
+	}
+}
+
+func (s *ExcelSource) run() error {
+	data, err := filesystem.ReadFile(s.ctx, s.spec.File)
+	if err != nil {
+		return errors.Wrap(err, codes.Inherit, "failed to read file")
+	}
+	wb, err := openWorkbook(data)
+	if err != nil {
+		return err
+	}
+
+	names := wb.sheetNames()
+	if s.spec.Sheet != "" {
+		names = []string{s.spec.Sheet}
+	}
+	sheets := make([]*sheet, 0, len(names))
+	for _, name := range names {
+		sh, err := wb.readSheet(name)
+		if err != nil {
+			return err
+		}
+		sheets = append(sheets, sh)
+	}
+
+	for _, t := range s.ts {
+		// Build the tables for each downstream transformation
+		// so a table instance goes to one and only one transformation.
+		for _, sh := range sheets {
+			tbl, err := sh.table(int(s.spec.HeaderRow), s.alloc)
+			if err != nil {
+				return err
+			}
+			if err := t.Process(s.id, tbl); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+package excel
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/dependencies/filesystem"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/mock"
+	"github.com/influxdata/flux/values"
+)
+
+const (
+	testWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets>
+<sheet name="hosts" sheetId="1" r:id="rId1"/>
+<sheet name="raw" sheetId="2" r:id="rId2"/>
+</sheets>
+</workbook>`
+	testRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="/xl/worksheets/sheet2.xml"/>
+</Relationships>`
+	testSharedStrings = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<si><t>host</t></si>
+<si><t>installed</t></si>
+<si><t>cores</t></si>
+<si><t>active</t></si>
+<si><t>rack</t></si>
+<si><r><t>server</t></r><r><t>-a</t></r></si>
+<si><t>server-b</t></si>
+<si><t>r1</t></si>
+</sst>`
+	testStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<numFmts><numFmt numFmtId="164" formatCode="yyyy\-mm\-dd\ hh:mm"/></numFmts>
+<cellXfs>
+<xf numFmtId="0"/>
+<xf numFmtId="14"/>
+<xf numFmtId="164"/>
+<xf numFmtId="2"/>
+</cellXfs>
+</styleSheet>`
+	// The hosts sheet has a header row and the rack column mixes text and numbers.
+	testSheet1 = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<sheetData>
+<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="s"><v>2</v></c><c r="D1" t="s"><v>3</v></c><c r="E1" t="s"><v>4</v></c></row>
+<row r="2"><c r="A2" t="s"><v>5</v></c><c r="B2" s="1"><v>44562</v></c><c r="C2" s="3"><v>8</v></c><c r="D2" t="b"><v>1</v></c><c r="E2" t="s"><v>7</v></c></row>
+<row r="4"><c r="A4" t="s"><v>6</v></c><c r="B4" s="2"><v>44562.75</v></c><c r="D4" t="b"><v>0</v></c><c r="E4"><v>2</v></c><c r="F4" s="1"/></row>
+</sheetData>
+</worksheet>`
+	// The raw sheet has no header row and uses inline strings and formula results.
+	testSheet2 = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<sheetData>
+<row r="1"><c r="A1" t="inlineStr"><is><t>x</t></is></c><c r="C1"><f>1/0</f><v>1.5</v></c></row>
+<row r="2"><c r="A2" t="str"><f>"y"</f><v>y</v></c><c r="C2" t="e"><f>1/0</f><v>#DIV/0!</v></c></row>
+</sheetData>
+</worksheet>`
+)
+
+func writeWorkbook(t *testing.T) string {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range map[string]string{
+		"xl/workbook.xml":            testWorkbook,
+		"xl/_rels/workbook.xml.rels": testRels,
+		"xl/sharedStrings.xml":       testSharedStrings,
+		"xl/styles.xml":              testStyles,
+		"xl/worksheets/sheet1.xml":   testSheet1,
+		"xl/worksheets/sheet2.xml":   testSheet2,
+	} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "flux-excel-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	fpath := filepath.Join(dir, "test.xlsx")
+	if err := ioutil.WriteFile(fpath, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return fpath
+}
+
+func TestFromExcel_Run(t *testing.T) {
+	fpath := writeWorkbook(t)
+
+	hosts := &executetest.Table{
+		KeyCols: []string{"_sheet"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_sheet", Type: flux.TString},
+			{Label: "host", Type: flux.TString},
+			{Label: "installed", Type: flux.TTime},
+			{Label: "cores", Type: flux.TFloat},
+			{Label: "active", Type: flux.TBool},
+			{Label: "rack", Type: flux.TString},
+		},
+		Data: [][]interface{}{
+			{"hosts", "server-a", values.ConvertTime(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)), 8.0, true, "r1"},
+			{"hosts", "server-b", values.ConvertTime(time.Date(2022, 1, 1, 18, 0, 0, 0, time.UTC)), nil, false, "2"},
+		},
+	}
+	raw := &executetest.Table{
+		KeyCols: []string{"_sheet"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_sheet", Type: flux.TString},
+			{Label: "A", Type: flux.TString},
+			{Label: "B", Type: flux.TString},
+			{Label: "C", Type: flux.TFloat},
+		},
+		Data: [][]interface{}{
+			{"raw", "x", nil, 1.5},
+			{"raw", "y", nil, nil},
+		},
+	}
+
+	testCases := []struct {
+		name    string
+		spec    *FromExcelProcedureSpec
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "single sheet",
+			spec: &FromExcelProcedureSpec{File: fpath, Sheet: "hosts", HeaderRow: 1},
+			want: []*executetest.Table{hosts},
+		},
+		{
+			name: "all sheets",
+			spec: &FromExcelProcedureSpec{File: fpath, HeaderRow: 0},
+			want: []*executetest.Table{
+				{
+					KeyCols: []string{"_sheet"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_sheet", Type: flux.TString},
+						{Label: "A", Type: flux.TString},
+						{Label: "B", Type: flux.TString},
+						{Label: "C", Type: flux.TString},
+						{Label: "D", Type: flux.TString},
+						{Label: "E", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{"hosts", "host", "installed", "cores", "active", "rack"},
+						{"hosts", "server-a", "2022-01-01T00:00:00Z", "8", "true", "r1"},
+						{"hosts", "server-b", "2022-01-01T18:00:00Z", nil, "false", "2"},
+					},
+				},
+				raw,
+			},
+		},
+		{
+			name: "header row below data",
+			spec: &FromExcelProcedureSpec{File: fpath, Sheet: "hosts", HeaderRow: 2},
+			want: []*executetest.Table{{
+				KeyCols: []string{"_sheet"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_sheet", Type: flux.TString},
+					{Label: "server-a", Type: flux.TString},
+					{Label: "2022-01-01T00:00:00Z", Type: flux.TTime},
+					{Label: "8", Type: flux.TString},
+					{Label: "true", Type: flux.TBool},
+					{Label: "r1", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{"hosts", "server-b", values.ConvertTime(time.Date(2022, 1, 1, 18, 0, 0, 0, time.UTC)), nil, false, 2.0},
+				},
+			}},
+		},
+		{
+			name:    "missing sheet",
+			spec:    &FromExcelProcedureSpec{File: fpath, Sheet: "missing", HeaderRow: 1},
+			wantErr: errors.New(codes.NotFound, `error in excel.from(): sheet "missing" does not exist`),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.RunSourceHelper(t,
+				tc.want,
+				tc.wantErr,
+				func(id execute.DatasetID) execute.Source {
+					ctx := filesystem.Inject(context.Background(), filesystem.SystemFS)
+					s, err := CreateSource(tc.spec, id, mock.AdministrationWithContext(ctx))
+					if err != nil {
+						t.Fatal(err)
+					}
+					return s
+				},
+			)
+		})
+	}
+}
+
+func TestColumnIndex(t *testing.T) {
+	for ref, want := range map[string]int{
+		"A1":   0,
+		"Z9":   25,
+		"AA10": 26,
+		"ab3":  27,
+		"XFD1": 16383,
+	} {
+		got, err := columnIndex(ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("unexpected column index of %s: want %d, got %d", ref, want, got)
+		}
+		if name := columnName(want); !bytes.EqualFold([]byte(name), []byte(ref[:len(name)])) {
+			t.Errorf("unexpected column name of %d: want %s, got %s", want, ref, name)
+		}
+	}
+	if _, err := columnIndex("12"); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestIsDateFormat(t *testing.T) {
+	for code, want := range map[string]bool{
+		"General":                    false,
+		"0.00":                       false,
+		"#,##0.00_);[Red](#,##0.00)": false,
+		"0.00E+00":                   false,
+		`0 "days"`:                   false,
+		"yyyy-mm-dd":                 true,
+		"[$-409]h:mm AM/PM":          true,
+		"[h]:mm":                     true,
+		"mm:ss":                      true,
+	} {
+		if got := isDateFormat(code); got != want {
+			t.Errorf("unexpected result for %q: want %v, got %v", code, want, got)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package excel
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/values"
+)
+
+// SheetColLabel is the label of the group key column
+// that contains the name of the sheet of each table.
+const SheetColLabel = "_sheet"
+
+// table converts the sheet to a table with a column for each column of the sheet.
+//
+// If headerRow is greater than zero, it is the one based number of the row
+// that contains the column labels. Rows above the header row are skipped.
+// Otherwise, and for columns without a label, the column letter is used as the label.
+//
+// A column whose values all have the same kind has the matching type.
+// Columns with values of mixed kinds are strings.
+func (s *sheet) table(headerRow int, mem memory.Allocator) (flux.Table, error) {
+	var header []cell
+	rows := s.rows
+	if headerRow > 0 {
+		if headerRow <= len(rows) {
+			header = rows[headerRow-1]
+			rows = rows[headerRow:]
+		} else {
+			rows = nil
+		}
+	}
+
+	// Skip empty rows and find the number of columns with data.
+	data := make([][]cell, 0, len(rows))
+	ncols := lastNonNull(header)
+	for _, row := range rows {
+		n := lastNonNull(row)
+		if n == 0 {
+			continue
+		}
+		if n > ncols {
+			ncols = n
+		}
+		data = append(data, row)
+	}
+
+	cols := make([]flux.ColMeta, 0, ncols+1)
+	cols = append(cols, flux.ColMeta{Label: SheetColLabel, Type: flux.TString})
+	seen := map[string]bool{SheetColLabel: true}
+	for j := 0; j < ncols; j++ {
+		label := columnName(j)
+		if j < len(header) && header[j].kind != nullCell {
+			label = header[j].String()
+		}
+		if seen[label] {
+			return nil, errors.Newf(codes.Invalid, "sheet %q has duplicate column %q", s.name, label)
+		}
+		seen[label] = true
+		cols = append(cols, flux.ColMeta{Label: label, Type: columnType(data, j)})
+	}
+
+	key := execute.NewGroupKey(cols[:1], []values.Value{values.NewString(s.name)})
+	vs := make([]array.Array, len(cols))
+	vs[0] = arrow.Repeat(flux.TString, values.NewString(s.name), len(data), mem)
+	for j := 0; j < ncols; j++ {
+		arr, err := buildColumn(data, j, cols[j+1].Type, mem)
+		if err != nil {
+			return nil, err
+		}
+		vs[j+1] = arr
+	}
+	return table.FromBuffer(&arrow.TableBuffer{
+		GroupKey: key,
+		Columns:  cols,
+		Values:   vs,
+	}), nil
+}
+
+// lastNonNull returns the number of cells up to and including the last non-null cell.
+func lastNonNull(row []cell) int {
+	for j := len(row) - 1; j >= 0; j-- {
+		if row[j].kind != nullCell {
+			return j + 1
+		}
+	}
+	return 0
+}
+
+func cellAt(row []cell, j int) cell {
+	if j < len(row) {
+		return row[j]
+	}
+	return cell{}
+}
+
+func columnType(rows [][]cell, j int) flux.ColType {
+	kind := nullCell
+	for _, row := range rows {
+		c := cellAt(row, j)
+		if c.kind == nullCell {
+			continue
+		}
+		if kind == nullCell {
+			kind = c.kind
+		} else if kind != c.kind {
+			return flux.TString
+		}
+	}
+	switch kind {
+	case numberCell:
+		return flux.TFloat
+	case dateCell:
+		return flux.TTime
+	case boolCell:
+		return flux.TBool
+	default:
+		return flux.TString
+	}
+}
+
+func buildColumn(rows [][]cell, j int, typ flux.ColType, mem memory.Allocator) (array.Array, error) {
+	b := arrow.NewBuilder(typ, mem)
+	b.Reserve(len(rows))
+	for _, row := range rows {
+		c := cellAt(row, j)
+		if c.kind == nullCell {
+			b.AppendNull()
+			continue
+		}
+		var err error
+		switch typ {
+		case flux.TFloat:
+			err = arrow.AppendFloat(b, c.num)
+		case flux.TTime:
+			err = arrow.AppendTime(b, values.ConvertTime(c.date))
+		case flux.TBool:
+			err = arrow.AppendBool(b, c.b)
+		default:
+			err = arrow.AppendString(b, c.String())
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return b.NewArray(), nil
+}
+
+// String formats the value of the cell as a string.
+func (c cell) String() string {
+	switch c.kind {
+	case numberCell:
+		return strconv.FormatFloat(c.num, 'f', -1, 64)
+	case dateCell:
+		return c.date.Format(time.RFC3339Nano)
+	case boolCell:
+		return strconv.FormatBool(c.b)
+	default:
+		return c.str
+	}
+}
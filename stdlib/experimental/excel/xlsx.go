@@ -0,0 +1,383 @@
+package excel
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+)
+
+// maxPartSize is the maximum uncompressed size of a single part of a workbook.
+const maxPartSize = 256 * 1024 * 1024
+
+// cellKind is the kind of value stored in a cell.
+type cellKind int
+
+const (
+	nullCell cellKind = iota
+	numberCell
+	dateCell
+	boolCell
+	stringCell
+)
+
+// cell is the value of a single worksheet cell.
+type cell struct {
+	kind cellKind
+	num  float64
+	date time.Time
+	b    bool
+	str  string
+}
+
+// sheet is a worksheet read from a workbook.
+// Rows are indexed from zero and may have different lengths.
+type sheet struct {
+	name string
+	rows [][]cell
+}
+
+// workbook is an xlsx workbook.
+type workbook struct {
+	zip          *zip.Reader
+	sheets       []workbookSheet
+	strings      []string
+	dateStyles   []bool
+	date1904     bool
+	sheetTargets map[string]string
+}
+
+type workbookSheet struct {
+	Name string `xml:"name,attr"`
+	RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+}
+
+// openWorkbook reads the workbook, shared strings and styles of an xlsx document.
+func openWorkbook(data []byte) (*workbook, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, errors.Wrap(err, codes.Invalid, "file is not an xlsx workbook")
+	}
+	wb := &workbook{zip: zr}
+
+	var book struct {
+		Pr struct {
+			Date1904 string `xml:"date1904,attr"`
+		} `xml:"workbookPr"`
+		Sheets []workbookSheet `xml:"sheets>sheet"`
+	}
+	if ok, err := wb.decodePart("xl/workbook.xml", &book); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, errors.New(codes.Invalid, "file is not an xlsx workbook: missing xl/workbook.xml")
+	}
+	wb.sheets = book.Sheets
+	wb.date1904 = book.Pr.Date1904 == "1" || book.Pr.Date1904 == "true"
+
+	var rels struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if _, err := wb.decodePart("xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return nil, err
+	}
+	wb.sheetTargets = make(map[string]string, len(rels.Relationships))
+	for _, r := range rels.Relationships {
+		target := r.Target
+		if strings.HasPrefix(target, "/") {
+			target = strings.TrimPrefix(target, "/")
+		} else {
+			target = path.Join("xl", target)
+		}
+		wb.sheetTargets[r.ID] = target
+	}
+
+	var sst struct {
+		Items []struct {
+			T    string `xml:"t"`
+			Runs []struct {
+				T string `xml:"t"`
+			} `xml:"r"`
+		} `xml:"si"`
+	}
+	if _, err := wb.decodePart("xl/sharedStrings.xml", &sst); err != nil {
+		return nil, err
+	}
+	wb.strings = make([]string, len(sst.Items))
+	for i, item := range sst.Items {
+		if len(item.Runs) == 0 {
+			wb.strings[i] = item.T
+			continue
+		}
+		var sb strings.Builder
+		for _, r := range item.Runs {
+			sb.WriteString(r.T)
+		}
+		wb.strings[i] = sb.String()
+	}
+
+	var styles struct {
+		NumFmts []struct {
+			ID   int    `xml:"numFmtId,attr"`
+			Code string `xml:"formatCode,attr"`
+		} `xml:"numFmts>numFmt"`
+		CellXfs []struct {
+			NumFmtID int `xml:"numFmtId,attr"`
+		} `xml:"cellXfs>xf"`
+	}
+	if _, err := wb.decodePart("xl/styles.xml", &styles); err != nil {
+		return nil, err
+	}
+	customDates := make(map[int]bool, len(styles.NumFmts))
+	for _, f := range styles.NumFmts {
+		customDates[f.ID] = isDateFormat(f.Code)
+	}
+	wb.dateStyles = make([]bool, len(styles.CellXfs))
+	for i, xf := range styles.CellXfs {
+		if isDate, ok := customDates[xf.NumFmtID]; ok {
+			wb.dateStyles[i] = isDate
+		} else {
+			wb.dateStyles[i] = isBuiltinDateFormat(xf.NumFmtID)
+		}
+	}
+	return wb, nil
+}
+
+// decodePart decodes the XML part with the given name.
+// It reports whether the part exists.
+func (wb *workbook) decodePart(name string, v interface{}) (bool, error) {
+	for _, f := range wb.zip.File {
+		if f.Name != name {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return true, errors.Wrapf(err, codes.Invalid, "cannot read %s", name)
+		}
+		defer func() { _ = rc.Close() }()
+		if err := xml.NewDecoder(io.LimitReader(rc, maxPartSize)).Decode(v); err != nil {
+			return true, errors.Wrapf(err, codes.Invalid, "cannot decode %s", name)
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// sheetNames returns the names of the worksheets in workbook order.
+func (wb *workbook) sheetNames() []string {
+	names := make([]string, len(wb.sheets))
+	for i, s := range wb.sheets {
+		names[i] = s.Name
+	}
+	return names
+}
+
+// readSheet reads the cells of the named worksheet.
+func (wb *workbook) readSheet(name string) (*sheet, error) {
+	var target string
+	for i, s := range wb.sheets {
+		if s.Name != name {
+			continue
+		}
+		var ok bool
+		if target, ok = wb.sheetTargets[s.RID]; !ok {
+			// Fall back to the conventional location of the worksheet.
+			target = "xl/worksheets/sheet" + strconv.Itoa(i+1) + ".xml"
+		}
+		break
+	}
+	if target == "" {
+		return nil, errors.Newf(codes.NotFound, "sheet %q does not exist", name)
+	}
+
+	var ws struct {
+		Rows []struct {
+			R     int `xml:"r,attr"`
+			Cells []struct {
+				R      string `xml:"r,attr"`
+				T      string `xml:"t,attr"`
+				S      int    `xml:"s,attr"`
+				V      string `xml:"v"`
+				Inline struct {
+					T    string `xml:"t"`
+					Runs []struct {
+						T string `xml:"t"`
+					} `xml:"r"`
+				} `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if ok, err := wb.decodePart(target, &ws); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, errors.Newf(codes.Invalid, "sheet %q is missing from the workbook", name)
+	}
+
+	s := &sheet{name: name}
+	for i, row := range ws.Rows {
+		r := i
+		if row.R > 0 {
+			r = row.R - 1
+		}
+		if r < len(s.rows) {
+			// Rows must be in ascending order.
+			r = len(s.rows)
+		}
+		for len(s.rows) < r {
+			s.rows = append(s.rows, nil)
+		}
+		cells := make([]cell, 0, len(row.Cells))
+		for _, c := range row.Cells {
+			j := len(cells)
+			if c.R != "" {
+				col, err := columnIndex(c.R)
+				if err != nil {
+					return nil, errors.Wrapf(err, codes.Invalid, "sheet %q", name)
+				}
+				if col >= j {
+					j = col
+				}
+			}
+			for len(cells) < j {
+				cells = append(cells, cell{})
+			}
+
+			var v cell
+			switch c.T {
+			case "s":
+				idx, err := strconv.Atoi(c.V)
+				if err != nil || idx < 0 || idx >= len(wb.strings) {
+					return nil, errors.Newf(codes.Invalid, "sheet %q: cell %s has an invalid shared string index", name, c.R)
+				}
+				v = cell{kind: stringCell, str: wb.strings[idx]}
+			case "str":
+				v = cell{kind: stringCell, str: c.V}
+			case "inlineStr":
+				str := c.Inline.T
+				for _, r := range c.Inline.Runs {
+					str += r.T
+				}
+				v = cell{kind: stringCell, str: str}
+			case "b":
+				v = cell{kind: boolCell, b: c.V == "1" || c.V == "true"}
+			case "e":
+				// Formula errors such as #DIV/0! are null.
+			case "d":
+				t, err := time.Parse(time.RFC3339Nano, c.V)
+				if err != nil {
+					if t, err = time.Parse("2006-01-02T15:04:05", c.V); err != nil {
+						return nil, errors.Newf(codes.Invalid, "sheet %q: cell %s has an invalid date %q", name, c.R, c.V)
+					}
+				}
+				v = cell{kind: dateCell, date: t}
+			default:
+				if c.V == "" {
+					break
+				}
+				f, err := strconv.ParseFloat(c.V, 64)
+				if err != nil {
+					return nil, errors.Newf(codes.Invalid, "sheet %q: cell %s has an invalid number %q", name, c.R, c.V)
+				}
+				if c.S >= 0 && c.S < len(wb.dateStyles) && wb.dateStyles[c.S] {
+					v = cell{kind: dateCell, date: serialToTime(f, wb.date1904)}
+				} else {
+					v = cell{kind: numberCell, num: f}
+				}
+			}
+			cells = append(cells, v)
+		}
+		s.rows = append(s.rows, cells)
+	}
+	return s, nil
+}
+
+// columnIndex returns the zero based column index of a cell reference such as "AB12".
+func columnIndex(ref string) (int, error) {
+	col := 0
+	n := 0
+	for _, r := range ref {
+		if r >= 'a' && r <= 'z' {
+			r -= 'a' - 'A'
+		}
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		col = col*26 + int(r-'A'+1)
+		n++
+	}
+	if n == 0 || n > 3 {
+		return 0, errors.Newf(codes.Invalid, "invalid cell reference %q", ref)
+	}
+	return col - 1, nil
+}
+
+// columnName returns the column letters of the zero based column index.
+func columnName(j int) string {
+	var b []byte
+	for j++; j > 0; j = (j - 1) / 26 {
+		b = append([]byte{byte('A' + (j-1)%26)}, b...)
+	}
+	return string(b)
+}
+
+// isBuiltinDateFormat reports whether the builtin number format is a date or time format.
+func isBuiltinDateFormat(id int) bool {
+	return (id >= 14 && id <= 22) || (id >= 45 && id <= 47)
+}
+
+// isDateFormat reports whether the custom number format code formats a date or time.
+func isDateFormat(code string) bool {
+	if strings.EqualFold(code, "general") {
+		return false
+	}
+	// Ignore quoted text, escaped characters and sections like [Red] or [$-409].
+	var b strings.Builder
+	for i := 0; i < len(code); i++ {
+		switch c := code[i]; c {
+		case '"':
+			if j := strings.IndexByte(code[i+1:], '"'); j >= 0 {
+				i += j + 1
+			} else {
+				i = len(code)
+			}
+		case '[':
+			// Elapsed time sections like [h] are times.
+			if j := strings.IndexByte(code[i+1:], ']'); j >= 0 {
+				section := strings.ToLower(code[i+1 : i+1+j])
+				if strings.Trim(section, "hms") == "" {
+					b.WriteByte('h')
+				}
+				i += j + 1
+			} else {
+				i = len(code)
+			}
+		case '\\', '_', '*':
+			i++
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return strings.ContainsAny(strings.ToLower(b.String()), "ydhs")
+}
+
+// serialToTime converts an Excel serial date to a time.
+func serialToTime(serial float64, date1904 bool) time.Time {
+	epoch := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	if date1904 {
+		epoch = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	days := math.Floor(serial)
+	// Round the time of day to the millisecond, the precision Excel displays.
+	ms := math.Round((serial - days) * 24 * 60 * 60 * 1000)
+	return epoch.AddDate(0, 0, int(days)).Add(time.Duration(ms) * time.Millisecond)
+}
@@ -0,0 +1,417 @@
+package fit
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/runtime"
+)
+
+const pkgpath = "experimental/fit"
+
+const (
+	LinearKind = "fitLinear"
+	PolyKind   = "fitPoly"
+)
+
+const (
+	SlopeColumn     = "slope"
+	InterceptColumn = "intercept"
+	R2Column        = "r2"
+	PredictedColumn = "predicted"
+	ResidualColumn  = "residual"
+)
+
+func init() {
+	runtime.RegisterPackageValue(pkgpath, "linear", flux.MustValue(flux.FunctionValue("linear", createLinearOpSpec, runtime.MustLookupBuiltinType(pkgpath, "linear"))))
+	plan.RegisterProcedureSpec(LinearKind, newLinearProcedure, LinearKind)
+	execute.RegisterTransformation(LinearKind, createLinearTransformation)
+
+	runtime.RegisterPackageValue(pkgpath, "poly", flux.MustValue(flux.FunctionValue("poly", createPolyOpSpec, runtime.MustLookupBuiltinType(pkgpath, "poly"))))
+	plan.RegisterProcedureSpec(PolyKind, newPolyProcedure, PolyKind)
+	execute.RegisterTransformation(PolyKind, createPolyTransformation)
+}
+
+// FitOptions are the options shared by the fit functions.
+type FitOptions struct {
+	X             string `json:"x"`
+	Y             string `json:"y"`
+	WithResiduals bool   `json:"withResiduals"`
+}
+
+func readFitOptions(args flux.Arguments) (FitOptions, error) {
+	var opts FitOptions
+	if x, ok, err := args.GetString("x"); err != nil {
+		return opts, err
+	} else if ok {
+		opts.X = x
+	} else {
+		opts.X = execute.DefaultTimeColLabel
+	}
+
+	if y, ok, err := args.GetString("y"); err != nil {
+		return opts, err
+	} else if ok {
+		opts.Y = y
+	} else {
+		opts.Y = execute.DefaultValueColLabel
+	}
+
+	if withResiduals, ok, err := args.GetBool("withResiduals"); err != nil {
+		return opts, err
+	} else if ok {
+		opts.WithResiduals = withResiduals
+	}
+	return opts, nil
+}
+
+type LinearOpSpec struct {
+	FitOptions
+}
+
+func createLinearOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	opts, err := readFitOptions(args)
+	if err != nil {
+		return nil, err
+	}
+	return &LinearOpSpec{FitOptions: opts}, nil
+}
+
+func (s *LinearOpSpec) Kind() flux.OperationKind {
+	return LinearKind
+}
+
+type PolyOpSpec struct {
+	Degree int64 `json:"degree"`
+	FitOptions
+}
+
+func createPolyOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(PolyOpSpec)
+	if degree, err := args.GetRequiredInt("degree"); err != nil {
+		return nil, err
+	} else if degree < 1 {
+		return nil, errors.Newf(codes.Invalid, "degree must be at least 1, got %d", degree)
+	} else {
+		spec.Degree = degree
+	}
+
+	opts, err := readFitOptions(args)
+	if err != nil {
+		return nil, err
+	}
+	spec.FitOptions = opts
+	return spec, nil
+}
+
+func (s *PolyOpSpec) Kind() flux.OperationKind {
+	return PolyKind
+}
+
+type LinearProcedureSpec struct {
+	plan.DefaultCost
+	FitOptions
+}
+
+func newLinearProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*LinearOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+	return &LinearProcedureSpec{FitOptions: spec.FitOptions}, nil
+}
+
+func (s *LinearProcedureSpec) Kind() plan.ProcedureKind {
+	return LinearKind
+}
+
+func (s *LinearProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+type PolyProcedureSpec struct {
+	plan.DefaultCost
+	Degree int64
+	FitOptions
+}
+
+func newPolyProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*PolyOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+	return &PolyProcedureSpec{
+		Degree:     spec.Degree,
+		FitOptions: spec.FitOptions,
+	}, nil
+}
+
+func (s *PolyProcedureSpec) Kind() plan.ProcedureKind {
+	return PolyKind
+}
+
+func (s *PolyProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+func createLinearTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*LinearProcedureSpec)
+	if !ok {
+		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
+	}
+	return NewLinearTransformation(id, s, a.Allocator())
+}
+
+func createPolyTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*PolyProcedureSpec)
+	if !ok {
+		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
+	}
+	return NewPolyTransformation(id, s, a.Allocator())
+}
+
+type fitTransformation struct {
+	degree        int
+	x, y          string
+	withResiduals bool
+	// coefficients are the names of the columns of the
+	// coefficients in order of increasing degree.
+	coefficients []string
+}
+
+// NewLinearTransformation constructs a transformation that fits a line
+// to each table and outputs either the slope and intercept of the line or
+// the input rows with the predicted values and residuals.
+func NewLinearTransformation(id execute.DatasetID, spec *LinearProcedureSpec, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	tr := newFitTransformation(1, spec.FitOptions)
+	tr.coefficients = []string{InterceptColumn, SlopeColumn}
+	return execute.NewAggregateTransformation(id, tr, mem)
+}
+
+// NewPolyTransformation constructs a transformation that fits a polynomial
+// to each table and outputs either the coefficients of the polynomial or
+// the input rows with the predicted values and residuals.
+func NewPolyTransformation(id execute.DatasetID, spec *PolyProcedureSpec, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	tr := newFitTransformation(int(spec.Degree), spec.FitOptions)
+	for k := 0; k <= tr.degree; k++ {
+		tr.coefficients = append(tr.coefficients, fmt.Sprintf("c%d", k))
+	}
+	return execute.NewAggregateTransformation(id, tr, mem)
+}
+
+func newFitTransformation(degree int, opts FitOptions) *fitTransformation {
+	return &fitTransformation{
+		degree:        degree,
+		x:             opts.X,
+		y:             opts.Y,
+		withResiduals: opts.WithResiduals,
+	}
+}
+
+type fitState struct {
+	chunks []table.Chunk
+}
+
+func (s *fitState) Close() error {
+	for _, chunk := range s.chunks {
+		chunk.Release()
+	}
+	s.chunks = nil
+	return nil
+}
+
+func (t *fitTransformation) Aggregate(chunk table.Chunk, state interface{}, mem memory.Allocator) (interface{}, bool, error) {
+	s, _ := state.(*fitState)
+	if s == nil {
+		s = &fitState{}
+	}
+	chunk.Retain()
+	s.chunks = append(s.chunks, chunk)
+	return s, true, nil
+}
+
+// values reads the x and y values of each row of the chunk.
+// Time values are read as seconds since the Unix epoch.
+func (t *fitTransformation) values(chunk table.Chunk) (xs, ys []float64, xok, yok []bool, err error) {
+	n := chunk.Len()
+	xs, ys = make([]float64, n), make([]float64, n)
+	xok, yok = make([]bool, n), make([]bool, n)
+	for _, c := range []struct {
+		label string
+		vs    []float64
+		ok    []bool
+		time  bool
+	}{
+		{label: t.x, vs: xs, ok: xok, time: true},
+		{label: t.y, vs: ys, ok: yok},
+	} {
+		j := chunk.Index(c.label)
+		if j < 0 {
+			return nil, nil, nil, nil, errors.Newf(codes.FailedPrecondition, "no column %q exists", c.label)
+		}
+		switch typ := chunk.Col(j).Type; {
+		case typ == flux.TFloat:
+			arr := chunk.Floats(j)
+			for i := 0; i < n; i++ {
+				c.vs[i], c.ok[i] = arr.Value(i), arr.IsValid(i)
+			}
+		case typ == flux.TInt:
+			arr := chunk.Ints(j)
+			for i := 0; i < n; i++ {
+				c.vs[i], c.ok[i] = float64(arr.Value(i)), arr.IsValid(i)
+			}
+		case typ == flux.TUInt:
+			arr := chunk.Uints(j)
+			for i := 0; i < n; i++ {
+				c.vs[i], c.ok[i] = float64(arr.Value(i)), arr.IsValid(i)
+			}
+		case typ == flux.TTime && c.time:
+			arr := chunk.Ints(j)
+			for i := 0; i < n; i++ {
+				c.vs[i], c.ok[i] = float64(arr.Value(i))/1e9, arr.IsValid(i)
+			}
+		default:
+			return nil, nil, nil, nil, errors.Newf(codes.FailedPrecondition, "cannot fit column %q of type %s", c.label, typ)
+		}
+	}
+	return xs, ys, xok, yok, nil
+}
+
+func (t *fitTransformation) Compute(key flux.GroupKey, state interface{}, d *execute.TransportDataset, mem memory.Allocator) error {
+	s := state.(*fitState)
+
+	var xs, ys []float64
+	for _, chunk := range s.chunks {
+		if t.withResiduals {
+			for _, label := range []string{PredictedColumn, ResidualColumn} {
+				if chunk.Index(label) >= 0 {
+					return errors.Newf(codes.FailedPrecondition, "column %q already exists", label)
+				}
+			}
+		}
+		if chunk.Len() == 0 {
+			continue
+		}
+
+		cxs, cys, xok, yok, err := t.values(chunk)
+		if err != nil {
+			return err
+		}
+		for i := range cxs {
+			if xok[i] && yok[i] {
+				xs, ys = append(xs, cxs[i]), append(ys, cys[i])
+			}
+		}
+	}
+	p, ok := fitPolynomial(xs, ys, t.degree)
+
+	if !t.withResiduals {
+		return t.summary(key, p, ok, xs, ys, d, mem)
+	}
+
+	for _, chunk := range s.chunks {
+		l := chunk.Len()
+		pb, rb := array.NewFloatBuilder(mem), array.NewFloatBuilder(mem)
+		pb.Resize(l)
+		rb.Resize(l)
+		if l > 0 {
+			cxs, cys, xok, yok, err := t.values(chunk)
+			if err != nil {
+				return err
+			}
+			for i := 0; i < l; i++ {
+				if !ok || !xok[i] {
+					pb.AppendNull()
+					rb.AppendNull()
+					continue
+				}
+				v := p.predict(cxs[i])
+				pb.Append(v)
+				if yok[i] {
+					rb.Append(cys[i] - v)
+				} else {
+					rb.AppendNull()
+				}
+			}
+		}
+
+		buffer := chunk.Buffer()
+		out := arrow.TableBuffer{
+			GroupKey: key,
+			Columns: append(append(make([]flux.ColMeta, 0, len(buffer.Columns)+2), buffer.Columns...),
+				flux.ColMeta{Label: PredictedColumn, Type: flux.TFloat},
+				flux.ColMeta{Label: ResidualColumn, Type: flux.TFloat},
+			),
+			Values: make([]array.Array, 0, len(buffer.Values)+2),
+		}
+		for _, vs := range buffer.Values {
+			vs.Retain()
+			out.Values = append(out.Values, vs)
+		}
+		out.Values = append(out.Values, pb.NewArray(), rb.NewArray())
+		if err := d.Process(table.ChunkFromBuffer(out)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// summary outputs a single row with the coefficients of the polynomial
+// and the coefficient of determination. The values are null if the
+// polynomial could not be fit.
+func (t *fitTransformation) summary(key flux.GroupKey, p *polynomial, ok bool, xs, ys []float64, d *execute.TransportDataset, mem memory.Allocator) error {
+	labels := append(append(make([]string, 0, len(t.coefficients)+1), t.coefficients...), R2Column)
+	for _, label := range labels {
+		if key.HasCol(label) {
+			return errors.Newf(codes.FailedPrecondition, "column %q must not be part of the group key", label)
+		}
+	}
+
+	var vs []float64
+	if ok {
+		vs = append(p.raw(), p.rSquared(xs, ys))
+	}
+
+	cols := make([]flux.ColMeta, 0, len(key.Cols())+len(labels))
+	values := make([]array.Array, 0, len(key.Cols())+len(labels))
+	for j, col := range key.Cols() {
+		cols = append(cols, col)
+		values = append(values, arrow.Repeat(col.Type, key.Value(j), 1, mem))
+	}
+	for i, label := range labels {
+		cols = append(cols, flux.ColMeta{Label: label, Type: flux.TFloat})
+		b := array.NewFloatBuilder(mem)
+		b.Resize(1)
+		if ok {
+			b.Append(vs[i])
+		} else {
+			b.AppendNull()
+		}
+		values = append(values, b.NewArray())
+	}
+	return d.Process(table.ChunkFromBuffer(arrow.TableBuffer{
+		GroupKey: key,
+		Columns:  cols,
+		Values:   values,
+	}))
+}
+
+func (t *fitTransformation) Close() error { return nil }
@@ -0,0 +1,219 @@
+package fit_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/stdlib/experimental/fit"
+)
+
+var inputCols = []flux.ColMeta{
+	{Label: "_time", Type: flux.TTime},
+	{Label: "_value", Type: flux.TFloat},
+	{Label: "t0", Type: flux.TString},
+	{Label: "x", Type: flux.TInt},
+}
+
+// input creates a table with a value for each row where
+// the _time is the row number in seconds and x is the
+// row number plus 1.
+func input(vs ...interface{}) *executetest.Table {
+	tbl := &executetest.Table{KeyCols: []string{"t0"}, ColMeta: inputCols}
+	for i, v := range vs {
+		tbl.Data = append(tbl.Data, []interface{}{execute.Time(int64(i+1) * 1e9), v, "a", int64(i + 1)})
+	}
+	return tbl
+}
+
+func TestLinear(t *testing.T) {
+	summaryCols := []flux.ColMeta{
+		{Label: "t0", Type: flux.TString},
+		{Label: "intercept", Type: flux.TFloat},
+		{Label: "slope", Type: flux.TFloat},
+		{Label: "r2", Type: flux.TFloat},
+	}
+	testCases := []struct {
+		name    string
+		spec    *fit.LinearProcedureSpec
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "summary",
+			spec: &fit.LinearProcedureSpec{FitOptions: fit.FitOptions{
+				X: "x",
+				Y: execute.DefaultValueColLabel,
+			}},
+			data: []flux.Table{input(1.0, 3.0, 2.0, 5.0)},
+			want: []*executetest.Table{{
+				KeyCols: []string{"t0"},
+				ColMeta: summaryCols,
+				Data: [][]interface{}{
+					{"a", 0.0, 1.1, 0.6914285714285715},
+				},
+			}},
+		},
+		{
+			name: "residuals over time",
+			spec: &fit.LinearProcedureSpec{FitOptions: fit.FitOptions{
+				X:             execute.DefaultTimeColLabel,
+				Y:             execute.DefaultValueColLabel,
+				WithResiduals: true,
+			}},
+			data: []flux.Table{input(1.0, 3.0, 2.0, 5.0, nil)},
+			want: []*executetest.Table{{
+				KeyCols: []string{"t0"},
+				ColMeta: append(append([]flux.ColMeta{}, inputCols...),
+					flux.ColMeta{Label: "predicted", Type: flux.TFloat},
+					flux.ColMeta{Label: "residual", Type: flux.TFloat},
+				),
+				Data: [][]interface{}{
+					{execute.Time(1e9), 1.0, "a", int64(1), 1.1, -0.1},
+					{execute.Time(2e9), 3.0, "a", int64(2), 2.2, 0.8},
+					{execute.Time(3e9), 2.0, "a", int64(3), 3.3, -1.3},
+					{execute.Time(4e9), 5.0, "a", int64(4), 4.4, 0.6},
+					{execute.Time(5e9), nil, "a", int64(5), 5.5, nil},
+				},
+			}},
+		},
+		{
+			name: "single value",
+			spec: &fit.LinearProcedureSpec{FitOptions: fit.FitOptions{
+				X: "x",
+				Y: execute.DefaultValueColLabel,
+			}},
+			data: []flux.Table{input(1.0, nil)},
+			want: []*executetest.Table{{
+				KeyCols: []string{"t0"},
+				ColMeta: summaryCols,
+				Data: [][]interface{}{
+					{"a", nil, nil, nil},
+				},
+			}},
+		},
+		{
+			name: "residual column exists",
+			spec: &fit.LinearProcedureSpec{FitOptions: fit.FitOptions{
+				X:             "x",
+				Y:             execute.DefaultValueColLabel,
+				WithResiduals: true,
+			}},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "x", Type: flux.TInt},
+					{Label: "_value", Type: flux.TFloat},
+					{Label: "residual", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{int64(1), 1.0, 0.0},
+				},
+			}},
+			wantErr: errors.New(codes.FailedPrecondition, `column "residual" already exists`),
+		},
+		{
+			name: "unsupported type",
+			spec: &fit.LinearProcedureSpec{FitOptions: fit.FitOptions{
+				X: "x",
+				Y: "t0",
+			}},
+			data:    []flux.Table{input(1.0, 3.0)},
+			wantErr: errors.New(codes.FailedPrecondition, `cannot fit column "t0" of type string`),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper2(
+				t,
+				tc.data,
+				tc.want,
+				tc.wantErr,
+				func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+					tr, d, err := fit.NewLinearTransformation(id, tc.spec, alloc)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return tr, d
+				},
+			)
+		})
+	}
+}
+
+func TestPoly(t *testing.T) {
+	summaryCols := []flux.ColMeta{
+		{Label: "t0", Type: flux.TString},
+		{Label: "c0", Type: flux.TFloat},
+		{Label: "c1", Type: flux.TFloat},
+		{Label: "c2", Type: flux.TFloat},
+		{Label: "r2", Type: flux.TFloat},
+	}
+	testCases := []struct {
+		name string
+		spec *fit.PolyProcedureSpec
+		data []flux.Table
+		want []*executetest.Table
+	}{
+		{
+			// The values are 3 - 2x + 0.5x^2.
+			name: "quadratic",
+			spec: &fit.PolyProcedureSpec{
+				Degree: 2,
+				FitOptions: fit.FitOptions{
+					X: "x",
+					Y: execute.DefaultValueColLabel,
+				},
+			},
+			data: []flux.Table{input(1.5, 1.0, 1.5, 3.0, 5.5)},
+			want: []*executetest.Table{{
+				KeyCols: []string{"t0"},
+				ColMeta: summaryCols,
+				Data: [][]interface{}{
+					{"a", 3.0, -2.0, 0.5, 1.0},
+				},
+			}},
+		},
+		{
+			name: "too few values",
+			spec: &fit.PolyProcedureSpec{
+				Degree: 2,
+				FitOptions: fit.FitOptions{
+					X: "x",
+					Y: execute.DefaultValueColLabel,
+				},
+			},
+			data: []flux.Table{input(1.5, 1.0)},
+			want: []*executetest.Table{{
+				KeyCols: []string{"t0"},
+				ColMeta: summaryCols,
+				Data: [][]interface{}{
+					{"a", nil, nil, nil, nil},
+				},
+			}},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper2(
+				t,
+				tc.data,
+				tc.want,
+				nil,
+				func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+					tr, d, err := fit.NewPolyTransformation(id, tc.spec, alloc)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return tr, d
+				},
+			)
+		})
+	}
+}
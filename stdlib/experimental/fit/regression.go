@@ -0,0 +1,114 @@
+package fit
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// polynomial is a least squares polynomial fit of y on x.
+//
+// The fit is computed on x shifted by its mean and divided by its
+// largest deviation from the mean so the Vandermonde matrix stays
+// well conditioned for large x values such as timestamps.
+type polynomial struct {
+	// coefficients holds the coefficients of the
+	// scaled polynomial in order of increasing degree.
+	coefficients []float64
+	mean, scale  float64
+}
+
+// fitPolynomial computes the least squares polynomial of the degree
+// through the points. It returns false if the points do not determine
+// a unique polynomial.
+func fitPolynomial(xs, ys []float64, degree int) (*polynomial, bool) {
+	n := len(xs)
+	if n <= degree {
+		return nil, false
+	}
+
+	var mean float64
+	for _, x := range xs {
+		mean += x
+	}
+	mean /= float64(n)
+	var scale float64
+	for _, x := range xs {
+		scale = math.Max(scale, math.Abs(x-mean))
+	}
+	if scale == 0 {
+		return nil, false
+	}
+
+	a := mat.NewDense(n, degree+1, nil)
+	for i, x := range xs {
+		u, p := (x-mean)/scale, 1.0
+		for k := 0; k <= degree; k++ {
+			a.Set(i, k, p)
+			p *= u
+		}
+	}
+	var qr mat.QR
+	qr.Factorize(a)
+	var b mat.Dense
+	if err := qr.SolveTo(&b, false, mat.NewDense(n, 1, ys)); err != nil {
+		return nil, false
+	}
+
+	p := &polynomial{
+		coefficients: make([]float64, degree+1),
+		mean:         mean,
+		scale:        scale,
+	}
+	for k := range p.coefficients {
+		p.coefficients[k] = b.At(k, 0)
+	}
+	return p, true
+}
+
+// predict evaluates the polynomial at x.
+func (p *polynomial) predict(x float64) float64 {
+	u := (x - p.mean) / p.scale
+	var v float64
+	for k := len(p.coefficients) - 1; k >= 0; k-- {
+		v = v*u + p.coefficients[k]
+	}
+	return v
+}
+
+// raw returns the coefficients of the polynomial in x
+// in order of increasing degree.
+func (p *polynomial) raw() []float64 {
+	// Expand each ((x - mean) / scale)^k term with the binomial theorem.
+	out := make([]float64, len(p.coefficients))
+	for k, b := range p.coefficients {
+		c := b / math.Pow(p.scale, float64(k))
+		binom := 1.0
+		for j := k; j >= 0; j-- {
+			out[j] += c * binom * math.Pow(-p.mean, float64(k-j))
+			binom = binom * float64(j) / float64(k-j+1)
+		}
+	}
+	return out
+}
+
+// rSquared returns the coefficient of determination of the polynomial
+// for the points. It is NaN if the y values have no variance.
+func (p *polynomial) rSquared(xs, ys []float64) float64 {
+	var mean float64
+	for _, y := range ys {
+		mean += y
+	}
+	mean /= float64(len(ys))
+
+	var ssRes, ssTot float64
+	for i, y := range ys {
+		r := y - p.predict(xs[i])
+		ssRes += r * r
+		ssTot += (y - mean) * (y - mean)
+	}
+	if ssTot == 0 {
+		return math.NaN()
+	}
+	return 1 - ssRes/ssTot
+}
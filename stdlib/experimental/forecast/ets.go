@@ -0,0 +1,275 @@
+package forecast
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/optimize"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// etsGuesses are the initial guesses of each smoothing parameter.
+// Every combination of guesses is used as a starting point
+// of the optimizer and the best fit is kept.
+var etsGuesses = []float64{0.2, 0.5, 0.8}
+
+// etsModel is an additive exponential smoothing model
+// with an optional trend and an optional seasonal component.
+type etsModel struct {
+	// trend enables the trend component.
+	trend bool
+	// m is the length of the season or zero
+	// when there is no seasonal component.
+	m int
+
+	alpha, beta, gamma float64
+
+	// Initial states estimated from the data.
+	level0, trend0 float64
+	season0        []float64
+}
+
+// etsParams are the fitted parameters of a model.
+type etsParams struct {
+	Alpha float64
+	Beta  float64
+	Gamma float64
+	SSE   float64
+	Sigma float64
+}
+
+// etsResult holds the fitted values, forecasts, and the
+// standard deviation of the forecast error at each horizon.
+type etsResult struct {
+	fitted    []float64
+	forecasts []float64
+	sigmas    []float64
+	params    etsParams
+}
+
+// minPoints returns the number of values needed to fit the model.
+func (m *etsModel) minPoints() int {
+	switch {
+	case m.m > 0 && m.trend:
+		return 2 * m.m
+	case m.m > 0:
+		return m.m
+	case m.trend:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// initialize estimates the states before the first value from the
+// first values. The first value must be valid.
+func (m *etsModel) initialize(ys []float64, valid []bool) {
+	mean := func(start, stop int) (float64, bool) {
+		var sum float64
+		var count int
+		for i := start; i < stop; i++ {
+			if valid[i] {
+				sum += ys[i]
+				count++
+			}
+		}
+		if count == 0 {
+			return 0, false
+		}
+		return sum / float64(count), true
+	}
+
+	if m.m == 0 {
+		if m.trend && valid[1] {
+			m.trend0 = ys[1] - ys[0]
+		}
+		m.level0 = ys[0] - m.trend0
+		return
+	}
+
+	// The mean of the first season is the level
+	// at the middle of the first season.
+	first, _ := mean(0, m.m)
+	if m.trend {
+		if next, ok := mean(m.m, 2*m.m); ok {
+			m.trend0 = (next - first) / float64(m.m)
+		}
+	}
+	mid := float64(m.m-1) / 2
+	m.level0 = first - m.trend0*(mid+1)
+	m.season0 = make([]float64, m.m)
+	for i := range m.season0 {
+		if valid[i] {
+			m.season0[i] = ys[i] - (first + m.trend0*(float64(i)-mid))
+		}
+	}
+}
+
+// run computes the one-step-ahead fitted values and the
+// forecasts for the next h values. It returns the sum of
+// the squared errors of the valid values.
+func (m *etsModel) run(ys []float64, valid []bool, h int, fitted, forecasts []float64) float64 {
+	level, trend := m.level0, m.trend0
+	var season []float64
+	if m.m > 0 {
+		season = make([]float64, m.m)
+		copy(season, m.season0)
+	}
+
+	var sse float64
+	for t, y := range ys {
+		yhat := level + trend
+		if season != nil {
+			yhat += season[t%m.m]
+		}
+		if fitted != nil {
+			fitted[t] = yhat
+		}
+
+		// Missing values are replaced by their forecast
+		// so they do not modify the states.
+		var e float64
+		if valid[t] {
+			e = y - yhat
+			sse += e * e
+		}
+		level = level + trend + m.alpha*e
+		if m.trend {
+			trend = trend + m.beta*e
+		}
+		if season != nil {
+			season[t%m.m] += m.gamma * e
+		}
+	}
+
+	for i := 0; i < h; i++ {
+		yhat := level + float64(i+1)*trend
+		if season != nil {
+			yhat += season[(len(ys)+i)%m.m]
+		}
+		forecasts[i] = yhat
+	}
+	return sse
+}
+
+// setParams sets the smoothing parameters from the
+// optimizer variables constrained to the range [0, 1].
+func (m *etsModel) setParams(x []float64) {
+	clamp := func(v float64) float64 {
+		return math.Max(0, math.Min(1, v))
+	}
+	m.alpha = clamp(x[0])
+	i := 1
+	if m.trend {
+		m.beta = clamp(x[i])
+		i++
+	}
+	if m.m > 0 {
+		m.gamma = clamp(x[i])
+	}
+}
+
+// fit chooses the smoothing parameters that minimize the sum of
+// the squared one-step-ahead errors and forecasts the next h values.
+func (m *etsModel) fit(ys []float64, valid []bool, h int) (etsResult, error) {
+	m.initialize(ys, valid)
+
+	dim := 1
+	if m.trend {
+		dim++
+	}
+	if m.m > 0 {
+		dim++
+	}
+	problem := optimize.Problem{
+		Func: func(x []float64) float64 {
+			m.setParams(x)
+			return m.run(ys, valid, 0, nil, nil)
+		},
+	}
+	settings := optimize.Settings{Converger: &optimize.FunctionConverge{Absolute: 1e-10, Iterations: 100}}
+
+	var best []float64
+	bestSSE := math.Inf(1)
+	guess := make([]float64, dim)
+	var search func(i int) error
+	search = func(i int) error {
+		if i == dim {
+			result, err := optimize.Minimize(problem, guess, &settings, &optimize.NelderMead{})
+			if err != nil {
+				return err
+			}
+			if result.F < bestSSE || best == nil {
+				bestSSE, best = result.F, result.X
+			}
+			return nil
+		}
+		for _, g := range etsGuesses {
+			guess[i] = g
+			if err := search(i + 1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := search(0); err != nil {
+		return etsResult{}, err
+	}
+	m.setParams(best)
+
+	res := etsResult{
+		fitted:    make([]float64, len(ys)),
+		forecasts: make([]float64, h),
+		sigmas:    make([]float64, h),
+	}
+	sse := m.run(ys, valid, h, res.fitted, res.forecasts)
+
+	// Estimate the variance of the one-step-ahead errors.
+	var count int
+	for _, v := range valid {
+		if v {
+			count++
+		}
+	}
+	df := count - dim
+	if df < 1 {
+		df = count
+	}
+	variance := sse / float64(df)
+
+	for i := range res.sigmas {
+		res.sigmas[i] = math.Sqrt(variance * m.varianceFactor(i+1))
+	}
+	res.params = etsParams{
+		Alpha: m.alpha,
+		Beta:  m.beta,
+		Gamma: m.gamma,
+		SSE:   sse,
+		Sigma: math.Sqrt(variance),
+	}
+	return res, nil
+}
+
+// varianceFactor returns the ratio of the variance of the forecast
+// error at horizon h to the variance of the one-step-ahead error.
+//
+// See Hyndman, R.J., Koehler, A.B., Ord, J.K. and Snyder, R.D. (2008)
+// Forecasting with Exponential Smoothing, table 6.1.
+func (m *etsModel) varianceFactor(h int) float64 {
+	a, b, g := m.alpha, m.beta, m.gamma
+	if !m.trend {
+		b = 0
+	}
+	hf := float64(h)
+	v := 1 + (hf-1)*(a*a+a*b*hf+b*b*hf*(2*hf-1)/6)
+	if m.m > 0 {
+		k := float64((h - 1) / m.m)
+		v += g * k * (2*a + g + b*float64(m.m)*(k+1))
+	}
+	return v
+}
+
+// zScore returns the number of standard deviations on either
+// side of the forecast that contain the given probability.
+func zScore(level float64) float64 {
+	return distuv.UnitNormal.Quantile((1 + level) / 2)
+}
@@ -0,0 +1,418 @@
+package forecast
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/metadata"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/runtime"
+	"github.com/influxdata/flux/values"
+)
+
+const pkgpath = "experimental/forecast"
+
+const ETSKind = "forecastETS"
+
+const (
+	ForecastColumn = "forecast"
+	LowerColumn    = "lower"
+	UpperColumn    = "upper"
+)
+
+// ETSMetadataKey is the metadata key that the fitted
+// model parameters of each table are reported under.
+const ETSMetadataKey = "flux/forecast-ets"
+
+func init() {
+	runtime.RegisterPackageValue(pkgpath, "ets", flux.MustValue(flux.FunctionValue("ets", createETSOpSpec, runtime.MustLookupBuiltinType(pkgpath, "ets"))))
+	plan.RegisterProcedureSpec(ETSKind, newETSProcedure, ETSKind)
+	execute.RegisterTransformation(ETSKind, createETSTransformation)
+}
+
+type ETSOpSpec struct {
+	N           int64         `json:"n"`
+	Interval    flux.Duration `json:"interval"`
+	Seasonality int64         `json:"seasonality"`
+	Trend       bool          `json:"trend"`
+	Level       float64       `json:"level"`
+	WithFit     bool          `json:"withFit"`
+	Column      string        `json:"column"`
+	TimeColumn  string        `json:"timeColumn"`
+}
+
+func createETSOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(ETSOpSpec)
+	if n, err := args.GetRequiredInt("n"); err != nil {
+		return nil, err
+	} else if n <= 0 {
+		return nil, errors.Newf(codes.Invalid, "n must be greater than zero, got %d", n)
+	} else {
+		spec.N = n
+	}
+
+	if interval, err := args.GetRequiredDuration("interval"); err != nil {
+		return nil, err
+	} else if !interval.IsPositive() {
+		return nil, errors.New(codes.Invalid, "interval must be positive")
+	} else {
+		spec.Interval = interval
+	}
+
+	if s, ok, err := args.GetInt("seasonality"); err != nil {
+		return nil, err
+	} else if ok {
+		if s < 0 {
+			return nil, errors.Newf(codes.Invalid, "seasonality must not be negative, got %d", s)
+		}
+		spec.Seasonality = s
+	}
+
+	if trend, ok, err := args.GetBool("trend"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Trend = trend
+	} else {
+		spec.Trend = true
+	}
+
+	if level, ok, err := args.GetFloat("level"); err != nil {
+		return nil, err
+	} else if ok {
+		if level <= 0 || level >= 1 {
+			return nil, errors.Newf(codes.Invalid, "level must be between 0 and 1, got %v", level)
+		}
+		spec.Level = level
+	} else {
+		spec.Level = 0.95
+	}
+
+	if withFit, ok, err := args.GetBool("withFit"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.WithFit = withFit
+	}
+
+	if col, ok, err := args.GetString("column"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Column = col
+	} else {
+		spec.Column = execute.DefaultValueColLabel
+	}
+
+	if col, ok, err := args.GetString("timeColumn"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.TimeColumn = col
+	} else {
+		spec.TimeColumn = execute.DefaultTimeColLabel
+	}
+	return spec, nil
+}
+
+func (s *ETSOpSpec) Kind() flux.OperationKind {
+	return ETSKind
+}
+
+type ETSProcedureSpec struct {
+	plan.DefaultCost
+	N           int64
+	Interval    flux.Duration
+	Seasonality int64
+	Trend       bool
+	Level       float64
+	WithFit     bool
+	Column      string
+	TimeColumn  string
+}
+
+func newETSProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*ETSOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+	return &ETSProcedureSpec{
+		N:           spec.N,
+		Interval:    spec.Interval,
+		Seasonality: spec.Seasonality,
+		Trend:       spec.Trend,
+		Level:       spec.Level,
+		WithFit:     spec.WithFit,
+		Column:      spec.Column,
+		TimeColumn:  spec.TimeColumn,
+	}, nil
+}
+
+func (s *ETSProcedureSpec) Kind() plan.ProcedureKind {
+	return ETSKind
+}
+
+func (s *ETSProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+func createETSTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*ETSProcedureSpec)
+	if !ok {
+		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
+	}
+	return NewETSTransformation(a.Context(), id, s, a.Allocator())
+}
+
+// ETSMetadata are the fitted model parameters of a table.
+type ETSMetadata struct {
+	// Group is the group key of the table.
+	Group string `json:"group"`
+	// Alpha, Beta, and Gamma are the smoothing parameters
+	// of the level, trend, and seasonal components.
+	Alpha float64 `json:"alpha"`
+	Beta  float64 `json:"beta"`
+	Gamma float64 `json:"gamma"`
+	// SSE is the sum of the squared one-step-ahead errors.
+	SSE float64 `json:"sse"`
+	// Sigma is the standard deviation of the one-step-ahead errors.
+	Sigma float64 `json:"sigma"`
+}
+
+type etsTransformation struct {
+	spec     *ETSProcedureSpec
+	interval values.Duration
+	z        float64
+
+	// metadata receives the fitted model parameters
+	// when the query has execution dependencies.
+	metadata *metadata.SyncMetadata
+}
+
+// NewETSTransformation constructs a transformation that fits an additive
+// exponential smoothing model to each table and forecasts the next n values
+// with prediction intervals.
+func NewETSTransformation(ctx context.Context, id execute.DatasetID, spec *ETSProcedureSpec, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	tr := &etsTransformation{
+		spec:     spec,
+		interval: values.Duration(spec.Interval),
+		z:        zScore(spec.Level),
+	}
+	if execute.HaveExecutionDependencies(ctx) {
+		md := execute.GetExecutionDependencies(ctx).Metadata
+		tr.metadata = &md
+	}
+	return execute.NewAggregateTransformation(id, tr, mem)
+}
+
+// etsState holds the points of a table with a valid time.
+type etsState struct {
+	times  []int64
+	values []float64
+	valid  []bool
+}
+
+func (s *etsState) Len() int           { return len(s.times) }
+func (s *etsState) Less(i, j int) bool { return s.times[i] < s.times[j] }
+func (s *etsState) Swap(i, j int) {
+	s.times[i], s.times[j] = s.times[j], s.times[i]
+	s.values[i], s.values[j] = s.values[j], s.values[i]
+	s.valid[i], s.valid[j] = s.valid[j], s.valid[i]
+}
+
+func (t *etsTransformation) Aggregate(chunk table.Chunk, state interface{}, mem memory.Allocator) (interface{}, bool, error) {
+	s, _ := state.(*etsState)
+	if s == nil {
+		s = &etsState{}
+	}
+	if chunk.Len() == 0 {
+		return s, true, nil
+	}
+
+	timeIdx := chunk.Index(t.spec.TimeColumn)
+	if timeIdx < 0 {
+		return nil, false, errors.Newf(codes.FailedPrecondition, "cannot find time column %s", t.spec.TimeColumn)
+	} else if typ := chunk.Col(timeIdx).Type; typ != flux.TTime {
+		return nil, false, errors.Newf(codes.FailedPrecondition, "time column %s is type %s and not %s", t.spec.TimeColumn, typ, flux.TTime)
+	}
+	valueIdx := chunk.Index(t.spec.Column)
+	if valueIdx < 0 {
+		return nil, false, errors.Newf(codes.FailedPrecondition, "cannot find column %s", t.spec.Column)
+	}
+
+	ts := chunk.Ints(timeIdx)
+	appendValue := func(i int, v float64, valid bool) error {
+		if valid && (math.IsNaN(v) || math.IsInf(v, 0)) {
+			return errors.New(codes.Invalid, "NaN/Inf in input")
+		}
+		s.times = append(s.times, ts.Value(i))
+		s.values = append(s.values, v)
+		s.valid = append(s.valid, valid)
+		return nil
+	}
+	for i, l := 0, chunk.Len(); i < l; i++ {
+		// Rows with a null time are discarded.
+		if ts.IsNull(i) {
+			continue
+		}
+		var err error
+		switch typ := chunk.Col(valueIdx).Type; typ {
+		case flux.TFloat:
+			vs := chunk.Floats(valueIdx)
+			err = appendValue(i, vs.Value(i), vs.IsValid(i))
+		case flux.TInt:
+			vs := chunk.Ints(valueIdx)
+			err = appendValue(i, float64(vs.Value(i)), vs.IsValid(i))
+		case flux.TUInt:
+			vs := chunk.Uints(valueIdx)
+			err = appendValue(i, float64(vs.Value(i)), vs.IsValid(i))
+		default:
+			return nil, false, errors.Newf(codes.FailedPrecondition, "ets can work only on numerical types, got %s", typ)
+		}
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	return s, true, nil
+}
+
+// regularize divides the points into buckets of the interval.
+// The first value of a bucket is used and a bucket without a
+// value is treated as missing. Leading missing values are dropped.
+// It returns the values with the times of the first and last values.
+func (t *etsTransformation) regularize(s *etsState) (ys []float64, valid []bool, start, stop values.Time) {
+	sort.Stable(s)
+
+	var bucket values.Time
+	for i, tm := range s.times {
+		ts := values.Time(tm)
+		if len(ys) == 0 {
+			if !s.valid[i] {
+				continue
+			}
+			start, stop, bucket = ts, ts, ts.Round(t.interval)
+			ys, valid = append(ys, s.values[i]), append(valid, true)
+			continue
+		}
+
+		rounded := ts.Round(t.interval)
+		if rounded <= bucket {
+			continue
+		}
+		bucket = bucket.Add(t.interval)
+		for rounded > bucket {
+			ys, valid = append(ys, 0), append(valid, false)
+			bucket = bucket.Add(t.interval)
+		}
+		ys, valid = append(ys, s.values[i]), append(valid, s.valid[i])
+		stop = ts
+	}
+	return ys, valid, start, stop
+}
+
+func (t *etsTransformation) Compute(key flux.GroupKey, state interface{}, d *execute.TransportDataset, mem memory.Allocator) error {
+	if key.HasCol(t.spec.TimeColumn) {
+		return errors.Newf(codes.FailedPrecondition, "time column %s must not be part of the group key", t.spec.TimeColumn)
+	}
+	s := state.(*etsState)
+
+	model := &etsModel{trend: t.spec.Trend}
+	if t.spec.Seasonality >= 2 {
+		model.m = int(t.spec.Seasonality)
+	}
+
+	var (
+		times []values.Time
+		ys    []float64
+		lower []float64
+		upper []float64
+	)
+	if vs, valid, start, stop := t.regularize(s); len(vs) >= model.minPoints() {
+		res, err := model.fit(vs, valid, int(t.spec.N))
+		if err != nil {
+			return err
+		}
+		if t.metadata != nil {
+			t.metadata.Add(ETSMetadataKey, ETSMetadata{
+				Group: key.String(),
+				Alpha: res.params.Alpha,
+				Beta:  res.params.Beta,
+				Gamma: res.params.Gamma,
+				SSE:   res.params.SSE,
+				Sigma: res.params.Sigma,
+			})
+		}
+
+		if t.spec.WithFit {
+			ts := start
+			for _, y := range res.fitted {
+				times = append(times, ts)
+				ys = append(ys, y)
+				lower = append(lower, y-t.z*res.params.Sigma)
+				upper = append(upper, y+t.z*res.params.Sigma)
+				ts = ts.Add(t.interval)
+			}
+		}
+		ts := stop
+		for i, y := range res.forecasts {
+			ts = ts.Add(t.interval)
+			times = append(times, ts)
+			ys = append(ys, y)
+			lower = append(lower, y-t.z*res.sigmas[i])
+			upper = append(upper, y+t.z*res.sigmas[i])
+		}
+	}
+
+	n := len(times)
+	cols := make([]flux.ColMeta, 0, len(key.Cols())+4)
+	vs := make([]array.Array, 0, len(key.Cols())+4)
+	for j, col := range key.Cols() {
+		cols = append(cols, col)
+		vs = append(vs, arrow.Repeat(col.Type, key.Value(j), n, mem))
+	}
+	cols = append(cols,
+		flux.ColMeta{Label: t.spec.TimeColumn, Type: flux.TTime},
+		flux.ColMeta{Label: ForecastColumn, Type: flux.TFloat},
+		flux.ColMeta{Label: LowerColumn, Type: flux.TFloat},
+		flux.ColMeta{Label: UpperColumn, Type: flux.TFloat},
+	)
+
+	tb := array.NewIntBuilder(mem)
+	tb.Resize(n)
+	for _, ts := range times {
+		tb.Append(int64(ts))
+	}
+	vs = append(vs,
+		tb.NewArray(),
+		floatArray(ys, mem),
+		floatArray(lower, mem),
+		floatArray(upper, mem),
+	)
+
+	return d.Process(table.ChunkFromBuffer(arrow.TableBuffer{
+		GroupKey: key,
+		Columns:  cols,
+		Values:   vs,
+	}))
+}
+
+func (t *etsTransformation) Close() error { return nil }
+
+func floatArray(vs []float64, mem memory.Allocator) array.Array {
+	b := array.NewFloatBuilder(mem)
+	b.Resize(len(vs))
+	for _, v := range vs {
+		b.Append(v)
+	}
+	return b.NewArray()
+}
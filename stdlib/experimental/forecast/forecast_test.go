@@ -0,0 +1,200 @@
+package forecast_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/metadata"
+	"github.com/influxdata/flux/stdlib/experimental/forecast"
+	"github.com/influxdata/flux/values"
+)
+
+var outputCols = []flux.ColMeta{
+	{Label: "t0", Type: flux.TString},
+	{Label: "_time", Type: flux.TTime},
+	{Label: "forecast", Type: flux.TFloat},
+	{Label: "lower", Type: flux.TFloat},
+	{Label: "upper", Type: flux.TFloat},
+}
+
+// inputTable creates a table with a row every 10 time units for each value.
+func inputTable(vs ...interface{}) *executetest.Table {
+	tbl := &executetest.Table{
+		KeyCols: []string{"t0"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TFloat},
+			{Label: "t0", Type: flux.TString},
+		},
+	}
+	for i, v := range vs {
+		tbl.Data = append(tbl.Data, []interface{}{execute.Time(i * 10), v, "a"})
+	}
+	return tbl
+}
+
+func TestETS(t *testing.T) {
+	testCases := []struct {
+		name    string
+		spec    *forecast.ETSProcedureSpec
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "trend",
+			spec: &forecast.ETSProcedureSpec{
+				N:          3,
+				Interval:   flux.ConvertDuration(10),
+				Trend:      true,
+				Level:      0.95,
+				Column:     execute.DefaultValueColLabel,
+				TimeColumn: execute.DefaultTimeColLabel,
+			},
+			data: []flux.Table{inputTable(1.0, 3.0, 5.0, 7.0, 9.0, 11.0)},
+			want: []*executetest.Table{{
+				KeyCols: []string{"t0"},
+				ColMeta: outputCols,
+				Data: [][]interface{}{
+					{"a", execute.Time(60), 13.0, 13.0, 13.0},
+					{"a", execute.Time(70), 15.0, 15.0, 15.0},
+					{"a", execute.Time(80), 17.0, 17.0, 17.0},
+				},
+			}},
+		},
+		{
+			name: "seasonal with fit",
+			spec: &forecast.ETSProcedureSpec{
+				N:           2,
+				Interval:    flux.ConvertDuration(10),
+				Seasonality: 2,
+				Level:       0.95,
+				WithFit:     true,
+				Column:      execute.DefaultValueColLabel,
+				TimeColumn:  execute.DefaultTimeColLabel,
+			},
+			data: []flux.Table{inputTable(100.0, 110.0, 100.0, nil, 100.0, 110.0)},
+			want: []*executetest.Table{{
+				KeyCols: []string{"t0"},
+				ColMeta: outputCols,
+				Data: [][]interface{}{
+					{"a", execute.Time(0), 100.0, 100.0, 100.0},
+					{"a", execute.Time(10), 110.0, 110.0, 110.0},
+					{"a", execute.Time(20), 100.0, 100.0, 100.0},
+					{"a", execute.Time(30), 110.0, 110.0, 110.0},
+					{"a", execute.Time(40), 100.0, 100.0, 100.0},
+					{"a", execute.Time(50), 110.0, 110.0, 110.0},
+					{"a", execute.Time(60), 100.0, 100.0, 100.0},
+					{"a", execute.Time(70), 110.0, 110.0, 110.0},
+				},
+			}},
+		},
+		{
+			name: "too few values",
+			spec: &forecast.ETSProcedureSpec{
+				N:          3,
+				Interval:   flux.ConvertDuration(10),
+				Trend:      true,
+				Level:      0.95,
+				Column:     execute.DefaultValueColLabel,
+				TimeColumn: execute.DefaultTimeColLabel,
+			},
+			data: []flux.Table{inputTable(1.0)},
+			want: []*executetest.Table{{
+				KeyCols:   []string{"t0"},
+				KeyValues: []interface{}{"a"},
+				ColMeta:   outputCols,
+			}},
+		},
+		{
+			name: "non-numeric column",
+			spec: &forecast.ETSProcedureSpec{
+				N:          3,
+				Interval:   flux.ConvertDuration(10),
+				Level:      0.95,
+				Column:     "t0",
+				TimeColumn: execute.DefaultTimeColLabel,
+			},
+			data:    []flux.Table{inputTable(1.0, 2.0)},
+			wantErr: errors.New(codes.FailedPrecondition, "ets can work only on numerical types, got string"),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper2(
+				t,
+				tc.data,
+				tc.want,
+				tc.wantErr,
+				func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+					tr, d, err := forecast.NewETSTransformation(context.Background(), id, tc.spec, alloc)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return tr, d
+				},
+			)
+		})
+	}
+}
+
+func TestETS_Metadata(t *testing.T) {
+	deps := execute.DefaultExecutionDependencies()
+	ctx := deps.Inject(context.Background())
+	spec := &forecast.ETSProcedureSpec{
+		N:          1,
+		Interval:   flux.ConvertDuration(10),
+		Trend:      true,
+		Level:      0.95,
+		Column:     execute.DefaultValueColLabel,
+		TimeColumn: execute.DefaultTimeColLabel,
+	}
+	executetest.ProcessTestHelper2(
+		t,
+		[]flux.Table{inputTable(1.0, 3.0, 5.0)},
+		[]*executetest.Table{{
+			KeyCols: []string{"t0"},
+			ColMeta: outputCols,
+			Data: [][]interface{}{
+				{"a", execute.Time(30), 7.0, 7.0, 7.0},
+			},
+		}},
+		nil,
+		func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+			tr, d, err := forecast.NewETSTransformation(ctx, id, spec, alloc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return tr, d
+		},
+	)
+
+	var got []interface{}
+	deps.Metadata.ReadView(func(meta metadata.Metadata) {
+		got = meta.GetAll(forecast.ETSMetadataKey)
+	})
+	if len(got) != 1 {
+		t.Fatalf("unexpected number of metadata values -want/+got:\n\t- 1\n\t+ %d", len(got))
+	}
+	md, ok := got[0].(forecast.ETSMetadata)
+	if !ok {
+		t.Fatalf("unexpected metadata type %T", got[0])
+	}
+	key := execute.NewGroupKey(
+		[]flux.ColMeta{{Label: "t0", Type: flux.TString}},
+		[]values.Value{values.NewString("a")},
+	)
+	if want, got := key.String(), md.Group; want != got {
+		t.Errorf("unexpected group -want/+got:\n\t- %s\n\t+ %s", want, got)
+	}
+	if md.SSE != 0 {
+		t.Errorf("unexpected sum of squared errors %v", md.SSE)
+	}
+}
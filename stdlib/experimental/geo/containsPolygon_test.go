@@ -0,0 +1,288 @@
+package geo_test
+
+import (
+	"context"
+	"testing"
+
+	_ "github.com/influxdata/flux/fluxinit/static"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/stdlib/experimental/geo"
+	"github.com/influxdata/flux/values"
+)
+
+func TestContainsPolygon_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name:    "no args",
+			Raw:     `import "experimental/geo" geo.containsPolygon()`,
+			WantErr: true, // missing required parameter(s)
+		},
+		{
+			Name:    "missing point arg",
+			Raw:     `import "experimental/geo" geo.containsPolygon(polygon: {points: [{lat: 40.0, lon: -74.0}, {lat: 40.0, lon: -73.0}, {lat: 41.0, lon: -73.5}]})`,
+			WantErr: true, // missing required parameter(s)
+		},
+		{
+			Name:    "invalid args - polygon with too few points",
+			Raw:     `import "experimental/geo" geo.containsPolygon(polygon: {points: [{lat: 40.0, lon: -74.0}]}, point: {lat: 40.5, lon: -74.5})`,
+			WantErr: true, // polygon must have at least 3 points
+		},
+		{
+			Name:    "invalid args - not a polygon",
+			Raw:     `import "experimental/geo" geo.containsPolygon(polygon: {lat: 40.5, lon: -74.5, radius: 15.0}, point: {lat: 40.5, lon: -74.5})`,
+			WantErr: true, // region is a circle
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func pointsToValue(points []point) values.Array {
+	array := values.NewArray(semantic.NewArrayType(pointT))
+	for _, p := range points {
+		array.Append(values.NewObjectWithValues(map[string]values.Value{
+			"lat": values.NewFloat(p.lat),
+			"lon": values.NewFloat(p.lon),
+		}))
+	}
+	return array
+}
+
+type point struct {
+	lat float64
+	lon float64
+}
+
+func TestContainsPolygon_Process(t *testing.T) {
+	shell := []point{
+		{lat: 40.0, lon: -74.0},
+		{lat: 40.0, lon: -73.0},
+		{lat: 41.0, lon: -73.0},
+		{lat: 41.0, lon: -74.0},
+	}
+	hole := []point{
+		{lat: 40.4, lon: -73.6},
+		{lat: 40.4, lon: -73.4},
+		{lat: 40.6, lon: -73.4},
+		{lat: 40.6, lon: -73.6},
+	}
+	// concave polygon shaped like the letter U
+	concave := []point{
+		{lat: 40.0, lon: -74.0},
+		{lat: 40.0, lon: -73.0},
+		{lat: 41.0, lon: -73.0},
+		{lat: 41.0, lon: -73.3},
+		{lat: 40.3, lon: -73.3},
+		{lat: 40.3, lon: -73.7},
+		{lat: 41.0, lon: -73.7},
+		{lat: 41.0, lon: -74.0},
+	}
+	reversed := func(points []point) []point {
+		r := make([]point, len(points))
+		for i, p := range points {
+			r[len(points)-1-i] = p
+		}
+		return r
+	}
+
+	testCases := []struct {
+		name  string
+		shell []point
+		holes [][]point
+		point point
+		want  bool
+	}{
+		{
+			name:  "contains",
+			shell: shell,
+			point: point{lat: 40.2, lon: -73.8},
+			want:  true,
+		},
+		{
+			name:  "contains - clockwise points",
+			shell: reversed(shell),
+			point: point{lat: 40.2, lon: -73.8},
+			want:  true,
+		},
+		{
+			name:  "not contains",
+			shell: shell,
+			point: point{lat: 41.5, lon: -73.5},
+			want:  false,
+		},
+		{
+			name:  "hole not contains",
+			shell: shell,
+			holes: [][]point{hole},
+			point: point{lat: 40.5, lon: -73.5},
+			want:  false,
+		},
+		{
+			name:  "hole not contains - clockwise hole",
+			shell: shell,
+			holes: [][]point{reversed(hole)},
+			point: point{lat: 40.5, lon: -73.5},
+			want:  false,
+		},
+		{
+			name:  "hole contains outside of hole",
+			shell: shell,
+			holes: [][]point{hole},
+			point: point{lat: 40.2, lon: -73.8},
+			want:  true,
+		},
+		{
+			name:  "concave contains",
+			shell: concave,
+			point: point{lat: 40.8, lon: -73.85},
+			want:  true,
+		},
+		{
+			name:  "concave not contains",
+			shell: concave,
+			point: point{lat: 40.8, lon: -73.5},
+			want:  false,
+		},
+	}
+
+	containsPolygon := geo.Functions["containsPolygon"]
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			polygon := map[string]values.Value{
+				"points": pointsToValue(tc.shell),
+			}
+			if tc.holes != nil {
+				holes := values.NewArray(semantic.NewArrayType(semantic.NewArrayType(pointT)))
+				for _, h := range tc.holes {
+					holes.Append(pointsToValue(h))
+				}
+				polygon["holes"] = holes
+			}
+			args := values.NewObjectWithValues(map[string]values.Value{
+				"polygon": values.NewObjectWithValues(polygon),
+				"point": values.NewObjectWithValues(map[string]values.Value{
+					"lat": values.NewFloat(tc.point.lat),
+					"lon": values.NewFloat(tc.point.lon),
+				}),
+			})
+			result, err := containsPolygon.Call(context.Background(), args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := result.Bool(); got != tc.want {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSTContains_Polygons(t *testing.T) {
+	polygonT := semantic.NewObjectType([]semantic.PropertyType{
+		{Key: []byte("points"), Value: semantic.NewArrayType(pointT)},
+	})
+	polygons := values.NewArray(semantic.NewArrayType(polygonT))
+	// A row of small squares along the equator, each one degree wide.
+	for lon := -50.0; lon < 50.0; lon += 2 {
+		polygons.Append(values.NewObjectWithValues(map[string]values.Value{
+			"points": pointsToValue([]point{
+				{lat: 0, lon: lon},
+				{lat: 0, lon: lon + 1},
+				{lat: 1, lon: lon + 1},
+				{lat: 1, lon: lon},
+			}),
+		}))
+	}
+	region := values.NewObjectWithValues(map[string]values.Value{
+		"polygons": polygons,
+	})
+
+	stContains := geo.Functions["stContains"]
+	for _, tc := range []struct {
+		point point
+		want  bool
+	}{
+		{point: point{lat: 0.5, lon: -49.5}, want: true},
+		{point: point{lat: 0.5, lon: -48.5}, want: false},
+		{point: point{lat: 0.5, lon: 10.5}, want: true},
+		{point: point{lat: 0.5, lon: 49.5}, want: false},
+		{point: point{lat: 1.5, lon: 10.5}, want: false},
+	} {
+		args := values.NewObjectWithValues(map[string]values.Value{
+			"region": region,
+			"geometry": values.NewObjectWithValues(map[string]values.Value{
+				"lat": values.NewFloat(tc.point.lat),
+				"lon": values.NewFloat(tc.point.lon),
+			}),
+			"units": unitsToValue(map[string]string{"distance": "km"}),
+		})
+		result, err := stContains.Call(context.Background(), args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := result.Bool(); got != tc.want {
+			t.Errorf("point %v: expected %v, got %v", tc.point, tc.want, got)
+		}
+	}
+}
+
+func TestDistance_Process(t *testing.T) {
+	distance := geo.Functions["_distance"]
+	for _, tc := range []struct {
+		name  string
+		units string
+		from  point
+		to    point
+		want  float64
+	}{
+		{
+			name:  "same point",
+			units: "km",
+			from:  point{lat: 40.7128, lon: -74.0060},
+			to:    point{lat: 40.7128, lon: -74.0060},
+			want:  0,
+		},
+		{
+			name:  "new york to boston",
+			units: "km",
+			from:  point{lat: 40.7128, lon: -74.0060},
+			to:    point{lat: 42.3601, lon: -71.0589},
+			want:  306.1089746831342,
+		},
+		{
+			name:  "new york to boston - m units",
+			units: "m",
+			from:  point{lat: 40.7128, lon: -74.0060},
+			to:    point{lat: 42.3601, lon: -71.0589},
+			want:  306108.9746831342,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			args := values.NewObjectWithValues(map[string]values.Value{
+				"from": values.NewObjectWithValues(map[string]values.Value{
+					"lat": values.NewFloat(tc.from.lat),
+					"lon": values.NewFloat(tc.from.lon),
+				}),
+				"to": values.NewObjectWithValues(map[string]values.Value{
+					"lat": values.NewFloat(tc.to.lat),
+					"lon": values.NewFloat(tc.to.lon),
+				}),
+				"units": unitsToValue(map[string]string{"distance": tc.units}),
+			})
+			result, err := distance.Call(context.Background(), args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := result.Float(); got < tc.want-1e-6 || got > tc.want+1e-6 {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
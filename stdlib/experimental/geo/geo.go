@@ -5,11 +5,13 @@ import (
 	"math"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/golang/geo/r1"
 	"github.com/golang/geo/s1"
 	"github.com/golang/geo/s2"
 	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/internal/errors"
 	"github.com/influxdata/flux/interpreter"
 	"github.com/influxdata/flux/runtime"
@@ -32,6 +34,7 @@ type circle struct {
 
 type polygon struct {
 	points []s2.Point
+	holes  [][]s2.Point
 }
 
 type polyline struct {
@@ -131,7 +134,9 @@ func generateGetGridFunc() values.Function {
 			case circle:
 				region = getS2CapRegion(v)
 			case polygon:
-				region = getS2LoopRegion(v)
+				region = getS2PolygonRegion(v)
+			case *shapeSet:
+				region = v
 			default:
 				return nil, errors.Newf(codes.Invalid, "unsupported region type: %T", geom)
 			}
@@ -257,11 +262,85 @@ func generateS2CellLatLonFunc() values.Function {
 	)
 }
 
+func generateContainsPolygonFunc() values.Function {
+	containsPolygonSignature := runtime.MustLookupBuiltinType("experimental/geo", "containsPolygon")
+	return values.NewFunction(
+		"containsPolygon",
+		containsPolygonSignature,
+		func(ctx context.Context, args values.Object) (values.Value, error) {
+			a := interpreter.NewArguments(args)
+
+			polygonArg, err := a.GetRequiredObject("polygon")
+			if err != nil {
+				return nil, err
+			}
+			pointArg, err := a.GetRequiredObject("point")
+			if err != nil {
+				return nil, err
+			}
+
+			region, err := parsePolygonRegionArgument(ctx, polygonArg)
+			if err != nil {
+				return nil, err
+			}
+
+			p, err := parsePointArgument("point", pointArg)
+			if err != nil {
+				return nil, err
+			}
+			return values.NewBool(region.ContainsPoint(getS2Point(p))), nil
+		}, false,
+	)
+}
+
+func generateDistanceFunc() values.Function {
+	distanceSignature := runtime.MustLookupBuiltinType("experimental/geo", "_distance")
+	return values.NewFunction(
+		"_distance",
+		distanceSignature,
+		func(ctx context.Context, args values.Object) (values.Value, error) {
+			a := interpreter.NewArguments(args)
+			unitsArg, err := a.GetRequiredObject("units")
+			if err != nil {
+				return nil, err
+			}
+			units, err := parseUnitsArgument(unitsArg)
+			if err != nil {
+				return nil, err
+			}
+
+			fromArg, err := a.GetRequiredObject("from")
+			if err != nil {
+				return nil, err
+			}
+			toArg, err := a.GetRequiredObject("to")
+			if err != nil {
+				return nil, err
+			}
+
+			from, err := parsePointArgument("from", fromArg)
+			if err != nil {
+				return nil, err
+			}
+			to, err := parsePointArgument("to", toArg)
+			if err != nil {
+				return nil, err
+			}
+
+			distance := s2.LatLngFromDegrees(from.lat, from.lon).Distance(s2.LatLngFromDegrees(to.lat, to.lon))
+			return values.NewFloat(units.distanceToUser(distance.Radians())), nil
+		}, false,
+	)
+}
+
 func init() {
+	runtime.RegisterPackageValue("experimental/geo", "_distance", generateDistanceFunc())
+	runtime.RegisterPackageValue("experimental/geo", "containsPolygon", generateContainsPolygonFunc())
 	runtime.RegisterPackageValue("experimental/geo", "getGrid", generateGetGridFunc())
 	runtime.RegisterPackageValue("experimental/geo", "getLevel", generateGetLevelFunc())
 	runtime.RegisterPackageValue("experimental/geo", "s2CellIDToken", generateS2CellIDTokenFunc())
 	runtime.RegisterPackageValue("experimental/geo", "s2CellLatLon", generateS2CellLatLonFunc())
+	runtime.RegisterPackageValue("experimental/geo", "shapes", generateShapesFunc())
 	runtime.RegisterPackageValue("experimental/geo", "stContains", generateSTContainsFunc())
 	runtime.RegisterPackageValue("experimental/geo", "stDistance", generateSTDistanceFunc())
 	runtime.RegisterPackageValue("experimental/geo", "stLength", generateSTLengthFunc())
@@ -339,31 +418,33 @@ func parseGeometryArgument(name string, arg values.Object, units *units) (geom i
 		}
 	}
 
-	points, polygonOk := arg.Get("points")
-	if polygonOk && arg.Len() == 1 {
-		array := points.Array()
-		if array.Len() < 3 {
-			err = errors.Newf(codes.Invalid, "polygon must have at least 3 points")
+	_, polygonOk := arg.Get("points")
+	_, holesOk := arg.Get("holes")
+	if polygonOk && (arg.Len() == 1 || holesOk && arg.Len() == 2) {
+		geom, err = parsePolygon(arg)
+		if err != nil {
+			return nil, err
 		}
+	}
 
-		s2points := make([]s2.Point, array.Len())
+	polygons, polygonsOk := arg.Get("polygons")
+	if polygonsOk && arg.Len() == 1 {
+		if polygons.IsNull() {
+			return nil, errors.Newf(codes.Invalid, "invalid polygons specification - polygons must not be null")
+		}
+		array := polygons.Array()
+		if array.Len() == 0 {
+			return nil, errors.Newf(codes.Invalid, "invalid polygons specification - at least one polygon is required")
+		}
+		ps := make([]polygon, array.Len())
 		for i := 0; i < array.Len(); i++ {
-			p := array.Get(i).Object()
-			lat, latOk := p.Get("lat")
-			lon, lonOk := p.Get("lon")
-
-			if !latOk || !lonOk {
-				return nil, errors.Newf(codes.Invalid, "invalid polygon point specification - must have lat, lon fields")
-			} else if lat.IsNull() || lon.IsNull() {
-				return nil, errors.Newf(codes.Invalid, "invalid polygon point specification - lat, lon must not be null")
+			p, err := parsePolygon(array.Get(i).Object())
+			if err != nil {
+				return nil, err
 			}
-
-			s2points[i] = s2.PointFromLatLng(s2.LatLngFromDegrees(lat.Float(), lon.Float()))
-		}
-
-		geom = polygon{
-			points: s2points,
+			ps[i] = p
 		}
+		geom = newShapeSet(ps)
 	}
 
 	ls, lsOk := arg.Get("linestring")
@@ -399,6 +480,163 @@ func parseGeometryArgument(name string, arg values.Object, units *units) (geom i
 	return geom, err
 }
 
+// regionCache holds the regions most recently built from region records.
+// Row functions, such as the one used by strictFilter, pass the same region
+// record for every row so caching the result avoids rebuilding the region,
+// and any index over it, once per row.
+// Each query has its own cache so the regions are released with the query.
+type regionCache struct {
+	sync.Mutex
+	entries map[regionCacheKey]s2.Region
+}
+
+// regionCacheID is the key of the region cache in the query cache.
+type regionCacheID struct{}
+
+func getRegionCache(ctx context.Context) *regionCache {
+	return execute.GetQueryCache(ctx).LoadOrCreate(regionCacheID{}, func() interface{} {
+		return &regionCache{entries: make(map[regionCacheKey]s2.Region)}
+	}).(*regionCache)
+}
+
+// maxCachedRegions is the number of regions kept before the cache is reset.
+const maxCachedRegions = 64
+
+type regionCacheKey struct {
+	arg   values.Object
+	units string
+}
+
+// parseRegionArgument parses the record as a region.
+// Regions are cached by the identity of the record so repeated calls
+// with the same record do not reparse it.
+func parseRegionArgument(ctx context.Context, name string, arg values.Object, units *units) (s2.Region, error) {
+	return cachedRegion(ctx, arg, units.distance, func() (s2.Region, error) {
+		geom, err := parseGeometryArgument(name, arg, units)
+		if err != nil {
+			return nil, err
+		}
+		switch v := geom.(type) {
+		case box:
+			return getS2RectRegion(v), nil
+		case circle:
+			return getS2CapRegion(v), nil
+		case polygon:
+			return getS2PolygonRegion(v), nil
+		case *shapeSet:
+			return v, nil
+		default:
+			return nil, errors.Newf(codes.Invalid, "unsupported region type: %T", geom)
+		}
+	})
+}
+
+// parsePolygonRegionArgument parses the record as a polygon region.
+// Unlike parseRegionArgument, properties other than points and holes are ignored
+// so records such as the ones returned by shapes() can be used directly.
+func parsePolygonRegionArgument(ctx context.Context, arg values.Object) (*s2.Polygon, error) {
+	region, err := cachedRegion(ctx, arg, "", func() (s2.Region, error) {
+		p, err := parsePolygon(arg)
+		if err != nil {
+			return nil, err
+		}
+		return getS2PolygonRegion(p), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return region.(*s2.Polygon), nil
+}
+
+func cachedRegion(ctx context.Context, arg values.Object, units string, build func() (s2.Region, error)) (s2.Region, error) {
+	cache := getRegionCache(ctx)
+	key := regionCacheKey{arg: arg, units: units}
+	cache.Lock()
+	region, ok := cache.entries[key]
+	cache.Unlock()
+	if ok {
+		return region, nil
+	}
+
+	region, err := build()
+	if err != nil {
+		return nil, err
+	}
+
+	cache.Lock()
+	if len(cache.entries) >= maxCachedRegions {
+		cache.entries = make(map[regionCacheKey]s2.Region)
+	}
+	cache.entries[key] = region
+	cache.Unlock()
+	return region, nil
+}
+
+func parsePointArgument(name string, arg values.Object) (point, error) {
+	lat, latOk := arg.Get("lat")
+	lon, lonOk := arg.Get("lon")
+	if !latOk || !lonOk {
+		return point{}, errors.Newf(codes.Invalid, "invalid point specification for '%s' - must have lat, lon fields", name)
+	} else if lat.IsNull() || lon.IsNull() {
+		return point{}, errors.Newf(codes.Invalid, "invalid point specification for '%s' - lat, lon must not be null", name)
+	}
+	return point{
+		lat: lat.Float(),
+		lon: lon.Float(),
+	}, nil
+}
+
+// parsePolygon reads a polygon from a record with a points property
+// and an optional holes property. Any other properties are ignored.
+func parsePolygon(arg values.Object) (polygon, error) {
+	points, ok := arg.Get("points")
+	if !ok || points.IsNull() {
+		return polygon{}, errors.Newf(codes.Invalid, "invalid polygon specification - must have points field")
+	}
+	shell, err := parsePolygonPoints(points.Array())
+	if err != nil {
+		return polygon{}, err
+	}
+	p := polygon{
+		points: shell,
+	}
+
+	if holes, ok := arg.Get("holes"); ok && !holes.IsNull() {
+		array := holes.Array()
+		p.holes = make([][]s2.Point, array.Len())
+		for i := 0; i < array.Len(); i++ {
+			hole, err := parsePolygonPoints(array.Get(i).Array())
+			if err != nil {
+				return polygon{}, err
+			}
+			p.holes[i] = hole
+		}
+	}
+	return p, nil
+}
+
+func parsePolygonPoints(array values.Array) ([]s2.Point, error) {
+	if array.Len() < 3 {
+		return nil, errors.Newf(codes.Invalid, "polygon must have at least 3 points")
+	}
+
+	s2points := make([]s2.Point, array.Len())
+	for i := 0; i < array.Len(); i++ {
+		p := array.Get(i).Object()
+		lat, latOk := p.Get("lat")
+		lon, lonOk := p.Get("lon")
+
+		if !latOk || !lonOk {
+			return nil, errors.Newf(codes.Invalid, "invalid polygon point specification - must have lat, lon fields")
+		} else if lat.IsNull() || lon.IsNull() {
+			return nil, errors.Newf(codes.Invalid, "invalid polygon point specification - lat, lon must not be null")
+		}
+
+		s2points[i] = s2.PointFromLatLng(s2.LatLngFromDegrees(lat.Float(), lon.Float()))
+	}
+	return s2points, nil
+}
+
 // Return units object
 func parseUnitsArgument(arg values.Object) (*units, error) {
 	var u *units
@@ -484,14 +722,26 @@ func getS2CapRegion(c circle) s2.Cap {
 	return s2.CapFromCenterAngle(center, s1.Angle(c.radius))
 }
 
-func getS2LoopRegion(p polygon) *s2.Loop {
-	loop := s2.LoopFromPoints(p.points)
+func getS2Loop(points []s2.Point) *s2.Loop {
+	loop := s2.LoopFromPoints(points)
 	if loop.Area() >= 2*math.Pi { // points are not CCW but CW
 		loop.Invert()
 	}
 	return loop
 }
 
+// getS2PolygonRegion returns the polygon as an s2 polygon.
+// The order of the points in the shell and in the holes does not matter,
+// the loops are normalized and nested by containment.
+func getS2PolygonRegion(p polygon) *s2.Polygon {
+	loops := make([]*s2.Loop, 0, len(p.holes)+1)
+	loops = append(loops, getS2Loop(p.points))
+	for _, hole := range p.holes {
+		loops = append(loops, getS2Loop(hole))
+	}
+	return s2.PolygonFromLoops(loops)
+}
+
 func getGrid(region s2.Region, reqLevel, maxLevel, minSize, maxSize int) (*grid, error) {
 	var result *grid
 
@@ -0,0 +1,247 @@
+package geo
+
+import (
+	"math"
+	"sort"
+
+	"github.com/golang/geo/s2"
+)
+
+// rtreeNodeSize is the maximum number of entries stored in a single node of the index.
+const rtreeNodeSize = 16
+
+// rtree is a static, bulk loaded R-tree over the bounding rectangles of a set of regions.
+// It is built once using the sort-tile-recursive (STR) algorithm and is never modified
+// afterwards, which keeps the nodes compact and the lookups cheap.
+type rtree struct {
+	root   *rtreeNode
+	bounds []s2.Rect
+}
+
+type rtreeNode struct {
+	bound    s2.Rect
+	children []*rtreeNode
+	// entries contains the indexes of the regions for leaf nodes.
+	entries []int
+}
+
+func (n *rtreeNode) isLeaf() bool {
+	return n.children == nil
+}
+
+// newRTree builds an index over the given bounding rectangles.
+// The position of each rectangle is the value reported by the search functions.
+func newRTree(bounds []s2.Rect) *rtree {
+	if len(bounds) == 0 {
+		return &rtree{bounds: bounds}
+	}
+
+	leaves := make([]*rtreeNode, 0, (len(bounds)+rtreeNodeSize-1)/rtreeNodeSize)
+	entries := make([]int, len(bounds))
+	for i := range entries {
+		entries[i] = i
+	}
+	strPartition(entries, func(i int) s2.Rect { return bounds[i] }, func(group []int) {
+		n := &rtreeNode{
+			bound:   s2.EmptyRect(),
+			entries: group,
+		}
+		for _, idx := range group {
+			n.bound = n.bound.Union(bounds[idx])
+		}
+		leaves = append(leaves, n)
+	})
+
+	level := leaves
+	for len(level) > 1 {
+		next := make([]*rtreeNode, 0, (len(level)+rtreeNodeSize-1)/rtreeNodeSize)
+		idxs := make([]int, len(level))
+		for i := range idxs {
+			idxs[i] = i
+		}
+		nodes := level
+		strPartition(idxs, func(i int) s2.Rect { return nodes[i].bound }, func(group []int) {
+			n := &rtreeNode{
+				bound:    s2.EmptyRect(),
+				children: make([]*rtreeNode, len(group)),
+			}
+			for i, idx := range group {
+				n.children[i] = nodes[idx]
+				n.bound = n.bound.Union(nodes[idx].bound)
+			}
+			next = append(next, n)
+		})
+		level = next
+	}
+	return &rtree{root: level[0], bounds: bounds}
+}
+
+// strPartition groups the indexes into runs of at most rtreeNodeSize entries
+// that are close to each other. The indexes are first sorted into vertical slices
+// by the longitude of their center and then each slice is sorted by latitude.
+func strPartition(idxs []int, bound func(i int) s2.Rect, fn func(group []int)) {
+	center := func(i int) s2.LatLng {
+		return bound(i).Center()
+	}
+	sort.SliceStable(idxs, func(i, j int) bool {
+		return center(idxs[i]).Lng < center(idxs[j]).Lng
+	})
+
+	leafCount := (len(idxs) + rtreeNodeSize - 1) / rtreeNodeSize
+	sliceCount := int(math.Ceil(math.Sqrt(float64(leafCount))))
+	sliceSize := sliceCount * rtreeNodeSize
+	for start := 0; start < len(idxs); start += sliceSize {
+		end := start + sliceSize
+		if end > len(idxs) {
+			end = len(idxs)
+		}
+		slice := idxs[start:end]
+		sort.SliceStable(slice, func(i, j int) bool {
+			return center(slice[i]).Lat < center(slice[j]).Lat
+		})
+		for i := 0; i < len(slice); i += rtreeNodeSize {
+			j := i + rtreeNodeSize
+			if j > len(slice) {
+				j = len(slice)
+			}
+			fn(slice[i:j:j])
+		}
+	}
+}
+
+// searchPoint calls fn for every entry whose bounding rectangle contains the point.
+// The search stops early if fn returns false.
+func (t *rtree) searchPoint(ll s2.LatLng, fn func(i int) bool) {
+	if t.root == nil {
+		return
+	}
+	t.searchPointNode(t.root, ll, fn)
+}
+
+func (t *rtree) searchPointNode(n *rtreeNode, ll s2.LatLng, fn func(i int) bool) bool {
+	if !n.bound.ContainsLatLng(ll) {
+		return true
+	}
+	if n.isLeaf() {
+		for _, i := range n.entries {
+			if t.bounds[i].ContainsLatLng(ll) && !fn(i) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, c := range n.children {
+		if !t.searchPointNode(c, ll, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// searchRect calls fn for every entry whose bounding rectangle intersects the rectangle.
+// The search stops early if fn returns false.
+func (t *rtree) searchRect(r s2.Rect, fn func(i int) bool) {
+	if t.root == nil {
+		return
+	}
+	t.searchRectNode(t.root, r, fn)
+}
+
+func (t *rtree) searchRectNode(n *rtreeNode, r s2.Rect, fn func(i int) bool) bool {
+	if !n.bound.Intersects(r) {
+		return true
+	}
+	if n.isLeaf() {
+		for _, i := range n.entries {
+			if t.bounds[i].Intersects(r) && !fn(i) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, c := range n.children {
+		if !t.searchRectNode(c, r, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// shapeSet is a region composed of multiple polygons.
+// A point is contained by the set if any of the polygons contain it.
+// Lookups go through an R-tree over the polygon bounds so that large
+// sets of shapes do not require a check against every polygon.
+type shapeSet struct {
+	polygons []*s2.Polygon
+	index    *rtree
+	bound    s2.Rect
+}
+
+func newShapeSet(polygons []polygon) *shapeSet {
+	s := &shapeSet{
+		polygons: make([]*s2.Polygon, len(polygons)),
+		bound:    s2.EmptyRect(),
+	}
+	bounds := make([]s2.Rect, len(polygons))
+	for i, p := range polygons {
+		s.polygons[i] = getS2PolygonRegion(p)
+		bounds[i] = s.polygons[i].RectBound()
+		s.bound = s.bound.Union(bounds[i])
+	}
+	s.index = newRTree(bounds)
+	return s
+}
+
+// CapBound returns a bounding spherical cap.
+func (s *shapeSet) CapBound() s2.Cap {
+	return s.bound.CapBound()
+}
+
+// RectBound returns a bounding latitude-longitude rectangle.
+func (s *shapeSet) RectBound() s2.Rect {
+	return s.bound
+}
+
+// ContainsCell reports whether any of the polygons contain the given cell.
+func (s *shapeSet) ContainsCell(c s2.Cell) bool {
+	contains := false
+	s.index.searchRect(c.RectBound(), func(i int) bool {
+		contains = s.polygons[i].ContainsCell(c)
+		return !contains
+	})
+	return contains
+}
+
+// IntersectsCell reports whether any of the polygons intersect the given cell.
+func (s *shapeSet) IntersectsCell(c s2.Cell) bool {
+	intersects := false
+	s.index.searchRect(c.RectBound(), func(i int) bool {
+		intersects = s.polygons[i].IntersectsCell(c)
+		return !intersects
+	})
+	return intersects
+}
+
+// ContainsPoint reports whether any of the polygons contain the given point.
+func (s *shapeSet) ContainsPoint(p s2.Point) bool {
+	contains := false
+	s.index.searchPoint(s2.LatLngFromPoint(p), func(i int) bool {
+		contains = s.polygons[i].ContainsPoint(p)
+		return !contains
+	})
+	return contains
+}
+
+// CellUnionBound returns a small collection of cell ids that cover the set.
+func (s *shapeSet) CellUnionBound() []s2.CellID {
+	return s.CapBound().CellUnionBound()
+}
+
+// shapeIndex returns a shape index containing all polygons of the set.
+func (s *shapeSet) shapeIndex() *s2.ShapeIndex {
+	index := s2.NewShapeIndex()
+	for _, p := range s.polygons {
+		index.Add(p)
+	}
+	return index
+}
@@ -0,0 +1,86 @@
+package geo
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/golang/geo/r1"
+	"github.com/golang/geo/s1"
+	"github.com/golang/geo/s2"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRTree_SearchPoint(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	bounds := make([]s2.Rect, 1000)
+	for i := range bounds {
+		lat := rnd.Float64()*170 - 85
+		lon := rnd.Float64()*350 - 175
+		bounds[i] = s2.RectFromLatLng(s2.LatLngFromDegrees(lat, lon)).
+			AddPoint(s2.LatLngFromDegrees(lat+rnd.Float64()*5, lon+rnd.Float64()*5))
+	}
+	index := newRTree(bounds)
+
+	for i := 0; i < 100; i++ {
+		ll := s2.LatLngFromDegrees(rnd.Float64()*170-85, rnd.Float64()*350-175)
+
+		var want []int
+		for j, b := range bounds {
+			if b.ContainsLatLng(ll) {
+				want = append(want, j)
+			}
+		}
+		var got []int
+		index.searchPoint(ll, func(j int) bool {
+			got = append(got, j)
+			return true
+		})
+		sort.Ints(got)
+
+		if !cmp.Equal(want, got) {
+			t.Fatalf("unexpected entries for %v -want/+got:\n%s", ll, cmp.Diff(want, got))
+		}
+	}
+}
+
+func TestRTree_SearchRect(t *testing.T) {
+	rnd := rand.New(rand.NewSource(2))
+	bounds := make([]s2.Rect, 200)
+	for i := range bounds {
+		lat := rnd.Float64()*80 - 40
+		lon := rnd.Float64()*160 - 80
+		bounds[i] = s2.RectFromLatLng(s2.LatLngFromDegrees(lat, lon)).
+			AddPoint(s2.LatLngFromDegrees(lat+1, lon+1))
+	}
+	index := newRTree(bounds)
+
+	r := s2.Rect{
+		Lat: r1.Interval{Lo: s1.Degree.Radians() * -10, Hi: s1.Degree.Radians() * 10},
+		Lng: s1.Interval{Lo: s1.Degree.Radians() * -10, Hi: s1.Degree.Radians() * 10},
+	}
+	var want []int
+	for j, b := range bounds {
+		if b.Intersects(r) {
+			want = append(want, j)
+		}
+	}
+	var got []int
+	index.searchRect(r, func(j int) bool {
+		got = append(got, j)
+		return true
+	})
+	sort.Ints(got)
+
+	if !cmp.Equal(want, got) {
+		t.Fatalf("unexpected entries -want/+got:\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestRTree_Empty(t *testing.T) {
+	index := newRTree(nil)
+	index.searchPoint(s2.LatLngFromDegrees(0, 0), func(i int) bool {
+		t.Fatalf("unexpected entry %d", i)
+		return true
+	})
+}
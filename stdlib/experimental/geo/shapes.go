@@ -0,0 +1,323 @@
+package geo
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/dependencies/filesystem"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/runtime"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+	"github.com/opentracing/opentracing-go"
+)
+
+// maxShapesSize is the maximum size of a GeoJSON document read by shapes().
+const maxShapesSize = 100 * 1024 * 1024
+
+var (
+	shapePointType = semantic.NewObjectType([]semantic.PropertyType{
+		{Key: []byte("lat"), Value: semantic.BasicFloat},
+		{Key: []byte("lon"), Value: semantic.BasicFloat},
+	})
+	shapeRingType = semantic.NewArrayType(shapePointType)
+	shapeType     = semantic.NewObjectType([]semantic.PropertyType{
+		{Key: []byte("holes"), Value: semantic.NewArrayType(shapeRingType)},
+		{Key: []byte("id"), Value: semantic.BasicString},
+		{Key: []byte("points"), Value: shapeRingType},
+	})
+)
+
+func generateShapesFunc() values.Function {
+	shapesSignature := runtime.MustLookupBuiltinType("experimental/geo", "shapes")
+	return values.NewFunction(
+		"shapes",
+		shapesSignature,
+		func(ctx context.Context, args values.Object) (values.Value, error) {
+			a := interpreter.NewArguments(args)
+
+			file, fileOk, err := a.GetString("file")
+			if err != nil {
+				return nil, err
+			}
+			u, urlOk, err := a.GetString("url")
+			if err != nil {
+				return nil, err
+			}
+			if fileOk == urlOk {
+				return nil, errors.New(codes.Invalid, "exactly one of file or url must be specified")
+			}
+
+			idProperty, _, err := a.GetString("idProperty")
+			if err != nil {
+				return nil, err
+			}
+
+			var data []byte
+			if fileOk {
+				data, err = filesystem.ReadFile(ctx, file)
+				if err != nil {
+					return nil, errors.Wrap(err, codes.Inherit, "geo.shapes() failed to read file")
+				}
+			} else {
+				data, err = readShapesURL(ctx, u)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			shapes, err := decodeGeoJSON(data, idProperty)
+			if err != nil {
+				return nil, err
+			}
+			return shapesToValue(shapes), nil
+		}, false,
+	)
+}
+
+func readShapesURL(ctx context.Context, u string) ([]byte, error) {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return nil, errors.Wrap(err, codes.Invalid, "invalid url")
+	}
+	deps := flux.GetDependencies(ctx)
+	validator, err := flux.PackageURLValidator(deps, "experimental/geo")
+	if err != nil {
+		return nil, err
+	}
+	if err := validator.Validate(parsed); err != nil {
+		return nil, errors.New(codes.Invalid, "no such host")
+	}
+	client, err := deps.HTTPClient()
+	if err != nil {
+		return nil, errors.Wrap(err, codes.Aborted, "missing client in geo.shapes")
+	}
+
+	s, ctx := opentracing.StartSpanFromContext(ctx, "geo.shapes")
+	s.SetTag("url", parsed.String())
+	defer s.Finish()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, parsed.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "no such host") {
+			return nil, errors.New(codes.Invalid, "no such host")
+		}
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode/100 != 2 {
+		return nil, errors.Newf(codes.Invalid, "geo.shapes() failed to read url: %s", resp.Status)
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(nil, resp.Body, maxShapesSize))
+	if err != nil {
+		return nil, errors.Wrap(err, codes.Invalid, "geo.shapes() failed to read url")
+	}
+	return body, nil
+}
+
+// shape is a single polygon read from a GeoJSON document.
+type shape struct {
+	id     string
+	points [][2]float64
+	holes  [][][2]float64
+}
+
+type geoJSONObject struct {
+	Type        string                     `json:"type"`
+	ID          json.RawMessage            `json:"id"`
+	Properties  map[string]json.RawMessage `json:"properties"`
+	Geometry    *geoJSONObject             `json:"geometry"`
+	Geometries  []*geoJSONObject           `json:"geometries"`
+	Features    []*geoJSONObject           `json:"features"`
+	Coordinates json.RawMessage            `json:"coordinates"`
+}
+
+// decodeGeoJSON reads the polygons from a GeoJSON FeatureCollection, Feature or geometry.
+// Multi polygons are split into one shape for each polygon that all share the same id.
+// Geometries that are not polygons are ignored.
+func decodeGeoJSON(data []byte, idProperty string) ([]shape, error) {
+	var obj geoJSONObject
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, errors.Wrap(err, codes.Invalid, "invalid GeoJSON")
+	}
+
+	var shapes []shape
+	switch obj.Type {
+	case "FeatureCollection":
+		for i, f := range obj.Features {
+			if f == nil || f.Type != "Feature" {
+				return nil, errors.Newf(codes.Invalid, "invalid GeoJSON: feature %d is not a Feature", i)
+			}
+			id, err := featureID(f, idProperty, i)
+			if err != nil {
+				return nil, err
+			}
+			if shapes, err = appendGeometry(shapes, id, f.Geometry); err != nil {
+				return nil, err
+			}
+		}
+	case "Feature":
+		id, err := featureID(&obj, idProperty, 0)
+		if err != nil {
+			return nil, err
+		}
+		if shapes, err = appendGeometry(shapes, id, obj.Geometry); err != nil {
+			return nil, err
+		}
+	default:
+		var err error
+		if shapes, err = appendGeometry(shapes, "0", &obj); err != nil {
+			return nil, err
+		}
+	}
+	return shapes, nil
+}
+
+// featureID returns the identifier of a feature. The identifier is read from
+// the idProperty property if it is set, else the feature id is used.
+// Features without an identifier are identified by their index.
+func featureID(f *geoJSONObject, idProperty string, index int) (string, error) {
+	raw := f.ID
+	if idProperty != "" {
+		raw = f.Properties[idProperty]
+	}
+	if len(raw) == 0 || string(raw) == "null" {
+		return strconv.Itoa(index), nil
+	}
+
+	var id interface{}
+	if err := json.Unmarshal(raw, &id); err != nil {
+		return "", errors.Wrap(err, codes.Invalid, "invalid GeoJSON feature id")
+	}
+	switch id := id.(type) {
+	case string:
+		return id, nil
+	case float64:
+		return strconv.FormatFloat(id, 'f', -1, 64), nil
+	default:
+		return string(raw), nil
+	}
+}
+
+func appendGeometry(shapes []shape, id string, g *geoJSONObject) ([]shape, error) {
+	if g == nil {
+		return shapes, nil
+	}
+	switch g.Type {
+	case "Polygon":
+		var coords [][][]float64
+		if err := json.Unmarshal(g.Coordinates, &coords); err != nil {
+			return nil, errors.Wrap(err, codes.Invalid, "invalid GeoJSON polygon coordinates")
+		}
+		s, err := polygonToShape(id, coords)
+		if err != nil {
+			return nil, err
+		}
+		return append(shapes, s), nil
+	case "MultiPolygon":
+		var coords [][][][]float64
+		if err := json.Unmarshal(g.Coordinates, &coords); err != nil {
+			return nil, errors.Wrap(err, codes.Invalid, "invalid GeoJSON multi polygon coordinates")
+		}
+		for _, polygon := range coords {
+			s, err := polygonToShape(id, polygon)
+			if err != nil {
+				return nil, err
+			}
+			shapes = append(shapes, s)
+		}
+		return shapes, nil
+	case "GeometryCollection":
+		for _, child := range g.Geometries {
+			var err error
+			if shapes, err = appendGeometry(shapes, id, child); err != nil {
+				return nil, err
+			}
+		}
+		return shapes, nil
+	case "Point", "MultiPoint", "LineString", "MultiLineString":
+		return shapes, nil
+	default:
+		return nil, errors.Newf(codes.Invalid, "invalid GeoJSON: unsupported type %q", g.Type)
+	}
+}
+
+// polygonToShape converts the linear rings of a GeoJSON polygon into a shape.
+// The first ring is the exterior of the polygon and the remaining rings are holes.
+func polygonToShape(id string, rings [][][]float64) (shape, error) {
+	if len(rings) == 0 {
+		return shape{}, errors.New(codes.Invalid, "invalid GeoJSON: polygon must have at least one ring")
+	}
+	s := shape{id: id}
+	for i, ring := range rings {
+		points, err := ringToPoints(ring)
+		if err != nil {
+			return shape{}, err
+		}
+		if i == 0 {
+			s.points = points
+		} else {
+			s.holes = append(s.holes, points)
+		}
+	}
+	return s, nil
+}
+
+// ringToPoints converts a linear ring into a list of lon/lat pairs.
+// GeoJSON rings are closed so the last position is dropped when it repeats the first one.
+func ringToPoints(ring [][]float64) ([][2]float64, error) {
+	points := make([][2]float64, 0, len(ring))
+	for _, pos := range ring {
+		if len(pos) < 2 {
+			return nil, errors.New(codes.Invalid, "invalid GeoJSON: position must have longitude and latitude")
+		}
+		points = append(points, [2]float64{pos[0], pos[1]})
+	}
+	if n := len(points); n > 1 && points[0] == points[n-1] {
+		points = points[:n-1]
+	}
+	if len(points) < 3 {
+		return nil, errors.New(codes.Invalid, "invalid GeoJSON: polygon ring must have at least 3 distinct positions")
+	}
+	return points, nil
+}
+
+func shapesToValue(shapes []shape) values.Array {
+	ringToValue := func(ring [][2]float64) values.Array {
+		arr := values.NewArray(shapeRingType)
+		for _, pos := range ring {
+			arr.Append(values.NewObjectWithValues(map[string]values.Value{
+				"lat": values.NewFloat(pos[1]),
+				"lon": values.NewFloat(pos[0]),
+			}))
+		}
+		return arr
+	}
+
+	arr := values.NewArray(semantic.NewArrayType(shapeType))
+	for _, s := range shapes {
+		holes := values.NewArray(semantic.NewArrayType(shapeRingType))
+		for _, h := range s.holes {
+			holes.Append(ringToValue(h))
+		}
+		arr.Append(values.NewObjectWithValues(map[string]values.Value{
+			"id":     values.NewString(s.id),
+			"points": ringToValue(s.points),
+			"holes":  holes,
+		}))
+	}
+	return arr
+}
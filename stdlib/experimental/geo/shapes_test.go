@@ -0,0 +1,243 @@
+package geo_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/dependencies/filesystem"
+	"github.com/influxdata/flux/stdlib/experimental/geo"
+	"github.com/influxdata/flux/values"
+)
+
+const featureCollection = `{
+  "type": "FeatureCollection",
+  "features": [
+    {
+      "type": "Feature",
+      "id": "square",
+      "properties": {"name": "manhattan"},
+      "geometry": {
+        "type": "Polygon",
+        "coordinates": [
+          [[-74.0, 40.0], [-73.0, 40.0], [-73.0, 41.0], [-74.0, 41.0], [-74.0, 40.0]],
+          [[-73.6, 40.4], [-73.4, 40.4], [-73.4, 40.6], [-73.6, 40.6], [-73.6, 40.4]]
+        ]
+      }
+    },
+    {
+      "type": "Feature",
+      "id": 7,
+      "properties": {"name": "islands"},
+      "geometry": {
+        "type": "MultiPolygon",
+        "coordinates": [
+          [[[-74.0, 42.0], [-73.0, 42.0], [-73.5, 43.0], [-74.0, 42.0]]],
+          [[[-72.0, 42.0], [-71.0, 42.0], [-71.5, 43.0]]]
+        ]
+      }
+    },
+    {
+      "type": "Feature",
+      "properties": {"name": "marker"},
+      "geometry": {"type": "Point", "coordinates": [-73.5, 40.5]}
+    }
+  ]
+}`
+
+type shapeResult struct {
+	ID     string
+	Points []point
+	Holes  [][]point
+}
+
+func shapesFromValue(t *testing.T, v values.Value) []shapeResult {
+	t.Helper()
+	ring := func(arr values.Array) []point {
+		var points []point
+		arr.Range(func(i int, v values.Value) {
+			lat, _ := v.Object().Get("lat")
+			lon, _ := v.Object().Get("lon")
+			points = append(points, point{lat: lat.Float(), lon: lon.Float()})
+		})
+		return points
+	}
+	var shapes []shapeResult
+	v.Array().Range(func(i int, v values.Value) {
+		id, _ := v.Object().Get("id")
+		points, _ := v.Object().Get("points")
+		holes, _ := v.Object().Get("holes")
+		s := shapeResult{
+			ID:     id.Str(),
+			Points: ring(points.Array()),
+		}
+		holes.Array().Range(func(i int, v values.Value) {
+			s.Holes = append(s.Holes, ring(v.Array()))
+		})
+		shapes = append(shapes, s)
+	})
+	return shapes
+}
+
+func TestShapes(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "regions.geojson")
+	if err := ioutil.WriteFile(file, []byte(featureCollection), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/regions.geojson" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(featureCollection))
+	}))
+	defer ts.Close()
+
+	ctx := flux.NewDefaultDependencies().Inject(context.Background())
+	ctx = filesystem.Inject(ctx, filesystem.SystemFS)
+
+	want := []shapeResult{
+		{
+			ID: "square",
+			Points: []point{
+				{lat: 40.0, lon: -74.0},
+				{lat: 40.0, lon: -73.0},
+				{lat: 41.0, lon: -73.0},
+				{lat: 41.0, lon: -74.0},
+			},
+			Holes: [][]point{{
+				{lat: 40.4, lon: -73.6},
+				{lat: 40.4, lon: -73.4},
+				{lat: 40.6, lon: -73.4},
+				{lat: 40.6, lon: -73.6},
+			}},
+		},
+		{
+			ID: "7",
+			Points: []point{
+				{lat: 42.0, lon: -74.0},
+				{lat: 42.0, lon: -73.0},
+				{lat: 43.0, lon: -73.5},
+			},
+		},
+		{
+			ID: "7",
+			Points: []point{
+				{lat: 42.0, lon: -72.0},
+				{lat: 42.0, lon: -71.0},
+				{lat: 43.0, lon: -71.5},
+			},
+		},
+	}
+	withNames := make([]shapeResult, len(want))
+	copy(withNames, want)
+	withNames[0].ID = "manhattan"
+	withNames[1].ID = "islands"
+	withNames[2].ID = "islands"
+
+	shapes := geo.Functions["shapes"]
+	for _, tc := range []struct {
+		name    string
+		args    map[string]values.Value
+		want    []shapeResult
+		wantErr bool
+	}{
+		{
+			name: "file",
+			args: map[string]values.Value{"file": values.NewString(file)},
+			want: want,
+		},
+		{
+			name: "url",
+			args: map[string]values.Value{"url": values.NewString(ts.URL + "/regions.geojson")},
+			want: want,
+		},
+		{
+			name: "id property",
+			args: map[string]values.Value{
+				"file":       values.NewString(file),
+				"idProperty": values.NewString("name"),
+			},
+			want: withNames,
+		},
+		{
+			name:    "no source",
+			args:    map[string]values.Value{},
+			wantErr: true,
+		},
+		{
+			name: "file and url",
+			args: map[string]values.Value{
+				"file": values.NewString(file),
+				"url":  values.NewString(ts.URL + "/regions.geojson"),
+			},
+			wantErr: true,
+		},
+		{
+			name:    "missing file",
+			args:    map[string]values.Value{"file": values.NewString(filepath.Join(dir, "missing.geojson"))},
+			wantErr: true,
+		},
+		{
+			name:    "url not found",
+			args:    map[string]values.Value{"url": values.NewString(ts.URL + "/missing.geojson")},
+			wantErr: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := shapes.Call(ctx, values.NewObjectWithValues(tc.args))
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := shapesFromValue(t, result)
+			if !cmp.Equal(tc.want, got, cmp.AllowUnexported(point{})) {
+				t.Errorf("unexpected shapes -want/+got:\n%s", cmp.Diff(tc.want, got, cmp.AllowUnexported(point{})))
+			}
+		})
+	}
+}
+
+func TestShapes_InvalidGeoJSON(t *testing.T) {
+	dir := t.TempDir()
+	ctx := filesystem.Inject(context.Background(), filesystem.SystemFS)
+
+	shapes := geo.Functions["shapes"]
+	for _, tc := range []struct {
+		name string
+		data string
+	}{
+		{name: "not json", data: `not json`},
+		{name: "unknown type", data: `{"type": "Circle", "coordinates": [0, 0]}`},
+		{name: "too few positions", data: `{"type": "Polygon", "coordinates": [[[0, 0], [1, 1], [0, 0]]]}`},
+		{name: "invalid position", data: `{"type": "Polygon", "coordinates": [[[0], [1, 1], [1, 0]]]}`},
+		{name: "invalid feature", data: `{"type": "FeatureCollection", "features": [{"type": "Polygon"}]}`},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			file := filepath.Join(dir, tc.name+".geojson")
+			if err := ioutil.WriteFile(file, []byte(tc.data), 0644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := shapes.Call(ctx, values.NewObjectWithValues(map[string]values.Value{
+				"file": values.NewString(file),
+			}))
+			if err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
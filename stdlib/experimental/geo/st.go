@@ -39,7 +39,7 @@ func generateSTContainsFunc() values.Function {
 				return nil, err
 			}
 
-			geom1, err := parseGeometryArgument("region", geom1Arg, units)
+			region, err := parseRegionArgument(ctx, "region", geom1Arg, units)
 			if err != nil {
 				return nil, err
 			}
@@ -49,18 +49,6 @@ func generateSTContainsFunc() values.Function {
 				return nil, err
 			}
 
-			var region s2.Region
-			switch v := geom1.(type) {
-			case box:
-				region = getS2RectRegion(v)
-			case circle:
-				region = getS2CapRegion(v)
-			case polygon:
-				region = getS2LoopRegion(v)
-			default:
-				return nil, errors.Newf(codes.Invalid, "unsupported region type: %T", geom1)
-			}
-
 			var retVal bool
 			switch v := geom2.(type) {
 			case point:
@@ -133,8 +121,10 @@ func generateSTDistanceFunc() values.Function {
 						distance = 0.0
 					}
 				case polygon: // polygon-point distance
-					index := shapeToIndex(getS2LoopRegion(v))
+					index := shapeToIndex(getS2PolygonRegion(v))
 					distance = minDistanceToPoint(index, to)
+				case *shapeSet: // polygons-point distance
+					distance = minDistanceToPoint(v.shapeIndex(), to)
 				}
 			case polyline: // linestring represents path (track) in GIS
 				toIndex := shapeToIndex(s2.PolylineFromLatLngs(v.latlngs))
@@ -142,7 +132,7 @@ func generateSTDistanceFunc() values.Function {
 				case point: // point-polyline distance
 					distance = minDistanceToPoint(toIndex, getS2Point(v))
 				case box: // box-polyline distance
-					index := shapeToIndex(getS2LoopRegion(boxToPolygon(v))) // represent box as polygon
+					index := shapeToIndex(getS2PolygonRegion(boxToPolygon(v))) // represent box as polygon
 					distance = minDistanceToShapeIndex(index, toIndex)
 				case circle: // circle-polyline distance
 					distance = minDistanceToPoint(toIndex, getS2Point(v.point)) - s1.Angle(v.radius)
@@ -150,8 +140,10 @@ func generateSTDistanceFunc() values.Function {
 						distance = 0.0
 					}
 				case polygon: // polygon-polyline distance
-					index := shapeToIndex(getS2LoopRegion(v))
+					index := shapeToIndex(getS2PolygonRegion(v))
 					distance = minDistanceToShapeIndex(index, toIndex)
+				case *shapeSet: // polygons-polyline distance
+					distance = minDistanceToShapeIndex(v.shapeIndex(), toIndex)
 				}
 			default:
 				return nil, errors.Newf(codes.Invalid, "unsupported geometry type: %T", geom2)
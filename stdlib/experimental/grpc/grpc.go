@@ -0,0 +1,204 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/dependencies/filesystem"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/runtime"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const pkgpath = "experimental/grpc"
+
+// defaultTimeout is the timeout of a call that does not set one.
+const defaultTimeout = 30 * time.Second
+
+func init() {
+	runtime.RegisterPackageValue(pkgpath, "call", values.NewFunction(
+		"call",
+		runtime.MustLookupBuiltinType(pkgpath, "call"),
+		func(ctx context.Context, args values.Object) (values.Value, error) {
+			return interpreter.DoFunctionCallContext(call, ctx, args)
+		},
+		false,
+	))
+}
+
+func call(ctx context.Context, args interpreter.Arguments) (values.Value, error) {
+	rawURL, err := args.GetRequiredString("url")
+	if err != nil {
+		return nil, err
+	}
+	name, err := args.GetRequiredString("method")
+	if err != nil {
+		return nil, err
+	}
+	descriptors, err := args.GetRequiredString("descriptors")
+	if err != nil {
+		return nil, err
+	}
+	request, err := args.GetRequiredObject("request")
+	if err != nil {
+		return nil, err
+	}
+
+	md := metadata.MD{}
+	if d, ok, err := args.GetDictionary("metadata"); err != nil {
+		return nil, err
+	} else if ok {
+		d.Range(func(k, v values.Value) {
+			md.Append(k.Str(), v.Str())
+		})
+	}
+
+	timeout := defaultTimeout
+	if v, ok := args.Get("timeout"); ok {
+		if v.Type().Nature() != semantic.Duration {
+			return nil, errors.Newf(codes.Invalid, "timeout must be a duration but was %v", v.Type())
+		}
+		d := v.Duration()
+		if d.IsMixed() || d.Months() != 0 {
+			return nil, errors.New(codes.Invalid, "timeout must not use months")
+		}
+		timeout = d.Duration()
+	}
+
+	u, err := parseURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	method, err := loadMethod(ctx, descriptors, name)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return invoke(ctx, u, method, request, md)
+}
+
+// parseURL parses the URL of a gRPC server. A server may also be
+// given as a plain host and port, in which case a plaintext connection is used.
+func parseURL(rawURL string) (*url.URL, error) {
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "http://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.Wrap(err, codes.Invalid, "invalid url")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, errors.Newf(codes.Invalid, "url scheme must be http or https but was %s", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, errors.Newf(codes.Invalid, "url %q has no host", rawURL)
+	}
+	return u, nil
+}
+
+// loadMethod finds the method with the full name, such as "package.Service/Method",
+// in the descriptor set file at the path.
+func loadMethod(ctx context.Context, path, name string) (protoreflect.MethodDescriptor, error) {
+	data, err := filesystem.ReadFile(ctx, path)
+	if err != nil {
+		return nil, errors.Wrapf(err, codes.Inherit, "cannot read descriptors %q", path)
+	}
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(data, &set); err != nil {
+		return nil, errors.Wrapf(err, codes.Invalid, "invalid descriptor set %q", path)
+	}
+	files, err := protodesc.NewFiles(&set)
+	if err != nil {
+		return nil, errors.Wrapf(err, codes.Invalid, "invalid descriptor set %q", path)
+	}
+
+	name = strings.TrimPrefix(name, "/")
+	i := strings.LastIndex(name, "/")
+	if i < 0 {
+		return nil, errors.Newf(codes.Invalid, "method %q must have the form \"package.Service/Method\"", name)
+	}
+	service, methodName := name[:i], name[i+1:]
+	d, err := files.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, errors.Newf(codes.NotFound, "service %q not found in descriptors %q", service, path)
+	}
+	sd, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, errors.Newf(codes.Invalid, "%q is not a service", service)
+	}
+	method := sd.Methods().ByName(protoreflect.Name(methodName))
+	if method == nil {
+		return nil, errors.Newf(codes.NotFound, "service %q has no method %q", service, methodName)
+	}
+	if method.IsStreamingClient() || method.IsStreamingServer() {
+		return nil, errors.Newf(codes.Unimplemented, "streaming method %q is not supported", name)
+	}
+	return method, nil
+}
+
+// invoke calls the method on the server with the request
+// and returns the response as a record.
+func invoke(ctx context.Context, u *url.URL, method protoreflect.MethodDescriptor, request values.Object, md metadata.MD) (values.Value, error) {
+	req := dynamicpb.NewMessage(method.Input())
+	if err := toMessage(req, request); err != nil {
+		return nil, err
+	}
+
+	validator, err := flux.PackageURLValidator(flux.GetDependencies(ctx), pkgpath)
+	if err != nil {
+		return nil, err
+	}
+	if err := validator.Validate(u); err != nil {
+		return nil, errors.New(codes.Invalid, "no such host")
+	}
+	dialer, err := flux.GetDialer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	creds := insecure.NewCredentials()
+	if u.Scheme == "https" {
+		creds = credentials.NewTLS(&tls.Config{})
+	}
+	conn, err := grpc.DialContext(ctx, u.Host,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "tcp", addr)
+		}),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, codes.Unavailable, "failed to connect to grpc server")
+	}
+	defer func() { _ = conn.Close() }()
+
+	if len(md) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, md)
+	}
+	fullMethod := "/" + string(method.Parent().FullName()) + "/" + string(method.Name())
+	resp := dynamicpb.NewMessage(method.Output())
+	if err := conn.Invoke(ctx, fullMethod, req, resp); err != nil {
+		s := status.Convert(err)
+		return nil, errors.Newf(codes.Code(s.Code()), "grpc call to %s failed: %s", fullMethod, s.Message())
+	}
+	return fromMessage(resp), nil
+}
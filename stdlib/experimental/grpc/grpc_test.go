@@ -0,0 +1,317 @@
+package grpc
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/dependencies/filesystem"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// testFile describes a users.v1.UserService with a GetUser method.
+func testFile() *descriptorpb.FileDescriptorProto {
+	field := func(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, typeName string, repeated bool) *descriptorpb.FieldDescriptorProto {
+		label := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+		if repeated {
+			label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+		}
+		f := &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(jsonName(name)),
+			Number:   proto.Int32(number),
+			Type:     typ.Enum(),
+			Label:    label.Enum(),
+		}
+		if typeName != "" {
+			f.TypeName = proto.String(typeName)
+		}
+		return f
+	}
+	return &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("users.proto"),
+		Package:    proto.String("users.v1"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/timestamp.proto"},
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name: proto.String("Role"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: proto.String("ROLE_UNSPECIFIED"), Number: proto.Int32(0)},
+				{Name: proto.String("ROLE_ADMIN"), Number: proto.Int32(1)},
+			},
+		}},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("GetUserRequest"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("user_id", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, "", false),
+					field("since", 2, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.Timestamp", false),
+				},
+			},
+			{
+				Name: proto.String("User"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, "", false),
+					field("age", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32, "", false),
+					field("role", 3, descriptorpb.FieldDescriptorProto_TYPE_ENUM, ".users.v1.Role", false),
+					field("groups", 4, descriptorpb.FieldDescriptorProto_TYPE_STRING, "", true),
+					field("created", 5, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.Timestamp", false),
+					field("manager", 6, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".users.v1.User", false),
+				},
+			},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("UserService"),
+			Method: []*descriptorpb.MethodDescriptorProto{
+				{
+					Name:       proto.String("GetUser"),
+					InputType:  proto.String(".users.v1.GetUserRequest"),
+					OutputType: proto.String(".users.v1.User"),
+				},
+				{
+					Name:            proto.String("WatchUser"),
+					InputType:       proto.String(".users.v1.GetUserRequest"),
+					OutputType:      proto.String(".users.v1.User"),
+					ServerStreaming: proto.Bool(true),
+				},
+			},
+		}},
+	}
+}
+
+func jsonName(name string) string {
+	parts := strings.Split(name, "_")
+	for i := 1; i < len(parts); i++ {
+		parts[i] = strings.Title(parts[i])
+	}
+	return strings.Join(parts, "")
+}
+
+// writeDescriptors writes the descriptor set of the test file and returns its path.
+func writeDescriptors(t *testing.T) (string, protoreflect.FileDescriptor) {
+	t.Helper()
+	set := &descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{
+			protodesc.ToFileDescriptorProto(timestamppb.File_google_protobuf_timestamp_proto),
+			testFile(),
+		},
+	}
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fd, err := files.FindFileByPath("users.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := proto.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "users.pb")
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path, fd
+}
+
+// startServer starts a server that answers GetUser calls
+// with a user named after the requested user id.
+func startServer(t *testing.T, fd protoreflect.FileDescriptor) string {
+	t.Helper()
+	msgs := fd.Messages()
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		method, _ := grpc.MethodFromServerStream(stream)
+		if method != "/users.v1.UserService/GetUser" {
+			return status.Errorf(grpccodes.Unimplemented, "unknown method %s", method)
+		}
+		md, _ := metadata.FromIncomingContext(stream.Context())
+		if auth := md.Get("authorization"); len(auth) != 1 || auth[0] != "Bearer token" {
+			return status.Error(grpccodes.Unauthenticated, "invalid token")
+		}
+
+		req := dynamicpb.NewMessage(msgs.ByName("GetUserRequest"))
+		if err := stream.RecvMsg(req); err != nil {
+			return err
+		}
+		userID := req.Get(req.Descriptor().Fields().ByName("user_id")).String()
+		if userID == "unknown" {
+			return status.Errorf(grpccodes.NotFound, "user %s not found", userID)
+		}
+		since := req.Get(req.Descriptor().Fields().ByName("since")).Message()
+		created := dynamicpb.NewMessage(since.Descriptor())
+		created.Set(since.Descriptor().Fields().ByName("seconds"), since.Get(since.Descriptor().Fields().ByName("seconds")))
+
+		user := dynamicpb.NewMessage(msgs.ByName("User"))
+		fields := user.Descriptor().Fields()
+		user.Set(fields.ByName("name"), protoreflect.ValueOfString("user "+userID))
+		user.Set(fields.ByName("age"), protoreflect.ValueOfInt32(42))
+		user.Set(fields.ByName("role"), protoreflect.ValueOfEnum(1))
+		groups := user.Mutable(fields.ByName("groups")).List()
+		groups.Append(protoreflect.ValueOfString("a"))
+		groups.Append(protoreflect.ValueOfString("b"))
+		user.Set(fields.ByName("created"), protoreflect.ValueOfMessage(created))
+		manager := dynamicpb.NewMessage(user.Descriptor())
+		manager.Set(fields.ByName("name"), protoreflect.ValueOfString("manager"))
+		user.Set(fields.ByName("manager"), protoreflect.ValueOfMessage(manager))
+		return stream.SendMsg(user)
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer(grpc.UnknownServiceHandler(handler))
+	go func() { _ = srv.Serve(l) }()
+	t.Cleanup(srv.Stop)
+	return l.Addr().String()
+}
+
+func testContext() context.Context {
+	deps := flux.NewDefaultDependencies()
+	deps.Deps.FilesystemService = filesystem.SystemFS
+	return deps.Inject(context.Background())
+}
+
+func callArgs(addr, path, method string, request map[string]values.Value) interpreter.Arguments {
+	md := values.NewDictBuilder(semantic.NewDictType(semantic.BasicString, semantic.BasicString))
+	_ = md.Insert(values.NewString("Authorization"), values.NewString("Bearer token"))
+	return interpreter.NewArguments(values.NewObjectWithValues(map[string]values.Value{
+		"url":         values.NewString(addr),
+		"method":      values.NewString(method),
+		"descriptors": values.NewString(path),
+		"request":     values.NewObjectWithValues(request),
+		"metadata":    md.Dict(),
+		"timeout":     values.NewDuration(values.ConvertDurationNsecs(10 * time.Second)),
+	}))
+}
+
+func TestCall(t *testing.T) {
+	path, fd := writeDescriptors(t)
+	addr := startServer(t, fd)
+
+	since := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	v, err := call(testContext(), callArgs(addr, path, "users.v1.UserService/GetUser", map[string]values.Value{
+		"userId": values.NewString("42"),
+		"since":  values.NewTime(values.ConvertTime(since)),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := v.Object()
+	get := func(name string) values.Value {
+		t.Helper()
+		v, ok := user.Get(name)
+		if !ok {
+			t.Fatalf("response is missing %q: %v", name, user)
+		}
+		return v
+	}
+	if got := get("name").Str(); got != "user 42" {
+		t.Errorf("unexpected name %q", got)
+	}
+	if got := get("age").Int(); got != 42 {
+		t.Errorf("unexpected age %d", got)
+	}
+	if got := get("role").Str(); got != "ROLE_ADMIN" {
+		t.Errorf("unexpected role %q", got)
+	}
+	if got := get("groups").Array(); got.Len() != 2 || got.Get(1).Str() != "b" {
+		t.Errorf("unexpected groups %v", got)
+	}
+	if got := get("created").Time().Time(); !got.Equal(since) {
+		t.Errorf("unexpected created time %s", got)
+	}
+	manager := get("manager").Object()
+	if name, _ := manager.Get("name"); name.Str() != "manager" {
+		t.Errorf("unexpected manager %v", manager)
+	}
+	if _, ok := manager.Get("manager"); ok {
+		t.Error("unset message fields should be left out")
+	}
+	if groups, _ := manager.Get("groups"); groups.Type().String() != "[string]" {
+		t.Errorf("unexpected type of empty groups %v", groups.Type())
+	}
+}
+
+func TestCall_Errors(t *testing.T) {
+	path, fd := writeDescriptors(t)
+	addr := startServer(t, fd)
+
+	testCases := []struct {
+		name    string
+		method  string
+		request map[string]values.Value
+		code    codes.Code
+		want    string
+	}{
+		{
+			name:    "status",
+			method:  "users.v1.UserService/GetUser",
+			request: map[string]values.Value{"user_id": values.NewString("unknown")},
+			code:    codes.NotFound,
+			want:    "grpc call to /users.v1.UserService/GetUser failed: user unknown not found",
+		},
+		{
+			name:    "unknown field",
+			method:  "users.v1.UserService/GetUser",
+			request: map[string]values.Value{"id": values.NewString("42")},
+			code:    codes.Invalid,
+			want:    `message users.v1.GetUserRequest has no field "id"`,
+		},
+		{
+			name:    "field type",
+			method:  "users.v1.UserService/GetUser",
+			request: map[string]values.Value{"user_id": values.NewInt(42)},
+			code:    codes.Invalid,
+			want:    "cannot use int value for string field users.v1.GetUserRequest.user_id",
+		},
+		{
+			name:   "unknown method",
+			method: "users.v1.UserService/DeleteUser",
+			code:   codes.NotFound,
+			want:   `service "users.v1.UserService" has no method "DeleteUser"`,
+		},
+		{
+			name:   "streaming method",
+			method: "/users.v1.UserService/WatchUser",
+			code:   codes.Unimplemented,
+			want:   `streaming method "users.v1.UserService/WatchUser" is not supported`,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := call(testContext(), callArgs(addr, path, tc.method, tc.request))
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if err.Error() != tc.want {
+				t.Errorf("unexpected error: want %q, got %q", tc.want, err)
+			}
+			if errors.Code(err) != tc.code {
+				t.Errorf("unexpected error code: want %v, got %v", tc.code, errors.Code(err))
+			}
+		})
+	}
+}
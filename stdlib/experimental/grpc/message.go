@@ -0,0 +1,336 @@
+package grpc
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const (
+	timestampName protoreflect.FullName = "google.protobuf.Timestamp"
+	durationName  protoreflect.FullName = "google.protobuf.Duration"
+)
+
+// toMessage sets the fields of the message from the properties of the record.
+// Properties match fields by their protocol buffer or JSON name.
+func toMessage(m protoreflect.Message, obj values.Object) error {
+	fields := m.Descriptor().Fields()
+	var err error
+	obj.Range(func(name string, v values.Value) {
+		if err != nil {
+			return
+		}
+		fd := fields.ByName(protoreflect.Name(name))
+		if fd == nil {
+			fd = fields.ByJSONName(name)
+		}
+		if fd == nil {
+			err = errors.Newf(codes.Invalid, "message %s has no field %q", m.Descriptor().FullName(), name)
+			return
+		}
+		if v.IsNull() {
+			return
+		}
+		err = setField(m, fd, v)
+	})
+	return err
+}
+
+func setField(m protoreflect.Message, fd protoreflect.FieldDescriptor, v values.Value) error {
+	switch {
+	case fd.IsList():
+		if v.Type().Nature() != semantic.Array {
+			return typeError(fd, v)
+		}
+		list := m.Mutable(fd).List()
+		var err error
+		v.Array().Range(func(i int, elem values.Value) {
+			if err != nil {
+				return
+			}
+			var pv protoreflect.Value
+			if pv, err = toProtoValue(fd, elem, list.NewElement); err == nil {
+				list.Append(pv)
+			}
+		})
+		return err
+	case fd.IsMap():
+		mp := m.Mutable(fd).Map()
+		set := func(k, v values.Value) error {
+			key, err := toProtoValue(fd.MapKey(), k, nil)
+			if err != nil {
+				return err
+			}
+			val, err := toProtoValue(fd.MapValue(), v, mp.NewValue)
+			if err != nil {
+				return err
+			}
+			mp.Set(key.MapKey(), val)
+			return nil
+		}
+		var err error
+		switch v.Type().Nature() {
+		case semantic.Dictionary:
+			v.Dict().Range(func(k, v values.Value) {
+				if err == nil {
+					err = set(k, v)
+				}
+			})
+		case semantic.Object:
+			v.Object().Range(func(k string, v values.Value) {
+				if err == nil {
+					err = set(values.NewString(k), v)
+				}
+			})
+		default:
+			return typeError(fd, v)
+		}
+		return err
+	default:
+		pv, err := toProtoValue(fd, v, func() protoreflect.Value { return m.NewField(fd) })
+		if err != nil {
+			return err
+		}
+		m.Set(fd, pv)
+		return nil
+	}
+}
+
+// toProtoValue converts a Flux value to a singular value of the field.
+// The newValue function creates the message of message fields.
+func toProtoValue(fd protoreflect.FieldDescriptor, v values.Value, newValue func() protoreflect.Value) (protoreflect.Value, error) {
+	n := v.Type().Nature()
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		if n == semantic.Bool {
+			return protoreflect.ValueOfBool(v.Bool()), nil
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		if n == semantic.Int {
+			i := v.Int()
+			if i < math.MinInt32 || i > math.MaxInt32 {
+				return protoreflect.Value{}, errors.Newf(codes.Invalid, "value %d overflows field %s", i, fd.FullName())
+			}
+			return protoreflect.ValueOfInt32(int32(i)), nil
+		}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		if n == semantic.Int {
+			return protoreflect.ValueOfInt64(v.Int()), nil
+		}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		if u, ok := toUInt(v); ok {
+			if u > math.MaxUint32 {
+				return protoreflect.Value{}, errors.Newf(codes.Invalid, "value %d overflows field %s", u, fd.FullName())
+			}
+			return protoreflect.ValueOfUint32(uint32(u)), nil
+		}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if u, ok := toUInt(v); ok {
+			return protoreflect.ValueOfUint64(u), nil
+		}
+	case protoreflect.FloatKind:
+		if f, ok := toFloat(v); ok {
+			return protoreflect.ValueOfFloat32(float32(f)), nil
+		}
+	case protoreflect.DoubleKind:
+		if f, ok := toFloat(v); ok {
+			return protoreflect.ValueOfFloat64(f), nil
+		}
+	case protoreflect.StringKind:
+		if n == semantic.String {
+			return protoreflect.ValueOfString(v.Str()), nil
+		}
+	case protoreflect.BytesKind:
+		if n == semantic.Bytes {
+			return protoreflect.ValueOfBytes(v.Bytes()), nil
+		}
+	case protoreflect.EnumKind:
+		switch n {
+		case semantic.String:
+			ev := fd.Enum().Values().ByName(protoreflect.Name(v.Str()))
+			if ev == nil {
+				return protoreflect.Value{}, errors.Newf(codes.Invalid, "enum %s has no value %q", fd.Enum().FullName(), v.Str())
+			}
+			return protoreflect.ValueOfEnum(ev.Number()), nil
+		case semantic.Int:
+			return protoreflect.ValueOfEnum(protoreflect.EnumNumber(v.Int())), nil
+		}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		switch {
+		case fd.Message().FullName() == timestampName && n == semantic.Time:
+			t := v.Time().Time()
+			return wellKnownValue(newValue(), t.Unix(), int64(t.Nanosecond())), nil
+		case fd.Message().FullName() == durationName && n == semantic.Duration:
+			d := v.Duration()
+			if d.Months() != 0 {
+				return protoreflect.Value{}, errors.Newf(codes.Invalid, "duration of field %s must not use months", fd.FullName())
+			}
+			nsecs := d.Duration()
+			return wellKnownValue(newValue(), int64(nsecs/time.Second), int64(nsecs%time.Second)), nil
+		case n == semantic.Object:
+			pv := newValue()
+			if err := toMessage(pv.Message(), v.Object()); err != nil {
+				return protoreflect.Value{}, err
+			}
+			return pv, nil
+		}
+	}
+	return protoreflect.Value{}, typeError(fd, v)
+}
+
+// wellKnownValue sets the seconds and nanos fields
+// of a google.protobuf.Timestamp or google.protobuf.Duration.
+func wellKnownValue(pv protoreflect.Value, seconds, nanos int64) protoreflect.Value {
+	m := pv.Message()
+	fields := m.Descriptor().Fields()
+	m.Set(fields.ByName("seconds"), protoreflect.ValueOfInt64(seconds))
+	m.Set(fields.ByName("nanos"), protoreflect.ValueOfInt32(int32(nanos)))
+	return pv
+}
+
+func toUInt(v values.Value) (uint64, bool) {
+	switch v.Type().Nature() {
+	case semantic.UInt:
+		return v.UInt(), true
+	case semantic.Int:
+		if i := v.Int(); i >= 0 {
+			return uint64(i), true
+		}
+	}
+	return 0, false
+}
+
+func toFloat(v values.Value) (float64, bool) {
+	switch v.Type().Nature() {
+	case semantic.Float:
+		return v.Float(), true
+	case semantic.Int:
+		return float64(v.Int()), true
+	}
+	return 0, false
+}
+
+func typeError(fd protoreflect.FieldDescriptor, v values.Value) error {
+	kind := fd.Kind().String()
+	switch {
+	case fd.IsMap():
+		kind = "map"
+	case fd.IsList():
+		kind = "repeated " + kind
+	}
+	return errors.Newf(codes.Invalid, "cannot use %v value for %s field %s", v.Type(), kind, fd.FullName())
+}
+
+// fromMessage converts the message to a record with a property for every
+// scalar, repeated and map field. Message fields and oneof fields
+// that are not set are left out.
+func fromMessage(m protoreflect.Message) values.Object {
+	fields := m.Descriptor().Fields()
+	vals := make(map[string]values.Value, fields.Len())
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if !fd.IsList() && !fd.IsMap() && (fd.Message() != nil || fd.ContainingOneof() != nil) && !m.Has(fd) {
+			continue
+		}
+		vals[string(fd.Name())] = fromField(fd, m.Get(fd))
+	}
+	return values.NewObjectWithValues(vals)
+}
+
+func fromField(fd protoreflect.FieldDescriptor, pv protoreflect.Value) values.Value {
+	switch {
+	case fd.IsList():
+		list := pv.List()
+		elems := make([]values.Value, list.Len())
+		for i := range elems {
+			elems[i] = fromValue(fd, list.Get(i))
+		}
+		elemType := fieldType(fd)
+		if len(elems) > 0 {
+			elemType = elems[0].Type()
+		}
+		return values.NewArrayWithBacking(semantic.NewArrayType(elemType), elems)
+	case fd.IsMap():
+		mp := pv.Map()
+		builder := values.NewDictBuilder(semantic.NewDictType(fieldType(fd.MapKey()), fieldType(fd.MapValue())))
+		mp.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			_ = builder.Insert(fromValue(fd.MapKey(), k.Value()), fromValue(fd.MapValue(), v))
+			return true
+		})
+		return builder.Dict()
+	default:
+		return fromValue(fd, pv)
+	}
+}
+
+// fromValue converts a singular value of the field to a Flux value.
+func fromValue(fd protoreflect.FieldDescriptor, pv protoreflect.Value) values.Value {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return values.NewBool(pv.Bool())
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return values.NewInt(pv.Int())
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return values.NewUInt(pv.Uint())
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return values.NewFloat(pv.Float())
+	case protoreflect.StringKind:
+		return values.NewString(pv.String())
+	case protoreflect.BytesKind:
+		return values.NewBytes(append([]byte(nil), pv.Bytes()...))
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(pv.Enum()); ev != nil {
+			return values.NewString(string(ev.Name()))
+		}
+		return values.NewString(strconv.Itoa(int(pv.Enum())))
+	default:
+		m := pv.Message()
+		fields := m.Descriptor().Fields()
+		switch m.Descriptor().FullName() {
+		case timestampName:
+			seconds, nanos := m.Get(fields.ByName("seconds")).Int(), m.Get(fields.ByName("nanos")).Int()
+			return values.NewTime(values.ConvertTime(time.Unix(seconds, nanos).UTC()))
+		case durationName:
+			seconds, nanos := m.Get(fields.ByName("seconds")).Int(), m.Get(fields.ByName("nanos")).Int()
+			return values.NewDuration(values.ConvertDurationNsecs(time.Duration(seconds)*time.Second + time.Duration(nanos)))
+		}
+		return fromMessage(m)
+	}
+}
+
+// fieldType returns the Flux type of a singular value of the field.
+func fieldType(fd protoreflect.FieldDescriptor) semantic.MonoType {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return semantic.BasicBool
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return semantic.BasicInt
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return semantic.BasicUint
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return semantic.BasicFloat
+	case protoreflect.StringKind, protoreflect.EnumKind:
+		return semantic.BasicString
+	case protoreflect.BytesKind:
+		return semantic.BasicBytes
+	default:
+		switch fd.Message().FullName() {
+		case timestampName:
+			return semantic.BasicTime
+		case durationName:
+			return semantic.BasicDuration
+		}
+		// An empty message has the type of a message
+		// that only has scalar fields set.
+		return fromMessage(dynamicpb.NewMessage(fd.Message())).Type()
+	}
+}
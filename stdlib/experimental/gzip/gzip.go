@@ -0,0 +1,70 @@
+package gzip
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/internal/function"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+// data reads the v argument that may be a string or bytes.
+func data(args *function.Arguments) ([]byte, error) {
+	v, err := args.GetRequired("v")
+	if err != nil {
+		return nil, err
+	}
+	switch v.Type().Nature() {
+	case semantic.String:
+		return []byte(v.Str()), nil
+	case semantic.Bytes:
+		return v.Bytes(), nil
+	default:
+		return nil, errors.Newf(codes.Invalid, "expected string or bytes for %q, got %s", "v", v.Type())
+	}
+}
+
+func encode(args *function.Arguments) (values.Value, error) {
+	v, err := data(args)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(v); err != nil {
+		return nil, errors.Wrap(err, codes.Internal, "failed to compress data")
+	}
+	if err := w.Close(); err != nil {
+		return nil, errors.Wrap(err, codes.Internal, "failed to compress data")
+	}
+	return values.NewBytes(buf.Bytes()), nil
+}
+
+func decode(args *function.Arguments) (values.Value, error) {
+	v, err := data(args)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(v))
+	if err != nil {
+		return nil, errors.Wrap(err, codes.Invalid, "invalid gzip data")
+	}
+	defer func() { _ = r.Close() }()
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrap(err, codes.Invalid, "invalid gzip data")
+	}
+	return values.NewBytes(b), nil
+}
+
+func init() {
+	b := function.ForPackage("experimental/gzip")
+	b.Register("encode", encode)
+	b.Register("decode", decode)
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/codes"
+	fhttp "github.com/influxdata/flux/dependencies/http"
 	"github.com/influxdata/flux/internal/errors"
 	"github.com/influxdata/flux/runtime"
 	"github.com/influxdata/flux/semantic"
@@ -34,7 +35,7 @@ var get = values.NewFunction(
 			return nil, err
 		}
 		deps := flux.GetDependencies(ctx)
-		validator, err := deps.URLValidator()
+		validator, err := flux.PackageURLValidator(deps, "experimental/http")
 		if err != nil {
 			return nil, err
 		}
@@ -90,6 +91,7 @@ var get = values.NewFunction(
 			defer cncl()
 
 			req = req.WithContext(ccctx)
+			fhttp.InjectSpanContext(req)
 			response, err := dc.Do(req)
 			if err != nil {
 				// Alias the DNS lookup error so as not to disclose the
@@ -0,0 +1,235 @@
+package lp
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/dependencies/filesystem"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/lineprotocol"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/runtime"
+	"github.com/opentracing/opentracing-go"
+)
+
+const FromLPKind = "fromLP"
+
+// maxURLSize is the maximum size of a line protocol document read from a url.
+const maxURLSize = 100 * 1024 * 1024
+
+func init() {
+	fromLPSignature := runtime.MustLookupBuiltinType(pkgpath, "from")
+	runtime.RegisterPackageValue(pkgpath, "from", flux.MustValue(flux.FunctionValue(FromLPKind, createFromLPOpSpec, fromLPSignature)))
+	plan.RegisterProcedureSpec(FromLPKind, newFromLPProcedure, FromLPKind)
+	execute.RegisterSource(FromLPKind, createFromLPSource)
+}
+
+// FromLPOpSpec is the flux.OperationSpec for the `lp.from` flux function.
+type FromLPOpSpec struct {
+	File      string        `json:"file,omitempty"`
+	URL       string        `json:"url,omitempty"`
+	Data      string        `json:"data,omitempty"`
+	Precision time.Duration `json:"precision,omitempty"`
+}
+
+func createFromLPOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	spec := new(FromLPOpSpec)
+
+	n := 0
+	for _, arg := range []struct {
+		name string
+		dst  *string
+	}{
+		{name: "file", dst: &spec.File},
+		{name: "url", dst: &spec.URL},
+		{name: "data", dst: &spec.Data},
+	} {
+		if v, ok, err := args.GetString(arg.name); err != nil {
+			return nil, err
+		} else if ok {
+			*arg.dst = v
+			n++
+		}
+	}
+	if n != 1 {
+		return nil, errors.New(codes.Invalid, "must provide exactly one of the parameters file, url or data")
+	}
+
+	if d, ok, err := args.GetDuration("precision"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Precision = d.Duration()
+	}
+	if err := (lineprotocol.ResultDecoderConfig{Precision: spec.Precision}).Validate(); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func (s *FromLPOpSpec) Kind() flux.OperationKind {
+	return FromLPKind
+}
+
+type FromLPProcedureSpec struct {
+	plan.DefaultCost
+	File      string
+	URL       string
+	Data      string
+	Precision time.Duration
+}
+
+func newFromLPProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*FromLPOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+
+	return &FromLPProcedureSpec{
+		File:      spec.File,
+		URL:       spec.URL,
+		Data:      spec.Data,
+		Precision: spec.Precision,
+	}, nil
+}
+
+func (s *FromLPProcedureSpec) Kind() plan.ProcedureKind {
+	return FromLPKind
+}
+
+func (s *FromLPProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+func createFromLPSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	spec, ok := prSpec.(*FromLPProcedureSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", prSpec)
+	}
+	return CreateSource(spec, dsid, a)
+}
+
+// CreateSource creates a source that decodes line protocol from the file,
+// url or data of the procedure spec. Lines without a timestamp are
+// given the time the query is run.
+func CreateSource(spec *FromLPProcedureSpec, dsid execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	var getDataStream func() (io.ReadCloser, error)
+	switch {
+	case spec.File != "":
+		getDataStream = func() (io.ReadCloser, error) {
+			f, err := filesystem.OpenFile(a.Context(), spec.File)
+			if err != nil {
+				return nil, errors.Wrap(err, codes.Inherit, "failed to read file")
+			}
+			return f, nil
+		}
+	case spec.URL != "":
+		getDataStream = func() (io.ReadCloser, error) {
+			return openURL(a.Context(), spec.URL)
+		}
+	default:
+		getDataStream = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(strings.NewReader(spec.Data)), nil
+		}
+	}
+	return &LPSource{
+		id:            dsid,
+		getDataStream: getDataStream,
+		config: lineprotocol.ResultDecoderConfig{
+			Allocator:   a.Allocator(),
+			Precision:   spec.Precision,
+			DefaultTime: a.ResolveTime(flux.Now).Time(),
+		},
+	}, nil
+}
+
+func openURL(ctx context.Context, u string) (io.ReadCloser, error) {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return nil, errors.Wrap(err, codes.Invalid, "invalid url")
+	}
+	deps := flux.GetDependencies(ctx)
+	validator, err := flux.PackageURLValidator(deps, "experimental/lp")
+	if err != nil {
+		return nil, err
+	}
+	if err := validator.Validate(parsed); err != nil {
+		return nil, errors.New(codes.Invalid, "no such host")
+	}
+	client, err := deps.HTTPClient()
+	if err != nil {
+		return nil, errors.Wrap(err, codes.Aborted, "missing client")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, parsed.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "no such host") {
+			return nil, errors.New(codes.Invalid, "no such host")
+		}
+		return nil, err
+	}
+	if resp.StatusCode/100 != 2 {
+		_ = resp.Body.Close()
+		return nil, errors.Newf(codes.Invalid, "failed to read url: %s", resp.Status)
+	}
+	return http.MaxBytesReader(nil, resp.Body, maxURLSize), nil
+}
+
+type LPSource struct {
+	execute.ExecutionNode
+	id            execute.DatasetID
+	getDataStream func() (io.ReadCloser, error)
+	ts            []execute.Transformation
+	config        lineprotocol.ResultDecoderConfig
+}
+
+func (s *LPSource) AddTransformation(t execute.Transformation) {
+	s.ts = append(s.ts, t)
+}
+
+func (s *LPSource) Run(ctx context.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx, "lp.from")
+	defer span.Finish()
+
+	err := s.run()
+	if err != nil {
+		err = errors.Wrap(err, codes.Inherit, "error in lp.from()")
+	}
+	for _, t := range s.ts {
+		t.Finish(s.id, err)
+	}
+}
+
+func (s *LPSource) run() error {
+	for _, t := range s.ts {
+		// Decode the data once for each downstream transformation
+		// so a table instance goes to one and only one transformation.
+		data, err := s.getDataStream()
+		if err != nil {
+			return err
+		}
+		result, err := lineprotocol.NewResultDecoder(s.config).Decode(data)
+		_ = data.Close()
+		if err != nil {
+			return err
+		}
+		if err := result.Tables().Do(func(tbl flux.Table) error {
+			return t.Process(s.id, tbl)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
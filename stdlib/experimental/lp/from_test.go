@@ -0,0 +1,154 @@
+package lp_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/dependencies/filesystem"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	_ "github.com/influxdata/flux/fluxinit/static" // We need to init flux for the tests to work.
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/internal/operation"
+	"github.com/influxdata/flux/mock"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/experimental/lp"
+	"github.com/influxdata/flux/values"
+)
+
+func TestFromLP_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name:    "from no args",
+			Raw:     `import "experimental/lp" lp.from()`,
+			WantErr: true,
+		},
+		{
+			Name:    "from conflicting args",
+			Raw:     `import "experimental/lp" lp.from(data: "m v=1 1", file: "/tmp/data.lp")`,
+			WantErr: true,
+		},
+		{
+			Name:    "from invalid precision",
+			Raw:     `import "experimental/lp" lp.from(data: "m v=1 1", precision: 1m)`,
+			WantErr: true,
+		},
+		{
+			Name: "from data",
+			Raw:  `import "experimental/lp" lp.from(data: "m v=1 1", precision: 1s)`,
+			Want: &operation.Spec{
+				Operations: []*operation.Node{
+					{
+						ID: "fromLP0",
+						Spec: &lp.FromLPOpSpec{
+							Data:      "m v=1 1",
+							Precision: time.Second,
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestFromLP_Run(t *testing.T) {
+	dir, err := ioutil.TempDir("", "flux-lp-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	fpath := filepath.Join(dir, "data.lp")
+	if err := ioutil.WriteFile(fpath, []byte("cpu,host=a usage=1.5 1\ncpu,host=a usage=2.5 2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name    string
+		spec    *lp.FromLPProcedureSpec
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "data",
+			spec: &lp.FromLPProcedureSpec{
+				Data:      "cpu,host=a usage=1.5 1\ncpu,host=a usage=2.5 2\n",
+				Precision: time.Second,
+			},
+			want: []*executetest.Table{{
+				KeyCols: []string{"_field", "_measurement", "host"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+					{Label: "_field", Type: flux.TString},
+					{Label: "_measurement", Type: flux.TString},
+					{Label: "host", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{values.ConvertTime(time.Unix(1, 0)), 1.5, "usage", "cpu", "a"},
+					{values.ConvertTime(time.Unix(2, 0)), 2.5, "usage", "cpu", "a"},
+				},
+			}},
+		},
+		{
+			name: "file",
+			spec: &lp.FromLPProcedureSpec{
+				File: fpath,
+			},
+			want: []*executetest.Table{{
+				KeyCols: []string{"_field", "_measurement", "host"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+					{Label: "_field", Type: flux.TString},
+					{Label: "_measurement", Type: flux.TString},
+					{Label: "host", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{values.ConvertTime(time.Unix(0, 1)), 1.5, "usage", "cpu", "a"},
+					{values.ConvertTime(time.Unix(0, 2)), 2.5, "usage", "cpu", "a"},
+				},
+			}},
+		},
+		{
+			name: "conflicting types",
+			spec: &lp.FromLPProcedureSpec{
+				Data: "m v=1i 1\nm v=2 2\n",
+			},
+			wantErr: errors.New(
+				codes.Invalid,
+				`error in lp.from(): line protocol decoder error: field "v" of measurement "m" has conflicting types int and float`,
+			),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.RunSourceHelper(t,
+				tc.want,
+				tc.wantErr,
+				func(id execute.DatasetID) execute.Source {
+					ctx := filesystem.Inject(context.Background(), filesystem.SystemFS)
+					a := mock.AdministrationWithContext(ctx)
+					s, err := lp.CreateSource(tc.spec, id, a)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return s
+				},
+			)
+		})
+	}
+}
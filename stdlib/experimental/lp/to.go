@@ -0,0 +1,149 @@
+package lp
+
+import (
+	"bufio"
+	"context"
+	"io"
+
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/dependencies/filesystem"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/lineprotocol"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/runtime"
+)
+
+const (
+	ToLPKind = "toLP"
+
+	pkgpath = "experimental/lp"
+)
+
+func init() {
+	toLPSignature := runtime.MustLookupBuiltinType(pkgpath, "to")
+	runtime.RegisterPackageValue(pkgpath, "to", flux.MustValue(flux.FunctionValueWithSideEffect(ToLPKind, createToLPOpSpec, toLPSignature)))
+	plan.RegisterProcedureSpecWithSideEffect(ToLPKind, newToLPProcedure, ToLPKind)
+	execute.RegisterTransformation(ToLPKind, createToLPTransformation)
+}
+
+// ToLPOpSpec is the flux.OperationSpec for the `lp.to` flux function.
+type ToLPOpSpec struct {
+	File string `json:"file"`
+	lineprotocol.EncoderConfig
+}
+
+// ReadArgs loads a flux.Arguments into ToLPOpSpec.
+func (o *ToLPOpSpec) ReadArgs(args flux.Arguments) error {
+	var err error
+	if o.File, err = args.GetRequiredString("file"); err != nil {
+		return err
+	}
+	return o.EncoderConfig.ReadArgs(args)
+}
+
+func createToLPOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+	s := new(ToLPOpSpec)
+	if err := s.ReadArgs(args); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (ToLPOpSpec) Kind() flux.OperationKind {
+	return ToLPKind
+}
+
+type ToLPProcedureSpec struct {
+	plan.DefaultCost
+	Spec *ToLPOpSpec
+}
+
+func (o *ToLPProcedureSpec) Kind() plan.ProcedureKind {
+	return ToLPKind
+}
+
+func (o *ToLPProcedureSpec) Copy() plan.ProcedureSpec {
+	s := *o.Spec
+	if o.Spec.TagColumns != nil {
+		s.TagColumns = append([]string{}, o.Spec.TagColumns...)
+	}
+	if o.Spec.FieldColumns != nil {
+		s.FieldColumns = append([]string{}, o.Spec.FieldColumns...)
+	}
+	return &ToLPProcedureSpec{Spec: &s}
+}
+
+func newToLPProcedure(qs flux.OperationSpec, a plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*ToLPOpSpec)
+	if !ok && spec != nil {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+	return &ToLPProcedureSpec{Spec: spec}, nil
+}
+
+func createToLPTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*ToLPProcedureSpec)
+	if !ok {
+		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
+	}
+	return NewToLPTransformation(a.Context(), id, s, a.Allocator())
+}
+
+type toLPTransformation struct {
+	config lineprotocol.EncoderConfig
+	file   io.WriteCloser
+	w      *bufio.Writer
+	line   []byte
+}
+
+// NewToLPTransformation returns a transformation that writes each row of the
+// table chunks to the file as line protocol and passes the chunks on unchanged.
+// The file is created, or truncated, when the transformation is created.
+func NewToLPTransformation(ctx context.Context, id execute.DatasetID, spec *ToLPProcedureSpec, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	f, err := filesystem.CreateFile(ctx, spec.Spec.File)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, codes.Inherit, "cannot create file %q", spec.Spec.File)
+	}
+	return execute.NewNarrowTransformation(id, &toLPTransformation{
+		config: spec.Spec.EncoderConfig,
+		file:   f,
+		w:      bufio.NewWriter(f),
+	}, mem)
+}
+
+func (t *toLPTransformation) Process(chunk table.Chunk, d *execute.TransportDataset, mem memory.Allocator) error {
+	if chunk.Len() > 0 {
+		enc, err := lineprotocol.NewTableEncoder(t.config, chunk.Key(), chunk.Cols())
+		if err != nil {
+			return err
+		}
+		buf := chunk.Buffer()
+		for i, n := 0, chunk.Len(); i < n; i++ {
+			t.line, err = enc.AppendLine(t.line[:0], &buf, i)
+			if err != nil {
+				return err
+			}
+			if _, err := t.w.Write(t.line); err != nil {
+				return err
+			}
+		}
+	}
+
+	chunk.Retain()
+	return d.Process(chunk)
+}
+
+func (t *toLPTransformation) Close() error {
+	err := t.w.Flush()
+	if cerr := t.file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
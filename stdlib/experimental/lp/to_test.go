@@ -0,0 +1,189 @@
+package lp
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/dependencies/filesystem"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/lineprotocol"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/values"
+)
+
+func runTo(t *testing.T, spec *ToLPOpSpec, data []flux.Table, wantErr error) {
+	t.Helper()
+	ctx := filesystem.Inject(context.Background(), filesystem.SystemFS)
+	want := make([]*executetest.Table, 0, len(data))
+	for _, tbl := range data {
+		want = append(want, copyTable(tbl.(*executetest.Table)))
+	}
+	if wantErr != nil {
+		want = nil
+	}
+	executetest.ProcessTestHelper2(t, data, want, wantErr,
+		func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+			tr, d, err := NewToLPTransformation(ctx, id, &ToLPProcedureSpec{Spec: spec}, alloc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return tr, d
+		},
+	)
+}
+
+func tempFile(t *testing.T) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "flux-lp-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	return filepath.Join(dir, "out.lp")
+}
+
+func readFile(t *testing.T, fpath string) string {
+	t.Helper()
+	data, err := ioutil.ReadFile(fpath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestTo(t *testing.T) {
+	fpath := tempFile(t)
+	spec := &ToLPOpSpec{
+		File:          fpath,
+		EncoderConfig: lineprotocol.DefaultEncoderConfig(),
+	}
+	data := []flux.Table{
+		&executetest.Table{
+			KeyCols: []string{"_measurement", "_field", "host"},
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_measurement", Type: flux.TString},
+				{Label: "_field", Type: flux.TString},
+				{Label: "host", Type: flux.TString},
+				{Label: "_value", Type: flux.TFloat},
+			},
+			Data: [][]interface{}{
+				{values.ConvertTime(time.Unix(1, 0)), "cpu", "usage", "a", 1.5},
+				{values.ConvertTime(time.Unix(2, 0)), "cpu", "usage", "a", 2.5},
+			},
+		},
+		&executetest.Table{
+			KeyCols: []string{"_measurement", "_field", "host"},
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_measurement", Type: flux.TString},
+				{Label: "_field", Type: flux.TString},
+				{Label: "host", Type: flux.TString},
+				{Label: "_value", Type: flux.TInt},
+			},
+			Data: [][]interface{}{
+				{values.ConvertTime(time.Unix(1, 0)), "cpu", "count", "b", int64(3)},
+			},
+		},
+	}
+	runTo(t, spec, data, nil)
+
+	want := `cpu,host=a usage=1.5 1000000000
+cpu,host=a usage=2.5 2000000000
+cpu,host=b count=3i 1000000000
+`
+	if got := readFile(t, fpath); want != got {
+		t.Errorf("unexpected file contents -want/+got:\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestTo_FieldColumns(t *testing.T) {
+	fpath := tempFile(t)
+	config := lineprotocol.DefaultEncoderConfig()
+	config.Measurement = "weather"
+	config.FieldColumns = []string{"temp", "hum"}
+	config.Precision = time.Millisecond
+	spec := &ToLPOpSpec{
+		File:          fpath,
+		EncoderConfig: config,
+	}
+	data := []flux.Table{
+		&executetest.Table{
+			KeyCols: []string{"location"},
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "location", Type: flux.TString},
+				{Label: "hum", Type: flux.TFloat},
+				{Label: "temp", Type: flux.TFloat},
+			},
+			Data: [][]interface{}{
+				{values.ConvertTime(time.Unix(1, 0)), "denver", 81.5, 10.2},
+				{values.ConvertTime(time.Unix(2, 0)), "denver", nil, 12.4},
+			},
+		},
+	}
+	runTo(t, spec, data, nil)
+
+	want := `weather,location=denver hum=81.5,temp=10.2 1000
+weather,location=denver temp=12.4 2000
+`
+	if got := readFile(t, fpath); want != got {
+		t.Errorf("unexpected file contents -want/+got:\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestTo_Error(t *testing.T) {
+	spec := &ToLPOpSpec{
+		File:          tempFile(t),
+		EncoderConfig: lineprotocol.DefaultEncoderConfig(),
+	}
+	data := []flux.Table{
+		&executetest.Table{
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_value", Type: flux.TFloat},
+			},
+			Data: [][]interface{}{
+				{values.ConvertTime(time.Unix(1, 0)), 1.5},
+			},
+		},
+	}
+	runTo(t, spec, data, errors.New(codes.FailedPrecondition, `table has no measurement column "_measurement"`))
+}
+
+func TestTo_UnwritableFilesystem(t *testing.T) {
+	ctx := filesystem.Inject(context.Background(), readOnlyFS{})
+	spec := &ToLPOpSpec{
+		File:          tempFile(t),
+		EncoderConfig: lineprotocol.DefaultEncoderConfig(),
+	}
+	_, _, err := NewToLPTransformation(ctx, executetest.RandomDatasetID(), &ToLPProcedureSpec{Spec: spec}, memory.DefaultAllocator)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if want := "filesystem service does not support writing files"; !strings.Contains(err.Error(), want) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+type readOnlyFS struct{}
+
+func (readOnlyFS) Open(fpath string) (filesystem.File, error) {
+	return filesystem.SystemFS.Open(fpath)
+}
+
+// copyTable copies the test table before it is consumed by the transformation.
+func copyTable(tbl *executetest.Table) *executetest.Table {
+	cpy := *tbl
+	return &cpy
+}
@@ -0,0 +1,188 @@
+package otel
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	fluxhttp "github.com/influxdata/flux/dependencies/http"
+	"github.com/influxdata/flux/internal/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultMetricsPath is the path of the OTLP/HTTP metrics endpoint.
+	defaultMetricsPath = "/v1/metrics"
+	// exportMethod is the full name of the OTLP gRPC metrics export method.
+	exportMethod = "/opentelemetry.proto.collector.metrics.v1.MetricsService/Export"
+)
+
+// exporter sends encoded ExportMetricsServiceRequest messages to an OTLP receiver.
+type exporter interface {
+	Export(ctx context.Context, payload []byte) error
+	Close() error
+}
+
+func newExporter(ctx context.Context, spec *ToOTelOpSpec) (exporter, error) {
+	u, err := parseEndpoint(spec.Endpoint, spec.Protocol)
+	if err != nil {
+		return nil, err
+	}
+
+	deps := flux.GetDependencies(ctx)
+	validator, err := flux.PackageURLValidator(deps, "experimental/otel")
+	if err != nil {
+		return nil, err
+	}
+	if err := validator.Validate(u); err != nil {
+		return nil, errors.New(codes.Invalid, "no such host")
+	}
+
+	switch spec.Protocol {
+	case GRPCProtocol:
+		return newGRPCExporter(ctx, u, spec.Headers)
+	default:
+		client, err := deps.HTTPClient()
+		if err != nil {
+			return nil, errors.Wrap(err, codes.Aborted, "missing client in otel.to")
+		}
+		if u.Path == "" || u.Path == "/" {
+			u.Path = defaultMetricsPath
+		}
+		return &httpExporter{
+			client:  client,
+			url:     u.String(),
+			headers: spec.Headers,
+		}, nil
+	}
+}
+
+// parseEndpoint parses the endpoint into a URL. A gRPC endpoint may also be
+// given as a plain host and port, in which case an insecure connection is used.
+func parseEndpoint(endpoint, protocol string) (*url.URL, error) {
+	if protocol == GRPCProtocol && !strings.Contains(endpoint, "://") {
+		endpoint = "http://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, codes.Invalid, "invalid endpoint")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, errors.Newf(codes.Invalid, "endpoint scheme must be http or https but was %s", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, errors.Newf(codes.Invalid, "endpoint %q has no host", endpoint)
+	}
+	return u, nil
+}
+
+type httpExporter struct {
+	client  fluxhttp.Client
+	url     string
+	headers map[string]string
+}
+
+func (e *httpExporter) Export(ctx context.Context, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		// Alias the DNS lookup error so as not to disclose the
+		// DNS server address.
+		if strings.HasSuffix(err.Error(), "no such host") {
+			return errors.New(codes.Invalid, "no such host")
+		}
+		return errors.Wrap(err, codes.Unavailable, "failed to export metrics")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode/100 != 2 {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Newf(codes.Unavailable, "failed to export metrics: %s: %s", resp.Status, bytes.TrimSpace(body))
+	}
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	return nil
+}
+
+func (e *httpExporter) Close() error {
+	return nil
+}
+
+type grpcExporter struct {
+	conn    *grpc.ClientConn
+	headers metadata.MD
+}
+
+func newGRPCExporter(ctx context.Context, u *url.URL, headers map[string]string) (*grpcExporter, error) {
+	creds := insecure.NewCredentials()
+	if u.Scheme == "https" {
+		creds = credentials.NewTLS(&tls.Config{})
+	}
+	conn, err := grpc.DialContext(ctx, u.Host, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, errors.Wrap(err, codes.Unavailable, "failed to connect to OTLP endpoint")
+	}
+	return &grpcExporter{
+		conn:    conn,
+		headers: metadata.New(headers),
+	}, nil
+}
+
+func (e *grpcExporter) Export(ctx context.Context, payload []byte) error {
+	if len(e.headers) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, e.headers)
+	}
+	var resp []byte
+	if err := e.conn.Invoke(ctx, exportMethod, payload, &resp, grpc.ForceCodec(rawCodec{})); err != nil {
+		return errors.Newf(codes.Unavailable, "failed to export metrics: %s", status.Convert(err).Message())
+	}
+	return nil
+}
+
+func (e *grpcExporter) Close() error {
+	return e.conn.Close()
+}
+
+// rawCodec passes already encoded protocol buffer messages through gRPC.
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+	b, ok := v.([]byte)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "unexpected message type %T", v)
+	}
+	return b, nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v interface{}) error {
+	b, ok := v.(*[]byte)
+	if !ok {
+		return errors.Newf(codes.Internal, "unexpected message type %T", v)
+	}
+	*b = append((*b)[:0], data...)
+	return nil
+}
+
+// Name returns the name of the protocol buffer codec so the
+// receiver decodes the messages as protocol buffers.
+func (rawCodec) Name() string {
+	return "proto"
+}
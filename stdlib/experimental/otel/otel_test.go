@@ -0,0 +1,40 @@
+package otel_test
+
+import (
+	"testing"
+
+	_ "github.com/influxdata/flux/fluxinit/static"
+	"github.com/influxdata/flux/querytest"
+)
+
+func TestTo_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name:    "missing endpoint",
+			Raw:     `import "experimental/otel" from(bucket: "b") |> otel.to()`,
+			WantErr: true,
+		},
+		{
+			Name:    "unsupported signal",
+			Raw:     `import "experimental/otel" from(bucket: "b") |> otel.to(endpoint: "http://localhost:4318", signal: "traces")`,
+			WantErr: true,
+		},
+		{
+			Name:    "invalid protocol",
+			Raw:     `import "experimental/otel" from(bucket: "b") |> otel.to(endpoint: "http://localhost:4318", protocol: "udp")`,
+			WantErr: true,
+		},
+		{
+			Name:    "invalid kind",
+			Raw:     `import "experimental/otel" from(bucket: "b") |> otel.to(endpoint: "http://localhost:4318", kind: "histogram")`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package otel
+
+import (
+	"math"
+	"sort"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The OTLP messages are encoded by hand so that the package does not need the generated
+// OpenTelemetry protocol buffers. The field numbers below follow the definitions in
+// opentelemetry/proto/collector/metrics/v1/metrics_service.proto and its imports.
+const (
+	exportRequestResourceMetrics = 1
+
+	resourceMetricsResource     = 1
+	resourceMetricsScopeMetrics = 2
+
+	resourceAttributes = 1
+
+	scopeMetricsScope   = 1
+	scopeMetricsMetrics = 2
+
+	scopeName = 1
+
+	metricName  = 1
+	metricUnit  = 3
+	metricGauge = 5
+	metricSum   = 7
+
+	gaugeDataPoints = 1
+
+	sumDataPoints             = 1
+	sumAggregationTemporality = 2
+	sumIsMonotonic            = 3
+
+	dataPointStartTime  = 2
+	dataPointTime       = 3
+	dataPointAsDouble   = 4
+	dataPointAsInt      = 6
+	dataPointAttributes = 7
+
+	keyValueKey   = 1
+	keyValueValue = 2
+
+	anyValueString = 1
+	anyValueBool   = 2
+	anyValueInt    = 3
+	anyValueDouble = 4
+
+	// aggregationTemporalityCumulative is the AGGREGATION_TEMPORALITY_CUMULATIVE enum value.
+	aggregationTemporalityCumulative = 2
+)
+
+// attribute is an OTLP key value pair.
+type attribute struct {
+	Key   string
+	Value interface{} // string, bool, int64 or float64
+}
+
+// dataPoint is a single OTLP number data point.
+type dataPoint struct {
+	Attributes []attribute
+	StartTime  int64
+	Time       int64
+	// IsInt reports whether the value is stored in IntValue instead of DoubleValue.
+	IsInt       bool
+	IntValue    int64
+	DoubleValue float64
+}
+
+// metric is an OTLP metric with all of its data points.
+type metric struct {
+	Name string
+	Unit string
+	// Sum marks a cumulative, monotonic sum. All other metrics are gauges.
+	Sum        bool
+	DataPoints []dataPoint
+}
+
+// exportRequest is an OTLP ExportMetricsServiceRequest with a single resource and scope.
+type exportRequest struct {
+	Resource  []attribute
+	ScopeName string
+	Metrics   []*metric
+}
+
+// Marshal encodes the request with the protocol buffer wire format.
+func (r *exportRequest) Marshal() []byte {
+	var resource []byte
+	for _, a := range r.Resource {
+		resource = appendMessage(resource, resourceAttributes, appendKeyValue(nil, a))
+	}
+
+	var scope []byte
+	scope = appendString(scope, scopeName, r.ScopeName)
+
+	var scopeMetrics []byte
+	scopeMetrics = appendMessage(scopeMetrics, scopeMetricsScope, scope)
+	for _, m := range r.Metrics {
+		scopeMetrics = appendMessage(scopeMetrics, scopeMetricsMetrics, m.marshal())
+	}
+
+	var resourceMetrics []byte
+	resourceMetrics = appendMessage(resourceMetrics, resourceMetricsResource, resource)
+	resourceMetrics = appendMessage(resourceMetrics, resourceMetricsScopeMetrics, scopeMetrics)
+
+	return appendMessage(nil, exportRequestResourceMetrics, resourceMetrics)
+}
+
+func (m *metric) marshal() []byte {
+	var points []byte
+	for _, p := range m.DataPoints {
+		field := protowire.Number(gaugeDataPoints)
+		if m.Sum {
+			field = sumDataPoints
+		}
+		points = appendMessage(points, field, p.marshal())
+	}
+
+	var b []byte
+	b = appendString(b, metricName, m.Name)
+	b = appendString(b, metricUnit, m.Unit)
+	if m.Sum {
+		points = protowire.AppendTag(points, sumAggregationTemporality, protowire.VarintType)
+		points = protowire.AppendVarint(points, aggregationTemporalityCumulative)
+		points = protowire.AppendTag(points, sumIsMonotonic, protowire.VarintType)
+		points = protowire.AppendVarint(points, 1)
+		b = appendMessage(b, metricSum, points)
+	} else {
+		b = appendMessage(b, metricGauge, points)
+	}
+	return b
+}
+
+func (p *dataPoint) marshal() []byte {
+	var b []byte
+	if p.StartTime != 0 {
+		b = protowire.AppendTag(b, dataPointStartTime, protowire.Fixed64Type)
+		b = protowire.AppendFixed64(b, uint64(p.StartTime))
+	}
+	b = protowire.AppendTag(b, dataPointTime, protowire.Fixed64Type)
+	b = protowire.AppendFixed64(b, uint64(p.Time))
+	if p.IsInt {
+		b = protowire.AppendTag(b, dataPointAsInt, protowire.Fixed64Type)
+		b = protowire.AppendFixed64(b, uint64(p.IntValue))
+	} else {
+		b = protowire.AppendTag(b, dataPointAsDouble, protowire.Fixed64Type)
+		b = protowire.AppendFixed64(b, math.Float64bits(p.DoubleValue))
+	}
+	for _, a := range p.Attributes {
+		b = appendMessage(b, dataPointAttributes, appendKeyValue(nil, a))
+	}
+	return b
+}
+
+func appendKeyValue(b []byte, a attribute) []byte {
+	var v []byte
+	switch value := a.Value.(type) {
+	case string:
+		v = protowire.AppendTag(v, anyValueString, protowire.BytesType)
+		v = protowire.AppendString(v, value)
+	case bool:
+		v = protowire.AppendTag(v, anyValueBool, protowire.VarintType)
+		v = protowire.AppendVarint(v, protowire.EncodeBool(value))
+	case int64:
+		v = protowire.AppendTag(v, anyValueInt, protowire.VarintType)
+		v = protowire.AppendVarint(v, uint64(value))
+	case float64:
+		v = protowire.AppendTag(v, anyValueDouble, protowire.Fixed64Type)
+		v = protowire.AppendFixed64(v, math.Float64bits(value))
+	}
+	b = appendString(b, keyValueKey, a.Key)
+	return appendMessage(b, keyValueValue, v)
+}
+
+// appendString appends a string field. Empty strings are the default value and are omitted.
+func appendString(b []byte, num protowire.Number, s string) []byte {
+	if s == "" {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
+
+func appendMessage(b []byte, num protowire.Number, msg []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, msg)
+}
+
+// metricSet collects data points into metrics by name, keeping the order in which
+// the metrics were first seen.
+type metricSet struct {
+	sum     bool
+	unit    string
+	metrics []*metric
+	byName  map[string]*metric
+}
+
+func newMetricSet(sum bool, unit string) *metricSet {
+	return &metricSet{
+		sum:    sum,
+		unit:   unit,
+		byName: make(map[string]*metric),
+	}
+}
+
+func (s *metricSet) add(name string, p dataPoint) {
+	m, ok := s.byName[name]
+	if !ok {
+		m = &metric{Name: name, Unit: s.unit, Sum: s.sum}
+		s.byName[name] = m
+		s.metrics = append(s.metrics, m)
+	}
+	sort.Slice(p.Attributes, func(i, j int) bool {
+		return p.Attributes[i].Key < p.Attributes[j].Key
+	})
+	m.DataPoints = append(m.DataPoints, p)
+}
+
+func (s *metricSet) len() int {
+	return len(s.metrics)
+}
@@ -0,0 +1,414 @@
+package otel
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/runtime"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+	"github.com/opentracing/opentracing-go"
+)
+
+const (
+	ToOTelKind = "toOTel"
+
+	pkgpath = "experimental/otel"
+
+	// MetricsSignal is the only OpenTelemetry signal supported by to().
+	MetricsSignal = "metrics"
+
+	// HTTPProtocol exports the payloads as protocol buffers over HTTP.
+	HTTPProtocol = "http"
+	// GRPCProtocol exports the payloads with the OTLP gRPC metrics service.
+	GRPCProtocol = "grpc"
+
+	// GaugeKind exports the values as gauges.
+	GaugeKind = "gauge"
+	// SumKind exports the values as cumulative, monotonic sums.
+	SumKind = "sum"
+
+	defaultNameColLabel  = "_field"
+	defaultStartColLabel = "_start"
+	defaultServiceName   = "flux"
+	defaultTimeout       = 30 * time.Second
+)
+
+func init() {
+	toOTelSignature := runtime.MustLookupBuiltinType(pkgpath, "to")
+	runtime.RegisterPackageValue(pkgpath, "to", flux.MustValue(flux.FunctionValueWithSideEffect(ToOTelKind, createToOTelOpSpec, toOTelSignature)))
+	plan.RegisterProcedureSpecWithSideEffect(ToOTelKind, newToOTelProcedure, ToOTelKind)
+	execute.RegisterTransformation(ToOTelKind, createToOTelTransformation)
+}
+
+// ToOTelOpSpec is the flux.OperationSpec for the `otel.to` flux function.
+type ToOTelOpSpec struct {
+	Endpoint         string            `json:"endpoint"`
+	Signal           string            `json:"signal"`
+	Protocol         string            `json:"protocol"`
+	Headers          map[string]string `json:"headers"`
+	ServiceName      string            `json:"serviceName"`
+	NameColumn       string            `json:"nameColumn"`
+	ValueColumn      string            `json:"valueColumn"`
+	TimeColumn       string            `json:"timeColumn"`
+	AttributeColumns []string          `json:"attributeColumns"`
+	MetricKind       string            `json:"kind"`
+	Unit             string            `json:"unit"`
+	Timeout          time.Duration     `json:"timeout"`
+}
+
+// ReadArgs loads a flux.Arguments into ToOTelOpSpec. It sets several default values.
+func (o *ToOTelOpSpec) ReadArgs(args flux.Arguments) error {
+	var err error
+	var ok bool
+
+	if o.Endpoint, err = args.GetRequiredString("endpoint"); err != nil {
+		return err
+	}
+
+	if o.Signal, ok, err = args.GetString("signal"); err != nil {
+		return err
+	} else if !ok {
+		o.Signal = MetricsSignal
+	}
+	if o.Signal != MetricsSignal {
+		return errors.Newf(codes.Invalid, "unsupported signal %q, only %q is supported", o.Signal, MetricsSignal)
+	}
+
+	if o.Protocol, ok, err = args.GetString("protocol"); err != nil {
+		return err
+	} else if !ok {
+		o.Protocol = HTTPProtocol
+	}
+	if o.Protocol != HTTPProtocol && o.Protocol != GRPCProtocol {
+		return errors.Newf(codes.Invalid, "invalid protocol %q, must be one of %q or %q", o.Protocol, HTTPProtocol, GRPCProtocol)
+	}
+
+	if headers, ok, err := args.GetObject("headers"); err != nil {
+		return err
+	} else if ok {
+		o.Headers = make(map[string]string, headers.Len())
+		var rangeErr error
+		headers.Range(func(k string, v values.Value) {
+			if v.Type().Nature() != semantic.String {
+				rangeErr = errors.Newf(codes.Invalid, "header value %q must be a string", k)
+				return
+			}
+			o.Headers[k] = v.Str()
+		})
+		if rangeErr != nil {
+			return rangeErr
+		}
+	}
+
+	if o.ServiceName, ok, err = args.GetString("serviceName"); err != nil {
+		return err
+	} else if !ok {
+		o.ServiceName = defaultServiceName
+	}
+
+	if o.NameColumn, ok, err = args.GetString("nameColumn"); err != nil {
+		return err
+	} else if !ok {
+		o.NameColumn = defaultNameColLabel
+	}
+
+	if o.ValueColumn, ok, err = args.GetString("valueColumn"); err != nil {
+		return err
+	} else if !ok {
+		o.ValueColumn = execute.DefaultValueColLabel
+	}
+
+	if o.TimeColumn, ok, err = args.GetString("timeColumn"); err != nil {
+		return err
+	} else if !ok {
+		o.TimeColumn = execute.DefaultTimeColLabel
+	}
+
+	if attrs, ok, err := args.GetArrayAllowEmpty("attributeColumns", semantic.String); err != nil {
+		return err
+	} else if ok {
+		o.AttributeColumns = make([]string, attrs.Len())
+		attrs.Range(func(i int, v values.Value) {
+			o.AttributeColumns[i] = v.Str()
+		})
+		sort.Strings(o.AttributeColumns)
+	}
+
+	if o.MetricKind, ok, err = args.GetString("kind"); err != nil {
+		return err
+	} else if !ok {
+		o.MetricKind = GaugeKind
+	}
+	if o.MetricKind != GaugeKind && o.MetricKind != SumKind {
+		return errors.Newf(codes.Invalid, "invalid kind %q, must be one of %q or %q", o.MetricKind, GaugeKind, SumKind)
+	}
+
+	if o.Unit, _, err = args.GetString("unit"); err != nil {
+		return err
+	}
+
+	if timeout, ok, err := args.GetDuration("timeout"); err != nil {
+		return err
+	} else if ok {
+		o.Timeout = timeout.Duration()
+	} else {
+		o.Timeout = defaultTimeout
+	}
+	return nil
+}
+
+func createToOTelOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+	s := new(ToOTelOpSpec)
+	if err := s.ReadArgs(args); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (ToOTelOpSpec) Kind() flux.OperationKind {
+	return ToOTelKind
+}
+
+type ToOTelProcedureSpec struct {
+	plan.DefaultCost
+	Spec *ToOTelOpSpec
+}
+
+func (o *ToOTelProcedureSpec) Kind() plan.ProcedureKind {
+	return ToOTelKind
+}
+
+func (o *ToOTelProcedureSpec) Copy() plan.ProcedureSpec {
+	s := *o.Spec
+	if o.Spec.Headers != nil {
+		s.Headers = make(map[string]string, len(o.Spec.Headers))
+		for k, v := range o.Spec.Headers {
+			s.Headers[k] = v
+		}
+	}
+	if o.Spec.AttributeColumns != nil {
+		s.AttributeColumns = append([]string{}, o.Spec.AttributeColumns...)
+	}
+	return &ToOTelProcedureSpec{Spec: &s}
+}
+
+func newToOTelProcedure(qs flux.OperationSpec, a plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*ToOTelOpSpec)
+	if !ok && spec != nil {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+	return &ToOTelProcedureSpec{Spec: spec}, nil
+}
+
+func createToOTelTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*ToOTelProcedureSpec)
+	if !ok {
+		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
+	}
+	return NewToOTelTransformation(a.Context(), id, s, a.Allocator())
+}
+
+type toOTelTransformation struct {
+	ctx      context.Context
+	spec     *ToOTelOpSpec
+	exporter exporter
+	span     opentracing.Span
+}
+
+// NewToOTelTransformation returns a transformation that exports each table chunk
+// as an OTLP metrics payload and passes the chunk on unchanged.
+func NewToOTelTransformation(ctx context.Context, id execute.DatasetID, spec *ToOTelProcedureSpec, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	var span opentracing.Span
+	span, ctx = opentracing.StartSpanFromContext(ctx, "ToOTelTransformation.Process")
+
+	exporter, err := newExporter(ctx, spec.Spec)
+	if err != nil {
+		span.Finish()
+		return nil, nil, err
+	}
+	return execute.NewNarrowTransformation(id, &toOTelTransformation{
+		ctx:      ctx,
+		spec:     spec.Spec,
+		exporter: exporter,
+		span:     span,
+	}, mem)
+}
+
+func (t *toOTelTransformation) Process(chunk table.Chunk, d *execute.TransportDataset, mem memory.Allocator) error {
+	metrics, err := t.convert(chunk)
+	if err != nil {
+		return err
+	}
+
+	if metrics.len() > 0 {
+		req := &exportRequest{
+			Resource:  []attribute{{Key: "service.name", Value: t.spec.ServiceName}},
+			ScopeName: "github.com/influxdata/flux/stdlib/experimental/otel",
+			Metrics:   metrics.metrics,
+		}
+		ctx, cancel := context.WithTimeout(t.ctx, t.spec.Timeout)
+		err := t.exporter.Export(ctx, req.Marshal())
+		cancel()
+		if err != nil {
+			return err
+		}
+	}
+
+	chunk.Retain()
+	return d.Process(chunk)
+}
+
+// convert reads the data points from the rows of the chunk.
+// Rows with a null name, value or time are skipped.
+func (t *toOTelTransformation) convert(chunk table.Chunk) (*metricSet, error) {
+	cols := chunk.Cols()
+
+	nameIdx := execute.ColIdx(t.spec.NameColumn, cols)
+	if nameIdx < 0 {
+		return nil, errors.Newf(codes.Invalid, "no column with label %s exists", t.spec.NameColumn)
+	} else if cols[nameIdx].Type != flux.TString {
+		return nil, errors.Newf(codes.Invalid, "column %s of type %s is not of type %s", t.spec.NameColumn, cols[nameIdx].Type, flux.TString)
+	}
+
+	timeIdx := execute.ColIdx(t.spec.TimeColumn, cols)
+	if timeIdx < 0 {
+		return nil, errors.New(codes.Invalid, "no time column detected")
+	} else if cols[timeIdx].Type != flux.TTime {
+		return nil, errors.Newf(codes.Invalid, "column %s of type %s is not of type %s", t.spec.TimeColumn, cols[timeIdx].Type, flux.TTime)
+	}
+
+	valueIdx := execute.ColIdx(t.spec.ValueColumn, cols)
+	if valueIdx < 0 {
+		return nil, errors.Newf(codes.Invalid, "no column with label %s exists", t.spec.ValueColumn)
+	}
+	switch cols[valueIdx].Type {
+	case flux.TFloat, flux.TInt, flux.TUInt:
+	default:
+		return nil, errors.Newf(codes.Invalid, "column %s of type %s must be numeric", t.spec.ValueColumn, cols[valueIdx].Type)
+	}
+
+	// Sums report the start of the aggregation window when the table has one.
+	startIdx := -1
+	if t.spec.MetricKind == SumKind {
+		if idx := execute.ColIdx(defaultStartColLabel, cols); idx >= 0 && cols[idx].Type == flux.TTime {
+			startIdx = idx
+		}
+	}
+
+	attrIdxs, err := t.attributeColumns(cols, nameIdx, timeIdx, valueIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := newMetricSet(t.spec.MetricKind == SumKind, t.spec.Unit)
+	er := chunk.Buffer()
+	for i := 0; i < chunk.Len(); i++ {
+		names, times := er.Strings(nameIdx), er.Times(timeIdx)
+		if names.IsNull(i) || times.IsNull(i) {
+			continue
+		}
+
+		p := dataPoint{Time: times.Value(i)}
+		switch cols[valueIdx].Type {
+		case flux.TFloat:
+			vs := er.Floats(valueIdx)
+			if vs.IsNull(i) {
+				continue
+			}
+			p.DoubleValue = vs.Value(i)
+		case flux.TInt:
+			vs := er.Ints(valueIdx)
+			if vs.IsNull(i) {
+				continue
+			}
+			p.IsInt, p.IntValue = true, vs.Value(i)
+		case flux.TUInt:
+			vs := er.UInts(valueIdx)
+			if vs.IsNull(i) {
+				continue
+			}
+			if v := vs.Value(i); v <= math.MaxInt64 {
+				p.IsInt, p.IntValue = true, int64(v)
+			} else {
+				p.DoubleValue = float64(v)
+			}
+		}
+
+		if startIdx >= 0 {
+			if starts := er.Times(startIdx); starts.IsValid(i) {
+				p.StartTime = starts.Value(i)
+			}
+		}
+
+		for _, j := range attrIdxs {
+			v := execute.ValueForRow(&er, i, j)
+			if v.IsNull() {
+				continue
+			}
+			a := attribute{Key: cols[j].Label}
+			switch cols[j].Type {
+			case flux.TString:
+				a.Value = v.Str()
+			case flux.TBool:
+				a.Value = v.Bool()
+			case flux.TInt:
+				a.Value = v.Int()
+			case flux.TUInt:
+				a.Value = int64(v.UInt())
+			case flux.TFloat:
+				a.Value = v.Float()
+			case flux.TTime:
+				a.Value = v.Time().Time().Format(time.RFC3339Nano)
+			}
+			p.Attributes = append(p.Attributes, a)
+		}
+		metrics.add(names.Value(i), p)
+	}
+	return metrics, nil
+}
+
+// attributeColumns returns the indexes of the columns that are exported as attributes.
+// If no attribute columns were specified, all string columns except the name column are used.
+func (t *toOTelTransformation) attributeColumns(cols []flux.ColMeta, nameIdx, timeIdx, valueIdx int) ([]int, error) {
+	var idxs []int
+	if t.spec.AttributeColumns == nil {
+		for j, col := range cols {
+			if col.Type == flux.TString && j != nameIdx && j != valueIdx {
+				idxs = append(idxs, j)
+			}
+		}
+		return idxs, nil
+	}
+
+	for _, label := range t.spec.AttributeColumns {
+		j := execute.ColIdx(label, cols)
+		if j < 0 {
+			// Attribute columns missing from a table are omitted,
+			// in the same way as a null value.
+			continue
+		}
+		if j == nameIdx || j == timeIdx || j == valueIdx {
+			return nil, errors.Newf(codes.Invalid, "column %s cannot be used as an attribute", label)
+		}
+		idxs = append(idxs, j)
+	}
+	return idxs, nil
+}
+
+func (t *toOTelTransformation) Close() error {
+	defer t.span.Finish()
+	return t.exporter.Close()
+}
@@ -0,0 +1,413 @@
+package otel
+
+import (
+	"context"
+	"io/ioutil"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/memory"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// decodeMessage decodes a protocol buffer message into its fields.
+// Length delimited fields are returned as []byte and all other fields as uint64.
+func decodeMessage(t *testing.T, b []byte) map[protowire.Number][]interface{} {
+	t.Helper()
+	fields := make(map[protowire.Number][]interface{})
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			t.Fatalf("invalid tag: %v", protowire.ParseError(n))
+		}
+		b = b[n:]
+		var v interface{}
+		switch typ {
+		case protowire.BytesType:
+			v, n = protowire.ConsumeBytes(b)
+		case protowire.VarintType:
+			v, n = protowire.ConsumeVarint(b)
+		case protowire.Fixed64Type:
+			v, n = protowire.ConsumeFixed64(b)
+		default:
+			t.Fatalf("unexpected wire type %v", typ)
+		}
+		if n < 0 {
+			t.Fatalf("invalid value: %v", protowire.ParseError(n))
+		}
+		b = b[n:]
+		fields[num] = append(fields[num], v)
+	}
+	return fields
+}
+
+type testPoint struct {
+	Metric     string
+	Unit       string
+	Sum        bool
+	Start      int64
+	Time       int64
+	Value      interface{}
+	Attributes map[string]interface{}
+}
+
+// decodeRequest decodes an ExportMetricsServiceRequest into a flat list of points
+// together with the resource attributes.
+func decodeRequest(t *testing.T, b []byte) (map[string]interface{}, []testPoint) {
+	t.Helper()
+	attrs := func(kvs []interface{}) map[string]interface{} {
+		m := make(map[string]interface{})
+		for _, kv := range kvs {
+			f := decodeMessage(t, kv.([]byte))
+			key := string(f[keyValueKey][0].([]byte))
+			v := decodeMessage(t, f[keyValueValue][0].([]byte))
+			switch {
+			case v[anyValueString] != nil:
+				m[key] = string(v[anyValueString][0].([]byte))
+			case v[anyValueBool] != nil:
+				m[key] = protowire.DecodeBool(v[anyValueBool][0].(uint64))
+			case v[anyValueInt] != nil:
+				m[key] = int64(v[anyValueInt][0].(uint64))
+			case v[anyValueDouble] != nil:
+				m[key] = math.Float64frombits(v[anyValueDouble][0].(uint64))
+			}
+		}
+		return m
+	}
+
+	req := decodeMessage(t, b)
+	if n := len(req[exportRequestResourceMetrics]); n != 1 {
+		t.Fatalf("expected 1 resource metrics, got %d", n)
+	}
+	rm := decodeMessage(t, req[exportRequestResourceMetrics][0].([]byte))
+	resource := decodeMessage(t, rm[resourceMetricsResource][0].([]byte))
+	sm := decodeMessage(t, rm[resourceMetricsScopeMetrics][0].([]byte))
+
+	var points []testPoint
+	for _, mb := range sm[scopeMetricsMetrics] {
+		m := decodeMessage(t, mb.([]byte))
+		name := string(m[metricName][0].([]byte))
+		var unit string
+		if m[metricUnit] != nil {
+			unit = string(m[metricUnit][0].([]byte))
+		}
+		data, isSum := m[metricGauge], false
+		if m[metricSum] != nil {
+			data, isSum = m[metricSum], true
+		}
+		d := decodeMessage(t, data[0].([]byte))
+		if isSum {
+			if got := d[sumAggregationTemporality][0].(uint64); got != aggregationTemporalityCumulative {
+				t.Errorf("unexpected aggregation temporality %d", got)
+			}
+		}
+		for _, pb := range d[gaugeDataPoints] {
+			p := decodeMessage(t, pb.([]byte))
+			tp := testPoint{
+				Metric:     name,
+				Unit:       unit,
+				Sum:        isSum,
+				Time:       int64(p[dataPointTime][0].(uint64)),
+				Attributes: attrs(p[dataPointAttributes]),
+			}
+			if p[dataPointStartTime] != nil {
+				tp.Start = int64(p[dataPointStartTime][0].(uint64))
+			}
+			if p[dataPointAsInt] != nil {
+				tp.Value = int64(p[dataPointAsInt][0].(uint64))
+			} else {
+				tp.Value = math.Float64frombits(p[dataPointAsDouble][0].(uint64))
+			}
+			points = append(points, tp)
+		}
+	}
+	return attrs(resource[resourceAttributes]), points
+}
+
+func runTo(t *testing.T, spec *ToOTelOpSpec, data []flux.Table, wantErr error) {
+	t.Helper()
+	ctx := flux.NewDefaultDependencies().Inject(context.Background())
+	want := make([]*executetest.Table, 0, len(data))
+	for _, tbl := range data {
+		want = append(want, copyTable(tbl.(*executetest.Table)))
+	}
+	if wantErr != nil {
+		want = nil
+	}
+	executetest.ProcessTestHelper2(t, data, want, wantErr,
+		func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+			tr, d, err := NewToOTelTransformation(ctx, id, &ToOTelProcedureSpec{Spec: spec}, alloc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return tr, d
+		},
+	)
+}
+
+// copyTable copies the test table before it is consumed by the transformation.
+func copyTable(tbl *executetest.Table) *executetest.Table {
+	cpy := *tbl
+	return &cpy
+}
+
+func newSpec(endpoint string) *ToOTelOpSpec {
+	return &ToOTelOpSpec{
+		Endpoint:    endpoint,
+		Signal:      MetricsSignal,
+		Protocol:    HTTPProtocol,
+		ServiceName: defaultServiceName,
+		NameColumn:  defaultNameColLabel,
+		ValueColumn: execute.DefaultValueColLabel,
+		TimeColumn:  execute.DefaultTimeColLabel,
+		MetricKind:  GaugeKind,
+		Timeout:     defaultTimeout,
+	}
+}
+
+func TestTo_HTTP(t *testing.T) {
+	var (
+		path, contentType, apiKey string
+		payloads                  [][]byte
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		contentType = r.Header.Get("Content-Type")
+		apiKey = r.Header.Get("X-Api-Key")
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		payloads = append(payloads, body)
+	}))
+	defer ts.Close()
+
+	spec := newSpec(ts.URL)
+	spec.Headers = map[string]string{"X-Api-Key": "secret"}
+	spec.ServiceName = "tasks"
+	data := []flux.Table{&executetest.Table{
+		KeyCols: []string{"_measurement", "_field", "host"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_measurement", Type: flux.TString},
+			{Label: "_field", Type: flux.TString},
+			{Label: "host", Type: flux.TString},
+			{Label: "_value", Type: flux.TFloat},
+		},
+		Data: [][]interface{}{
+			{execute.Time(1), "cpu", "usage", "a", 1.5},
+			{execute.Time(2), "cpu", "usage", "a", nil},
+			{execute.Time(3), "cpu", "usage", "a", 2.5},
+		},
+	}}
+	runTo(t, spec, data, nil)
+
+	if want, got := defaultMetricsPath, path; want != got {
+		t.Errorf("unexpected path want: %q got: %q", want, got)
+	}
+	if want, got := "application/x-protobuf", contentType; want != got {
+		t.Errorf("unexpected content type want: %q got: %q", want, got)
+	}
+	if want, got := "secret", apiKey; want != got {
+		t.Errorf("unexpected api key want: %q got: %q", want, got)
+	}
+	if len(payloads) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(payloads))
+	}
+
+	resource, points := decodeRequest(t, payloads[0])
+	if want := map[string]interface{}{"service.name": "tasks"}; !cmp.Equal(want, resource) {
+		t.Errorf("unexpected resource -want/+got:\n%s", cmp.Diff(want, resource))
+	}
+	attrs := map[string]interface{}{"_measurement": "cpu", "host": "a"}
+	wantPoints := []testPoint{
+		{Metric: "usage", Time: 1, Value: 1.5, Attributes: attrs},
+		{Metric: "usage", Time: 3, Value: 2.5, Attributes: attrs},
+	}
+	if !cmp.Equal(wantPoints, points) {
+		t.Errorf("unexpected points -want/+got:\n%s", cmp.Diff(wantPoints, points))
+	}
+}
+
+func TestTo_Sum(t *testing.T) {
+	var payload []byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, _ = ioutil.ReadAll(r.Body)
+	}))
+	defer ts.Close()
+
+	spec := newSpec(ts.URL + "/otlp/v1/metrics")
+	spec.MetricKind = SumKind
+	spec.Unit = "{requests}"
+	spec.NameColumn = "name"
+	spec.AttributeColumns = []string{"code", "missing"}
+	data := []flux.Table{&executetest.Table{
+		KeyCols: []string{"_start", "_stop", "name"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_start", Type: flux.TTime},
+			{Label: "_stop", Type: flux.TTime},
+			{Label: "_time", Type: flux.TTime},
+			{Label: "name", Type: flux.TString},
+			{Label: "code", Type: flux.TInt},
+			{Label: "host", Type: flux.TString},
+			{Label: "_value", Type: flux.TUInt},
+		},
+		Data: [][]interface{}{
+			{execute.Time(0), execute.Time(10), execute.Time(5), "requests", int64(200), "a", uint64(10)},
+			{execute.Time(0), execute.Time(10), execute.Time(6), "requests", int64(500), "a", uint64(math.MaxUint64)},
+		},
+	}}
+	runTo(t, spec, data, nil)
+
+	_, points := decodeRequest(t, payload)
+	wantPoints := []testPoint{
+		{Metric: "requests", Unit: "{requests}", Sum: true, Start: 0, Time: 5, Value: int64(10), Attributes: map[string]interface{}{"code": int64(200)}},
+		{Metric: "requests", Unit: "{requests}", Sum: true, Start: 0, Time: 6, Value: float64(math.MaxUint64), Attributes: map[string]interface{}{"code": int64(500)}},
+	}
+	if !cmp.Equal(wantPoints, points) {
+		t.Errorf("unexpected points -want/+got:\n%s", cmp.Diff(wantPoints, points))
+	}
+}
+
+func TestTo_HTTPError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad payload", http.StatusBadRequest)
+	}))
+	defer ts.Close()
+
+	data := []flux.Table{&executetest.Table{
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_field", Type: flux.TString},
+			{Label: "_value", Type: flux.TFloat},
+		},
+		Data: [][]interface{}{
+			{execute.Time(1), "usage", 1.5},
+		},
+	}}
+	ctx := flux.NewDefaultDependencies().Inject(context.Background())
+	tr, _, err := NewToOTelTransformation(ctx, executetest.RandomDatasetID(), &ToOTelProcedureSpec{Spec: newSpec(ts.URL)}, &memory.ResourceAllocator{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = tr.Process(executetest.RandomDatasetID(), data[0])
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), "bad payload") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestTo_InvalidColumns(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request")
+	}))
+	defer ts.Close()
+
+	for _, tc := range []struct {
+		name string
+		cols []flux.ColMeta
+		row  []interface{}
+	}{
+		{
+			name: "missing name column",
+			cols: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_value", Type: flux.TFloat},
+			},
+			row: []interface{}{execute.Time(1), 1.0},
+		},
+		{
+			name: "string value column",
+			cols: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_field", Type: flux.TString},
+				{Label: "_value", Type: flux.TString},
+			},
+			row: []interface{}{execute.Time(1), "usage", "high"},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := flux.NewDefaultDependencies().Inject(context.Background())
+			tr, _, err := NewToOTelTransformation(ctx, executetest.RandomDatasetID(), &ToOTelProcedureSpec{Spec: newSpec(ts.URL)}, &memory.ResourceAllocator{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			tbl := &executetest.Table{ColMeta: tc.cols, Data: [][]interface{}{tc.row}}
+			if err := tr.Process(executetest.RandomDatasetID(), tbl); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestTo_GRPC(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		method  string
+		apiKey  []string
+		payload []byte
+	)
+	srv := grpc.NewServer(
+		grpc.ForceServerCodec(rawCodec{}),
+		grpc.UnknownServiceHandler(func(srv interface{}, stream grpc.ServerStream) error {
+			method, _ = grpc.MethodFromServerStream(stream)
+			md, _ := metadata.FromIncomingContext(stream.Context())
+			apiKey = md.Get("x-api-key")
+			if err := stream.RecvMsg(&payload); err != nil {
+				return err
+			}
+			return stream.SendMsg([]byte{})
+		}),
+	)
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	spec := newSpec(lis.Addr().String())
+	spec.Protocol = GRPCProtocol
+	spec.Headers = map[string]string{"x-api-key": "secret"}
+	spec.Timeout = 5 * time.Second
+	data := []flux.Table{&executetest.Table{
+		KeyCols: []string{"_field"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_field", Type: flux.TString},
+			{Label: "_value", Type: flux.TInt},
+		},
+		Data: [][]interface{}{
+			{execute.Time(1), "queue_length", int64(3)},
+		},
+	}}
+	runTo(t, spec, data, nil)
+
+	if want, got := exportMethod, method; want != got {
+		t.Errorf("unexpected method want: %q got: %q", want, got)
+	}
+	if want, got := []string{"secret"}, apiKey; !cmp.Equal(want, got) {
+		t.Errorf("unexpected api key -want/+got:\n%s", cmp.Diff(want, got))
+	}
+	_, points := decodeRequest(t, payload)
+	wantPoints := []testPoint{
+		{Metric: "queue_length", Time: 1, Value: int64(3), Attributes: map[string]interface{}{}},
+	}
+	if !cmp.Equal(wantPoints, points) {
+		t.Errorf("unexpected points -want/+got:\n%s", cmp.Diff(wantPoints, points))
+	}
+}
@@ -0,0 +1,215 @@
+package prometheus
+
+import (
+	"bufio"
+	"bytes"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+)
+
+// extractExemplars removes OpenMetrics exemplars from a text exposition body.
+// The exemplars of histogram buckets are returned keyed by bucketKey so they can be
+// attached to the buckets once the cleaned body has been parsed.
+// Exemplars of other samples are dropped.
+func extractExemplars(body []byte) ([]byte, map[string]Exemplar, error) {
+	if !bytes.Contains(body, []byte("# {")) && !bytes.Contains(body, []byte("#{")) {
+		return body, nil, nil
+	}
+
+	var (
+		out       bytes.Buffer
+		exemplars = make(map[string]Exemplar)
+		scanner   = bufio.NewScanner(bytes.NewReader(body))
+	)
+	scanner.Buffer(make([]byte, 0, 64*1024), len(body)+1)
+	for scanner.Scan() {
+		line := scanner.Text()
+		sample, exemplar, ok := splitExemplar(line)
+		if !ok {
+			out.WriteString(line)
+			out.WriteByte('\n')
+			continue
+		}
+		out.WriteString(sample)
+		out.WriteByte('\n')
+
+		name, labels, _, err := parseSeries(strings.TrimSpace(sample))
+		if err != nil {
+			return nil, nil, err
+		}
+		e, err := parseExemplar(exemplar)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !strings.HasSuffix(name, "_bucket") {
+			continue
+		}
+		le, ok := labels["le"]
+		if !ok {
+			continue
+		}
+		bound, err := strconv.ParseFloat(le, 64)
+		if err != nil {
+			continue
+		}
+		delete(labels, "le")
+		exemplars[bucketKey(strings.TrimSuffix(name, "_bucket"), labels, bound)] = e
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+	return out.Bytes(), exemplars, nil
+}
+
+// splitExemplar splits a sample line into the sample and its exemplar.
+// It reports false if the line is not a sample or has no exemplar.
+func splitExemplar(line string) (sample, exemplar string, ok bool) {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || trimmed[0] == '#' {
+		return "", "", false
+	}
+	_, _, rest, err := parseSeries(trimmed)
+	if err != nil {
+		return "", "", false
+	}
+	idx := strings.IndexByte(rest, '#')
+	if idx < 0 {
+		return "", "", false
+	}
+	end := len(trimmed) - len(rest) + idx
+	return strings.TrimRight(trimmed[:end], " \t"), rest[idx+1:], true
+}
+
+// parseSeries parses the metric name and the labels at the start of a sample line
+// and returns the remainder of the line.
+func parseSeries(s string) (name string, labels map[string]string, rest string, err error) {
+	i := strings.IndexAny(s, "{ \t")
+	if i < 0 {
+		return s, map[string]string{}, "", nil
+	}
+	name = s[:i]
+	if s[i] != '{' {
+		return name, map[string]string{}, s[i:], nil
+	}
+	labels, rest, err = parseLabelSet(s[i:])
+	return name, labels, rest, err
+}
+
+// parseLabelSet parses a label set such as {a="b",c="d"} and returns
+// the labels and the remainder of the string.
+func parseLabelSet(s string) (map[string]string, string, error) {
+	if !strings.HasPrefix(s, "{") {
+		return nil, "", errors.New(codes.Invalid, "invalid label set: expected '{'")
+	}
+	labels := make(map[string]string)
+	i := 1
+	for {
+		for i < len(s) && (s[i] == ' ' || s[i] == '\t' || s[i] == ',') {
+			i++
+		}
+		if i >= len(s) {
+			return nil, "", errors.New(codes.Invalid, "invalid label set: missing '}'")
+		}
+		if s[i] == '}' {
+			return labels, s[i+1:], nil
+		}
+
+		eq := strings.IndexByte(s[i:], '=')
+		if eq < 0 {
+			return nil, "", errors.New(codes.Invalid, "invalid label set: missing '='")
+		}
+		name := strings.TrimSpace(s[i : i+eq])
+		i += eq + 1
+		for i < len(s) && (s[i] == ' ' || s[i] == '\t') {
+			i++
+		}
+		if i >= len(s) || s[i] != '"' {
+			return nil, "", errors.Newf(codes.Invalid, "invalid label set: value of label %q must be quoted", name)
+		}
+		i++
+
+		var value strings.Builder
+		closed := false
+		for ; i < len(s); i++ {
+			c := s[i]
+			if c == '\\' && i+1 < len(s) {
+				i++
+				switch s[i] {
+				case 'n':
+					value.WriteByte('\n')
+				default:
+					value.WriteByte(s[i])
+				}
+				continue
+			}
+			if c == '"' {
+				closed = true
+				i++
+				break
+			}
+			value.WriteByte(c)
+		}
+		if !closed {
+			return nil, "", errors.Newf(codes.Invalid, "invalid label set: value of label %q is not terminated", name)
+		}
+		labels[name] = value.String()
+	}
+}
+
+// parseExemplar parses the exemplar part of a sample line,
+// which is a label set followed by a value and an optional timestamp in seconds.
+func parseExemplar(s string) (Exemplar, error) {
+	labels, rest, err := parseLabelSet(strings.TrimSpace(s))
+	if err != nil {
+		return Exemplar{}, errors.Wrap(err, codes.Invalid, "invalid exemplar")
+	}
+	fields := strings.Fields(rest)
+	if len(fields) == 0 || len(fields) > 2 {
+		return Exemplar{}, errors.Newf(codes.Invalid, "invalid exemplar %q", s)
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return Exemplar{}, errors.Newf(codes.Invalid, "invalid exemplar value %q", fields[0])
+	}
+	e := Exemplar{
+		Labels: labels,
+		Value:  value,
+	}
+	if len(fields) == 2 {
+		ts, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return Exemplar{}, errors.Newf(codes.Invalid, "invalid exemplar timestamp %q", fields[1])
+		}
+		sec, frac := math.Modf(ts)
+		t := time.Unix(int64(sec), int64(math.Round(frac*1e9))).UTC()
+		e.Timestamp = &t
+	}
+	return e, nil
+}
+
+// bucketKey returns a key that identifies a single bucket of a histogram series.
+func bucketKey(metricName string, tags map[string]string, bound float64) string {
+	names := make([]string, 0, len(tags))
+	for name := range tags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString(metricName)
+	for _, name := range names {
+		b.WriteByte(0xff)
+		b.WriteString(name)
+		b.WriteByte('=')
+		b.WriteString(tags[name])
+	}
+	b.WriteByte(0xff)
+	b.WriteString(strconv.FormatFloat(bound, 'g', -1, 64))
+	return b.String()
+}
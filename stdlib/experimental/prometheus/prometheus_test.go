@@ -3,6 +3,7 @@ package prometheus
 import (
 	"context"
 	"fmt"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -225,6 +226,228 @@ func TestSummary(t *testing.T) {
 
 }
 
+// TestHistogram will make sure that histogram metrics produce accurate flux Tables.
+func TestHistogram(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `
+		# TYPE http_request_duration_seconds histogram
+		http_request_duration_seconds_bucket{le="0.5"} 3
+		http_request_duration_seconds_bucket{le="+Inf"} 4
+		http_request_duration_seconds_sum 1.75
+		http_request_duration_seconds_count 4
+		`)
+	}))
+	defer ts.Close()
+
+	spec := &ScrapePrometheusProcedureSpec{URL: ts.URL, Mode: FlatMode}
+	admin := &mock.Administration{}
+	c := execute.NewTableBuilderCache(admin.Allocator())
+	timestamp := time.Now()
+	p := &PrometheusIterator{
+		NowFn:          func() time.Time { return timestamp },
+		spec:           spec,
+		administration: admin,
+		cache:          c,
+	}
+
+	results := testSourceDecoder(p, t)
+
+	cols := []flux.ColMeta{
+		{Label: "_time", Type: flux.TTime},
+		{Label: "_value", Type: flux.TFloat},
+		{Label: "_measurement", Type: flux.TString},
+		{Label: "_field", Type: flux.TString},
+		{Label: "url", Type: flux.TString},
+	}
+	bucketCols := append(cols[:len(cols):len(cols)], flux.ColMeta{Label: "le", Type: flux.TString})
+	wantResults := &executetest.Result{
+		Tbls: []*executetest.Table{
+			{
+				KeyCols: []string{"_measurement", "_field"},
+				ColMeta: cols,
+				Data: [][]interface{}{
+					{values.ConvertTime(timestamp), 4.0, "prometheus", "http_request_duration_seconds_count", ts.URL},
+				},
+			},
+			{
+				KeyCols: []string{"_measurement", "_field"},
+				ColMeta: cols,
+				Data: [][]interface{}{
+					{values.ConvertTime(timestamp), 1.75, "prometheus", "http_request_duration_seconds_sum", ts.URL},
+				},
+			},
+			{
+				KeyCols: []string{"_measurement", "_field", "le"},
+				ColMeta: bucketCols,
+				Data: [][]interface{}{
+					{values.ConvertTime(timestamp), 3.0, "prometheus", "http_request_duration_seconds", ts.URL, "0.5"},
+				},
+			},
+			{
+				KeyCols: []string{"_measurement", "_field", "le"},
+				ColMeta: bucketCols,
+				Data: [][]interface{}{
+					{values.ConvertTime(timestamp), 4.0, "prometheus", "http_request_duration_seconds", ts.URL, "+Inf"},
+				},
+			},
+		},
+	}
+
+	err := executetest.EqualResult(wantResults, results)
+
+	if err != nil {
+		t.Fatal(err, wantResults.Tables(), results.Tables())
+	}
+}
+
+// TestHistogram_Structured will make sure that histogram metrics scraped in structured mode
+// produce a single table of buckets with their exemplars.
+func TestHistogram_Structured(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/openmetrics-text; version=1.0.0; charset=utf-8")
+		fmt.Fprintln(w, `# TYPE http_request_duration_seconds histogram
+http_request_duration_seconds_bucket{handler="api",le="0.5"} 3 # {trace_id="abc"} 0.25 1609459200.5
+http_request_duration_seconds_bucket{handler="api",le="1"} 3
+http_request_duration_seconds_bucket{handler="api",le="+Inf"} 4 # {trace_id="def",span_id="1"} 2.5
+http_request_duration_seconds_sum{handler="api"} 3.25
+http_request_duration_seconds_count{handler="api"} 4
+# EOF`)
+	}))
+	defer ts.Close()
+
+	spec := &ScrapePrometheusProcedureSpec{URL: ts.URL, Mode: StructuredMode}
+	admin := &mock.Administration{}
+	c := execute.NewTableBuilderCache(admin.Allocator())
+	timestamp := time.Now()
+	p := &PrometheusIterator{
+		NowFn:          func() time.Time { return timestamp },
+		spec:           spec,
+		administration: admin,
+		cache:          c,
+	}
+
+	results := testSourceDecoder(p, t)
+
+	cols := []flux.ColMeta{
+		{Label: "_time", Type: flux.TTime},
+		{Label: "_value", Type: flux.TFloat},
+		{Label: "_measurement", Type: flux.TString},
+		{Label: "_field", Type: flux.TString},
+		{Label: "url", Type: flux.TString},
+		{Label: "handler", Type: flux.TString},
+	}
+	now := values.ConvertTime(timestamp)
+	wantResults := &executetest.Result{
+		Tbls: []*executetest.Table{
+			{
+				KeyCols: []string{"_measurement", "_field", "handler"},
+				ColMeta: cols,
+				Data: [][]interface{}{
+					{now, 4.0, "prometheus", "http_request_duration_seconds_count", ts.URL, "api"},
+				},
+			},
+			{
+				KeyCols: []string{"_measurement", "_field", "handler"},
+				ColMeta: cols,
+				Data: [][]interface{}{
+					{now, 3.25, "prometheus", "http_request_duration_seconds_sum", ts.URL, "api"},
+				},
+			},
+			{
+				KeyCols: []string{"_measurement", "_field", "handler"},
+				ColMeta: append(cols[:len(cols):len(cols)],
+					flux.ColMeta{Label: "le", Type: flux.TFloat},
+					flux.ColMeta{Label: "exemplar_value", Type: flux.TFloat},
+					flux.ColMeta{Label: "exemplar_time", Type: flux.TTime},
+					flux.ColMeta{Label: "exemplar_span_id", Type: flux.TString},
+					flux.ColMeta{Label: "exemplar_trace_id", Type: flux.TString},
+				),
+				Data: [][]interface{}{
+					{now, 3.0, "prometheus", "http_request_duration_seconds", ts.URL, "api", 0.5, 0.25, values.ConvertTime(time.Unix(1609459200, 5e8)), nil, "abc"},
+					{now, 3.0, "prometheus", "http_request_duration_seconds", ts.URL, "api", 1.0, nil, nil, nil, nil},
+					{now, 4.0, "prometheus", "http_request_duration_seconds", ts.URL, "api", math.Inf(1), 2.5, nil, "1", "def"},
+				},
+			},
+		},
+	}
+
+	err := executetest.EqualResult(wantResults, results)
+
+	if err != nil {
+		t.Fatal(err, wantResults.Tables(), results.Tables())
+	}
+}
+
+// TestSummary_Structured will make sure that summary metrics scraped in structured mode
+// produce a single table of quantiles.
+func TestSummary_Structured(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `
+		# TYPE go_gc_duration_seconds summary
+		go_gc_duration_seconds{quantile="0"} 1.5819e-05
+		go_gc_duration_seconds{quantile="0.5"} 3.2e-05
+		go_gc_duration_seconds{quantile="1"} NaN
+		go_gc_duration_seconds_sum 53.148989515
+		go_gc_duration_seconds_count 1.405614e+06
+		`)
+	}))
+	defer ts.Close()
+
+	spec := &ScrapePrometheusProcedureSpec{URL: ts.URL, Mode: StructuredMode}
+	admin := &mock.Administration{}
+	c := execute.NewTableBuilderCache(admin.Allocator())
+	timestamp := time.Now()
+	p := &PrometheusIterator{
+		NowFn:          func() time.Time { return timestamp },
+		spec:           spec,
+		administration: admin,
+		cache:          c,
+	}
+
+	results := testSourceDecoder(p, t)
+
+	cols := []flux.ColMeta{
+		{Label: "_time", Type: flux.TTime},
+		{Label: "_value", Type: flux.TFloat},
+		{Label: "_measurement", Type: flux.TString},
+		{Label: "_field", Type: flux.TString},
+		{Label: "url", Type: flux.TString},
+	}
+	now := values.ConvertTime(timestamp)
+	wantResults := &executetest.Result{
+		Tbls: []*executetest.Table{
+			{
+				KeyCols: []string{"_measurement", "_field"},
+				ColMeta: cols,
+				Data: [][]interface{}{
+					{now, 1.405614e+06, "prometheus", "go_gc_duration_seconds_count", ts.URL},
+				},
+			},
+			{
+				KeyCols: []string{"_measurement", "_field"},
+				ColMeta: cols,
+				Data: [][]interface{}{
+					{now, 53.148989515, "prometheus", "go_gc_duration_seconds_sum", ts.URL},
+				},
+			},
+			{
+				KeyCols: []string{"_measurement", "_field"},
+				ColMeta: append(cols[:len(cols):len(cols)], flux.ColMeta{Label: "quantile", Type: flux.TFloat}),
+				Data: [][]interface{}{
+					{now, 1.5819e-05, "prometheus", "go_gc_duration_seconds", ts.URL, 0.0},
+					{now, 3.2e-05, "prometheus", "go_gc_duration_seconds", ts.URL, 0.5},
+				},
+			},
+		},
+	}
+
+	err := executetest.EqualResult(wantResults, results)
+
+	if err != nil {
+		t.Fatal(err, wantResults.Tables(), results.Tables())
+	}
+}
+
 // testSourceDecoder will mimic the SourceDecoder interface for testing purposes.
 func testSourceDecoder(p *PrometheusIterator, t *testing.T) *executetest.Result {
 	results := &executetest.Result{}
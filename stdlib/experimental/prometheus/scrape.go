@@ -3,13 +3,16 @@ package prometheus
 import (
 	// Go stdlib and other packages
 
+	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"math"
 	"mime"
 	"net/http"
 	"net/url"
+	"sort"
 	"time"
 
 	"github.com/influxdata/flux/runtime"
@@ -30,8 +33,17 @@ import (
 
 const ScrapePrometheusKind = "scrapePrometheus"
 
+const (
+	// FlatMode emits every histogram bucket and summary quantile as its own table.
+	FlatMode = "flat"
+	// StructuredMode emits a single table for each histogram or summary series
+	// with the bucket bounds or quantiles stored in a float column.
+	StructuredMode = "structured"
+)
+
 type ScrapePrometheusOpSpec struct {
-	URL string `json:"token,omitempty"`
+	URL  string `json:"token,omitempty"`
+	Mode string `json:"mode,omitempty"`
 }
 
 func init() {
@@ -49,6 +61,17 @@ func createScrapePrometheusOpSpec(args flux.Arguments, administration *flux.Admi
 	} else {
 		spec.URL = url
 	}
+
+	if mode, ok, err := args.GetString("mode"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Mode = mode
+	} else {
+		spec.Mode = FlatMode
+	}
+	if spec.Mode != FlatMode && spec.Mode != StructuredMode {
+		return nil, errors.Newf(codes.Invalid, "invalid mode %q, must be one of %q or %q", spec.Mode, FlatMode, StructuredMode)
+	}
 	return spec, nil
 }
 
@@ -58,7 +81,8 @@ func (s *ScrapePrometheusOpSpec) Kind() flux.OperationKind {
 
 type ScrapePrometheusProcedureSpec struct {
 	plan.DefaultCost
-	URL string
+	URL  string
+	Mode string
 }
 
 func newScrapePrometheusProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
@@ -68,7 +92,8 @@ func newScrapePrometheusProcedure(qs flux.OperationSpec, pa plan.Administration)
 	}
 
 	return &ScrapePrometheusProcedureSpec{
-		URL: spec.URL,
+		URL:  spec.URL,
+		Mode: spec.Mode,
 	}, nil
 }
 
@@ -79,6 +104,7 @@ func (s *ScrapePrometheusProcedureSpec) Kind() plan.ProcedureKind {
 func (s *ScrapePrometheusProcedureSpec) Copy() plan.ProcedureSpec {
 	ns := new(ScrapePrometheusProcedureSpec)
 	ns.URL = s.URL
+	ns.Mode = s.Mode
 	return ns
 }
 
@@ -120,6 +146,25 @@ type Metric struct {
 	TypeVal   map[string]interface{} // key is metric type; val is metric value
 	Timestamp time.Time
 	Type      string // Prometheus metric type
+
+	// BoundLabel and Buckets are set for metrics decoded in structured mode.
+	// BoundLabel is the name of the column that stores the bucket bounds.
+	BoundLabel string
+	Buckets    []Bucket
+}
+
+// Bucket stores a single histogram bucket or summary quantile of a structured metric.
+type Bucket struct {
+	Bound    float64 // upper bound of a histogram bucket or the quantile of a summary
+	Value    float64
+	Exemplar *Exemplar
+}
+
+// Exemplar stores an OpenMetrics exemplar attached to a histogram bucket.
+type Exemplar struct {
+	Labels    map[string]string
+	Value     float64
+	Timestamp *time.Time
 }
 
 // This implementation of Connect takes in a user defined url, validates the url
@@ -141,7 +186,7 @@ func (p *PrometheusIterator) Connect(ctx context.Context) error {
 
 	// Validate url
 	deps := flux.GetDependencies(ctx)
-	validator, err := deps.URLValidator()
+	validator, err := flux.PackageURLValidator(deps, "experimental/prometheus")
 	if err != nil {
 		return err
 	}
@@ -178,6 +223,7 @@ func (p *PrometheusIterator) parse(reader io.Reader, header http.Header) (err er
 	}
 
 	metricFamilies := make(map[string]*dto.MetricFamily)
+	var exemplars map[string]Exemplar
 	if mediatype == "application/vnd.google.protobuf" &&
 		params["encoding"] == "delimited" &&
 		params["proto"] == "io.prometheus.client.MetricFamily" {
@@ -192,12 +238,23 @@ func (p *PrometheusIterator) parse(reader io.Reader, header http.Header) (err er
 			metricFamilies[mf.GetName()] = mf
 		}
 	} else {
-		metricFamilies, err = parser.TextToMetricFamilies(reader)
+		// The text parser does not understand OpenMetrics exemplars
+		// so they are removed from the body before it is parsed.
+		body, err := ioutil.ReadAll(reader)
+		if err != nil {
+			return err
+		}
+		body, exemplars, err = extractExemplars(body)
+		if err != nil {
+			return err
+		}
+		metricFamilies, err = parser.TextToMetricFamilies(bytes.NewReader(body))
 		if err != nil {
 			return errors.Newf(codes.Internal, "reading text format failed: %s", err)
 		}
 	}
 	p.metrics = make([]Metric, 0)
+	structured := p.spec.Mode == StructuredMode
 
 	// Read metrics
 	for field, family := range metricFamilies {
@@ -208,12 +265,12 @@ func (p *PrometheusIterator) parse(reader io.Reader, header http.Header) (err er
 
 			// Metric Type: Summary
 			case dto.MetricType_SUMMARY:
-				makeMetrics := p.makeQuantiles(metr, tags, field, family.GetType())
+				makeMetrics := p.makeQuantiles(metr, tags, field, family.GetType(), structured)
 				p.metrics = append(p.metrics, makeMetrics...)
 
 			// Metric Type: Histogram
 			case dto.MetricType_HISTOGRAM:
-				makeMetrics := p.makeBuckets(metr, tags, field, family.GetType())
+				makeMetrics := p.makeBuckets(metr, tags, field, family.GetType(), structured, exemplars)
 				p.metrics = append(p.metrics, makeMetrics...)
 
 			// Metric Type: Gague, Counter, Untyped
@@ -260,22 +317,141 @@ func (p *PrometheusIterator) Fetch(ctx context.Context) (bool, error) {
 func (p *PrometheusIterator) Decode(ctx context.Context) (table flux.Table, err error) {
 	met := p.metrics[p.i]
 
+	// Grab the next metric in list
+	p.i++
+
+	if met.BoundLabel != "" {
+		return p.decodeBuckets(met)
+	}
+
 	// Unpacking TypeVal map
 	var val interface{}
 	for _, v := range met.TypeVal {
 		val = v
 	}
 
+	builder, err := p.newTableBuilder(met)
+	if err != nil {
+		return nil, err
+	}
+
+	builder.AppendTime(0, values.ConvertTime(met.Timestamp))
+	builder.AppendValue(1, values.New(val))
+	builder.AppendValue(2, values.New("prometheus"))
+	builder.AppendValue(3, values.New(met.Field))
+	builder.AppendValue(4, values.New(p.url))
+
+	// Add tag values
+	for name, tagVal := range met.Tags {
+		builder.AppendValue(execute.ColIdx(name, builder.Cols()), values.New(tagVal))
+	}
+
+	return builder.Table()
+}
+
+// decodeBuckets creates a single table that contains a row for each bucket of a structured metric.
+// Exemplar columns are only added when at least one of the buckets has an exemplar.
+func (p *PrometheusIterator) decodeBuckets(met Metric) (flux.Table, error) {
+	builder, err := p.newTableBuilder(met)
+	if err != nil {
+		return nil, err
+	}
+	boundIdx, err := builder.AddCol(flux.ColMeta{
+		Label: met.BoundLabel,
+		Type:  flux.TFloat,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var exemplarLabels []string
+	hasExemplars := false
+	for _, b := range met.Buckets {
+		if b.Exemplar == nil {
+			continue
+		}
+		hasExemplars = true
+		for name := range b.Exemplar.Labels {
+			exemplarLabels = appendUnique(exemplarLabels, name)
+		}
+	}
+	sort.Strings(exemplarLabels)
+
+	var exemplarValueIdx, exemplarTimeIdx int
+	exemplarLabelIdx := make(map[string]int, len(exemplarLabels))
+	if hasExemplars {
+		if exemplarValueIdx, err = builder.AddCol(flux.ColMeta{Label: "exemplar_value", Type: flux.TFloat}); err != nil {
+			return nil, err
+		}
+		if exemplarTimeIdx, err = builder.AddCol(flux.ColMeta{Label: "exemplar_time", Type: flux.TTime}); err != nil {
+			return nil, err
+		}
+		for _, name := range exemplarLabels {
+			idx, err := builder.AddCol(flux.ColMeta{Label: "exemplar_" + name, Type: flux.TString})
+			if err != nil {
+				return nil, err
+			}
+			exemplarLabelIdx[name] = idx
+		}
+	}
+
+	for _, b := range met.Buckets {
+		builder.AppendTime(0, values.ConvertTime(met.Timestamp))
+		builder.AppendValue(1, values.New(b.Value))
+		builder.AppendValue(2, values.New("prometheus"))
+		builder.AppendValue(3, values.New(met.Field))
+		builder.AppendValue(4, values.New(p.url))
+		for name, tagVal := range met.Tags {
+			builder.AppendValue(execute.ColIdx(name, builder.Cols()), values.New(tagVal))
+		}
+		builder.AppendValue(boundIdx, values.New(b.Bound))
+		if !hasExemplars {
+			continue
+		}
+
+		if b.Exemplar == nil {
+			builder.AppendNil(exemplarValueIdx)
+			builder.AppendNil(exemplarTimeIdx)
+			for _, idx := range exemplarLabelIdx {
+				builder.AppendNil(idx)
+			}
+			continue
+		}
+		builder.AppendValue(exemplarValueIdx, values.New(b.Exemplar.Value))
+		if b.Exemplar.Timestamp != nil {
+			builder.AppendTime(exemplarTimeIdx, values.ConvertTime(*b.Exemplar.Timestamp))
+		} else {
+			builder.AppendNil(exemplarTimeIdx)
+		}
+		for name, idx := range exemplarLabelIdx {
+			if v, ok := b.Exemplar.Labels[name]; ok {
+				builder.AppendValue(idx, values.New(v))
+			} else {
+				builder.AppendNil(idx)
+			}
+		}
+	}
+	return builder.Table()
+}
+
+// newTableBuilder creates a table builder with the group key and the columns shared by all metrics.
+func (p *PrometheusIterator) newTableBuilder(met Metric) (*execute.ColListTableBuilder, error) {
+	tagNames := make([]string, 0, len(met.Tags))
+	for name := range met.Tags {
+		tagNames = append(tagNames, name)
+	}
+	sort.Strings(tagNames)
+
 	groupKey := execute.NewGroupKeyBuilder(nil)
 	groupKey.AddKeyValue("_measurement", values.New("prometheus"))
 	groupKey.AddKeyValue("_field", values.New(met.Field))
 
 	// Add all tag names to Group Key
 	gkInt := 2
-	for name, val := range met.Tags {
+	for _, name := range tagNames {
 		gkInt++
 		if groupKey.Len() < gkInt {
-			groupKey.AddKeyValue(name, values.New(val))
+			groupKey.AddKeyValue(name, values.New(met.Tags[name]))
 		}
 	}
 
@@ -308,7 +484,7 @@ func (p *PrometheusIterator) Decode(ctx context.Context) (table flux.Table, err
 	})
 
 	// Add all tags to Col list
-	for name := range met.Tags {
+	for _, name := range tagNames {
 		if execute.ColIdx(name, builder.Cols()) == -1 {
 			builder.AddCol(flux.ColMeta{
 				Label: name,
@@ -316,22 +492,16 @@ func (p *PrometheusIterator) Decode(ctx context.Context) (table flux.Table, err
 			})
 		}
 	}
+	return builder, nil
+}
 
-	builder.AppendTime(0, values.ConvertTime(met.Timestamp))
-	builder.AppendValue(1, values.New(val))
-	builder.AppendValue(2, values.New("prometheus"))
-	builder.AppendValue(3, values.New(met.Field))
-	builder.AppendValue(4, values.New(p.url))
-
-	// Add tag values
-	for name, tagVal := range met.Tags {
-		builder.AppendValue(execute.ColIdx(name, builder.Cols()), values.New(tagVal))
+func appendUnique(list []string, s string) []string {
+	for _, v := range list {
+		if v == s {
+			return list
+		}
 	}
-
-	// Grab the next metric in list
-	p.i++
-
-	return builder.Table()
+	return append(list, s)
 }
 
 func (p *PrometheusIterator) Close() error {
@@ -339,40 +509,64 @@ func (p *PrometheusIterator) Close() error {
 	return nil
 }
 
-// makeBuckets will return a list of summary values of type Metric given the prometheus metric, tags,
-// name and metric type
-func (p *PrometheusIterator) makeQuantiles(m *dto.Metric, tags map[string]string, metricName string, metricType dto.MetricType) []Metric {
-	var metrics []Metric
-	typeValue := make(map[string]interface{})
-	var t time.Time
+// metricTime returns the timestamp of the metric or the scrape time if the metric has no timestamp.
+func (p *PrometheusIterator) metricTime(m *dto.Metric) time.Time {
 	if m.TimestampMs != nil && *m.TimestampMs > 0 {
-		t = time.Unix(0, *m.TimestampMs*int64(time.Millisecond))
-	} else {
-		t = p.now
+		return time.Unix(0, *m.TimestampMs*int64(time.Millisecond))
 	}
+	return p.now
+}
 
+// makeCountAndSum will return the count and sum values of a summary or histogram
+func makeCountAndSum(t time.Time, tags map[string]string, metricName, metricType string, count, sum float64) []Metric {
 	countName := metricName + "_count"
-	typeValue[countName] = float64(m.GetSummary().GetSampleCount())
 	countMet := Metric{
 		Timestamp: t,
 		Tags:      tags,
-		TypeVal:   typeValue,
+		TypeVal:   map[string]interface{}{countName: count},
 		Field:     countName,
-		Type:      "summary",
+		Type:      metricType,
 	}
 
-	// Clear map for sum values
-	typeValue = make(map[string]interface{})
 	sumName := metricName + "_sum"
-	typeValue[sumName] = float64(m.GetSummary().GetSampleSum())
 	sumMet := Metric{
 		Timestamp: t,
 		Tags:      tags,
-		TypeVal:   typeValue,
+		TypeVal:   map[string]interface{}{sumName: sum},
 		Field:     sumName,
-		Type:      "summary",
+		Type:      metricType,
+	}
+	return []Metric{countMet, sumMet}
+}
+
+// makeQuantiles will return a list of summary values of type Metric given the prometheus metric, tags,
+// name and metric type. In structured mode all quantiles are returned in a single Metric.
+func (p *PrometheusIterator) makeQuantiles(m *dto.Metric, tags map[string]string, metricName string, metricType dto.MetricType, structured bool) []Metric {
+	t := p.metricTime(m)
+	metrics := makeCountAndSum(t, tags, metricName, "summary",
+		float64(m.GetSummary().GetSampleCount()), m.GetSummary().GetSampleSum())
+
+	if structured {
+		met := Metric{
+			Timestamp:  t,
+			Tags:       tags,
+			Field:      metricName,
+			Type:       "summary",
+			BoundLabel: "quantile",
+		}
+		for _, q := range m.GetSummary().Quantile {
+			if !math.IsNaN(q.GetValue()) {
+				met.Buckets = append(met.Buckets, Bucket{
+					Bound: q.GetQuantile(),
+					Value: q.GetValue(),
+				})
+			}
+		}
+		if len(met.Buckets) > 0 {
+			metrics = append(metrics, met)
+		}
+		return metrics
 	}
-	metrics = append(metrics, countMet, sumMet)
 
 	for _, q := range m.GetSummary().Quantile {
 		newTags := make(map[string]string)
@@ -380,14 +574,12 @@ func (p *PrometheusIterator) makeQuantiles(m *dto.Metric, tags map[string]string
 			newTags[k] = v
 		}
 
-		typeValue = make(map[string]interface{})
 		if !math.IsNaN(q.GetValue()) {
 			newTags["quantile"] = fmt.Sprint(q.GetQuantile())
-			typeValue[metricName] = float64(q.GetValue())
 			met := Metric{
 				Timestamp: t,
 				Tags:      newTags,
-				TypeVal:   typeValue,
+				TypeVal:   map[string]interface{}{metricName: q.GetValue()},
 				Field:     metricName,
 				Type:      "summary",
 			}
@@ -397,41 +589,37 @@ func (p *PrometheusIterator) makeQuantiles(m *dto.Metric, tags map[string]string
 	return metrics
 }
 
-// makeBuckets will return a list of histogram values of type Metric given the prometheus metric, taags,
-// name and metric type
-func (p *PrometheusIterator) makeBuckets(m *dto.Metric, tags map[string]string, metricName string, metricType dto.MetricType) []Metric {
-	var metrics []Metric
-	typeValue := make(map[string]interface{})
-
-	var t time.Time
-	if m.TimestampMs != nil && *m.TimestampMs > 0 {
-		t = time.Unix(0, *m.TimestampMs*int64(time.Millisecond))
-	} else {
-		t = p.now
-	}
+// makeBuckets will return a list of histogram values of type Metric given the prometheus metric, tags,
+// name and metric type. In structured mode all buckets are returned in a single Metric
+// together with the exemplars that were found for them.
+func (p *PrometheusIterator) makeBuckets(m *dto.Metric, tags map[string]string, metricName string, metricType dto.MetricType, structured bool, exemplars map[string]Exemplar) []Metric {
+	t := p.metricTime(m)
+	metrics := makeCountAndSum(t, tags, metricName, "histogram",
+		float64(m.GetHistogram().GetSampleCount()), m.GetHistogram().GetSampleSum())
 
-	countName := metricName + "_count"
-	typeValue[countName] = float64(m.GetHistogram().GetSampleCount())
-	countMet := Metric{
-		Timestamp: t,
-		Tags:      tags,
-		TypeVal:   typeValue,
-		Field:     countName,
-		Type:      "histogram",
-	}
-
-	typeValue[metricName+"_sum"] = float64(m.GetHistogram().GetSampleSum())
-
-	sumName := metricName + "_sum"
-	typeValue[sumName] = float64(m.GetSummary().GetSampleSum())
-	sumMet := Metric{
-		Timestamp: t,
-		Tags:      tags,
-		TypeVal:   typeValue,
-		Field:     sumName,
-		Type:      "histogram",
+	if structured {
+		met := Metric{
+			Timestamp:  t,
+			Tags:       tags,
+			Field:      metricName,
+			Type:       "histogram",
+			BoundLabel: "le",
+		}
+		for _, b := range m.GetHistogram().Bucket {
+			bucket := Bucket{
+				Bound: b.GetUpperBound(),
+				Value: float64(b.GetCumulativeCount()),
+			}
+			if e, ok := exemplars[bucketKey(metricName, tags, b.GetUpperBound())]; ok {
+				bucket.Exemplar = &e
+			}
+			met.Buckets = append(met.Buckets, bucket)
+		}
+		if len(met.Buckets) > 0 {
+			metrics = append(metrics, met)
+		}
+		return metrics
 	}
-	metrics = append(metrics, countMet, sumMet)
 
 	for _, b := range m.GetHistogram().Bucket {
 		newTags := make(map[string]string)
@@ -439,14 +627,11 @@ func (p *PrometheusIterator) makeBuckets(m *dto.Metric, tags map[string]string,
 			newTags[k] = v
 		}
 
-		typeValue = make(map[string]interface{})
 		newTags["le"] = fmt.Sprint(b.GetUpperBound())
-		typeValue[metricName] = float64(b.GetCumulativeCount())
-
 		met := Metric{
 			Timestamp: t,
 			Tags:      newTags,
-			TypeVal:   typeValue,
+			TypeVal:   map[string]interface{}{metricName: float64(b.GetCumulativeCount())},
 			Field:     metricName,
 			Type:      "histogram",
 		}
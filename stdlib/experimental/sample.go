@@ -0,0 +1,364 @@
+package experimental
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/apache/arrow/go/v7/arrow/bitutil"
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/arrowutil"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/runtime"
+	"github.com/influxdata/flux/values"
+)
+
+const (
+	BernoulliSampleKind = "experimental.bernoulliSample"
+	ReservoirSampleKind = "experimental.reservoirSample"
+)
+
+type BernoulliSampleOpSpec struct {
+	Probability float64 `json:"probability"`
+	Seed        *int64  `json:"seed,omitempty"`
+}
+
+type ReservoirSampleOpSpec struct {
+	N    int64  `json:"n"`
+	Seed *int64 `json:"seed,omitempty"`
+}
+
+func init() {
+	bernoulliSampleSignature := runtime.MustLookupBuiltinType("experimental", "bernoulliSample")
+	runtime.RegisterPackageValue("experimental", "bernoulliSample", flux.MustValue(flux.FunctionValue(BernoulliSampleKind, createBernoulliSampleOpSpec, bernoulliSampleSignature)))
+	plan.RegisterProcedureSpec(BernoulliSampleKind, newBernoulliSampleProcedure, BernoulliSampleKind)
+	execute.RegisterTransformation(BernoulliSampleKind, createBernoulliSampleTransformation)
+
+	reservoirSampleSignature := runtime.MustLookupBuiltinType("experimental", "reservoirSample")
+	runtime.RegisterPackageValue("experimental", "reservoirSample", flux.MustValue(flux.FunctionValue(ReservoirSampleKind, createReservoirSampleOpSpec, reservoirSampleSignature)))
+	plan.RegisterProcedureSpec(ReservoirSampleKind, newReservoirSampleProcedure, ReservoirSampleKind)
+	execute.RegisterTransformation(ReservoirSampleKind, createReservoirSampleTransformation)
+}
+
+func readSeed(args flux.Arguments) (*int64, error) {
+	if seed, ok, err := args.GetInt("seed"); err != nil {
+		return nil, err
+	} else if ok {
+		return &seed, nil
+	}
+	return nil, nil
+}
+
+func createBernoulliSampleOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(BernoulliSampleOpSpec)
+	p, err := args.GetRequiredFloat("probability")
+	if err != nil {
+		return nil, err
+	} else if p < 0 || p > 1 {
+		return nil, errors.Newf(codes.Invalid, "probability must be between 0 and 1, but was %g", p)
+	}
+	spec.Probability = p
+
+	if spec.Seed, err = readSeed(args); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func (s *BernoulliSampleOpSpec) Kind() flux.OperationKind {
+	return BernoulliSampleKind
+}
+
+func createReservoirSampleOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(ReservoirSampleOpSpec)
+	n, err := args.GetRequiredInt("n")
+	if err != nil {
+		return nil, err
+	} else if n <= 0 {
+		return nil, errors.Newf(codes.Invalid, "n must be a positive integer, but was %d", n)
+	}
+	spec.N = n
+
+	if spec.Seed, err = readSeed(args); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func (s *ReservoirSampleOpSpec) Kind() flux.OperationKind {
+	return ReservoirSampleKind
+}
+
+type BernoulliSampleProcedureSpec struct {
+	plan.DefaultCost
+	Probability float64
+	Seed        *int64
+}
+
+func newBernoulliSampleProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*BernoulliSampleOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+	return &BernoulliSampleProcedureSpec{
+		Probability: spec.Probability,
+		Seed:        spec.Seed,
+	}, nil
+}
+
+func (s *BernoulliSampleProcedureSpec) Kind() plan.ProcedureKind {
+	return BernoulliSampleKind
+}
+
+func (s *BernoulliSampleProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+// TriggerSpec implements plan.TriggerAwareProcedureSpec
+func (s *BernoulliSampleProcedureSpec) TriggerSpec() plan.TriggerSpec {
+	return plan.NarrowTransformationTriggerSpec{}
+}
+
+type ReservoirSampleProcedureSpec struct {
+	plan.DefaultCost
+	N    int64
+	Seed *int64
+}
+
+func newReservoirSampleProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*ReservoirSampleOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+	return &ReservoirSampleProcedureSpec{
+		N:    spec.N,
+		Seed: spec.Seed,
+	}, nil
+}
+
+func (s *ReservoirSampleProcedureSpec) Kind() plan.ProcedureKind {
+	return ReservoirSampleKind
+}
+
+func (s *ReservoirSampleProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+func createBernoulliSampleTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*BernoulliSampleProcedureSpec)
+	if !ok {
+		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
+	}
+	return NewBernoulliSampleTransformation(id, s, a.Allocator())
+}
+
+func createReservoirSampleTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*ReservoirSampleProcedureSpec)
+	if !ok {
+		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
+	}
+	return NewReservoirSampleTransformation(id, s, a.Allocator())
+}
+
+// sampleRand creates the random number generator of a group.
+//
+// With a seed, each group has its own generator derived from the seed
+// and the group key, so the sample of a group does not depend on the
+// order that the tables arrive in.
+type sampleRand struct {
+	seed *int64
+	rand *rand.Rand
+}
+
+func newSampleRand(seed *int64) sampleRand {
+	s := sampleRand{seed: seed}
+	if seed == nil {
+		s.rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return s
+}
+
+func (s sampleRand) forKey(key flux.GroupKey) *rand.Rand {
+	if s.seed == nil {
+		return s.rand
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key.String()))
+	return rand.New(rand.NewSource(*s.seed ^ int64(h.Sum64())))
+}
+
+type bernoulliSampleTransformation struct {
+	probability float64
+	rand        sampleRand
+}
+
+// NewBernoulliSampleTransformation creates a transformation that keeps
+// each row independently with the probability of the spec.
+func NewBernoulliSampleTransformation(id execute.DatasetID, spec *BernoulliSampleProcedureSpec, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	tr := &bernoulliSampleTransformation{
+		probability: spec.Probability,
+		rand:        newSampleRand(spec.Seed),
+	}
+	return execute.NewNarrowStateTransformation[*rand.Rand](id, tr, mem)
+}
+
+func (t *bernoulliSampleTransformation) Process(chunk table.Chunk, state *rand.Rand, d *execute.TransportDataset, mem memory.Allocator) (*rand.Rand, bool, error) {
+	if state == nil {
+		state = t.rand.forKey(chunk.Key())
+	}
+
+	n := chunk.Len()
+	bitset := memory.NewResizableBuffer(mem)
+	bitset.Resize(n)
+	defer bitset.Release()
+
+	selected := 0
+	for i := 0; i < n; i++ {
+		keep := state.Float64() < t.probability
+		if keep {
+			selected++
+		}
+		bitutil.SetBitTo(bitset.Buf(), i, keep)
+	}
+
+	vs := make([]array.Array, chunk.NCols())
+	for j, col := range chunk.Cols() {
+		arr := chunk.Values(j)
+		if chunk.Key().HasCol(col.Label) {
+			vs[j] = arrow.Slice(arr, 0, int64(selected))
+			continue
+		}
+		vs[j] = arrowutil.Filter(arr, bitset.Bytes(), mem)
+	}
+	out := table.ChunkFromBuffer(arrow.TableBuffer{
+		GroupKey: chunk.Key(),
+		Columns:  chunk.Cols(),
+		Values:   vs,
+	})
+	if err := d.Process(out); err != nil {
+		return nil, false, err
+	}
+	return state, true, nil
+}
+
+func (t *bernoulliSampleTransformation) Close() error {
+	return nil
+}
+
+type reservoirSampleTransformation struct {
+	n    int
+	rand sampleRand
+}
+
+// NewReservoirSampleTransformation creates a transformation that keeps
+// a uniform random sample of at most n rows from each group.
+func NewReservoirSampleTransformation(id execute.DatasetID, spec *ReservoirSampleProcedureSpec, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	tr := &reservoirSampleTransformation{
+		n:    int(spec.N),
+		rand: newSampleRand(spec.Seed),
+	}
+	return execute.NewAggregateTransformation(id, tr, mem)
+}
+
+// reservoirSampleState is the reservoir of a group.
+type reservoirSampleState struct {
+	rand *rand.Rand
+	cols []flux.ColMeta
+	// rows are the sampled rows with their values in column order.
+	rows []reservoirRow
+	// seen is the number of rows seen in the group.
+	seen int
+}
+
+type reservoirRow struct {
+	// index is the position of the row in the group,
+	// so rows are sent in the order they arrived.
+	index  int
+	values []values.Value
+}
+
+func (t *reservoirSampleTransformation) Aggregate(chunk table.Chunk, state interface{}, mem memory.Allocator) (interface{}, bool, error) {
+	var s *reservoirSampleState
+	if state != nil {
+		s = state.(*reservoirSampleState)
+	} else {
+		s = &reservoirSampleState{
+			rand: t.rand.forKey(chunk.Key()),
+			cols: chunk.Cols(),
+			rows: make([]reservoirRow, 0, t.n),
+		}
+	}
+
+	buffer := chunk.Buffer()
+	for i, l := 0, chunk.Len(); i < l; i++ {
+		pos := len(s.rows)
+		if s.seen >= t.n {
+			// Replace a sampled row with the probability n/seen.
+			pos = s.rand.Intn(s.seen + 1)
+		}
+		s.seen++
+		if pos >= t.n {
+			continue
+		}
+
+		row := reservoirRow{
+			index:  s.seen - 1,
+			values: make([]values.Value, len(s.cols)),
+		}
+		for j := range s.cols {
+			row.values[j] = execute.ValueForRow(&buffer, i, j)
+		}
+		if pos == len(s.rows) {
+			s.rows = append(s.rows, row)
+		} else {
+			s.rows[pos] = row
+		}
+	}
+	return s, true, nil
+}
+
+func (t *reservoirSampleTransformation) Compute(key flux.GroupKey, state interface{}, d *execute.TransportDataset, mem memory.Allocator) error {
+	s := state.(*reservoirSampleState)
+	sort.Slice(s.rows, func(i, j int) bool {
+		return s.rows[i].index < s.rows[j].index
+	})
+
+	vs := make([]array.Array, len(s.cols))
+	for j, col := range s.cols {
+		b := arrow.NewBuilder(col.Type, mem)
+		b.Resize(len(s.rows))
+		for _, row := range s.rows {
+			if err := arrow.AppendValue(b, row.values[j]); err != nil {
+				return err
+			}
+		}
+		vs[j] = b.NewArray()
+	}
+	return d.Process(table.ChunkFromBuffer(arrow.TableBuffer{
+		GroupKey: key,
+		Columns:  s.cols,
+		Values:   vs,
+	}))
+}
+
+func (t *reservoirSampleTransformation) Close() error {
+	return nil
+}
@@ -0,0 +1,118 @@
+package sample
+
+import (
+	"math"
+
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/plan"
+)
+
+type LTTBOpSpec struct {
+	DownsampleSpec
+}
+
+func createLTTBOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(LTTBOpSpec)
+	// The first and last points are always selected
+	// so at least one more point is needed to downsample.
+	if err := spec.readArgs(args, 3); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func (s *LTTBOpSpec) Kind() flux.OperationKind {
+	return LTTBKind
+}
+
+type LTTBProcedureSpec struct {
+	plan.DefaultCost
+	DownsampleSpec
+}
+
+func newLTTBProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*LTTBOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+	return &LTTBProcedureSpec{
+		DownsampleSpec: spec.DownsampleSpec,
+	}, nil
+}
+
+func (s *LTTBProcedureSpec) Kind() plan.ProcedureKind {
+	return LTTBKind
+}
+
+func (s *LTTBProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+func createLTTBTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*LTTBProcedureSpec)
+	if !ok {
+		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
+	}
+	return NewLTTBTransformation(id, s, a.Allocator())
+}
+
+// NewLTTBTransformation constructs a transformation that downsamples each
+// table to at most n rows using the largest-triangle-three-buckets algorithm.
+func NewLTTBTransformation(id execute.DatasetID, spec *LTTBProcedureSpec, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	return newDownsampleTransformation(id, spec.DownsampleSpec, lttb, mem)
+}
+
+// lttb selects n points using the largest-triangle-three-buckets algorithm.
+//
+// The first and last points are always selected. The remaining points are
+// divided into n-2 buckets and, from each bucket, the point that forms the
+// largest triangle with the previously selected point and the average of the
+// next bucket is selected.
+func lttb(xs, ys []float64, n int) []int {
+	l := len(xs)
+	indices := make([]int, 0, n)
+	indices = append(indices, 0)
+
+	every := float64(l-2) / float64(n-2)
+	a := 0
+	for i := 0; i < n-2; i++ {
+		// Compute the average point of the next bucket.
+		avgStart := int(math.Floor(float64(i+1)*every)) + 1
+		avgStop := int(math.Floor(float64(i+2)*every)) + 1
+		if avgStop > l {
+			avgStop = l
+		}
+		var avgX, avgY float64
+		for j := avgStart; j < avgStop; j++ {
+			avgX += xs[j]
+			avgY += ys[j]
+		}
+		if count := float64(avgStop - avgStart); count > 0 {
+			avgX /= count
+			avgY /= count
+		}
+
+		// Select the point in this bucket with the largest triangle area.
+		start := int(math.Floor(float64(i)*every)) + 1
+		stop := int(math.Floor(float64(i+1)*every)) + 1
+		maxArea, next := -1.0, start
+		for j := start; j < stop; j++ {
+			area := math.Abs((xs[a]-avgX)*(ys[j]-ys[a]) - (xs[a]-xs[j])*(avgY-ys[a]))
+			if area > maxArea {
+				maxArea, next = area, j
+			}
+		}
+		indices = append(indices, next)
+		a = next
+	}
+	return append(indices, l-1)
+}
@@ -0,0 +1,104 @@
+package sample
+
+import (
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/plan"
+)
+
+type MinMaxOpSpec struct {
+	DownsampleSpec
+}
+
+func createMinMaxOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(MinMaxOpSpec)
+	// Each bucket selects a minimum and a maximum
+	// so at least two points are needed.
+	if err := spec.readArgs(args, 2); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func (s *MinMaxOpSpec) Kind() flux.OperationKind {
+	return MinMaxKind
+}
+
+type MinMaxProcedureSpec struct {
+	plan.DefaultCost
+	DownsampleSpec
+}
+
+func newMinMaxProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*MinMaxOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+	return &MinMaxProcedureSpec{
+		DownsampleSpec: spec.DownsampleSpec,
+	}, nil
+}
+
+func (s *MinMaxProcedureSpec) Kind() plan.ProcedureKind {
+	return MinMaxKind
+}
+
+func (s *MinMaxProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+func createMinMaxTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*MinMaxProcedureSpec)
+	if !ok {
+		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
+	}
+	return NewMinMaxTransformation(id, s, a.Allocator())
+}
+
+// NewMinMaxTransformation constructs a transformation that downsamples each
+// table to at most n rows by selecting the minimum and maximum of each bucket.
+func NewMinMaxTransformation(id execute.DatasetID, spec *MinMaxProcedureSpec, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	return newDownsampleTransformation(id, spec.DownsampleSpec, minMax, mem)
+}
+
+// minMax divides the points into n/2 buckets with an equal number
+// of points and selects the points with the minimum and maximum
+// value from each bucket in the order they appear.
+func minMax(xs, ys []float64, n int) []int {
+	l, buckets := len(ys), n/2
+	indices := make([]int, 0, buckets*2)
+	for i := 0; i < buckets; i++ {
+		start, stop := i*l/buckets, (i+1)*l/buckets
+		if start == stop {
+			continue
+		}
+
+		lo, hi := start, start
+		for j := start + 1; j < stop; j++ {
+			if ys[j] < ys[lo] {
+				lo = j
+			}
+			if ys[j] > ys[hi] {
+				hi = j
+			}
+		}
+
+		switch {
+		case lo == hi:
+			indices = append(indices, lo)
+		case lo < hi:
+			indices = append(indices, lo, hi)
+		default:
+			indices = append(indices, hi, lo)
+		}
+	}
+	return indices
+}
@@ -0,0 +1,191 @@
+package sample
+
+import (
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/arrowutil"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/runtime"
+)
+
+const pkgpath = "experimental/sample"
+
+const (
+	LTTBKind   = "sampleLTTB"
+	MinMaxKind = "sampleMinMax"
+)
+
+func init() {
+	runtime.RegisterPackageValue(pkgpath, "lttb", flux.MustValue(flux.FunctionValue("lttb", createLTTBOpSpec, runtime.MustLookupBuiltinType(pkgpath, "lttb"))))
+	plan.RegisterProcedureSpec(LTTBKind, newLTTBProcedure, LTTBKind)
+	execute.RegisterTransformation(LTTBKind, createLTTBTransformation)
+
+	runtime.RegisterPackageValue(pkgpath, "minMax", flux.MustValue(flux.FunctionValue("minMax", createMinMaxOpSpec, runtime.MustLookupBuiltinType(pkgpath, "minMax"))))
+	plan.RegisterProcedureSpec(MinMaxKind, newMinMaxProcedure, MinMaxKind)
+	execute.RegisterTransformation(MinMaxKind, createMinMaxTransformation)
+}
+
+// DownsampleSpec holds the arguments shared by the downsampling functions.
+type DownsampleSpec struct {
+	N          int64  `json:"n"`
+	Column     string `json:"column"`
+	TimeColumn string `json:"timeColumn"`
+}
+
+func (s *DownsampleSpec) readArgs(args flux.Arguments, min int64) error {
+	n, err := args.GetRequiredInt("n")
+	if err != nil {
+		return err
+	} else if n < min {
+		return errors.Newf(codes.Invalid, "n must be at least %d, got %d", min, n)
+	}
+	s.N = n
+
+	if col, ok, err := args.GetString("column"); err != nil {
+		return err
+	} else if ok {
+		s.Column = col
+	} else {
+		s.Column = execute.DefaultValueColLabel
+	}
+
+	if col, ok, err := args.GetString("timeColumn"); err != nil {
+		return err
+	} else if ok {
+		s.TimeColumn = col
+	} else {
+		s.TimeColumn = execute.DefaultTimeColLabel
+	}
+	return nil
+}
+
+// selectFunc selects the indices of the points to keep
+// from the points with the x and y coordinates.
+// The selected indices must be in ascending order.
+type selectFunc func(xs, ys []float64, n int) []int
+
+// downsampleTransformation buffers each table and selects
+// a subset of its rows once the entire table has been read.
+type downsampleTransformation struct {
+	spec     DownsampleSpec
+	selectFn selectFunc
+}
+
+func newDownsampleTransformation(id execute.DatasetID, spec DownsampleSpec, fn selectFunc, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	tr := &downsampleTransformation{
+		spec:     spec,
+		selectFn: fn,
+	}
+	return execute.NewAggregateTransformation(id, tr, mem)
+}
+
+type downsampleState struct {
+	chunks []table.Chunk
+}
+
+func (s *downsampleState) Close() error {
+	for _, chunk := range s.chunks {
+		chunk.Release()
+	}
+	s.chunks = nil
+	return nil
+}
+
+func (t *downsampleTransformation) Aggregate(chunk table.Chunk, state interface{}, mem memory.Allocator) (interface{}, bool, error) {
+	s, _ := state.(*downsampleState)
+	if s == nil {
+		s = &downsampleState{}
+	}
+	chunk.Retain()
+	s.chunks = append(s.chunks, chunk)
+	return s, true, nil
+}
+
+// point references a row with a non-null value in a buffered chunk.
+type point struct {
+	chunk, row int
+}
+
+func (t *downsampleTransformation) Compute(key flux.GroupKey, state interface{}, d *execute.TransportDataset, mem memory.Allocator) error {
+	s := state.(*downsampleState)
+
+	var (
+		xs, ys []float64
+		points []point
+	)
+	for ci, chunk := range s.chunks {
+		if chunk.Len() == 0 {
+			continue
+		}
+
+		timeIdx := chunk.Index(t.spec.TimeColumn)
+		if timeIdx < 0 {
+			return errors.Newf(codes.FailedPrecondition, "no column %q exists", t.spec.TimeColumn)
+		} else if want, got := flux.TTime, chunk.Col(timeIdx).Type; want != got {
+			return errors.Newf(codes.FailedPrecondition, "time column %q is type %s and not %s", t.spec.TimeColumn, got, want)
+		}
+		valueIdx := chunk.Index(t.spec.Column)
+		if valueIdx < 0 {
+			return errors.Newf(codes.FailedPrecondition, "no column %q exists", t.spec.Column)
+		}
+
+		ts := chunk.Ints(timeIdx)
+		value, ok := arrowutil.FloatValueFunc(chunk.Values(valueIdx))
+		if !ok {
+			col := chunk.Col(valueIdx)
+			return errors.Newf(codes.FailedPrecondition, "cannot downsample column %q of type %s", col.Label, col.Type)
+		}
+		for i, l := 0, chunk.Len(); i < l; i++ {
+			if ts.IsNull(i) {
+				return errors.New(codes.FailedPrecondition, "downsample found null time in time column")
+			}
+			y, ok := value(i)
+			if !ok {
+				continue
+			}
+			xs = append(xs, float64(ts.Value(i)))
+			ys = append(ys, y)
+			points = append(points, point{chunk: ci, row: i})
+		}
+	}
+
+	// Group the selected rows by the chunk they belong to
+	// so each chunk can be filtered in a single pass.
+	sel := make([][]int, len(s.chunks))
+	for _, idx := range t.selectPoints(xs, ys) {
+		p := points[idx]
+		sel[p.chunk] = append(sel[p.chunk], p.row)
+	}
+
+	for ci, chunk := range s.chunks {
+		// Skip chunks without any selected rows, but always
+		// output the first chunk so an empty table is still
+		// produced when no rows are selected.
+		if len(sel[ci]) == 0 && ci > 0 {
+			continue
+		}
+		buffer := chunk.Buffer()
+		out := table.Filter(&buffer, sel[ci], mem)
+		if err := d.Process(table.ChunkFromBuffer(out)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *downsampleTransformation) selectPoints(xs, ys []float64) []int {
+	if int64(len(xs)) <= t.spec.N {
+		indices := make([]int, len(xs))
+		for i := range indices {
+			indices[i] = i
+		}
+		return indices
+	}
+	return t.selectFn(xs, ys, int(t.spec.N))
+}
+
+func (t *downsampleTransformation) Close() error { return nil }
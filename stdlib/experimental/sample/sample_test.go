@@ -0,0 +1,200 @@
+package sample_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/stdlib/experimental/sample"
+)
+
+var sampleCols = []flux.ColMeta{
+	{Label: "_time", Type: flux.TTime},
+	{Label: "_value", Type: flux.TFloat},
+	{Label: "t0", Type: flux.TString},
+}
+
+func sampleInput() []flux.Table {
+	return []flux.Table{&executetest.Table{
+		KeyCols: []string{"t0"},
+		ColMeta: sampleCols,
+		Data: [][]interface{}{
+			{execute.Time(0), 1.0, "a"},
+			{execute.Time(10), 3.0, "a"},
+			{execute.Time(20), 2.0, "a"},
+			{execute.Time(30), 9.0, "a"},
+			{execute.Time(40), 4.0, "a"},
+			{execute.Time(50), 5.0, "a"},
+			{execute.Time(60), 0.0, "a"},
+			{execute.Time(70), 6.0, "a"},
+			{execute.Time(80), 7.0, "a"},
+			{execute.Time(90), 2.0, "a"},
+		},
+	}}
+}
+
+func TestLTTB(t *testing.T) {
+	testCases := []struct {
+		name    string
+		spec    *sample.LTTBProcedureSpec
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "basic",
+			spec: &sample.LTTBProcedureSpec{
+				DownsampleSpec: sample.DownsampleSpec{
+					N:          5,
+					Column:     execute.DefaultValueColLabel,
+					TimeColumn: execute.DefaultTimeColLabel,
+				},
+			},
+			data: sampleInput(),
+			want: []*executetest.Table{{
+				KeyCols: []string{"t0"},
+				ColMeta: sampleCols,
+				Data: [][]interface{}{
+					{execute.Time(0), 1.0, "a"},
+					{execute.Time(20), 2.0, "a"},
+					{execute.Time(30), 9.0, "a"},
+					{execute.Time(60), 0.0, "a"},
+					{execute.Time(90), 2.0, "a"},
+				},
+			}},
+		},
+		{
+			name: "fewer rows than n",
+			spec: &sample.LTTBProcedureSpec{
+				DownsampleSpec: sample.DownsampleSpec{
+					N:          20,
+					Column:     execute.DefaultValueColLabel,
+					TimeColumn: execute.DefaultTimeColLabel,
+				},
+			},
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"t0"},
+				ColMeta: sampleCols,
+				Data: [][]interface{}{
+					{execute.Time(0), 1.0, "a"},
+					{execute.Time(10), nil, "a"},
+					{execute.Time(20), 2.0, "a"},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"t0"},
+				ColMeta: sampleCols,
+				Data: [][]interface{}{
+					{execute.Time(0), 1.0, "a"},
+					{execute.Time(20), 2.0, "a"},
+				},
+			}},
+		},
+		{
+			name: "unsupported type",
+			spec: &sample.LTTBProcedureSpec{
+				DownsampleSpec: sample.DownsampleSpec{
+					N:          5,
+					Column:     "t0",
+					TimeColumn: execute.DefaultTimeColLabel,
+				},
+			},
+			data:    sampleInput(),
+			wantErr: errors.New(codes.FailedPrecondition, `cannot downsample column "t0" of type string`),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper2(
+				t,
+				tc.data,
+				tc.want,
+				tc.wantErr,
+				func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+					tr, d, err := sample.NewLTTBTransformation(id, tc.spec, alloc)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return tr, d
+				},
+			)
+		})
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	testCases := []struct {
+		name string
+		spec *sample.MinMaxProcedureSpec
+		data []flux.Table
+		want []*executetest.Table
+	}{
+		{
+			name: "two buckets",
+			spec: &sample.MinMaxProcedureSpec{
+				DownsampleSpec: sample.DownsampleSpec{
+					N:          4,
+					Column:     execute.DefaultValueColLabel,
+					TimeColumn: execute.DefaultTimeColLabel,
+				},
+			},
+			data: sampleInput(),
+			want: []*executetest.Table{{
+				KeyCols: []string{"t0"},
+				ColMeta: sampleCols,
+				Data: [][]interface{}{
+					{execute.Time(0), 1.0, "a"},
+					{execute.Time(30), 9.0, "a"},
+					{execute.Time(60), 0.0, "a"},
+					{execute.Time(80), 7.0, "a"},
+				},
+			}},
+		},
+		{
+			name: "three buckets",
+			spec: &sample.MinMaxProcedureSpec{
+				DownsampleSpec: sample.DownsampleSpec{
+					N:          6,
+					Column:     execute.DefaultValueColLabel,
+					TimeColumn: execute.DefaultTimeColLabel,
+				},
+			},
+			data: sampleInput(),
+			want: []*executetest.Table{{
+				KeyCols: []string{"t0"},
+				ColMeta: sampleCols,
+				Data: [][]interface{}{
+					{execute.Time(0), 1.0, "a"},
+					{execute.Time(10), 3.0, "a"},
+					{execute.Time(30), 9.0, "a"},
+					{execute.Time(40), 4.0, "a"},
+					{execute.Time(60), 0.0, "a"},
+					{execute.Time(80), 7.0, "a"},
+				},
+			}},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper2(
+				t,
+				tc.data,
+				tc.want,
+				nil,
+				func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+					tr, d, err := sample.NewMinMaxTransformation(id, tc.spec, alloc)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return tr, d
+				},
+			)
+		})
+	}
+}
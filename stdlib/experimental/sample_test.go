@@ -0,0 +1,177 @@
+package experimental_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/stdlib/experimental"
+)
+
+// sampleTable creates a table with n rows in the group of the tag.
+func sampleTable(tag string, n int) *executetest.Table {
+	tbl := &executetest.Table{
+		KeyCols: []string{"t"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "t", Type: flux.TString},
+			{Label: "_value", Type: flux.TInt},
+		},
+	}
+	for i := 0; i < n; i++ {
+		tbl.Data = append(tbl.Data, []interface{}{execute.Time(i), tag, int64(i)})
+	}
+	return tbl
+}
+
+// runSample processes the tables with the transformation and returns the output tables.
+func runSample(t *testing.T, data []*executetest.Table, create func(id execute.DatasetID, mem memory.Allocator) (execute.Transformation, execute.Dataset, error)) []*executetest.Table {
+	t.Helper()
+	store := executetest.NewDataStore()
+	tx, d, err := create(executetest.RandomDatasetID(), &memory.ResourceAllocator{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.SetTriggerSpec(plan.DefaultTriggerSpec)
+	d.AddTransformation(store)
+
+	parentID := executetest.RandomDatasetID()
+	for _, tbl := range data {
+		// Tables can only be read once, so process a copy
+		// that is sent with one chunk per row.
+		cpy := &executetest.RowWiseTable{
+			Table: &executetest.Table{KeyCols: tbl.KeyCols, ColMeta: tbl.ColMeta, Data: tbl.Data},
+		}
+		if err := tx.Process(parentID, cpy); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tx.Finish(parentID, nil)
+
+	got, err := executetest.TablesFromCache(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tbl := range got {
+		tbl.Normalize()
+	}
+	return got
+}
+
+func bernoulliSample(probability float64, seed *int64) func(id execute.DatasetID, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	return func(id execute.DatasetID, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+		return experimental.NewBernoulliSampleTransformation(id, &experimental.BernoulliSampleProcedureSpec{
+			Probability: probability,
+			Seed:        seed,
+		}, mem)
+	}
+}
+
+func reservoirSample(n int64, seed *int64) func(id execute.DatasetID, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	return func(id execute.DatasetID, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+		return experimental.NewReservoirSampleTransformation(id, &experimental.ReservoirSampleProcedureSpec{
+			N:    n,
+			Seed: seed,
+		}, mem)
+	}
+}
+
+// rowsOf returns the tables of the output by their tag.
+func rowsOf(t *testing.T, tables []*executetest.Table) map[string][][]interface{} {
+	t.Helper()
+	rows := make(map[string][][]interface{}, len(tables))
+	for _, tbl := range tables {
+		rows[tbl.Key().ValueString(0)] = tbl.Data
+	}
+	return rows
+}
+
+// checkInOrder checks that the rows are in input order.
+func checkInOrder(t *testing.T, rows [][]interface{}) {
+	t.Helper()
+	for i := 1; i < len(rows); i++ {
+		if rows[i-1][2].(int64) >= rows[i][2].(int64) {
+			t.Errorf("rows are not in input order: %v", rows)
+			return
+		}
+	}
+}
+
+func TestBernoulliSample_Process(t *testing.T) {
+	seed := int64(42)
+	data := []*executetest.Table{sampleTable("a", 1000), sampleTable("b", 1000)}
+
+	got := runSample(t, data, bernoulliSample(0.5, &seed))
+	rows := rowsOf(t, got)
+	for _, tag := range []string{"a", "b"} {
+		if n := len(rows[tag]); n < 400 || n > 600 {
+			t.Errorf("expected about 500 rows in table %s, got %d", tag, n)
+		}
+		checkInOrder(t, rows[tag])
+	}
+
+	// The same seed selects the same rows regardless of the table order.
+	again := rowsOf(t, runSample(t, []*executetest.Table{data[1], data[0]}, bernoulliSample(0.5, &seed)))
+	if !cmp.Equal(rows, again) {
+		t.Errorf("expected the same sample with the same seed -want/+got\n%s", cmp.Diff(rows, again))
+	}
+
+	other := int64(7)
+	if cmp.Equal(rows, rowsOf(t, runSample(t, data, bernoulliSample(0.5, &other)))) {
+		t.Error("expected a different sample with a different seed")
+	}
+
+	if got := rowsOf(t, runSample(t, data, bernoulliSample(1, nil))); len(got["a"]) != 1000 || len(got["b"]) != 1000 {
+		t.Errorf("expected every row with probability 1, got %d and %d rows", len(got["a"]), len(got["b"]))
+	}
+
+	// Empty tables are kept.
+	got = runSample(t, data, bernoulliSample(0, nil))
+	if len(got) != 2 {
+		t.Fatalf("expected two tables, got %d", len(got))
+	}
+	for _, tbl := range got {
+		if len(tbl.Data) != 0 {
+			t.Errorf("expected no rows with probability 0, got %d", len(tbl.Data))
+		}
+	}
+}
+
+func TestReservoirSample_Process(t *testing.T) {
+	seed := int64(42)
+	data := []*executetest.Table{sampleTable("a", 20), sampleTable("b", 2)}
+
+	got := runSample(t, data, reservoirSample(3, &seed))
+	rows := rowsOf(t, got)
+	if len(rows["a"]) != 3 {
+		t.Errorf("expected three rows in table a, got %v", rows["a"])
+	}
+	checkInOrder(t, rows["a"])
+	if want := sampleTable("b", 2).Data; !cmp.Equal(want, rows["b"]) {
+		t.Errorf("expected small tables to be unchanged -want/+got\n%s", cmp.Diff(want, rows["b"]))
+	}
+
+	again := rowsOf(t, runSample(t, data, reservoirSample(3, &seed)))
+	if !cmp.Equal(rows, again) {
+		t.Errorf("expected the same sample with the same seed -want/+got\n%s", cmp.Diff(rows, again))
+	}
+
+	// Every row has the same chance of being selected.
+	counts := make([]int, 20)
+	for i := int64(0); i < 2000; i++ {
+		i := i
+		for _, row := range rowsOf(t, runSample(t, data, reservoirSample(3, &i)))["a"] {
+			counts[row[2].(int64)]++
+		}
+	}
+	for i, n := range counts {
+		// Each row is expected to be selected 2000 * 3 / 20 = 300 times.
+		if n < 220 || n > 380 {
+			t.Errorf("row %d was selected %d times, expected about 300", i, n)
+		}
+	}
+}
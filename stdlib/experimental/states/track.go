@@ -0,0 +1,287 @@
+package states
+
+import (
+	"time"
+
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/runtime"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+const pkgpath = "experimental/states"
+
+const TrackKind = "statesTrack"
+
+// Labels of the columns in the output of track.
+const (
+	StateLabel    = "state"
+	StartLabel    = "start"
+	StopLabel     = "stop"
+	DurationLabel = "duration"
+	CountLabel    = "count"
+)
+
+type TrackOpSpec struct {
+	StateColumn  string        `json:"stateColumn"`
+	States       []string      `json:"states"`
+	TimeColumn   string        `json:"timeColumn"`
+	DurationUnit flux.Duration `json:"durationUnit"`
+}
+
+func init() {
+	runtime.RegisterPackageValue(pkgpath, "_track", flux.MustValue(flux.FunctionValue("_track", createTrackOpSpec, runtime.MustLookupBuiltinType(pkgpath, "_track"))))
+	plan.RegisterProcedureSpec(TrackKind, newTrackProcedure, TrackKind)
+	execute.RegisterTransformation(TrackKind, createTrackTransformation)
+}
+
+func createTrackOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := &TrackOpSpec{
+		TimeColumn:   execute.DefaultTimeColLabel,
+		DurationUnit: flux.ConvertDuration(time.Second),
+	}
+	if label, err := args.GetRequiredString("stateColumn"); err != nil {
+		return nil, err
+	} else {
+		spec.StateColumn = label
+	}
+	if states, ok, err := args.GetArray("states", semantic.String); err != nil {
+		return nil, err
+	} else if ok {
+		if spec.States, err = interpreter.ToStringArray(states); err != nil {
+			return nil, err
+		}
+	}
+	if label, ok, err := args.GetString("timeColumn"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.TimeColumn = label
+	}
+	if unit, ok, err := args.GetDuration("durationUnit"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.DurationUnit = unit
+	}
+
+	if !values.Duration(spec.DurationUnit).IsPositive() {
+		return nil, errors.New(codes.Invalid, "state tracking duration unit must be greater than zero")
+	}
+	return spec, nil
+}
+
+func (s *TrackOpSpec) Kind() flux.OperationKind {
+	return TrackKind
+}
+
+type TrackProcedureSpec struct {
+	plan.DefaultCost
+	StateColumn  string
+	States       []string
+	TimeColumn   string
+	DurationUnit flux.Duration
+}
+
+func newTrackProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*TrackOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+	return &TrackProcedureSpec{
+		StateColumn:  spec.StateColumn,
+		States:       spec.States,
+		TimeColumn:   spec.TimeColumn,
+		DurationUnit: spec.DurationUnit,
+	}, nil
+}
+
+func (s *TrackProcedureSpec) Kind() plan.ProcedureKind {
+	return TrackKind
+}
+
+func (s *TrackProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	if s.States != nil {
+		ns.States = make([]string, len(s.States))
+		copy(ns.States, s.States)
+	}
+	return &ns
+}
+
+func createTrackTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*TrackProcedureSpec)
+	if !ok {
+		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
+	}
+	return NewTrackTransformation(id, s, a.Allocator())
+}
+
+type trackTransformation struct {
+	stateCol string
+	timeCol  string
+	states   map[string]bool
+	unit     int64
+}
+
+// NewTrackTransformation constructs a transformation that outputs
+// a row for each period of consecutive rows with the same state.
+func NewTrackTransformation(id execute.DatasetID, spec *TrackProcedureSpec, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	tr := &trackTransformation{
+		stateCol: spec.StateColumn,
+		timeCol:  spec.TimeColumn,
+		unit:     int64(spec.DurationUnit.Duration()),
+	}
+	if len(spec.States) > 0 {
+		tr.states = make(map[string]bool, len(spec.States))
+		for _, s := range spec.States {
+			tr.states[s] = true
+		}
+	}
+	return execute.NewAggregateTransformation(id, tr, mem)
+}
+
+// period is a run of consecutive rows with the same state.
+// The stop time is the time the next period started or,
+// for the last period, the time of the last row.
+type period struct {
+	state       string
+	start, stop int64
+	count       int64
+}
+
+type trackState struct {
+	periods     []period
+	current     *period
+	t           int64
+	initialized bool
+}
+
+// add records a row with a state at a time. A change of state ends
+// the current period, which is kept if it is a tracked state.
+func (t *trackTransformation) add(s *trackState, state string, ts int64) {
+	if s.current != nil && s.current.state == state {
+		s.current.count++
+		return
+	}
+	t.end(s, ts)
+	s.current = &period{state: state, start: ts, count: 1}
+}
+
+func (t *trackTransformation) end(s *trackState, ts int64) {
+	if s.current == nil {
+		return
+	}
+	s.current.stop = ts
+	if t.states == nil || t.states[s.current.state] {
+		s.periods = append(s.periods, *s.current)
+	}
+	s.current = nil
+}
+
+func (t *trackTransformation) Aggregate(chunk table.Chunk, state interface{}, mem memory.Allocator) (interface{}, bool, error) {
+	s, _ := state.(*trackState)
+	if s == nil {
+		s = &trackState{}
+	}
+	if chunk.Len() == 0 {
+		return s, true, nil
+	}
+
+	timeIdx := chunk.Index(t.timeCol)
+	if timeIdx < 0 {
+		return nil, false, errors.Newf(codes.FailedPrecondition, "column %q does not exist", t.timeCol)
+	} else if typ := chunk.Col(timeIdx).Type; typ != flux.TTime {
+		return nil, false, errors.Newf(codes.FailedPrecondition, "column %q is type %s and not a time", t.timeCol, typ)
+	}
+	stateIdx := chunk.Index(t.stateCol)
+	if stateIdx < 0 {
+		return nil, false, errors.Newf(codes.FailedPrecondition, "column %q does not exist", t.stateCol)
+	} else if typ := chunk.Col(stateIdx).Type; typ != flux.TString {
+		return nil, false, errors.Newf(codes.FailedPrecondition, "column %q is type %s and not a string", t.stateCol, typ)
+	}
+
+	times, states := chunk.Ints(timeIdx), chunk.Strings(stateIdx)
+	for i, l := 0, chunk.Len(); i < l; i++ {
+		if times.IsNull(i) {
+			return nil, false, errors.New(codes.FailedPrecondition, "got a null timestamp")
+		}
+		ts := times.Value(i)
+		if s.initialized && ts < s.t {
+			return nil, false, errors.New(codes.FailedPrecondition, "got an out-of-order timestamp")
+		}
+		s.t, s.initialized = ts, true
+		if states.IsNull(i) {
+			continue
+		}
+		t.add(s, states.Value(i), ts)
+	}
+	return s, true, nil
+}
+
+func (t *trackTransformation) Compute(key flux.GroupKey, state interface{}, d *execute.TransportDataset, mem memory.Allocator) error {
+	s := state.(*trackState)
+	t.end(s, s.t)
+
+	n := len(s.periods)
+	cols := make([]flux.ColMeta, 0, len(key.Cols())+5)
+	vs := make([]array.Array, 0, len(key.Cols())+5)
+	for j, col := range key.Cols() {
+		switch col.Label {
+		case StateLabel, StartLabel, StopLabel, DurationLabel, CountLabel:
+			return errors.Newf(codes.FailedPrecondition, "column %q must not be part of the group key", col.Label)
+		}
+		cols = append(cols, col)
+		vs = append(vs, arrow.Repeat(col.Type, key.Value(j), n, mem))
+	}
+
+	states := array.NewStringBuilder(mem)
+	states.Resize(n)
+	starts := array.NewIntBuilder(mem)
+	starts.Resize(n)
+	stops := array.NewIntBuilder(mem)
+	stops.Resize(n)
+	durations := array.NewIntBuilder(mem)
+	durations.Resize(n)
+	counts := array.NewIntBuilder(mem)
+	counts.Resize(n)
+	for _, p := range s.periods {
+		states.Append(p.state)
+		starts.Append(p.start)
+		stops.Append(p.stop)
+		durations.Append((p.stop - p.start) / t.unit)
+		counts.Append(p.count)
+	}
+	cols = append(cols,
+		flux.ColMeta{Label: StateLabel, Type: flux.TString},
+		flux.ColMeta{Label: StartLabel, Type: flux.TTime},
+		flux.ColMeta{Label: StopLabel, Type: flux.TTime},
+		flux.ColMeta{Label: DurationLabel, Type: flux.TInt},
+		flux.ColMeta{Label: CountLabel, Type: flux.TInt},
+	)
+	vs = append(vs,
+		states.NewArray(),
+		starts.NewArray(),
+		stops.NewArray(),
+		durations.NewArray(),
+		counts.NewArray(),
+	)
+	return d.Process(table.ChunkFromBuffer(arrow.TableBuffer{
+		GroupKey: key,
+		Columns:  cols,
+		Values:   vs,
+	}))
+}
+
+func (t *trackTransformation) Close() error { return nil }
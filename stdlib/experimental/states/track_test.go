@@ -0,0 +1,122 @@
+package states_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/stdlib/experimental/states"
+)
+
+func TestTrack(t *testing.T) {
+	inputCols := []flux.ColMeta{
+		{Label: "_time", Type: flux.TTime},
+		{Label: "s", Type: flux.TString},
+		{Label: "t0", Type: flux.TString},
+	}
+	outputCols := []flux.ColMeta{
+		{Label: "t0", Type: flux.TString},
+		{Label: "state", Type: flux.TString},
+		{Label: "start", Type: flux.TTime},
+		{Label: "stop", Type: flux.TTime},
+		{Label: "duration", Type: flux.TInt},
+		{Label: "count", Type: flux.TInt},
+	}
+	input := func() []flux.Table {
+		return []flux.Table{&executetest.Table{
+			KeyCols: []string{"t0"},
+			ColMeta: inputCols,
+			Data: [][]interface{}{
+				{execute.Time(0), "up", "a"},
+				{execute.Time(1 * time.Second), "up", "a"},
+				{execute.Time(2 * time.Second), "down", "a"},
+				{execute.Time(3 * time.Second), "down", "a"},
+				{execute.Time(4 * time.Second), "up", "a"},
+				{execute.Time(5 * time.Second), nil, "a"},
+			},
+		}}
+	}
+	testCases := []struct {
+		name    string
+		spec    *states.TrackProcedureSpec
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "all states",
+			spec: &states.TrackProcedureSpec{
+				StateColumn:  "s",
+				TimeColumn:   execute.DefaultTimeColLabel,
+				DurationUnit: flux.ConvertDuration(time.Second),
+			},
+			data: input(),
+			want: []*executetest.Table{{
+				KeyCols: []string{"t0"},
+				ColMeta: outputCols,
+				Data: [][]interface{}{
+					{"a", "up", execute.Time(0), execute.Time(2 * time.Second), int64(2), int64(2)},
+					{"a", "down", execute.Time(2 * time.Second), execute.Time(4 * time.Second), int64(2), int64(2)},
+					{"a", "up", execute.Time(4 * time.Second), execute.Time(5 * time.Second), int64(1), int64(1)},
+				},
+			}},
+		},
+		{
+			name: "tracked states",
+			spec: &states.TrackProcedureSpec{
+				StateColumn:  "s",
+				States:       []string{"down"},
+				TimeColumn:   execute.DefaultTimeColLabel,
+				DurationUnit: flux.ConvertDuration(time.Millisecond),
+			},
+			data: input(),
+			want: []*executetest.Table{{
+				KeyCols: []string{"t0"},
+				ColMeta: outputCols,
+				Data: [][]interface{}{
+					{"a", "down", execute.Time(2 * time.Second), execute.Time(4 * time.Second), int64(2000), int64(2)},
+				},
+			}},
+		},
+		{
+			name: "out of order",
+			spec: &states.TrackProcedureSpec{
+				StateColumn:  "s",
+				TimeColumn:   execute.DefaultTimeColLabel,
+				DurationUnit: flux.ConvertDuration(time.Second),
+			},
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"t0"},
+				ColMeta: inputCols,
+				Data: [][]interface{}{
+					{execute.Time(2 * time.Second), "up", "a"},
+					{execute.Time(1 * time.Second), "up", "a"},
+				},
+			}},
+			wantErr: errors.New(codes.FailedPrecondition, "got an out-of-order timestamp"),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper2(
+				t,
+				tc.data,
+				tc.want,
+				tc.wantErr,
+				func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+					tr, d, err := states.NewTrackTransformation(id, tc.spec, alloc)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return tr, d
+				},
+			)
+		})
+	}
+}
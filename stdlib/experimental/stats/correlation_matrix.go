@@ -0,0 +1,273 @@
+package stats
+
+import (
+	"math"
+
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+)
+
+const (
+	MethodPearson    = "pearson"
+	MethodCovariance = "covariance"
+)
+
+// MatrixLabelColumn is the column that contains the
+// name of the column each row of the matrix belongs to.
+const MatrixLabelColumn = "column"
+
+type CorrelationMatrixOpSpec struct {
+	Columns []string `json:"columns"`
+	Method  string   `json:"method"`
+}
+
+func createCorrelationMatrixOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(CorrelationMatrixOpSpec)
+	if cols, err := args.GetRequiredArray("columns", semantic.String); err != nil {
+		return nil, err
+	} else {
+		columns, err := interpreter.ToStringArray(cols)
+		if err != nil {
+			return nil, err
+		}
+		spec.Columns = columns
+	}
+	if len(spec.Columns) < 2 {
+		return nil, errors.New(codes.Invalid, "must provide at least two columns")
+	}
+	seen := make(map[string]bool, len(spec.Columns))
+	for _, label := range spec.Columns {
+		if label == MatrixLabelColumn {
+			return nil, errors.Newf(codes.Invalid, "column %q is reserved for the row label", label)
+		} else if seen[label] {
+			return nil, errors.Newf(codes.Invalid, "column %q is listed more than once", label)
+		}
+		seen[label] = true
+	}
+
+	if method, ok, err := args.GetString("method"); err != nil {
+		return nil, err
+	} else if ok {
+		switch method {
+		case MethodPearson, MethodCovariance:
+			spec.Method = method
+		default:
+			return nil, errors.Newf(codes.Invalid, "unknown method %q, expected %q or %q", method, MethodPearson, MethodCovariance)
+		}
+	} else {
+		spec.Method = MethodPearson
+	}
+	return spec, nil
+}
+
+func (s *CorrelationMatrixOpSpec) Kind() flux.OperationKind {
+	return CorrelationMatrixKind
+}
+
+type CorrelationMatrixProcedureSpec struct {
+	plan.DefaultCost
+	Columns []string
+	Method  string
+}
+
+func newCorrelationMatrixProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*CorrelationMatrixOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+	ps := &CorrelationMatrixProcedureSpec{
+		Method: spec.Method,
+	}
+	ps.Columns = make([]string, len(spec.Columns))
+	copy(ps.Columns, spec.Columns)
+	return ps, nil
+}
+
+func (s *CorrelationMatrixProcedureSpec) Kind() plan.ProcedureKind {
+	return CorrelationMatrixKind
+}
+
+func (s *CorrelationMatrixProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	ns.Columns = make([]string, len(s.Columns))
+	copy(ns.Columns, s.Columns)
+	return &ns
+}
+
+func createCorrelationMatrixTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*CorrelationMatrixProcedureSpec)
+	if !ok {
+		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
+	}
+	return NewCorrelationMatrixTransformation(id, s, a.Allocator())
+}
+
+type correlationMatrixTransformation struct {
+	columns []string
+	method  string
+}
+
+// NewCorrelationMatrixTransformation constructs a transformation that computes
+// the correlation or covariance between each pair of columns of each table.
+func NewCorrelationMatrixTransformation(id execute.DatasetID, spec *CorrelationMatrixProcedureSpec, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	tr := &correlationMatrixTransformation{
+		columns: spec.Columns,
+		method:  spec.Method,
+	}
+	return execute.NewAggregateTransformation(id, tr, mem)
+}
+
+// comoment accumulates the means and the second moments of two
+// columns over the rows where both values are not null.
+type comoment struct {
+	n, mx, my, mxx, myy, mxy float64
+}
+
+func (c *comoment) add(x, y float64) {
+	c.n++
+	dx := x - c.mx
+	c.mx += dx / c.n
+	dy := y - c.my
+	c.my += dy / c.n
+	c.mxx += dx * (x - c.mx)
+	c.myy += dy * (y - c.my)
+	c.mxy += dx * (y - c.my)
+}
+
+func (c *comoment) covariance() (float64, bool) {
+	if c.n < 2 {
+		return 0, false
+	}
+	return c.mxy / (c.n - 1), true
+}
+
+func (c *comoment) pearsonr() (float64, bool) {
+	if c.n < 2 {
+		return 0, false
+	}
+	return c.mxy / math.Sqrt(c.mxx*c.myy), true
+}
+
+// correlationMatrixState holds the comoments of each pair of columns
+// in the upper triangle of the matrix, including the diagonal.
+type correlationMatrixState struct {
+	pairs [][]comoment
+}
+
+func (t *correlationMatrixTransformation) Aggregate(chunk table.Chunk, state interface{}, mem memory.Allocator) (interface{}, bool, error) {
+	s, _ := state.(*correlationMatrixState)
+	if s == nil {
+		s = &correlationMatrixState{pairs: make([][]comoment, len(t.columns))}
+		for i := range s.pairs {
+			s.pairs[i] = make([]comoment, len(t.columns)-i)
+		}
+	}
+	for _, label := range append([]string{MatrixLabelColumn}, t.columns...) {
+		if chunk.Key().HasCol(label) {
+			return nil, false, errors.Newf(codes.FailedPrecondition, "column %q must not be part of the group key", label)
+		}
+	}
+	if chunk.Len() == 0 {
+		return s, true, nil
+	}
+
+	fns := make([]func(i int) (float64, bool), len(t.columns))
+	for j, label := range t.columns {
+		fn, err := floatValueFunc(chunk, label)
+		if err != nil {
+			return nil, false, err
+		}
+		fns[j] = fn
+	}
+
+	vs := make([]float64, len(t.columns))
+	valid := make([]bool, len(t.columns))
+	for i, l := 0, chunk.Len(); i < l; i++ {
+		for j, fn := range fns {
+			vs[j], valid[j] = fn(i)
+		}
+		for a := range t.columns {
+			if !valid[a] {
+				continue
+			}
+			for b := a; b < len(t.columns); b++ {
+				if valid[b] {
+					s.pairs[a][b-a].add(vs[a], vs[b])
+				}
+			}
+		}
+	}
+	return s, true, nil
+}
+
+func (t *correlationMatrixTransformation) Compute(key flux.GroupKey, state interface{}, d *execute.TransportDataset, mem memory.Allocator) error {
+	s := state.(*correlationMatrixState)
+
+	k := len(t.columns)
+	cols := make([]flux.ColMeta, 0, len(key.Cols())+k+1)
+	vs := make([]array.Array, 0, len(key.Cols())+k+1)
+	for j, col := range key.Cols() {
+		cols = append(cols, col)
+		vs = append(vs, arrow.Repeat(col.Type, key.Value(j), k, mem))
+	}
+
+	cols = append(cols, flux.ColMeta{Label: MatrixLabelColumn, Type: flux.TString})
+	labels := array.NewStringBuilder(mem)
+	labels.Resize(k)
+	for _, label := range t.columns {
+		labels.Append(label)
+	}
+	vs = append(vs, labels.NewArray())
+
+	for b, label := range t.columns {
+		cols = append(cols, flux.ColMeta{Label: label, Type: flux.TFloat})
+		fb := array.NewFloatBuilder(mem)
+		fb.Resize(k)
+		for a := range t.columns {
+			// The matrix is symmetric so only one
+			// triangle of the comoments is stored.
+			lo, hi := a, b
+			if lo > hi {
+				lo, hi = hi, lo
+			}
+			c := &s.pairs[lo][hi-lo]
+
+			var (
+				v  float64
+				ok bool
+			)
+			if t.method == MethodCovariance {
+				v, ok = c.covariance()
+			} else {
+				v, ok = c.pearsonr()
+			}
+			if ok {
+				fb.Append(v)
+			} else {
+				fb.AppendNull()
+			}
+		}
+		vs = append(vs, fb.NewArray())
+	}
+
+	return d.Process(table.ChunkFromBuffer(arrow.TableBuffer{
+		GroupKey: key,
+		Columns:  cols,
+		Values:   vs,
+	}))
+}
+
+func (t *correlationMatrixTransformation) Close() error { return nil }
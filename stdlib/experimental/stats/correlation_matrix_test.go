@@ -0,0 +1,153 @@
+package stats_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/stdlib/experimental/stats"
+)
+
+func TestCorrelationMatrix(t *testing.T) {
+	input := func() *executetest.Table {
+		return &executetest.Table{
+			KeyCols: []string{"t0"},
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "t0", Type: flux.TString},
+				{Label: "x", Type: flux.TFloat},
+				{Label: "y", Type: flux.TInt},
+				{Label: "z", Type: flux.TFloat},
+			},
+			Data: [][]interface{}{
+				{execute.Time(1), "a", 1.0, int64(2), 4.0},
+				{execute.Time(2), "a", 2.0, int64(4), 3.0},
+				{execute.Time(3), "a", 3.0, int64(6), 2.0},
+				{execute.Time(4), "a", 4.0, int64(8), 1.0},
+			},
+		}
+	}
+	outputCols := []flux.ColMeta{
+		{Label: "t0", Type: flux.TString},
+		{Label: "column", Type: flux.TString},
+		{Label: "x", Type: flux.TFloat},
+		{Label: "y", Type: flux.TFloat},
+		{Label: "z", Type: flux.TFloat},
+	}
+	testCases := []struct {
+		name    string
+		spec    *stats.CorrelationMatrixProcedureSpec
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "pearson",
+			spec: &stats.CorrelationMatrixProcedureSpec{
+				Columns: []string{"x", "y", "z"},
+				Method:  stats.MethodPearson,
+			},
+			data: []flux.Table{input()},
+			want: []*executetest.Table{{
+				KeyCols: []string{"t0"},
+				ColMeta: outputCols,
+				Data: [][]interface{}{
+					{"a", "x", 1.0, 1.0, -1.0},
+					{"a", "y", 1.0, 1.0, -1.0},
+					{"a", "z", -1.0, -1.0, 1.0},
+				},
+			}},
+		},
+		{
+			name: "covariance",
+			spec: &stats.CorrelationMatrixProcedureSpec{
+				Columns: []string{"x", "y", "z"},
+				Method:  stats.MethodCovariance,
+			},
+			data: []flux.Table{input()},
+			want: []*executetest.Table{{
+				KeyCols: []string{"t0"},
+				ColMeta: outputCols,
+				Data: [][]interface{}{
+					{"a", "x", 5.0 / 3, 10.0 / 3, -5.0 / 3},
+					{"a", "y", 10.0 / 3, 20.0 / 3, -10.0 / 3},
+					{"a", "z", -5.0 / 3, -10.0 / 3, 5.0 / 3},
+				},
+			}},
+		},
+		{
+			name: "nulls and constant column",
+			spec: &stats.CorrelationMatrixProcedureSpec{
+				Columns: []string{"x", "y", "z"},
+				Method:  stats.MethodPearson,
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "x", Type: flux.TFloat},
+					{Label: "y", Type: flux.TFloat},
+					{Label: "z", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{1.0, 2.0, 5.0},
+					{2.0, nil, 5.0},
+					{3.0, 6.0, nil},
+					{4.0, 9.0, nil},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "column", Type: flux.TString},
+					{Label: "x", Type: flux.TFloat},
+					{Label: "y", Type: flux.TFloat},
+					{Label: "z", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{"x", 1.0, 0.9941916256019199, math.NaN()},
+					{"y", 0.9941916256019199, 1.0, nil},
+					{"z", math.NaN(), nil, math.NaN()},
+				},
+			}},
+		},
+		{
+			name: "column in group key",
+			spec: &stats.CorrelationMatrixProcedureSpec{
+				Columns: []string{"t0", "x"},
+				Method:  stats.MethodPearson,
+			},
+			data:    []flux.Table{input()},
+			wantErr: errors.New(codes.FailedPrecondition, `column "t0" must not be part of the group key`),
+		},
+		{
+			name: "missing column",
+			spec: &stats.CorrelationMatrixProcedureSpec{
+				Columns: []string{"x", "w"},
+				Method:  stats.MethodPearson,
+			},
+			data:    []flux.Table{input()},
+			wantErr: errors.New(codes.FailedPrecondition, `no column "w" exists`),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper2(
+				t,
+				tc.data,
+				tc.want,
+				tc.wantErr,
+				func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+					tr, d, err := stats.NewCorrelationMatrixTransformation(id, tc.spec, alloc)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return tr, d
+				},
+			)
+		})
+	}
+}
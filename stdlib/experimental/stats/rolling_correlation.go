@@ -0,0 +1,235 @@
+package stats
+
+import (
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/plan"
+)
+
+type RollingCorrelationOpSpec struct {
+	X        string `json:"x"`
+	Y        string `json:"y"`
+	N        int64  `json:"n"`
+	ValueDst string `json:"valueDst"`
+}
+
+func createRollingCorrelationOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(RollingCorrelationOpSpec)
+	if x, err := args.GetRequiredString("x"); err != nil {
+		return nil, err
+	} else {
+		spec.X = x
+	}
+	if y, err := args.GetRequiredString("y"); err != nil {
+		return nil, err
+	} else {
+		spec.Y = y
+	}
+
+	if n, err := args.GetRequiredInt("n"); err != nil {
+		return nil, err
+	} else if n < 2 {
+		return nil, errors.Newf(codes.Invalid, "cannot compute a rolling correlation with a window of %d (must be at least 2)", n)
+	} else {
+		spec.N = n
+	}
+
+	if valueDst, ok, err := args.GetString("valueDst"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.ValueDst = valueDst
+	} else {
+		spec.ValueDst = execute.DefaultValueColLabel
+	}
+	return spec, nil
+}
+
+func (s *RollingCorrelationOpSpec) Kind() flux.OperationKind {
+	return RollingCorrelationKind
+}
+
+type RollingCorrelationProcedureSpec struct {
+	plan.DefaultCost
+	X, Y     string
+	N        int64
+	ValueDst string
+}
+
+func newRollingCorrelationProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*RollingCorrelationOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+	return &RollingCorrelationProcedureSpec{
+		X:        spec.X,
+		Y:        spec.Y,
+		N:        spec.N,
+		ValueDst: spec.ValueDst,
+	}, nil
+}
+
+func (s *RollingCorrelationProcedureSpec) Kind() plan.ProcedureKind {
+	return RollingCorrelationKind
+}
+
+func (s *RollingCorrelationProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+// TriggerSpec implements plan.TriggerAwareProcedureSpec
+func (s *RollingCorrelationProcedureSpec) TriggerSpec() plan.TriggerSpec {
+	return plan.NarrowTransformationTriggerSpec{}
+}
+
+func createRollingCorrelationTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*RollingCorrelationProcedureSpec)
+	if !ok {
+		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
+	}
+	return NewRollingCorrelationTransformation(id, s, a.Allocator())
+}
+
+type rollingCorrelationTransformation struct {
+	x, y     string
+	n        int64
+	valueDst string
+}
+
+// NewRollingCorrelationTransformation constructs a transformation that computes
+// the correlation between two columns over a window of the last n rows.
+func NewRollingCorrelationTransformation(id execute.DatasetID, spec *RollingCorrelationProcedureSpec, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	tr := &rollingCorrelationTransformation{
+		x:        spec.X,
+		y:        spec.Y,
+		n:        spec.N,
+		valueDst: spec.ValueDst,
+	}
+	return execute.NewNarrowStateTransformation[*rollingCorrelationState](id, tr, mem)
+}
+
+// rollingCorrelationState holds the pairs of values in the current window.
+type rollingCorrelationState struct {
+	xs, ys []float64
+	valid  []bool
+	index  int
+	needed int64
+}
+
+func (s *rollingCorrelationState) add(x, y float64, valid bool) {
+	s.xs[s.index], s.ys[s.index], s.valid[s.index] = x, y, valid
+	s.index++
+	if s.index >= len(s.xs) {
+		s.index = 0
+	}
+}
+
+// compute returns the correlation of the pairs in the window
+// where both values are not null.
+func (s *rollingCorrelationState) compute() (float64, bool) {
+	var c comoment
+	for i, ok := range s.valid {
+		if ok {
+			c.add(s.xs[i], s.ys[i])
+		}
+	}
+	return c.pearsonr()
+}
+
+func (t *rollingCorrelationTransformation) Process(chunk table.Chunk, state *rollingCorrelationState, d *execute.TransportDataset, mem memory.Allocator) (*rollingCorrelationState, bool, error) {
+	if chunk.Key().HasCol(t.valueDst) {
+		return nil, false, errors.Newf(codes.FailedPrecondition, "column %q must not be part of the group key", t.valueDst)
+	}
+	xf, err := floatValueFunc(chunk, t.x)
+	if err != nil {
+		return nil, false, err
+	}
+	yf, err := floatValueFunc(chunk, t.y)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if state == nil {
+		state = &rollingCorrelationState{
+			xs:     make([]float64, t.n),
+			ys:     make([]float64, t.n),
+			valid:  make([]bool, t.n),
+			needed: t.n - 1,
+		}
+	}
+
+	cols := chunk.Cols()
+	idx := chunk.Index(t.valueDst)
+	if idx < 0 {
+		idx = len(cols)
+		cols = append(cols[:len(cols):len(cols)], flux.ColMeta{Label: t.valueDst, Type: flux.TFloat})
+	} else if cols[idx].Type != flux.TFloat {
+		newCols := make([]flux.ColMeta, len(cols))
+		copy(newCols, cols)
+		newCols[idx].Type = flux.TFloat
+		cols = newCols
+	}
+
+	// The first n-1 rows of the table do not have
+	// a full window and are not part of the output.
+	skip := state.needed
+	if l := int64(chunk.Len()); skip > l {
+		skip = l
+	}
+	buffer := arrow.TableBuffer{
+		GroupKey: chunk.Key(),
+		Columns:  cols,
+		Values:   make([]array.Array, len(cols)),
+	}
+	for i, col := range cols {
+		if i == idx {
+			continue
+		}
+		arr := chunk.Values(i)
+		if skip > 0 {
+			if skip == int64(arr.Len()) {
+				buffer.Values[i] = arrow.Empty(col.Type)
+			} else {
+				buffer.Values[i] = arrow.Slice(arr, skip, int64(arr.Len()))
+			}
+		} else {
+			arr.Retain()
+			buffer.Values[i] = arr
+		}
+	}
+
+	b := array.NewFloatBuilder(mem)
+	b.Resize(chunk.Len() - int(skip))
+	for i, l := 0, chunk.Len(); i < l; i++ {
+		x, xok := xf(i)
+		y, yok := yf(i)
+		state.add(x, y, xok && yok)
+		if state.needed > 0 {
+			state.needed--
+			continue
+		}
+		if v, ok := state.compute(); ok {
+			b.Append(v)
+		} else {
+			b.AppendNull()
+		}
+	}
+	buffer.Values[idx] = b.NewArray()
+
+	if err := d.Process(table.ChunkFromBuffer(buffer)); err != nil {
+		return nil, false, err
+	}
+	return state, true, nil
+}
+
+func (t *rollingCorrelationTransformation) Close() error { return nil }
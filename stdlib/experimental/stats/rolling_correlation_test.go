@@ -0,0 +1,135 @@
+package stats_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/stdlib/experimental/stats"
+)
+
+func TestRollingCorrelation(t *testing.T) {
+	inputCols := []flux.ColMeta{
+		{Label: "_time", Type: flux.TTime},
+		{Label: "t0", Type: flux.TString},
+		{Label: "x", Type: flux.TInt},
+		{Label: "y", Type: flux.TFloat},
+	}
+	input := func(ys ...interface{}) *executetest.Table {
+		tbl := &executetest.Table{KeyCols: []string{"t0"}, ColMeta: inputCols}
+		for i, y := range ys {
+			tbl.Data = append(tbl.Data, []interface{}{execute.Time(i), "a", int64(i + 1), y})
+		}
+		return tbl
+	}
+	testCases := []struct {
+		name    string
+		spec    *stats.RollingCorrelationProcedureSpec
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "basic",
+			spec: &stats.RollingCorrelationProcedureSpec{
+				X:        "x",
+				Y:        "y",
+				N:        3,
+				ValueDst: "corr",
+			},
+			data: []flux.Table{input(1.0, 2.0, 4.0, 3.0, 5.0)},
+			want: []*executetest.Table{{
+				KeyCols: []string{"t0"},
+				ColMeta: append(append([]flux.ColMeta{}, inputCols...), flux.ColMeta{Label: "corr", Type: flux.TFloat}),
+				Data: [][]interface{}{
+					{execute.Time(2), "a", int64(3), 4.0, 0.9819805060619656},
+					{execute.Time(3), "a", int64(4), 3.0, 0.5},
+					{execute.Time(4), "a", int64(5), 5.0, 0.5},
+				},
+			}},
+		},
+		{
+			name: "replace column with nulls",
+			spec: &stats.RollingCorrelationProcedureSpec{
+				X:        "x",
+				Y:        "y",
+				N:        2,
+				ValueDst: "x",
+			},
+			data: []flux.Table{input(1.0, 2.0, nil, 3.0, 5.0)},
+			want: []*executetest.Table{{
+				KeyCols: []string{"t0"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "t0", Type: flux.TString},
+					{Label: "x", Type: flux.TFloat},
+					{Label: "y", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), "a", 1.0, 2.0},
+					{execute.Time(2), "a", nil, nil},
+					{execute.Time(3), "a", nil, 3.0},
+					{execute.Time(4), "a", 1.0, 5.0},
+				},
+			}},
+		},
+		{
+			name: "fewer rows than window",
+			spec: &stats.RollingCorrelationProcedureSpec{
+				X:        "x",
+				Y:        "y",
+				N:        3,
+				ValueDst: "corr",
+			},
+			data: []flux.Table{input(1.0, 2.0)},
+			want: []*executetest.Table{{
+				KeyCols: []string{"t0"},
+				ColMeta: append(append([]flux.ColMeta{}, inputCols...), flux.ColMeta{Label: "corr", Type: flux.TFloat}),
+			}},
+		},
+		{
+			name: "destination in group key",
+			spec: &stats.RollingCorrelationProcedureSpec{
+				X:        "x",
+				Y:        "y",
+				N:        3,
+				ValueDst: "t0",
+			},
+			data:    []flux.Table{input(1.0, 2.0, 4.0)},
+			wantErr: errors.New(codes.FailedPrecondition, `column "t0" must not be part of the group key`),
+		},
+		{
+			name: "unsupported type",
+			spec: &stats.RollingCorrelationProcedureSpec{
+				X:        "x",
+				Y:        "t0",
+				N:        3,
+				ValueDst: "corr",
+			},
+			data:    []flux.Table{input(1.0, 2.0, 4.0)},
+			wantErr: errors.New(codes.FailedPrecondition, `column "t0" is type string and not a numeric type`),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper2(
+				t,
+				tc.data,
+				tc.want,
+				tc.wantErr,
+				func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+					tr, d, err := stats.NewRollingCorrelationTransformation(id, tc.spec, alloc)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return tr, d
+				},
+			)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package stats
+
+import (
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/arrowutil"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/runtime"
+)
+
+const pkgpath = "experimental/stats"
+
+const (
+	CorrelationMatrixKind  = "statsCorrelationMatrix"
+	RollingCorrelationKind = "statsRollingCorrelation"
+)
+
+func init() {
+	runtime.RegisterPackageValue(pkgpath, "correlationMatrix", flux.MustValue(flux.FunctionValue("correlationMatrix", createCorrelationMatrixOpSpec, runtime.MustLookupBuiltinType(pkgpath, "correlationMatrix"))))
+	plan.RegisterProcedureSpec(CorrelationMatrixKind, newCorrelationMatrixProcedure, CorrelationMatrixKind)
+	execute.RegisterTransformation(CorrelationMatrixKind, createCorrelationMatrixTransformation)
+
+	runtime.RegisterPackageValue(pkgpath, "rollingCorrelation", flux.MustValue(flux.FunctionValue("rollingCorrelation", createRollingCorrelationOpSpec, runtime.MustLookupBuiltinType(pkgpath, "rollingCorrelation"))))
+	plan.RegisterProcedureSpec(RollingCorrelationKind, newRollingCorrelationProcedure, RollingCorrelationKind)
+	execute.RegisterTransformation(RollingCorrelationKind, createRollingCorrelationTransformation)
+}
+
+// floatValueFunc returns a function that reads the value
+// of a numeric column at an index as a float.
+func floatValueFunc(chunk table.Chunk, label string) (func(i int) (float64, bool), error) {
+	j := chunk.Index(label)
+	if j < 0 {
+		return nil, errors.Newf(codes.FailedPrecondition, "no column %q exists", label)
+	}
+	fn, ok := arrowutil.FloatValueFunc(chunk.Values(j))
+	if !ok {
+		col := chunk.Col(j)
+		return nil, errors.Newf(codes.FailedPrecondition, "column %q is type %s and not a numeric type", label, col.Type)
+	}
+	return fn, nil
+}
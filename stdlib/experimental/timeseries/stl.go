@@ -0,0 +1,301 @@
+package timeseries
+
+import (
+	"math"
+	"sort"
+)
+
+// stl decomposes a series into trend, seasonal, and residual components
+// using seasonal-trend decomposition based on loess (STL).
+//
+// See Cleveland, R.B., Cleveland, W.S., McRae, J.E. and Terpenning, I. (1990)
+// STL: A seasonal-trend decomposition procedure based on loess.
+type stl struct {
+	// period is the number of values in a season.
+	period int
+	// seasonal and trend are the spans of the loess
+	// smoothing of the seasonal and trend components.
+	seasonal, trend int
+	// lowPass is the span of the loess smoothing of the low-pass filter.
+	lowPass int
+	// inner and outer are the number of passes of the inner loop
+	// and the number of robustness iterations.
+	inner, outer int
+}
+
+// newSTL creates an STL decomposition with the default parameters.
+// A zero seasonal or trend span uses the default span.
+func newSTL(period, seasonal, trend int, robust bool) *stl {
+	if seasonal == 0 {
+		seasonal = 7
+	}
+	seasonal = nextOdd(seasonal)
+	if trend == 0 {
+		trend = int(math.Ceil(1.5 * float64(period) / (1 - 1.5/float64(seasonal))))
+	}
+	s := &stl{
+		period:   period,
+		seasonal: seasonal,
+		trend:    nextOdd(trend),
+		lowPass:  nextOdd(period),
+		inner:    2,
+	}
+	if robust {
+		s.inner, s.outer = 1, 15
+	}
+	return s
+}
+
+// decompose computes the components of the values.
+// Values that are not valid do not contribute to the components.
+func (s *stl) decompose(ys []float64, valid []bool) (trend, seasonal, residual []float64) {
+	n := len(ys)
+	weights := make([]float64, n)
+	for i := range weights {
+		if valid[i] {
+			weights[i] = 1
+		}
+	}
+
+	trend = make([]float64, n)
+	seasonal = make([]float64, n)
+	residual = make([]float64, n)
+	for k := 0; ; k++ {
+		s.innerLoop(ys, weights, trend, seasonal)
+		for i := range residual {
+			residual[i] = ys[i] - trend[i] - seasonal[i]
+		}
+		if k >= s.outer {
+			break
+		}
+		s.robustnessWeights(ys, residual, valid, weights)
+	}
+	return trend, seasonal, residual
+}
+
+func (s *stl) innerLoop(ys, weights, trend, seasonal []float64) {
+	n, np := len(ys), s.period
+	detrended := make([]float64, n)
+	cycle := make([]float64, n+2*np)
+	deseasonalized := make([]float64, n)
+	ones := make([]float64, n)
+	for i := range ones {
+		ones[i] = 1
+	}
+
+	for pass := 0; pass < s.inner; pass++ {
+		for i := range detrended {
+			detrended[i] = ys[i] - trend[i]
+		}
+
+		// Smooth each cycle-subseries and extend it by
+		// one value on each side.
+		var sub, subWeights []float64
+		for p := 0; p < np; p++ {
+			sub, subWeights = sub[:0], subWeights[:0]
+			for i := p; i < n; i += np {
+				sub = append(sub, detrended[i])
+				subWeights = append(subWeights, weights[i])
+			}
+			smoothed := loessAt(sub, subWeights, s.seasonal, -1, len(sub)+1)
+			for j, v := range smoothed {
+				cycle[j*np+p] = v
+			}
+		}
+
+		// Remove any trend from the smoothed cycle-subseries
+		// with a low-pass filter.
+		low := movingAverage(movingAverage(movingAverage(cycle, np), np), 3)
+		low = loessAt(low, ones, s.lowPass, 0, n)
+		for i := range seasonal {
+			seasonal[i] = cycle[np+i] - low[i]
+		}
+
+		for i := range deseasonalized {
+			deseasonalized[i] = ys[i] - seasonal[i]
+		}
+		copy(trend, loessAt(deseasonalized, weights, s.trend, 0, n))
+	}
+}
+
+// robustnessWeights computes the bisquare weights of the residuals
+// so that outliers have less influence on the next iteration.
+func (s *stl) robustnessWeights(ys, residual []float64, valid []bool, weights []float64) {
+	var scale float64
+	abs := make([]float64, 0, len(residual))
+	for i, r := range residual {
+		if valid[i] {
+			abs = append(abs, math.Abs(r))
+			scale = math.Max(scale, math.Abs(ys[i]))
+		}
+	}
+	sort.Float64s(abs)
+	var m float64
+	if l := len(abs); l%2 == 1 {
+		m = abs[l/2]
+	} else {
+		m = (abs[l/2-1] + abs[l/2]) / 2
+	}
+
+	// The weights are left unchanged when the residuals are
+	// negligible since they would only weigh rounding errors.
+	h := 6 * m
+	if h <= 1e-10*scale {
+		return
+	}
+	for i, r := range residual {
+		if !valid[i] {
+			continue
+		}
+		u := math.Abs(r) / h
+		switch {
+		case u <= 0.001:
+			weights[i] = 1
+		case u <= 0.999:
+			weights[i] = (1 - u*u) * (1 - u*u)
+		default:
+			weights[i] = 0
+		}
+	}
+}
+
+// loessAt evaluates a locally weighted linear regression of the values
+// at each position in [start, stop). The values are at positions 0
+// to len(ys)-1 and each regression uses the q nearest values.
+//
+// Positions where no value has a weight are linearly
+// interpolated from the neighboring positions.
+func loessAt(ys, weights []float64, q, start, stop int) []float64 {
+	out := make([]float64, stop-start)
+	ok := make([]bool, len(out))
+	w := make([]float64, len(ys))
+	for x := start; x < stop; x++ {
+		out[x-start], ok[x-start] = loess(ys, weights, w, q, float64(x))
+	}
+	interpolateMissing(out, ok)
+	return out
+}
+
+// loess evaluates a locally weighted linear regression at position x
+// using the q nearest values. The w slice is used as scratch space.
+func loess(ys, weights, w []float64, q int, x float64) (float64, bool) {
+	n := len(ys)
+	if n == 0 {
+		return 0, false
+	}
+
+	// Find the window of the q nearest positions
+	// and the distance to the farthest of them.
+	lo, hi := 0, n-1
+	if q < n {
+		lo = int(x) - (q-1)/2
+		if lo < 0 {
+			lo = 0
+		} else if lo > n-q {
+			lo = n - q
+		}
+		hi = lo + q - 1
+	}
+	d := math.Max(x-float64(lo), float64(hi)-x)
+	if q > n {
+		d += float64(q-n) / 2
+	}
+
+	// Compute the tricube weights.
+	var sum float64
+	for j := lo; j <= hi; j++ {
+		w[j] = 0
+		r := math.Abs(float64(j) - x)
+		switch {
+		case r <= 0.001*d:
+			w[j] = weights[j]
+		case r <= 0.999*d:
+			t := r / d
+			t = 1 - t*t*t
+			w[j] = weights[j] * t * t * t
+		}
+		sum += w[j]
+	}
+	if sum <= 0 {
+		return 0, false
+	}
+	for j := lo; j <= hi; j++ {
+		w[j] /= sum
+	}
+
+	// Adjust the weights to fit a line instead of a constant
+	// when the positions are spread out enough.
+	if d > 0 {
+		var a float64
+		for j := lo; j <= hi; j++ {
+			a += w[j] * float64(j)
+		}
+		var c float64
+		for j := lo; j <= hi; j++ {
+			c += w[j] * (float64(j) - a) * (float64(j) - a)
+		}
+		if math.Sqrt(c) > 0.001*float64(n-1) {
+			b := (x - a) / c
+			for j := lo; j <= hi; j++ {
+				w[j] *= b*(float64(j)-a) + 1
+			}
+		}
+	}
+
+	var v float64
+	for j := lo; j <= hi; j++ {
+		v += w[j] * ys[j]
+	}
+	return v, true
+}
+
+// interpolateMissing replaces the values that are not ok with a linear
+// interpolation of the nearest ok values. Leading and trailing values
+// take the value of the nearest ok value.
+func interpolateMissing(vs []float64, ok []bool) {
+	prev := -1
+	for i := range vs {
+		if !ok[i] {
+			continue
+		}
+		switch {
+		case prev < 0:
+			for j := 0; j < i; j++ {
+				vs[j] = vs[i]
+			}
+		case prev < i-1:
+			step := (vs[i] - vs[prev]) / float64(i-prev)
+			for j := prev + 1; j < i; j++ {
+				vs[j] = vs[prev] + step*float64(j-prev)
+			}
+		}
+		prev = i
+	}
+	if prev >= 0 {
+		for j := prev + 1; j < len(vs); j++ {
+			vs[j] = vs[prev]
+		}
+	}
+}
+
+// movingAverage returns the averages of each window of m values.
+func movingAverage(vs []float64, m int) []float64 {
+	out := make([]float64, len(vs)-m+1)
+	var sum float64
+	for i := 0; i < m; i++ {
+		sum += vs[i]
+	}
+	out[0] = sum / float64(m)
+	for i := 1; i < len(out); i++ {
+		sum += vs[i+m-1] - vs[i-1]
+		out[i] = sum / float64(m)
+	}
+	return out
+}
+
+func nextOdd(v int) int {
+	if v%2 == 0 {
+		return v + 1
+	}
+	return v
+}
@@ -0,0 +1,265 @@
+package timeseries
+
+import (
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/runtime"
+)
+
+const pkgpath = "experimental/timeseries"
+
+const DecomposeKind = "timeseriesDecompose"
+
+const (
+	TrendColumn    = "trend"
+	SeasonalColumn = "seasonal"
+	ResidualColumn = "residual"
+)
+
+func init() {
+	runtime.RegisterPackageValue(pkgpath, "decompose", flux.MustValue(flux.FunctionValue("decompose", createDecomposeOpSpec, runtime.MustLookupBuiltinType(pkgpath, "decompose"))))
+	plan.RegisterProcedureSpec(DecomposeKind, newDecomposeProcedure, DecomposeKind)
+	execute.RegisterTransformation(DecomposeKind, createDecomposeTransformation)
+}
+
+type DecomposeOpSpec struct {
+	Seasonality    int64  `json:"seasonality"`
+	SeasonalWindow int64  `json:"seasonalWindow"`
+	TrendWindow    int64  `json:"trendWindow"`
+	Robust         bool   `json:"robust"`
+	Column         string `json:"column"`
+}
+
+func createDecomposeOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(DecomposeOpSpec)
+	if s, err := args.GetRequiredInt("seasonality"); err != nil {
+		return nil, err
+	} else if s < 2 {
+		return nil, errors.Newf(codes.Invalid, "seasonality must be at least 2, got %d", s)
+	} else {
+		spec.Seasonality = s
+	}
+
+	for _, arg := range []struct {
+		name string
+		v    *int64
+	}{
+		{name: "seasonalWindow", v: &spec.SeasonalWindow},
+		{name: "trendWindow", v: &spec.TrendWindow},
+	} {
+		if w, ok, err := args.GetInt(arg.name); err != nil {
+			return nil, err
+		} else if ok {
+			if w < 3 {
+				return nil, errors.Newf(codes.Invalid, "%s must be at least 3, got %d", arg.name, w)
+			}
+			*arg.v = w
+		}
+	}
+
+	if robust, ok, err := args.GetBool("robust"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Robust = robust
+	}
+
+	if col, ok, err := args.GetString("column"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Column = col
+	} else {
+		spec.Column = execute.DefaultValueColLabel
+	}
+	return spec, nil
+}
+
+func (s *DecomposeOpSpec) Kind() flux.OperationKind {
+	return DecomposeKind
+}
+
+type DecomposeProcedureSpec struct {
+	plan.DefaultCost
+	Seasonality    int64
+	SeasonalWindow int64
+	TrendWindow    int64
+	Robust         bool
+	Column         string
+}
+
+func newDecomposeProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*DecomposeOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+	return &DecomposeProcedureSpec{
+		Seasonality:    spec.Seasonality,
+		SeasonalWindow: spec.SeasonalWindow,
+		TrendWindow:    spec.TrendWindow,
+		Robust:         spec.Robust,
+		Column:         spec.Column,
+	}, nil
+}
+
+func (s *DecomposeProcedureSpec) Kind() plan.ProcedureKind {
+	return DecomposeKind
+}
+
+func (s *DecomposeProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+func createDecomposeTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*DecomposeProcedureSpec)
+	if !ok {
+		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
+	}
+	return NewDecomposeTransformation(id, s, a.Allocator())
+}
+
+type decomposeTransformation struct {
+	column string
+	stl    *stl
+}
+
+// NewDecomposeTransformation constructs a transformation that appends the
+// trend, seasonal, and residual components of a column to each table.
+func NewDecomposeTransformation(id execute.DatasetID, spec *DecomposeProcedureSpec, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	tr := &decomposeTransformation{
+		column: spec.Column,
+		stl:    newSTL(int(spec.Seasonality), int(spec.SeasonalWindow), int(spec.TrendWindow), spec.Robust),
+	}
+	return execute.NewAggregateTransformation(id, tr, mem)
+}
+
+type decomposeState struct {
+	chunks []table.Chunk
+}
+
+func (s *decomposeState) Close() error {
+	for _, chunk := range s.chunks {
+		chunk.Release()
+	}
+	s.chunks = nil
+	return nil
+}
+
+func (t *decomposeTransformation) Aggregate(chunk table.Chunk, state interface{}, mem memory.Allocator) (interface{}, bool, error) {
+	s, _ := state.(*decomposeState)
+	if s == nil {
+		s = &decomposeState{}
+	}
+	chunk.Retain()
+	s.chunks = append(s.chunks, chunk)
+	return s, true, nil
+}
+
+func (t *decomposeTransformation) Compute(key flux.GroupKey, state interface{}, d *execute.TransportDataset, mem memory.Allocator) error {
+	s := state.(*decomposeState)
+
+	var (
+		ys    []float64
+		valid []bool
+		count int
+	)
+	for _, chunk := range s.chunks {
+		for _, label := range []string{TrendColumn, SeasonalColumn, ResidualColumn} {
+			if chunk.Index(label) >= 0 {
+				return errors.Newf(codes.FailedPrecondition, "column %q already exists", label)
+			}
+		}
+		if chunk.Len() == 0 {
+			continue
+		}
+
+		j := chunk.Index(t.column)
+		if j < 0 {
+			return errors.Newf(codes.FailedPrecondition, "no column %q exists", t.column)
+		}
+		for i, l := 0, chunk.Len(); i < l; i++ {
+			var (
+				y  float64
+				ok bool
+			)
+			switch typ := chunk.Col(j).Type; typ {
+			case flux.TFloat:
+				vs := chunk.Floats(j)
+				y, ok = vs.Value(i), vs.IsValid(i)
+			case flux.TInt:
+				vs := chunk.Ints(j)
+				y, ok = float64(vs.Value(i)), vs.IsValid(i)
+			case flux.TUInt:
+				vs := chunk.Uints(j)
+				y, ok = float64(vs.Value(i)), vs.IsValid(i)
+			default:
+				return errors.Newf(codes.FailedPrecondition, "cannot decompose column %q of type %s", t.column, typ)
+			}
+			if !ok {
+				y = 0
+			} else {
+				count++
+			}
+			ys, valid = append(ys, y), append(valid, ok)
+		}
+	}
+
+	var trend, seasonal, residual []float64
+	if len(ys) > 0 {
+		if min := 2 * t.stl.period; count < min {
+			return errors.Newf(codes.FailedPrecondition, "decompose requires at least %d non-null values, got %d", min, count)
+		}
+		trend, seasonal, residual = t.stl.decompose(ys, valid)
+	}
+
+	n := 0
+	for _, chunk := range s.chunks {
+		l := chunk.Len()
+		tb, sb, rb := array.NewFloatBuilder(mem), array.NewFloatBuilder(mem), array.NewFloatBuilder(mem)
+		tb.Resize(l)
+		sb.Resize(l)
+		rb.Resize(l)
+		for i := 0; i < l; i++ {
+			tb.Append(trend[n])
+			sb.Append(seasonal[n])
+			if valid[n] {
+				rb.Append(residual[n])
+			} else {
+				rb.AppendNull()
+			}
+			n++
+		}
+
+		buffer := chunk.Buffer()
+		out := arrow.TableBuffer{
+			GroupKey: key,
+			Columns: append(append(make([]flux.ColMeta, 0, len(buffer.Columns)+3), buffer.Columns...),
+				flux.ColMeta{Label: TrendColumn, Type: flux.TFloat},
+				flux.ColMeta{Label: SeasonalColumn, Type: flux.TFloat},
+				flux.ColMeta{Label: ResidualColumn, Type: flux.TFloat},
+			),
+			Values: make([]array.Array, 0, len(buffer.Values)+3),
+		}
+		for _, vs := range buffer.Values {
+			vs.Retain()
+			out.Values = append(out.Values, vs)
+		}
+		out.Values = append(out.Values, tb.NewArray(), sb.NewArray(), rb.NewArray())
+		if err := d.Process(table.ChunkFromBuffer(out)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *decomposeTransformation) Close() error { return nil }
@@ -0,0 +1,128 @@
+package timeseries_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/stdlib/experimental/timeseries"
+)
+
+var inputCols = []flux.ColMeta{
+	{Label: "_time", Type: flux.TTime},
+	{Label: "_value", Type: flux.TFloat},
+	{Label: "t0", Type: flux.TString},
+}
+
+var outputCols = append(append([]flux.ColMeta{}, inputCols...),
+	flux.ColMeta{Label: "trend", Type: flux.TFloat},
+	flux.ColMeta{Label: "seasonal", Type: flux.TFloat},
+	flux.ColMeta{Label: "residual", Type: flux.TFloat},
+)
+
+// seasonalTable creates a table with a linear trend of 2 per row
+// and a seasonal pattern of 1 and -1. The rows at the null indices
+// have a null value.
+func seasonalTable(n int, nulls ...int) (input, want *executetest.Table) {
+	input = &executetest.Table{KeyCols: []string{"t0"}, ColMeta: inputCols}
+	want = &executetest.Table{KeyCols: []string{"t0"}, ColMeta: outputCols}
+	for i := 0; i < n; i++ {
+		trend, seasonal := 2*float64(i), 1.0
+		if i%2 == 1 {
+			seasonal = -1
+		}
+		var v, residual interface{} = trend + seasonal, 0.0
+		for _, j := range nulls {
+			if i == j {
+				v, residual = nil, nil
+			}
+		}
+		input.Data = append(input.Data, []interface{}{execute.Time(i * 10), v, "a"})
+		want.Data = append(want.Data, []interface{}{execute.Time(i * 10), v, "a", trend, seasonal, residual})
+	}
+	return input, want
+}
+
+func TestDecompose(t *testing.T) {
+	exact, exactWant := seasonalTable(12)
+	missing, missingWant := seasonalTable(12, 5)
+	robustMissing, robustMissingWant := seasonalTable(12, 5)
+	short, _ := seasonalTable(3)
+	strings, _ := seasonalTable(12)
+	testCases := []struct {
+		name    string
+		spec    *timeseries.DecomposeProcedureSpec
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "exact",
+			spec: &timeseries.DecomposeProcedureSpec{
+				Seasonality: 2,
+				Column:      execute.DefaultValueColLabel,
+			},
+			data: []flux.Table{exact},
+			want: []*executetest.Table{exactWant},
+		},
+		{
+			name: "missing value",
+			spec: &timeseries.DecomposeProcedureSpec{
+				Seasonality: 2,
+				Column:      execute.DefaultValueColLabel,
+			},
+			data: []flux.Table{missing},
+			want: []*executetest.Table{missingWant},
+		},
+		{
+			name: "robust missing value",
+			spec: &timeseries.DecomposeProcedureSpec{
+				Seasonality: 2,
+				Robust:      true,
+				Column:      execute.DefaultValueColLabel,
+			},
+			data: []flux.Table{robustMissing},
+			want: []*executetest.Table{robustMissingWant},
+		},
+		{
+			name: "too few values",
+			spec: &timeseries.DecomposeProcedureSpec{
+				Seasonality: 2,
+				Column:      execute.DefaultValueColLabel,
+			},
+			data:    []flux.Table{short},
+			wantErr: errors.New(codes.FailedPrecondition, "decompose requires at least 4 non-null values, got 3"),
+		},
+		{
+			name: "unsupported type",
+			spec: &timeseries.DecomposeProcedureSpec{
+				Seasonality: 2,
+				Column:      "t0",
+			},
+			data:    []flux.Table{strings},
+			wantErr: errors.New(codes.FailedPrecondition, `cannot decompose column "t0" of type string`),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper2(
+				t,
+				tc.data,
+				tc.want,
+				tc.wantErr,
+				func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+					tr, d, err := timeseries.NewDecomposeTransformation(id, tc.spec, alloc)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return tr, d
+				},
+			)
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package experimental
+
+import (
+	"bytes"
+
+	"github.com/apache/arrow/go/v7/arrow/memory"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/internal/execute/table"
+	"github.com/influxdata/flux/lineprotocol"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/runtime"
+)
+
+const ToLineProtocolKind = "experimental.toLineProtocol"
+
+type ToLineProtocolOpSpec struct {
+	lineprotocol.EncoderConfig
+}
+
+func init() {
+	toLineProtocolSig := runtime.MustLookupBuiltinType("experimental", "toLineProtocol")
+
+	runtime.RegisterPackageValue("experimental", "toLineProtocol", flux.MustValue(flux.FunctionValue(ToLineProtocolKind, createToLineProtocolOpSpec, toLineProtocolSig)))
+	plan.RegisterProcedureSpec(ToLineProtocolKind, newToLineProtocolProcedure, ToLineProtocolKind)
+	execute.RegisterTransformation(ToLineProtocolKind, createToLineProtocolTransformation)
+}
+
+func createToLineProtocolOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(ToLineProtocolOpSpec)
+	if err := spec.ReadArgs(args); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func (s *ToLineProtocolOpSpec) Kind() flux.OperationKind {
+	return ToLineProtocolKind
+}
+
+type ToLineProtocolProcedureSpec struct {
+	plan.DefaultCost
+	Config lineprotocol.EncoderConfig
+}
+
+func newToLineProtocolProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*ToLineProtocolOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+
+	return &ToLineProtocolProcedureSpec{
+		Config: spec.EncoderConfig,
+	}, nil
+}
+
+func (s *ToLineProtocolProcedureSpec) Kind() plan.ProcedureKind {
+	return ToLineProtocolKind
+}
+
+func (s *ToLineProtocolProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(ToLineProtocolProcedureSpec)
+	*ns = *s
+	ns.Config.TagColumns = copyStrings(s.Config.TagColumns)
+	ns.Config.FieldColumns = copyStrings(s.Config.FieldColumns)
+	return ns
+}
+
+func copyStrings(strs []string) []string {
+	if strs == nil {
+		return nil
+	}
+	return append([]string(nil), strs...)
+}
+
+func createToLineProtocolTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*ToLineProtocolProcedureSpec)
+	if !ok {
+		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
+	}
+	return NewToLineProtocolTransformation(s, id, a.Allocator())
+}
+
+func NewToLineProtocolTransformation(spec *ToLineProtocolProcedureSpec, id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	t := &toLineProtocolTransformation{
+		config: spec.Config,
+	}
+	return execute.NewNarrowTransformation(id, t, alloc)
+}
+
+type toLineProtocolTransformation struct {
+	execute.ExecutionNode
+	config lineprotocol.EncoderConfig
+}
+
+func (t *toLineProtocolTransformation) Close() error { return nil }
+
+// Process encodes each row of the chunk as a line of line protocol.
+// The output retains the group key columns and stores the lines in the _value column.
+func (t *toLineProtocolTransformation) Process(chunk table.Chunk, d *execute.TransportDataset, mem memory.Allocator) error {
+	key := chunk.Key()
+	if key.HasCol(execute.DefaultValueColLabel) {
+		return errors.Newf(codes.FailedPrecondition, "cannot encode line protocol: group key contains the %q column", execute.DefaultValueColLabel)
+	}
+
+	lines := array.NewStringBuilder(mem)
+	if chunk.Len() > 0 {
+		enc, err := lineprotocol.NewTableEncoder(t.config, key, chunk.Cols())
+		if err != nil {
+			return err
+		}
+		buf := chunk.Buffer()
+		var line []byte
+		for i, n := 0, chunk.Len(); i < n; i++ {
+			line, err = enc.AppendLine(line[:0], &buf, i)
+			if err != nil {
+				lines.Release()
+				return err
+			}
+			if len(line) > 0 {
+				lines.Append(string(bytes.TrimSuffix(line, []byte("\n"))))
+			}
+		}
+	}
+	values := lines.NewStringArray()
+
+	cols := make([]flux.ColMeta, 0, len(key.Cols())+1)
+	cols = append(cols, key.Cols()...)
+	cols = append(cols, flux.ColMeta{Label: execute.DefaultValueColLabel, Type: flux.TString})
+
+	buffer := arrow.TableBuffer{
+		GroupKey: key,
+		Columns:  cols,
+		Values:   make([]array.Array, 0, len(cols)),
+	}
+	for j, c := range key.Cols() {
+		buffer.Values = append(buffer.Values, arrow.Repeat(c.Type, key.Value(j), values.Len(), mem))
+	}
+	buffer.Values = append(buffer.Values, values)
+	return d.Process(table.ChunkFromBuffer(buffer))
+}
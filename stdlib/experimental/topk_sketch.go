@@ -0,0 +1,462 @@
+package experimental
+
+import (
+	"container/heap"
+	"encoding/binary"
+	"math"
+	"sort"
+
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/runtime"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+const TopKSketchKind = "experimental.topkSketch"
+
+const (
+	topkSketchCountColLabel = "_count"
+	topkSketchErrorColLabel = "_error"
+
+	// defaultTopKSketchCapacityFactor is the number of counters
+	// kept for each requested row when no capacity is given.
+	defaultTopKSketchCapacityFactor = 10
+)
+
+type TopKSketchOpSpec struct {
+	N        int64    `json:"n"`
+	Columns  []string `json:"columns"`
+	Capacity int64    `json:"capacity"`
+}
+
+func init() {
+	topkSketchSignature := runtime.MustLookupBuiltinType("experimental", "topkSketch")
+	runtime.RegisterPackageValue("experimental", "topkSketch", flux.MustValue(flux.FunctionValue(TopKSketchKind, createTopKSketchOpSpec, topkSketchSignature)))
+	plan.RegisterProcedureSpec(TopKSketchKind, newTopKSketchProcedure, TopKSketchKind)
+	execute.RegisterTransformation(TopKSketchKind, createTopKSketchTransformation)
+}
+
+func createTopKSketchOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(TopKSketchOpSpec)
+	n, err := args.GetRequiredInt("n")
+	if err != nil {
+		return nil, err
+	} else if n <= 0 {
+		return nil, errors.Newf(codes.Invalid, "n must be a positive integer, but was %d", n)
+	}
+	spec.N = n
+
+	if cols, ok, err := args.GetArray("columns", semantic.String); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Columns, err = interpreter.ToStringArray(cols)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		spec.Columns = []string{execute.DefaultValueColLabel}
+	}
+
+	spec.Capacity = defaultTopKSketchCapacityFactor * n
+	if capacity, ok, err := args.GetInt("capacity"); err != nil {
+		return nil, err
+	} else if ok {
+		if capacity < n {
+			return nil, errors.Newf(codes.Invalid, "capacity must be at least n (%d), but was %d", n, capacity)
+		}
+		spec.Capacity = capacity
+	}
+	return spec, nil
+}
+
+func (s *TopKSketchOpSpec) Kind() flux.OperationKind {
+	return TopKSketchKind
+}
+
+type TopKSketchProcedureSpec struct {
+	plan.DefaultCost
+	N        int64
+	Columns  []string
+	Capacity int64
+}
+
+func newTopKSketchProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*TopKSketchOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+	return &TopKSketchProcedureSpec{
+		N:        spec.N,
+		Columns:  spec.Columns,
+		Capacity: spec.Capacity,
+	}, nil
+}
+
+func (s *TopKSketchProcedureSpec) Kind() plan.ProcedureKind {
+	return TopKSketchKind
+}
+
+func (s *TopKSketchProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	ns.Columns = make([]string, len(s.Columns))
+	copy(ns.Columns, s.Columns)
+	return &ns
+}
+
+func createTopKSketchTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*TopKSketchProcedureSpec)
+	if !ok {
+		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
+	}
+	return NewTopKSketchTransformation(id, a.Parents(), s, a.Allocator())
+}
+
+type topkSketchTransformation struct {
+	n        int
+	columns  []string
+	capacity int
+}
+
+// NewTopKSketchTransformation creates a transformation that estimates
+// the n most frequent combinations of values in the columns of each group.
+//
+// Each group keeps a fixed number of counters, so the memory used does not
+// depend on the number of distinct values. When there are multiple parents,
+// the sketches of each parent are merged before the result is computed.
+func NewTopKSketchTransformation(id execute.DatasetID, parents []execute.DatasetID, spec *TopKSketchProcedureSpec, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	tr := &topkSketchTransformation{
+		n:        int(spec.N),
+		columns:  spec.Columns,
+		capacity: int(spec.Capacity),
+	}
+	if tr.capacity < tr.n {
+		tr.capacity = tr.n
+	}
+	return execute.NewAggregateParallelTransformation(id, parents, tr, mem)
+}
+
+// topkSketchState is the sketch of a group.
+type topkSketchState struct {
+	// cols are the value columns that are counted.
+	cols   []flux.ColMeta
+	sketch *spaceSaving
+}
+
+func (t *topkSketchTransformation) Aggregate(chunk table.Chunk, state interface{}, mem memory.Allocator) (interface{}, bool, error) {
+	indices := make([]int, len(t.columns))
+	cols := make([]flux.ColMeta, len(t.columns))
+	for j, label := range t.columns {
+		idx := chunk.Index(label)
+		if idx < 0 {
+			return nil, false, errors.Newf(codes.FailedPrecondition, "column %q does not exist", label)
+		}
+		indices[j], cols[j] = idx, chunk.Col(idx)
+	}
+
+	var s *topkSketchState
+	if state != nil {
+		s = state.(*topkSketchState)
+		if err := checkTopKSketchCols(s.cols, cols); err != nil {
+			return nil, false, err
+		}
+	} else {
+		s = &topkSketchState{
+			cols:   cols,
+			sketch: newSpaceSaving(t.capacity),
+		}
+	}
+
+	buffer := chunk.Buffer()
+	var key []byte
+	for i, l := 0, chunk.Len(); i < l; i++ {
+		vs := make([]values.Value, len(indices))
+		key = key[:0]
+		for j, idx := range indices {
+			vs[j] = execute.ValueForRow(&buffer, i, idx)
+			key = appendSketchKey(key, vs[j])
+		}
+		s.sketch.Add(string(key), vs, 1)
+	}
+	return s, true, nil
+}
+
+func (t *topkSketchTransformation) Merge(into, from interface{}, mem memory.Allocator) (interface{}, error) {
+	intoState := into.(*topkSketchState)
+	fromState := from.(*topkSketchState)
+	if err := checkTopKSketchCols(intoState.cols, fromState.cols); err != nil {
+		return nil, err
+	}
+	intoState.sketch.Merge(fromState.sketch)
+	return intoState, nil
+}
+
+func checkTopKSketchCols(want, got []flux.ColMeta) error {
+	for j := range want {
+		if want[j].Type != got[j].Type {
+			return errors.Newf(codes.FailedPrecondition, "schema collision detected: column %q is both of type %s and %s", want[j].Label, want[j].Type, got[j].Type)
+		}
+	}
+	return nil
+}
+
+func (t *topkSketchTransformation) Compute(key flux.GroupKey, state interface{}, d *execute.TransportDataset, mem memory.Allocator) error {
+	s := state.(*topkSketchState)
+	top := s.sketch.Top(t.n)
+
+	// The value columns that are also part of the group key
+	// are only written once.
+	cols := make([]flux.ColMeta, 0, len(key.Cols())+len(s.cols)+2)
+	cols = append(cols, key.Cols()...)
+	var valueCols []int
+	for j, col := range s.cols {
+		if !key.HasCol(col.Label) {
+			cols = append(cols, col)
+			valueCols = append(valueCols, j)
+		}
+	}
+	cols = append(cols,
+		flux.ColMeta{Label: topkSketchCountColLabel, Type: flux.TInt},
+		flux.ColMeta{Label: topkSketchErrorColLabel, Type: flux.TInt},
+	)
+
+	vs := make([]array.Array, 0, len(cols))
+	for j := range key.Cols() {
+		vs = append(vs, arrow.Repeat(key.Cols()[j].Type, key.Value(j), len(top), mem))
+	}
+	for _, j := range valueCols {
+		b := arrow.NewBuilder(s.cols[j].Type, mem)
+		b.Resize(len(top))
+		for _, c := range top {
+			if err := arrow.AppendValue(b, c.values[j]); err != nil {
+				return err
+			}
+		}
+		vs = append(vs, b.NewArray())
+	}
+	counts := array.NewIntBuilder(mem)
+	errs := array.NewIntBuilder(mem)
+	counts.Resize(len(top))
+	errs.Resize(len(top))
+	for _, c := range top {
+		counts.Append(c.count)
+		errs.Append(c.error)
+	}
+	vs = append(vs, counts.NewArray(), errs.NewArray())
+
+	return d.Process(table.ChunkFromBuffer(arrow.TableBuffer{
+		GroupKey: key,
+		Columns:  cols,
+		Values:   vs,
+	}))
+}
+
+func (t *topkSketchTransformation) Close() error {
+	return nil
+}
+
+// appendSketchKey appends an encoding of the value that is unique
+// for each value of a column type.
+func appendSketchKey(key []byte, v values.Value) []byte {
+	if v.IsNull() {
+		return append(key, 0)
+	}
+	var buf [binary.MaxVarintLen64]byte
+	key = append(key, 1)
+	switch v.Type().Nature() {
+	case semantic.String:
+		n := binary.PutUvarint(buf[:], uint64(len(v.Str())))
+		key = append(key, buf[:n]...)
+		key = append(key, v.Str()...)
+	case semantic.Int:
+		n := binary.PutVarint(buf[:], v.Int())
+		key = append(key, buf[:n]...)
+	case semantic.UInt:
+		n := binary.PutUvarint(buf[:], v.UInt())
+		key = append(key, buf[:n]...)
+	case semantic.Float:
+		n := binary.PutUvarint(buf[:], math.Float64bits(v.Float()))
+		key = append(key, buf[:n]...)
+	case semantic.Bool:
+		if v.Bool() {
+			key = append(key, 1)
+		} else {
+			key = append(key, 0)
+		}
+	case semantic.Time:
+		n := binary.PutVarint(buf[:], int64(v.Time()))
+		key = append(key, buf[:n]...)
+	}
+	return key
+}
+
+// spaceSaving is a Space-Saving sketch that counts the most
+// frequent items of a stream with a fixed number of counters.
+//
+// The count of an item is never underestimated and is overestimated
+// by at most the error of its counter. The error is bounded by the
+// total count divided by the capacity.
+type spaceSaving struct {
+	capacity int
+	counters map[string]*spaceSavingCounter
+	// heap orders the counters by count with the smallest first,
+	// so the counter to replace is at the root.
+	heap spaceSavingHeap
+}
+
+type spaceSavingCounter struct {
+	key    string
+	values []values.Value
+	count  int64
+	error  int64
+	index  int
+}
+
+func newSpaceSaving(capacity int) *spaceSaving {
+	return &spaceSaving{
+		capacity: capacity,
+		counters: make(map[string]*spaceSavingCounter, capacity),
+		heap:     make(spaceSavingHeap, 0, capacity),
+	}
+}
+
+// Add counts the item with the key weight times.
+func (s *spaceSaving) Add(key string, vs []values.Value, weight int64) {
+	if c, ok := s.counters[key]; ok {
+		c.count += weight
+		heap.Fix(&s.heap, c.index)
+		return
+	}
+	if len(s.heap) < s.capacity {
+		c := &spaceSavingCounter{key: key, values: vs, count: weight}
+		s.counters[key] = c
+		heap.Push(&s.heap, c)
+		return
+	}
+
+	// Replace the item with the smallest count. Its count
+	// becomes the error of the new item.
+	c := s.heap[0]
+	delete(s.counters, c.key)
+	c.key, c.values = key, vs
+	c.error = c.count
+	c.count += weight
+	s.counters[key] = c
+	heap.Fix(&s.heap, 0)
+}
+
+// min returns the smallest count that an item absent from
+// the sketch may have.
+func (s *spaceSaving) min() int64 {
+	if len(s.heap) < s.capacity {
+		return 0
+	}
+	return s.heap[0].count
+}
+
+// Merge adds the counts of the other sketch to this sketch.
+//
+// An item that is missing from one of the sketches may have been
+// counted up to that sketch's smallest count, so that count is added
+// to both the count and the error of the item. The counters with the
+// largest counts are kept.
+func (s *spaceSaving) Merge(other *spaceSaving) {
+	minThis, minOther := s.min(), other.min()
+	for key, c := range s.counters {
+		if o, ok := other.counters[key]; ok {
+			c.count += o.count
+			c.error += o.error
+		} else {
+			c.count += minOther
+			c.error += minOther
+		}
+	}
+	for key, o := range other.counters {
+		if _, ok := s.counters[key]; ok {
+			continue
+		}
+		s.counters[key] = &spaceSavingCounter{
+			key:    key,
+			values: o.values,
+			count:  o.count + minThis,
+			error:  o.error + minThis,
+		}
+	}
+
+	counters := s.sorted()
+	if len(counters) > s.capacity {
+		for _, c := range counters[s.capacity:] {
+			delete(s.counters, c.key)
+		}
+		counters = counters[:s.capacity]
+	}
+	s.heap = append(s.heap[:0], counters...)
+	for i, c := range s.heap {
+		c.index = i
+	}
+	heap.Init(&s.heap)
+}
+
+// Top returns at most n counters with the largest counts.
+func (s *spaceSaving) Top(n int) []*spaceSavingCounter {
+	counters := s.sorted()
+	if len(counters) > n {
+		counters = counters[:n]
+	}
+	return counters
+}
+
+// sorted returns the counters from the largest count to the smallest.
+// Counters with the same count are ordered by the smallest error first
+// and then by their key so the order is deterministic.
+func (s *spaceSaving) sorted() []*spaceSavingCounter {
+	counters := make([]*spaceSavingCounter, 0, len(s.counters))
+	for _, c := range s.counters {
+		counters = append(counters, c)
+	}
+	sort.Slice(counters, func(i, j int) bool {
+		if counters[i].count != counters[j].count {
+			return counters[i].count > counters[j].count
+		}
+		if counters[i].error != counters[j].error {
+			return counters[i].error < counters[j].error
+		}
+		return counters[i].key < counters[j].key
+	})
+	return counters
+}
+
+type spaceSavingHeap []*spaceSavingCounter
+
+func (h spaceSavingHeap) Len() int           { return len(h) }
+func (h spaceSavingHeap) Less(i, j int) bool { return h[i].count < h[j].count }
+func (h spaceSavingHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *spaceSavingHeap) Push(x interface{}) {
+	c := x.(*spaceSavingCounter)
+	c.index = len(*h)
+	*h = append(*h, c)
+}
+
+func (h *spaceSavingHeap) Pop() interface{} {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
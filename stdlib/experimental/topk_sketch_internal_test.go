@@ -0,0 +1,113 @@
+package experimental
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+// zipfStream returns n items drawn from a skewed distribution
+// over m distinct items, along with the true count of each item.
+func zipfStream(seed int64, n int, m uint64) ([]string, map[string]int64) {
+	z := rand.NewZipf(rand.New(rand.NewSource(seed)), 1.2, 1, m-1)
+	items := make([]string, n)
+	counts := make(map[string]int64)
+	for i := range items {
+		items[i] = strconv.FormatUint(z.Uint64(), 10)
+		counts[items[i]]++
+	}
+	return items, counts
+}
+
+// checkSketch verifies that the counts of the sketch are within
+// the error bounds of the true counts.
+func checkSketch(t *testing.T, s *spaceSaving, counts map[string]int64, total int64) {
+	t.Helper()
+	for _, c := range s.Top(len(s.counters)) {
+		want := counts[c.key]
+		if c.count < want {
+			t.Errorf("item %s: count %d is lower than the true count %d", c.key, c.count, want)
+		}
+		if c.count-c.error > want {
+			t.Errorf("item %s: count %d with error %d is higher than the true count %d", c.key, c.count, c.error, want)
+		}
+		if bound := total / int64(s.capacity); c.error > bound {
+			t.Errorf("item %s: error %d is higher than the bound %d", c.key, c.error, bound)
+		}
+	}
+}
+
+func TestSpaceSaving(t *testing.T) {
+	items, counts := zipfStream(1, 100000, 10000)
+	s := newSpaceSaving(100)
+	for _, item := range items {
+		s.Add(item, nil, 1)
+	}
+	if len(s.counters) != 100 || len(s.heap) != 100 {
+		t.Fatalf("expected 100 counters, got %d in the map and %d in the heap", len(s.counters), len(s.heap))
+	}
+	checkSketch(t, s, counts, int64(len(items)))
+
+	// The most frequent items of a skewed distribution are found.
+	for i, c := range s.Top(3) {
+		if want := strconv.Itoa(i); c.key != want {
+			t.Errorf("expected item %s at position %d, got %s", want, i, c.key)
+		}
+	}
+}
+
+func TestSpaceSaving_Merge(t *testing.T) {
+	items, counts := zipfStream(2, 100000, 10000)
+	left, right := newSpaceSaving(100), newSpaceSaving(100)
+	for i, item := range items {
+		if i%2 == 0 {
+			left.Add(item, nil, 1)
+		} else {
+			right.Add(item, nil, 1)
+		}
+	}
+	left.Merge(right)
+	if len(left.counters) != 100 || len(left.heap) != 100 {
+		t.Fatalf("expected 100 counters, got %d in the map and %d in the heap", len(left.counters), len(left.heap))
+	}
+	checkSketch(t, left, counts, int64(len(items)))
+	for i, c := range left.Top(3) {
+		if want := strconv.Itoa(i); c.key != want {
+			t.Errorf("expected item %s at position %d, got %s", want, i, c.key)
+		}
+	}
+
+	// The merged sketch can still be updated.
+	for i := 0; i < 100000; i++ {
+		left.Add("new", nil, 1)
+	}
+	if top := left.Top(1); top[0].key != "new" || top[0].count < 100000 {
+		t.Errorf("expected the new item to be the most frequent, got %s with count %d", top[0].key, top[0].count)
+	}
+}
+
+func TestSpaceSaving_MergeExact(t *testing.T) {
+	left, right := newSpaceSaving(10), newSpaceSaving(10)
+	for _, item := range []string{"a", "b", "a"} {
+		left.Add(item, nil, 1)
+	}
+	for _, item := range []string{"b", "c", "b"} {
+		right.Add(item, nil, 1)
+	}
+	left.Merge(right)
+
+	want := []struct {
+		key   string
+		count int64
+	}{{"b", 3}, {"a", 2}, {"c", 1}}
+	got := left.Top(10)
+	if len(got) != len(want) {
+		t.Fatalf("expected %d counters, got %d", len(want), len(got))
+	}
+	for i, c := range got {
+		if c.key != want[i].key || c.count != want[i].count || c.error != 0 {
+			t.Errorf("expected %s with count %d and no error at position %d, got %s with count %d and error %d",
+				want[i].key, want[i].count, i, c.key, c.count, c.error)
+		}
+	}
+}
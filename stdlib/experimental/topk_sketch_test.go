@@ -0,0 +1,144 @@
+package experimental_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/stdlib/experimental"
+)
+
+func TestTopKSketch_Process(t *testing.T) {
+	newTable := func(values ...string) *executetest.Table {
+		tbl := &executetest.Table{
+			KeyCols: []string{"t"},
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "t", Type: flux.TString},
+				{Label: "host", Type: flux.TString},
+			},
+		}
+		for i, v := range values {
+			tbl.Data = append(tbl.Data, []interface{}{execute.Time(i), "a", v})
+		}
+		return tbl
+	}
+
+	testCases := []struct {
+		name    string
+		spec    *experimental.TopKSketchProcedureSpec
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "exact",
+			spec: &experimental.TopKSketchProcedureSpec{
+				N:        2,
+				Columns:  []string{"host"},
+				Capacity: 10,
+			},
+			data: []flux.Table{newTable("x", "y", "x", "z", "y", "x")},
+			want: []*executetest.Table{{
+				KeyCols: []string{"t"},
+				ColMeta: []flux.ColMeta{
+					{Label: "t", Type: flux.TString},
+					{Label: "host", Type: flux.TString},
+					{Label: "_count", Type: flux.TInt},
+					{Label: "_error", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{"a", "x", int64(3), int64(0)},
+					{"a", "y", int64(2), int64(0)},
+				},
+			}},
+		},
+		{
+			name: "heavy hitter",
+			spec: &experimental.TopKSketchProcedureSpec{
+				N:        1,
+				Columns:  []string{"host"},
+				Capacity: 2,
+			},
+			data: []flux.Table{newTable("x", "a", "x", "b", "x", "c", "x", "d", "x")},
+			want: []*executetest.Table{{
+				KeyCols: []string{"t"},
+				ColMeta: []flux.ColMeta{
+					{Label: "t", Type: flux.TString},
+					{Label: "host", Type: flux.TString},
+					{Label: "_count", Type: flux.TInt},
+					{Label: "_error", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{"a", "x", int64(5), int64(0)},
+				},
+			}},
+		},
+		{
+			name: "multiple columns with nulls",
+			spec: &experimental.TopKSketchProcedureSpec{
+				N:        3,
+				Columns:  []string{"host", "_value"},
+				Capacity: 10,
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "host", Type: flux.TString},
+					{Label: "_value", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{"x", int64(1)},
+					{"x", nil},
+					{"x", int64(1)},
+					{nil, int64(1)},
+					{"x", nil},
+					{"x", int64(1)},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "host", Type: flux.TString},
+					{Label: "_value", Type: flux.TInt},
+					{Label: "_count", Type: flux.TInt},
+					{Label: "_error", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{"x", int64(1), int64(3), int64(0)},
+					{"x", nil, int64(2), int64(0)},
+					{nil, int64(1), int64(1), int64(0)},
+				},
+			}},
+		},
+		{
+			name: "missing column",
+			spec: &experimental.TopKSketchProcedureSpec{
+				N:        1,
+				Columns:  []string{"_value"},
+				Capacity: 10,
+			},
+			data:    []flux.Table{newTable("x")},
+			wantErr: errors.New(`column "_value" does not exist`),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper2(
+				t,
+				tc.data,
+				tc.want,
+				tc.wantErr,
+				func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+					tr, d, err := experimental.NewTopKSketchTransformation(id, []execute.DatasetID{executetest.RandomDatasetID()}, tc.spec, alloc)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return tr, d
+				},
+			)
+		})
+	}
+}
@@ -21,7 +21,10 @@ import (
 const UnpivotKind = "experimental.unpivot"
 
 type UnpivotOpSpec struct {
+	columns      []string
 	otherColumns []string
+	keyColumn    string
+	valueColumn  string
 }
 
 func init() {
@@ -39,17 +42,51 @@ func createUnpivotOpSpec(args flux.Arguments, a *flux.Administration) (flux.Oper
 
 	spec := new(UnpivotOpSpec)
 
+	if columns, ok, err := args.GetArray("columns", semantic.String); err != nil {
+		return nil, err
+	} else if ok {
+		spec.columns, err = interpreter.ToStringArray(columns)
+		if err != nil {
+			return nil, err
+		}
+		if len(spec.columns) == 0 {
+			return nil, errors.New(codes.Invalid, "unpivot requires at least one column")
+		}
+	}
+
 	if columns, ok, err := args.GetArray("otherColumns", semantic.String); err != nil {
 		return nil, err
 	} else if ok {
+		if len(spec.columns) > 0 {
+			return nil, errors.New(codes.Invalid, "unpivot cannot use both columns and otherColumns")
+		}
 		spec.otherColumns, err = interpreter.ToStringArray(columns)
 		if err != nil {
 			return nil, err
 		}
-	} else {
+	} else if len(spec.columns) == 0 {
 		spec.otherColumns = []string{execute.DefaultTimeColLabel}
 	}
 
+	if label, ok, err := args.GetString("keyColumn"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.keyColumn = label
+	} else {
+		spec.keyColumn = "_field"
+	}
+
+	if label, ok, err := args.GetString("valueColumn"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.valueColumn = label
+	} else {
+		spec.valueColumn = execute.DefaultValueColLabel
+	}
+
+	if spec.keyColumn == spec.valueColumn {
+		return nil, errors.Newf(codes.Invalid, "unpivot keyColumn and valueColumn must be different, both are %q", spec.keyColumn)
+	}
 	return spec, nil
 }
 
@@ -64,7 +101,10 @@ func newUnpivotProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.Pr
 	}
 
 	return &UnpivotProcedureSpec{
+		Columns:      opSpec.columns,
 		OtherColumns: opSpec.otherColumns,
+		KeyColumn:    opSpec.keyColumn,
+		ValueColumn:  opSpec.valueColumn,
 	}, nil
 }
 
@@ -80,7 +120,13 @@ func createUnpivotTransformation(id execute.DatasetID, mode execute.Accumulation
 
 type UnpivotProcedureSpec struct {
 	plan.DefaultCost
+	// Columns are the columns to unpivot. When it is empty,
+	// every column that is not in the group key or OtherColumns
+	// is unpivoted.
+	Columns      []string
 	OtherColumns []string
+	KeyColumn    string
+	ValueColumn  string
 }
 
 func (s *UnpivotProcedureSpec) Kind() plan.ProcedureKind {
@@ -94,7 +140,16 @@ func (s *UnpivotProcedureSpec) Copy() plan.ProcedureSpec {
 
 func NewUnpivotTransformation(spec *UnpivotProcedureSpec, id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset, error) {
 	t := &unpivotTransformation{
+		columns:      spec.Columns,
 		otherColumns: spec.OtherColumns,
+		keyColumn:    spec.KeyColumn,
+		valueColumn:  spec.ValueColumn,
+	}
+	if t.keyColumn == "" {
+		t.keyColumn = "_field"
+	}
+	if t.valueColumn == "" {
+		t.valueColumn = execute.DefaultValueColLabel
 	}
 	return execute.NewNarrowTransformation(id, t, alloc)
 
@@ -102,29 +157,32 @@ func NewUnpivotTransformation(spec *UnpivotProcedureSpec, id execute.DatasetID,
 
 type unpivotTransformation struct {
 	execute.ExecutionNode
+	columns      []string
 	otherColumns []string
+	keyColumn    string
+	valueColumn  string
 }
 
 func (t *unpivotTransformation) Close() error { return nil }
 
 func (t *unpivotTransformation) Process(chunk table.Chunk, d *execute.TransportDataset, mem memory.Allocator) error {
-	otherCols := make([]flux.ColMeta, len(t.otherColumns))
-	for i, utc := range t.otherColumns {
-		found := false
-		for _, c := range chunk.Cols() {
-			if c.Label == utc {
-				otherCols[i] = c
-				found = true
-				break
-			}
-		}
-		if !found {
-			return errors.Newf(codes.Invalid, "unpivot could not find column named %q", utc)
+	key := chunk.Key()
+	if key.HasCol(t.keyColumn) {
+		return errors.Newf(codes.Invalid, "unpivot keyColumn %q is already in the group key", t.keyColumn)
+	}
+
+	otherCols, err := t.otherCols(chunk)
+	if err != nil {
+		return err
+	}
+	for _, label := range t.columns {
+		if !chunk.HasCol(label) {
+			return errors.Newf(codes.Invalid, "unpivot could not find column named %q", label)
 		}
 	}
 
 	for i, c := range chunk.Cols() {
-		if chunk.Key().HasCol(c.Label) || execute.HasCol(c.Label, otherCols) {
+		if key.HasCol(c.Label) || execute.HasCol(c.Label, otherCols) {
 			continue
 		}
 
@@ -139,25 +197,25 @@ func (t *unpivotTransformation) Process(chunk table.Chunk, d *execute.TransportD
 		// gk_col_1
 		// ...
 		// gk_col_n
-		// _field
+		// keyColumn (_field by default)
 		// other_col_0 (one of these may be _time)
 		// other_col_1
 		// ...
 		// other_col_n
-		// _value
+		// valueColumn (_value by default)
 
-		groupKey := chunk.Key()
+		groupKey := key
 		nCols := len(groupKey.Cols()) + len(otherCols) + 2
 
 		columns := make([]flux.ColMeta, 0, nCols)
 		columns = append(columns, groupKey.Cols()...)
-		columns = append(columns, flux.ColMeta{Label: "_field", Type: flux.TString})
+		columns = append(columns, flux.ColMeta{Label: t.keyColumn, Type: flux.TString})
 		columns = append(columns, otherCols...)
-		columns = append(columns, flux.ColMeta{Label: execute.DefaultValueColLabel, Type: c.Type})
+		columns = append(columns, flux.ColMeta{Label: t.valueColumn, Type: c.Type})
 
 		groupCols := make([]flux.ColMeta, 0, len(groupKey.Cols())+1)
 		groupCols = append(groupCols, groupKey.Cols()...)
-		groupCols = append(groupCols, flux.ColMeta{Label: "_field", Type: flux.TString})
+		groupCols = append(groupCols, flux.ColMeta{Label: t.keyColumn, Type: flux.TString})
 
 		groupValues := make([]values.Value, 0, len(groupKey.Cols())+1)
 		groupValues = append(groupValues, groupKey.Values()...)
@@ -186,7 +244,7 @@ func (t *unpivotTransformation) Process(chunk table.Chunk, d *execute.TransportD
 			}
 			buffer.Values = append(buffer.Values, values)
 		}
-		// append the name of the value column into _field
+		// append the name of the value column into the key column
 		buffer.Values = append(buffer.Values, array.StringRepeat(c.Label, newChunkLen, mem))
 
 		// Copy cols that are neither group columns nor value columns
@@ -212,3 +270,38 @@ func (t *unpivotTransformation) Process(chunk table.Chunk, d *execute.TransportD
 
 	return nil
 }
+
+// otherCols returns the columns that are copied to every output table.
+// When the columns to unpivot are listed, these are the columns
+// that are neither in the group key nor unpivoted.
+func (t *unpivotTransformation) otherCols(chunk table.Chunk) ([]flux.ColMeta, error) {
+	if len(t.columns) > 0 {
+		otherCols := make([]flux.ColMeta, 0, chunk.NCols())
+		for _, c := range chunk.Cols() {
+			if chunk.Key().HasCol(c.Label) || execute.ContainsStr(t.columns, c.Label) {
+				continue
+			}
+			if c.Label == t.keyColumn || c.Label == t.valueColumn {
+				return nil, errors.Newf(codes.Invalid, "unpivot output column %q already exists in the input", c.Label)
+			}
+			otherCols = append(otherCols, c)
+		}
+		return otherCols, nil
+	}
+
+	otherCols := make([]flux.ColMeta, len(t.otherColumns))
+	for i, utc := range t.otherColumns {
+		found := false
+		for _, c := range chunk.Cols() {
+			if c.Label == utc {
+				otherCols[i] = c
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errors.Newf(codes.Invalid, "unpivot could not find column named %q", utc)
+		}
+	}
+	return otherCols, nil
+}
@@ -0,0 +1,183 @@
+package wasm
+
+import (
+	"context"
+	"math"
+
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/dependencies/filesystem"
+	wasmdep "github.com/influxdata/flux/dependencies/wasm"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/internal/function"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+const pkgpath = "experimental/wasm"
+
+func init() {
+	b := function.ForPackage(pkgpath)
+	b.RegisterContext("call", func(ctx context.Context, args *function.Arguments) (values.Value, error) {
+		return call(ctx, args, semantic.Float)
+	})
+	b.RegisterContext("callInt", func(ctx context.Context, args *function.Arguments) (values.Value, error) {
+		return call(ctx, args, semantic.Int)
+	})
+}
+
+// call invokes an exported function of a module and converts
+// its result to a value with the nature.
+func call(ctx context.Context, args *function.Arguments, nature semantic.Nature) (values.Value, error) {
+	path, err := args.GetRequiredString("module")
+	if err != nil {
+		return nil, err
+	}
+	name, err := args.GetRequiredString("fn")
+	if err != nil {
+		return nil, err
+	}
+	params, err := arguments(args)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := wasmdep.GetModule(ctx, path, func() ([]byte, error) {
+		code, err := filesystem.ReadFile(ctx, path)
+		if err != nil {
+			return nil, errors.Wrapf(err, codes.Inherit, "cannot read module %q", path)
+		}
+		return code, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result values.Value
+	if err := m.Call(ctx, name, func(f wasmdep.Function) error {
+		encoded, err := encode(name, f.ParamTypes(), params)
+		if err != nil {
+			return err
+		}
+		results, err := f.Call(ctx, encoded...)
+		if err != nil {
+			return errors.Wrapf(err, codes.Inherit, "call to %q failed", name)
+		}
+		result, err = decode(name, f.ResultTypes(), results, nature)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// arguments reads the optional args parameter.
+func arguments(args *function.Arguments) ([]values.Value, error) {
+	v, ok := args.Get("args")
+	if !ok {
+		return nil, nil
+	}
+	if v.Type().Nature() != semantic.Array {
+		return nil, errors.Newf(codes.Invalid, "args must be an array but was %v", v.Type())
+	}
+	arr := v.Array()
+	params := make([]values.Value, 0, arr.Len())
+	arr.Range(func(i int, v values.Value) {
+		params = append(params, v)
+	})
+	return params, nil
+}
+
+// encode converts the arguments to the parameter types of a function.
+func encode(name string, types []wasmdep.ValueType, params []values.Value) ([]uint64, error) {
+	if len(params) != len(types) {
+		return nil, errors.Newf(codes.Invalid, "function %q expects %d arguments but was called with %d", name, len(types), len(params))
+	}
+
+	encoded := make([]uint64, len(params))
+	for i, v := range params {
+		if v.IsNull() {
+			return nil, errors.Newf(codes.Invalid, "argument %d to function %q is null", i, name)
+		}
+
+		var (
+			n      int64
+			f      float64
+			isReal bool
+		)
+		switch v.Type().Nature() {
+		case semantic.Int:
+			n = v.Int()
+		case semantic.UInt:
+			n = int64(v.UInt())
+		case semantic.Float:
+			f, isReal = v.Float(), true
+		default:
+			return nil, errors.Newf(codes.Invalid, "argument %d to function %q must be an int, uint or float but was %v", i, name, v.Type())
+		}
+
+		switch types[i] {
+		case wasmdep.ValueTypeI32:
+			if isReal {
+				n = int64(f)
+			}
+			if n < math.MinInt32 || n > math.MaxUint32 {
+				return nil, errors.Newf(codes.Invalid, "argument %d to function %q overflows i32", i, name)
+			}
+			encoded[i] = wasmdep.EncodeI32(int32(n))
+		case wasmdep.ValueTypeI64:
+			if isReal {
+				n = int64(f)
+			}
+			encoded[i] = wasmdep.EncodeI64(n)
+		case wasmdep.ValueTypeF32:
+			if !isReal {
+				f = float64(n)
+			}
+			encoded[i] = wasmdep.EncodeF32(float32(f))
+		case wasmdep.ValueTypeF64:
+			if !isReal {
+				f = float64(n)
+			}
+			encoded[i] = wasmdep.EncodeF64(f)
+		default:
+			return nil, errors.Newf(codes.Invalid, "parameter %d of function %q has unsupported type %v", i, name, types[i])
+		}
+	}
+	return encoded, nil
+}
+
+// decode converts the result of a function to a value with the nature.
+func decode(name string, types []wasmdep.ValueType, results []uint64, nature semantic.Nature) (values.Value, error) {
+	if len(types) != 1 || len(results) != 1 {
+		return nil, errors.Newf(codes.Invalid, "function %q must return exactly one value", name)
+	}
+
+	var (
+		n      int64
+		f      float64
+		isReal bool
+	)
+	switch types[0] {
+	case wasmdep.ValueTypeI32:
+		n = int64(wasmdep.DecodeI32(results[0]))
+	case wasmdep.ValueTypeI64:
+		n = wasmdep.DecodeI64(results[0])
+	case wasmdep.ValueTypeF32:
+		f, isReal = float64(wasmdep.DecodeF32(results[0])), true
+	case wasmdep.ValueTypeF64:
+		f, isReal = wasmdep.DecodeF64(results[0]), true
+	default:
+		return nil, errors.Newf(codes.Invalid, "result of function %q has unsupported type %v", name, types[0])
+	}
+
+	if nature == semantic.Int {
+		if isReal {
+			return values.NewInt(int64(f)), nil
+		}
+		return values.NewInt(n), nil
+	}
+	if !isReal {
+		f = float64(n)
+	}
+	return values.NewFloat(f), nil
+}
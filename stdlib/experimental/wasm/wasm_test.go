@@ -0,0 +1,145 @@
+package wasm
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/dependencies/filesystem"
+	wasmdep "github.com/influxdata/flux/dependencies/wasm"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/internal/function"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+// scaleFunction multiplies an f64 by an i32.
+type scaleFunction struct{}
+
+func (scaleFunction) ParamTypes() []wasmdep.ValueType {
+	return []wasmdep.ValueType{wasmdep.ValueTypeF64, wasmdep.ValueTypeI32}
+}
+
+func (scaleFunction) ResultTypes() []wasmdep.ValueType {
+	return []wasmdep.ValueType{wasmdep.ValueTypeF64}
+}
+
+func (scaleFunction) Call(ctx context.Context, params ...uint64) ([]uint64, error) {
+	v := wasmdep.DecodeF64(params[0]) * float64(wasmdep.DecodeI32(params[1]))
+	return []uint64{wasmdep.EncodeF64(v)}, nil
+}
+
+type mockModule struct{}
+
+func (mockModule) Function(name string) (wasmdep.Function, bool) {
+	if name != "scale" {
+		return nil, false
+	}
+	return scaleFunction{}, true
+}
+
+func (mockModule) Close(ctx context.Context) error { return nil }
+
+type mockRuntime struct {
+	code []byte
+}
+
+func (r *mockRuntime) Instantiate(ctx context.Context, name string, code []byte) (wasmdep.Module, error) {
+	r.code = code
+	return mockModule{}, nil
+}
+
+func testContext(rt wasmdep.Runtime) context.Context {
+	deps := flux.NewDefaultDependencies()
+	deps.Deps.FilesystemService = filesystem.SystemFS
+	return wasmdep.Inject(deps.Inject(context.Background()), rt)
+}
+
+func TestCall(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scale.wasm")
+	if err := ioutil.WriteFile(path, []byte("\x00asm"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rt := &mockRuntime{}
+	for _, tt := range []struct {
+		nature semantic.Nature
+		args   []values.Value
+		want   values.Value
+	}{
+		{
+			nature: semantic.Float,
+			args:   []values.Value{values.NewFloat(1.5), values.NewInt(3)},
+			want:   values.NewFloat(4.5),
+		},
+		{
+			nature: semantic.Float,
+			args:   []values.Value{values.NewInt(2), values.NewFloat(3.9)},
+			want:   values.NewFloat(6),
+		},
+		{
+			nature: semantic.Int,
+			args:   []values.Value{values.NewFloat(1.5), values.NewUInt(3)},
+			want:   values.NewInt(4),
+		},
+	} {
+		args := values.NewObjectWithValues(map[string]values.Value{
+			"module": values.NewString(path),
+			"fn":     values.NewString("scale"),
+			"args":   values.NewArrayWithBacking(semantic.NewArrayType(tt.args[0].Type()), tt.args),
+		})
+		got, err := function.InvokeContext(func(ctx context.Context, args *function.Arguments) (values.Value, error) {
+			return call(ctx, args, tt.nature)
+		}, testContext(rt), args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("unexpected result -want/+got:\n\t- %v\n\t+ %v", tt.want, got)
+		}
+	}
+	if got, want := string(rt.code), "\x00asm"; got != want {
+		t.Errorf("unexpected module code -want/+got:\n\t- %q\n\t+ %q", want, got)
+	}
+}
+
+func TestEncode_Errors(t *testing.T) {
+	types := scaleFunction{}.ParamTypes()
+	for _, tt := range []struct {
+		name   string
+		params []values.Value
+		want   string
+	}{
+		{
+			name:   "wrong number of arguments",
+			params: []values.Value{values.NewFloat(1)},
+			want:   `function "scale" expects 2 arguments but was called with 1`,
+		},
+		{
+			name:   "null",
+			params: []values.Value{values.NewFloat(1), values.NewNull(semantic.BasicInt)},
+			want:   `argument 1 to function "scale" is null`,
+		},
+		{
+			name:   "overflow",
+			params: []values.Value{values.NewFloat(1), values.NewInt(1 << 40)},
+			want:   `argument 1 to function "scale" overflows i32`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := encode("scale", types, tt.params)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if got := errors.Code(err); got != codes.Invalid {
+				t.Errorf("unexpected error code: %v", got)
+			}
+			if got := err.Error(); got != tt.want {
+				t.Errorf("unexpected error -want/+got:\n\t- %s\n\t+ %s", tt.want, got)
+			}
+		})
+	}
+}
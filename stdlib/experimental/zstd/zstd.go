@@ -0,0 +1,59 @@
+package zstd
+
+import (
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/internal/function"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+	"github.com/klauspost/compress/zstd"
+)
+
+// The encoder and decoder are safe for concurrent use
+// when they are only used to compress whole values.
+var (
+	encoder, _ = zstd.NewWriter(nil)
+	decoder, _ = zstd.NewReader(nil)
+)
+
+// data reads the v argument that may be a string or bytes.
+func data(args *function.Arguments) ([]byte, error) {
+	v, err := args.GetRequired("v")
+	if err != nil {
+		return nil, err
+	}
+	switch v.Type().Nature() {
+	case semantic.String:
+		return []byte(v.Str()), nil
+	case semantic.Bytes:
+		return v.Bytes(), nil
+	default:
+		return nil, errors.Newf(codes.Invalid, "expected string or bytes for %q, got %s", "v", v.Type())
+	}
+}
+
+func encode(args *function.Arguments) (values.Value, error) {
+	v, err := data(args)
+	if err != nil {
+		return nil, err
+	}
+	return values.NewBytes(encoder.EncodeAll(v, nil)), nil
+}
+
+func decode(args *function.Arguments) (values.Value, error) {
+	v, err := data(args)
+	if err != nil {
+		return nil, err
+	}
+	b, err := decoder.DecodeAll(v, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, codes.Invalid, "invalid zstd data")
+	}
+	return values.NewBytes(b), nil
+}
+
+func init() {
+	b := function.ForPackage("experimental/zstd")
+	b.Register("encode", encode)
+	b.Register("decode", decode)
+}
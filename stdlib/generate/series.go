@@ -0,0 +1,207 @@
+package generate
+
+import (
+	"context"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/internal/gen"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/runtime"
+)
+
+const SeriesKind = "generate.series"
+
+const (
+	defaultSeriesKind  = "float"
+	defaultSeriesEvery = 10 * time.Second
+	// seriesTag is the name of the tag that distinguishes
+	// the series when the cardinality is set.
+	seriesTag = "tag"
+)
+
+// seriesKinds maps the kinds of series to the type of their values.
+var seriesKinds = map[string]flux.ColType{
+	"float":  flux.TFloat,
+	"int":    flux.TInt,
+	"uint":   flux.TUInt,
+	"string": flux.TString,
+	"bool":   flux.TBool,
+}
+
+type SeriesOpSpec struct {
+	N           int64         `json:"n"`
+	Type        string        `json:"kind"`
+	Nulls       float64       `json:"nulls,omitempty"`
+	Cardinality int64         `json:"cardinality,omitempty"`
+	Seed        int64         `json:"seed"`
+	Start       *flux.Time    `json:"start,omitempty"`
+	Every       flux.Duration `json:"every"`
+}
+
+func init() {
+	seriesSignature := runtime.MustLookupBuiltinType("generate", "series")
+	runtime.RegisterPackageValue("generate", "series", flux.MustValue(flux.FunctionValue(SeriesKind, createSeriesOpSpec, seriesSignature)))
+	plan.RegisterProcedureSpec(SeriesKind, newSeriesProcedure, SeriesKind)
+	execute.RegisterSource(SeriesKind, createSeriesSource)
+}
+
+func createSeriesOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	spec := &SeriesOpSpec{
+		Type:  defaultSeriesKind,
+		Every: flux.ConvertDuration(defaultSeriesEvery),
+	}
+
+	if n, err := args.GetRequiredInt("n"); err != nil {
+		return nil, err
+	} else if n <= 0 {
+		return nil, errors.New(codes.Invalid, "n must be positive")
+	} else {
+		spec.N = n
+	}
+
+	if kind, ok, err := args.GetString("kind"); err != nil {
+		return nil, err
+	} else if ok {
+		if _, ok := seriesKinds[kind]; !ok {
+			return nil, errors.Newf(codes.Invalid, "invalid series kind %q", kind)
+		}
+		spec.Type = kind
+	}
+
+	if nulls, ok, err := args.GetFloat("nulls"); err != nil {
+		return nil, err
+	} else if ok {
+		if nulls < 0 || nulls > 1 {
+			return nil, errors.New(codes.Invalid, "nulls must be between 0.0 and 1.0")
+		}
+		spec.Nulls = nulls
+	}
+
+	if cardinality, ok, err := args.GetInt("cardinality"); err != nil {
+		return nil, err
+	} else if ok {
+		if cardinality <= 0 {
+			return nil, errors.New(codes.Invalid, "cardinality must be positive")
+		}
+		spec.Cardinality = cardinality
+	}
+
+	if seed, ok, err := args.GetInt("seed"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Seed = seed
+	}
+
+	if start, ok, err := args.GetTime("start"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Start = &start
+	}
+
+	if every, ok, err := args.GetDuration("every"); err != nil {
+		return nil, err
+	} else if ok {
+		if !every.IsPositive() || !every.NanoOnly() {
+			return nil, errors.New(codes.Invalid, "every must be a positive duration without months")
+		}
+		spec.Every = every
+	}
+	return spec, nil
+}
+
+func (s *SeriesOpSpec) Kind() flux.OperationKind {
+	return SeriesKind
+}
+
+type SeriesProcedureSpec struct {
+	plan.DefaultCost
+	Schema gen.Schema
+}
+
+func newSeriesProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*SeriesOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+
+	seed := spec.Seed
+	schema := gen.Schema{
+		NumPoints: int(spec.N),
+		Nulls:     spec.Nulls,
+		Period:    spec.Every.Duration(),
+		Types:     map[flux.ColType]int{seriesKinds[spec.Type]: 1},
+		Seed:      &seed,
+	}
+	if spec.Cardinality > 0 {
+		schema.Tags = []gen.Tag{{Name: seriesTag, Cardinality: int(spec.Cardinality)}}
+	}
+
+	// The series end at now unless a start time is given so
+	// the data does not depend on the time the query runs.
+	now := pa.Now()
+	if spec.Start != nil {
+		schema.Start = spec.Start.Time(now)
+	} else {
+		schema.Start = now.Truncate(schema.Period).Add(-schema.Period * time.Duration(spec.N))
+	}
+	return &SeriesProcedureSpec{Schema: schema}, nil
+}
+
+func (s *SeriesProcedureSpec) Kind() plan.ProcedureKind {
+	return SeriesKind
+}
+
+func (s *SeriesProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	if s.Schema.Tags != nil {
+		ns.Schema.Tags = make([]gen.Tag, len(s.Schema.Tags))
+		copy(ns.Schema.Tags, s.Schema.Tags)
+	}
+	return &ns
+}
+
+func createSeriesSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	spec, ok := prSpec.(*SeriesProcedureSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", prSpec)
+	}
+	return &SeriesSource{
+		id:     dsid,
+		schema: spec.Schema,
+		alloc:  a.Allocator(),
+	}, nil
+}
+
+type SeriesSource struct {
+	execute.ExecutionNode
+	id execute.DatasetID
+	ts execute.TransformationSet
+
+	schema gen.Schema
+	alloc  memory.Allocator
+}
+
+func (s *SeriesSource) AddTransformation(t execute.Transformation) {
+	s.ts = append(s.ts, t)
+}
+
+func (s *SeriesSource) Run(ctx context.Context) {
+	schema := s.schema
+	schema.Alloc = s.alloc
+
+	tables, err := gen.Input(ctx, schema)
+	if err != nil {
+		s.ts.Finish(s.id, err)
+		return
+	}
+
+	err = tables.Do(func(table flux.Table) error {
+		return s.ts.Process(s.id, table)
+	})
+	s.ts.Finish(s.id, err)
+}
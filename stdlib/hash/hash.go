@@ -0,0 +1,57 @@
+package hash
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	stdhash "hash"
+
+	"github.com/gofrs/uuid"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/internal/function"
+	"github.com/influxdata/flux/values"
+)
+
+// digest returns a function that computes the hex encoded digest
+// of a string with the hash function.
+func digest(newHash func() stdhash.Hash) function.Definition {
+	return func(args *function.Arguments) (values.Value, error) {
+		v, err := args.GetRequiredString("v")
+		if err != nil {
+			return nil, err
+		}
+		h := newHash()
+		_, _ = h.Write([]byte(v))
+		return values.NewString(hex.EncodeToString(h.Sum(nil))), nil
+	}
+}
+
+// uuidV5 computes a name based UUID from the string.
+func uuidV5(args *function.Arguments) (values.Value, error) {
+	v, err := args.GetRequiredString("v")
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := uuid.NamespaceOID
+	if s, ok, err := args.GetString("namespace"); err != nil {
+		return nil, err
+	} else if ok {
+		if namespace, err = uuid.FromString(s); err != nil {
+			return nil, errors.Wrapf(err, codes.Invalid, "invalid namespace %q", s)
+		}
+	}
+	return values.NewString(uuid.NewV5(namespace, v).String()), nil
+}
+
+func init() {
+	b := function.ForPackage("hash")
+	b.Register("md5", digest(md5.New))
+	b.Register("sha1", digest(sha1.New))
+	b.Register("sha256", digest(sha256.New))
+	b.Register("sha512", digest(sha512.New))
+	b.Register("uuid", uuidV5)
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/codes"
+	fhttp "github.com/influxdata/flux/dependencies/http"
 	"github.com/influxdata/flux/internal/errors"
 	"github.com/influxdata/flux/iocounter"
 	"github.com/influxdata/flux/runtime"
@@ -73,6 +74,7 @@ func init() {
 				defer s.Finish()
 
 				req = req.WithContext(cctx)
+				fhttp.InjectSpanContext(req)
 				response, err := dc.Do(req)
 				if err != nil {
 					// If an error is returned during a request (from the control
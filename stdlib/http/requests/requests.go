@@ -3,7 +3,6 @@ package requests
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -15,6 +14,7 @@ import (
 	"github.com/influxdata/flux/codes"
 	fhttp "github.com/influxdata/flux/dependencies/http"
 	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/interpreter"
 	"github.com/influxdata/flux/runtime"
 	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/flux/values"
@@ -125,39 +125,15 @@ var do = values.NewFunction(
 			}
 		}
 
-		// Get Client and configure it
-		deps := flux.GetDependencies(ctx)
-		dc, err := deps.HTTPClient()
+		dc, err := configuredClient(ctx, config)
 		if err != nil {
-			return nil, errors.Wrap(err, codes.Aborted, "missing client in http.request")
-		}
-
-		timeoutV, ok := config.Get("timeout")
-		if !ok {
-			return nil, errors.New(codes.Invalid, "config is missing \"timeout\" property")
-		}
-		timeout := timeoutV.Duration()
-		if timeout.IsMixed() {
-			return nil, errors.New(codes.Invalid, "config timeout must not be a mixed duration")
+			return nil, err
 		}
-		dc, err = fhttp.WithTimeout(dc, timeout.Duration())
+		retries, retryInterval, err := retryPolicy(config)
 		if err != nil {
 			return nil, err
 		}
 
-		insecureSkipVerifyV, ok := config.Get("insecureSkipVerify")
-		if !ok {
-			return nil, errors.New(codes.Invalid, "config is missing \"insecureSkipVerify\" property")
-		}
-		if insecureSkipVerifyV.Bool() {
-			dc, err = fhttp.WithTLSConfig(dc, &tls.Config{
-				InsecureSkipVerify: true,
-			})
-			if err != nil {
-				return nil, err
-			}
-		}
-
 		// Do request, using local anonymous functions to facilitate timing the request
 		statusCode, responseBody, headers, duration, err := func(req *http.Request) (statusCode int, body []byte, headers values.Dictionary, duration time.Duration, err error) {
 			startTime := time.Now()
@@ -173,7 +149,8 @@ var do = values.NewFunction(
 			}()
 
 			req = req.WithContext(cctx)
-			response, err := dc.Do(req)
+			fhttp.InjectSpanContext(req)
+			response, err := doRetry(dc, req, retries, retryInterval)
 			if err != nil {
 				// Alias the DNS lookup error so as not to disclose the
 				// DNS server address. This error is private in the net/http
@@ -215,6 +192,160 @@ var do = values.NewFunction(
 	true, // _do has side-effects
 )
 
+// oauth2Token requests an OAuth2 token with the client credentials grant.
+// Tokens are cached by the token cache of the HTTP dependency.
+var oauth2Token = values.NewFunction(
+	"_oauth2Token",
+	runtime.MustLookupBuiltinType("http/requests", "_oauth2Token"),
+	func(ctx context.Context, args values.Object) (values.Value, error) {
+		fargs := interpreter.NewArguments(args)
+		var config fhttp.OAuth2Config
+		var err error
+		if config.TokenURL, err = fargs.GetRequiredString("tokenURL"); err != nil {
+			return nil, err
+		}
+		if config.ClientID, err = fargs.GetRequiredString("clientID"); err != nil {
+			return nil, err
+		}
+		if config.ClientSecret, err = fargs.GetRequiredString("clientSecret"); err != nil {
+			return nil, err
+		}
+		scopes, err := fargs.GetRequiredArrayAllowEmpty("scopes", semantic.String)
+		if err != nil {
+			return nil, err
+		}
+		scopes.Range(func(i int, v values.Value) {
+			config.Scopes = append(config.Scopes, v.Str())
+		})
+		clientConfig, err := fargs.GetRequiredObject("config")
+		if err != nil {
+			return nil, err
+		}
+
+		dc, err := configuredClient(ctx, clientConfig)
+		if err != nil {
+			return nil, err
+		}
+		token, err := fhttp.GetTokenCache(ctx).Token(ctx, dc, config)
+		if err != nil {
+			return nil, err
+		}
+		return values.NewObjectWithValues(map[string]values.Value{
+			"accessToken": values.NewString(token.AccessToken),
+			"tokenType":   values.NewString(token.TokenType),
+			"expiry":      values.NewTime(values.ConvertTime(token.Expiry)),
+		}), nil
+	},
+	true, // _oauth2Token has side-effects
+)
+
+// configuredClient returns the HTTP client of the dependencies
+// configured with the timeout and TLS options of the config record.
+func configuredClient(ctx context.Context, config values.Object) (fhttp.Client, error) {
+	// Get Client and configure it
+	deps := flux.GetDependencies(ctx)
+	dc, err := deps.HTTPClient()
+	if err != nil {
+		return nil, errors.Wrap(err, codes.Aborted, "missing client in http.request")
+	}
+
+	timeoutV, ok := config.Get("timeout")
+	if !ok {
+		return nil, errors.New(codes.Invalid, "config is missing \"timeout\" property")
+	}
+	timeout := timeoutV.Duration()
+	if timeout.IsMixed() {
+		return nil, errors.New(codes.Invalid, "config timeout must not be a mixed duration")
+	}
+	dc, err = fhttp.WithTimeout(dc, timeout.Duration())
+	if err != nil {
+		return nil, err
+	}
+
+	insecureSkipVerifyV, ok := config.Get("insecureSkipVerify")
+	if !ok {
+		return nil, errors.New(codes.Invalid, "config is missing \"insecureSkipVerify\" property")
+	}
+	if insecureSkipVerifyV.Bool() {
+		dc, err = fhttp.WithInsecureSkipVerify(dc)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return dc, nil
+}
+
+// retryPolicy returns the number of times to retry a failed request and the
+// interval before the first retry. Both properties of the config record are
+// optional and default to zero, which means a request is not retried.
+func retryPolicy(config values.Object) (retries int, interval time.Duration, err error) {
+	if retriesV, ok := config.Get("retries"); ok {
+		if retriesV.Type().Nature() != semantic.Int {
+			return 0, 0, errors.Newf(codes.Invalid, "config retries is not of type int: %v", retriesV.Type())
+		}
+		if retriesV.Int() < 0 {
+			return 0, 0, errors.Newf(codes.Invalid, "config retries must be non-negative, got %d", retriesV.Int())
+		}
+		retries = int(retriesV.Int())
+	}
+	if intervalV, ok := config.Get("retryInterval"); ok {
+		if intervalV.Type().Nature() != semantic.Duration {
+			return 0, 0, errors.Newf(codes.Invalid, "config retryInterval is not of type duration: %v", intervalV.Type())
+		}
+		d := intervalV.Duration()
+		if d.IsMixed() {
+			return 0, 0, errors.New(codes.Invalid, "config retryInterval must not be a mixed duration")
+		} else if d.IsNegative() {
+			return 0, 0, errors.Newf(codes.Invalid, "config retryInterval must be non-negative, got %v", d)
+		}
+		interval = d.Duration()
+	}
+	return retries, interval, nil
+}
+
+// doRetry performs the request and retries it up to the given number of times
+// when it fails with a connection error or a 429 or 5xx status code.
+// The interval between retries doubles after each retry.
+func doRetry(dc fhttp.Client, req *http.Request, retries int, interval time.Duration) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		r := req
+		if attempt > 0 {
+			r = req.Clone(ctx)
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				r.Body = body
+			}
+		}
+		response, err := dc.Do(r)
+		if attempt >= retries || !shouldRetry(ctx, response, err) {
+			return response, err
+		}
+		if response != nil {
+			_, _ = io.Copy(ioutil.Discard, response.Body)
+			_ = response.Body.Close()
+		}
+
+		timer := time.NewTimer(interval << attempt)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func shouldRetry(ctx context.Context, response *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil
+	}
+	return response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= 500
+}
+
 // headerToDict constructs a values.Dictionary from a map of header keys and values.
 func headerToDict(header http.Header) (values.Dictionary, error) {
 	builder := values.NewDictBuilder(semantic.NewDictType(semantic.BasicString, semantic.BasicString))
@@ -230,4 +361,5 @@ func headerToDict(header http.Header) (values.Dictionary, error) {
 
 func init() {
 	runtime.RegisterPackageValue("http/requests", "_do", do)
+	runtime.RegisterPackageValue("http/requests", "_oauth2Token", oauth2Token)
 }
@@ -179,6 +179,60 @@ requests.do(method: "GET", url:"%s/path/a/b/c", config: config)
 		t.Errorf("unexpected cause of failure, got err: %v", err)
 	}
 }
+func TestDo_Retry(t *testing.T) {
+	var attempts int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+		attempts++
+		body, _ := io.ReadAll(request.Body)
+		if string(body) != "data" {
+			w.WriteHeader(400)
+			return
+		}
+		if attempts < 3 {
+			w.WriteHeader(503)
+			return
+		}
+		w.WriteHeader(204)
+	}))
+	defer ts.Close()
+
+	for _, tc := range []struct {
+		retries      int
+		wantStatus   int64
+		wantAttempts int
+	}{
+		{retries: 0, wantStatus: 503, wantAttempts: 1},
+		{retries: 1, wantStatus: 503, wantAttempts: 2},
+		{retries: 3, wantStatus: 204, wantAttempts: 3},
+	} {
+		attempts = 0
+		script := fmt.Sprintf(`
+import "http/requests"
+
+c = requests.defaultConfig
+config = {c with retries: %d, retryInterval: 1ms}
+response = requests.post(url:"%s", body: bytes(v: "data"), config: config)
+`, tc.retries, ts.URL)
+
+		ctx := flux.NewDefaultDependencies().Inject(context.Background())
+		_, scope, err := runtime.Eval(ctx, script)
+		if err != nil {
+			t.Fatal(err)
+		}
+		response, ok := scope.Lookup("response")
+		if !ok {
+			t.Fatal("unable to find response in scope")
+		}
+		statusCode, _ := response.Object().Get("statusCode")
+		if got := statusCode.Int(); got != tc.wantStatus {
+			t.Errorf("retries %d: unexpected status code, got %d want %d", tc.retries, got, tc.wantStatus)
+		}
+		if attempts != tc.wantAttempts {
+			t.Errorf("retries %d: unexpected number of attempts, got %d want %d", tc.retries, attempts, tc.wantAttempts)
+		}
+	}
+}
+
 func TestDo_VerifyTLS_Pass(t *testing.T) {
 	var req *http.Request
 
@@ -241,3 +295,49 @@ requests.do(method: "GET", url:"%s/path/a/b/c")
 		t.Errorf("unexpected cause of failure, got err: %v", err)
 	}
 }
+
+func TestOAuth2(t *testing.T) {
+	var tokenRequests int
+	auth := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+		tokenRequests++
+		if id, secret, _ := request.BasicAuth(); id != "flux" || secret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "abc", "token_type": "bearer", "expires_in": 3600}`))
+	}))
+	defer auth.Close()
+
+	var authorization []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+		authorization = append(authorization, request.Header.Get("Authorization"))
+		w.WriteHeader(204)
+	}))
+	defer ts.Close()
+
+	script := fmt.Sprintf(`
+import "http/requests"
+
+get = () => {
+    credential = requests.oauth2(tokenURL: "%s", clientID: "flux", clientSecret: "secret", scopes: ["read"])
+
+    return requests.get(url: "%s", headers: ["Authorization": credential.authorization])
+}
+
+a = get()
+b = get()
+`, auth.URL, ts.URL)
+
+	ctx := flux.NewDefaultDependencies().Inject(context.Background())
+	ctx = fhttp.InjectTokenCache(ctx, fhttp.NewTokenCache())
+	if _, _, err := runtime.Eval(ctx, script); err != nil {
+		t.Fatal("evaluation of requests.oauth2 failed: ", err)
+	}
+	if want := []string{"Bearer abc", "Bearer abc"}; !cmp.Equal(want, authorization) {
+		t.Errorf("unexpected authorization headers -want/+got\n%s", cmp.Diff(want, authorization))
+	}
+	if tokenRequests != 1 {
+		t.Errorf("expected one token request, got %d", tokenRequests)
+	}
+}
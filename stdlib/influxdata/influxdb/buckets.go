@@ -86,6 +86,7 @@ func (s *BucketsProcedureSpec) Kind() plan.ProcedureKind {
 
 func (s *BucketsProcedureSpec) Copy() plan.ProcedureSpec {
 	ns := new(BucketsProcedureSpec)
+	*ns = *s
 	return ns
 }
 
@@ -5,6 +5,8 @@
 package influxdb
 
 import (
+	"context"
+
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/codes"
 	"github.com/influxdata/flux/dependencies/influxdb"
@@ -167,6 +169,10 @@ type FromRemoteProcedureSpec struct {
 	influxdb.Config
 	Bounds       flux.Bounds
 	PredicateSet influxdb.PredicateSet
+
+	// SchemaOnly reads the group keys and columns of the tables
+	// from the metadata of the influxdb instance instead of the rows.
+	SchemaOnly bool
 }
 
 func (s *FromRemoteProcedureSpec) Kind() plan.ProcedureKind {
@@ -194,6 +200,22 @@ func (s *FromRemoteProcedureSpec) Copy() plan.ProcedureSpec {
 	return ns
 }
 
+// SchemaSpec implements plan.SchemaSource.
+// The read is only replaced when the provider can produce
+// the schema of the read.
+func (s *FromRemoteProcedureSpec) SchemaSpec(ctx context.Context) (plan.PhysicalProcedureSpec, bool) {
+	if s.SchemaOnly || s.Bounds.IsEmpty() {
+		return nil, false
+	}
+	provider := influxdb.GetProvider(ctx)
+	if _, err := provider.SchemaReaderFor(ctx, s.Config, s.Bounds, s.PredicateSet); err != nil {
+		return nil, false
+	}
+	ns := s.Copy().(*FromRemoteProcedureSpec)
+	ns.SchemaOnly = true
+	return ns, true
+}
+
 func (s *FromRemoteProcedureSpec) PostPhysicalValidate(id plan.NodeID) error {
 	if s.Bounds.IsEmpty() {
 		var bucket string
@@ -214,7 +236,11 @@ func createFromSource(ps plan.ProcedureSpec, id execute.DatasetID, a execute.Adm
 	}
 
 	provider := influxdb.GetProvider(a.Context())
-	reader, err := provider.ReaderFor(a.Context(), spec.Config, spec.Bounds, spec.PredicateSet)
+	readerFor := provider.ReaderFor
+	if spec.SchemaOnly {
+		readerFor = provider.SchemaReaderFor
+	}
+	reader, err := readerFor(a.Context(), spec.Config, spec.Bounds, spec.PredicateSet)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,258 @@
+package monitor
+
+import (
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/runtime"
+)
+
+const pkgpath = "influxdata/influxdb/monitor"
+
+const SuppressKind = "monitorSuppress"
+
+const (
+	LevelLabel           = "_level"
+	ObservedLevelLabel   = "_observed_level"
+	SourceTimestampLabel = "_source_timestamp"
+)
+
+type SuppressOpSpec struct {
+	Holdoff       flux.Duration `json:"holdoff"`
+	FlapWindow    flux.Duration `json:"flapWindow"`
+	FlapThreshold int64         `json:"flapThreshold"`
+}
+
+func init() {
+	runtime.RegisterPackageValue(pkgpath, "_suppress", flux.MustValue(flux.FunctionValue("_suppress", createSuppressOpSpec, runtime.MustLookupBuiltinType(pkgpath, "_suppress"))))
+	plan.RegisterProcedureSpec(SuppressKind, newSuppressProcedure, SuppressKind)
+	execute.RegisterTransformation(SuppressKind, createSuppressTransformation)
+}
+
+func createSuppressOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(SuppressOpSpec)
+	if holdoff, ok, err := args.GetDuration("holdoff"); err != nil {
+		return nil, err
+	} else if ok {
+		if holdoff.IsNegative() {
+			return nil, errors.Newf(codes.Invalid, "holdoff must be non-negative, got %v", holdoff)
+		}
+		spec.Holdoff = holdoff
+	}
+	if window, ok, err := args.GetDuration("flapWindow"); err != nil {
+		return nil, err
+	} else if ok {
+		if window.IsNegative() {
+			return nil, errors.Newf(codes.Invalid, "flapWindow must be non-negative, got %v", window)
+		}
+		spec.FlapWindow = window
+	}
+	if threshold, ok, err := args.GetInt("flapThreshold"); err != nil {
+		return nil, err
+	} else if ok {
+		if threshold < 0 {
+			return nil, errors.Newf(codes.Invalid, "flapThreshold must be non-negative, got %d", threshold)
+		}
+		spec.FlapThreshold = threshold
+	}
+	return spec, nil
+}
+
+func (s *SuppressOpSpec) Kind() flux.OperationKind {
+	return SuppressKind
+}
+
+type SuppressProcedureSpec struct {
+	plan.DefaultCost
+	Holdoff       flux.Duration
+	FlapWindow    flux.Duration
+	FlapThreshold int64
+}
+
+func newSuppressProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*SuppressOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+	return &SuppressProcedureSpec{
+		Holdoff:       spec.Holdoff,
+		FlapWindow:    spec.FlapWindow,
+		FlapThreshold: spec.FlapThreshold,
+	}, nil
+}
+
+func (s *SuppressProcedureSpec) Kind() plan.ProcedureKind {
+	return SuppressKind
+}
+
+func (s *SuppressProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+// TriggerSpec implements plan.TriggerAwareProcedureSpec
+func (s *SuppressProcedureSpec) TriggerSpec() plan.TriggerSpec {
+	return plan.NarrowTransformationTriggerSpec{}
+}
+
+func createSuppressTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*SuppressProcedureSpec)
+	if !ok {
+		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
+	}
+	return NewSuppressTransformation(id, s, a.Allocator())
+}
+
+type suppressTransformation struct {
+	holdoff       int64
+	flapWindow    int64
+	flapThreshold int
+}
+
+// NewSuppressTransformation constructs a transformation that holds the level
+// of a check at the last reported level when a level transition repeats within
+// the holdoff or when the level is flapping.
+func NewSuppressTransformation(id execute.DatasetID, spec *SuppressProcedureSpec, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	tr := &suppressTransformation{
+		holdoff:       int64(spec.Holdoff.Duration()),
+		flapWindow:    int64(spec.FlapWindow.Duration()),
+		flapThreshold: int(spec.FlapThreshold),
+	}
+	return execute.NewNarrowStateTransformation[*suppressState](id, tr, mem)
+}
+
+type transition struct {
+	from, to string
+}
+
+type suppressState struct {
+	// reported is the last level that was not suppressed.
+	reported    string
+	hasReported bool
+
+	// observed is the level of the previous row.
+	observed    string
+	hasObserved bool
+
+	// changes holds the times the observed level changed
+	// within the flap window.
+	changes []int64
+
+	// transitions holds the last time each reported transition occurred.
+	transitions map[transition]int64
+}
+
+// flapping reports whether the observed level changed at least
+// the flap threshold number of times within the flap window.
+func (t *suppressTransformation) flapping(s *suppressState, ts int64) bool {
+	if t.flapThreshold <= 0 || t.flapWindow <= 0 {
+		return false
+	}
+	i := 0
+	for i < len(s.changes) && ts-s.changes[i] >= t.flapWindow {
+		i++
+	}
+	s.changes = s.changes[i:]
+	return len(s.changes) >= t.flapThreshold
+}
+
+// update records the observed level at a time
+// and returns the level to report.
+func (t *suppressTransformation) update(s *suppressState, level string, ts int64) string {
+	if s.hasObserved && level != s.observed {
+		s.changes = append(s.changes, ts)
+	}
+	s.observed, s.hasObserved = level, true
+	flapping := t.flapping(s, ts)
+
+	if !s.hasReported {
+		s.reported, s.hasReported = level, true
+		return level
+	} else if level == s.reported {
+		return level
+	}
+
+	tr := transition{from: s.reported, to: level}
+	if flapping {
+		return s.reported
+	} else if last, ok := s.transitions[tr]; ok && t.holdoff > 0 && ts-last < t.holdoff {
+		return s.reported
+	}
+	s.transitions[tr] = ts
+	s.reported = level
+	return level
+}
+
+func (t *suppressTransformation) Process(chunk table.Chunk, state *suppressState, d *execute.TransportDataset, mem memory.Allocator) (*suppressState, bool, error) {
+	if state == nil {
+		state = &suppressState{transitions: make(map[transition]int64)}
+	}
+
+	levelIdx := chunk.Index(LevelLabel)
+	if levelIdx < 0 {
+		return nil, false, errors.Newf(codes.FailedPrecondition, "column %q does not exist", LevelLabel)
+	} else if typ := chunk.Col(levelIdx).Type; typ != flux.TString {
+		return nil, false, errors.Newf(codes.FailedPrecondition, "column %q is type %s and not a string", LevelLabel, typ)
+	} else if chunk.Key().HasCol(LevelLabel) {
+		return nil, false, errors.Newf(codes.FailedPrecondition, "column %q must not be part of the group key", LevelLabel)
+	}
+	timeIdx := chunk.Index(SourceTimestampLabel)
+	if timeIdx < 0 {
+		return nil, false, errors.Newf(codes.FailedPrecondition, "column %q does not exist", SourceTimestampLabel)
+	} else if typ := chunk.Col(timeIdx).Type; typ != flux.TInt {
+		return nil, false, errors.Newf(codes.FailedPrecondition, "column %q is type %s and not an int", SourceTimestampLabel, typ)
+	}
+	if chunk.HasCol(ObservedLevelLabel) {
+		return nil, false, errors.Newf(codes.FailedPrecondition, "column %q already exists", ObservedLevelLabel)
+	}
+
+	levels, times := chunk.Strings(levelIdx), chunk.Ints(timeIdx)
+	b := array.NewStringBuilder(mem)
+	b.Resize(chunk.Len())
+	for i, l := 0, chunk.Len(); i < l; i++ {
+		if levels.IsNull(i) {
+			b.AppendNull()
+		} else if times.IsNull(i) {
+			b.Append(levels.Value(i))
+		} else {
+			b.Append(t.update(state, levels.Value(i), times.Value(i)))
+		}
+	}
+
+	cols := make([]flux.ColMeta, 0, chunk.NCols()+1)
+	vs := make([]array.Array, 0, chunk.NCols()+1)
+	for j, col := range chunk.Cols() {
+		cols = append(cols, col)
+		if j == levelIdx {
+			vs = append(vs, b.NewArray())
+			continue
+		}
+		arr := chunk.Values(j)
+		arr.Retain()
+		vs = append(vs, arr)
+	}
+	levels.Retain()
+	cols = append(cols, flux.ColMeta{Label: ObservedLevelLabel, Type: flux.TString})
+	vs = append(vs, levels)
+
+	if err := d.Process(table.ChunkFromBuffer(arrow.TableBuffer{
+		GroupKey: chunk.Key(),
+		Columns:  cols,
+		Values:   vs,
+	})); err != nil {
+		return nil, false, err
+	}
+	return state, true, nil
+}
+
+func (t *suppressTransformation) Close() error { return nil }
@@ -0,0 +1,125 @@
+package monitor_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb/monitor"
+)
+
+func TestSuppress(t *testing.T) {
+	inputCols := []flux.ColMeta{
+		{Label: "_source_timestamp", Type: flux.TInt},
+		{Label: "_level", Type: flux.TString},
+		{Label: "host", Type: flux.TString},
+	}
+	outputCols := append(append([]flux.ColMeta{}, inputCols...),
+		flux.ColMeta{Label: "_observed_level", Type: flux.TString},
+	)
+	testCases := []struct {
+		name    string
+		spec    *monitor.SuppressProcedureSpec
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "holdoff",
+			spec: &monitor.SuppressProcedureSpec{
+				Holdoff: flux.ConvertDuration(10),
+			},
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"host"},
+				ColMeta: inputCols,
+				Data: [][]interface{}{
+					{int64(0), "ok", "a"},
+					{int64(1), "crit", "a"},
+					{int64(2), "ok", "a"},
+					{int64(3), "crit", "a"},
+					{int64(15), "crit", "a"},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"host"},
+				ColMeta: outputCols,
+				Data: [][]interface{}{
+					{int64(0), "ok", "a", "ok"},
+					{int64(1), "crit", "a", "crit"},
+					{int64(2), "ok", "a", "ok"},
+					{int64(3), "ok", "a", "crit"},
+					{int64(15), "crit", "a", "crit"},
+				},
+			}},
+		},
+		{
+			name: "flapping",
+			spec: &monitor.SuppressProcedureSpec{
+				FlapWindow:    flux.ConvertDuration(10),
+				FlapThreshold: 2,
+			},
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"host"},
+				ColMeta: inputCols,
+				Data: [][]interface{}{
+					{int64(0), "ok", "a"},
+					{int64(1), "crit", "a"},
+					{int64(2), "ok", "a"},
+					{int64(3), "crit", "a"},
+					{int64(20), "crit", "a"},
+					{int64(21), "ok", "a"},
+					{int64(22), nil, "a"},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"host"},
+				ColMeta: outputCols,
+				Data: [][]interface{}{
+					{int64(0), "ok", "a", "ok"},
+					{int64(1), "crit", "a", "crit"},
+					{int64(2), "crit", "a", "ok"},
+					{int64(3), "crit", "a", "crit"},
+					{int64(20), "crit", "a", "crit"},
+					{int64(21), "ok", "a", "ok"},
+					{int64(22), nil, "a", nil},
+				},
+			}},
+		},
+		{
+			name: "level in group key",
+			spec: &monitor.SuppressProcedureSpec{
+				Holdoff: flux.ConvertDuration(10),
+			},
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"_level"},
+				ColMeta: inputCols,
+				Data: [][]interface{}{
+					{int64(0), "ok", "a"},
+				},
+			}},
+			wantErr: errors.New(codes.FailedPrecondition, `column "_level" must not be part of the group key`),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper2(
+				t,
+				tc.data,
+				tc.want,
+				tc.wantErr,
+				func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+					tr, d, err := monitor.NewSuppressTransformation(id, tc.spec, alloc)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return tr, d
+				},
+			)
+		})
+	}
+}
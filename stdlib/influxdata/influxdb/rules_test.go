@@ -444,3 +444,108 @@ func TestDefaultFromAttributes(t *testing.T) {
 		})
 	}
 }
+
+type schemaProvider struct {
+	influxdeps.UnimplementedProvider
+}
+
+func (schemaProvider) SchemaReaderFor(ctx context.Context, conf influxdeps.Config, bounds flux.Bounds, predicateSet influxdeps.PredicateSet) (influxdeps.Reader, error) {
+	return nil, nil
+}
+
+func TestSchemaSourceRule(t *testing.T) {
+	fromSpec := &influxdb.FromRemoteProcedureSpec{
+		Config: influxdb.Config{
+			Bucket: influxdb.NameOrID{Name: "telegraf"},
+			Host:   "http://localhost:8086",
+		},
+		Bounds: flux.Bounds{
+			Start: flux.Time{
+				IsRelative: true,
+				Relative:   -time.Minute,
+			},
+			Stop: flux.Time{
+				IsRelative: true,
+			},
+		},
+	}
+	schemaSpec := fromSpec.Copy().(*influxdb.FromRemoteProcedureSpec)
+	schemaSpec.SchemaOnly = true
+
+	newContext := func(provider influxdeps.Provider) context.Context {
+		deps := flux.NewDefaultDependencies()
+		ctx := deps.Inject(context.Background())
+		return influxdeps.Dependency{Provider: provider}.Inject(ctx)
+	}
+
+	for _, tc := range []plantest.RuleTestCase{
+		{
+			Name:    "columns",
+			Context: newContext(schemaProvider{}),
+			Rules:   []plan.Rule{universe.SchemaSourceRule{}},
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("fromRemote", fromSpec),
+					plan.CreatePhysicalNode("columns", &universe.ColumnsProcedureSpec{Column: "_value"}),
+				},
+				Edges: [][2]int{{0, 1}},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("fromRemote", schemaSpec),
+					plan.CreatePhysicalNode("columns", &universe.ColumnsProcedureSpec{Column: "_value"}),
+				},
+				Edges: [][2]int{{0, 1}},
+			},
+		},
+		{
+			Name:    "keys",
+			Context: newContext(schemaProvider{}),
+			Rules:   []plan.Rule{universe.SchemaSourceRule{}},
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("fromRemote", fromSpec),
+					plan.CreatePhysicalNode("keys", &universe.KeysProcedureSpec{Column: "_value"}),
+				},
+				Edges: [][2]int{{0, 1}},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("fromRemote", schemaSpec),
+					plan.CreatePhysicalNode("keys", &universe.KeysProcedureSpec{Column: "_value"}),
+				},
+				Edges: [][2]int{{0, 1}},
+			},
+		},
+		{
+			Name:    "unsupported provider",
+			Context: newContext(influxdeps.HttpProvider{}),
+			Rules:   []plan.Rule{universe.SchemaSourceRule{}},
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("fromRemote", fromSpec),
+					plan.CreatePhysicalNode("columns", &universe.ColumnsProcedureSpec{Column: "_value"}),
+				},
+				Edges: [][2]int{{0, 1}},
+			},
+			NoChange: true,
+		},
+		{
+			Name:    "data successor",
+			Context: newContext(schemaProvider{}),
+			Rules:   []plan.Rule{universe.SchemaSourceRule{}},
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("fromRemote", fromSpec),
+					plan.CreatePhysicalNode("sum", &universe.SumProcedureSpec{}),
+				},
+				Edges: [][2]int{{0, 1}},
+			},
+			NoChange: true,
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			plantest.PhysicalRuleTestHelper(t, &tc)
+		})
+	}
+}
@@ -1,5 +0,0 @@
-// DO NOT EDIT: This file is autogenerated via the builtin command.
-//
-// This file ensures that this directory is a Go package
-
-package schema
@@ -0,0 +1,272 @@
+package schema
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/runtime"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/values"
+)
+
+const (
+	pkgpath    = "influxdata/influxdb/schema"
+	RemoteKind = "influxdata/influxdb/schema.remote"
+)
+
+// remoteFunctions are the schema functions that can be invoked on a remote host.
+// The other schema functions are implemented with these.
+var remoteFunctions = map[string]bool{
+	"tagValues": true,
+	"tagKeys":   true,
+}
+
+// RemoteOpSpec invokes a schema function on a remote host. The remote
+// host can answer the call from the metadata of the storage engine
+// instead of scanning and transferring the data.
+type RemoteOpSpec struct {
+	Function  string
+	Bucket    string
+	Tag       string
+	Predicate interpreter.ResolvedFunction
+	Start     flux.Time
+	Stop      flux.Time
+	Org       *influxdb.NameOrID
+	Host      *string
+	Token     *string
+}
+
+func init() {
+	remoteSignature := runtime.MustLookupBuiltinType(pkgpath, "_remote")
+
+	runtime.RegisterPackageValue(pkgpath, "_remote", flux.MustValue(flux.FunctionValue("_remote", createRemoteOpSpec, remoteSignature)))
+	plan.RegisterProcedureSpec(RemoteKind, newRemoteProcedure, RemoteKind)
+	execute.RegisterSource(RemoteKind, createRemoteSource)
+}
+
+func createRemoteOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	spec := new(RemoteOpSpec)
+
+	var err error
+	if spec.Function, err = args.GetRequiredString("fn"); err != nil {
+		return nil, err
+	} else if !remoteFunctions[spec.Function] {
+		return nil, errors.Newf(codes.Invalid, "schema function %q cannot be invoked on a remote host", spec.Function)
+	}
+
+	if spec.Bucket, err = args.GetRequiredString("bucket"); err != nil {
+		return nil, err
+	}
+
+	if tag, ok, err := args.GetString("tag"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Tag = tag
+	} else if spec.Function == "tagValues" {
+		return nil, errors.New(codes.Invalid, "missing required keyword argument \"tag\"")
+	}
+
+	predicate, err := args.GetRequiredFunction("predicate")
+	if err != nil {
+		return nil, err
+	}
+	if spec.Predicate, err = interpreter.ResolveFunction(predicate); err != nil {
+		return nil, err
+	}
+
+	if spec.Start, err = args.GetRequiredTime("start"); err != nil {
+		return nil, err
+	}
+	if spec.Stop, err = args.GetRequiredTime("stop"); err != nil {
+		return nil, err
+	}
+
+	// Empty strings are the defaults of the schema functions
+	// and mean the parameter was not specified.
+	host, err := args.GetRequiredString("host")
+	if err != nil {
+		return nil, err
+	} else if host == "" {
+		return nil, errors.New(codes.Invalid, "schema functions require a host to be invoked remotely")
+	}
+	spec.Host = &host
+
+	if org, ok, err := args.GetString("org"); err != nil {
+		return nil, err
+	} else if ok && org != "" {
+		spec.Org = &influxdb.NameOrID{Name: org}
+	}
+
+	if token, ok, err := args.GetString("token"); err != nil {
+		return nil, err
+	} else if ok && token != "" {
+		spec.Token = &token
+	}
+	return spec, nil
+}
+
+func (s *RemoteOpSpec) Kind() flux.OperationKind {
+	return RemoteKind
+}
+
+var (
+	_ influxdb.ProcedureSpec       = (*RemoteProcedureSpec)(nil)
+	_ influxdb.RemoteProcedureSpec = (*RemoteProcedureSpec)(nil)
+)
+
+type RemoteProcedureSpec struct {
+	plan.DefaultCost
+
+	Function  string
+	Bucket    string
+	Tag       string
+	Predicate interpreter.ResolvedFunction
+	Bounds    flux.Bounds
+	Org       *influxdb.NameOrID
+	Host      *string
+	Token     *string
+}
+
+func newRemoteProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*RemoteOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+
+	bounds := flux.Bounds{
+		Start: spec.Start,
+		Stop:  spec.Stop,
+		Now:   pa.Now(),
+	}
+	if bounds.IsEmpty() {
+		return nil, errors.New(codes.Invalid, "cannot query an empty range")
+	}
+	return &RemoteProcedureSpec{
+		Function:  spec.Function,
+		Bucket:    spec.Bucket,
+		Tag:       spec.Tag,
+		Predicate: spec.Predicate,
+		Bounds:    bounds,
+		Org:       spec.Org,
+		Host:      spec.Host,
+		Token:     spec.Token,
+	}, nil
+}
+
+func (s *RemoteProcedureSpec) Kind() plan.ProcedureKind {
+	return RemoteKind
+}
+
+func (s *RemoteProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(RemoteProcedureSpec)
+	*ns = *s
+	ns.Predicate = s.Predicate.Copy()
+	return ns
+}
+
+func (s *RemoteProcedureSpec) SetOrg(org *influxdb.NameOrID) { s.Org = org }
+func (s *RemoteProcedureSpec) SetHost(host *string)          { s.Host = host }
+func (s *RemoteProcedureSpec) SetToken(token *string)        { s.Token = token }
+func (s *RemoteProcedureSpec) GetOrg() *influxdb.NameOrID    { return s.Org }
+func (s *RemoteProcedureSpec) GetHost() *string              { return s.Host }
+func (s *RemoteProcedureSpec) GetToken() *string             { return s.Token }
+
+func createRemoteSource(ps plan.ProcedureSpec, id execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	spec := ps.(*RemoteProcedureSpec)
+	return influxdb.CreateSource(id, spec, a)
+}
+
+// BuildQuery builds a query that invokes the schema function on the
+// remote host. Relative times are resolved using the now time of this
+// query so both hosts use the same bounds.
+func (s *RemoteProcedureSpec) BuildQuery() *ast.File {
+	imports := make(map[string]string)
+	predicate := s.predicateToAST(imports)
+
+	// Find a name for the schema package that is not used
+	// by an import of the predicate.
+	name := "schema"
+	for num := 1; ; num++ {
+		if path, ok := imports[name]; !ok || path == pkgpath {
+			break
+		}
+		name = "schema" + strconv.Itoa(num)
+	}
+	imports[name] = pkgpath
+
+	properties := []*ast.Property{{
+		Key:   &ast.Identifier{Name: "bucket"},
+		Value: ast.StringLiteralFromValue(s.Bucket),
+	}}
+	if s.Function == "tagValues" {
+		properties = append(properties, &ast.Property{
+			Key:   &ast.Identifier{Name: "tag"},
+			Value: ast.StringLiteralFromValue(s.Tag),
+		})
+	}
+	properties = append(properties,
+		&ast.Property{
+			Key:   &ast.Identifier{Name: "predicate"},
+			Value: predicate,
+		},
+		&ast.Property{
+			Key:   &ast.Identifier{Name: "start"},
+			Value: ast.DateTimeLiteralFromValue(s.Bounds.Start.Time(s.Bounds.Now)),
+		},
+		&ast.Property{
+			Key:   &ast.Identifier{Name: "stop"},
+			Value: ast.DateTimeLiteralFromValue(s.Bounds.Stop.Time(s.Bounds.Now)),
+		},
+	)
+
+	file := &ast.File{
+		Package: &ast.PackageClause{
+			Name: &ast.Identifier{Name: "main"},
+		},
+		Name: "query.flux",
+		Body: []ast.Statement{
+			&ast.ExpressionStatement{
+				Expression: &ast.CallExpression{
+					Callee: &ast.MemberExpression{
+						Object:   &ast.Identifier{Name: name},
+						Property: &ast.Identifier{Name: s.Function},
+					},
+					Arguments: []ast.Expression{
+						&ast.ObjectExpression{Properties: properties},
+					},
+				},
+			},
+		},
+	}
+	for name, path := range imports {
+		file.Imports = append(file.Imports, &ast.ImportDeclaration{
+			Path: &ast.StringLiteral{Value: path},
+			As:   &ast.Identifier{Name: name},
+		})
+	}
+	sort.Slice(file.Imports, func(i, j int) bool {
+		return file.Imports[i].As.Name < file.Imports[j].As.Name
+	})
+	return file
+}
+
+// predicateToAST converts the predicate back to its ast representation
+// and records the packages that it references in the imports.
+func (s *RemoteProcedureSpec) predicateToAST(imports map[string]string) ast.Expression {
+	if s.Predicate.Scope != nil {
+		s.Predicate.Scope.Range(func(k string, v values.Value) {
+			if pkg, ok := v.(values.Package); ok && pkg.Path() != "" {
+				imports[k] = pkg.Path()
+			}
+		})
+	}
+	return semantic.ToAST(s.Predicate.Fn).(ast.Expression)
+}
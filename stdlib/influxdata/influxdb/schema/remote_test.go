@@ -0,0 +1,130 @@
+package schema_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb/internal/testutil"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb/schema"
+	"github.com/influxdata/flux/values/valuestest"
+)
+
+func TestRemote_Run(t *testing.T) {
+	now := time.Date(2020, 10, 22, 9, 30, 0, 0, time.UTC)
+	bounds := flux.Bounds{
+		Start: flux.Time{
+			Relative:   -time.Hour,
+			IsRelative: true,
+		},
+		Stop: flux.Time{
+			IsRelative: true,
+		},
+		Now: now,
+	}
+	org := &influxdb.NameOrID{Name: "influxdata"}
+
+	for _, tt := range []struct {
+		name string
+		spec *schema.RemoteProcedureSpec
+		want testutil.Want
+	}{
+		{
+			name: "tag values",
+			spec: &schema.RemoteProcedureSpec{
+				Function: "tagValues",
+				Bucket:   "telegraf",
+				Tag:      "host",
+				Predicate: interpreter.ResolvedFunction{
+					Fn:    executetest.FunctionExpression(t, `(r) => r._measurement == "cpu"`),
+					Scope: valuestest.Scope(),
+				},
+				Bounds: bounds,
+				Org:    org,
+			},
+			want: testutil.Want{
+				Params: url.Values{
+					"org": []string{"influxdata"},
+				},
+				Query: `package main
+
+
+import schema "influxdata/influxdb/schema"
+
+schema.tagValues(
+    bucket: "telegraf",
+    tag: "host",
+    predicate: (r) => {
+        return r["_measurement"] == "cpu"
+    },
+    start: 2020-10-22T08:30:00Z,
+    stop: 2020-10-22T09:30:00Z,
+)
+`,
+				Tables: func() []*executetest.Table {
+					return []*executetest.Table{{
+						ColMeta: []flux.ColMeta{
+							{Label: "_value", Type: flux.TString},
+						},
+						Data: [][]interface{}{
+							{"server01"},
+							{"server02"},
+						},
+					}}
+				},
+			},
+		},
+		{
+			name: "tag keys",
+			spec: &schema.RemoteProcedureSpec{
+				Function: "tagKeys",
+				Bucket:   "telegraf",
+				Predicate: interpreter.ResolvedFunction{
+					Fn:    executetest.FunctionExpression(t, `(r) => true`),
+					Scope: valuestest.Scope(),
+				},
+				Bounds: bounds,
+				Org:    org,
+			},
+			want: testutil.Want{
+				Params: url.Values{
+					"org": []string{"influxdata"},
+				},
+				Query: `package main
+
+
+import schema "influxdata/influxdb/schema"
+
+schema.tagKeys(
+    bucket: "telegraf",
+    predicate: (r) => {
+        return true
+    },
+    start: 2020-10-22T08:30:00Z,
+    stop: 2020-10-22T09:30:00Z,
+)
+`,
+				Tables: func() []*executetest.Table {
+					return []*executetest.Table{{
+						ColMeta: []flux.ColMeta{
+							{Label: "_value", Type: flux.TString},
+						},
+						Data: [][]interface{}{
+							{"_field"},
+							{"_measurement"},
+							{"host"},
+						},
+					}}
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			testutil.RunSourceTestHelper(t, tt.spec, tt.want)
+		})
+	}
+}
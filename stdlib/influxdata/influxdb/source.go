@@ -18,6 +18,7 @@ import (
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/internal/errors"
 	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/metadata"
 )
 
 type ProcedureSpec interface {
@@ -39,11 +40,12 @@ type RemoteProcedureSpec interface {
 
 type source struct {
 	execute.ExecutionNode
-	id   execute.DatasetID
-	spec RemoteProcedureSpec
-	deps flux.Dependencies
-	mem  memory.Allocator
-	ts   execute.TransformationSet
+	id    execute.DatasetID
+	spec  RemoteProcedureSpec
+	deps  flux.Dependencies
+	mem   memory.Allocator
+	ts    execute.TransformationSet
+	stats influxdb.ReadStats
 }
 
 func CreateSource(id execute.DatasetID, spec RemoteProcedureSpec, a execute.Administration) (execute.Source, error) {
@@ -66,10 +68,15 @@ func (s *source) AddTransformation(t execute.Transformation) {
 }
 
 func (s *source) Run(ctx context.Context) {
-	err := s.run(ctx)
+	var err error
+	s.stats, err = influxdb.LimitRead(ctx, s.run)
 	s.ts.Finish(s.id, err)
 }
 
+func (s *source) Metadata() metadata.Metadata {
+	return readMetadata(s.stats)
+}
+
 func (s *source) run(ctx context.Context) error {
 	req, err := s.newRequest(ctx)
 	if err != nil {
@@ -84,14 +91,17 @@ func (s *source) run(ctx context.Context) error {
 	resp, err := client.Do(req)
 	if err != nil {
 		return err
-	} else if resp.StatusCode != 200 {
-		data, err := ioutil.ReadAll(resp.Body)
+	}
+	body := influxdb.DrainBody(resp.Body)
+	if resp.StatusCode != 200 {
+		defer func() { _ = body.Close() }()
+		data, err := ioutil.ReadAll(body)
 		if err != nil {
 			return errors.Newf(codes.Invalid, "error when reading response body: %s", err)
 		}
 		return s.parseError(data)
 	}
-	return s.processResults(resp.Body)
+	return s.processResults(body)
 }
 
 func (s *source) validateHost(host string) error {
@@ -208,8 +218,26 @@ func (s *source) parseError(p []byte) error {
 type sourceIterator struct {
 	reader influxdb.Reader
 	mem    memory.Allocator
+	stats  influxdb.ReadStats
 }
 
 func (s *sourceIterator) Do(ctx context.Context, f func(flux.Table) error) error {
-	return s.reader.Read(ctx, f, s.mem)
+	var err error
+	s.stats, err = influxdb.LimitRead(ctx, func(ctx context.Context) error {
+		return s.reader.Read(ctx, f, s.mem)
+	})
+	return err
+}
+
+func (s *sourceIterator) Metadata() metadata.Metadata {
+	return readMetadata(s.stats)
+}
+
+// readMetadata returns the statistics of a read as
+// metadata for the statistics of the query.
+func readMetadata(stats influxdb.ReadStats) metadata.Metadata {
+	return metadata.Metadata{
+		"influxdb/read-duration":      []interface{}{stats.ReadDuration},
+		"influxdb/read-wait-duration": []interface{}{stats.WaitDuration},
+	}
 }
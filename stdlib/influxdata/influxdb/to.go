@@ -10,6 +10,7 @@ import (
 	"github.com/apache/arrow/go/v7/arrow/memory"
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/arrow"
 	"github.com/influxdata/flux/codes"
 	"github.com/influxdata/flux/compiler"
 	"github.com/influxdata/flux/dependencies/influxdb"
@@ -28,6 +29,11 @@ import (
 // ToKind is the kind for the `to` flux function
 const ToKind = "to"
 
+// ToWithSummaryKind is the name of the flux function that writes
+// like `to` but outputs a summary of the write. It shares the
+// operation kind of `to`.
+const ToWithSummaryKind = "toWithSummary"
+
 type (
 	Tag    = influxdb.Tag
 	Field  = influxdb.Field
@@ -38,6 +44,8 @@ type (
 func init() {
 	toSignature := runtime.MustLookupBuiltinType("influxdata/influxdb", "to")
 	runtime.RegisterPackageValue("influxdata/influxdb", ToKind, flux.MustValue(flux.FunctionValueWithSideEffect(ToKind, createToOpSpec, toSignature)))
+	toWithSummarySignature := runtime.MustLookupBuiltinType("influxdata/influxdb", ToWithSummaryKind)
+	runtime.RegisterPackageValue("influxdata/influxdb", ToWithSummaryKind, flux.MustValue(flux.FunctionValueWithSideEffect(ToWithSummaryKind, createToWithSummaryOpSpec, toWithSummarySignature)))
 	plan.RegisterProcedureSpecWithSideEffect(ToKind, newToProcedure, ToKind)
 	execute.RegisterTransformation(ToKind, createToTransformation)
 }
@@ -46,6 +54,10 @@ const (
 	defaultToFieldColLabel       = DefaultFieldColLabel
 	defaultToMeasurementColLabel = DefaultMeasurementColLabel
 	toOp                         = "influxdata/influxdb/to"
+
+	toSummaryStatusColLabel = "status"
+	toSummaryReasonColLabel = "reason"
+	toSummaryCountColLabel  = "count"
 )
 
 func createToTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
@@ -93,13 +105,19 @@ func NewToTransformation(ctx context.Context, id execute.DatasetID, spec *ToProc
 		Bucket: bucket,
 		Host:   spec.Spec.Host,
 		Token:  spec.Spec.Token,
+		WriteOptions: influxdb.WriteOptions{
+			MaxRetries:       spec.Spec.MaxRetries,
+			RetryInterval:    spec.Spec.RetryInterval.Duration(),
+			MaxRetryInterval: spec.Spec.MaxRetryInterval.Duration(),
+			Gzip:             spec.Spec.Gzip,
+		},
 	}
 	writer, err := deps.WriterFor(ctx, conf)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return execute.NewNarrowTransformation(id, &toTransformation{
+	t := &toTransformation{
 		ctx:                ctx,
 		fn:                 fn,
 		spec:               spec.Spec,
@@ -107,11 +125,27 @@ func NewToTransformation(ctx context.Context, id execute.DatasetID, spec *ToProc
 		tagColumns:         append([]string(nil), spec.Spec.TagColumns...),
 		writer:             writer,
 		span:               span,
-	}, mem)
+	}
+	if spec.Spec.Summary {
+		return execute.NewAggregateTransformation(id, &toSummaryTransformation{toTransformation: t}, mem)
+	}
+	return execute.NewNarrowTransformation(id, t, mem)
 }
 
 // Process does the actual work for the ToTransformation.
 func (t *toTransformation) Process(chunk table.Chunk, d *execute.TransportDataset, mem memory.Allocator) error {
+	if _, err := t.write(chunk); err != nil {
+		return err
+	}
+
+	// Filter out rows with null times if they exist.
+	filtered := t.filterNulls(chunk, mem)
+	return d.Process(filtered)
+}
+
+// write will write the table chunk and return the number of
+// points that were written and rejected.
+func (t *toTransformation) write(chunk table.Chunk) (writeStats, error) {
 	// If no tag columns are specified, by default we exclude
 	// _field and _value from being tag columns.
 	if t.implicitTagColumns {
@@ -138,14 +172,7 @@ func (t *toTransformation) Process(chunk table.Chunk, d *execute.TransportDatase
 
 		t.addTagsFromTable(chunk.Cols(), excludeColumns)
 	}
-
-	if err := t.writeTable(chunk); err != nil {
-		return err
-	}
-
-	// Filter out rows with null times if they exist.
-	filtered := t.filterNulls(chunk, mem)
-	return d.Process(filtered)
+	return t.writeTable(chunk)
 }
 
 func (t *toTransformation) addTagsFromTable(cols []flux.ColMeta, exclude map[string]bool) {
@@ -163,7 +190,7 @@ func (t *toTransformation) addTagsFromTable(cols []flux.ColMeta, exclude map[str
 	sort.Strings(t.tagColumns)
 }
 
-func (t *toTransformation) writeTable(chunk table.Chunk) (err error) {
+func (t *toTransformation) writeTable(chunk table.Chunk) (stats writeStats, err error) {
 	spec := t.spec
 
 	// cache tag columns
@@ -179,9 +206,9 @@ func (t *toTransformation) writeTable(chunk table.Chunk) (err error) {
 	measurementColIdx := execute.ColIdx(measurementColLabel, columns)
 
 	if measurementColIdx < 0 {
-		return errors.Newf(codes.Invalid, "no column with label %s exists", measurementColLabel)
+		return stats, errors.Newf(codes.Invalid, "no column with label %s exists", measurementColLabel)
 	} else if columns[measurementColIdx].Type != flux.TString {
-		return errors.Newf(codes.Invalid, "column %s of type %s is not of type %s", measurementColLabel, columns[measurementColIdx].Type, flux.TString)
+		return stats, errors.Newf(codes.Invalid, "column %s of type %s is not of type %s", measurementColLabel, columns[measurementColIdx].Type, flux.TString)
 	}
 
 	// do time
@@ -189,9 +216,9 @@ func (t *toTransformation) writeTable(chunk table.Chunk) (err error) {
 	timeColIdx := execute.ColIdx(timeColLabel, columns)
 
 	if timeColIdx < 0 {
-		return errors.New(codes.Invalid, "no time column detected")
+		return stats, errors.New(codes.Invalid, "no time column detected")
 	} else if columns[timeColIdx].Type != flux.TTime {
-		return errors.Newf(codes.Invalid, "column %s of type %s is not of type %s", timeColLabel, columns[timeColIdx].Type, flux.TTime)
+		return stats, errors.Newf(codes.Invalid, "column %s of type %s is not of type %s", timeColLabel, columns[timeColIdx].Type, flux.TTime)
 	}
 
 	// prepare field function if applicable and record the number of values to write per row
@@ -199,7 +226,7 @@ func (t *toTransformation) writeTable(chunk table.Chunk) (err error) {
 	if spec.FieldFn.Fn != nil {
 		var err error
 		if fn, err = t.fn.Prepare(columns); err != nil {
-			return err
+			return stats, err
 		}
 	}
 
@@ -222,12 +249,13 @@ outer:
 				valueTime := execute.ValueForRow(&er, i, j)
 				if valueTime.IsNull() {
 					// skip rows with null timestamp
+					stats.nullTime++
 					continue outer
 				}
 				metric.TS = valueTime.Time().Time()
 			case isTag[j]:
 				if col.Type != flux.TString {
-					return errors.New(codes.Invalid, "invalid type for tag column")
+					return stats, errors.New(codes.Invalid, "invalid type for tag column")
 				}
 
 				value := er.Strings(j).Value(i)
@@ -244,15 +272,15 @@ outer:
 		}
 
 		if metric.TS.IsZero() {
-			return errors.New(codes.Invalid, "timestamp missing from block")
+			return stats, errors.New(codes.Invalid, "timestamp missing from block")
 		}
 
 		if fn == nil {
 			if fieldValues, err = defaultFieldMapping(&er, i); err != nil {
-				return err
+				return stats, err
 			}
 		} else if fieldValues, err = fn.Eval(t.ctx, i, &er); err != nil {
-			return err
+			return stats, err
 		}
 
 		metric.Fields = make([]*Field, 0, fieldValues.Len())
@@ -294,21 +322,26 @@ outer:
 		})
 
 		if err != nil {
-			return err
+			return stats, err
 		}
 
 		// drop metrics without any measurements
 		if len(metric.Fields) > 0 {
 			metrics = append(metrics, metric)
+		} else {
+			stats.noFields++
 		}
 	}
 
 	// only write if we have any metrics to write
 	if len(metrics) > 0 {
-		err = t.writer.Write(metrics...)
+		if err = t.writer.Write(metrics...); err != nil {
+			return stats, err
+		}
+		stats.written += int64(len(metrics))
 	}
 
-	return err
+	return stats, nil
 }
 
 // filterNulls will filter out the rows where the time is null from the table chunk.
@@ -348,6 +381,93 @@ func (t *toTransformation) Close() error {
 	return t.writer.Close()
 }
 
+// writeStats counts the points of a table that were written
+// and the points that were rejected before they were written.
+type writeStats struct {
+	written  int64
+	nullTime int64
+	noFields int64
+}
+
+// toSummaryTransformation writes tables in the same way as the
+// toTransformation, but outputs a table for each group key that
+// summarizes the points that were written and rejected instead
+// of the written rows.
+type toSummaryTransformation struct {
+	*toTransformation
+}
+
+func (t *toSummaryTransformation) Aggregate(chunk table.Chunk, state interface{}, mem memory.Allocator) (interface{}, bool, error) {
+	stats, err := t.write(chunk)
+	if err != nil {
+		return nil, false, err
+	}
+
+	s, _ := state.(*writeStats)
+	if s == nil {
+		s = &writeStats{}
+	}
+	s.written += stats.written
+	s.nullTime += stats.nullTime
+	s.noFields += stats.noFields
+	return s, true, nil
+}
+
+func (t *toSummaryTransformation) Compute(key flux.GroupKey, state interface{}, d *execute.TransportDataset, mem memory.Allocator) error {
+	for _, label := range []string{toSummaryStatusColLabel, toSummaryReasonColLabel, toSummaryCountColLabel} {
+		if key.HasCol(label) {
+			return errors.Newf(codes.FailedPrecondition, "column %q must not be part of the group key", label)
+		}
+	}
+
+	// The written points are always reported while the
+	// rejected points are only reported when there are any.
+	s := state.(*writeStats)
+	type outcome struct {
+		status, reason string
+		count          int64
+	}
+	outcomes := []outcome{{status: "written", count: s.written}}
+	if s.nullTime > 0 {
+		outcomes = append(outcomes, outcome{status: "rejected", reason: "null time", count: s.nullTime})
+	}
+	if s.noFields > 0 {
+		outcomes = append(outcomes, outcome{status: "rejected", reason: "no valid fields", count: s.noFields})
+	}
+
+	n := len(outcomes)
+	cols := make([]flux.ColMeta, 0, len(key.Cols())+3)
+	vs := make([]array.Array, 0, len(key.Cols())+3)
+	for j, col := range key.Cols() {
+		cols = append(cols, col)
+		vs = append(vs, arrow.Repeat(col.Type, key.Value(j), n, mem))
+	}
+
+	statuses := array.NewStringBuilder(mem)
+	reasons := array.NewStringBuilder(mem)
+	counts := array.NewIntBuilder(mem)
+	statuses.Resize(n)
+	reasons.Resize(n)
+	counts.Resize(n)
+	for _, o := range outcomes {
+		statuses.Append(o.status)
+		reasons.Append(o.reason)
+		counts.Append(o.count)
+	}
+	cols = append(cols,
+		flux.ColMeta{Label: toSummaryStatusColLabel, Type: flux.TString},
+		flux.ColMeta{Label: toSummaryReasonColLabel, Type: flux.TString},
+		flux.ColMeta{Label: toSummaryCountColLabel, Type: flux.TInt},
+	)
+	vs = append(vs, statuses.NewArray(), reasons.NewArray(), counts.NewArray())
+
+	return d.Process(table.ChunkFromBuffer(arrow.TableBuffer{
+		GroupKey: key,
+		Columns:  cols,
+		Values:   vs,
+	}))
+}
+
 // fieldFunctionVisitor implements semantic.Visitor.
 // fieldFunctionVisitor is used to walk the the field function expression
 // of the `to` operation and to record all referenced columns. This visitor
@@ -433,6 +553,11 @@ type ToOpSpec struct {
 	MeasurementColumn string                       `json:"measurementColumn"`
 	TagColumns        []string                     `json:"tagColumns"`
 	FieldFn           interpreter.ResolvedFunction `json:"fieldFn"`
+	MaxRetries        int                          `json:"maxRetries"`
+	RetryInterval     flux.Duration                `json:"retryInterval"`
+	MaxRetryInterval  flux.Duration                `json:"maxRetryInterval"`
+	Gzip              bool                         `json:"gzip"`
+	Summary           bool                         `json:"summary"`
 }
 
 // ToProcedureSpec is the procedure spec for the `to` flux function.
@@ -442,6 +567,10 @@ type ToProcedureSpec struct {
 }
 
 func (o *ToProcedureSpec) PassThroughAttribute(attrKey string) bool {
+	if o.Spec.Summary {
+		// The summary replaces the rows so there is nothing to pass through.
+		return false
+	}
 	switch attrKey {
 	case plan.ParallelRunKey, plan.CollationKey:
 		return true
@@ -469,6 +598,11 @@ func (o *ToProcedureSpec) Copy() plan.ProcedureSpec {
 			MeasurementColumn: s.MeasurementColumn,
 			TagColumns:        append([]string(nil), s.TagColumns...),
 			FieldFn:           s.FieldFn.Copy(),
+			MaxRetries:        s.MaxRetries,
+			RetryInterval:     s.RetryInterval,
+			MaxRetryInterval:  s.MaxRetryInterval,
+			Gzip:              s.Gzip,
+			Summary:           s.Summary,
 		},
 	}
 	return res
@@ -542,6 +676,39 @@ func (o *ToOpSpec) ReadArgs(args flux.Arguments) error {
 		}
 	}
 
+	if maxRetries, ok, err := args.GetInt("maxRetries"); err != nil {
+		return err
+	} else if ok {
+		if maxRetries < 0 {
+			return errors.New(codes.Invalid, "maxRetries must not be negative")
+		}
+		o.MaxRetries = int(maxRetries)
+	}
+
+	if interval, ok, err := args.GetDuration("retryInterval"); err != nil {
+		return err
+	} else if ok {
+		if !interval.IsPositive() {
+			return errors.New(codes.Invalid, "retryInterval must be positive")
+		}
+		o.RetryInterval = interval
+	}
+
+	if interval, ok, err := args.GetDuration("maxRetryInterval"); err != nil {
+		return err
+	} else if ok {
+		if !interval.IsPositive() {
+			return errors.New(codes.Invalid, "maxRetryInterval must be positive")
+		}
+		o.MaxRetryInterval = interval
+	}
+
+	if gzip, ok, err := args.GetBool("gzip"); err != nil {
+		return err
+	} else if ok {
+		o.Gzip = gzip
+	}
+
 	return err
 }
 
@@ -556,6 +723,15 @@ func createToOpSpec(args flux.Arguments, a *flux.Administration) (flux.Operation
 	return s, nil
 }
 
+func createToWithSummaryOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	spec, err := createToOpSpec(args, a)
+	if err != nil {
+		return nil, err
+	}
+	spec.(*ToOpSpec).Summary = true
+	return spec, nil
+}
+
 // Kind returns the kind for the ToOpSpec function.
 func (ToOpSpec) Kind() flux.OperationKind {
 	return ToKind
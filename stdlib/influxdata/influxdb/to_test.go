@@ -797,3 +797,77 @@ func TestTo_Process(t *testing.T) {
 		})
 	}
 }
+
+func TestToWithSummary_Process(t *testing.T) {
+	spec := &influxdb.ToProcedureSpec{
+		Spec: &influxdb.ToOpSpec{
+			Org:               "my-org",
+			Bucket:            "my-bucket",
+			TimeColumn:        "_time",
+			MeasurementColumn: "_measurement",
+			Summary:           true,
+		},
+	}
+	data := []*executetest.Table{{
+		KeyCols: []string{"_measurement"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_measurement", Type: flux.TString},
+			{Label: "_field", Type: flux.TString},
+			{Label: "_value", Type: flux.TFloat},
+		},
+		Data: [][]interface{}{
+			{execute.Time(11), "a", "_value", 2.0},
+			{nil, "a", "_value", 2.0},
+			{execute.Time(21), "a", "_value", math.NaN()},
+			{execute.Time(31), "a", "_value", 3.0},
+			{execute.Time(41), "a", "_value", nil},
+		},
+	}}
+	want := []*executetest.Table{{
+		KeyCols: []string{"_measurement"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_measurement", Type: flux.TString},
+			{Label: "status", Type: flux.TString},
+			{Label: "reason", Type: flux.TString},
+			{Label: "count", Type: flux.TInt},
+		},
+		Data: [][]interface{}{
+			{"a", "written", "", int64(2)},
+			{"a", "rejected", "null time", int64(1)},
+			{"a", "rejected", "no valid fields", int64(2)},
+		},
+	}}
+
+	writer := &pointsWriter{}
+	provider := mock.InfluxDBProvider{
+		WriterForFn: func(ctx context.Context, conf influxdb2.Config) (influxdb2.Writer, error) {
+			return writer, nil
+		},
+	}
+	inTables := make([]flux.Table, 0, len(data))
+	for _, tbl := range data {
+		inTables = append(inTables, tbl)
+	}
+	executetest.ProcessTestHelper2(
+		t,
+		inTables,
+		want,
+		nil,
+		func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+			tr, d, err := influxdb.NewToTransformation(context.TODO(), id, spec, provider, alloc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return tr, d
+		},
+	)
+
+	wantWrites := []influxdb.Metric{
+		rowMetric("a", [][2]string{}, [][2]interface{}{{"_value", 2.0}}, time.Unix(0, 11)),
+		rowMetric("a", [][2]string{}, [][2]interface{}{{"_value", 3.0}}, time.Unix(0, 31)),
+	}
+	if !cmp.Equal(wantWrites, writer.writes) {
+		t.Errorf("got other than expected -want/+got %s", cmp.Diff(wantWrites, writer.writes))
+	}
+}
@@ -0,0 +1,330 @@
+package debug
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/runtime"
+)
+
+// The fault injection transformations pass their input through to the next
+// transformation like pass, but they also delay, fail or lose data on purpose.
+// They are used to test how the engine and the programs that embed it handle
+// slow and failing queries.
+const (
+	SlowNodeKind    = "internal/debug.slowNode"
+	FailAfterKind   = "internal/debug.failAfter"
+	DropBuffersKind = "internal/debug.dropBuffers"
+)
+
+// DefaultFailMessage is the message of the error returned
+// by failAfter when no message has been specified.
+const DefaultFailMessage = "injected failure"
+
+type SlowNodeOpSpec struct {
+	Delay flux.Duration
+}
+
+type FailAfterOpSpec struct {
+	N       int64
+	Message string
+}
+
+type DropBuffersOpSpec struct {
+	Every int64
+}
+
+func init() {
+	slowNodeSignature := runtime.MustLookupBuiltinType("internal/debug", "slowNode")
+	failAfterSignature := runtime.MustLookupBuiltinType("internal/debug", "failAfter")
+	dropBuffersSignature := runtime.MustLookupBuiltinType("internal/debug", "dropBuffers")
+
+	runtime.RegisterPackageValue("internal/debug", "slowNode", flux.MustValue(flux.FunctionValue(SlowNodeKind, createSlowNodeOpSpec, slowNodeSignature)))
+	runtime.RegisterPackageValue("internal/debug", "failAfter", flux.MustValue(flux.FunctionValue(FailAfterKind, createFailAfterOpSpec, failAfterSignature)))
+	runtime.RegisterPackageValue("internal/debug", "dropBuffers", flux.MustValue(flux.FunctionValue(DropBuffersKind, createDropBuffersOpSpec, dropBuffersSignature)))
+	plan.RegisterProcedureSpec(SlowNodeKind, newSlowNodeProcedure, SlowNodeKind)
+	plan.RegisterProcedureSpec(FailAfterKind, newFailAfterProcedure, FailAfterKind)
+	plan.RegisterProcedureSpec(DropBuffersKind, newDropBuffersProcedure, DropBuffersKind)
+	execute.RegisterTransformation(SlowNodeKind, createSlowNodeTransformation)
+	execute.RegisterTransformation(FailAfterKind, createFailAfterTransformation)
+	execute.RegisterTransformation(DropBuffersKind, createDropBuffersTransformation)
+}
+
+func createSlowNodeOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(SlowNodeOpSpec)
+	delay, err := args.GetRequiredDuration("delay")
+	if err != nil {
+		return nil, err
+	} else if delay.IsNegative() {
+		return nil, errors.New(codes.Invalid, "delay must not be negative")
+	}
+	spec.Delay = delay
+	return spec, nil
+}
+
+func (s *SlowNodeOpSpec) Kind() flux.OperationKind {
+	return SlowNodeKind
+}
+
+func createFailAfterOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := &FailAfterOpSpec{
+		Message: DefaultFailMessage,
+	}
+	n, err := args.GetRequiredInt("n")
+	if err != nil {
+		return nil, err
+	} else if n < 0 {
+		return nil, errors.New(codes.Invalid, "n must not be negative")
+	}
+	spec.N = n
+
+	if message, ok, err := args.GetString("message"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Message = message
+	}
+	return spec, nil
+}
+
+func (s *FailAfterOpSpec) Kind() flux.OperationKind {
+	return FailAfterKind
+}
+
+func createDropBuffersOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(DropBuffersOpSpec)
+	every, err := args.GetRequiredInt("every")
+	if err != nil {
+		return nil, err
+	} else if every <= 0 {
+		return nil, errors.New(codes.Invalid, "every must be greater than zero")
+	}
+	spec.Every = every
+	return spec, nil
+}
+
+func (s *DropBuffersOpSpec) Kind() flux.OperationKind {
+	return DropBuffersKind
+}
+
+type SlowNodeProcedureSpec struct {
+	plan.DefaultCost
+	Delay time.Duration
+}
+
+func newSlowNodeProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*SlowNodeOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+	return &SlowNodeProcedureSpec{
+		Delay: spec.Delay.Duration(),
+	}, nil
+}
+
+func (s *SlowNodeProcedureSpec) Kind() plan.ProcedureKind {
+	return SlowNodeKind
+}
+
+func (s *SlowNodeProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+// TriggerSpec implements plan.TriggerAwareProcedureSpec
+func (s *SlowNodeProcedureSpec) TriggerSpec() plan.TriggerSpec {
+	return plan.NarrowTransformationTriggerSpec{}
+}
+
+type FailAfterProcedureSpec struct {
+	plan.DefaultCost
+	N       int64
+	Message string
+}
+
+func newFailAfterProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*FailAfterOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+	return &FailAfterProcedureSpec{
+		N:       spec.N,
+		Message: spec.Message,
+	}, nil
+}
+
+func (s *FailAfterProcedureSpec) Kind() plan.ProcedureKind {
+	return FailAfterKind
+}
+
+func (s *FailAfterProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+// TriggerSpec implements plan.TriggerAwareProcedureSpec
+func (s *FailAfterProcedureSpec) TriggerSpec() plan.TriggerSpec {
+	return plan.NarrowTransformationTriggerSpec{}
+}
+
+type DropBuffersProcedureSpec struct {
+	plan.DefaultCost
+	Every int64
+}
+
+func newDropBuffersProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*DropBuffersOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+	return &DropBuffersProcedureSpec{
+		Every: spec.Every,
+	}, nil
+}
+
+func (s *DropBuffersProcedureSpec) Kind() plan.ProcedureKind {
+	return DropBuffersKind
+}
+
+func (s *DropBuffersProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+// TriggerSpec implements plan.TriggerAwareProcedureSpec
+func (s *DropBuffersProcedureSpec) TriggerSpec() plan.TriggerSpec {
+	return plan.NarrowTransformationTriggerSpec{}
+}
+
+func createSlowNodeTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*SlowNodeProcedureSpec)
+	if !ok {
+		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
+	}
+	return NewSlowNodeTransformation(a.Context(), id, s, a.Allocator())
+}
+
+func createFailAfterTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*FailAfterProcedureSpec)
+	if !ok {
+		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
+	}
+	return NewFailAfterTransformation(id, s, a.Allocator())
+}
+
+func createDropBuffersTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*DropBuffersProcedureSpec)
+	if !ok {
+		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
+	}
+	return NewDropBuffersTransformation(id, s, a.Allocator())
+}
+
+type slowNodeTransformation struct {
+	ctx   context.Context
+	delay time.Duration
+}
+
+// NewSlowNodeTransformation constructs a transformation that waits for
+// the delay before it passes each buffer to the next transformation.
+// The wait ends early when the context is canceled.
+func NewSlowNodeTransformation(ctx context.Context, id execute.DatasetID, spec *SlowNodeProcedureSpec, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	tr := &slowNodeTransformation{
+		ctx:   ctx,
+		delay: spec.Delay,
+	}
+	return execute.NewNarrowTransformation(id, tr, mem)
+}
+
+func (t *slowNodeTransformation) Process(chunk table.Chunk, d *execute.TransportDataset, mem memory.Allocator) error {
+	timer := time.NewTimer(t.delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-t.ctx.Done():
+		return t.ctx.Err()
+	}
+
+	chunk.Retain()
+	return d.Process(chunk)
+}
+
+func (t *slowNodeTransformation) Close() error { return nil }
+
+type failAfterTransformation struct {
+	n       int64
+	message string
+	tables  int64
+}
+
+// NewFailAfterTransformation constructs a transformation that passes
+// the first n tables to the next transformation and fails with an
+// error when it receives the next table.
+func NewFailAfterTransformation(id execute.DatasetID, spec *FailAfterProcedureSpec, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	tr := &failAfterTransformation{
+		n:       spec.N,
+		message: spec.Message,
+	}
+	return execute.NewNarrowStateTransformation[bool](id, tr, mem)
+}
+
+func (t *failAfterTransformation) Process(chunk table.Chunk, seen bool, d *execute.TransportDataset, mem memory.Allocator) (bool, bool, error) {
+	if !seen {
+		if t.tables >= t.n {
+			return false, false, errors.New(codes.Internal, t.message)
+		}
+		t.tables++
+	}
+
+	chunk.Retain()
+	if err := d.Process(chunk); err != nil {
+		return false, false, err
+	}
+	return true, !seen, nil
+}
+
+func (t *failAfterTransformation) Close() error { return nil }
+
+type dropBuffersTransformation struct {
+	every   int64
+	buffers int64
+}
+
+// NewDropBuffersTransformation constructs a transformation that drops
+// every nth buffer and passes the other buffers to the next transformation.
+func NewDropBuffersTransformation(id execute.DatasetID, spec *DropBuffersProcedureSpec, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	tr := &dropBuffersTransformation{
+		every: spec.Every,
+	}
+	return execute.NewNarrowTransformation(id, tr, mem)
+}
+
+func (t *dropBuffersTransformation) Process(chunk table.Chunk, d *execute.TransportDataset, mem memory.Allocator) error {
+	t.buffers++
+	if t.buffers%t.every == 0 {
+		return nil
+	}
+
+	chunk.Retain()
+	return d.Process(chunk)
+}
+
+func (t *dropBuffersTransformation) Close() error { return nil }
@@ -0,0 +1,150 @@
+package debug_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/stdlib/internal/debug"
+)
+
+func faultTestTables() []*executetest.Table {
+	var tables []*executetest.Table
+	for _, t0 := range []string{"a", "b", "c"} {
+		tables = append(tables, &executetest.Table{
+			KeyCols: []string{"t0"},
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "t0", Type: flux.TString},
+				{Label: "_value", Type: flux.TFloat},
+			},
+			Data: [][]interface{}{
+				{execute.Time(1), t0, 2.0},
+				{execute.Time(2), t0, 3.0},
+			},
+		})
+	}
+	return tables
+}
+
+func toFluxTables(tables []*executetest.Table) []flux.Table {
+	data := make([]flux.Table, len(tables))
+	for i, tbl := range tables {
+		data[i] = tbl
+	}
+	return data
+}
+
+func TestSlowNode_Process(t *testing.T) {
+	data := faultTestTables()
+
+	start := time.Now()
+	executetest.ProcessTestHelper2(
+		t,
+		toFluxTables(faultTestTables()),
+		data,
+		nil,
+		func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+			spec := &debug.SlowNodeProcedureSpec{Delay: 10 * time.Millisecond}
+			tr, d, err := debug.NewSlowNodeTransformation(context.Background(), id, spec, alloc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return tr, d
+		},
+	)
+	if got, want := time.Since(start), 30*time.Millisecond; got < want {
+		t.Errorf("expected the tables to be delayed by at least %v, got %v", want, got)
+	}
+}
+
+func TestSlowNode_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	executetest.ProcessTestHelper2(
+		t,
+		toFluxTables(faultTestTables()),
+		nil,
+		context.Canceled,
+		func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+			spec := &debug.SlowNodeProcedureSpec{Delay: time.Hour}
+			tr, d, err := debug.NewSlowNodeTransformation(ctx, id, spec, alloc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return tr, d
+		},
+	)
+}
+
+func TestFailAfter_Process(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		n       int64
+		wantErr error
+	}{
+		{
+			name:    "fail on first table",
+			n:       0,
+			wantErr: errors.New(codes.Internal, "injected failure"),
+		},
+		{
+			name:    "fail on third table",
+			n:       2,
+			wantErr: errors.New(codes.Internal, "injected failure"),
+		},
+		{
+			name: "no failure",
+			n:    3,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var want []*executetest.Table
+			if tt.wantErr == nil {
+				want = faultTestTables()
+			}
+			executetest.ProcessTestHelper2(
+				t,
+				toFluxTables(faultTestTables()),
+				want,
+				tt.wantErr,
+				func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+					spec := &debug.FailAfterProcedureSpec{N: tt.n, Message: debug.DefaultFailMessage}
+					tr, d, err := debug.NewFailAfterTransformation(id, spec, alloc)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return tr, d
+				},
+			)
+		})
+	}
+}
+
+func TestDropBuffers_Process(t *testing.T) {
+	want := faultTestTables()
+	want = append(want[:1], want[2:]...)
+
+	executetest.ProcessTestHelper2(
+		t,
+		toFluxTables(faultTestTables()),
+		want,
+		nil,
+		func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+			spec := &debug.DropBuffersProcedureSpec{Every: 2}
+			tr, d, err := debug.NewDropBuffersTransformation(id, spec, alloc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return tr, d
+		},
+	)
+}
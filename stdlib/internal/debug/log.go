@@ -0,0 +1,214 @@
+package debug
+
+import (
+	"strings"
+
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/internal/feature"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/runtime"
+	"github.com/influxdata/flux/values"
+	"go.uber.org/zap"
+)
+
+const LogKind = "internal/debug.log"
+
+// DefaultLogRows is the number of rows of each table that are logged
+// when the number of rows has not been specified.
+const DefaultLogRows = 5
+
+type LogOpSpec struct {
+	Prefix string
+	Rows   int64
+}
+
+func init() {
+	logSignature := runtime.MustLookupBuiltinType("internal/debug", "log")
+
+	runtime.RegisterPackageValue("internal/debug", "log", flux.MustValue(flux.FunctionValue(LogKind, createLogOpSpec, logSignature)))
+	plan.RegisterProcedureSpec(LogKind, newLogProcedure, LogKind)
+	execute.RegisterTransformation(LogKind, createLogTransformation)
+}
+
+func createLogOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := &LogOpSpec{
+		Rows: DefaultLogRows,
+	}
+	if prefix, ok, err := args.GetString("prefix"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Prefix = prefix
+	}
+
+	if rows, ok, err := args.GetInt("rows"); err != nil {
+		return nil, err
+	} else if ok {
+		if rows < 0 {
+			return nil, errors.New(codes.Invalid, "rows must not be negative")
+		}
+		spec.Rows = rows
+	}
+	return spec, nil
+}
+
+func (s *LogOpSpec) Kind() flux.OperationKind {
+	return LogKind
+}
+
+type LogProcedureSpec struct {
+	plan.DefaultCost
+	Prefix string
+	Rows   int64
+}
+
+func newLogProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*LogOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+	return &LogProcedureSpec{
+		Prefix: spec.Prefix,
+		Rows:   spec.Rows,
+	}, nil
+}
+
+func (s *LogProcedureSpec) Kind() plan.ProcedureKind {
+	return LogKind
+}
+
+func (s *LogProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+// PassThroughAttribute implements the PassThroughAttributer interface used
+// by the planner. The tables are not modified so any attributes provided by
+// the input are also propagated to the output.
+func (s *LogProcedureSpec) PassThroughAttribute(attrKey string) bool {
+	return true
+}
+
+// TriggerSpec implements plan.TriggerAwareProcedureSpec
+func (s *LogProcedureSpec) TriggerSpec() plan.TriggerSpec {
+	return plan.NarrowTransformationTriggerSpec{}
+}
+
+func createLogTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*LogProcedureSpec)
+	if !ok {
+		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
+	}
+
+	// The tables are only logged when the feature is enabled
+	// and the query has been executed with a logger.
+	var logger *zap.Logger
+	if feature.DebugLog().Enabled(a.Context()) {
+		logger = execute.GetExecutionDependencies(a.Context()).Logger
+	}
+	return NewLogTransformation(id, s, logger, a.Allocator())
+}
+
+type logTransformation struct {
+	logger *zap.Logger
+	prefix string
+	rows   int
+}
+
+// NewLogTransformation constructs a transformation that passes the tables
+// through unmodified and writes a summary of each table to the logger.
+// The summary contains the group key, the schema, the number of rows and
+// the first rows of the table. Nothing is logged if the logger is nil.
+func NewLogTransformation(id execute.DatasetID, spec *LogProcedureSpec, logger *zap.Logger, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	tr := &logTransformation{
+		logger: logger,
+		prefix: spec.Prefix,
+		rows:   int(spec.Rows),
+	}
+	return execute.NewNarrowStateTransformation[*tableSummary](id, tr, mem)
+}
+
+func (t *logTransformation) Process(chunk table.Chunk, state *tableSummary, d *execute.TransportDataset, mem memory.Allocator) (*tableSummary, bool, error) {
+	if t.logger != nil {
+		if state == nil {
+			state = &tableSummary{
+				t:   t,
+				key: chunk.Key(),
+			}
+		}
+		state.add(chunk)
+	}
+
+	chunk.Retain()
+	if err := d.Process(chunk); err != nil {
+		return nil, false, err
+	}
+	return state, state != nil, nil
+}
+
+func (t *logTransformation) Close() error { return nil }
+
+// tableSummary accumulates the summary of a table and writes it to
+// the logger when the table is closed.
+type tableSummary struct {
+	t      *logTransformation
+	key    flux.GroupKey
+	schema []string
+	nrows  int
+	rows   []string
+}
+
+func (s *tableSummary) add(chunk table.Chunk) {
+	if s.schema == nil {
+		s.schema = make([]string, 0, chunk.NCols())
+		for _, col := range chunk.Cols() {
+			s.schema = append(s.schema, col.Label+": "+col.Type.String())
+		}
+	}
+	s.nrows += chunk.Len()
+
+	buffer := chunk.Buffer()
+	var sb strings.Builder
+	for i, l := 0, chunk.Len(); i < l && len(s.rows) < s.t.rows; i++ {
+		sb.Reset()
+		sb.WriteByte('{')
+		for j, col := range chunk.Cols() {
+			if j > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(col.Label)
+			sb.WriteString(": ")
+			sb.WriteString(values.DisplayString(execute.ValueForRow(&buffer, i, j)))
+		}
+		sb.WriteByte('}')
+		s.rows = append(s.rows, sb.String())
+	}
+}
+
+func (s *tableSummary) groupKey() []string {
+	cols := s.key.Cols()
+	key := make([]string, len(cols))
+	for j, col := range cols {
+		key[j] = col.Label + "=" + values.DisplayString(s.key.Value(j))
+	}
+	return key
+}
+
+func (s *tableSummary) Close() error {
+	s.t.logger.Info("table summary",
+		zap.String("prefix", s.t.prefix),
+		zap.Strings("group_key", s.groupKey()),
+		zap.Strings("schema", s.schema),
+		zap.Int("rows", s.nrows),
+		zap.Strings("first_rows", s.rows),
+	)
+	return nil
+}
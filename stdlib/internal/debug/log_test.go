@@ -0,0 +1,119 @@
+package debug_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/stdlib/internal/debug"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLog_Process(t *testing.T) {
+	data := []*executetest.Table{
+		{
+			KeyCols: []string{"t0"},
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "t0", Type: flux.TString},
+				{Label: "_value", Type: flux.TFloat},
+			},
+			Data: [][]interface{}{
+				{execute.Time(1), "a", 2.0},
+				{execute.Time(2), "a", nil},
+				{execute.Time(3), "a", 4.0},
+			},
+		},
+		{
+			KeyCols: []string{"t0"},
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "t0", Type: flux.TString},
+				{Label: "_value", Type: flux.TFloat},
+			},
+			Data: [][]interface{}{
+				{execute.Time(1), "b", 5.0},
+			},
+		},
+	}
+
+	core, logs := observer.New(zap.InfoLevel)
+	logger := zap.New(core)
+
+	tables := make([]flux.Table, len(data))
+	for i, tbl := range data {
+		tables[i] = tbl
+	}
+	executetest.ProcessTestHelper2(
+		t,
+		tables,
+		data,
+		nil,
+		func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+			spec := &debug.LogProcedureSpec{Prefix: "test", Rows: 2}
+			tr, d, err := debug.NewLogTransformation(id, spec, logger, alloc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return tr, d
+		},
+	)
+
+	var got []map[string]interface{}
+	for _, entry := range logs.All() {
+		got = append(got, entry.ContextMap())
+	}
+	want := []map[string]interface{}{
+		{
+			"prefix":     "test",
+			"group_key":  []interface{}{"t0=a"},
+			"schema":     []interface{}{"_time: time", "t0: string", "_value: float"},
+			"rows":       int64(3),
+			"first_rows": []interface{}{"{_time: 1970-01-01T00:00:00.000000001Z, t0: a, _value: 2}", "{_time: 1970-01-01T00:00:00.000000002Z, t0: a, _value: <null>}"},
+		},
+		{
+			"prefix":     "test",
+			"group_key":  []interface{}{"t0=b"},
+			"schema":     []interface{}{"_time: time", "t0: string", "_value: float"},
+			"rows":       int64(1),
+			"first_rows": []interface{}{"{_time: 1970-01-01T00:00:00.000000001Z, t0: b, _value: 5}"},
+		},
+	}
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected log entries -want/+got:\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestLog_NoLogger(t *testing.T) {
+	data := []*executetest.Table{
+		{
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_value", Type: flux.TFloat},
+			},
+			Data: [][]interface{}{
+				{execute.Time(1), 2.0},
+				{execute.Time(2), 3.0},
+			},
+		},
+	}
+
+	executetest.ProcessTestHelper2(
+		t,
+		[]flux.Table{data[0]},
+		data,
+		nil,
+		func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+			spec := &debug.LogProcedureSpec{Rows: debug.DefaultLogRows}
+			tr, d, err := debug.NewLogTransformation(id, spec, nil, alloc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return tr, d
+		},
+	)
+}
@@ -29,7 +29,11 @@ func Null(ctx context.Context, args interpreter.Arguments) (values.Value, error)
 	if !ok {
 		return values.Null, nil
 	}
+	return TypedNull(typ)
+}
 
+// TypedNull returns the null value of the basic type with the given name.
+func TypedNull(typ string) (values.Value, error) {
 	var semanticType semantic.MonoType
 	switch typ {
 	case "string":
@@ -0,0 +1,518 @@
+package interpolate
+
+import (
+	"math"
+	"sort"
+
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/runtime"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+const FillKind = "interpolateFill"
+
+const (
+	FillMethodPrevious = "previous"
+	FillMethodNext     = "next"
+	FillMethodLinear   = "linear"
+	FillMethodConstant = "constant"
+)
+
+type FillOpSpec struct {
+	Columns    []FillColumn  `json:"columns"`
+	MaxGap     flux.Duration `json:"maxGap"`
+	TimeColumn string        `json:"timeColumn"`
+}
+
+// FillColumn describes how null values are filled in a column.
+type FillColumn struct {
+	Label  string `json:"label"`
+	Method string `json:"method"`
+
+	// Value is the value used by the constant method.
+	Value values.Value `json:"value"`
+}
+
+func init() {
+	runtime.RegisterPackageValue("interpolate", "fill",
+		flux.MustValue(flux.FunctionValue("fill",
+			createFillOpSpec,
+			runtime.MustLookupBuiltinType("interpolate", "fill"),
+		)),
+	)
+	plan.RegisterProcedureSpec(FillKind, newFillProcedure, FillKind)
+	execute.RegisterTransformation(FillKind, createFillTransformation)
+}
+
+func createFillOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	columns := []string{execute.DefaultValueColLabel}
+	if cols, ok, err := args.GetArray("columns", semantic.String); err != nil {
+		return nil, err
+	} else if ok {
+		if columns, err = interpreter.ToStringArray(cols); err != nil {
+			return nil, err
+		}
+	}
+
+	method := FillMethodPrevious
+	if m, ok, err := args.GetString("method"); err != nil {
+		return nil, err
+	} else if ok {
+		method = m
+	}
+
+	// Per-column methods override the default method and add
+	// the column to the list of columns if it is missing.
+	methods := make(map[string]string)
+	if obj, ok, err := args.GetObject("methods"); err != nil {
+		return nil, err
+	} else if ok {
+		var rangeErr error
+		obj.Range(func(k string, v values.Value) {
+			if v.Type().Nature() != semantic.String {
+				rangeErr = errors.Newf(codes.Invalid, "fill method for column %q must be a string", k)
+				return
+			}
+			methods[k] = v.Str()
+		})
+		if rangeErr != nil {
+			return nil, rangeErr
+		}
+
+		extra := make([]string, 0, len(methods))
+		for label := range methods {
+			if !execute.ContainsStr(columns, label) {
+				extra = append(extra, label)
+			}
+		}
+		sort.Strings(extra)
+		columns = append(columns, extra...)
+	}
+
+	constants := make(map[string]values.Value)
+	if obj, ok, err := args.GetObject("values"); err != nil {
+		return nil, err
+	} else if ok {
+		obj.Range(func(k string, v values.Value) {
+			constants[k] = v
+		})
+	}
+
+	spec := &FillOpSpec{
+		Columns:    make([]FillColumn, len(columns)),
+		TimeColumn: execute.DefaultTimeColLabel,
+	}
+	for i, label := range columns {
+		c := FillColumn{Label: label, Method: method}
+		if m, ok := methods[label]; ok {
+			c.Method = m
+		}
+
+		switch c.Method {
+		case FillMethodPrevious, FillMethodNext, FillMethodLinear:
+		case FillMethodConstant:
+			v, ok := constants[label]
+			if !ok {
+				return nil, errors.Newf(codes.Invalid, "fill method %q for column %q requires a value", c.Method, label)
+			} else if v.IsNull() {
+				return nil, errors.Newf(codes.Invalid, "fill value for column %q must not be null", label)
+			}
+			c.Value = v
+		default:
+			return nil, errors.Newf(codes.Invalid, "unknown fill method %q for column %q", c.Method, label)
+		}
+		spec.Columns[i] = c
+	}
+
+	if maxGap, ok, err := args.GetDuration("maxGap"); err != nil {
+		return nil, err
+	} else if ok {
+		if maxGap.IsNegative() || maxGap.IsZero() {
+			return nil, errors.New(codes.Invalid, "maxGap must be positive")
+		}
+		spec.MaxGap = maxGap
+	}
+
+	if timeCol, ok, err := args.GetString("timeColumn"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.TimeColumn = timeCol
+	}
+	return spec, nil
+}
+
+func (s *FillOpSpec) Kind() flux.OperationKind {
+	return FillKind
+}
+
+type FillProcedureSpec struct {
+	plan.DefaultCost
+	Columns    []FillColumn  `json:"columns"`
+	MaxGap     flux.Duration `json:"maxGap"`
+	TimeColumn string        `json:"timeColumn"`
+}
+
+func newFillProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*FillOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+
+	return &FillProcedureSpec{
+		Columns:    spec.Columns,
+		MaxGap:     spec.MaxGap,
+		TimeColumn: spec.TimeColumn,
+	}, nil
+}
+
+func (s *FillProcedureSpec) Kind() plan.ProcedureKind {
+	return FillKind
+}
+func (s *FillProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	ns.Columns = make([]FillColumn, len(s.Columns))
+	copy(ns.Columns, s.Columns)
+	return &ns
+}
+
+func createFillTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*FillProcedureSpec)
+	if !ok {
+		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
+	}
+	return NewFillTransformation(id, s, a.Allocator())
+}
+
+// NewFillTransformation constructs a transformation that fills
+// null values in multiple columns using the configured methods.
+//
+// Filling with the next value or with linear interpolation requires
+// looking ahead so the transformation buffers each table and
+// fills it once the entire table has been read.
+func NewFillTransformation(id execute.DatasetID, spec *FillProcedureSpec, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	tr := &fillTransformation{
+		columns: spec.Columns,
+		maxGap:  int64(spec.MaxGap.Duration()),
+		timeCol: spec.TimeColumn,
+	}
+	return execute.NewAggregateTransformation(id, tr, mem)
+}
+
+type fillTransformation struct {
+	columns []FillColumn
+	maxGap  int64
+	timeCol string
+}
+
+type fillState struct {
+	chunks []table.Chunk
+	n      int
+}
+
+func (s *fillState) Close() error {
+	for _, chunk := range s.chunks {
+		chunk.Release()
+	}
+	s.chunks = nil
+	return nil
+}
+
+func (t *fillTransformation) Aggregate(chunk table.Chunk, state interface{}, mem memory.Allocator) (interface{}, bool, error) {
+	s, _ := state.(*fillState)
+	if s == nil {
+		s = &fillState{}
+	} else if err := validateFillSchema(s.chunks[0], chunk); err != nil {
+		return nil, false, err
+	}
+
+	chunk.Retain()
+	s.chunks = append(s.chunks, chunk)
+	s.n += chunk.Len()
+	return s, true, nil
+}
+
+// validateFillSchema verifies that a chunk has the same schema
+// as the first chunk for the group key.
+func validateFillSchema(first, chunk table.Chunk) error {
+	if first.NCols() != chunk.NCols() {
+		return errors.New(codes.FailedPrecondition, "fill found tables with different schemas for the same group key")
+	}
+	for j, col := range first.Cols() {
+		if c := chunk.Col(j); c != col {
+			return errors.Newf(codes.FailedPrecondition, "schema collision detected: column %q is both of type %s and %s", col.Label, col.Type, c.Type)
+		}
+	}
+	return nil
+}
+
+func (t *fillTransformation) Compute(key flux.GroupKey, state interface{}, d *execute.TransportDataset, mem memory.Allocator) error {
+	s := state.(*fillState)
+	first := s.chunks[0]
+
+	filled := make([]array.Array, first.NCols())
+	defer func() {
+		for _, arr := range filled {
+			if arr != nil {
+				arr.Release()
+			}
+		}
+	}()
+
+	if s.n > 0 {
+		ts, err := t.readTimes(s)
+		if err != nil {
+			return err
+		}
+
+		for i := range t.columns {
+			c := &t.columns[i]
+			j := first.Index(c.Label)
+			if j < 0 || key.HasCol(c.Label) {
+				// Columns that do not exist or are part of the group
+				// key are passed through without modification.
+				continue
+			}
+
+			arr, err := t.fillColumn(s, j, ts, c, mem)
+			if err != nil {
+				return err
+			}
+			filled[j] = arr
+		}
+	}
+
+	offset := 0
+	for _, chunk := range s.chunks {
+		buffer := chunk.Buffer()
+		buffer.Values = make([]array.Array, chunk.NCols())
+		for j := range buffer.Values {
+			if filled[j] != nil {
+				buffer.Values[j] = arrow.Slice(filled[j], int64(offset), int64(offset+chunk.Len()))
+				continue
+			}
+			arr := chunk.Values(j)
+			arr.Retain()
+			buffer.Values[j] = arr
+		}
+		offset += chunk.Len()
+
+		if err := d.Process(table.ChunkFromBuffer(buffer)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readTimes reads the time column from every chunk when a method
+// requires the time of each row. It returns nil if none do.
+func (t *fillTransformation) readTimes(s *fillState) ([]int64, error) {
+	needed := t.maxGap > 0
+	for _, c := range t.columns {
+		if c.Method == FillMethodLinear {
+			needed = true
+		}
+	}
+	if !needed {
+		return nil, nil
+	}
+
+	first := s.chunks[0]
+	j := first.Index(t.timeCol)
+	if j < 0 {
+		return nil, errors.Newf(codes.FailedPrecondition, "no column %q exists", t.timeCol)
+	} else if want, got := flux.TTime, first.Col(j).Type; want != got {
+		return nil, errors.Newf(codes.FailedPrecondition, "time column %q is type %s and not %s", t.timeCol, got, want)
+	}
+
+	ts := make([]int64, 0, s.n)
+	for _, chunk := range s.chunks {
+		vs := chunk.Ints(j)
+		if vs.NullN() > 0 {
+			return nil, errors.New(codes.FailedPrecondition, "fill found null time in time column")
+		}
+		for i, l := 0, vs.Len(); i < l; i++ {
+			ts = append(ts, vs.Value(i))
+		}
+	}
+	return ts, nil
+}
+
+func (t *fillTransformation) fillColumn(s *fillState, j int, ts []int64, c *FillColumn, mem memory.Allocator) (array.Array, error) {
+	col := s.chunks[0].Col(j)
+	if c.Method == FillMethodConstant {
+		if want, got := col.Type, flux.ColumnType(c.Value.Type()); want != got {
+			return nil, errors.Newf(codes.FailedPrecondition, "fill column type mismatch: %s/%s", want, got)
+		}
+	}
+
+	f := filler{ts: ts, maxGap: t.maxGap, method: c.Method}
+	switch col.Type {
+	case flux.TFloat:
+		return fillArray[float64, *array.Float](s, j, f, c.Value, values.Value.Float, interpolateFloat, array.NewFloatBuilder(mem)), nil
+	case flux.TInt:
+		return fillArray[int64, *array.Int](s, j, f, c.Value, values.Value.Int, interpolateInt, array.NewIntBuilder(mem)), nil
+	case flux.TUInt:
+		return fillArray[uint64, *array.Uint](s, j, f, c.Value, values.Value.UInt, interpolateUint, array.NewUintBuilder(mem)), nil
+	case flux.TTime:
+		return fillArray[int64, *array.Int](s, j, f, c.Value, timeValue, interpolateInt, array.NewIntBuilder(mem)), nil
+	case flux.TString:
+		if c.Method == FillMethodLinear {
+			break
+		}
+		return fillArray[string, *array.String](s, j, f, c.Value, values.Value.Str, nil, array.NewStringBuilder(mem)), nil
+	case flux.TBool:
+		if c.Method == FillMethodLinear {
+			break
+		}
+		return fillArray[bool, *array.Boolean](s, j, f, c.Value, values.Value.Bool, nil, array.NewBooleanBuilder(mem)), nil
+	}
+	return nil, errors.Newf(codes.FailedPrecondition, "cannot fill column %q of type %s using method %q", c.Label, col.Type, c.Method)
+}
+
+func (t *fillTransformation) Close() error { return nil }
+
+type fillValuer[T any] interface {
+	array.Array
+	Value(i int) T
+}
+
+type fillBuilder[T any] interface {
+	array.Builder
+	Append(v T)
+}
+
+// fillArray reads the column from each chunk into a single slice of values,
+// fills the null values, and builds a single array with the result.
+func fillArray[T any, A fillValuer[T], B fillBuilder[T]](s *fillState, j int, f filler, constant values.Value, valueOf func(values.Value) T, interpolate func(a, b T, frac float64) T, b B) array.Array {
+	defer b.Release()
+
+	vs, valid := make([]T, 0, s.n), make([]bool, 0, s.n)
+	for _, chunk := range s.chunks {
+		arr := chunk.Values(j).(A)
+		for i, l := 0, arr.Len(); i < l; i++ {
+			if arr.IsNull(i) {
+				var zero T
+				vs, valid = append(vs, zero), append(valid, false)
+				continue
+			}
+			vs, valid = append(vs, arr.Value(i)), append(valid, true)
+		}
+	}
+
+	var cv T
+	if f.method == FillMethodConstant {
+		cv = valueOf(constant)
+	}
+	fillValues(vs, valid, f, cv, interpolate)
+
+	b.Resize(len(vs))
+	for i, v := range vs {
+		if !valid[i] {
+			b.AppendNull()
+			continue
+		}
+		b.Append(v)
+	}
+	return b.NewArray()
+}
+
+// filler holds the parameters used to fill a single column.
+type filler struct {
+	ts     []int64
+	maxGap int64
+	method string
+}
+
+// withinGap reports whether the rows at index i and j
+// are close enough in time to fill the values between them.
+func (f filler) withinGap(i, j int) bool {
+	if f.maxGap <= 0 {
+		return true
+	}
+	return f.ts[j]-f.ts[i] <= f.maxGap
+}
+
+// fillValues fills each run of null values in place.
+//
+// The previous and next methods use the last non-null value before
+// and the first non-null value after the run. The linear method
+// interpolates between both of them using the time of each row.
+// When a maximum gap is set, values are only filled when the distance
+// in time to the values used for filling is within the gap.
+func fillValues[T any](vs []T, valid []bool, f filler, constant T, interpolate func(a, b T, frac float64) T) {
+	for start, n := 0, len(vs); start < n; {
+		if valid[start] {
+			start++
+			continue
+		}
+
+		stop := start + 1
+		for stop < n && !valid[stop] {
+			stop++
+		}
+		prev, next := start-1, stop
+		hasPrev, hasNext := prev >= 0, next < n
+
+		for i := start; i < stop; i++ {
+			switch f.method {
+			case FillMethodPrevious:
+				if !hasPrev || !f.withinGap(prev, i) {
+					continue
+				}
+				vs[i] = vs[prev]
+			case FillMethodNext:
+				if !hasNext || !f.withinGap(i, next) {
+					continue
+				}
+				vs[i] = vs[next]
+			case FillMethodLinear:
+				if !hasPrev || !hasNext || !f.withinGap(prev, next) {
+					continue
+				}
+				var frac float64
+				if elapsed := f.ts[next] - f.ts[prev]; elapsed > 0 {
+					frac = float64(f.ts[i]-f.ts[prev]) / float64(elapsed)
+				}
+				vs[i] = interpolate(vs[prev], vs[next], frac)
+			case FillMethodConstant:
+				vs[i] = constant
+			}
+			valid[i] = true
+		}
+		start = stop
+	}
+}
+
+func interpolateFloat(a, b float64, frac float64) float64 {
+	return a + (b-a)*frac
+}
+
+func interpolateInt(a, b int64, frac float64) int64 {
+	return a + int64(math.Round(float64(b-a)*frac))
+}
+
+func interpolateUint(a, b uint64, frac float64) uint64 {
+	// Avoid wrapping on unsigned subtraction.
+	if b < a {
+		return a - uint64(math.Round(float64(a-b)*frac))
+	}
+	return a + uint64(math.Round(float64(b-a)*frac))
+}
+
+func timeValue(v values.Value) int64 {
+	return int64(v.Time())
+}
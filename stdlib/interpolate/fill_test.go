@@ -0,0 +1,208 @@
+package interpolate_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/stdlib/interpolate"
+	"github.com/influxdata/flux/values"
+)
+
+func TestFill(t *testing.T) {
+	input := func() []flux.Table {
+		return []flux.Table{&executetest.Table{
+			KeyCols: []string{"t0"},
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_value", Type: flux.TFloat},
+				{Label: "n", Type: flux.TInt},
+				{Label: "s", Type: flux.TString},
+				{Label: "t0", Type: flux.TString},
+			},
+			Data: [][]interface{}{
+				{execute.Time(0), nil, int64(1), "a", "x"},
+				{execute.Time(10), 1.0, nil, nil, "x"},
+				{execute.Time(20), nil, nil, nil, "x"},
+				{execute.Time(30), nil, int64(4), "b", "x"},
+				{execute.Time(40), 4.0, nil, nil, "x"},
+				{execute.Time(100), nil, int64(10), nil, "x"},
+				{execute.Time(110), 10.0, nil, "c", "x"},
+			},
+		}}
+	}
+
+	testCases := []struct {
+		name    string
+		spec    *interpolate.FillProcedureSpec
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "previous",
+			spec: &interpolate.FillProcedureSpec{
+				Columns: []interpolate.FillColumn{
+					{Label: "_value", Method: interpolate.FillMethodPrevious},
+					{Label: "s", Method: interpolate.FillMethodPrevious},
+				},
+				TimeColumn: execute.DefaultTimeColLabel,
+			},
+			data: input(),
+			want: []*executetest.Table{{
+				KeyCols: []string{"t0"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+					{Label: "n", Type: flux.TInt},
+					{Label: "s", Type: flux.TString},
+					{Label: "t0", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), nil, int64(1), "a", "x"},
+					{execute.Time(10), 1.0, nil, "a", "x"},
+					{execute.Time(20), 1.0, nil, "a", "x"},
+					{execute.Time(30), 1.0, int64(4), "b", "x"},
+					{execute.Time(40), 4.0, nil, "b", "x"},
+					{execute.Time(100), 4.0, int64(10), "b", "x"},
+					{execute.Time(110), 10.0, nil, "c", "x"},
+				},
+			}},
+		},
+		{
+			name: "next",
+			spec: &interpolate.FillProcedureSpec{
+				Columns: []interpolate.FillColumn{
+					{Label: "_value", Method: interpolate.FillMethodNext},
+					{Label: "n", Method: interpolate.FillMethodNext},
+				},
+				TimeColumn: execute.DefaultTimeColLabel,
+			},
+			data: input(),
+			want: []*executetest.Table{{
+				KeyCols: []string{"t0"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+					{Label: "n", Type: flux.TInt},
+					{Label: "s", Type: flux.TString},
+					{Label: "t0", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), 1.0, int64(1), "a", "x"},
+					{execute.Time(10), 1.0, int64(4), nil, "x"},
+					{execute.Time(20), 4.0, int64(4), nil, "x"},
+					{execute.Time(30), 4.0, int64(4), "b", "x"},
+					{execute.Time(40), 4.0, int64(10), nil, "x"},
+					{execute.Time(100), 10.0, int64(10), nil, "x"},
+					{execute.Time(110), 10.0, nil, "c", "x"},
+				},
+			}},
+		},
+		{
+			name: "linear and constant with max gap",
+			spec: &interpolate.FillProcedureSpec{
+				Columns: []interpolate.FillColumn{
+					{Label: "_value", Method: interpolate.FillMethodLinear},
+					{Label: "n", Method: interpolate.FillMethodLinear},
+					{Label: "s", Method: interpolate.FillMethodConstant, Value: values.NewString("-")},
+				},
+				MaxGap:     flux.ConvertDuration(30),
+				TimeColumn: execute.DefaultTimeColLabel,
+			},
+			data: input(),
+			want: []*executetest.Table{{
+				KeyCols: []string{"t0"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+					{Label: "n", Type: flux.TInt},
+					{Label: "s", Type: flux.TString},
+					{Label: "t0", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), nil, int64(1), "a", "x"},
+					{execute.Time(10), 1.0, int64(2), "-", "x"},
+					{execute.Time(20), 2.0, int64(3), "-", "x"},
+					{execute.Time(30), 3.0, int64(4), "b", "x"},
+					{execute.Time(40), 4.0, nil, "-", "x"},
+					{execute.Time(100), nil, int64(10), "-", "x"},
+					{execute.Time(110), 10.0, nil, "c", "x"},
+				},
+			}},
+		},
+		{
+			name: "missing column",
+			spec: &interpolate.FillProcedureSpec{
+				Columns: []interpolate.FillColumn{
+					{Label: "missing", Method: interpolate.FillMethodPrevious},
+				},
+				TimeColumn: execute.DefaultTimeColLabel,
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), 1.0},
+					{execute.Time(10), nil},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), 1.0},
+					{execute.Time(10), nil},
+				},
+			}},
+		},
+		{
+			name: "linear string",
+			spec: &interpolate.FillProcedureSpec{
+				Columns: []interpolate.FillColumn{
+					{Label: "s", Method: interpolate.FillMethodLinear},
+				},
+				TimeColumn: execute.DefaultTimeColLabel,
+			},
+			data:    input(),
+			wantErr: errors.New(codes.FailedPrecondition, `cannot fill column "s" of type string using method "linear"`),
+		},
+		{
+			name: "constant type mismatch",
+			spec: &interpolate.FillProcedureSpec{
+				Columns: []interpolate.FillColumn{
+					{Label: "_value", Method: interpolate.FillMethodConstant, Value: values.NewInt(0)},
+				},
+				TimeColumn: execute.DefaultTimeColLabel,
+			},
+			data:    input(),
+			wantErr: errors.New(codes.FailedPrecondition, "fill column type mismatch: float/int"),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper2(
+				t,
+				tc.data,
+				tc.want,
+				tc.wantErr,
+				func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+					tr, d, err := interpolate.NewFillTransformation(id, tc.spec, alloc)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return tr, d
+				},
+			)
+		})
+	}
+}
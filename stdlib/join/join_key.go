@@ -1,6 +1,7 @@
 package join
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/influxdata/flux"
@@ -85,6 +86,20 @@ func (k *joinKey) less(other joinKey) bool {
 			if av, bv := a.values[i].Time(), b.values[i].Time(); av != bv {
 				return av < bv
 			}
+		case flux.TBytes:
+			if c := bytes.Compare(a.values[i].Bytes(), b.values[i].Bytes()); c != 0 {
+				return c < 0
+			}
+		case flux.TDuration:
+			if av, bv := a.values[i].Duration().Duration(), b.values[i].Duration().Duration(); av != bv {
+				return av < bv
+			}
+		default:
+			if a.columns[i].Type.IsArray() {
+				if av, bv := values.DisplayString(a.values[i]), values.DisplayString(b.values[i]); av != bv {
+					return av < bv
+				}
+			}
 		}
 	}
 	return false
@@ -228,7 +228,7 @@ func (t *ToKafkaTransformation) RetractTable(id execute.DatasetID, key flux.Grou
 	return t.d.RetractTable(key)
 }
 func NewToKafkaTransformation(d execute.Dataset, deps flux.Dependencies, cache execute.TableBuilderCache, spec *ToKafkaProcedureSpec) (*ToKafkaTransformation, error) {
-	validator, err := deps.URLValidator()
+	validator, err := flux.PackageURLValidator(deps, "kafka")
 	if err != nil {
 		return nil, err
 	}
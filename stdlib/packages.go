@@ -32,28 +32,45 @@ import (
 	_ "github.com/influxdata/flux/stdlib/date"
 	_ "github.com/influxdata/flux/stdlib/date/boundaries"
 	_ "github.com/influxdata/flux/stdlib/dict"
+	_ "github.com/influxdata/flux/stdlib/encoding"
 	_ "github.com/influxdata/flux/stdlib/experimental"
 	_ "github.com/influxdata/flux/stdlib/experimental/aggregate"
+	_ "github.com/influxdata/flux/stdlib/experimental/anomaly"
 	_ "github.com/influxdata/flux/stdlib/experimental/array"
 	_ "github.com/influxdata/flux/stdlib/experimental/bigtable"
 	_ "github.com/influxdata/flux/stdlib/experimental/bitwise"
+	_ "github.com/influxdata/flux/stdlib/experimental/cron"
 	_ "github.com/influxdata/flux/stdlib/experimental/csv"
 	_ "github.com/influxdata/flux/stdlib/experimental/date/boundaries"
+	_ "github.com/influxdata/flux/stdlib/experimental/excel"
+	_ "github.com/influxdata/flux/stdlib/experimental/fit"
+	_ "github.com/influxdata/flux/stdlib/experimental/forecast"
 	_ "github.com/influxdata/flux/stdlib/experimental/geo"
+	_ "github.com/influxdata/flux/stdlib/experimental/grpc"
+	_ "github.com/influxdata/flux/stdlib/experimental/gzip"
 	_ "github.com/influxdata/flux/stdlib/experimental/http"
 	_ "github.com/influxdata/flux/stdlib/experimental/http/requests"
 	_ "github.com/influxdata/flux/stdlib/experimental/influxdb"
 	_ "github.com/influxdata/flux/stdlib/experimental/iox"
 	_ "github.com/influxdata/flux/stdlib/experimental/json"
+	_ "github.com/influxdata/flux/stdlib/experimental/lp"
 	_ "github.com/influxdata/flux/stdlib/experimental/mqtt"
 	_ "github.com/influxdata/flux/stdlib/experimental/oee"
+	_ "github.com/influxdata/flux/stdlib/experimental/otel"
 	_ "github.com/influxdata/flux/stdlib/experimental/polyline"
 	_ "github.com/influxdata/flux/stdlib/experimental/prometheus"
 	_ "github.com/influxdata/flux/stdlib/experimental/query"
 	_ "github.com/influxdata/flux/stdlib/experimental/record"
+	_ "github.com/influxdata/flux/stdlib/experimental/sample"
+	_ "github.com/influxdata/flux/stdlib/experimental/states"
+	_ "github.com/influxdata/flux/stdlib/experimental/stats"
 	_ "github.com/influxdata/flux/stdlib/experimental/table"
+	_ "github.com/influxdata/flux/stdlib/experimental/timeseries"
 	_ "github.com/influxdata/flux/stdlib/experimental/usage"
+	_ "github.com/influxdata/flux/stdlib/experimental/wasm"
+	_ "github.com/influxdata/flux/stdlib/experimental/zstd"
 	_ "github.com/influxdata/flux/stdlib/generate"
+	_ "github.com/influxdata/flux/stdlib/hash"
 	_ "github.com/influxdata/flux/stdlib/http"
 	_ "github.com/influxdata/flux/stdlib/http/requests"
 	_ "github.com/influxdata/flux/stdlib/influxdata/influxdb"
@@ -80,6 +97,7 @@ import (
 	_ "github.com/influxdata/flux/stdlib/planner"
 	_ "github.com/influxdata/flux/stdlib/profiler"
 	_ "github.com/influxdata/flux/stdlib/pushbullet"
+	_ "github.com/influxdata/flux/stdlib/random"
 	_ "github.com/influxdata/flux/stdlib/regexp"
 	_ "github.com/influxdata/flux/stdlib/runtime"
 	_ "github.com/influxdata/flux/stdlib/sampledata"
@@ -0,0 +1,106 @@
+package random
+
+import (
+	"math"
+	"math/bits"
+
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/internal/function"
+	"github.com/influxdata/flux/values"
+)
+
+// The random values are computed from the seed and the position
+// in the sequence instead of the state of a generator, so a value
+// does not depend on the order in which the values are computed.
+
+// splitmix64 is the mixing function of the SplitMix64 generator.
+func splitmix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+// draw returns the nth random number of the sequence for the seed.
+// Independent numbers for the same position are drawn from different streams.
+func draw(seed, n int64, stream uint64) uint64 {
+	return splitmix64(splitmix64(uint64(seed)^(stream*0xd1b54a32d192ed03)) + uint64(n))
+}
+
+// uniform returns the nth random float of the sequence in the range [0.0, 1.0).
+func uniform(seed, n int64, stream uint64) float64 {
+	return float64(draw(seed, n, stream)>>11) / (1 << 53)
+}
+
+// sequence reads the seed and the position of the value in the sequence.
+func sequence(args *function.Arguments) (seed, n int64, err error) {
+	seed, err = args.GetRequiredInt("seed")
+	if err != nil {
+		return 0, 0, err
+	}
+	if v, ok, err := args.GetInt("n"); err != nil {
+		return 0, 0, err
+	} else if ok {
+		n = v
+	}
+	return seed, n, nil
+}
+
+func randFloat(args *function.Arguments) (values.Value, error) {
+	seed, n, err := sequence(args)
+	if err != nil {
+		return nil, err
+	}
+	return values.NewFloat(uniform(seed, n, 0)), nil
+}
+
+func randInt(args *function.Arguments) (values.Value, error) {
+	max, err := args.GetRequiredInt("max")
+	if err != nil {
+		return nil, err
+	} else if max <= 0 {
+		return nil, errors.New(codes.Invalid, "max must be positive")
+	}
+	seed, n, err := sequence(args)
+	if err != nil {
+		return nil, err
+	}
+	hi, _ := bits.Mul64(draw(seed, n, 0), uint64(max))
+	return values.NewInt(int64(hi)), nil
+}
+
+func randNormal(args *function.Arguments) (values.Value, error) {
+	mu, sigma := 0.0, 1.0
+	if v, ok, err := args.GetFloat("mu"); err != nil {
+		return nil, err
+	} else if ok {
+		mu = v
+	}
+	if v, ok, err := args.GetFloat("sigma"); err != nil {
+		return nil, err
+	} else if ok {
+		if v < 0 {
+			return nil, errors.New(codes.Invalid, "sigma must be non-negative")
+		}
+		sigma = v
+	}
+	seed, n, err := sequence(args)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use the Box-Muller transform with two uniform values.
+	// The first value is in the range (0.0, 1.0] to avoid log(0).
+	u1 := 1 - uniform(seed, n, 0)
+	u2 := uniform(seed, n, 1)
+	z := math.Sqrt(-2*math.Log(u1)) * math.Cos(2*math.Pi*u2)
+	return values.NewFloat(mu + sigma*z), nil
+}
+
+func init() {
+	b := function.ForPackage("random")
+	b.Register("float", randFloat)
+	b.Register("int", randInt)
+	b.Register("normal", randNormal)
+}
@@ -0,0 +1,74 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/flux/internal/feature"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+// Commit and BuildDate may be set at link time to override the values
+// reported by buildInfo, for example:
+//
+//	go build -ldflags "-X github.com/influxdata/flux/stdlib/runtime.BuildDate=2022-06-01T00:00:00Z"
+//
+// When unset, they are read from the version control information of the binary.
+var (
+	Commit    string
+	BuildDate string
+)
+
+// BuildInfo returns the build information of the flux runtime and the
+// values of the feature flags in the current execution context.
+// If the binary does not contain build information, the version,
+// commit, build date and Go version are empty.
+func BuildInfo(ctx context.Context, args values.Object) (values.Value, error) {
+	var version, commit, buildDate, goVersion string
+	if bi, ok := readBuildInfo(); ok {
+		version, goVersion = moduleVersion(bi), bi.GoVersion
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				commit = s.Value
+			case "vcs.time":
+				buildDate = s.Value
+			}
+		}
+	}
+	if Commit != "" {
+		commit = Commit
+	}
+	if BuildDate != "" {
+		buildDate = BuildDate
+	}
+
+	builder := values.NewDictBuilder(semantic.NewDictType(semantic.BasicString, semantic.BasicString))
+	for _, flag := range feature.Flags() {
+		var v interface{}
+		switch flag := flag.(type) {
+		case feature.BoolFlag:
+			v = flag.Enabled(ctx)
+		case feature.IntFlag:
+			v = flag.Int(ctx)
+		case feature.FloatFlag:
+			v = flag.Float(ctx)
+		case feature.StringFlag:
+			v = flag.String(ctx)
+		default:
+			v = flag.Default()
+		}
+		if err := builder.Insert(values.NewString(flag.Key()), values.NewString(fmt.Sprint(v))); err != nil {
+			return nil, err
+		}
+	}
+
+	return values.NewObjectWithValues(map[string]values.Value{
+		"version":   values.NewString(version),
+		"commit":    values.NewString(commit),
+		"buildDate": values.NewString(buildDate),
+		"goVersion": values.NewString(goVersion),
+		"features":  builder.Dict(),
+	}), nil
+}
@@ -0,0 +1,81 @@
+package runtime_test
+
+import (
+	"context"
+	"runtime/debug"
+	"testing"
+
+	"github.com/influxdata/flux/internal/feature"
+	"github.com/influxdata/flux/stdlib/runtime"
+	"github.com/influxdata/flux/values"
+)
+
+type testFlagger map[string]interface{}
+
+func (f testFlagger) FlagValue(ctx context.Context, flag feature.Flag) interface{} {
+	if v, ok := f[flag.Key()]; ok {
+		return v
+	}
+	return flag.Default()
+}
+
+func TestBuildInfo(t *testing.T) {
+	runtime.SetBuildInfo(&debug.BuildInfo{
+		GoVersion: "go1.18",
+		Path:      "github.com/influxdata/flux",
+		Main: debug.Module{
+			Path:    "github.com/influxdata/flux",
+			Version: "v0.185.0",
+		},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "0123456789abcdef"},
+			{Key: "vcs.time", Value: "2022-06-01T00:00:00Z"},
+		},
+	})
+	defer runtime.SetBuildInfo(nil)
+
+	ctx := feature.Inject(context.Background(), testFlagger{
+		"vectorizedConst":          true,
+		"queryConcurrencyIncrease": 4,
+	})
+	got, err := runtime.BuildInfo(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for k, want := range map[string]string{
+		"version":   "v0.185.0",
+		"commit":    "0123456789abcdef",
+		"buildDate": "2022-06-01T00:00:00Z",
+		"goVersion": "go1.18",
+	} {
+		if v, _ := got.Object().Get(k); v.Str() != want {
+			t.Errorf("unexpected %s -want/+got:\n\t- %s\n\t+ %s", k, want, v.Str())
+		}
+	}
+
+	features, _ := got.Object().Get("features")
+	for k, want := range map[string]string{
+		"vectorizedConst":          "true",
+		"queryConcurrencyIncrease": "4",
+		"groupTransformationGroup": "true",
+	} {
+		if v := features.Dict().Get(values.NewString(k), values.NewString("")); v.Str() != want {
+			t.Errorf("unexpected value of feature %s -want/+got:\n\t- %s\n\t+ %s", k, want, v.Str())
+		}
+	}
+	if got, want := features.Dict().Len(), len(feature.Flags()); got != want {
+		t.Errorf("unexpected number of features -want/+got:\n\t- %d\n\t+ %d", want, got)
+	}
+}
+
+func TestBuildInfo_NotPresent(t *testing.T) {
+	runtime.SetBuildInfo(nil)
+	got, err := runtime.BuildInfo(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := got.Object().Get("version"); v.Str() != "" {
+		t.Errorf("expected an empty version, got %q", v.Str())
+	}
+}
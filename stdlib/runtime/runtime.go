@@ -7,7 +7,10 @@ import (
 	"github.com/influxdata/flux/values"
 )
 
-const versionFuncName = "version"
+const (
+	versionFuncName   = "version"
+	buildInfoFuncName = "buildInfo"
+)
 
 var errBuildInfoNotPresent = errors.New(codes.NotFound, "build info is not present")
 
@@ -18,4 +21,10 @@ func init() {
 		Version,
 		false,
 	))
+	runtime.RegisterPackageValue("runtime", buildInfoFuncName, values.NewFunction(
+		buildInfoFuncName,
+		runtime.MustLookupBuiltinType("runtime", buildInfoFuncName),
+		BuildInfo,
+		false,
+	))
 }
@@ -23,7 +23,11 @@ func Version(ctx context.Context, args values.Object) (values.Value, error) {
 	if !ok {
 		return nil, errBuildInfoNotPresent
 	}
+	return values.NewString(moduleVersion(bi)), nil
+}
 
+// moduleVersion returns the version of the flux module in the build info.
+func moduleVersion(bi *debug.BuildInfo) string {
 	// Find the module in the build info.
 	var m debug.Module
 	if bi.Main.Path == modulePath {
@@ -43,5 +47,5 @@ func Version(ctx context.Context, args values.Object) (values.Value, error) {
 		// If the module has been replaced, take the version from it.
 		v = m.Replace.Version
 	}
-	return values.NewString(v), nil
+	return v
 }
@@ -132,7 +132,7 @@ func createFromSocketSource(s plan.ProcedureSpec, dsid execute.DatasetID, a exec
 		return nil, errors.Newf(codes.Invalid, "invalid url: %v", err)
 	}
 	deps := flux.GetDependencies(a.Context())
-	validator, err := deps.URLValidator()
+	validator, err := flux.PackageURLValidator(deps, "socket")
 	if err != nil {
 		return nil, err
 	}
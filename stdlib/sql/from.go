@@ -12,6 +12,7 @@ import (
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/runtime"
 	_ "github.com/lib/pq"
+	"github.com/opentracing/opentracing-go"
 	_ "github.com/vertica/vertica-sql-go"
 )
 
@@ -63,6 +64,10 @@ type FromSQLProcedureSpec struct {
 	DriverName     string
 	DataSourceName string
 	Query          string
+
+	// SchemaOnly produces the table of the query with its columns
+	// but without reading any rows.
+	SchemaOnly bool
 }
 
 func newFromSQLProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
@@ -87,9 +92,22 @@ func (s *FromSQLProcedureSpec) Copy() plan.ProcedureSpec {
 	ns.DriverName = s.DriverName
 	ns.DataSourceName = s.DataSourceName
 	ns.Query = s.Query
+	ns.SchemaOnly = s.SchemaOnly
 	return ns
 }
 
+// SchemaSpec implements plan.SchemaSource.
+// The columns of the query are reported by the database
+// so the rows of the result do not need to be read.
+func (s *FromSQLProcedureSpec) SchemaSpec(ctx context.Context) (plan.PhysicalProcedureSpec, bool) {
+	if s.SchemaOnly {
+		return nil, false
+	}
+	ns := s.Copy().(*FromSQLProcedureSpec)
+	ns.SchemaOnly = true
+	return ns, true
+}
+
 func createFromSQLSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execute.Administration) (execute.Source, error) {
 	spec, ok := prSpec.(*FromSQLProcedureSpec)
 	if !ok {
@@ -98,7 +116,7 @@ func createFromSQLSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a ex
 
 	// validate the data driver name and source name.
 	deps := flux.GetDependencies(a.Context())
-	validator, err := deps.URLValidator()
+	validator, err := flux.PackageURLValidator(deps, "sql")
 	if err != nil {
 		return nil, err
 	}
@@ -137,7 +155,7 @@ func createFromSQLSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a ex
 			_ = rows.Close()
 			return nil, err
 		}
-		return read(ctx, reader, a.Allocator())
+		return read(ctx, reader, spec.SchemaOnly, a.Allocator())
 	}
 	iterator := &sqlIterator{spec: spec, id: dsid, read: readFn}
 	return execute.CreateSourceFromIterator(iterator, dsid)
@@ -156,7 +174,7 @@ func (c *sqlIterator) connect(ctx context.Context) (*sql.DB, error) {
 	if err != nil {
 		return nil, err
 	}
-	if err := db.Ping(); err != nil {
+	if err := db.PingContext(ctx); err != nil {
 		_ = db.Close()
 		return nil, err
 	}
@@ -164,6 +182,10 @@ func (c *sqlIterator) connect(ctx context.Context) (*sql.DB, error) {
 }
 
 func (c *sqlIterator) Do(ctx context.Context, f func(flux.Table) error) error {
+	s, ctx := opentracing.StartSpanFromContext(ctx, "sql.from")
+	s.SetTag("db.type", c.spec.DriverName)
+	defer s.Finish()
+
 	// Connect to the database so we can execute the query.
 	db, err := c.connect(ctx)
 	if err != nil {
@@ -185,7 +207,9 @@ func (c *sqlIterator) Do(ctx context.Context, f func(flux.Table) error) error {
 }
 
 // read will use the RowReader to construct a flux.Table.
-func read(ctx context.Context, reader execute.RowReader, alloc memory.Allocator) (flux.Table, error) {
+// If schemaOnly is set, the table has the columns of the reader
+// and the rows are not read.
+func read(ctx context.Context, reader execute.RowReader, schemaOnly bool, alloc memory.Allocator) (flux.Table, error) {
 	// Ensure that the reader is always freed so the underlying
 	// cursor can be returned.
 	defer func() { _ = reader.Close() }()
@@ -197,7 +221,7 @@ func read(ctx context.Context, reader execute.RowReader, alloc memory.Allocator)
 			return nil, err
 		}
 	}
-	for reader.Next() {
+	for !schemaOnly && reader.Next() {
 		row, err := reader.GetNextRow()
 		if err != nil {
 			return nil, err
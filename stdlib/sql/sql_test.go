@@ -76,7 +76,7 @@ func TestFromRowReader(t *testing.T) {
 		var rr execute.RowReader = &MockRowReader{row: 0}
 		rr.(*MockRowReader).InitColumnTypes(nil)
 		alloc := &memory.ResourceAllocator{}
-		table, err := read(context.Background(), rr, alloc)
+		table, err := read(context.Background(), rr, false, alloc)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -132,6 +132,31 @@ func TestFromRowReader(t *testing.T) {
 			}
 		}
 	})
+	t.Run("Schema only", func(t *testing.T) {
+		rr := &MockRowReader{row: 0}
+		rr.InitColumnTypes(nil)
+		alloc := &memory.ResourceAllocator{}
+		table, err := read(context.Background(), rr, true, alloc)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []flux.ColMeta{
+			{Label: "int", Type: flux.TInt},
+			{Label: "float", Type: flux.TFloat},
+			{Label: "bool", Type: flux.TBool},
+			{Label: "timestamp", Type: flux.TTime},
+		}
+		if !cmp.Equal(want, table.Cols()) {
+			t.Fatalf("unexpected result -want/+got\n\n%s\n\n", cmp.Diff(want, table.Cols()))
+		}
+		if !table.Empty() {
+			t.Fatal("expected table to have no rows")
+		}
+		if rr.row != 0 {
+			t.Fatalf("expected no rows to be read, got %d", rr.row)
+		}
+	})
 }
 
 func TestMySqlParsing(t *testing.T) {
@@ -148,7 +148,7 @@ func (t *ToSQLTransformation) RetractTable(id execute.DatasetID, key flux.GroupK
 }
 
 func NewToSQLTransformation(d execute.Dataset, deps flux.Dependencies, cache execute.TableBuilderCache, spec *ToSQLProcedureSpec) (*ToSQLTransformation, error) {
-	validator, err := deps.URLValidator()
+	validator, err := flux.PackageURLValidator(deps, "sql")
 	if err != nil {
 		return nil, err
 	}
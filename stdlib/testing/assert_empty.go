@@ -1,16 +1,27 @@
 package testing
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/codes"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/internal/errors"
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/runtime"
+	"github.com/influxdata/flux/values"
 )
 
 const AssertEmptyKind = "assertEmpty"
 
+// The number of tables and rows per table that are
+// included in the error when a table is not empty.
+const (
+	assertEmptyMaxTables = 3
+	assertEmptyMaxRows   = 10
+)
+
 type AssertEmptyOpSpec struct{}
 
 func (s *AssertEmptyOpSpec) Kind() flux.OperationKind {
@@ -55,6 +66,9 @@ func newAssertEmptyProcedure(qs flux.OperationSpec, pa plan.Administration) (pla
 type AssertEmptyTransformation struct {
 	execute.ExecutionNode
 	failures int64
+	// tables contains the formatted rows of the first
+	// tables that were not empty.
+	tables []string
 
 	d     execute.Dataset
 	cache execute.TableBuilderCache
@@ -83,13 +97,52 @@ func (t *AssertEmptyTransformation) RetractTable(id execute.DatasetID, key flux.
 }
 
 func (t *AssertEmptyTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
-	if !tbl.Empty() {
-		t.failures++
+	if tbl.Empty() {
+		// TODO: The Do method must be called at the moment.
+		return tbl.Do(func(cr flux.ColReader) error {
+			return nil
+		})
 	}
-	// TODO: The Do method must be called at the moment.
-	return tbl.Do(func(cr flux.ColReader) error {
+
+	t.failures++
+	if len(t.tables) >= assertEmptyMaxTables {
+		return tbl.Do(func(cr flux.ColReader) error {
+			return nil
+		})
+	}
+
+	var (
+		b    strings.Builder
+		rows int
+	)
+	fmt.Fprintf(&b, "table %v:", tbl.Key())
+	if err := tbl.Do(func(cr flux.ColReader) error {
+		for i, n := 0, cr.Len(); i < n; i++ {
+			if rows < assertEmptyMaxRows {
+				b.WriteString("\n    ")
+				writeRow(&b, cr, i)
+			}
+			rows++
+		}
 		return nil
-	})
+	}); err != nil {
+		return err
+	}
+	if rows > assertEmptyMaxRows {
+		fmt.Fprintf(&b, "\n    ... %d more rows", rows-assertEmptyMaxRows)
+	}
+	t.tables = append(t.tables, b.String())
+	return nil
+}
+
+// writeRow writes the columns of a row in the same format as a group key.
+func writeRow(b *strings.Builder, cr flux.ColReader, i int) {
+	for j, col := range cr.Cols() {
+		if j > 0 {
+			b.WriteRune(',')
+		}
+		fmt.Fprintf(b, "%s=%s", col.Label, values.DisplayString(execute.ValueForRow(cr, i, j)))
+	}
 }
 
 func (t *AssertEmptyTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
@@ -102,7 +155,7 @@ func (t *AssertEmptyTransformation) UpdateProcessingTime(id execute.DatasetID, m
 
 func (t *AssertEmptyTransformation) Finish(id execute.DatasetID, err error) {
 	if err == nil && t.failures > 0 {
-		err = errors.Newf(codes.Aborted, "found %d tables that were not empty", t.failures)
+		err = errors.Newf(codes.Aborted, "found %d tables that were not empty:\n%s", t.failures, strings.Join(t.tables, "\n"))
 	}
 	t.d.Finish(err)
 }
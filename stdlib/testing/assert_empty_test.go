@@ -50,7 +50,9 @@ func TestAssertEmpty_Process(t *testing.T) {
 					{execute.Time(0), 7.0, "a", "y"},
 				},
 			}},
-			wantErr: errors.New("found 1 tables that were not empty"),
+			wantErr: errors.New("found 1 tables that were not empty:\n" +
+				"table {t1=a}:\n" +
+				"    _time=1970-01-01T00:00:00.000000000Z,_value=7,t1=a,t2=y"),
 		},
 	}
 	for _, tc := range testCases {
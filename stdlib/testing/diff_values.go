@@ -0,0 +1,127 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/runtime"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+const DiffValuesKind = "_diffValues"
+
+func init() {
+	runtime.RegisterPackageValue("testing", DiffValuesKind, DiffValues())
+}
+
+// DiffValues returns a function that lists the differences between two values.
+//
+// Each difference is a record with the path of the different value,
+// such as value.a[1], and the values on each side.
+func DiffValues() values.Function {
+	signature := runtime.MustLookupBuiltinType("testing", DiffValuesKind)
+	returnType, err := signature.ReturnType()
+	if err != nil {
+		panic(err)
+	}
+	return values.NewFunction(
+		DiffValuesKind,
+		signature,
+		func(ctx context.Context, args values.Object) (values.Value, error) {
+			return interpreter.DoFunctionCallContext(func(ctx context.Context, args interpreter.Arguments) (values.Value, error) {
+				got, err := args.GetRequired("got")
+				if err != nil {
+					return nil, err
+				}
+				want, err := args.GetRequired("want")
+				if err != nil {
+					return nil, err
+				}
+
+				var diffs []values.Value
+				diffValues("value", got, want, func(path string, got, want values.Value) {
+					diffs = append(diffs, values.NewObjectWithValues(map[string]values.Value{
+						"path": values.NewString(path),
+						"got":  values.NewString(displayValue(got)),
+						"want": values.NewString(displayValue(want)),
+					}))
+				})
+				return values.NewArrayWithBacking(returnType, diffs), nil
+			}, ctx, args)
+		}, false,
+	)
+}
+
+// diffValues calls report for each nested value of got
+// that is not equal to the same value of want.
+func diffValues(path string, got, want values.Value, report func(path string, got, want values.Value)) {
+	if got == nil || want == nil || got.IsNull() || want.IsNull() {
+		if !isNull(got) || !isNull(want) {
+			report(path, got, want)
+		}
+		return
+	}
+	if got.Type().Nature() != want.Type().Nature() {
+		report(path, got, want)
+		return
+	}
+
+	switch got.Type().Nature() {
+	case semantic.Object:
+		gotObj, wantObj := got.Object(), want.Object()
+		labels := make(map[string]bool)
+		gotObj.Range(func(name string, _ values.Value) { labels[name] = true })
+		wantObj.Range(func(name string, _ values.Value) { labels[name] = true })
+		keys := make([]string, 0, len(labels))
+		for k := range labels {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			g, _ := gotObj.Get(k)
+			w, _ := wantObj.Get(k)
+			diffValues(path+"."+k, g, w, report)
+		}
+	case semantic.Array:
+		gotArr, wantArr := got.Array(), want.Array()
+		n := gotArr.Len()
+		if wantArr.Len() > n {
+			n = wantArr.Len()
+		}
+		for i := 0; i < n; i++ {
+			var g, w values.Value
+			if i < gotArr.Len() {
+				g = gotArr.Get(i)
+			}
+			if i < wantArr.Len() {
+				w = wantArr.Get(i)
+			}
+			diffValues(fmt.Sprintf("%s[%d]", path, i), g, w, report)
+		}
+	case semantic.Float:
+		g, w := got.Float(), want.Float()
+		if !(math.IsNaN(g) && math.IsNaN(w)) && g != w {
+			report(path, got, want)
+		}
+	default:
+		if !got.Equal(want) {
+			report(path, got, want)
+		}
+	}
+}
+
+func isNull(v values.Value) bool {
+	return v == nil || v.IsNull()
+}
+
+// displayValue formats the value for a difference.
+func displayValue(v values.Value) string {
+	if v == nil {
+		return "<missing>"
+	}
+	return values.DisplayString(v)
+}
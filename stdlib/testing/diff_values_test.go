@@ -0,0 +1,84 @@
+package testing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux/dependencies/dependenciestest"
+	"github.com/influxdata/flux/dependency"
+	"github.com/influxdata/flux/semantic"
+	fluxtesting "github.com/influxdata/flux/stdlib/testing"
+	"github.com/influxdata/flux/values"
+)
+
+func TestDiffValues(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		got, want values.Value
+		diffs     [][3]string
+	}{
+		{
+			name: "equal",
+			got:  values.NewInt(1),
+			want: values.NewInt(1),
+		},
+		{
+			name:  "scalar",
+			got:   values.NewInt(1),
+			want:  values.NewInt(2),
+			diffs: [][3]string{{"value", "1", "2"}},
+		},
+		{
+			name:  "null",
+			got:   values.NewNull(semantic.BasicInt),
+			want:  values.NewInt(2),
+			diffs: [][3]string{{"value", "<null>", "2"}},
+		},
+		{
+			name: "record",
+			got: values.NewObjectWithValues(map[string]values.Value{
+				"a": values.NewString("x"),
+				"b": values.NewArrayWithBacking(semantic.NewArrayType(semantic.BasicFloat), []values.Value{
+					values.NewFloat(1), values.NewFloat(2),
+				}),
+			}),
+			want: values.NewObjectWithValues(map[string]values.Value{
+				"a": values.NewString("y"),
+				"b": values.NewArrayWithBacking(semantic.NewArrayType(semantic.BasicFloat), []values.Value{
+					values.NewFloat(1),
+				}),
+			}),
+			diffs: [][3]string{
+				{"value.a", "x", "y"},
+				{"value.b[1]", "2", "<missing>"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, deps := dependency.Inject(context.Background(), dependenciestest.Default())
+			defer deps.Finish()
+
+			got, err := fluxtesting.DiffValues().Call(ctx, values.NewObjectWithValues(map[string]values.Value{
+				"got":  tc.got,
+				"want": tc.want,
+			}))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var diffs [][3]string
+			got.Array().Range(func(i int, v values.Value) {
+				var diff [3]string
+				for j, label := range []string{"path", "got", "want"} {
+					s, _ := v.Object().Get(label)
+					diff[j] = s.Str()
+				}
+				diffs = append(diffs, diff)
+			})
+			if !cmp.Equal(tc.diffs, diffs) {
+				t.Fatalf("unexpected differences -want/+got:\n%s", cmp.Diff(tc.diffs, diffs))
+			}
+		})
+	}
+}
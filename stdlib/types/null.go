@@ -0,0 +1,32 @@
+package types
+
+import (
+	"context"
+
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/runtime"
+	"github.com/influxdata/flux/stdlib/internal/debug"
+	"github.com/influxdata/flux/values"
+)
+
+const NullKind = "null"
+
+func init() {
+	runtime.RegisterPackageValue("types", NullKind, Null())
+}
+
+func Null() values.Function {
+	return values.NewFunction(
+		NullKind,
+		runtime.MustLookupBuiltinType("types", NullKind),
+		func(ctx context.Context, args values.Object) (values.Value, error) {
+			return interpreter.DoFunctionCallContext(func(ctx context.Context, args interpreter.Arguments) (values.Value, error) {
+				typ, err := args.GetRequiredString("type")
+				if err != nil {
+					return nil, err
+				}
+				return debug.TypedNull(typ)
+			}, ctx, args)
+		}, false,
+	)
+}
@@ -0,0 +1,64 @@
+package types_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/flux/dependencies/dependenciestest"
+	"github.com/influxdata/flux/dependency"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/stdlib/types"
+	"github.com/influxdata/flux/values"
+)
+
+func TestNull(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		args    map[string]values.Value
+		want    semantic.Nature
+		wantErr bool
+	}{
+		{
+			name: "int",
+			args: map[string]values.Value{"type": values.NewString("int")},
+			want: semantic.Int,
+		},
+		{
+			name: "time",
+			args: map[string]values.Value{"type": values.NewString("time")},
+			want: semantic.Time,
+		},
+		{
+			name:    "missing type",
+			args:    map[string]values.Value{},
+			wantErr: true,
+		},
+		{
+			name:    "invalid type",
+			args:    map[string]values.Value{"type": values.NewString("array")},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, deps := dependency.Inject(context.Background(), dependenciestest.Default())
+			defer deps.Finish()
+
+			got, err := types.Null().Call(ctx, values.NewObjectWithValues(tc.args))
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			if !got.IsNull() {
+				t.Fatalf("expected null value, got %v", got)
+			}
+			if n := got.Type().Nature(); n != tc.want {
+				t.Fatalf("unexpected type -want/+got:\n\t- %v\n\t+ %v", tc.want, n)
+			}
+		})
+	}
+}
@@ -917,19 +917,19 @@ func (a AggregateWindowRule) isValidAggregateSpec(spec plan.ProcedureSpec) (stri
 	switch spec.Kind() {
 	case CountKind:
 		aggregateSpec := spec.(*CountProcedureSpec)
-		if len(aggregateSpec.Columns) != 1 {
+		if len(aggregateSpec.Columns) != 1 || aggregateSpec.Nulls != execute.NullModeDefault {
 			return "", false
 		}
 		return aggregateSpec.Columns[0], true
 	case SumKind:
 		aggregateSpec := spec.(*SumProcedureSpec)
-		if len(aggregateSpec.Columns) != 1 {
+		if len(aggregateSpec.Columns) != 1 || aggregateSpec.Nulls != execute.NullModeDefault {
 			return "", false
 		}
 		return aggregateSpec.Columns[0], true
 	case MeanKind:
 		aggregateSpec := spec.(*MeanProcedureSpec)
-		if len(aggregateSpec.Columns) != 1 {
+		if len(aggregateSpec.Columns) != 1 || aggregateSpec.Nulls != execute.NullModeDefault {
 			return "", false
 		}
 		return aggregateSpec.Columns[0], true
@@ -0,0 +1,168 @@
+package universe
+
+import (
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/runtime"
+	"github.com/influxdata/flux/values"
+)
+
+const CollectKind = "collect"
+
+type CollectOpSpec struct {
+	Column string `json:"column"`
+	As     string `json:"as"`
+}
+
+func init() {
+	collectSignature := runtime.MustLookupBuiltinType("universe", CollectKind)
+
+	runtime.RegisterPackageValue("universe", CollectKind, flux.MustValue(flux.FunctionValue(CollectKind, createCollectOpSpec, collectSignature)))
+	plan.RegisterProcedureSpec(CollectKind, newCollectProcedure, CollectKind)
+	execute.RegisterTransformation(CollectKind, createCollectTransformation)
+}
+
+func createCollectOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := &CollectOpSpec{
+		Column: execute.DefaultValueColLabel,
+	}
+	if col, ok, err := args.GetString("column"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Column = col
+	}
+
+	spec.As = spec.Column
+	if as, ok, err := args.GetString("as"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.As = as
+	}
+	return spec, nil
+}
+
+func (s *CollectOpSpec) Kind() flux.OperationKind {
+	return CollectKind
+}
+
+type CollectProcedureSpec struct {
+	plan.DefaultCost
+	Column string
+	As     string
+}
+
+func newCollectProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*CollectOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+	return &CollectProcedureSpec{
+		Column: spec.Column,
+		As:     spec.As,
+	}, nil
+}
+
+func (s *CollectProcedureSpec) Kind() plan.ProcedureKind {
+	return CollectKind
+}
+
+func (s *CollectProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+func createCollectTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*CollectProcedureSpec)
+	if !ok {
+		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
+	}
+	return NewCollectTransformation(id, s, a.Allocator())
+}
+
+type collectTransformation struct {
+	column string
+	as     string
+}
+
+// NewCollectTransformation constructs a transformation that collects
+// the values of a column of each table into a single array value.
+// Each output table has one row with the group key and the array.
+func NewCollectTransformation(id execute.DatasetID, spec *CollectProcedureSpec, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	tr := &collectTransformation{
+		column: spec.Column,
+		as:     spec.As,
+	}
+	return execute.NewAggregateTransformation(id, tr, mem)
+}
+
+type collectState struct {
+	typ   flux.ColType
+	elems []values.Value
+}
+
+func (t *collectTransformation) Aggregate(chunk table.Chunk, state interface{}, mem memory.Allocator) (interface{}, bool, error) {
+	idx := chunk.Index(t.column)
+	if idx < 0 {
+		return nil, false, errors.Newf(codes.FailedPrecondition, "no column %q exists", t.column)
+	}
+	typ := chunk.Col(idx).Type
+	if typ.IsArray() {
+		return nil, false, errors.Newf(codes.FailedPrecondition, "cannot collect array column %q", t.column)
+	}
+
+	s, _ := state.(*collectState)
+	if s == nil {
+		s = &collectState{typ: typ}
+	} else if s.typ != typ {
+		return nil, false, errors.Newf(codes.FailedPrecondition, "schema collision: cannot collect column %q of type %s and %s", t.column, s.typ, typ)
+	}
+	buffer := chunk.Buffer()
+	for i, l := 0, chunk.Len(); i < l; i++ {
+		s.elems = append(s.elems, execute.ValueForRow(&buffer, i, idx))
+	}
+	return s, true, nil
+}
+
+func (t *collectTransformation) Compute(key flux.GroupKey, state interface{}, d *execute.TransportDataset, mem memory.Allocator) error {
+	s := state.(*collectState)
+	if key.HasCol(t.as) {
+		return errors.Newf(codes.FailedPrecondition, "column %q must not be part of the group key", t.as)
+	}
+
+	cols := make([]flux.ColMeta, 0, len(key.Cols())+1)
+	vs := make([]array.Array, 0, len(key.Cols())+1)
+	for j, col := range key.Cols() {
+		cols = append(cols, col)
+		vs = append(vs, arrow.Repeat(col.Type, key.Value(j), 1, mem))
+	}
+
+	typ := flux.ArrayOf(s.typ)
+	cols = append(cols, flux.ColMeta{Label: t.as, Type: typ})
+	b := arrow.NewListBuilder(s.typ, mem)
+	b.Resize(1)
+	if err := arrow.AppendArray(b, values.NewArrayWithBacking(flux.SemanticType(typ), s.elems)); err != nil {
+		b.Release()
+		return err
+	}
+	vs = append(vs, b.NewArray())
+	b.Release()
+
+	return d.Process(table.ChunkFromBuffer(arrow.TableBuffer{
+		GroupKey: key,
+		Columns:  cols,
+		Values:   vs,
+	}))
+}
+
+func (t *collectTransformation) Close() error { return nil }
@@ -0,0 +1,102 @@
+package universe_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/stdlib/universe"
+	"github.com/influxdata/flux/values"
+)
+
+func floatArray(vs ...interface{}) values.Array {
+	elems := make([]values.Value, len(vs))
+	for i, v := range vs {
+		if v == nil {
+			elems[i] = values.NewNull(semantic.BasicFloat)
+		} else {
+			elems[i] = values.NewFloat(v.(float64))
+		}
+	}
+	return values.NewArrayWithBacking(semantic.NewArrayType(semantic.BasicFloat), elems)
+}
+
+func TestCollect_Process(t *testing.T) {
+	testCases := []struct {
+		name    string
+		spec    *universe.CollectProcedureSpec
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "collect values",
+			spec: &universe.CollectProcedureSpec{
+				Column: execute.DefaultValueColLabel,
+				As:     "values",
+			},
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"t0"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "t0", Type: flux.TString},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), "a", 2.0},
+					{execute.Time(2), "a", nil},
+					{execute.Time(3), "a", 5.0},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"t0"},
+				ColMeta: []flux.ColMeta{
+					{Label: "t0", Type: flux.TString},
+					{Label: "values", Type: flux.ArrayOf(flux.TFloat)},
+				},
+				Data: [][]interface{}{
+					{"a", floatArray(2.0, nil, 5.0)},
+				},
+			}},
+		},
+		{
+			name: "missing column",
+			spec: &universe.CollectProcedureSpec{
+				Column: "x",
+				As:     "x",
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{2.0},
+				},
+			}},
+			wantErr: errors.New(codes.FailedPrecondition, `no column "x" exists`),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper2(
+				t,
+				tc.data,
+				tc.want,
+				tc.wantErr,
+				func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+					tr, d, err := universe.NewCollectTransformation(id, tc.spec, alloc)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return tr, d
+				},
+			)
+		})
+	}
+}
@@ -18,7 +18,8 @@ type CountOpSpec struct {
 
 func init() {
 	countSignature := runtime.MustLookupBuiltinType("universe", "count")
-	runtime.RegisterPackageValue("universe", CountKind, flux.MustValue(flux.FunctionValue(CountKind, CreateCountOpSpec, countSignature)))
+	countFunction = flux.MustValue(flux.FunctionValue(CountKind, CreateCountOpSpec, countSignature))
+	runtime.RegisterPackageValue("universe", CountKind, countFunction)
 	plan.RegisterProcedureSpec(CountKind, newCountProcedure, CountKind)
 	execute.RegisterTransformation(CountKind, createCountTransformation)
 }
@@ -14,13 +14,15 @@ import (
 )
 
 type derivativeInt struct {
-	t           int64
-	v           int64
-	isValid     bool
-	unit        float64
-	nonNegative bool
-	initialized bool
-	initialZero bool
+	t              int64
+	v              int64
+	isValid        bool
+	unit           float64
+	nonNegative    bool
+	initialized    bool
+	initialZero    bool
+	propagateNull  bool
+	resetThreshold float64
 }
 
 func (d *derivativeInt) Type() flux.ColType {
@@ -55,8 +57,13 @@ func (d *derivativeInt) Do(ts *array.Int, in array.Array, mem memory.Allocator)
 	for l := vs.Len(); i < l; i++ {
 		// If the current value is nil, append nil and skip to the
 		// next point. We do not modify the previous value when we
-		// see null and we do not update the timestamp.
+		// see null and we do not update the timestamp unless nulls
+		// are propagated. When nulls are propagated, the next valid
+		// value has no previous value and produces null.
 		if vs.IsNull(i) {
+			if d.propagateNull {
+				d.isValid = false
+			}
 			b.AppendNull()
 			continue
 		}
@@ -74,7 +81,12 @@ func (d *derivativeInt) Do(ts *array.Int, in array.Array, mem memory.Allocator)
 
 		// We have seen a valid value so retrieve it now.
 		pv, cv := d.v, vs.Value(i)
-		if d.nonNegative && pv > cv {
+		if d.nonNegative && pv > cv && float64(pv-cv) <= d.resetThreshold {
+			// The decrease is too small to be a counter reset
+			// so the previous value and time are kept.
+			b.Append(0)
+			continue
+		} else if d.nonNegative && pv > cv {
 			// The previous value is greater than the current
 			// value and non-negative was set.
 			if d.initialZero {
@@ -102,13 +114,15 @@ func (d *derivativeInt) Do(ts *array.Int, in array.Array, mem memory.Allocator)
 }
 
 type derivativeUint struct {
-	t           int64
-	v           uint64
-	isValid     bool
-	unit        float64
-	nonNegative bool
-	initialized bool
-	initialZero bool
+	t              int64
+	v              uint64
+	isValid        bool
+	unit           float64
+	nonNegative    bool
+	initialized    bool
+	initialZero    bool
+	propagateNull  bool
+	resetThreshold float64
 }
 
 func (d *derivativeUint) Type() flux.ColType {
@@ -143,8 +157,13 @@ func (d *derivativeUint) Do(ts *array.Int, in array.Array, mem memory.Allocator)
 	for l := vs.Len(); i < l; i++ {
 		// If the current value is nil, append nil and skip to the
 		// next point. We do not modify the previous value when we
-		// see null and we do not update the timestamp.
+		// see null and we do not update the timestamp unless nulls
+		// are propagated. When nulls are propagated, the next valid
+		// value has no previous value and produces null.
 		if vs.IsNull(i) {
+			if d.propagateNull {
+				d.isValid = false
+			}
 			b.AppendNull()
 			continue
 		}
@@ -162,7 +181,12 @@ func (d *derivativeUint) Do(ts *array.Int, in array.Array, mem memory.Allocator)
 
 		// We have seen a valid value so retrieve it now.
 		pv, cv := d.v, vs.Value(i)
-		if d.nonNegative && pv > cv {
+		if d.nonNegative && pv > cv && float64(pv-cv) <= d.resetThreshold {
+			// The decrease is too small to be a counter reset
+			// so the previous value and time are kept.
+			b.Append(0)
+			continue
+		} else if d.nonNegative && pv > cv {
 			// The previous value is greater than the current
 			// value and non-negative was set.
 			if d.initialZero {
@@ -190,13 +214,15 @@ func (d *derivativeUint) Do(ts *array.Int, in array.Array, mem memory.Allocator)
 }
 
 type derivativeFloat struct {
-	t           int64
-	v           float64
-	isValid     bool
-	unit        float64
-	nonNegative bool
-	initialized bool
-	initialZero bool
+	t              int64
+	v              float64
+	isValid        bool
+	unit           float64
+	nonNegative    bool
+	initialized    bool
+	initialZero    bool
+	propagateNull  bool
+	resetThreshold float64
 }
 
 func (d *derivativeFloat) Type() flux.ColType {
@@ -231,8 +257,13 @@ func (d *derivativeFloat) Do(ts *array.Int, in array.Array, mem memory.Allocator
 	for l := vs.Len(); i < l; i++ {
 		// If the current value is nil, append nil and skip to the
 		// next point. We do not modify the previous value when we
-		// see null and we do not update the timestamp.
+		// see null and we do not update the timestamp unless nulls
+		// are propagated. When nulls are propagated, the next valid
+		// value has no previous value and produces null.
 		if vs.IsNull(i) {
+			if d.propagateNull {
+				d.isValid = false
+			}
 			b.AppendNull()
 			continue
 		}
@@ -250,7 +281,12 @@ func (d *derivativeFloat) Do(ts *array.Int, in array.Array, mem memory.Allocator
 
 		// We have seen a valid value so retrieve it now.
 		pv, cv := d.v, vs.Value(i)
-		if d.nonNegative && pv > cv {
+		if d.nonNegative && pv > cv && float64(pv-cv) <= d.resetThreshold {
+			// The decrease is too small to be a counter reset
+			// so the previous value and time are kept.
+			b.Append(0)
+			continue
+		} else if d.nonNegative && pv > cv {
 			// The previous value is greater than the current
 			// value and non-negative was set.
 			if d.initialZero {
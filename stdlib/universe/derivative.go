@@ -26,11 +26,13 @@ import (
 const DerivativeKind = "derivative"
 
 type DerivativeOpSpec struct {
-	Unit        flux.Duration `json:"unit"`
-	NonNegative bool          `json:"nonNegative"`
-	Columns     []string      `json:"columns"`
-	TimeColumn  string        `json:"timeColumn"`
-	InitialZero bool          `json:"initialZero"`
+	Unit           flux.Duration    `json:"unit"`
+	NonNegative    bool             `json:"nonNegative"`
+	Columns        []string         `json:"columns"`
+	TimeColumn     string           `json:"timeColumn"`
+	InitialZero    bool             `json:"initialZero"`
+	Nulls          execute.NullMode `json:"nulls"`
+	ResetThreshold float64          `json:"resetThreshold"`
 }
 
 func init() {
@@ -77,6 +79,21 @@ func createDerivativeOpSpec(args flux.Arguments, a *flux.Administration) (flux.O
 		spec.InitialZero = iz
 	}
 
+	if threshold, ok, err := args.GetFloat("resetThreshold"); err != nil {
+		return nil, err
+	} else if ok {
+		if threshold < 0 {
+			return nil, errors.Newf(codes.Invalid, "resetThreshold must be non-negative, got %v", threshold)
+		}
+		spec.ResetThreshold = threshold
+	}
+
+	nulls, err := execute.ReadNullMode(args)
+	if err != nil {
+		return nil, err
+	}
+	spec.Nulls = nulls
+
 	if cols, ok, err := args.GetArray("columns", semantic.String); err != nil {
 		return nil, err
 	} else if ok {
@@ -97,11 +114,13 @@ func (s *DerivativeOpSpec) Kind() flux.OperationKind {
 
 type DerivativeProcedureSpec struct {
 	plan.DefaultCost
-	Unit        flux.Duration `json:"unit"`
-	NonNegative bool          `json:"non_negative"`
-	Columns     []string      `json:"columns"`
-	TimeColumn  string        `json:"timeColumn"`
-	InitialZero bool          `json:"initialZero"`
+	Unit           flux.Duration    `json:"unit"`
+	NonNegative    bool             `json:"non_negative"`
+	Columns        []string         `json:"columns"`
+	TimeColumn     string           `json:"timeColumn"`
+	InitialZero    bool             `json:"initialZero"`
+	Nulls          execute.NullMode `json:"nulls"`
+	ResetThreshold float64          `json:"resetThreshold"`
 }
 
 func newDerivativeProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
@@ -111,11 +130,13 @@ func newDerivativeProcedure(qs flux.OperationSpec, pa plan.Administration) (plan
 	}
 
 	return &DerivativeProcedureSpec{
-		Unit:        spec.Unit,
-		NonNegative: spec.NonNegative,
-		Columns:     spec.Columns,
-		TimeColumn:  spec.TimeColumn,
-		InitialZero: spec.InitialZero,
+		Unit:           spec.Unit,
+		NonNegative:    spec.NonNegative,
+		Columns:        spec.Columns,
+		TimeColumn:     spec.TimeColumn,
+		InitialZero:    spec.InitialZero,
+		Nulls:          spec.Nulls,
+		ResetThreshold: spec.ResetThreshold,
 	}, nil
 }
 
@@ -147,21 +168,25 @@ func createDerivativeTransformation(id execute.DatasetID, mode execute.Accumulat
 
 func NewDerivativeTransformation(ctx context.Context, id execute.DatasetID, spec *DerivativeProcedureSpec, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
 	tr := &derivativeTransformation{
-		unit:        float64(spec.Unit.Duration()),
-		nonNegative: spec.NonNegative,
-		columns:     spec.Columns,
-		timeCol:     spec.TimeColumn,
-		initialZero: spec.InitialZero,
+		unit:           float64(spec.Unit.Duration()),
+		nonNegative:    spec.NonNegative,
+		columns:        spec.Columns,
+		timeCol:        spec.TimeColumn,
+		initialZero:    spec.InitialZero,
+		nulls:          spec.Nulls,
+		resetThreshold: spec.ResetThreshold,
 	}
 	return execute.NewNarrowStateTransformation[*derivativeState](id, tr, mem)
 }
 
 type derivativeTransformation struct {
-	unit        float64
-	nonNegative bool
-	columns     []string
-	timeCol     string
-	initialZero bool
+	unit           float64
+	nonNegative    bool
+	columns        []string
+	timeCol        string
+	initialZero    bool
+	nulls          execute.NullMode
+	resetThreshold float64
 }
 
 func (t *derivativeTransformation) Process(chunk table.Chunk, state *derivativeState, d *execute.TransportDataset, mem memory.Allocator) (*derivativeState, bool, error) {
@@ -390,24 +415,30 @@ func (t *derivativeTransformation) derivativeStateFor(col flux.ColMeta, state *d
 		switch col.Type {
 		case flux.TInt:
 			return &derivativeInt{
-				unit:        t.unit,
-				nonNegative: t.nonNegative,
-				initialized: state.initialized,
-				initialZero: t.initialZero,
+				unit:           t.unit,
+				nonNegative:    t.nonNegative,
+				initialized:    state.initialized,
+				initialZero:    t.initialZero,
+				propagateNull:  t.nulls == execute.NullModePropagate,
+				resetThreshold: t.resetThreshold,
 			}, nil
 		case flux.TUInt:
 			return &derivativeUint{
-				unit:        t.unit,
-				nonNegative: t.nonNegative,
-				initialized: state.initialized,
-				initialZero: t.initialZero,
+				unit:           t.unit,
+				nonNegative:    t.nonNegative,
+				initialized:    state.initialized,
+				initialZero:    t.initialZero,
+				propagateNull:  t.nulls == execute.NullModePropagate,
+				resetThreshold: t.resetThreshold,
 			}, nil
 		case flux.TFloat:
 			return &derivativeFloat{
-				unit:        t.unit,
-				nonNegative: t.nonNegative,
-				initialized: state.initialized,
-				initialZero: t.initialZero,
+				unit:           t.unit,
+				nonNegative:    t.nonNegative,
+				initialized:    state.initialized,
+				initialZero:    t.initialZero,
+				propagateNull:  t.nulls == execute.NullModePropagate,
+				resetThreshold: t.resetThreshold,
 			}, nil
 		default:
 			return nil, errors.Newf(codes.FailedPrecondition, "unsupported derivative column type %s:%s", col.Label, col.Type)
@@ -167,6 +167,39 @@ func TestDerivative_Process(t *testing.T) {
 				},
 			}},
 		},
+		{
+			name: "float non negative with reset threshold",
+			spec: &universe.DerivativeProcedureSpec{
+				Columns:        []string{"x", "y"},
+				TimeColumn:     execute.DefaultTimeColLabel,
+				Unit:           flux.ConvertDuration(1),
+				NonNegative:    true,
+				ResetThreshold: 1.0,
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "x", Type: flux.TFloat},
+					{Label: "y", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 2.0, 20.0},
+					{execute.Time(2), 1.5, 10.0},
+					{execute.Time(3), 4.0, 0.0},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "x", Type: flux.TFloat},
+					{Label: "y", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(2), float64(0), nil},
+					{execute.Time(3), 1.0, nil},
+				},
+			}},
+		},
 		{
 			name: "float non negative/initial zero with multiple values",
 			spec: &universe.DerivativeProcedureSpec{
@@ -231,6 +264,38 @@ func TestDerivative_Process(t *testing.T) {
 				},
 			}},
 		},
+		{
+			name: "float with null values propagated",
+			spec: &universe.DerivativeProcedureSpec{
+				Columns:    []string{"x", "y"},
+				TimeColumn: execute.DefaultTimeColLabel,
+				Unit:       flux.ConvertDuration(1),
+				Nulls:      execute.NullModePropagate,
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "x", Type: flux.TFloat},
+					{Label: "y", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 2.0, nil},
+					{execute.Time(2), nil, 10.0},
+					{execute.Time(3), 8.0, 20.0},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "x", Type: flux.TFloat},
+					{Label: "y", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(2), nil, nil},
+					{execute.Time(3), nil, 10.0},
+				},
+			}},
+		},
 		{
 			name: "float rowwise",
 			spec: &universe.DerivativeProcedureSpec{
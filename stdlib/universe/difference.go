@@ -1,6 +1,8 @@
 package universe
 
 import (
+	"regexp"
+
 	"github.com/apache/arrow/go/v7/arrow/memory"
 
 	"github.com/influxdata/flux"
@@ -21,10 +23,12 @@ import (
 const DifferenceKind = "difference"
 
 type DifferenceOpSpec struct {
-	NonNegative bool     `json:"nonNegative"`
-	Columns     []string `json:"columns"`
-	KeepFirst   bool     `json:"keepFirst"`
-	InitialZero bool     `json:"initialZero"`
+	NonNegative    bool           `json:"nonNegative"`
+	Columns        []string       `json:"columns"`
+	Pattern        *regexp.Regexp `json:"-"`
+	KeepFirst      bool           `json:"keepFirst"`
+	InitialZero    bool           `json:"initialZero"`
+	ResetThreshold float64        `json:"resetThreshold"`
 }
 
 func init() {
@@ -48,6 +52,12 @@ func createDifferenceOpSpec(args flux.Arguments, a *flux.Administration) (flux.O
 		spec.NonNegative = nn
 	}
 
+	pattern, patternOk, err := getColumnPattern(args)
+	if err != nil {
+		return nil, err
+	}
+	spec.Pattern = pattern
+
 	if cols, ok, err := args.GetArray("columns", semantic.String); err != nil {
 		return nil, err
 	} else if ok {
@@ -56,7 +66,7 @@ func createDifferenceOpSpec(args flux.Arguments, a *flux.Administration) (flux.O
 			return nil, err
 		}
 		spec.Columns = columns
-	} else {
+	} else if !patternOk {
 		spec.Columns = []string{execute.DefaultValueColLabel}
 	}
 
@@ -76,6 +86,15 @@ func createDifferenceOpSpec(args flux.Arguments, a *flux.Administration) (flux.O
 		spec.InitialZero = false
 	}
 
+	if threshold, ok, err := args.GetFloat("resetThreshold"); err != nil {
+		return nil, err
+	} else if ok {
+		if threshold < 0 {
+			return nil, errors.Newf(codes.Invalid, "resetThreshold must be non-negative, got %v", threshold)
+		}
+		spec.ResetThreshold = threshold
+	}
+
 	return spec, nil
 }
 
@@ -83,12 +102,37 @@ func (s *DifferenceOpSpec) Kind() flux.OperationKind {
 	return DifferenceKind
 }
 
+// getColumnPattern reads the regular expression that selects
+// columns by label from the pattern argument.
+func getColumnPattern(args flux.Arguments) (*regexp.Regexp, bool, error) {
+	v, ok := args.Get("pattern")
+	if !ok {
+		return nil, false, nil
+	} else if n := v.Type().Nature(); n != semantic.Regexp {
+		return nil, false, errors.Newf(codes.Invalid, "keyword argument %q should be of kind %v, but got %v", "pattern", semantic.Regexp, n)
+	}
+	return v.Regexp(), true, nil
+}
+
+// matchColumn reports if the label is one of the columns
+// or matches the pattern.
+func matchColumn(label string, columns []string, pattern *regexp.Regexp) bool {
+	for _, c := range columns {
+		if c == label {
+			return true
+		}
+	}
+	return pattern != nil && pattern.MatchString(label)
+}
+
 type DifferenceProcedureSpec struct {
 	plan.DefaultCost
-	NonNegative bool     `json:"non_negative"`
-	Columns     []string `json:"columns"`
-	KeepFirst   bool     `json:"keepFirst"`
-	InitialZero bool     `json:"initialZero"`
+	NonNegative    bool           `json:"non_negative"`
+	Columns        []string       `json:"columns"`
+	Pattern        *regexp.Regexp `json:"-"`
+	KeepFirst      bool           `json:"keepFirst"`
+	InitialZero    bool           `json:"initialZero"`
+	ResetThreshold float64        `json:"resetThreshold"`
 }
 
 func newDifferenceProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
@@ -98,10 +142,12 @@ func newDifferenceProcedure(qs flux.OperationSpec, pa plan.Administration) (plan
 	}
 
 	return &DifferenceProcedureSpec{
-		NonNegative: spec.NonNegative,
-		Columns:     spec.Columns,
-		KeepFirst:   spec.KeepFirst,
-		InitialZero: spec.InitialZero,
+		NonNegative:    spec.NonNegative,
+		Columns:        spec.Columns,
+		Pattern:        spec.Pattern,
+		KeepFirst:      spec.KeepFirst,
+		InitialZero:    spec.InitialZero,
+		ResetThreshold: spec.ResetThreshold,
 	}, nil
 }
 
@@ -143,20 +189,24 @@ type differenceTransformation struct {
 	d     execute.Dataset
 	cache execute.TableBuilderCache
 
-	nonNegative bool
-	columns     []string
-	keepFirst   bool
-	initialZero bool
+	nonNegative    bool
+	columns        []string
+	pattern        *regexp.Regexp
+	keepFirst      bool
+	initialZero    bool
+	resetThreshold float64
 }
 
 func NewDifferenceTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *DifferenceProcedureSpec) *differenceTransformation {
 	return &differenceTransformation{
-		d:           d,
-		cache:       cache,
-		nonNegative: spec.NonNegative,
-		columns:     spec.Columns,
-		keepFirst:   spec.KeepFirst,
-		initialZero: spec.InitialZero,
+		d:              d,
+		cache:          cache,
+		nonNegative:    spec.NonNegative,
+		columns:        spec.Columns,
+		pattern:        spec.Pattern,
+		keepFirst:      spec.KeepFirst,
+		initialZero:    spec.InitialZero,
+		resetThreshold: spec.ResetThreshold,
 	}
 }
 
@@ -172,14 +222,7 @@ func (t *differenceTransformation) Process(id execute.DatasetID, tbl flux.Table)
 	cols := tbl.Cols()
 	differences := make([]*difference, len(cols))
 	for j, c := range cols {
-		found := false
-		for _, label := range t.columns {
-			if c.Label == label {
-				found = true
-				break
-			}
-		}
-		if !found {
+		if !matchColumn(c.Label, t.columns, t.pattern) {
 			if _, err := builder.AddCol(c); err != nil {
 				return err
 			}
@@ -202,7 +245,7 @@ func (t *differenceTransformation) Process(id execute.DatasetID, tbl flux.Table)
 		}); err != nil {
 			return err
 		}
-		differences[j] = newDifference(t.nonNegative, t.keepFirst, t.initialZero)
+		differences[j] = newDifference(t.nonNegative, t.keepFirst, t.initialZero, t.resetThreshold)
 	}
 
 	// We need to drop the first row since its difference is undefined
@@ -343,10 +386,12 @@ type differenceTransformationAdapter struct {
 
 func NewNarrowDifferenceTransformation(spec *DifferenceProcedureSpec, id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset, error) {
 	differenceTransformation := differenceTransformation{
-		nonNegative: spec.NonNegative,
-		columns:     spec.Columns,
-		keepFirst:   spec.KeepFirst,
-		initialZero: spec.InitialZero,
+		nonNegative:    spec.NonNegative,
+		columns:        spec.Columns,
+		pattern:        spec.Pattern,
+		keepFirst:      spec.KeepFirst,
+		initialZero:    spec.InitialZero,
+		resetThreshold: spec.ResetThreshold,
 	}
 	t := &differenceTransformationAdapter{
 		differenceTransformation,
@@ -358,17 +403,10 @@ func (t *differenceTransformation) createDifferences(cols []flux.ColMeta) []*dif
 
 	differences := make([]*difference, len(cols))
 	for j, c := range cols {
-		found := false
-		for _, label := range t.columns {
-			if c.Label == label {
-				found = true
-				break
-			}
-		}
-		if !found {
+		if !matchColumn(c.Label, t.columns, t.pattern) {
 			continue
 		}
-		differences[j] = newDifference(t.nonNegative, t.keepFirst, t.initialZero)
+		differences[j] = newDifference(t.nonNegative, t.keepFirst, t.initialZero, t.resetThreshold)
 	}
 	return differences
 }
@@ -376,14 +414,7 @@ func (t *differenceTransformation) createDifferences(cols []flux.ColMeta) []*dif
 func (t *differenceTransformation) createOutputColumns(cols []flux.ColMeta) ([]flux.ColMeta, error) {
 	newCols := make([]flux.ColMeta, len(cols))
 	for j, c := range cols {
-		found := false
-		for _, label := range t.columns {
-			if c.Label == label {
-				found = true
-				break
-			}
-		}
-		if !found {
+		if !matchColumn(c.Label, t.columns, t.pattern) {
 			newCols[j] = c
 			continue
 		}
@@ -553,18 +584,20 @@ func processFloats(d *difference, l int, values *array.Float, firstIdx int, mem
 	return b.NewFloatArray()
 }
 
-func newDifference(nonNegative, keepFirst, initialZero bool) *difference {
+func newDifference(nonNegative, keepFirst, initialZero bool, resetThreshold float64) *difference {
 	return &difference{
-		nonNegative: nonNegative,
-		keepFirst:   keepFirst,
-		initialZero: initialZero,
+		nonNegative:    nonNegative,
+		keepFirst:      keepFirst,
+		initialZero:    initialZero,
+		resetThreshold: resetThreshold,
 	}
 }
 
 type difference struct {
-	nonNegative bool
-	keepFirst   bool
-	initialZero bool
+	nonNegative    bool
+	keepFirst      bool
+	initialZero    bool
+	resetThreshold float64
 
 	valid       bool
 	pIntValue   int64
@@ -589,6 +622,11 @@ func (d *difference) updateInt(v int64, valid bool) (int64, bool) {
 	diff := v - prev
 	if diff >= 0 || !d.nonNegative {
 		return diff, true
+	} else if float64(-diff) <= d.resetThreshold {
+		// The decrease is too small to be a counter reset
+		// so the previous value is kept.
+		d.pIntValue = prev
+		return 0, true
 	} else if d.nonNegative && d.initialZero && v >= 0 {
 		return v, true
 	}
@@ -614,6 +652,11 @@ func (d *difference) updateUInt(v uint64, valid bool) (int64, bool) {
 	diff := int64(v - prev)
 	if diff >= 0 || !d.nonNegative {
 		return diff, true
+	} else if float64(-diff) <= d.resetThreshold {
+		// The decrease is too small to be a counter reset
+		// so the previous value is kept.
+		d.pUIntValue = prev
+		return 0, true
 	} else if d.nonNegative && d.initialZero && int64(v) >= 0 {
 		return int64(v), true
 	}
@@ -637,6 +680,11 @@ func (d *difference) updateFloat(v float64, valid bool) (float64, bool) {
 	diff := v - prev
 	if diff >= 0 || !d.nonNegative {
 		return diff, true
+	} else if -diff <= d.resetThreshold {
+		// The decrease is too small to be a counter reset
+		// so the previous value is kept.
+		d.pFloatValue = prev
+		return 0, true
 	} else if d.nonNegative && d.initialZero && v >= 0 {
 		return v, true
 	}
@@ -1,6 +1,7 @@
 package universe_test
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/influxdata/flux"
@@ -771,6 +772,37 @@ func TestDifference_Process(t *testing.T) {
 				},
 			}},
 		},
+		{
+			name: "pattern",
+			spec: &universe.DifferenceProcedureSpec{
+				Pattern: regexp.MustCompile("^usage_"),
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "usage_idle", Type: flux.TFloat},
+					{Label: "usage_user", Type: flux.TInt},
+					{Label: "cpu", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 90.0, int64(5), int64(1)},
+					{execute.Time(2), 80.0, int64(15), int64(2)},
+					{execute.Time(3), 85.0, int64(10), int64(3)},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "usage_idle", Type: flux.TFloat},
+					{Label: "usage_user", Type: flux.TInt},
+					{Label: "cpu", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(2), -10.0, int64(10), int64(2)},
+					{execute.Time(3), 5.0, int64(-5), int64(3)},
+				},
+			}},
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -936,6 +968,42 @@ func TestDifference_Process_With_NonNegative_KeepFirst_InitialZero(t *testing.T)
 				},
 			}},
 		},
+		{
+			name: "float with InitialZero and ResetThreshold",
+			spec: &universe.DifferenceProcedureSpec{
+				Columns:        []string{execute.DefaultValueColLabel},
+				NonNegative:    true,
+				KeepFirst:      true,
+				InitialZero:    true,
+				ResetThreshold: 1.0,
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 20.0},
+					{execute.Time(2), 30.0},
+					{execute.Time(3), 29.5},
+					{execute.Time(4), 31.0},
+					{execute.Time(5), 5.0},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), float64(0)},
+					{execute.Time(2), 10.0},
+					{execute.Time(3), 0.0},
+					{execute.Time(4), 1.0},
+					{execute.Time(5), 5.0},
+				},
+			}},
+		},
 		{
 			name: "with null non negative and InitialZero",
 			spec: &universe.DifferenceProcedureSpec{
@@ -1774,6 +1842,37 @@ func TestDifference_Process_Narrow(t *testing.T) {
 				},
 			}},
 		},
+		{
+			name: "pattern",
+			spec: &universe.DifferenceProcedureSpec{
+				Pattern: regexp.MustCompile("^usage_"),
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "usage_idle", Type: flux.TFloat},
+					{Label: "usage_user", Type: flux.TInt},
+					{Label: "cpu", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 90.0, int64(5), int64(1)},
+					{execute.Time(2), 80.0, int64(15), int64(2)},
+					{execute.Time(3), 85.0, int64(10), int64(3)},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "usage_idle", Type: flux.TFloat},
+					{Label: "usage_user", Type: flux.TInt},
+					{Label: "cpu", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(2), -10.0, int64(10), int64(2)},
+					{execute.Time(3), 5.0, int64(-5), int64(3)},
+				},
+			}},
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -1,23 +1,29 @@
 package universe
 
 import (
+	"regexp"
 	"time"
 
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/codes"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/interpreter"
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/runtime"
+	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/flux/values"
 )
 
 const ElapsedKind = "elapsed"
 
 type ElapsedOpSpec struct {
-	Unit       flux.Duration `json:"unit"`
-	TimeColumn string        `json:"timeColumn"`
-	ColumnName string        `json:"columnName"`
+	Unit       flux.Duration  `json:"unit"`
+	TimeColumn string         `json:"timeColumn"`
+	Columns    []string       `json:"columns,omitempty"`
+	Pattern    *regexp.Regexp `json:"-"`
+	ColumnName string         `json:"columnName"`
+	KeepFirst  bool           `json:"keepFirst"`
 }
 
 func init() {
@@ -51,6 +57,22 @@ func createElapsedOpSpec(args flux.Arguments, a *flux.Administration) (flux.Oper
 		spec.TimeColumn = execute.DefaultTimeColLabel
 	}
 
+	if cols, ok, err := args.GetArray("columns", semantic.String); err != nil {
+		return nil, err
+	} else if ok {
+		columns, err := interpreter.ToStringArray(cols)
+		if err != nil {
+			return nil, err
+		}
+		spec.Columns = columns
+	}
+
+	if pattern, ok, err := getColumnPattern(args); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Pattern = pattern
+	}
+
 	if name, ok, err := args.GetString("columnName"); err != nil {
 		return nil, err
 	} else if ok {
@@ -59,6 +81,12 @@ func createElapsedOpSpec(args flux.Arguments, a *flux.Administration) (flux.Oper
 		spec.ColumnName = "elapsed"
 	}
 
+	if keepFirst, ok, err := args.GetBool("keepFirst"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.KeepFirst = keepFirst
+	}
+
 	return spec, nil
 }
 
@@ -70,7 +98,15 @@ type ElapsedProcedureSpec struct {
 	plan.DefaultCost
 	Unit       flux.Duration `json:"unit"`
 	TimeColumn string        `json:"timeColumn"`
-	ColumnName string        `json:"columnName"`
+
+	// Columns and Pattern select the time columns to compute the
+	// elapsed time of when there is more than one.
+	// TimeColumn is used when neither is set.
+	Columns []string       `json:"columns,omitempty"`
+	Pattern *regexp.Regexp `json:"-"`
+
+	ColumnName string `json:"columnName"`
+	KeepFirst  bool   `json:"keepFirst"`
 }
 
 func newElapsedProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
@@ -82,7 +118,10 @@ func newElapsedProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.Pr
 	return &ElapsedProcedureSpec{
 		Unit:       spec.Unit,
 		TimeColumn: spec.TimeColumn,
+		Columns:    spec.Columns,
+		Pattern:    spec.Pattern,
 		ColumnName: spec.ColumnName,
+		KeepFirst:  spec.KeepFirst,
 	}, nil
 }
 
@@ -91,11 +130,18 @@ func (s *ElapsedProcedureSpec) Kind() plan.ProcedureKind {
 }
 
 func (s *ElapsedProcedureSpec) Copy() plan.ProcedureSpec {
-	return &ElapsedProcedureSpec{
+	ns := &ElapsedProcedureSpec{
 		Unit:       s.Unit,
 		TimeColumn: s.TimeColumn,
+		Pattern:    s.Pattern,
 		ColumnName: s.ColumnName,
+		KeepFirst:  s.KeepFirst,
+	}
+	if s.Columns != nil {
+		ns.Columns = make([]string, len(s.Columns))
+		copy(ns.Columns, s.Columns)
 	}
+	return ns
 }
 
 func createElapsedTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
@@ -115,19 +161,41 @@ type elapsedTransformation struct {
 	cache execute.TableBuilderCache
 
 	unit       float64
-	timeColumn string
+	columns    []string
+	pattern    *regexp.Regexp
 	columnName string
+	keepFirst  bool
+
+	// suffix is set when more than one time column may be selected.
+	// The elapsed time of each column is then stored in a column
+	// named after the time column followed by the column name.
+	suffix bool
+}
+
+// elapsedColumn is a time column and the column that stores its elapsed time.
+type elapsedColumn struct {
+	timeIdx  int
+	idx      int
+	prevTime float64
+	hasPrev  bool
 }
 
 func NewElapsedTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *ElapsedProcedureSpec) *elapsedTransformation {
-	return &elapsedTransformation{
+	t := &elapsedTransformation{
 		d:     d,
 		cache: cache,
 
 		unit:       float64(values.Duration(spec.Unit).Duration()),
-		timeColumn: spec.TimeColumn,
+		columns:    spec.Columns,
+		pattern:    spec.Pattern,
 		columnName: spec.ColumnName,
+		keepFirst:  spec.KeepFirst,
+		suffix:     len(spec.Columns) > 1 || spec.Pattern != nil,
 	}
+	if len(t.columns) == 0 && t.pattern == nil {
+		t.columns = []string{spec.TimeColumn}
+	}
+	return t
 }
 
 func (t *elapsedTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
@@ -152,62 +220,81 @@ func (t *elapsedTransformation) Process(id execute.DatasetID, tbl flux.Table) er
 		return errors.Newf(codes.FailedPrecondition, "found duplicate table with key: %v", tbl.Key())
 	}
 	cols := tbl.Cols()
-	numCol := 0
 
 	err := execute.AddTableCols(tbl, builder)
 	if err != nil {
 		return err
 	}
 
-	timeIdx := execute.ColIdx(t.timeColumn, cols)
-	if timeIdx < 0 {
-		return errors.Newf(codes.FailedPrecondition, "column %q does not exist", t.timeColumn)
+	for _, label := range t.columns {
+		if execute.ColIdx(label, cols) < 0 {
+			return errors.Newf(codes.FailedPrecondition, "column %q does not exist", label)
+		}
 	}
 
-	timeCol := cols[timeIdx]
-	if timeCol.Type == flux.TTime {
-		if numCol, err = builder.AddCol(flux.ColMeta{
-			Label: t.columnName,
+	var elapsed []elapsedColumn
+	for j, c := range cols {
+		if c.Type != flux.TTime || !matchColumn(c.Label, t.columns, t.pattern) {
+			continue
+		}
+		label := t.columnName
+		if t.suffix {
+			label = c.Label + "_" + t.columnName
+		}
+		idx, err := builder.AddCol(flux.ColMeta{
+			Label: label,
 			Type:  flux.TInt,
-		}); err != nil {
+		})
+		if err != nil {
 			return err
 		}
+		elapsed = append(elapsed, elapsedColumn{timeIdx: j, idx: idx})
 	}
 
-	prevTime, first := float64(0), true
+	if len(elapsed) == 0 {
+		return nil
+	}
 
+	first := true
 	colMap := execute.ColMap([]int{0}, builder, tbl.Cols())
 
 	return tbl.Do(func(cr flux.ColReader) error {
-		l := cr.Len()
-		if l != 0 {
-			for j, c := range cols {
-
-				if c.Type == flux.TTime && c.Label == t.timeColumn {
-					ts := cr.Times(j)
-					i := 0
-					if first {
-						prevTime = float64(execute.Time(ts.Value(0)))
-						i, first = 1, false
-					}
-					for ; i < l; i++ {
-
-						if err := execute.AppendMappedRecordExplicit(i, cr, builder, colMap); err != nil {
-							return err
-						}
+		for i, l := 0, cr.Len(); i < l; i++ {
+			// The first row has no elapsed time and is dropped
+			// unless the user wants to keep it.
+			skip := first && !t.keepFirst
+			first = false
+
+			if !skip {
+				if err := execute.AppendMappedRecordExplicit(i, cr, builder, colMap); err != nil {
+					return err
+				}
+			}
 
-						pTime := execute.Time(ts.Value(i))
-						currTime := float64(pTime)
-						if err := builder.AppendInt(numCol, int64((currTime-prevTime)/t.unit)); err != nil {
+			for k := range elapsed {
+				e := &elapsed[k]
+				ts := cr.Times(e.timeIdx)
+				if ts.IsNull(i) || !e.hasPrev {
+					if !skip {
+						if err := builder.AppendNil(e.idx); err != nil {
 							return err
 						}
+					}
+					if ts.IsValid(i) {
+						e.prevTime, e.hasPrev = float64(execute.Time(ts.Value(i))), true
+					}
+					continue
+				}
 
-						prevTime = currTime
+				currTime := float64(execute.Time(ts.Value(i)))
+				if !skip {
+					if err := builder.AppendInt(e.idx, int64((currTime-e.prevTime)/t.unit)); err != nil {
+						return err
 					}
 				}
+				e.prevTime = currTime
 			}
 		}
-
 		return nil
 	})
 }
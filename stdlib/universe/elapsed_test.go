@@ -1,6 +1,7 @@
 package universe_test
 
 import (
+	"regexp"
 	"testing"
 	"time"
 
@@ -284,6 +285,70 @@ func TestElapsed_Process(t *testing.T) {
 				},
 			}},
 		},
+		{
+			name: "elapsed of multiple time columns",
+			spec: &universe.ElapsedProcedureSpec{
+				Unit:       flux.ConvertDuration(time.Nanosecond),
+				Columns:    []string{"start", "end"},
+				ColumnName: "elapsed",
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "start", Type: flux.TTime},
+					{Label: "end", Type: flux.TTime},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), execute.Time(2)},
+					{execute.Time(2), execute.Time(5)},
+					{execute.Time(4), execute.Time(6)},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "start", Type: flux.TTime},
+					{Label: "end", Type: flux.TTime},
+					{Label: "start_elapsed", Type: flux.TInt},
+					{Label: "end_elapsed", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(2), execute.Time(5), int64(1), int64(3)},
+					{execute.Time(4), execute.Time(6), int64(2), int64(1)},
+				},
+			}},
+		},
+		{
+			name: "pattern with keepFirst",
+			spec: &universe.ElapsedProcedureSpec{
+				Unit:       flux.ConvertDuration(time.Nanosecond),
+				Pattern:    regexp.MustCompile("^_st"),
+				ColumnName: "elapsed",
+				KeepFirst:  true,
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_start", Type: flux.TTime},
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "_time", Type: flux.TTime},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(10), execute.Time(5)},
+					{execute.Time(10), execute.Time(20), execute.Time(15)},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_start", Type: flux.TTime},
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_start_elapsed", Type: flux.TInt},
+					{Label: "_stop_elapsed", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(10), execute.Time(5), nil, nil},
+					{execute.Time(10), execute.Time(20), execute.Time(15), int64(10), int64(10)},
+				},
+			}},
+		},
 		{
 			name: "multiple buffers",
 			spec: &universe.ElapsedProcedureSpec{
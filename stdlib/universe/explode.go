@@ -0,0 +1,157 @@
+package universe
+
+import (
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/runtime"
+)
+
+const ExplodeKind = "explode"
+
+type ExplodeOpSpec struct {
+	Column string `json:"column"`
+}
+
+func init() {
+	explodeSignature := runtime.MustLookupBuiltinType("universe", ExplodeKind)
+
+	runtime.RegisterPackageValue("universe", ExplodeKind, flux.MustValue(flux.FunctionValue(ExplodeKind, createExplodeOpSpec, explodeSignature)))
+	plan.RegisterProcedureSpec(ExplodeKind, newExplodeProcedure, ExplodeKind)
+	execute.RegisterTransformation(ExplodeKind, createExplodeTransformation)
+}
+
+func createExplodeOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := &ExplodeOpSpec{
+		Column: execute.DefaultValueColLabel,
+	}
+	if col, ok, err := args.GetString("column"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Column = col
+	}
+	return spec, nil
+}
+
+func (s *ExplodeOpSpec) Kind() flux.OperationKind {
+	return ExplodeKind
+}
+
+type ExplodeProcedureSpec struct {
+	plan.DefaultCost
+	Column string
+}
+
+func newExplodeProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*ExplodeOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+	return &ExplodeProcedureSpec{
+		Column: spec.Column,
+	}, nil
+}
+
+func (s *ExplodeProcedureSpec) Kind() plan.ProcedureKind {
+	return ExplodeKind
+}
+
+func (s *ExplodeProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+// TriggerSpec implements plan.TriggerAwareProcedureSpec
+func (s *ExplodeProcedureSpec) TriggerSpec() plan.TriggerSpec {
+	return plan.NarrowTransformationTriggerSpec{}
+}
+
+func createExplodeTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*ExplodeProcedureSpec)
+	if !ok {
+		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
+	}
+	return NewExplodeTransformation(id, s, a.Allocator())
+}
+
+type explodeTransformation struct {
+	column string
+}
+
+// NewExplodeTransformation constructs a transformation that expands
+// each row into one row for each element of an array column.
+// Rows with a null or an empty array are removed.
+func NewExplodeTransformation(id execute.DatasetID, spec *ExplodeProcedureSpec, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	tr := &explodeTransformation{
+		column: spec.Column,
+	}
+	return execute.NewNarrowTransformation(id, tr, mem)
+}
+
+func (t *explodeTransformation) Process(chunk table.Chunk, d *execute.TransportDataset, mem memory.Allocator) error {
+	idx := chunk.Index(t.column)
+	if idx < 0 {
+		return errors.Newf(codes.FailedPrecondition, "no column %q exists", t.column)
+	}
+	typ := chunk.Col(idx).Type
+	if !typ.IsArray() {
+		return errors.Newf(codes.FailedPrecondition, "cannot explode column %q of type %s that is not an array", t.column, typ)
+	} else if chunk.Key().HasCol(t.column) {
+		return errors.Newf(codes.FailedPrecondition, "column %q must not be part of the group key", t.column)
+	}
+
+	cols := make([]flux.ColMeta, chunk.NCols())
+	copy(cols, chunk.Cols())
+	cols[idx].Type = typ.ElemType()
+
+	builders := make([]array.Builder, len(cols))
+	for j, col := range cols {
+		builders[j] = arrow.NewBuilder(col.Type, mem)
+	}
+
+	buffer := chunk.Buffer()
+	arrs := chunk.Arrays(idx)
+	for i, l := 0, chunk.Len(); i < l; i++ {
+		if arrs.IsNull(i) {
+			continue
+		}
+		elems := arrow.ArrayValue(arrs, i, typ.ElemType()).Array()
+		for k, n := 0, elems.Len(); k < n; k++ {
+			for j, b := range builders {
+				v := elems.Get(k)
+				if j != idx {
+					v = execute.ValueForRow(&buffer, i, j)
+				}
+				if err := arrow.AppendValue(b, v); err != nil {
+					for _, b := range builders {
+						b.Release()
+					}
+					return err
+				}
+			}
+		}
+	}
+
+	vs := make([]array.Array, len(builders))
+	for j, b := range builders {
+		vs[j] = b.NewArray()
+		b.Release()
+	}
+	return d.Process(table.ChunkFromBuffer(arrow.TableBuffer{
+		GroupKey: chunk.Key(),
+		Columns:  cols,
+		Values:   vs,
+	}))
+}
+
+func (t *explodeTransformation) Close() error { return nil }
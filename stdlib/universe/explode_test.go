@@ -0,0 +1,90 @@
+package universe_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/stdlib/universe"
+)
+
+func TestExplode_Process(t *testing.T) {
+	testCases := []struct {
+		name    string
+		spec    *universe.ExplodeProcedureSpec
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "explode values",
+			spec: &universe.ExplodeProcedureSpec{
+				Column: "values",
+			},
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"t0"},
+				ColMeta: []flux.ColMeta{
+					{Label: "t0", Type: flux.TString},
+					{Label: "n", Type: flux.TInt},
+					{Label: "values", Type: flux.ArrayOf(flux.TFloat)},
+				},
+				Data: [][]interface{}{
+					{"a", int64(1), floatArray(2.0, nil)},
+					{"a", int64(2), nil},
+					{"a", int64(3), floatArray()},
+					{"a", int64(4), floatArray(5.0)},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"t0"},
+				ColMeta: []flux.ColMeta{
+					{Label: "t0", Type: flux.TString},
+					{Label: "n", Type: flux.TInt},
+					{Label: "values", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{"a", int64(1), 2.0},
+					{"a", int64(1), nil},
+					{"a", int64(4), 5.0},
+				},
+			}},
+		},
+		{
+			name: "not an array",
+			spec: &universe.ExplodeProcedureSpec{
+				Column: "_value",
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{2.0},
+				},
+			}},
+			wantErr: errors.New(codes.FailedPrecondition, `cannot explode column "_value" of type float that is not an array`),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper2(
+				t,
+				tc.data,
+				tc.want,
+				tc.wantErr,
+				func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+					tr, d, err := universe.NewExplodeTransformation(id, tc.spec, alloc)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return tr, d
+				},
+			)
+		})
+	}
+}
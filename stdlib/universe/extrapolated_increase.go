@@ -0,0 +1,300 @@
+package universe
+
+import (
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/runtime"
+	"github.com/influxdata/flux/semantic"
+)
+
+const ExtrapolatedIncreaseKind = "_extrapolatedIncrease"
+
+type ExtrapolatedIncreaseOpSpec struct {
+	Columns        []string      `json:"columns"`
+	ResetThreshold float64       `json:"resetThreshold"`
+	Unit           flux.Duration `json:"unit"`
+}
+
+func init() {
+	extrapolatedIncreaseSignature := runtime.MustLookupBuiltinType("universe", ExtrapolatedIncreaseKind)
+
+	runtime.RegisterPackageValue("universe", ExtrapolatedIncreaseKind, flux.MustValue(flux.FunctionValue(ExtrapolatedIncreaseKind, createExtrapolatedIncreaseOpSpec, extrapolatedIncreaseSignature)))
+	plan.RegisterProcedureSpec(ExtrapolatedIncreaseKind, newExtrapolatedIncreaseProcedure, ExtrapolatedIncreaseKind)
+	execute.RegisterTransformation(ExtrapolatedIncreaseKind, createExtrapolatedIncreaseTransformation)
+}
+
+func createExtrapolatedIncreaseOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(ExtrapolatedIncreaseOpSpec)
+	if cols, err := args.GetRequiredArray("columns", semantic.String); err != nil {
+		return nil, err
+	} else {
+		columns, err := interpreter.ToStringArray(cols)
+		if err != nil {
+			return nil, err
+		}
+		spec.Columns = columns
+	}
+
+	if threshold, ok, err := args.GetFloat("resetThreshold"); err != nil {
+		return nil, err
+	} else if ok {
+		if threshold < 0 {
+			return nil, errors.Newf(codes.Invalid, "resetThreshold must be non-negative, got %v", threshold)
+		}
+		spec.ResetThreshold = threshold
+	}
+
+	if unit, ok, err := args.GetDuration("unit"); err != nil {
+		return nil, err
+	} else if ok {
+		if unit.IsNegative() {
+			return nil, errors.Newf(codes.Invalid, "unit must be non-negative, got %v", unit)
+		}
+		spec.Unit = unit
+	}
+	return spec, nil
+}
+
+func (s *ExtrapolatedIncreaseOpSpec) Kind() flux.OperationKind {
+	return ExtrapolatedIncreaseKind
+}
+
+type ExtrapolatedIncreaseProcedureSpec struct {
+	plan.DefaultCost
+	Columns        []string
+	ResetThreshold float64
+	Unit           flux.Duration
+}
+
+func newExtrapolatedIncreaseProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*ExtrapolatedIncreaseOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+	return &ExtrapolatedIncreaseProcedureSpec{
+		Columns:        spec.Columns,
+		ResetThreshold: spec.ResetThreshold,
+		Unit:           spec.Unit,
+	}, nil
+}
+
+func (s *ExtrapolatedIncreaseProcedureSpec) Kind() plan.ProcedureKind {
+	return ExtrapolatedIncreaseKind
+}
+
+func (s *ExtrapolatedIncreaseProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	if s.Columns != nil {
+		ns.Columns = make([]string, len(s.Columns))
+		copy(ns.Columns, s.Columns)
+	}
+	return &ns
+}
+
+func createExtrapolatedIncreaseTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*ExtrapolatedIncreaseProcedureSpec)
+	if !ok {
+		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
+	}
+	return NewExtrapolatedIncreaseTransformation(id, s, a.Allocator())
+}
+
+type extrapolatedIncreaseTransformation struct {
+	columns        []string
+	resetThreshold float64
+	unit           float64
+}
+
+// NewExtrapolatedIncreaseTransformation constructs a transformation that computes
+// the increase of counter columns over the window of each table. The increase
+// is extrapolated from the first and last values to the window boundaries
+// in the same way as the Prometheus increase() and rate() functions.
+func NewExtrapolatedIncreaseTransformation(id execute.DatasetID, spec *ExtrapolatedIncreaseProcedureSpec, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	tr := &extrapolatedIncreaseTransformation{
+		columns:        spec.Columns,
+		resetThreshold: spec.ResetThreshold,
+		unit:           float64(spec.Unit.Duration()),
+	}
+	return execute.NewAggregateTransformation(id, tr, mem)
+}
+
+// counterIncrease accumulates the increase of a counter
+// and the times of its first and last values.
+type counterIncrease struct {
+	n              int
+	firstT, lastT  int64
+	first, prev    float64
+	increase       float64
+	resetThreshold float64
+}
+
+func (c *counterIncrease) add(t int64, v float64) {
+	c.n++
+	if c.n == 1 {
+		c.firstT, c.lastT, c.first, c.prev = t, t, v, v
+		return
+	}
+	c.lastT = t
+	if diff := v - c.prev; diff >= 0 {
+		c.increase += diff
+	} else if -diff <= c.resetThreshold {
+		// The decrease is too small to be a counter reset
+		// so the previous value is kept.
+		return
+	} else {
+		// The counter was reset to zero so it
+		// increased by the current value.
+		c.increase += v
+	}
+	c.prev = v
+}
+
+// extrapolate extends the increase from the first and last values to the
+// window boundaries. The increase is only extended by up to half of the
+// average interval between values when a value is not close to a boundary,
+// and it is not extended before the point where the counter would be zero.
+func (c *counterIncrease) extrapolate(start, stop int64) (float64, bool) {
+	if c.n < 2 || c.lastT == c.firstT {
+		return 0, false
+	}
+	sampled := float64(c.lastT - c.firstT)
+	toStart := float64(c.firstT - start)
+	toStop := float64(stop - c.lastT)
+	avg := sampled / float64(c.n-1)
+
+	if c.increase > 0 && c.first >= 0 {
+		if toZero := sampled * (c.first / c.increase); toZero < toStart {
+			toStart = toZero
+		}
+	}
+
+	threshold := avg * 1.1
+	interval := sampled
+	if toStart < threshold {
+		interval += toStart
+	} else {
+		interval += avg / 2
+	}
+	if toStop < threshold {
+		interval += toStop
+	} else {
+		interval += avg / 2
+	}
+	return c.increase * interval / sampled, true
+}
+
+type extrapolatedIncreaseState struct {
+	columns     []*counterIncrease
+	t           int64
+	initialized bool
+}
+
+func (t *extrapolatedIncreaseTransformation) Aggregate(chunk table.Chunk, state interface{}, mem memory.Allocator) (interface{}, bool, error) {
+	s, _ := state.(*extrapolatedIncreaseState)
+	if s == nil {
+		s = &extrapolatedIncreaseState{columns: make([]*counterIncrease, len(t.columns))}
+		for i := range s.columns {
+			s.columns[i] = &counterIncrease{resetThreshold: t.resetThreshold}
+		}
+	}
+	if chunk.Len() == 0 {
+		return s, true, nil
+	}
+
+	timeIdx := chunk.Index(execute.DefaultTimeColLabel)
+	if timeIdx < 0 {
+		return nil, false, errors.Newf(codes.FailedPrecondition, "no column %q exists", execute.DefaultTimeColLabel)
+	} else if want, got := flux.TTime, chunk.Col(timeIdx).Type; want != got {
+		return nil, false, errors.Newf(codes.FailedPrecondition, "time column %q is type %s and not %s", execute.DefaultTimeColLabel, got, want)
+	}
+	ts := chunk.Ints(timeIdx)
+	for i, l := 0, ts.Len(); i < l; i++ {
+		if ts.IsNull(i) {
+			continue
+		} else if s.initialized && ts.Value(i) < s.t {
+			return nil, false, errors.New(codes.FailedPrecondition, "increase found out-of-order times in time column")
+		}
+		s.t, s.initialized = ts.Value(i), true
+	}
+
+	for j, label := range t.columns {
+		idx := chunk.Index(label)
+		if idx < 0 {
+			return nil, false, errors.Newf(codes.FailedPrecondition, "no column %q exists", label)
+		}
+		vs := chunk.Values(idx)
+		for i, l := 0, chunk.Len(); i < l; i++ {
+			if ts.IsNull(i) || vs.IsNull(i) {
+				continue
+			}
+			var v float64
+			switch vs := vs.(type) {
+			case *array.Float:
+				v = vs.Value(i)
+			case *array.Int:
+				v = float64(vs.Value(i))
+			case *array.Uint:
+				v = float64(vs.Value(i))
+			default:
+				return nil, false, errors.Newf(codes.FailedPrecondition, "unsupported increase column type %s:%s", label, chunk.Col(idx).Type)
+			}
+			s.columns[j].add(ts.Value(i), v)
+		}
+	}
+
+	return s, true, nil
+}
+
+func (t *extrapolatedIncreaseTransformation) Compute(key flux.GroupKey, state interface{}, d *execute.TransportDataset, mem memory.Allocator) error {
+	s := state.(*extrapolatedIncreaseState)
+
+	startIdx := execute.ColIdx(execute.DefaultStartColLabel, key.Cols())
+	stopIdx := execute.ColIdx(execute.DefaultStopColLabel, key.Cols())
+	if startIdx < 0 || key.Cols()[startIdx].Type != flux.TTime ||
+		stopIdx < 0 || key.Cols()[stopIdx].Type != flux.TTime {
+		return errors.Newf(codes.FailedPrecondition, "extrapolating the increase requires %q and %q time columns in the group key", execute.DefaultStartColLabel, execute.DefaultStopColLabel)
+	}
+	start, stop := int64(key.ValueTime(startIdx)), int64(key.ValueTime(stopIdx))
+
+	cols := make([]flux.ColMeta, 0, len(key.Cols())+len(t.columns))
+	vs := make([]array.Array, 0, len(key.Cols())+len(t.columns))
+	for j, col := range key.Cols() {
+		cols = append(cols, col)
+		vs = append(vs, arrow.Repeat(col.Type, key.Value(j), 1, mem))
+	}
+	for j, label := range t.columns {
+		if key.HasCol(label) {
+			return errors.Newf(codes.FailedPrecondition, "column %q must not be part of the group key", label)
+		}
+		cols = append(cols, flux.ColMeta{Label: label, Type: flux.TFloat})
+		b := array.NewFloatBuilder(mem)
+		b.Resize(1)
+		if v, ok := s.columns[j].extrapolate(start, stop); !ok {
+			b.AppendNull()
+		} else if t.unit > 0 {
+			b.Append(v / (float64(stop-start) / t.unit))
+		} else {
+			b.Append(v)
+		}
+		vs = append(vs, b.NewArray())
+	}
+	return d.Process(table.ChunkFromBuffer(arrow.TableBuffer{
+		GroupKey: key,
+		Columns:  cols,
+		Values:   vs,
+	}))
+}
+
+func (t *extrapolatedIncreaseTransformation) Close() error { return nil }
@@ -0,0 +1,168 @@
+package universe_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/stdlib/universe"
+)
+
+func TestExtrapolatedIncrease_Process(t *testing.T) {
+	inputCols := []flux.ColMeta{
+		{Label: "_start", Type: flux.TTime},
+		{Label: "_stop", Type: flux.TTime},
+		{Label: "_time", Type: flux.TTime},
+		{Label: "_value", Type: flux.TFloat},
+	}
+	outputCols := []flux.ColMeta{
+		{Label: "_start", Type: flux.TTime},
+		{Label: "_stop", Type: flux.TTime},
+		{Label: "_value", Type: flux.TFloat},
+	}
+	start, stop := execute.Time(0), execute.Time(60*time.Second)
+	testCases := []struct {
+		name    string
+		spec    *universe.ExtrapolatedIncreaseProcedureSpec
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "counter reset",
+			spec: &universe.ExtrapolatedIncreaseProcedureSpec{
+				Columns: []string{execute.DefaultValueColLabel},
+			},
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: inputCols,
+				Data: [][]interface{}{
+					{start, stop, execute.Time(5 * time.Second), 10.0},
+					{start, stop, execute.Time(15 * time.Second), 20.0},
+					{start, stop, execute.Time(25 * time.Second), 30.0},
+					{start, stop, execute.Time(35 * time.Second), 5.0},
+					{start, stop, execute.Time(45 * time.Second), 15.0},
+					{start, stop, execute.Time(55 * time.Second), 25.0},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: outputCols,
+				Data: [][]interface{}{
+					{start, stop, 54.0},
+				},
+			}},
+		},
+		{
+			name: "per second",
+			spec: &universe.ExtrapolatedIncreaseProcedureSpec{
+				Columns: []string{execute.DefaultValueColLabel},
+				Unit:    flux.ConvertDuration(time.Second),
+			},
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: inputCols,
+				Data: [][]interface{}{
+					{start, stop, execute.Time(5 * time.Second), 10.0},
+					{start, stop, execute.Time(15 * time.Second), 20.0},
+					{start, stop, execute.Time(25 * time.Second), 30.0},
+					{start, stop, execute.Time(35 * time.Second), 5.0},
+					{start, stop, execute.Time(45 * time.Second), 15.0},
+					{start, stop, execute.Time(55 * time.Second), 25.0},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: outputCols,
+				Data: [][]interface{}{
+					{start, stop, 0.9},
+				},
+			}},
+		},
+		{
+			name: "reset threshold",
+			spec: &universe.ExtrapolatedIncreaseProcedureSpec{
+				Columns:        []string{execute.DefaultValueColLabel},
+				ResetThreshold: 1.0,
+			},
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: inputCols,
+				Data: [][]interface{}{
+					{start, stop, execute.Time(10 * time.Second), 10.0},
+					{start, stop, execute.Time(20 * time.Second), 20.0},
+					{start, stop, execute.Time(30 * time.Second), 19.5},
+					{start, stop, execute.Time(40 * time.Second), 30.0},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: outputCols,
+				Data: [][]interface{}{
+					{start, stop, 30.0},
+				},
+			}},
+		},
+		{
+			name: "single value",
+			spec: &universe.ExtrapolatedIncreaseProcedureSpec{
+				Columns: []string{execute.DefaultValueColLabel},
+			},
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: inputCols,
+				Data: [][]interface{}{
+					{start, stop, execute.Time(10 * time.Second), 10.0},
+					{start, stop, execute.Time(20 * time.Second), nil},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: outputCols,
+				Data: [][]interface{}{
+					{start, stop, nil},
+				},
+			}},
+		},
+		{
+			name: "missing window bounds",
+			spec: &universe.ExtrapolatedIncreaseProcedureSpec{
+				Columns: []string{execute.DefaultValueColLabel},
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(10 * time.Second), 10.0},
+					{execute.Time(20 * time.Second), 20.0},
+				},
+			}},
+			wantErr: errors.New(codes.FailedPrecondition, `extrapolating the increase requires "_start" and "_stop" time columns in the group key`),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper2(
+				t,
+				tc.data,
+				tc.want,
+				tc.wantErr,
+				func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+					tr, d, err := universe.NewExtrapolatedIncreaseTransformation(id, tc.spec, alloc)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return tr, d
+				},
+			)
+		})
+	}
+}
@@ -29,6 +29,10 @@ const FilterKind = "filter"
 type FilterOpSpec struct {
 	Fn      interpreter.ResolvedFunction `json:"fn"`
 	OnEmpty string                       `json:"onEmpty,omitempty"`
+
+	// Subqueries are the streams read by findColumn and findRecord
+	// in the predicate. They are the parents of the operation after tables.
+	Subqueries []*flux.TableObject `json:"-"`
 }
 
 func init() {
@@ -68,9 +72,16 @@ func createFilterOpSpec(args flux.Arguments, a *flux.Administration) (flux.Opera
 		return nil, err
 	}
 
+	tables, _ := args.Get(flux.TablesParameter)
+	subqueries := findSubqueries(fn, tables)
+	for _, to := range subqueries {
+		a.AddParent(to)
+	}
+
 	return &FilterOpSpec{
-		Fn:      fn,
-		OnEmpty: onEmpty,
+		Fn:         fn,
+		OnEmpty:    onEmpty,
+		Subqueries: subqueries,
 	}, nil
 }
 
@@ -82,6 +93,11 @@ type FilterProcedureSpec struct {
 	plan.DefaultCost
 	Fn              interpreter.ResolvedFunction
 	KeepEmptyTables bool
+
+	// Subqueries are the streams read by findColumn and findRecord
+	// in the predicate. Each subquery is a predecessor of the filter
+	// after the input.
+	Subqueries []*flux.TableObject
 }
 
 func newFilterProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
@@ -98,12 +114,16 @@ func newFilterProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.Pro
 	return &FilterProcedureSpec{
 		Fn:              spec.Fn,
 		KeepEmptyTables: onEmpty == "keep",
+		Subqueries:      spec.Subqueries,
 	}, nil
 }
 
 func (s *FilterProcedureSpec) PassThroughAttribute(attrKey string) bool {
 	switch attrKey {
-	case plan.ParallelRunKey, plan.CollationKey:
+	case plan.ParallelRunKey:
+		// The subqueries are not read in parallel.
+		return len(s.Subqueries) == 0
+	case plan.CollationKey:
 		return true
 	}
 	return false
@@ -116,6 +136,10 @@ func (s *FilterProcedureSpec) Copy() plan.ProcedureSpec {
 	ns := new(FilterProcedureSpec)
 	ns.Fn = s.Fn.Copy()
 	ns.KeepEmptyTables = s.KeepEmptyTables
+	if len(s.Subqueries) > 0 {
+		ns.Subqueries = make([]*flux.TableObject, len(s.Subqueries))
+		copy(ns.Subqueries, s.Subqueries)
+	}
 	return ns
 }
 
@@ -129,6 +153,9 @@ func createFilterTransformation(id execute.DatasetID, mode execute.AccumulationM
 	if !ok {
 		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
 	}
+	if len(s.Subqueries) > 0 {
+		return newFilterSubqueryTransformation(a.Context(), s, id, a.Parents(), a.Allocator())
+	}
 	t, d, err := NewFilterTransformation(a.Context(), s, id, a.Allocator())
 	if err != nil {
 		return nil, nil, err
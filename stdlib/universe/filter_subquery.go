@@ -0,0 +1,476 @@
+package universe
+
+import (
+	"context"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/compiler"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+// findSubqueries returns the streams that the predicate reads with
+// findColumn or findRecord. Only the streams that are bound to an
+// identifier outside of the predicate are returned and the input of
+// the filter is never a subquery of itself.
+//
+// The filter reads each of these streams once as a predecessor in the
+// plan instead of executing them during the evaluation of the predicate.
+func findSubqueries(fn interpreter.ResolvedFunction, tables values.Value) []*flux.TableObject {
+	if fn.Fn == nil || fn.Scope == nil {
+		return nil
+	}
+	params := make(map[string]bool)
+	if fn.Fn.Parameters != nil {
+		for _, p := range fn.Fn.Parameters.List {
+			params[p.Key.Name.Name()] = true
+		}
+	}
+
+	var subqueries []*flux.TableObject
+	semantic.Inspect(fn.Fn.Block, func(n semantic.Node) bool {
+		call, ok := n.(*semantic.CallExpression)
+		if !ok {
+			return true
+		}
+		to := subqueryStream(call, fn.Scope, params)
+		if to == nil || values.Value(to) == tables {
+			return true
+		}
+		for _, sq := range subqueries {
+			if sq == to {
+				return true
+			}
+		}
+		subqueries = append(subqueries, to)
+		return true
+	})
+	return subqueries
+}
+
+// subqueryStream returns the stream read by the call
+// if the call is to findColumn or findRecord.
+func subqueryStream(call *semantic.CallExpression, scope values.Scope, params map[string]bool) *flux.TableObject {
+	callee, ok := call.Callee.(*semantic.IdentifierExpression)
+	if !ok || params[callee.Name.Name()] {
+		return nil
+	}
+	if v, ok := scope.Lookup(callee.Name.Name()); !ok ||
+		(v != values.Value(findColumnFunction) && v != values.Value(findRecordFunction)) {
+		return nil
+	}
+
+	tables := call.Pipe
+	if call.Arguments != nil {
+		for _, p := range call.Arguments.Properties {
+			if p.Key.Key() == tableFindStreamArg {
+				tables = p.Value
+			}
+		}
+	}
+	ident, ok := tables.(*semantic.IdentifierExpression)
+	if !ok || params[ident.Name.Name()] {
+		return nil
+	}
+	v, ok := scope.Lookup(ident.Name.Name())
+	if !ok {
+		return nil
+	}
+	to, _ := v.(*flux.TableObject)
+	return to
+}
+
+// referencesAny reports whether the node refers to one of the names.
+func referencesAny(node semantic.Node, names map[string]bool) bool {
+	found := false
+	semantic.Inspect(node, func(n semantic.Node) bool {
+		if ident, ok := n.(*semantic.IdentifierExpression); ok && names[ident.Name.Name()] {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// filterSubqueryTransformation filters the input with a predicate
+// that reads the tables of subqueries. The tables of the subqueries
+// are buffered and the input is buffered until every subquery
+// has finished.
+type filterSubqueryTransformation struct {
+	ctx  context.Context
+	spec *FilterProcedureSpec
+	d    *execute.TransportDataset
+	mem  memory.Allocator
+
+	input      execute.DatasetID
+	subqueries map[execute.DatasetID]*filterSubquery
+	streams    map[*flux.TableObject]*filterSubquery
+	pending    int
+
+	// buffered holds the messages of the input
+	// until the filter is ready.
+	buffered      []execute.Message
+	inputFinished bool
+	finished      bool
+
+	filter *filterTransformation
+	params map[string]bool
+	pure   map[*semantic.FunctionExpression]bool
+	cache  map[subqueryCall]values.Value
+}
+
+// subqueryCall identifies a call to findColumn or findRecord
+// whose result does not change between rows.
+type subqueryCall struct {
+	stream *flux.TableObject
+	fn     *semantic.FunctionExpression
+	column string
+	idx    int64
+}
+
+func newFilterSubqueryTransformation(ctx context.Context, spec *FilterProcedureSpec, id execute.DatasetID, parents []execute.DatasetID, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	if len(parents) != len(spec.Subqueries)+1 {
+		return nil, nil, errors.Newf(codes.Internal, "filter has %d subqueries but %d parents", len(spec.Subqueries), len(parents))
+	}
+	t := &filterSubqueryTransformation{
+		ctx:        ctx,
+		spec:       spec,
+		d:          execute.NewTransportDataset(id, mem),
+		mem:        mem,
+		input:      parents[0],
+		subqueries: make(map[execute.DatasetID]*filterSubquery, len(spec.Subqueries)),
+		streams:    make(map[*flux.TableObject]*filterSubquery, len(spec.Subqueries)),
+		pending:    len(spec.Subqueries),
+		params:     make(map[string]bool),
+		pure:       make(map[*semantic.FunctionExpression]bool),
+		cache:      make(map[subqueryCall]values.Value),
+	}
+	for i, to := range spec.Subqueries {
+		sq := &filterSubquery{tables: execute.NewGroupLookup()}
+		t.subqueries[parents[i+1]] = sq
+		t.streams[to] = sq
+	}
+	if spec.Fn.Fn.Parameters != nil {
+		for _, p := range spec.Fn.Fn.Parameters.List {
+			t.params[p.Key.Name.Name()] = true
+		}
+	}
+	return execute.NewTransformationFromTransport(t), t.d, nil
+}
+
+func (t *filterSubqueryTransformation) ProcessMessage(m execute.Message) error {
+	defer m.Ack()
+
+	switch m := m.(type) {
+	case execute.FinishMsg:
+		t.finish(m.SrcDatasetID(), m.Error())
+		return nil
+	case execute.ProcessChunkMsg:
+		chunk := m.TableChunk()
+		if sq, ok := t.subqueries[m.SrcDatasetID()]; ok {
+			sq.add(chunk)
+			return nil
+		}
+		if t.filter == nil {
+			t.buffer(m)
+			return nil
+		}
+		return t.filter.Process(chunk, t.d, t.mem)
+	case execute.FlushKeyMsg:
+		if _, ok := t.subqueries[m.SrcDatasetID()]; ok {
+			return nil
+		}
+		if t.filter == nil {
+			t.buffer(m)
+			return nil
+		}
+		return t.d.FlushKey(m.Key())
+	}
+	return nil
+}
+
+// buffer keeps a copy of the message until the filter is ready.
+func (t *filterSubqueryTransformation) buffer(m execute.Message) {
+	t.buffered = append(t.buffered, m.Dup())
+}
+
+func (t *filterSubqueryTransformation) finish(id execute.DatasetID, err error) {
+	if t.finished {
+		return
+	}
+	if err != nil {
+		t.done(err)
+		return
+	}
+
+	if id == t.input {
+		t.inputFinished = true
+	} else if _, ok := t.subqueries[id]; ok {
+		t.pending--
+		if t.pending == 0 {
+			if err := t.start(); err != nil {
+				t.done(err)
+				return
+			}
+		}
+	}
+	if t.inputFinished && t.pending == 0 {
+		t.done(nil)
+	}
+}
+
+// start creates the filter once every subquery has been read
+// and filters the buffered input.
+func (t *filterSubqueryTransformation) start() error {
+	scope := t.spec.Fn.Scope.Nest(nil)
+	scope.Set("findColumn", values.NewFunction("findColumn", findColumnFunction.Type(), t.findColumn, false))
+	scope.Set("findRecord", values.NewFunction("findRecord", findRecordFunction.Type(), t.findRecord, false))
+	t.filter = &filterTransformation{
+		ctx:             t.ctx,
+		fn:              execute.NewRowPredicateFn(t.spec.Fn.Fn, compiler.ToScope(scope)),
+		keepEmptyTables: t.spec.KeepEmptyTables,
+	}
+
+	buffered := t.buffered
+	t.buffered = nil
+	for i, m := range buffered {
+		var err error
+		switch m := m.(type) {
+		case execute.ProcessChunkMsg:
+			err = t.filter.Process(m.TableChunk(), t.d, t.mem)
+		case execute.FlushKeyMsg:
+			err = t.d.FlushKey(m.Key())
+		}
+		m.Ack()
+		if err != nil {
+			for _, m := range buffered[i+1:] {
+				m.Ack()
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *filterSubqueryTransformation) done(err error) {
+	t.finished = true
+	for _, m := range t.buffered {
+		m.Ack()
+	}
+	t.buffered = nil
+	for _, sq := range t.subqueries {
+		sq.release()
+	}
+	t.d.Finish(err)
+}
+
+// lookup returns the subquery of the tables argument and the resolved
+// predicate of the call. The subquery is nil if the tables argument is
+// not one of the subqueries of the filter.
+func (t *filterSubqueryTransformation) lookup(args values.Object) (*filterSubquery, *flux.TableObject, interpreter.ResolvedFunction, error) {
+	v, ok := args.Get(tableFindStreamArg)
+	if !ok {
+		return nil, nil, interpreter.ResolvedFunction{}, nil
+	}
+	to, ok := v.(*flux.TableObject)
+	if !ok {
+		return nil, nil, interpreter.ResolvedFunction{}, nil
+	}
+	sq, ok := t.streams[to]
+	if !ok {
+		return nil, nil, interpreter.ResolvedFunction{}, nil
+	}
+
+	call, err := interpreter.NewArguments(args).GetRequiredFunction(tableFindFunctionArg)
+	if err != nil {
+		return nil, nil, interpreter.ResolvedFunction{}, errors.Newf(codes.Invalid, "missing argument: %s", tableFindFunctionArg)
+	}
+	predicate, err := interpreter.ResolveFunction(call)
+	if err != nil {
+		return nil, nil, interpreter.ResolvedFunction{}, err
+	}
+	return sq, to, predicate, nil
+}
+
+// cacheable reports whether the result of a call with the predicate
+// is the same for every row. It is not when the predicate refers to
+// the parameters of the filter predicate.
+func (t *filterSubqueryTransformation) cacheable(fn *semantic.FunctionExpression) bool {
+	pure, ok := t.pure[fn]
+	if !ok {
+		pure = !referencesAny(fn, t.params)
+		t.pure[fn] = pure
+	}
+	return pure
+}
+
+func (t *filterSubqueryTransformation) findColumn(ctx context.Context, args values.Object) (values.Value, error) {
+	sq, to, predicate, err := t.lookup(args)
+	if err != nil {
+		return nil, err
+	} else if sq == nil {
+		return findColumnFunction.Call(ctx, args)
+	}
+	col, err := interpreter.NewArguments(args).GetRequiredString(getColumnColumnArg)
+	if err != nil {
+		return nil, err
+	}
+
+	key := subqueryCall{stream: to, fn: predicate.Fn, column: col}
+	cacheable := t.cacheable(predicate.Fn)
+	if v, ok := t.cache[key]; ok && cacheable {
+		return v, nil
+	}
+
+	fn := execute.NewTablePredicateFn(predicate.Fn, compiler.ToScope(predicate.Scope))
+	st, err := sq.find(ctx, fn)
+	if err != nil {
+		return nil, err
+	}
+	var v values.Value = emptyArray()
+	if st != nil {
+		if idx := execute.ColIdx(col, st.cols); idx >= 0 {
+			if v, err = arrayFromColumn(idx, st.table()); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if cacheable {
+		t.cache[key] = v
+	}
+	return v, nil
+}
+
+func (t *filterSubqueryTransformation) findRecord(ctx context.Context, args values.Object) (values.Value, error) {
+	sq, to, predicate, err := t.lookup(args)
+	if err != nil {
+		return nil, err
+	} else if sq == nil {
+		return findRecordFunction.Call(ctx, args)
+	}
+	rowIdx, err := interpreter.NewArguments(args).GetRequiredInt(getRecordIndexArg)
+	if err != nil {
+		return nil, err
+	}
+
+	key := subqueryCall{stream: to, fn: predicate.Fn, idx: rowIdx}
+	cacheable := t.cacheable(predicate.Fn)
+	if v, ok := t.cache[key]; ok && cacheable {
+		return v, nil
+	}
+
+	fn := execute.NewTablePredicateFn(predicate.Fn, compiler.ToScope(predicate.Scope))
+	st, err := sq.find(ctx, fn)
+	if err != nil {
+		return nil, err
+	}
+	var v values.Value = emptyObject()
+	if st != nil {
+		if r, ok := st.record(rowIdx); ok {
+			v = r
+		}
+	}
+	if cacheable {
+		t.cache[key] = v
+	}
+	return v, nil
+}
+
+// filterSubquery holds the tables read from a subquery.
+type filterSubquery struct {
+	tables *execute.GroupLookup
+	// order is the order in which the tables were first read.
+	order []*subqueryTable
+}
+
+type subqueryTable struct {
+	key    flux.GroupKey
+	cols   []flux.ColMeta
+	chunks []table.Chunk
+}
+
+func (sq *filterSubquery) add(chunk table.Chunk) {
+	chunk.Retain()
+	v, ok := sq.tables.Lookup(chunk.Key())
+	if !ok {
+		st := &subqueryTable{
+			key:  chunk.Key(),
+			cols: chunk.Cols(),
+		}
+		sq.tables.Set(chunk.Key(), st)
+		sq.order = append(sq.order, st)
+		v = st
+	}
+	st := v.(*subqueryTable)
+	st.chunks = append(st.chunks, chunk)
+}
+
+// find returns the first table whose group key matches the predicate.
+func (sq *filterSubquery) find(ctx context.Context, fn *execute.TablePredicateFn) (*subqueryTable, error) {
+	for _, st := range sq.order {
+		tbl := st.table()
+		prepared, err := fn.Prepare(tbl)
+		if err != nil {
+			tbl.Done()
+			return nil, err
+		}
+		found, err := prepared.Eval(ctx, tbl)
+		tbl.Done()
+		if err != nil {
+			return nil, errors.Wrap(err, codes.Inherit, "failed to evaluate group key predicate function")
+		}
+		if found {
+			return st, nil
+		}
+	}
+	return nil, nil
+}
+
+func (sq *filterSubquery) release() {
+	for _, st := range sq.order {
+		for _, c := range st.chunks {
+			c.Release()
+		}
+		st.chunks = nil
+	}
+	sq.order = nil
+	sq.tables.Clear()
+}
+
+// table returns a table that reads the buffered chunks.
+// The chunks remain buffered after the table has been read.
+func (st *subqueryTable) table() flux.Table {
+	buffers := make([]flux.ColReader, len(st.chunks))
+	for i, c := range st.chunks {
+		c.Retain()
+		buf := c.Buffer()
+		buffers[i] = &buf
+	}
+	return &table.BufferedTable{
+		GroupKey: st.key,
+		Columns:  st.cols,
+		Buffers:  buffers,
+	}
+}
+
+// record returns the row at the index of the table.
+func (st *subqueryTable) record(idx int64) (values.Object, bool) {
+	if idx < 0 {
+		return nil, false
+	}
+	for _, c := range st.chunks {
+		if n := int64(c.Len()); idx >= n {
+			idx -= n
+			continue
+		}
+		buf := c.Buffer()
+		return objectFromRow(int(idx), &buf), true
+	}
+	return nil, false
+}
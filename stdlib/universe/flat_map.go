@@ -0,0 +1,200 @@
+package universe
+
+import (
+	"context"
+
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/compiler"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/runtime"
+)
+
+const FlatMapKind = "flatMap"
+
+type FlatMapOpSpec struct {
+	Fn interpreter.ResolvedFunction `json:"fn"`
+}
+
+func init() {
+	flatMapSignature := runtime.MustLookupBuiltinType("universe", "flatMap")
+
+	runtime.RegisterPackageValue("universe", FlatMapKind, flux.MustValue(flux.FunctionValue(FlatMapKind, createFlatMapOpSpec, flatMapSignature)))
+	plan.RegisterProcedureSpec(FlatMapKind, newFlatMapProcedure, FlatMapKind)
+	execute.RegisterTransformation(FlatMapKind, createFlatMapTransformation)
+}
+
+func createFlatMapOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(FlatMapOpSpec)
+	if f, err := args.GetRequiredFunction("fn"); err != nil {
+		return nil, err
+	} else {
+		fn, err := interpreter.ResolveFunction(f)
+		if err != nil {
+			return nil, err
+		}
+		spec.Fn = fn
+	}
+	return spec, nil
+}
+
+func (s *FlatMapOpSpec) Kind() flux.OperationKind {
+	return FlatMapKind
+}
+
+type FlatMapProcedureSpec struct {
+	plan.DefaultCost
+	Fn interpreter.ResolvedFunction `json:"fn"`
+}
+
+func newFlatMapProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*FlatMapOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+
+	return &FlatMapProcedureSpec{
+		Fn: spec.Fn,
+	}, nil
+}
+
+func (s *FlatMapProcedureSpec) Kind() plan.ProcedureKind {
+	return FlatMapKind
+}
+
+func (s *FlatMapProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(FlatMapProcedureSpec)
+	*ns = *s
+	ns.Fn = s.Fn.Copy()
+	return ns
+}
+
+func createFlatMapTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*FlatMapProcedureSpec)
+	if !ok {
+		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
+	}
+
+	return newFlatMapTransformation(a.Context(), id, s, a.Allocator())
+}
+
+func newFlatMapTransformation(ctx context.Context, id execute.DatasetID, spec *FlatMapProcedureSpec, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	tr := &flatMapTransformation{
+		mapTransformation: mapTransformation{
+			ctx: ctx,
+			fn: &flatMapRowFunc{
+				fn: execute.NewRowFlatMapFn(
+					spec.Fn.Fn,
+					compiler.ToScope(spec.Fn.Scope),
+				),
+			},
+		},
+	}
+	return execute.NewGroupTransformation(id, tr, mem)
+}
+
+// flatMapTransformation is a map transformation where each input row
+// produces any number of output rows. The output rows are regrouped
+// the same way as the output of map.
+type flatMapTransformation struct {
+	mapTransformation
+}
+
+func (m *flatMapTransformation) Process(
+	chunk table.Chunk,
+	d *execute.TransportDataset,
+	mem memory.Allocator,
+) error {
+	if chunk.Len() == 0 {
+		return nil
+	}
+
+	fn, err := m.fn.Prepare(chunk.Cols())
+	if err != nil {
+		return err
+	}
+
+	cols, arrs, err := fn.Eval(m.ctx, chunk, mem)
+	if err != nil {
+		return err
+	} else if len(arrs) == 0 || arrs[0].Len() == 0 {
+		// None of the input rows produced an output row.
+		for _, arr := range arrs {
+			arr.Release()
+		}
+		return nil
+	}
+	return m.regroup(cols, chunk.Key(), arrs, d, mem)
+}
+
+type flatMapRowFunc struct {
+	fn *execute.RowFlatMapFn
+}
+
+func (m *flatMapRowFunc) Prepare(cols []flux.ColMeta) (mapPreparedFunc, error) {
+	fn, err := m.fn.Prepare(cols)
+	if err != nil {
+		return nil, err
+	}
+	return &flatMapRowPreparedFunc{
+		fn: fn,
+	}, nil
+}
+
+type flatMapRowPreparedFunc struct {
+	fn *execute.RowFlatMapPreparedFn
+}
+
+func (m *flatMapRowPreparedFunc) Eval(ctx context.Context, chunk table.Chunk, mem memory.Allocator) ([]flux.ColMeta, []array.Array, error) {
+	var (
+		cols     []flux.ColMeta
+		builders []array.Builder
+	)
+
+	buffer := chunk.Buffer()
+	for i, n := 0, chunk.Len(); i < n; i++ {
+		res, err := m.fn.Eval(ctx, i, &buffer)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, codes.Invalid, "failed to evaluate flatMap function")
+		}
+
+		for j, l := 0, res.Len(); j < l; j++ {
+			record := res.Get(j).Object()
+			if builders == nil {
+				cols, err = createMapSchema(m.fn.Type(), record)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				builders = make([]array.Builder, len(cols))
+				for k, col := range cols {
+					builders[k] = arrow.NewBuilder(col.Type, mem)
+				}
+			}
+
+			for k, col := range cols {
+				v, _ := record.Get(col.Label)
+				if err := arrow.AppendValue(builders[k], v); err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+	}
+
+	arrs := make([]array.Array, len(builders))
+	for i, b := range builders {
+		arrs[i] = b.NewArray()
+	}
+	return cols, arrs, nil
+}
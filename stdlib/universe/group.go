@@ -10,6 +10,7 @@ import (
 	"github.com/influxdata/flux/arrow"
 	"github.com/influxdata/flux/codes"
 	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/internal/arrowutil"
 	"github.com/influxdata/flux/internal/errors"
 	"github.com/influxdata/flux/internal/execute/dataset"
 	"github.com/influxdata/flux/internal/execute/table"
@@ -291,50 +292,8 @@ func (t *groupTransformation) appendTable(ab *table.BufferedBuilder, tbl flux.Ta
 }
 
 func (t *groupTransformation) groupChunkByRow(tbl table.Chunk, d *execute.TransportDataset, mem arrowmem.Allocator) error {
-	var on map[string]bool
-	switch t.mode {
-	case flux.GroupModeBy:
-		on = make(map[string]bool, len(t.keys))
-		for _, key := range t.keys {
-			on[key] = true
-		}
-	case flux.GroupModeExcept:
-		on = make(map[string]bool, len(tbl.Cols()))
-		for _, c := range tbl.Cols() {
-			if !execute.ContainsStr(t.keys, c.Label) {
-				on[c.Label] = true
-			}
-		}
-	}
-
-	// Construct a builder cache for the built tables.
-	cache := table.BuilderCache{
-		New: func(key flux.GroupKey) table.Builder {
-			return table.NewArrowBuilder(key, mem)
-		},
-	}
 	buffer := tbl.Buffer()
-	for i, l := 0, buffer.Len(); i < l; i++ {
-		key := execute.GroupKeyForRowOn(i, &buffer, on)
-		ab, created := table.GetArrowBuilder(key, &cache)
-		if created {
-			for _, c := range buffer.Cols() {
-				_, _ = ab.AddCol(c)
-			}
-		}
-		for j := range buffer.Cols() {
-			if err := t.appendValueFromRow(ab.Builders[j], &buffer, i, j); err != nil {
-				return err
-			}
-		}
-	}
-
-	// Pass a view of each table we grouped to the downstream datasets.
-	return cache.ForEach(func(key flux.GroupKey, builder table.Builder) error {
-		buf, err := builder.(*table.ArrowBuilder).Buffer()
-		if err != nil {
-			return err
-		}
+	return t.partitionRows(&buffer, mem, func(buf arrow.TableBuffer) error {
 		return d.Process(table.ChunkFromBuffer(buf))
 	})
 }
@@ -342,115 +301,111 @@ func (t *groupTransformation) groupChunkByRow(tbl table.Chunk, d *execute.Transp
 // groupByRow will determine which table each row belongs to
 // and to append them to that table.
 func (t *groupTransformation) groupByRow(tbl flux.Table) error {
-	var on map[string]bool
-	switch t.mode {
-	case flux.GroupModeBy:
-		on = make(map[string]bool, len(t.keys))
-		for _, key := range t.keys {
-			on[key] = true
-		}
-	case flux.GroupModeExcept:
-		on = make(map[string]bool, len(tbl.Cols()))
-		for _, c := range tbl.Cols() {
+	return tbl.Do(func(cr flux.ColReader) error {
+		return t.partitionRows(cr, t.mem, func(buf arrow.TableBuffer) error {
+			defer buf.Release()
+			ab, _ := table.GetBufferedBuilder(buf.GroupKey, &t.cache)
+			return ab.AppendBuffer(&buf)
+		})
+	})
+}
+
+// rowGroup contains the rows of a buffer that belong to the same group key.
+type rowGroup struct {
+	key     flux.GroupKey
+	indices []int64
+}
+
+// partitionRows determines the group key of each row in the column reader
+// and passes a buffer with the rows of each group to the function.
+//
+// The group key columns of each row are encoded and hashed into an index of the
+// groups so the group key is only constructed once for each group. When a group
+// consists of a contiguous run of rows, the columns are sliced from the input
+// instead of copied. Ownership of the buffer is passed to the function.
+func (t *groupTransformation) partitionRows(cr flux.ColReader, mem arrowmem.Allocator, fn func(buf arrow.TableBuffer) error) error {
+	var on []int
+	for j, c := range cr.Cols() {
+		switch t.mode {
+		case flux.GroupModeBy:
+			if execute.ContainsStr(t.keys, c.Label) {
+				on = append(on, j)
+			}
+		case flux.GroupModeExcept:
 			if !execute.ContainsStr(t.keys, c.Label) {
-				on[c.Label] = true
+				on = append(on, j)
 			}
 		}
 	}
 
-	// Construct a builder cache for the built tables.
-	cache := table.BuilderCache{
-		New: func(key flux.GroupKey) table.Builder {
-			return table.NewArrowBuilder(key, t.mem)
-		},
+	keyArrs := make([]array.Array, len(on))
+	for i, j := range on {
+		keyArrs[i] = table.Values(cr, j)
 	}
-	if err := tbl.Do(func(cr flux.ColReader) error {
-		for i, l := 0, cr.Len(); i < l; i++ {
-			key := execute.GroupKeyForRowOn(i, cr, on)
-			ab, created := table.GetArrowBuilder(key, &cache)
-			if created {
-				for _, c := range cr.Cols() {
-					_, _ = ab.AddCol(c)
-				}
-			}
-			for j := range cr.Cols() {
-				if err := t.appendValueFromRow(ab.Builders[j], cr, i, j); err != nil {
-					return err
-				}
+
+	var (
+		groups []*rowGroup
+		index  = make(map[string]int)
+		key    []byte
+	)
+	for i, l := 0, cr.Len(); i < l; i++ {
+		key = key[:0]
+		for _, arr := range keyArrs {
+			var err error
+			if key, err = appendValueKey(key, arr, i); err != nil {
+				return err
 			}
 		}
-		return nil
-	}); err != nil {
-		return err
+		idx, ok := index[string(key)]
+		if !ok {
+			idx = len(groups)
+			index[string(key)] = idx
+			groups = append(groups, &rowGroup{
+				key: t.groupKeyForRow(cr, on, i),
+			})
+		}
+		groups[idx].indices = append(groups[idx].indices, int64(i))
 	}
 
-	return cache.ForEach(func(key flux.GroupKey, builder table.Builder) error {
-		tbl, err := builder.Table()
-		if err != nil {
-			return err
+	for _, gr := range groups {
+		buf := arrow.TableBuffer{
+			GroupKey: gr.key,
+			Columns:  cr.Cols(),
+			Values:   make([]array.Array, len(cr.Cols())),
 		}
-
-		ab, _ := table.GetBufferedBuilder(key, &t.cache)
-		return t.appendTable(ab, tbl)
-	})
-}
-
-func (t *groupTransformation) appendValueFromRow(b array.Builder, cr flux.ColReader, i, j int) error {
-	switch cr.Cols()[j].Type {
-	case flux.TInt:
-		b := b.(*array.IntBuilder)
-		vs := cr.Ints(j)
-		if vs.IsNull(i) {
-			b.AppendNull()
-		} else {
-			b.Append(vs.Value(i))
-		}
-	case flux.TUInt:
-		b := b.(*array.UintBuilder)
-		vs := cr.UInts(j)
-		if vs.IsNull(i) {
-			b.AppendNull()
-		} else {
-			b.Append(vs.Value(i))
-		}
-	case flux.TFloat:
-		b := b.(*array.FloatBuilder)
-		vs := cr.Floats(j)
-		if vs.IsNull(i) {
-			b.AppendNull()
-		} else {
-			b.Append(vs.Value(i))
-		}
-	case flux.TString:
-		b := b.(*array.StringBuilder)
-		vs := cr.Strings(j)
-		if vs.IsNull(i) {
-			b.AppendNull()
-		} else {
-			b.Append(vs.Value(i))
-		}
-	case flux.TBool:
-		b := b.(*array.BooleanBuilder)
-		vs := cr.Bools(j)
-		if vs.IsNull(i) {
-			b.AppendNull()
+		if start, stop := gr.indices[0], gr.indices[len(gr.indices)-1]+1; stop-start == int64(len(gr.indices)) {
+			for j := range buf.Values {
+				buf.Values[j] = arrow.Slice(table.Values(cr, j), start, stop)
+			}
 		} else {
-			b.Append(vs.Value(i))
+			b := arrowutil.NewIntBuilder(mem)
+			b.AppendValues(gr.indices, nil)
+			indices := b.NewIntArray()
+			b.Release()
+			for j := range buf.Values {
+				buf.Values[j] = arrowutil.CopyByIndex(table.Values(cr, j), indices, mem)
+			}
+			indices.Release()
 		}
-	case flux.TTime:
-		b := b.(*array.IntBuilder)
-		vs := cr.Times(j)
-		if vs.IsNull(i) {
-			b.AppendNull()
-		} else {
-			b.Append(vs.Value(i))
+		if err := fn(buf); err != nil {
+			return err
 		}
-	default:
-		return errors.New(codes.Internal, "invalid builder type")
 	}
 	return nil
 }
 
+// groupKeyForRow constructs the group key from the columns
+// at the given indices for a row.
+func (t *groupTransformation) groupKeyForRow(cr flux.ColReader, on []int, i int) flux.GroupKey {
+	cols := make([]flux.ColMeta, len(on))
+	vs := make([]values.Value, len(on))
+	for k, j := range on {
+		cols[k] = cr.Cols()[j]
+		vs[k] = execute.ValueForRow(cr, i, j)
+	}
+	return execute.NewGroupKey(cols, vs)
+}
+
 func (t *groupTransformation) UpdateWatermark(id execute.DatasetID, ts execute.Time) error {
 	return t.d.UpdateWatermark(ts)
 }
@@ -753,6 +753,50 @@ func TestGroup_Process(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "bytes key",
+			spec: &universe.GroupProcedureSpec{
+				GroupMode: flux.GroupModeBy,
+				GroupKeys: []string{"id"},
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+					{Label: "id", Type: flux.TBytes},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.0, []byte("a")},
+					{execute.Time(2), 2.0, []byte("b")},
+					{execute.Time(3), 3.0, []byte("a")},
+				},
+			}},
+			want: []*executetest.Table{
+				{
+					KeyCols: []string{"id"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "id", Type: flux.TBytes},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 1.0, []byte("a")},
+						{execute.Time(3), 3.0, []byte("a")},
+					},
+				},
+				{
+					KeyCols: []string{"id"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "id", Type: flux.TBytes},
+					},
+					Data: [][]interface{}{
+						{execute.Time(2), 2.0, []byte("b")},
+					},
+				},
+			},
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -982,6 +1026,20 @@ func benchmarkGroupByKey(b *testing.B, n int) {
 	benchmarkGroup(b, n, spec)
 }
 
+func BenchmarkGroup_ByRow_1000(b *testing.B) {
+	benchmarkGroupByRow(b, 1000)
+}
+
+func benchmarkGroupByRow(b *testing.B, n int) {
+	// Grouping by a column that is not part of the group key
+	// requires inspecting each row to determine its group.
+	spec := &universe.GroupProcedureSpec{
+		GroupMode: flux.GroupModeBy,
+		GroupKeys: []string{"_field", execute.DefaultTimeColLabel},
+	}
+	benchmarkGroup(b, n, spec)
+}
+
 func benchmarkGroup(b *testing.B, n int, spec *universe.GroupProcedureSpec) {
 	b.ReportAllocs()
 	executetest.ProcessBenchmarkHelper(b,
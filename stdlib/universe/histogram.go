@@ -10,6 +10,7 @@ import (
 	"github.com/influxdata/flux/codes"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/internal/histogram"
 	"github.com/influxdata/flux/interpreter"
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/runtime"
@@ -178,11 +179,9 @@ func (t *histogramTransformation) Process(id execute.DatasetID, tbl flux.Table)
 	if err != nil {
 		return err
 	}
-	totalRows := 0.0
 	counts := make([]float64, len(t.spec.Bins))
 	err = tbl.Do(func(cr flux.ColReader) error {
 		vs := cr.Floats(valueIdx)
-		totalRows += float64(vs.Len() - vs.NullN())
 		for i := 0; i < vs.Len(); i++ {
 			if vs.IsNull(i) {
 				continue
@@ -205,23 +204,20 @@ func (t *histogramTransformation) Process(id execute.DatasetID, tbl flux.Table)
 		return err
 	}
 
-	// Add records making counts cumulative
-	total := 0.0
-	for i, v := range counts {
+	h := histogram.FromCounts(t.spec.Bins, counts)
+	if t.spec.Normalize {
+		h.Normalize()
+	}
+	for _, b := range h.Buckets {
 		if err := execute.AppendKeyValues(tbl.Key(), builder); err != nil {
 			return err
 		}
-		count := v + total
-		if t.spec.Normalize {
-			count /= totalRows
-		}
-		if err := builder.AppendFloat(countIdx, count); err != nil {
+		if err := builder.AppendFloat(countIdx, b.Count); err != nil {
 			return err
 		}
-		if err := builder.AppendFloat(boundIdx, t.spec.Bins[i]); err != nil {
+		if err := builder.AppendFloat(boundIdx, b.UpperBound); err != nil {
 			return err
 		}
-		total += v
 	}
 	return nil
 }
@@ -1,13 +1,11 @@
 package universe
 
 import (
-	"math"
-	"sort"
-
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/codes"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/internal/histogram"
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/runtime"
 )
@@ -120,11 +118,6 @@ type histogramQuantileTransformation struct {
 	spec HistogramQuantileProcedureSpec
 }
 
-type bucket struct {
-	count      float64
-	upperBound float64
-}
-
 func createHistogramQuantileTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
 	s, ok := spec.(*HistogramQuantileProcedureSpec)
 	if !ok {
@@ -185,14 +178,13 @@ func (t histogramQuantileTransformation) Process(id execute.DatasetID, tbl flux.
 		return errors.Newf(codes.FailedPrecondition, "upper bound column %q must be of type float", t.spec.UpperBoundColumn)
 	}
 	// Read buckets
-	var cdf []bucket
-	sorted := true // track if the cdf was naturally sorted
+	var cdf []histogram.Bucket
 	if err := tbl.Do(func(cr flux.ColReader) error {
 		offset := len(cdf)
 		// Grow cdf by number of rows
 		l := offset + cr.Len()
 		if cap(cdf) < l {
-			cpy := make([]bucket, l, l*2)
+			cpy := make([]histogram.Bucket, l, l*2)
 			// Copy existing buckets to new slice
 			copy(cpy, cdf)
 			cdf = cpy
@@ -200,37 +192,25 @@ func (t histogramQuantileTransformation) Process(id execute.DatasetID, tbl flux.
 			cdf = cdf[:l]
 		}
 		for i := 0; i < cr.Len(); i++ {
-			curr := i + offset
-			prev := curr - 1
-
-			b := bucket{}
+			b := histogram.Bucket{}
 			if vs := cr.Floats(countIdx); vs.IsValid(i) {
-				b.count = vs.Value(i)
+				b.Count = vs.Value(i)
 			} else {
 				return errors.Newf(codes.FailedPrecondition, "unexpected null in the countColumn")
 			}
 			if vs := cr.Floats(upperBoundIdx); vs.IsValid(i) {
-				b.upperBound = vs.Value(i)
+				b.UpperBound = vs.Value(i)
 			} else {
 				return errors.Newf(codes.FailedPrecondition, "unexpected null in the upperBoundColumn")
 			}
-			cdf[curr] = b
-			if prev >= 0 {
-				sorted = sorted && cdf[prev].upperBound <= cdf[curr].upperBound
-			}
+			cdf[i+offset] = b
 		}
 		return nil
 	}); err != nil {
 		return err
 	}
 
-	if !sorted {
-		sort.Slice(cdf, func(i, j int) bool {
-			return cdf[i].upperBound < cdf[j].upperBound
-		})
-	}
-
-	q, err := t.computeQuantile(cdf)
+	q, err := histogram.FromBuckets(cdf).Quantile(t.spec.Quantile, t.spec.MinValue)
 	if err != nil {
 		return err
 	}
@@ -243,64 +223,6 @@ func (t histogramQuantileTransformation) Process(id execute.DatasetID, tbl flux.
 	return nil
 }
 
-func (t *histogramQuantileTransformation) computeQuantile(cdf []bucket) (float64, error) {
-	if len(cdf) == 0 {
-		return 0, errors.New(codes.FailedPrecondition, "histogram is empty")
-	}
-	// Find rank index and check counts are monotonic
-	prevCount := 0.0
-	totalCount := cdf[len(cdf)-1].count
-	rank := t.spec.Quantile * totalCount
-	rankIdx := -1
-	for i, b := range cdf {
-		if b.count < prevCount {
-			return 0, errors.New(codes.FailedPrecondition, "histogram records counts are not monotonic")
-		}
-		prevCount = b.count
-
-		if rank >= b.count {
-			rankIdx = i
-		}
-	}
-	var (
-		lowerCount,
-		lowerBound,
-		upperCount,
-		upperBound float64
-	)
-	switch rankIdx {
-	case -1:
-		// Quantile is below the lowest upper bound, interpolate using the min value
-		lowerCount = 0
-		lowerBound = t.spec.MinValue
-		upperCount = cdf[0].count
-		upperBound = cdf[0].upperBound
-	case len(cdf) - 1:
-		// Quantile is above the highest upper bound, simply return it as it must be finite
-		return cdf[len(cdf)-1].upperBound, nil
-	default:
-		lowerCount = cdf[rankIdx].count
-		lowerBound = cdf[rankIdx].upperBound
-		upperCount = cdf[rankIdx+1].count
-		upperBound = cdf[rankIdx+1].upperBound
-	}
-	if rank == lowerCount {
-		// No need to interpolate
-		return lowerBound, nil
-	}
-	if math.IsInf(lowerBound, -1) {
-		// We cannot interpolate with infinity
-		return upperBound, nil
-	}
-	if math.IsInf(upperBound, 1) {
-		// We cannot interpolate with infinity
-		return lowerBound, nil
-	}
-	// Compute quantile using linear interpolation
-	scale := (rank - lowerCount) / (upperCount - lowerCount)
-	return lowerBound + (upperBound-lowerBound)*scale, nil
-}
-
 func (t histogramQuantileTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
 	return t.d.UpdateWatermark(mark)
 }
@@ -0,0 +1,484 @@
+package universe
+
+import (
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/arrowutil"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/runtime"
+)
+
+const (
+	LagKind  = "lag"
+	LeadKind = "lead"
+)
+
+// LagOpSpec is the operation spec for both lag() and lead().
+type LagOpSpec struct {
+	N      int64  `json:"n"`
+	Column string `json:"column"`
+	As     string `json:"as"`
+	Lead   bool   `json:"lead,omitempty"`
+}
+
+func init() {
+	lagSignature := runtime.MustLookupBuiltinType("universe", LagKind)
+	runtime.RegisterPackageValue("universe", LagKind, flux.MustValue(flux.FunctionValue(LagKind, createLagOpSpec, lagSignature)))
+
+	leadSignature := runtime.MustLookupBuiltinType("universe", LeadKind)
+	runtime.RegisterPackageValue("universe", LeadKind, flux.MustValue(flux.FunctionValue(LeadKind, createLeadOpSpec, leadSignature)))
+
+	plan.RegisterProcedureSpec(LagKind, newLagProcedure, LagKind, LeadKind)
+	execute.RegisterTransformation(LagKind, createLagTransformation)
+}
+
+func createLagOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	return newLagOpSpec(args, a, false)
+}
+
+func createLeadOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	return newLagOpSpec(args, a, true)
+}
+
+func newLagOpSpec(args flux.Arguments, a *flux.Administration, lead bool) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := &LagOpSpec{Lead: lead}
+	if n, ok, err := args.GetInt("n"); err != nil {
+		return nil, err
+	} else if ok {
+		if n <= 0 {
+			return nil, errors.Newf(codes.Invalid, "%s n must be greater than zero, got %d", spec.Kind(), n)
+		}
+		spec.N = n
+	} else {
+		spec.N = 1
+	}
+
+	if col, ok, err := args.GetString("column"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Column = col
+	} else {
+		spec.Column = execute.DefaultValueColLabel
+	}
+
+	if as, ok, err := args.GetString("as"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.As = as
+	} else {
+		spec.As = spec.Column
+	}
+	return spec, nil
+}
+
+func (s *LagOpSpec) Kind() flux.OperationKind {
+	if s.Lead {
+		return LeadKind
+	}
+	return LagKind
+}
+
+type LagProcedureSpec struct {
+	plan.DefaultCost
+	N      int64
+	Column string
+	As     string
+	Lead   bool
+}
+
+func newLagProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*LagOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+
+	return &LagProcedureSpec{
+		N:      spec.N,
+		Column: spec.Column,
+		As:     spec.As,
+		Lead:   spec.Lead,
+	}, nil
+}
+
+func (s *LagProcedureSpec) Kind() plan.ProcedureKind {
+	return LagKind
+}
+
+func (s *LagProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+// TriggerSpec implements plan.TriggerAwareProcedureSpec
+func (s *LagProcedureSpec) TriggerSpec() plan.TriggerSpec {
+	return plan.NarrowTransformationTriggerSpec{}
+}
+
+func createLagTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*LagProcedureSpec)
+	if !ok {
+		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
+	}
+	if s.Lead {
+		return NewLeadTransformation(id, s, a.Allocator())
+	}
+	return NewLagTransformation(id, s, a.Allocator())
+}
+
+// lagTransformation sets a column to the value of a column
+// n rows before the current row of the table.
+//
+// The last n values of the column are retained between chunks
+// of the same table so no other part of the table is buffered.
+type lagTransformation struct {
+	n      int
+	column string
+	as     string
+}
+
+// lagState contains the last n values of the column in a table.
+type lagState struct {
+	typ  flux.ColType
+	tail array.Array
+}
+
+func (s *lagState) Close() error {
+	if s.tail != nil {
+		s.tail.Release()
+		s.tail = nil
+	}
+	return nil
+}
+
+func NewLagTransformation(id execute.DatasetID, spec *LagProcedureSpec, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	tr := &lagTransformation{
+		n:      int(spec.N),
+		column: spec.Column,
+		as:     spec.As,
+	}
+	return execute.NewNarrowStateTransformation[*lagState](id, tr, mem)
+}
+
+func (t *lagTransformation) Process(chunk table.Chunk, state *lagState, d *execute.TransportDataset, mem memory.Allocator) (*lagState, bool, error) {
+	idx := chunk.Index(t.column)
+	if idx < 0 {
+		return nil, false, errors.Newf(codes.FailedPrecondition, "lag: column %q does not exist", t.column)
+	} else if chunk.Key().HasCol(t.as) {
+		return nil, false, errors.Newf(codes.FailedPrecondition, "lag: cannot set group key column %q", t.as)
+	}
+	typ := chunk.Col(idx).Type
+	if state == nil {
+		state = &lagState{typ: typ}
+	} else if state.typ != typ {
+		return nil, false, errors.Newf(codes.FailedPrecondition, "schema collision detected: column \"%s\" is both of type %s and %s", t.column, typ, state.typ)
+	}
+
+	// The output value of a row is the value n rows before it
+	// in the tail of the previous chunks followed by this chunk.
+	arr := chunk.Values(idx)
+	tailLen := 0
+	if state.tail != nil {
+		tailLen = state.tail.Len()
+	}
+	l, total := chunk.Len(), tailLen+chunk.Len()
+
+	b := arrow.NewBuilder(typ, mem)
+	b.Resize(l)
+	nulls := t.n - tailLen
+	if nulls > l {
+		nulls = l
+	}
+	for i := 0; i < nulls; i++ {
+		b.AppendNull()
+	}
+	if end := total - t.n; end > 0 {
+		appendRows(b, state.tail, arr, 0, end)
+	}
+	lagged := b.NewArray()
+
+	start := total - t.n
+	if start < 0 {
+		start = 0
+	}
+	tb := arrow.NewBuilder(typ, mem)
+	tb.Resize(total - start)
+	appendRows(tb, state.tail, arr, start, total)
+	_ = state.Close()
+	state.tail = tb.NewArray()
+
+	out := withColumn(chunk, t.as, typ, lagged)
+	if err := d.Process(out); err != nil {
+		return nil, false, err
+	}
+	return state, true, nil
+}
+
+func (t *lagTransformation) Close() error {
+	return nil
+}
+
+// leadTransformation sets a column to the value of a column
+// n rows after the current row of the table.
+//
+// The last n rows of each chunk are held back until the rows
+// that follow them have been read. The rows that are held back
+// when the table ends have no following rows and are sent with
+// a null value.
+type leadTransformation struct {
+	d      *execute.TransportDataset
+	mem    memory.Allocator
+	n      int
+	column string
+	as     string
+}
+
+// leadState contains the rows of a table that are held back.
+type leadState struct {
+	key  flux.GroupKey
+	cols []flux.ColMeta
+	rows []array.Array
+}
+
+func (s *leadState) len() int {
+	if len(s.rows) == 0 {
+		return 0
+	}
+	return s.rows[0].Len()
+}
+
+func (s *leadState) release() {
+	for _, arr := range s.rows {
+		arr.Release()
+	}
+	s.rows = nil
+}
+
+func NewLeadTransformation(id execute.DatasetID, spec *LagProcedureSpec, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	t := &leadTransformation{
+		d:      execute.NewTransportDataset(id, mem),
+		mem:    mem,
+		n:      int(spec.N),
+		column: spec.Column,
+		as:     spec.As,
+	}
+	return execute.NewTransformationFromTransport(t), t.d, nil
+}
+
+func (t *leadTransformation) ProcessMessage(m execute.Message) error {
+	defer m.Ack()
+
+	switch m := m.(type) {
+	case execute.FinishMsg:
+		t.finish(m.Error())
+		return nil
+	case execute.ProcessChunkMsg:
+		return t.processChunk(m.TableChunk())
+	case execute.FlushKeyMsg:
+		if err := t.flush(m.Key()); err != nil {
+			return err
+		}
+		return t.d.FlushKey(m.Key())
+	}
+	return nil
+}
+
+func (t *leadTransformation) processChunk(chunk table.Chunk) error {
+	idx := chunk.Index(t.column)
+	if idx < 0 {
+		return errors.Newf(codes.FailedPrecondition, "lead: column %q does not exist", t.column)
+	}
+
+	state := t.d.LookupOrCreate(chunk.Key(), func() interface{} {
+		return &leadState{key: chunk.Key(), cols: chunk.Cols()}
+	}).(*leadState)
+	if !sameColumns(state.cols, chunk.Cols()) {
+		return errors.New(codes.FailedPrecondition, "lead: the schema of a table changed between chunks")
+	} else if chunk.Key().HasCol(t.as) {
+		return errors.Newf(codes.FailedPrecondition, "lead: cannot set group key column %q", t.as)
+	}
+
+	// The rows of the output are the held back rows followed by
+	// the rows of this chunk except for the last n rows.
+	pending := state.len()
+	total := pending + chunk.Len()
+	end := total - t.n
+	if end < 0 {
+		end = 0
+	}
+
+	rows := make([]array.Array, chunk.NCols())
+	var values []array.Array
+	if end > 0 {
+		values = make([]array.Array, chunk.NCols())
+	}
+	for j, col := range chunk.Cols() {
+		var head array.Array
+		if pending > 0 {
+			head = state.rows[j]
+		}
+		arr := chunk.Values(j)
+		if end > 0 {
+			b := arrow.NewBuilder(col.Type, t.mem)
+			b.Resize(end)
+			appendRows(b, head, arr, 0, end)
+			values[j] = b.NewArray()
+		}
+		b := arrow.NewBuilder(col.Type, t.mem)
+		b.Resize(total - end)
+		appendRows(b, head, arr, end, total)
+		rows[j] = b.NewArray()
+	}
+
+	var led array.Array
+	if end > 0 {
+		typ := chunk.Col(idx).Type
+		b := arrow.NewBuilder(typ, t.mem)
+		b.Resize(end)
+		var head array.Array
+		if pending > 0 {
+			head = state.rows[idx]
+		}
+		appendRows(b, head, chunk.Values(idx), t.n, t.n+end)
+		led = b.NewArray()
+	}
+
+	state.release()
+	state.rows = rows
+	if end == 0 {
+		return nil
+	}
+	return t.process(state, values, led)
+}
+
+// process sends the rows in the values with the lead column.
+func (t *leadTransformation) process(state *leadState, values []array.Array, led array.Array) error {
+	buffer := arrow.TableBuffer{
+		GroupKey: state.key,
+		Columns:  state.cols,
+		Values:   values,
+	}
+	chunk := table.ChunkFromBuffer(buffer)
+	typ := state.cols[chunk.Index(t.column)].Type
+	out := withColumn(chunk, t.as, typ, led)
+	chunk.Release()
+	return t.d.Process(out)
+}
+
+// flush sends the held back rows of a table with null values.
+func (t *leadTransformation) flush(key flux.GroupKey) error {
+	v, ok := t.d.Delete(key)
+	if !ok {
+		return nil
+	}
+	state := v.(*leadState)
+	n := state.len()
+	if n == 0 {
+		return nil
+	}
+	typ := state.cols[execute.ColIdx(t.column, state.cols)].Type
+	values := state.rows
+	state.rows = nil
+	return t.process(state, values, arrow.Nulls(typ, n, t.mem))
+}
+
+func (t *leadTransformation) finish(err error) {
+	if err == nil {
+		var keys []flux.GroupKey
+		_ = t.d.Range(func(key flux.GroupKey, value interface{}) error {
+			keys = append(keys, key)
+			return nil
+		})
+		for _, key := range keys {
+			if err = t.flush(key); err != nil {
+				break
+			}
+		}
+	}
+	_ = t.d.Range(func(key flux.GroupKey, value interface{}) error {
+		value.(*leadState).release()
+		return nil
+	})
+	t.d.Finish(err)
+}
+
+func sameColumns(a, b []flux.ColMeta) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// appendRows appends the rows from i to j of the head array
+// followed by the tail array to the builder.
+// The head array may be nil.
+func appendRows(b array.Builder, head, tail array.Array, i, j int) {
+	n := 0
+	if head != nil {
+		n = head.Len()
+	}
+	if i < n {
+		end := j
+		if end > n {
+			end = n
+		}
+		arr := arrow.Slice(head, int64(i), int64(end))
+		arrowutil.CopyTo(b, arr)
+		arr.Release()
+	}
+	if j > n {
+		start := i - n
+		if start < 0 {
+			start = 0
+		}
+		arr := arrow.Slice(tail, int64(start), int64(j-n))
+		arrowutil.CopyTo(b, arr)
+		arr.Release()
+	}
+}
+
+// withColumn returns the chunk with the column set to the values.
+// The column replaces a column with the same label or is added
+// after the last column. The values are owned by the returned chunk.
+func withColumn(chunk table.Chunk, label string, typ flux.ColType, values array.Array) table.Chunk {
+	idx := chunk.Index(label)
+	n := chunk.NCols()
+	if idx < 0 {
+		n++
+	}
+	buffer := arrow.TableBuffer{
+		GroupKey: chunk.Key(),
+		Columns:  make([]flux.ColMeta, 0, n),
+		Values:   make([]array.Array, 0, n),
+	}
+	for j, col := range chunk.Cols() {
+		if j == idx {
+			buffer.Columns = append(buffer.Columns, flux.ColMeta{Label: label, Type: typ})
+			buffer.Values = append(buffer.Values, values)
+			continue
+		}
+		arr := chunk.Values(j)
+		arr.Retain()
+		buffer.Columns = append(buffer.Columns, col)
+		buffer.Values = append(buffer.Values, arr)
+	}
+	if idx < 0 {
+		buffer.Columns = append(buffer.Columns, flux.ColMeta{Label: label, Type: typ})
+		buffer.Values = append(buffer.Values, values)
+	}
+	return table.ChunkFromBuffer(buffer)
+}
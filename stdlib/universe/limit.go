@@ -3,8 +3,6 @@ package universe
 import (
 	arrowmem "github.com/apache/arrow/go/v7/arrow/memory"
 	"github.com/influxdata/flux"
-	"github.com/influxdata/flux/array"
-	"github.com/influxdata/flux/arrow"
 	"github.com/influxdata/flux/codes"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/internal/errors"
@@ -143,18 +141,8 @@ func (t *limitTransformation) processChunk(
 
 	// Pass empty chunks along to downstream transformations for these cases.
 	if state.n <= 0 || chunkLen == 0 {
-		// TODO(onelson): seems like there should be a more simple way to produce an empty chunk
 		buf := chunk.Buffer()
-		buf.Values = make([]array.Array, chunk.NCols())
-		for idx := range buf.Values {
-			values := chunk.Values(idx)
-			if values.Len() == 0 {
-				values.Retain()
-			} else {
-				values = arrow.Slice(values, int64(0), int64(0))
-			}
-			buf.Values[idx] = values
-		}
+		buf = table.Slice(&buf, 0, 0)
 		out := table.ChunkFromBuffer(buf)
 		if err := dataset.Process(out); err != nil {
 			return nil, false, err
@@ -180,22 +168,7 @@ func (t *limitTransformation) processChunk(
 	state.offset = 0
 
 	buf := chunk.Buffer()
-	// XXX(onelson): seems like we're building a 2D array where the outer is by
-	// column, and the inners are the column values per row?
-	buf.Values = make([]array.Array, chunk.NCols())
-	for idx := range buf.Values {
-		values := chunk.Values(idx)
-		// If there's no cruft at the end, just keep the original array,
-		// otherwise we need to truncate it to ensure all inners have the
-		// expected size.
-		// XXX(onelson): Could there be a 3rd case where we have less than the count?
-		if values.Len() == count {
-			values.Retain()
-		} else {
-			values = arrow.Slice(values, int64(start), int64(stop))
-		}
-		buf.Values[idx] = values
-	}
+	buf = table.Slice(&buf, start, stop)
 	out := table.ChunkFromBuffer(buf)
 	if err := dataset.Process(out); err != nil {
 		return nil, false, err
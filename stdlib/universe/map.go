@@ -358,8 +358,13 @@ func (m *mapRowPreparedFunc) initialize(cols []flux.ColMeta, mem memory.Allocato
 }
 
 func (m *mapRowPreparedFunc) createSchema(record values.Object) ([]flux.ColMeta, error) {
-	returnType := m.fn.Type()
+	return createMapSchema(m.fn.Type(), record)
+}
 
+// createMapSchema creates the columns for the records returned by a map function.
+// The types of the columns are taken from the return type when it is known
+// and from the values of the record otherwise.
+func createMapSchema(returnType semantic.MonoType, record values.Object) ([]flux.ColMeta, error) {
 	numProps, err := returnType.NumProperties()
 	if err != nil {
 		return nil, err
@@ -19,7 +19,8 @@ type MaxOpSpec struct {
 func init() {
 	maxSignature := runtime.MustLookupBuiltinType("universe", "max")
 
-	runtime.RegisterPackageValue("universe", MaxKind, flux.MustValue(flux.FunctionValue(MaxKind, CreateMaxOpSpec, maxSignature)))
+	maxFunction = flux.MustValue(flux.FunctionValue(MaxKind, CreateMaxOpSpec, maxSignature))
+	runtime.RegisterPackageValue("universe", MaxKind, maxFunction)
 	plan.RegisterProcedureSpec(MaxKind, newMaxProcedure, MaxKind)
 	execute.RegisterTransformation(MaxKind, createMaxTransformation)
 }
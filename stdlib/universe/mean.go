@@ -2,6 +2,7 @@ package universe
 
 import (
 	"math"
+	"time"
 
 	arrowmath "github.com/apache/arrow/go/v7/arrow/math"
 	"github.com/influxdata/flux"
@@ -11,6 +12,7 @@ import (
 	"github.com/influxdata/flux/internal/errors"
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/runtime"
+	"github.com/influxdata/flux/values"
 )
 
 const MeanKind = "mean"
@@ -22,7 +24,8 @@ type MeanOpSpec struct {
 func init() {
 	meanSignature := runtime.MustLookupBuiltinType("universe", "mean")
 
-	runtime.RegisterPackageValue("universe", MeanKind, flux.MustValue(flux.FunctionValue(MeanKind, CreateMeanOpSpec, meanSignature)))
+	meanFunction = flux.MustValue(flux.FunctionValue(MeanKind, CreateMeanOpSpec, meanSignature))
+	runtime.RegisterPackageValue("universe", MeanKind, meanFunction)
 	plan.RegisterProcedureSpec(MeanKind, newMeanProcedure, MeanKind)
 	execute.RegisterTransformation(MeanKind, createMeanTransformation)
 }
@@ -103,6 +106,10 @@ func (a *MeanAgg) NewStringAgg() execute.DoStringAgg {
 	return nil
 }
 
+func (a *MeanAgg) NewDurationAgg() execute.DoDurationAgg {
+	return new(MeanDurationAgg)
+}
+
 func (a *MeanAgg) DoInt(vs *array.Int) {
 	if l := vs.Len() - vs.NullN(); l > 0 {
 		a.count += int64(l)
@@ -157,3 +164,22 @@ func (a *MeanAgg) ValueFloat() float64 {
 func (a *MeanAgg) IsNull() bool {
 	return a.count == 0
 }
+
+// MeanDurationAgg computes the mean of a duration column.
+// The mean is rounded to the nearest nanosecond.
+type MeanDurationAgg struct {
+	mean MeanAgg
+}
+
+func (a *MeanDurationAgg) DoDuration(vs *array.Int) {
+	a.mean.DoInt(vs)
+}
+func (a *MeanDurationAgg) Type() flux.ColType {
+	return flux.TDuration
+}
+func (a *MeanDurationAgg) ValueDuration() values.Duration {
+	return values.ConvertDurationNsecs(time.Duration(math.Round(a.mean.ValueFloat())))
+}
+func (a *MeanDurationAgg) IsNull() bool {
+	return a.mean.IsNull()
+}
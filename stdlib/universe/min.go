@@ -19,7 +19,8 @@ type MinOpSpec struct {
 func init() {
 	minSignature := runtime.MustLookupBuiltinType("universe", "min")
 
-	runtime.RegisterPackageValue("universe", MinKind, flux.MustValue(flux.FunctionValue(MinKind, CreateMinOpSpec, minSignature)))
+	minFunction = flux.MustValue(flux.FunctionValue(MinKind, CreateMinOpSpec, minSignature))
+	runtime.RegisterPackageValue("universe", MinKind, minFunction)
 	plan.RegisterProcedureSpec(MinKind, newMinProcedure, MinKind)
 	execute.RegisterTransformation(MinKind, createMinTransformation)
 }
@@ -0,0 +1,399 @@
+package universe
+
+import (
+	"encoding/binary"
+	"math"
+
+	arrowmemory "github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/arrowutil"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/values"
+)
+
+// columnarPivotTransformation is an implementation of pivot that
+// builds the output columns directly instead of building the
+// output table one value at a time.
+//
+// The row key and column key of each row are encoded into bytes
+// and looked up in a hash map. The columns that are copied from the input
+// are appended to builders when a new row key is found, and the values of each
+// pivoted column are appended to a builder along with the row they belong to.
+// When the input is finished, each pivoted column is built with a builder that
+// is sized for the number of rows.
+type columnarPivotTransformation struct {
+	execute.ExecutionNode
+	d     *execute.PassthroughDataset
+	mem   arrowmemory.Allocator
+	spec  PivotProcedureSpec
+	isKey map[string]bool
+
+	// groups contains the *columnarPivotGroup for each output group key.
+	groups *execute.GroupLookup
+}
+
+// NewColumnarPivotTransformation creates a pivot transformation that builds
+// the output columns directly from the input arrays.
+func NewColumnarPivotTransformation(spec *PivotProcedureSpec, id execute.DatasetID, mem arrowmemory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	t := &columnarPivotTransformation{
+		d:      execute.NewPassthroughDataset(id),
+		mem:    mem,
+		spec:   *spec,
+		isKey:  make(map[string]bool, len(spec.ColumnKey)+1),
+		groups: execute.NewGroupLookup(),
+	}
+	for _, label := range spec.ColumnKey {
+		t.isKey[label] = true
+	}
+	t.isKey[spec.ValueColumn] = true
+	return t, t.d, nil
+}
+
+// columnarPivotGroup holds the output of a single group key.
+type columnarPivotGroup struct {
+	// cols are the columns that are copied from the input.
+	// These are the group key columns that are kept and the row key columns.
+	cols     []flux.ColMeta
+	builders []array.Builder
+
+	// rows maps the encoded row key to the index of the row.
+	rows  map[string]int
+	nrows int
+
+	// columns maps the encoded column key to the index of the pivoted column.
+	// Labels maps the label of a pivoted column to its index so
+	// different column keys that produce the same label share the column.
+	columns map[string]int
+	labels  map[string]int
+	pivots  []*columnarPivotColumn
+}
+
+// columnarPivotColumn holds the values of a pivoted column
+// in the order that they were read.
+type columnarPivotColumn struct {
+	col    flux.ColMeta
+	values array.Builder
+	// rows contains the row of each value.
+	rows []int
+}
+
+func (t *columnarPivotTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+func (t *columnarPivotTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	rowKeyIndex := make([]int, len(t.spec.RowKey))
+	for i, label := range t.spec.RowKey {
+		idx := execute.ColIdx(label, tbl.Cols())
+		if idx < 0 {
+			return errors.Newf(codes.Invalid, "specified row key column does not exist in table: %v", label)
+		}
+		rowKeyIndex[i] = idx
+	}
+	valueIndex := execute.ColIdx(t.spec.ValueColumn, tbl.Cols())
+	if valueIndex < 0 {
+		return errors.Newf(codes.Invalid, "specified value column does not exist in table: %v", t.spec.ValueColumn)
+	}
+	valueType := tbl.Cols()[valueIndex].Type
+	colKeyIndex := make([]int, len(t.spec.ColumnKey))
+	for i, label := range t.spec.ColumnKey {
+		idx := execute.ColIdx(label, tbl.Cols())
+		if idx < 0 {
+			return errors.Newf(codes.Invalid, "specified column does not exist in table: %v", label)
+		}
+		colKeyIndex[i] = idx
+	}
+
+	// The columns that are kept are the group key columns that are not
+	// in the column key and the row key columns.
+	var (
+		cols      []flux.ColMeta
+		colMap    []int
+		keyCols   []flux.ColMeta
+		keyValues []values.Value
+	)
+	for j, c := range tbl.Cols() {
+		if t.isKey[c.Label] {
+			continue
+		}
+		if tbl.Key().HasCol(c.Label) {
+			keyCols = append(keyCols, c)
+			keyValues = append(keyValues, tbl.Key().LabelValue(c.Label))
+		} else if !t.isRowKey(c.Label) {
+			continue
+		}
+		cols = append(cols, c)
+		colMap = append(colMap, j)
+	}
+
+	key := execute.NewGroupKey(keyCols, keyValues)
+	gr := t.groups.LookupOrCreate(key, func() interface{} {
+		gr := &columnarPivotGroup{
+			cols:     cols,
+			builders: make([]array.Builder, len(cols)),
+			rows:     make(map[string]int),
+			columns:  make(map[string]int),
+			labels:   make(map[string]int),
+		}
+		for j, c := range cols {
+			gr.builders[j] = arrow.NewBuilder(c.Type, t.mem)
+		}
+		return gr
+	}).(*columnarPivotGroup)
+
+	// Tables with the same output group key may have
+	// the kept columns in a different order.
+	if !gr.hasCols(cols) {
+		colMap = make([]int, len(gr.cols))
+		for j, c := range gr.cols {
+			idx := execute.ColIdx(c.Label, tbl.Cols())
+			if idx < 0 {
+				return errors.Newf(codes.FailedPrecondition, "column %q is missing from a table in group %v", c.Label, key)
+			} else if typ := tbl.Cols()[idx].Type; typ != c.Type {
+				return errors.Newf(codes.FailedPrecondition, "schema collision detected: column %q is both of type %s and %s", c.Label, c.Type, typ)
+			}
+			colMap[j] = idx
+		}
+	}
+
+	var rowKey, colKey []byte
+	return tbl.Do(func(cr flux.ColReader) error {
+		rowKeyArrs := make([]array.Array, len(rowKeyIndex))
+		for i, j := range rowKeyIndex {
+			rowKeyArrs[i] = table.Values(cr, j)
+		}
+		colKeyArrs := make([]array.Array, len(colKeyIndex))
+		for i, j := range colKeyIndex {
+			colKeyArrs[i] = table.Values(cr, j)
+		}
+		vs := table.Values(cr, valueIndex)
+
+		for i, n := 0, cr.Len(); i < n; i++ {
+			colKey = colKey[:0]
+			for _, arr := range colKeyArrs {
+				var err error
+				if colKey, err = appendValueKey(colKey, arr, i); err != nil {
+					return err
+				}
+			}
+			pc, ok := gr.columns[string(colKey)]
+			if !ok {
+				var err error
+				if pc, err = gr.addColumn(t.columnLabel(cr, colKeyIndex, i), valueType, t.mem); err != nil {
+					return err
+				}
+				gr.columns[string(colKey)] = pc
+			}
+			p := gr.pivots[pc]
+			if p.col.Type != valueType {
+				return errors.New(codes.FailedPrecondition, "value columns with the same column key have different types")
+			}
+
+			rowKey = rowKey[:0]
+			for _, arr := range rowKeyArrs {
+				var err error
+				if rowKey, err = appendValueKey(rowKey, arr, i); err != nil {
+					return err
+				}
+			}
+			row, ok := gr.rows[string(rowKey)]
+			if !ok {
+				row = gr.nrows
+				gr.rows[string(rowKey)] = row
+				gr.nrows++
+				for j, b := range gr.builders {
+					arrowutil.CopyValue(b, table.Values(cr, colMap[j]), i)
+				}
+			}
+
+			arrowutil.CopyValue(p.values, vs, i)
+			p.rows = append(p.rows, row)
+		}
+		return nil
+	})
+}
+
+func (t *columnarPivotTransformation) isRowKey(label string) bool {
+	for _, rk := range t.spec.RowKey {
+		if rk == label {
+			return true
+		}
+	}
+	return false
+}
+
+// columnLabel computes the label of the pivoted column for a row.
+func (t *columnarPivotTransformation) columnLabel(cr flux.ColReader, colKeyIndex []int, i int) string {
+	label := ""
+	for k, j := range colKeyIndex {
+		if k > 0 {
+			label += "_"
+		}
+		label += valueToStr(cr, cr.Cols()[j], i, j)
+	}
+	return label
+}
+
+func (gr *columnarPivotGroup) hasCols(cols []flux.ColMeta) bool {
+	if len(cols) != len(gr.cols) {
+		return false
+	}
+	for j, c := range cols {
+		if c != gr.cols[j] {
+			return false
+		}
+	}
+	return true
+}
+
+// addColumn returns the index of the pivoted column with the label
+// and creates the column if it does not exist.
+func (gr *columnarPivotGroup) addColumn(label string, typ flux.ColType, mem arrowmemory.Allocator) (int, error) {
+	if idx, ok := gr.labels[label]; ok {
+		return idx, nil
+	}
+	if execute.ColIdx(label, gr.cols) >= 0 {
+		return 0, errors.Newf(
+			codes.Invalid,
+			"value %q appears in a column key column, but a column named %q already exists; consider renaming %q to something else before pivoting",
+			label, label, label,
+		)
+	}
+	idx := len(gr.pivots)
+	gr.pivots = append(gr.pivots, &columnarPivotColumn{
+		col:    flux.ColMeta{Label: label, Type: typ},
+		values: arrow.NewBuilder(typ, mem),
+	})
+	gr.labels[label] = idx
+	return idx, nil
+}
+
+// buildTable builds the output table for the group.
+func (gr *columnarPivotGroup) buildTable(key flux.GroupKey, mem arrowmemory.Allocator) (flux.Table, error) {
+	ncols := len(gr.cols) + len(gr.pivots)
+	tb := &arrow.TableBuffer{
+		GroupKey: key,
+		Columns:  make([]flux.ColMeta, 0, ncols),
+		Values:   make([]array.Array, 0, ncols),
+	}
+	for j, b := range gr.builders {
+		tb.Columns = append(tb.Columns, gr.cols[j])
+		tb.Values = append(tb.Values, b.NewArray())
+	}
+
+	indices := make([]int, gr.nrows)
+	for _, p := range gr.pivots {
+		// When a row has multiple values for the same column,
+		// the last one is used.
+		for i := range indices {
+			indices[i] = -1
+		}
+		for i, row := range p.rows {
+			indices[row] = i
+		}
+
+		vs := p.values.NewArray()
+		b := arrow.NewBuilder(p.col.Type, mem)
+		b.Resize(gr.nrows)
+		for _, i := range indices {
+			if i < 0 {
+				b.AppendNull()
+				continue
+			}
+			arrowutil.CopyValue(b, vs, i)
+		}
+		vs.Release()
+
+		tb.Columns = append(tb.Columns, p.col)
+		tb.Values = append(tb.Values, b.NewArray())
+	}
+
+	if err := tb.Validate(); err != nil {
+		tb.Release()
+		return nil, err
+	}
+	return table.FromBuffer(tb), nil
+}
+
+func (gr *columnarPivotGroup) release() {
+	for _, b := range gr.builders {
+		b.Release()
+	}
+	for _, p := range gr.pivots {
+		p.values.Release()
+	}
+}
+
+// appendValueKey appends an encoding of the value at the index
+// that is unique within the column. It returns an error if values
+// of the column type cannot be encoded.
+func appendValueKey(key []byte, arr array.Array, i int) ([]byte, error) {
+	if arr.IsNull(i) {
+		return append(key, 0), nil
+	}
+	var buf [binary.MaxVarintLen64]byte
+	key = append(key, 1)
+	switch arr := arr.(type) {
+	case *array.Int:
+		n := binary.PutVarint(buf[:], arr.Value(i))
+		key = append(key, buf[:n]...)
+	case *array.Uint:
+		n := binary.PutUvarint(buf[:], arr.Value(i))
+		key = append(key, buf[:n]...)
+	case *array.Float:
+		n := binary.PutUvarint(buf[:], math.Float64bits(arr.Value(i)))
+		key = append(key, buf[:n]...)
+	case *array.String:
+		v := arr.Value(i)
+		n := binary.PutUvarint(buf[:], uint64(len(v)))
+		key = append(key, buf[:n]...)
+		key = append(key, v...)
+	case *array.Binary:
+		v := arr.Value(i)
+		n := binary.PutUvarint(buf[:], uint64(len(v)))
+		key = append(key, buf[:n]...)
+		key = append(key, v...)
+	case *array.Boolean:
+		if arr.Value(i) {
+			key = append(key, 1)
+		} else {
+			key = append(key, 0)
+		}
+	default:
+		return nil, errors.Newf(codes.Invalid, "cannot use values of type %s as a key", arr.DataType())
+	}
+	return key, nil
+}
+
+func (t *columnarPivotTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+
+func (t *columnarPivotTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+
+func (t *columnarPivotTransformation) Finish(id execute.DatasetID, err error) {
+	defer func() { t.d.Finish(err) }()
+
+	if err == nil {
+		err = t.groups.Range(func(key flux.GroupKey, value interface{}) error {
+			gr := value.(*columnarPivotGroup)
+			tbl, err := gr.buildTable(key, t.mem)
+			if err != nil {
+				return err
+			}
+			return t.d.Process(tbl)
+		})
+	}
+	_ = t.groups.Range(func(key flux.GroupKey, value interface{}) error {
+		value.(*columnarPivotGroup).release()
+		return nil
+	})
+	t.groups.Clear()
+}
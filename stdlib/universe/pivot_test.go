@@ -2,6 +2,7 @@ package universe_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -782,6 +783,9 @@ func TestPivot_Process(t *testing.T) {
 	}
 	for _, tc := range testCases {
 		tc := tc
+		// Tables can only be read once so each implementation
+		// reads its own copy of the input.
+		data := copyPivotTables(tc.data)
 		t.Run(tc.name, func(t *testing.T) {
 			executetest.ProcessTestHelper(
 				t,
@@ -793,7 +797,32 @@ func TestPivot_Process(t *testing.T) {
 				},
 			)
 		})
+
+		t.Run(fmt.Sprintf("%s columnar", tc.name), func(t *testing.T) {
+			executetest.ProcessTestHelper2(
+				t,
+				data,
+				tc.want,
+				tc.wantErr,
+				func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+					tr, d, err := universe.NewColumnarPivotTransformation(tc.spec, id, alloc)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return tr, d
+				},
+			)
+		})
+	}
+}
+
+func copyPivotTables(tables []flux.Table) []flux.Table {
+	cpy := make([]flux.Table, len(tables))
+	for i, tbl := range tables {
+		tbl := *tbl.(*executetest.Table)
+		cpy[i] = &tbl
 	}
+	return cpy
 }
 
 func TestSortedPivot_ProcessWithTags(t *testing.T) {
@@ -1621,3 +1650,50 @@ func benchmarkPivot(b *testing.B, n int) {
 		},
 	)
 }
+
+func BenchmarkPivot_Columnar(b *testing.B) {
+	spec := &universe.PivotProcedureSpec{
+		RowKey:      []string{execute.DefaultTimeColLabel},
+		ColumnKey:   []string{"_field"},
+		ValueColumn: execute.DefaultValueColLabel,
+	}
+	for _, n := range []int{1000, 100000} {
+		b.Run(fmt.Sprintf("%d/row", n), func(b *testing.B) {
+			benchmarkUnsortedPivot(b, n, func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+				cache := execute.NewTableBuilderCache(alloc)
+				d := execute.NewDataset(id, execute.DiscardingMode, cache)
+				t := universe.NewPivotTransformation(d, cache, spec)
+				return t, d
+			})
+		})
+		b.Run(fmt.Sprintf("%d/columnar", n), func(b *testing.B) {
+			benchmarkUnsortedPivot(b, n, func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+				t, d, err := universe.NewColumnarPivotTransformation(spec, id, alloc)
+				if err != nil {
+					b.Fatal(err)
+				}
+				return t, d
+			})
+		})
+	}
+}
+
+func benchmarkUnsortedPivot(b *testing.B, n int, create func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset)) {
+	b.ReportAllocs()
+	executetest.ProcessBenchmarkHelper(b,
+		func(alloc memory.Allocator) (flux.TableIterator, error) {
+			schema := gen.Schema{
+				NumPoints: n,
+				Alloc:     alloc,
+				Tags: []gen.Tag{
+					{Name: "_measurement", Cardinality: 1},
+					{Name: "_field", Cardinality: 6},
+					{Name: "t0", Cardinality: 100},
+					{Name: "t1", Cardinality: 50},
+				},
+			}
+			return gen.Input(context.Background(), schema)
+		},
+		create,
+	)
+}
@@ -0,0 +1,302 @@
+package universe
+
+import (
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/plan/planpb"
+	"google.golang.org/protobuf/proto"
+)
+
+// Register the protobuf encoding of the procedure specs
+// that can be sent to another process.
+func init() {
+	planpb.RegisterProcedureSpec(RangeKind, marshalRange, unmarshalRange)
+	planpb.RegisterProcedureSpec(LimitKind, marshalLimit, unmarshalLimit)
+	planpb.RegisterProcedureSpec(TailKind, marshalTail, unmarshalTail)
+	planpb.RegisterProcedureSpec(GroupKind, marshalGroup, unmarshalGroup)
+	planpb.RegisterProcedureSpec(SortKind, marshalSort, unmarshalSort)
+	planpb.RegisterProcedureSpec(YieldKind, marshalYield, unmarshalYield)
+	planpb.RegisterProcedureSpec(WindowKind, marshalWindow, unmarshalWindow)
+	planpb.RegisterProcedureSpec(FirstKind, marshalSelector, func(msg proto.Message) (plan.PhysicalProcedureSpec, error) {
+		c, err := unmarshalSelectorConfig(msg)
+		return &FirstProcedureSpec{SelectorConfig: c}, err
+	})
+	planpb.RegisterProcedureSpec(LastKind, marshalSelector, func(msg proto.Message) (plan.PhysicalProcedureSpec, error) {
+		c, err := unmarshalSelectorConfig(msg)
+		return &LastProcedureSpec{SelectorConfig: c}, err
+	})
+	planpb.RegisterProcedureSpec(MinKind, marshalSelector, func(msg proto.Message) (plan.PhysicalProcedureSpec, error) {
+		c, err := unmarshalSelectorConfig(msg)
+		return &MinProcedureSpec{SelectorConfig: c}, err
+	})
+	planpb.RegisterProcedureSpec(MaxKind, marshalSelector, func(msg proto.Message) (plan.PhysicalProcedureSpec, error) {
+		c, err := unmarshalSelectorConfig(msg)
+		return &MaxProcedureSpec{SelectorConfig: c}, err
+	})
+	planpb.RegisterProcedureSpec(CountKind, marshalAggregate, func(msg proto.Message) (plan.PhysicalProcedureSpec, error) {
+		c, err := unmarshalAggregateConfig(msg)
+		return &CountProcedureSpec{SimpleAggregateConfig: c}, err
+	})
+	planpb.RegisterProcedureSpec(SumKind, marshalAggregate, func(msg proto.Message) (plan.PhysicalProcedureSpec, error) {
+		c, err := unmarshalAggregateConfig(msg)
+		return &SumProcedureSpec{SimpleAggregateConfig: c}, err
+	})
+	planpb.RegisterProcedureSpec(MeanKind, marshalAggregate, func(msg proto.Message) (plan.PhysicalProcedureSpec, error) {
+		c, err := unmarshalAggregateConfig(msg)
+		return &MeanProcedureSpec{SimpleAggregateConfig: c}, err
+	})
+	planpb.RegisterProcedureSpec(DistinctKind, marshalDistinct, func(msg proto.Message) (plan.PhysicalProcedureSpec, error) {
+		pb, err := columnProcedureSpec(msg)
+		if err != nil {
+			return nil, err
+		}
+		return &DistinctProcedureSpec{Column: pb.Column}, nil
+	})
+	planpb.RegisterProcedureSpec(UniqueKind, marshalUnique, func(msg proto.Message) (plan.PhysicalProcedureSpec, error) {
+		pb, err := columnProcedureSpec(msg)
+		if err != nil {
+			return nil, err
+		}
+		return &UniqueProcedureSpec{Column: pb.Column}, nil
+	})
+}
+
+func invalidProtobufSpec(v interface{}) error {
+	return errors.Newf(codes.Internal, "invalid spec type %T", v)
+}
+
+func marshalRange(spec plan.ProcedureSpec) (proto.Message, error) {
+	s, ok := spec.(*RangeProcedureSpec)
+	if !ok {
+		return nil, invalidProtobufSpec(spec)
+	}
+	return &planpb.RangeProcedureSpec{
+		Bounds:      planpb.NewTimeBounds(s.Bounds),
+		TimeColumn:  s.TimeColumn,
+		StartColumn: s.StartColumn,
+		StopColumn:  s.StopColumn,
+	}, nil
+}
+
+func unmarshalRange(msg proto.Message) (plan.PhysicalProcedureSpec, error) {
+	pb, ok := msg.(*planpb.RangeProcedureSpec)
+	if !ok {
+		return nil, invalidProtobufSpec(msg)
+	}
+	return &RangeProcedureSpec{
+		Bounds:      pb.Bounds.AsBounds(),
+		TimeColumn:  pb.TimeColumn,
+		StartColumn: pb.StartColumn,
+		StopColumn:  pb.StopColumn,
+	}, nil
+}
+
+func marshalLimit(spec plan.ProcedureSpec) (proto.Message, error) {
+	s, ok := spec.(*LimitProcedureSpec)
+	if !ok {
+		return nil, invalidProtobufSpec(spec)
+	}
+	return &planpb.LimitProcedureSpec{N: s.N, Offset: s.Offset}, nil
+}
+
+func unmarshalLimit(msg proto.Message) (plan.PhysicalProcedureSpec, error) {
+	pb, ok := msg.(*planpb.LimitProcedureSpec)
+	if !ok {
+		return nil, invalidProtobufSpec(msg)
+	}
+	return &LimitProcedureSpec{N: pb.N, Offset: pb.Offset}, nil
+}
+
+func marshalTail(spec plan.ProcedureSpec) (proto.Message, error) {
+	s, ok := spec.(*TailProcedureSpec)
+	if !ok {
+		return nil, invalidProtobufSpec(spec)
+	}
+	return &planpb.LimitProcedureSpec{N: s.N, Offset: s.Offset}, nil
+}
+
+func unmarshalTail(msg proto.Message) (plan.PhysicalProcedureSpec, error) {
+	pb, ok := msg.(*planpb.LimitProcedureSpec)
+	if !ok {
+		return nil, invalidProtobufSpec(msg)
+	}
+	return &TailProcedureSpec{N: pb.N, Offset: pb.Offset}, nil
+}
+
+func marshalGroup(spec plan.ProcedureSpec) (proto.Message, error) {
+	s, ok := spec.(*GroupProcedureSpec)
+	if !ok {
+		return nil, invalidProtobufSpec(spec)
+	}
+	mode, err := planpb.NewGroupMode(s.GroupMode)
+	if err != nil {
+		return nil, err
+	}
+	return &planpb.GroupProcedureSpec{Mode: mode, Columns: s.GroupKeys}, nil
+}
+
+func unmarshalGroup(msg proto.Message) (plan.PhysicalProcedureSpec, error) {
+	pb, ok := msg.(*planpb.GroupProcedureSpec)
+	if !ok {
+		return nil, invalidProtobufSpec(msg)
+	}
+	mode, err := pb.Mode.AsGroupMode()
+	if err != nil {
+		return nil, err
+	}
+	return &GroupProcedureSpec{GroupMode: mode, GroupKeys: pb.Columns}, nil
+}
+
+func marshalSort(spec plan.ProcedureSpec) (proto.Message, error) {
+	s, ok := spec.(*SortProcedureSpec)
+	if !ok {
+		return nil, invalidProtobufSpec(spec)
+	}
+	return &planpb.SortProcedureSpec{Columns: s.Columns, Desc: s.Desc}, nil
+}
+
+func unmarshalSort(msg proto.Message) (plan.PhysicalProcedureSpec, error) {
+	pb, ok := msg.(*planpb.SortProcedureSpec)
+	if !ok {
+		return nil, invalidProtobufSpec(msg)
+	}
+	return &SortProcedureSpec{Columns: pb.Columns, Desc: pb.Desc}, nil
+}
+
+func marshalYield(spec plan.ProcedureSpec) (proto.Message, error) {
+	s, ok := spec.(*YieldProcedureSpec)
+	if !ok {
+		return nil, invalidProtobufSpec(spec)
+	}
+	return &planpb.YieldProcedureSpec{Name: s.Name}, nil
+}
+
+func unmarshalYield(msg proto.Message) (plan.PhysicalProcedureSpec, error) {
+	pb, ok := msg.(*planpb.YieldProcedureSpec)
+	if !ok {
+		return nil, invalidProtobufSpec(msg)
+	}
+	return &YieldProcedureSpec{Name: pb.Name}, nil
+}
+
+func marshalWindow(spec plan.ProcedureSpec) (proto.Message, error) {
+	s, ok := spec.(*WindowProcedureSpec)
+	if !ok {
+		return nil, invalidProtobufSpec(spec)
+	}
+	return &planpb.WindowProcedureSpec{
+		Every:       planpb.NewDuration(s.Window.Every),
+		Period:      planpb.NewDuration(s.Window.Period),
+		Offset:      planpb.NewDuration(s.Window.Offset),
+		Location:    planpb.NewLocation(s.Window.Location),
+		TimeColumn:  s.TimeColumn,
+		StartColumn: s.StartColumn,
+		StopColumn:  s.StopColumn,
+		CreateEmpty: s.CreateEmpty,
+	}, nil
+}
+
+func unmarshalWindow(msg proto.Message) (plan.PhysicalProcedureSpec, error) {
+	pb, ok := msg.(*planpb.WindowProcedureSpec)
+	if !ok {
+		return nil, invalidProtobufSpec(msg)
+	}
+	return &WindowProcedureSpec{
+		Window: plan.WindowSpec{
+			Every:    pb.Every.AsDuration(),
+			Period:   pb.Period.AsDuration(),
+			Offset:   pb.Offset.AsDuration(),
+			Location: pb.Location.AsLocation(),
+		},
+		TimeColumn:  pb.TimeColumn,
+		StartColumn: pb.StartColumn,
+		StopColumn:  pb.StopColumn,
+		CreateEmpty: pb.CreateEmpty,
+	}, nil
+}
+
+func marshalSelector(spec plan.ProcedureSpec) (proto.Message, error) {
+	var c execute.SelectorConfig
+	switch s := spec.(type) {
+	case *FirstProcedureSpec:
+		c = s.SelectorConfig
+	case *LastProcedureSpec:
+		c = s.SelectorConfig
+	case *MinProcedureSpec:
+		c = s.SelectorConfig
+	case *MaxProcedureSpec:
+		c = s.SelectorConfig
+	default:
+		return nil, invalidProtobufSpec(spec)
+	}
+	return &planpb.SelectorProcedureSpec{
+		Column:   c.Column,
+		NullMode: string(c.Nulls),
+	}, nil
+}
+
+func unmarshalSelectorConfig(msg proto.Message) (execute.SelectorConfig, error) {
+	pb, ok := msg.(*planpb.SelectorProcedureSpec)
+	if !ok {
+		return execute.SelectorConfig{}, invalidProtobufSpec(msg)
+	}
+	return execute.SelectorConfig{
+		Column: pb.Column,
+		Nulls:  execute.NullMode(pb.NullMode),
+	}, nil
+}
+
+func marshalAggregate(spec plan.ProcedureSpec) (proto.Message, error) {
+	var c execute.SimpleAggregateConfig
+	switch s := spec.(type) {
+	case *CountProcedureSpec:
+		c = s.SimpleAggregateConfig
+	case *SumProcedureSpec:
+		c = s.SimpleAggregateConfig
+	case *MeanProcedureSpec:
+		c = s.SimpleAggregateConfig
+	default:
+		return nil, invalidProtobufSpec(spec)
+	}
+	return &planpb.AggregateProcedureSpec{
+		Columns:  c.Columns,
+		NullMode: string(c.Nulls),
+	}, nil
+}
+
+func unmarshalAggregateConfig(msg proto.Message) (execute.SimpleAggregateConfig, error) {
+	pb, ok := msg.(*planpb.AggregateProcedureSpec)
+	if !ok {
+		return execute.SimpleAggregateConfig{}, invalidProtobufSpec(msg)
+	}
+	return execute.SimpleAggregateConfig{
+		Columns: pb.Columns,
+		Nulls:   execute.NullMode(pb.NullMode),
+	}, nil
+}
+
+func marshalDistinct(spec plan.ProcedureSpec) (proto.Message, error) {
+	s, ok := spec.(*DistinctProcedureSpec)
+	if !ok {
+		return nil, invalidProtobufSpec(spec)
+	}
+	return &planpb.ColumnProcedureSpec{Column: s.Column}, nil
+}
+
+func marshalUnique(spec plan.ProcedureSpec) (proto.Message, error) {
+	s, ok := spec.(*UniqueProcedureSpec)
+	if !ok {
+		return nil, invalidProtobufSpec(spec)
+	}
+	return &planpb.ColumnProcedureSpec{Column: s.Column}, nil
+}
+
+func columnProcedureSpec(msg proto.Message) (*planpb.ColumnProcedureSpec, error) {
+	pb, ok := msg.(*planpb.ColumnProcedureSpec)
+	if !ok {
+		return nil, invalidProtobufSpec(msg)
+	}
+	return pb, nil
+}
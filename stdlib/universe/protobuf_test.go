@@ -0,0 +1,110 @@
+package universe_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/plan/planpb"
+	"github.com/influxdata/flux/stdlib/universe"
+)
+
+func TestProcedureSpec_Protobuf(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		spec plan.PhysicalProcedureSpec
+	}{
+		{
+			name: "range",
+			spec: &universe.RangeProcedureSpec{
+				Bounds: flux.Bounds{
+					Start: flux.Time{IsRelative: true, Relative: -time.Hour},
+					Stop:  flux.Now,
+				},
+				TimeColumn:  execute.DefaultTimeColLabel,
+				StartColumn: execute.DefaultStartColLabel,
+				StopColumn:  execute.DefaultStopColLabel,
+			},
+		},
+		{
+			name: "limit",
+			spec: &universe.LimitProcedureSpec{N: 10, Offset: 2},
+		},
+		{
+			name: "tail",
+			spec: &universe.TailProcedureSpec{N: 10, Offset: 2},
+		},
+		{
+			name: "group",
+			spec: &universe.GroupProcedureSpec{
+				GroupMode: flux.GroupModeExcept,
+				GroupKeys: []string{"_time", "_value"},
+			},
+		},
+		{
+			name: "sort",
+			spec: &universe.SortProcedureSpec{Columns: []string{"_value"}, Desc: true},
+		},
+		{
+			name: "yield",
+			spec: &universe.YieldProcedureSpec{Name: "mean"},
+		},
+		{
+			name: "window",
+			spec: &universe.WindowProcedureSpec{
+				Window: plan.WindowSpec{
+					Every:    flux.ConvertDuration(time.Minute),
+					Period:   flux.ConvertDuration(time.Minute),
+					Offset:   flux.ConvertDuration(-time.Second),
+					Location: plan.Location{Name: "UTC"},
+				},
+				TimeColumn:  execute.DefaultTimeColLabel,
+				StartColumn: execute.DefaultStartColLabel,
+				StopColumn:  execute.DefaultStopColLabel,
+				CreateEmpty: true,
+			},
+		},
+		{
+			name: "first",
+			spec: &universe.FirstProcedureSpec{SelectorConfig: execute.SelectorConfig{
+				Column: execute.DefaultValueColLabel,
+				Nulls:  execute.NullModeSkip,
+			}},
+		},
+		{
+			name: "max",
+			spec: &universe.MaxProcedureSpec{SelectorConfig: execute.DefaultSelectorConfig},
+		},
+		{
+			name: "mean",
+			spec: &universe.MeanProcedureSpec{SimpleAggregateConfig: execute.DefaultSimpleAggregateConfig},
+		},
+		{
+			name: "unique",
+			spec: &universe.UniqueProcedureSpec{Column: "host"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := plan.NewPlanSpec()
+			spec.Roots[plan.CreatePhysicalNode("node", tc.spec)] = struct{}{}
+
+			data, err := planpb.Marshal(spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := planpb.Unmarshal(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for n := range got.Roots {
+				opt := cmp.Comparer(func(a, b flux.Duration) bool { return a.Equal(b) })
+				if want, got := tc.spec, n.ProcedureSpec(); !cmp.Equal(want, got, opt) {
+					t.Errorf("unexpected procedure spec -want/+got:\n%s", cmp.Diff(want, got, opt))
+				}
+			}
+		})
+	}
+}
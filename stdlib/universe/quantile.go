@@ -1,6 +1,7 @@
 package universe
 
 import (
+	"encoding/json"
 	"math"
 	"sort"
 
@@ -33,8 +34,50 @@ type QuantileOpSpec struct {
 	Compression float64 `json:"compression"`
 	Method      string  `json:"method"`
 	// quantile is either an aggregate, or a selector based on the options
-	execute.SimpleAggregateConfig
-	execute.SelectorConfig
+	execute.SimpleAggregateConfig `json:"-"`
+	execute.SelectorConfig        `json:"-"`
+}
+
+// quantileOpSpecJSON is the encoding of a QuantileOpSpec. The fields of
+// both configs are flattened into the spec, and the nulls field they
+// share is encoded once.
+type quantileOpSpecJSON struct {
+	Quantile    float64          `json:"quantile"`
+	Compression float64          `json:"compression"`
+	Method      string           `json:"method"`
+	Columns     []string         `json:"columns"`
+	Column      string           `json:"column"`
+	Nulls       execute.NullMode `json:"nulls,omitempty"`
+}
+
+func (s QuantileOpSpec) MarshalJSON() ([]byte, error) {
+	nulls := s.SimpleAggregateConfig.Nulls
+	if s.Method == methodExactSelector {
+		nulls = s.SelectorConfig.Nulls
+	}
+	return json.Marshal(quantileOpSpecJSON{
+		Quantile:    s.Quantile,
+		Compression: s.Compression,
+		Method:      s.Method,
+		Columns:     s.Columns,
+		Column:      s.Column,
+		Nulls:       nulls,
+	})
+}
+
+func (s *QuantileOpSpec) UnmarshalJSON(data []byte) error {
+	var v quantileOpSpecJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	s.Quantile = v.Quantile
+	s.Compression = v.Compression
+	s.Method = v.Method
+	s.SimpleAggregateConfig.Columns = v.Columns
+	s.SimpleAggregateConfig.Nulls = v.Nulls
+	s.SelectorConfig.Column = v.Column
+	s.SelectorConfig.Nulls = v.Nulls
+	return nil
 }
 
 func init() {
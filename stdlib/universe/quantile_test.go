@@ -1,9 +1,11 @@
 package universe_test
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/array"
 	"github.com/influxdata/flux/arrow"
@@ -296,6 +298,61 @@ func TestQuantile_NewQuery(t *testing.T) {
 	}
 }
 
+func TestQuantileOpSpec_JSON(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		data string
+		spec *universe.QuantileOpSpec
+	}{
+		{
+			// The encoding of specs that do not set nulls is unchanged.
+			name: "aggregate",
+			data: `{"quantile":0.9,"compression":1000,"method":"estimate_tdigest","columns":["_value"],"column":""}`,
+			spec: &universe.QuantileOpSpec{
+				Quantile:    0.9,
+				Compression: 1000,
+				Method:      "estimate_tdigest",
+				SimpleAggregateConfig: execute.SimpleAggregateConfig{
+					Columns: []string{"_value"},
+				},
+			},
+		},
+		{
+			name: "selector",
+			data: `{"quantile":0.5,"compression":0,"method":"exact_selector","columns":null,"column":"_value","nulls":"propagate"}`,
+			spec: &universe.QuantileOpSpec{
+				Quantile: 0.5,
+				Method:   "exact_selector",
+				SimpleAggregateConfig: execute.SimpleAggregateConfig{
+					Nulls: execute.NullModePropagate,
+				},
+				SelectorConfig: execute.SelectorConfig{
+					Column: "_value",
+					Nulls:  execute.NullModePropagate,
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := new(universe.QuantileOpSpec)
+			if err := json.Unmarshal([]byte(tc.data), got); err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(tc.spec, got) {
+				t.Fatalf("unexpected spec -want/+got:\n%s", cmp.Diff(tc.spec, got))
+			}
+
+			data, err := json.Marshal(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want, got := tc.data, string(data); want != got {
+				t.Fatalf("unexpected encoding -want/+got:\n\t- %s\n\t+ %s", want, got)
+			}
+		})
+	}
+}
+
 func TestQuantile_Process(t *testing.T) {
 	testCases := []struct {
 		name     string
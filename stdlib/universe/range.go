@@ -5,6 +5,8 @@ import (
 	"github.com/influxdata/flux/codes"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/internal/execute/table"
+	"github.com/influxdata/flux/memory"
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/runtime"
 	"github.com/influxdata/flux/values"
@@ -146,7 +148,7 @@ func createRangeTransformation(id execute.DatasetID, mode execute.AccumulationMo
 
 	bounds := a.StreamContext().Bounds()
 
-	t, err := NewRangeTransformation(d, cache, s, *bounds)
+	t, err := NewRangeTransformation(d, cache, s, *bounds, a.Allocator())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -161,12 +163,14 @@ type rangeTransformation struct {
 	timeCol  string
 	startCol string
 	stopCol  string
+	mem      memory.Allocator
 }
 
-func NewRangeTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *RangeProcedureSpec, absolute execute.Bounds) (*rangeTransformation, error) {
+func NewRangeTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *RangeProcedureSpec, absolute execute.Bounds, mem memory.Allocator) (*rangeTransformation, error) {
 	return &rangeTransformation{
 		d:        d,
 		cache:    cache,
+		mem:      mem,
 		bounds:   absolute,
 		timeCol:  spec.TimeColumn,
 		startCol: spec.StartColumn,
@@ -253,31 +257,34 @@ func (t *rangeTransformation) Process(id execute.DatasetID, tbl flux.Table) erro
 
 	startTime := outKey.Value(execute.ColIdx(t.startCol, outKey.Cols()))
 	stopTime := outKey.Value(execute.ColIdx(t.stopCol, outKey.Cols()))
+	var sel []int
 	return tbl.Do(func(cr flux.ColReader) error {
-		l := cr.Len()
-		for i := 0; i < l; i++ {
-			ts := cr.Times(timeIdx)
+		ts := cr.Times(timeIdx)
+		sel = sel[:0]
+		for i, l := 0, cr.Len(); i < l; i++ {
 			if ts.IsNull(i) {
 				continue
 			}
-			tVal := values.Time(ts.Value(i))
-			if !t.bounds.Contains(tVal) {
-				continue
+			if tVal := values.Time(ts.Value(i)); t.bounds.Contains(tVal) {
+				sel = append(sel, i)
 			}
-			for j, c := range builder.Cols() {
-				switch c.Label {
-				case t.startCol:
-					if err := builder.AppendValue(j, startTime); err != nil {
-						return err
-					}
-				case t.stopCol:
-					if err := builder.AppendValue(j, stopTime); err != nil {
-						return err
-					}
-				default:
-					if err := builder.AppendValue(j, execute.ValueForRow(cr, i, colMap[j])); err != nil {
-						return err
-					}
+		}
+
+		buf := table.Filter(cr, sel, t.mem)
+		defer buf.Release()
+		for j, c := range builder.Cols() {
+			switch c.Label {
+			case t.startCol:
+				if err := appendTimes(builder, j, startTime, len(sel)); err != nil {
+					return err
+				}
+			case t.stopCol:
+				if err := appendTimes(builder, j, stopTime, len(sel)); err != nil {
+					return err
+				}
+			default:
+				if err := execute.AppendCol(j, colMap[j], &buf, builder); err != nil {
+					return err
 				}
 			}
 		}
@@ -285,6 +292,16 @@ func (t *rangeTransformation) Process(id execute.DatasetID, tbl flux.Table) erro
 	})
 }
 
+// appendTimes appends the time value to the column n times.
+func appendTimes(builder execute.TableBuilder, j int, v values.Value, n int) error {
+	for i := 0; i < n; i++ {
+		if err := builder.AppendValue(j, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (t *rangeTransformation) createRangeGroupKey(inKey flux.GroupKey, startKeyColIdx, stopKeyColIdx int) flux.GroupKey {
 	var outKeyCols []flux.ColMeta
 	var outKeyValues []values.Value
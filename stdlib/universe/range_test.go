@@ -591,7 +591,7 @@ func TestRange_Process(t *testing.T) {
 						}
 					}
 
-					tr, err := universe.NewRangeTransformation(d, c, tc.spec, b)
+					tr, err := universe.NewRangeTransformation(d, c, tc.spec, b, executetest.UnlimitedAllocator)
 					if err != nil {
 						t.Fatal(err)
 					}
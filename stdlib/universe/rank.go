@@ -0,0 +1,240 @@
+package universe
+
+import (
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/arrowutil"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/runtime"
+	"github.com/influxdata/flux/semantic"
+)
+
+const (
+	RowNumberKind = "rowNumber"
+	RankKind      = "rank"
+	DenseRankKind = "denseRank"
+)
+
+const (
+	defaultRowNumberColLabel = "_row"
+	defaultRankColLabel      = "_rank"
+)
+
+// RankOpSpec is the operation spec for rowNumber(), rank() and denseRank().
+type RankOpSpec struct {
+	Columns []string `json:"columns,omitempty"`
+	As      string   `json:"as"`
+
+	kind flux.OperationKind
+}
+
+func init() {
+	for _, kind := range []flux.OperationKind{RowNumberKind, RankKind, DenseRankKind} {
+		kind := kind
+		signature := runtime.MustLookupBuiltinType("universe", string(kind))
+		create := func(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+			return createRankOpSpec(kind, args, a)
+		}
+		runtime.RegisterPackageValue("universe", string(kind), flux.MustValue(flux.FunctionValue(string(kind), create, signature)))
+	}
+	plan.RegisterProcedureSpec(RankKind, newRankProcedure, RowNumberKind, RankKind, DenseRankKind)
+	execute.RegisterTransformation(RankKind, createRankTransformation)
+}
+
+func createRankOpSpec(kind flux.OperationKind, args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := &RankOpSpec{kind: kind}
+	if kind != RowNumberKind {
+		if cols, ok, err := args.GetArray("columns", semantic.String); err != nil {
+			return nil, err
+		} else if ok {
+			columns, err := interpreter.ToStringArray(cols)
+			if err != nil {
+				return nil, err
+			}
+			spec.Columns = columns
+		} else {
+			spec.Columns = []string{execute.DefaultValueColLabel}
+		}
+	}
+
+	if as, ok, err := args.GetString("as"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.As = as
+	} else if kind == RowNumberKind {
+		spec.As = defaultRowNumberColLabel
+	} else {
+		spec.As = defaultRankColLabel
+	}
+	return spec, nil
+}
+
+func (s *RankOpSpec) Kind() flux.OperationKind {
+	return s.kind
+}
+
+type RankProcedureSpec struct {
+	plan.DefaultCost
+	Columns []string
+	As      string
+
+	// Dense ranks rows with consecutive ranks instead of skipping
+	// the ranks that are shared by equal rows. It is only used
+	// when Columns is set.
+	Dense bool
+}
+
+func newRankProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*RankOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+
+	return &RankProcedureSpec{
+		Columns: spec.Columns,
+		As:      spec.As,
+		Dense:   spec.kind == DenseRankKind,
+	}, nil
+}
+
+func (s *RankProcedureSpec) Kind() plan.ProcedureKind {
+	return RankKind
+}
+
+func (s *RankProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(RankProcedureSpec)
+	*ns = *s
+	if s.Columns != nil {
+		ns.Columns = make([]string, len(s.Columns))
+		copy(ns.Columns, s.Columns)
+	}
+	return ns
+}
+
+// TriggerSpec implements plan.TriggerAwareProcedureSpec
+func (s *RankProcedureSpec) TriggerSpec() plan.TriggerSpec {
+	return plan.NarrowTransformationTriggerSpec{}
+}
+
+func createRankTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*RankProcedureSpec)
+	if !ok {
+		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
+	}
+	return NewRankTransformation(id, s, a.Allocator())
+}
+
+// rankTransformation adds the position of each row in its table.
+//
+// The rows are expected to be sorted by the rank columns.
+// A row with the same values in the rank columns as the previous row
+// has the same rank as the previous row. Without rank columns, the rank
+// of each row is its row number.
+type rankTransformation struct {
+	columns []string
+	as      string
+	dense   bool
+}
+
+// rankState contains the rank of the last row of a table
+// and the values of the rank columns in that row.
+type rankState struct {
+	rows int64
+	rank int64
+	last []array.Array
+}
+
+func (s *rankState) Close() error {
+	for _, arr := range s.last {
+		arr.Release()
+	}
+	s.last = nil
+	return nil
+}
+
+func NewRankTransformation(id execute.DatasetID, spec *RankProcedureSpec, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	tr := &rankTransformation{
+		columns: spec.Columns,
+		as:      spec.As,
+		dense:   spec.Dense,
+	}
+	return execute.NewNarrowStateTransformation[*rankState](id, tr, mem)
+}
+
+func (t *rankTransformation) Process(chunk table.Chunk, state *rankState, d *execute.TransportDataset, mem memory.Allocator) (*rankState, bool, error) {
+	if chunk.Key().HasCol(t.as) {
+		return nil, false, errors.Newf(codes.FailedPrecondition, "cannot set group key column %q", t.as)
+	}
+
+	cols := make([]array.Array, len(t.columns))
+	for i, label := range t.columns {
+		idx := chunk.Index(label)
+		if idx < 0 {
+			return nil, false, errors.Newf(codes.FailedPrecondition, "rank: column %q does not exist", label)
+		}
+		cols[i] = chunk.Values(idx)
+	}
+	if state == nil {
+		state = &rankState{}
+	}
+
+	b := array.NewIntBuilder(mem)
+	b.Resize(chunk.Len())
+	for i, n := 0, chunk.Len(); i < n; i++ {
+		state.rows++
+		if t.changed(state, cols, i) {
+			if t.dense {
+				state.rank++
+			} else {
+				state.rank = state.rows
+			}
+		}
+		b.Append(state.rank)
+	}
+
+	if n := chunk.Len(); n > 0 && len(cols) > 0 {
+		_ = state.Close()
+		state.last = make([]array.Array, len(cols))
+		for i, arr := range cols {
+			state.last[i] = arrow.Slice(arr, int64(n-1), int64(n))
+		}
+	}
+
+	out := withColumn(chunk, t.as, flux.TInt, b.NewArray())
+	if err := d.Process(out); err != nil {
+		return nil, false, err
+	}
+	return state, true, nil
+}
+
+// changed reports if the row has a different rank than the previous row.
+func (t *rankTransformation) changed(state *rankState, cols []array.Array, i int) bool {
+	if len(cols) == 0 || state.rows == 1 {
+		return true
+	}
+	for j, arr := range cols {
+		if i == 0 {
+			if arrowutil.Compare(state.last[j], arr, 0, i) != 0 {
+				return true
+			}
+		} else if arrowutil.Compare(arr, arr, i-1, i) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *rankTransformation) Close() error {
+	return nil
+}
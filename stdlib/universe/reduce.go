@@ -18,9 +18,14 @@ import (
 
 const ReduceKind = "reduce"
 
+// accumulatorParamName is the name of the parameter that receives
+// the accumulator in the reduce and finalize functions.
+const accumulatorParamName = "accumulator"
+
 type ReduceOpSpec struct {
 	Fn       interpreter.ResolvedFunction `json:"fn"`
 	Identity values.Object                `json:"identity"`
+	Finalize interpreter.ResolvedFunction `json:"finalize"`
 }
 
 func init() {
@@ -54,6 +59,16 @@ func createReduceOpSpec(args flux.Arguments, a *flux.Administration) (flux.Opera
 		spec.Identity = o
 	}
 
+	if f, ok, err := args.GetFunction("finalize"); err != nil {
+		return nil, err
+	} else if ok {
+		fn, err := interpreter.ResolveFunction(f)
+		if err != nil {
+			return nil, err
+		}
+		spec.Finalize = fn
+	}
+
 	return spec, nil
 }
 
@@ -65,6 +80,7 @@ type ReduceProcedureSpec struct {
 	plan.DefaultCost
 	Fn       interpreter.ResolvedFunction
 	Identity values.Object
+	Finalize interpreter.ResolvedFunction
 }
 
 func newReduceProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
@@ -78,6 +94,7 @@ func newReduceProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.Pro
 	return &ReduceProcedureSpec{
 		Fn:       spec.Fn,
 		Identity: spec.Identity,
+		Finalize: spec.Finalize,
 	}, nil
 }
 
@@ -88,6 +105,7 @@ func (s *ReduceProcedureSpec) Copy() plan.ProcedureSpec {
 	ns := new(ReduceProcedureSpec)
 	*ns = *s
 	ns.Fn = s.Fn.Copy()
+	ns.Finalize = s.Finalize.Copy()
 	return ns
 }
 
@@ -112,17 +130,25 @@ type reduceTransformation struct {
 	ctx      context.Context
 	fn       *execute.RowReduceFn
 	identity values.Object
+	finalize *reduceFinalizeFn
 }
 
 func NewReduceTransformation(ctx context.Context, spec *ReduceProcedureSpec, d execute.Dataset, cache execute.TableBuilderCache) (*reduceTransformation, error) {
 	fn := execute.NewRowReduceFn(spec.Fn.Fn, compiler.ToScope(spec.Fn.Scope))
-	return &reduceTransformation{
+	t := &reduceTransformation{
 		d:        d,
 		cache:    cache,
 		ctx:      ctx,
 		fn:       fn,
 		identity: spec.Identity,
-	}, nil
+	}
+	if spec.Finalize.Fn != nil {
+		t.finalize = &reduceFinalizeFn{
+			scope: compiler.ToScope(spec.Finalize.Scope),
+			fn:    spec.Finalize.Fn,
+		}
+	}
+	return t, nil
 }
 
 func (t *reduceTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
@@ -134,7 +160,6 @@ func (t *reduceTransformation) Process(id execute.DatasetID, tbl flux.Table) err
 	}
 
 	// Start the reduce operation with the neutral element as the accumulator.
-	const accumulatorParamName = "accumulator"
 	params := map[string]values.Value{accumulatorParamName: t.identity}
 	if err := tbl.Do(func(cr flux.ColReader) error {
 		l := cr.Len()
@@ -152,8 +177,16 @@ func (t *reduceTransformation) Process(id execute.DatasetID, tbl flux.Table) err
 		return err
 	}
 
-	// Compute the group key by replacing columns from the reducer if needed.
+	// Apply the finalize function to the last accumulator.
 	m := params[accumulatorParamName].Object()
+	if t.finalize != nil {
+		m, err = t.finalize.eval(t.ctx, m)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Compute the group key by replacing columns from the reducer if needed.
 	key := t.computeGroupKey(tbl.Key(), m)
 
 	builder, created := t.cache.TableBuilder(key)
@@ -181,9 +214,13 @@ func (t *reduceTransformation) Process(id execute.DatasetID, tbl flux.Table) err
 		if v.IsNull() {
 			return errors.Newf(codes.Invalid, `null values are not supported for "%s" in the reduce() function`, label)
 		}
+		typ := flux.ColumnType(v.Type())
+		if typ == flux.TInvalid {
+			return errors.Newf(codes.Invalid, `%v values are not supported for "%s" in the reduce() output; use finalize to convert the accumulator`, v.Type().Nature(), label)
+		}
 		if _, err := builder.AddCol(flux.ColMeta{
 			Label: label,
-			Type:  flux.ColumnType(v.Type()),
+			Type:  typ,
 		}); err != nil {
 			return err
 		}
@@ -203,6 +240,41 @@ func (t *reduceTransformation) Process(id execute.DatasetID, tbl flux.Table) err
 	return nil
 }
 
+// reduceFinalizeFn is the function that converts the last accumulator
+// of a table into the output record. It is compiled again if the type
+// of the accumulator changes.
+type reduceFinalizeFn struct {
+	scope compiler.Scope
+	fn    *semantic.FunctionExpression
+
+	accType  string
+	compiled compiler.Func
+	input    values.Object
+}
+
+func (f *reduceFinalizeFn) eval(ctx context.Context, accumulator values.Object) (values.Object, error) {
+	typ := accumulator.Type()
+	if accType := typ.CanonicalString(); f.compiled == nil || f.accType != accType {
+		in := semantic.NewObjectType([]semantic.PropertyType{
+			{Key: []byte(accumulatorParamName), Value: typ},
+		})
+		fn, err := compiler.Compile(f.scope, f.fn, in)
+		if err != nil {
+			return nil, err
+		} else if n := fn.Type().Nature(); n != semantic.Object {
+			return nil, errors.Newf(codes.Invalid, "finalize function must return an object, got %s", n)
+		}
+		f.accType, f.compiled, f.input = accType, fn, values.NewObject(in)
+	}
+
+	f.input.Set(accumulatorParamName, accumulator)
+	v, err := f.compiled.Eval(ctx, f.input)
+	if err != nil {
+		return nil, errors.Wrap(err, codes.Inherit, "failed to evaluate finalize function")
+	}
+	return v.Object(), nil
+}
+
 func (t *reduceTransformation) computeGroupKey(key flux.GroupKey, v values.Object) flux.GroupKey {
 	replace := false
 	v.Range(func(name string, v values.Value) {
@@ -12,6 +12,7 @@ import (
 	"github.com/influxdata/flux/execute/executetest"
 	"github.com/influxdata/flux/internal/errors"
 	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/flux/stdlib/universe"
 	"github.com/influxdata/flux/values"
 	"github.com/influxdata/flux/values/valuestest"
@@ -111,6 +112,63 @@ func TestReduce_Process(t *testing.T) {
 			}},
 			wantErr: errors.New(codes.Invalid, `null values are not supported for "prod" in the reduce() function`),
 		},
+		{
+			name: `finalize mean`,
+			spec: &universe.ReduceProcedureSpec{
+				Identity: values.NewObjectWithValues(map[string]values.Value{
+					"sum":   values.NewFloat(0.0),
+					"count": values.NewInt(0),
+				}),
+				Fn: interpreter.ResolvedFunction{
+					Fn:    executetest.FunctionExpression(t, `(r, accumulator) => ({sum: r._value + accumulator.sum, count: accumulator.count + 1})`),
+					Scope: valuestest.Scope(),
+				},
+				Finalize: interpreter.ResolvedFunction{
+					Fn:    executetest.FunctionExpression(t, `(accumulator) => ({mean: accumulator.sum / float(v: accumulator.count)})`),
+					Scope: valuestest.Scope(),
+				},
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.0},
+					{execute.Time(2), 6.0},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "mean", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{3.5},
+				},
+			}},
+		},
+		{
+			name: `array in reduce output`,
+			spec: &universe.ReduceProcedureSpec{
+				Identity: values.NewObjectWithValues(map[string]values.Value{
+					"values": values.NewArrayWithBacking(semantic.NewArrayType(semantic.BasicFloat), []values.Value{}),
+				}),
+				Fn: interpreter.ResolvedFunction{
+					Fn:    executetest.FunctionExpression(t, `(r, accumulator) => ({values: [r._value]})`),
+					Scope: valuestest.Scope(),
+				},
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.0},
+				},
+			}},
+			wantErr: errors.New(codes.Invalid, `array values are not supported for "values" in the reduce() output; use finalize to convert the accumulator`),
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
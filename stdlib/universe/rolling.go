@@ -0,0 +1,675 @@
+package universe
+
+import (
+	"context"
+
+	"github.com/apache/arrow/go/v7/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/compiler"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/runtime"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+const RollingKind = "rolling"
+
+const rollingValuesParamName = "values"
+
+// The registered values of the aggregates that rolling() computes
+// incrementally instead of calling the function for each window.
+var countFunction, sumFunction, meanFunction, minFunction, maxFunction values.Value
+
+type RollingOpSpec struct {
+	N          int64         `json:"n,omitempty"`
+	Every      flux.Duration `json:"every,omitempty"`
+	Column     string        `json:"column"`
+	TimeColumn string        `json:"timeColumn"`
+	As         string        `json:"as"`
+
+	// Aggregate is the kind of the builtin aggregate passed as fn.
+	// It is empty if fn is a custom function.
+	Aggregate plan.ProcedureKind           `json:"aggregate,omitempty"`
+	Fn        interpreter.ResolvedFunction `json:"fn"`
+}
+
+func init() {
+	rollingSignature := runtime.MustLookupBuiltinType("universe", "rolling")
+
+	runtime.RegisterPackageValue("universe", RollingKind, flux.MustValue(flux.FunctionValue(RollingKind, createRollingOpSpec, rollingSignature)))
+	plan.RegisterProcedureSpec(RollingKind, newRollingProcedure, RollingKind)
+	execute.RegisterTransformation(RollingKind, createRollingTransformation)
+}
+
+func createRollingOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(RollingOpSpec)
+	n, nOk, err := args.GetInt("n")
+	if err != nil {
+		return nil, err
+	}
+	every, everyOk, err := args.GetDuration("every")
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case nOk == everyOk:
+		return nil, errors.New(codes.Invalid, "rolling requires exactly one of n or every")
+	case nOk && n <= 0:
+		return nil, errors.Newf(codes.Invalid, "cannot take rolling aggregate with a window of %v rows (must be greater than 0)", n)
+	case everyOk && !values.Duration(every).IsPositive():
+		return nil, errors.Newf(codes.Invalid, "cannot take rolling aggregate with a window of %v (must be positive)", every)
+	}
+	spec.N, spec.Every = n, every
+
+	f, err := args.GetRequiredFunction("fn")
+	if err != nil {
+		return nil, err
+	}
+	if kind, ok := rollingAggregateKind(f); ok {
+		spec.Aggregate = kind
+	} else {
+		fn, err := interpreter.ResolveFunction(f)
+		if err != nil {
+			return nil, errors.Wrap(err, codes.Invalid, "fn must be count, sum, mean, min, max or a function of the window values")
+		}
+		spec.Fn = fn
+	}
+
+	if label, ok, err := args.GetString("column"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Column = label
+	} else {
+		spec.Column = execute.DefaultValueColLabel
+	}
+
+	if label, ok, err := args.GetString("timeColumn"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.TimeColumn = label
+	} else {
+		spec.TimeColumn = execute.DefaultTimeColLabel
+	}
+
+	if label, ok, err := args.GetString("as"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.As = label
+	} else {
+		spec.As = spec.Column
+	}
+	return spec, nil
+}
+
+// rollingAggregateKind returns the kind of the builtin aggregate
+// that the function is the registered value of.
+func rollingAggregateKind(f values.Function) (plan.ProcedureKind, bool) {
+	switch values.Value(f) {
+	case countFunction:
+		return CountKind, true
+	case sumFunction:
+		return SumKind, true
+	case meanFunction:
+		return MeanKind, true
+	case minFunction:
+		return MinKind, true
+	case maxFunction:
+		return MaxKind, true
+	default:
+		return "", false
+	}
+}
+
+func (s *RollingOpSpec) Kind() flux.OperationKind {
+	return RollingKind
+}
+
+type RollingProcedureSpec struct {
+	plan.DefaultCost
+	N          int64
+	Every      flux.Duration
+	Column     string
+	TimeColumn string
+	As         string
+	Aggregate  plan.ProcedureKind
+	Fn         interpreter.ResolvedFunction
+}
+
+func newRollingProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*RollingOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+
+	return &RollingProcedureSpec{
+		N:          spec.N,
+		Every:      spec.Every,
+		Column:     spec.Column,
+		TimeColumn: spec.TimeColumn,
+		As:         spec.As,
+		Aggregate:  spec.Aggregate,
+		Fn:         spec.Fn,
+	}, nil
+}
+
+func (s *RollingProcedureSpec) Kind() plan.ProcedureKind {
+	return RollingKind
+}
+
+func (s *RollingProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(RollingProcedureSpec)
+	*ns = *s
+	ns.Fn = s.Fn.Copy()
+	return ns
+}
+
+// TriggerSpec implements plan.TriggerAwareProcedureSpec
+func (s *RollingProcedureSpec) TriggerSpec() plan.TriggerSpec {
+	return plan.NarrowTransformationTriggerSpec{}
+}
+
+func createRollingTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*RollingProcedureSpec)
+	if !ok {
+		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
+	}
+	return NewRollingTransformation(a.Context(), id, s, a.Allocator())
+}
+
+// rollingTransformation sets a column to the aggregate of the window
+// of rows that ends at each row of the table.
+//
+// The window contains the last n rows or the rows within the
+// duration before the time of the row. Rows are expected to be
+// sorted by time when the window is a duration.
+type rollingTransformation struct {
+	ctx        context.Context
+	n          int
+	every      values.Duration
+	column     string
+	timeColumn string
+	as         string
+	aggregate  plan.ProcedureKind
+
+	scope compiler.Scope
+	fn    *semantic.FunctionExpression
+	fns   map[flux.ColType]compiler.Func
+}
+
+// rollingState contains the rows in the current window of a table.
+type rollingState struct {
+	typ    flux.ColType
+	window rollingWindow
+}
+
+func NewRollingTransformation(ctx context.Context, id execute.DatasetID, spec *RollingProcedureSpec, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	tr := &rollingTransformation{
+		ctx:        ctx,
+		n:          int(spec.N),
+		every:      values.Duration(spec.Every),
+		column:     spec.Column,
+		timeColumn: spec.TimeColumn,
+		as:         spec.As,
+		aggregate:  spec.Aggregate,
+	}
+	if spec.Fn.Fn != nil {
+		tr.scope = compiler.ToScope(spec.Fn.Scope)
+		tr.fn = spec.Fn.Fn
+		tr.fns = make(map[flux.ColType]compiler.Func)
+	}
+	return execute.NewNarrowStateTransformation[*rollingState](id, tr, mem)
+}
+
+func (t *rollingTransformation) Process(chunk table.Chunk, state *rollingState, d *execute.TransportDataset, mem memory.Allocator) (*rollingState, bool, error) {
+	if chunk.Key().HasCol(t.as) {
+		return nil, false, errors.Newf(codes.FailedPrecondition, "cannot set group key column %q", t.as)
+	}
+
+	idx := chunk.Index(t.column)
+	if idx < 0 {
+		return nil, false, errors.Newf(codes.FailedPrecondition, "rolling: column %q does not exist", t.column)
+	}
+	typ := chunk.Col(idx).Type
+
+	var ts *array.Int
+	if t.n == 0 {
+		j := chunk.Index(t.timeColumn)
+		if j < 0 {
+			return nil, false, errors.Newf(codes.FailedPrecondition, "rolling: time column %q does not exist", t.timeColumn)
+		} else if c := chunk.Col(j); c.Type != flux.TTime {
+			return nil, false, errors.Newf(codes.FailedPrecondition, "rolling: time column %q has type %s", c.Label, c.Type)
+		}
+		ts = chunk.Ints(j)
+	}
+
+	if state == nil {
+		w, err := t.newWindow(typ)
+		if err != nil {
+			return nil, false, err
+		}
+		state = &rollingState{typ: typ, window: w}
+	} else if state.typ != typ {
+		return nil, false, errors.Newf(codes.FailedPrecondition, "schema collision detected: column \"%s\" is both of type %s and %s", t.column, typ, state.typ)
+	}
+
+	b := arrow.NewBuilder(state.window.Type(), mem)
+	b.Resize(chunk.Len())
+	if err := state.window.Process(t.ctx, chunk, idx, ts, b); err != nil {
+		b.Release()
+		return nil, false, err
+	}
+
+	out := withColumn(chunk, t.as, state.window.Type(), b.NewArray())
+	if err := d.Process(out); err != nil {
+		return nil, false, err
+	}
+	return state, true, nil
+}
+
+// newWindow creates the window for a table where the column has the given type.
+func (t *rollingTransformation) newWindow(typ flux.ColType) (rollingWindow, error) {
+	if t.fn != nil {
+		fn, err := t.compile(typ)
+		if err != nil {
+			return nil, err
+		}
+		return newRollingBuffer[values.Value](t, &rollingFn{fn: fn, typ: typ}, rollingValueReader), nil
+	}
+
+	switch typ {
+	case flux.TInt:
+		return newRollingNumberWindow[int64](t, typ)
+	case flux.TUInt:
+		return newRollingNumberWindow[uint64](t, typ)
+	case flux.TFloat:
+		return newRollingNumberWindow[float64](t, typ)
+	default:
+		if t.aggregate == CountKind {
+			return newRollingBuffer[values.Value](t, &rollingCount[values.Value]{}, rollingValueReader), nil
+		}
+		return nil, errors.Newf(codes.FailedPrecondition, "cannot compute rolling %s of column %q (type %s)", t.aggregate, t.column, typ)
+	}
+}
+
+func newRollingNumberWindow[T rollingNumber](t *rollingTransformation, typ flux.ColType) (rollingWindow, error) {
+	var agg rollingAggregate[T]
+	switch t.aggregate {
+	case CountKind:
+		agg = &rollingCount[T]{}
+	case SumKind:
+		agg = &rollingSum[T]{typ: typ}
+	case MeanKind:
+		agg = &rollingMean[T]{}
+	case MinKind:
+		agg = &rollingSelector[T]{typ: typ, less: func(a, b T) bool { return a < b }}
+	case MaxKind:
+		agg = &rollingSelector[T]{typ: typ, less: func(a, b T) bool { return a > b }}
+	default:
+		return nil, errors.Newf(codes.Internal, "unknown rolling aggregate %q", t.aggregate)
+	}
+	return newRollingBuffer[T](t, agg, rollingNumberReader[T]), nil
+}
+
+// compile compiles the custom function for the type of the column.
+func (t *rollingTransformation) compile(typ flux.ColType) (compiler.Func, error) {
+	if fn, ok := t.fns[typ]; ok {
+		return fn, nil
+	}
+
+	in := semantic.NewObjectType([]semantic.PropertyType{
+		{Key: []byte(rollingValuesParamName), Value: semantic.NewArrayType(flux.SemanticType(typ))},
+	})
+	fn, err := compiler.Compile(t.scope, t.fn, in)
+	if err != nil {
+		return nil, err
+	} else if flux.ColumnType(fn.Type()) == flux.TInvalid {
+		return nil, errors.Newf(codes.Invalid, "rolling function must return a basic type, got %s", fn.Type())
+	}
+	t.fns[typ] = fn
+	return fn, nil
+}
+
+func (t *rollingTransformation) Close() error {
+	return nil
+}
+
+// rollingWindow is the window of rows that ends at the current
+// row of a table.
+type rollingWindow interface {
+	// Type returns the type of the aggregate.
+	Type() flux.ColType
+
+	// Process appends the aggregate of the window that ends at each row
+	// of the chunk to the builder.
+	Process(ctx context.Context, chunk table.Chunk, idx int, ts *array.Int, b array.Builder) error
+}
+
+// rollingEntry is a row in a window.
+type rollingEntry[T any] struct {
+	seq   int64
+	time  int64
+	value T
+	valid bool
+}
+
+// rollingReader returns a function that reads the values of a column.
+type rollingReader[T any] func(chunk table.Chunk, idx int) func(i int) (T, bool)
+
+// rollingAggregate computes the aggregate of the rows in a window
+// as they are added and removed.
+type rollingAggregate[T any] interface {
+	Type() flux.ColType
+	Add(e rollingEntry[T])
+	Remove(e rollingEntry[T])
+	Append(ctx context.Context, window *rollingRing[T], b array.Builder) error
+}
+
+// rollingBuffer keeps the rows of a window in a ring buffer.
+type rollingBuffer[T any] struct {
+	n     int
+	every values.Duration
+
+	entries rollingRing[T]
+	seq     int64
+	agg     rollingAggregate[T]
+	read    rollingReader[T]
+}
+
+func newRollingBuffer[T any](t *rollingTransformation, agg rollingAggregate[T], read rollingReader[T]) *rollingBuffer[T] {
+	return &rollingBuffer[T]{
+		n:     t.n,
+		every: t.every,
+		agg:   agg,
+		read:  read,
+	}
+}
+
+func (w *rollingBuffer[T]) Type() flux.ColType {
+	return w.agg.Type()
+}
+
+func (w *rollingBuffer[T]) Process(ctx context.Context, chunk table.Chunk, idx int, ts *array.Int, b array.Builder) error {
+	read := w.read(chunk, idx)
+	for i, n := 0, chunk.Len(); i < n; i++ {
+		e := rollingEntry[T]{seq: w.seq}
+		w.seq++
+
+		if ts != nil {
+			if ts.IsNull(i) {
+				return errors.New(codes.FailedPrecondition, "rolling: time column contains null values")
+			}
+			e.time = ts.Value(i)
+			start := int64(values.Time(e.time).Add(w.every.Mul(-1)))
+			for w.entries.Len() > 0 && w.entries.Front().time <= start {
+				w.agg.Remove(w.entries.PopFront())
+			}
+		} else if w.entries.Len() == w.n {
+			w.agg.Remove(w.entries.PopFront())
+		}
+
+		e.value, e.valid = read(i)
+		w.entries.PushBack(e)
+		w.agg.Add(e)
+		if err := w.agg.Append(ctx, &w.entries, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rollingRing is a double ended queue backed by a ring buffer.
+type rollingRing[T any] struct {
+	buf  []rollingEntry[T]
+	head int
+	len  int
+}
+
+func (r *rollingRing[T]) Len() int {
+	return r.len
+}
+
+func (r *rollingRing[T]) At(i int) rollingEntry[T] {
+	return r.buf[(r.head+i)%len(r.buf)]
+}
+
+func (r *rollingRing[T]) Front() rollingEntry[T] {
+	return r.At(0)
+}
+
+func (r *rollingRing[T]) Back() rollingEntry[T] {
+	return r.At(r.len - 1)
+}
+
+func (r *rollingRing[T]) PushBack(e rollingEntry[T]) {
+	if r.len == len(r.buf) {
+		buf := make([]rollingEntry[T], 2*len(r.buf)+1)
+		for i := 0; i < r.len; i++ {
+			buf[i] = r.At(i)
+		}
+		r.buf, r.head = buf, 0
+	}
+	r.buf[(r.head+r.len)%len(r.buf)] = e
+	r.len++
+}
+
+func (r *rollingRing[T]) PopFront() rollingEntry[T] {
+	e := r.Front()
+	r.head = (r.head + 1) % len(r.buf)
+	r.len--
+	return e
+}
+
+func (r *rollingRing[T]) PopBack() rollingEntry[T] {
+	e := r.Back()
+	r.len--
+	return e
+}
+
+type rollingNumber interface {
+	int64 | uint64 | float64
+}
+
+func rollingNumberReader[T rollingNumber](chunk table.Chunk, idx int) func(i int) (T, bool) {
+	switch vs := chunk.Values(idx).(type) {
+	case *array.Int:
+		return func(i int) (T, bool) { return T(vs.Value(i)), vs.IsValid(i) }
+	case *array.Uint:
+		return func(i int) (T, bool) { return T(vs.Value(i)), vs.IsValid(i) }
+	case *array.Float:
+		return func(i int) (T, bool) { return T(vs.Value(i)), vs.IsValid(i) }
+	default:
+		return func(i int) (T, bool) { return 0, false }
+	}
+}
+
+func rollingValueReader(chunk table.Chunk, idx int) func(i int) (values.Value, bool) {
+	buffer := chunk.Buffer()
+	return func(i int) (values.Value, bool) {
+		v := execute.ValueForRow(&buffer, i, idx)
+		return v, !v.IsNull()
+	}
+}
+
+func appendRollingNumber[T rollingNumber](b array.Builder, v T) error {
+	switch v := any(v).(type) {
+	case int64:
+		return arrow.AppendInt(b, v)
+	case uint64:
+		return arrow.AppendUint(b, v)
+	default:
+		return arrow.AppendFloat(b, v.(float64))
+	}
+}
+
+// rollingCount counts the non-null values in the window.
+type rollingCount[T any] struct {
+	n int64
+}
+
+func (a *rollingCount[T]) Type() flux.ColType {
+	return flux.TInt
+}
+
+func (a *rollingCount[T]) Add(e rollingEntry[T]) {
+	if e.valid {
+		a.n++
+	}
+}
+
+func (a *rollingCount[T]) Remove(e rollingEntry[T]) {
+	if e.valid {
+		a.n--
+	}
+}
+
+func (a *rollingCount[T]) Append(ctx context.Context, window *rollingRing[T], b array.Builder) error {
+	return arrow.AppendInt(b, a.n)
+}
+
+// rollingSum sums the non-null values in the window.
+// The sum is null if the window has no values.
+type rollingSum[T rollingNumber] struct {
+	typ flux.ColType
+	sum T
+	n   int64
+}
+
+func (a *rollingSum[T]) Type() flux.ColType {
+	return a.typ
+}
+
+func (a *rollingSum[T]) Add(e rollingEntry[T]) {
+	if e.valid {
+		a.sum += e.value
+		a.n++
+	}
+}
+
+func (a *rollingSum[T]) Remove(e rollingEntry[T]) {
+	if e.valid {
+		a.sum -= e.value
+		a.n--
+	}
+}
+
+func (a *rollingSum[T]) Append(ctx context.Context, window *rollingRing[T], b array.Builder) error {
+	if a.n == 0 {
+		b.AppendNull()
+		return nil
+	}
+	return appendRollingNumber(b, a.sum)
+}
+
+// rollingMean averages the non-null values in the window.
+type rollingMean[T rollingNumber] struct {
+	sum float64
+	n   int64
+}
+
+func (a *rollingMean[T]) Type() flux.ColType {
+	return flux.TFloat
+}
+
+func (a *rollingMean[T]) Add(e rollingEntry[T]) {
+	if e.valid {
+		a.sum += float64(e.value)
+		a.n++
+	}
+}
+
+func (a *rollingMean[T]) Remove(e rollingEntry[T]) {
+	if e.valid {
+		a.sum -= float64(e.value)
+		a.n--
+	}
+}
+
+func (a *rollingMean[T]) Append(ctx context.Context, window *rollingRing[T], b array.Builder) error {
+	if a.n == 0 {
+		b.AppendNull()
+		return nil
+	}
+	return arrow.AppendFloat(b, a.sum/float64(a.n))
+}
+
+// rollingSelector selects the minimum or maximum value in the window.
+//
+// It keeps the values that may still be selected in a queue where
+// each value is selected over the values after it, so the selected
+// value is always at the front of the queue.
+type rollingSelector[T rollingNumber] struct {
+	typ   flux.ColType
+	less  func(a, b T) bool
+	queue rollingRing[T]
+}
+
+func (a *rollingSelector[T]) Type() flux.ColType {
+	return a.typ
+}
+
+func (a *rollingSelector[T]) Add(e rollingEntry[T]) {
+	if !e.valid {
+		return
+	}
+	for a.queue.Len() > 0 && !a.less(a.queue.Back().value, e.value) {
+		a.queue.PopBack()
+	}
+	a.queue.PushBack(e)
+}
+
+func (a *rollingSelector[T]) Remove(e rollingEntry[T]) {
+	if a.queue.Len() > 0 && a.queue.Front().seq == e.seq {
+		a.queue.PopFront()
+	}
+}
+
+func (a *rollingSelector[T]) Append(ctx context.Context, window *rollingRing[T], b array.Builder) error {
+	if a.queue.Len() == 0 {
+		b.AppendNull()
+		return nil
+	}
+	return appendRollingNumber(b, a.queue.Front().value)
+}
+
+// rollingFn calls a custom function with the non-null values
+// in the window.
+type rollingFn struct {
+	fn  compiler.Func
+	typ flux.ColType
+}
+
+func (a *rollingFn) Type() flux.ColType {
+	return flux.ColumnType(a.fn.Type())
+}
+
+func (a *rollingFn) Add(e rollingEntry[values.Value]) {}
+
+func (a *rollingFn) Remove(e rollingEntry[values.Value]) {}
+
+func (a *rollingFn) Append(ctx context.Context, window *rollingRing[values.Value], b array.Builder) error {
+	elements := make([]values.Value, 0, window.Len())
+	for i := 0; i < window.Len(); i++ {
+		if e := window.At(i); e.valid {
+			elements = append(elements, e.value)
+		}
+	}
+	arr := values.NewArrayWithBacking(semantic.NewArrayType(flux.SemanticType(a.typ)), elements)
+	in := values.NewObjectWithValues(map[string]values.Value{rollingValuesParamName: arr})
+
+	v, err := a.fn.Eval(ctx, in)
+	if err != nil {
+		return errors.Wrap(err, codes.Inherit, "failed to evaluate rolling function")
+	}
+	return arrow.AppendValue(b, v)
+}
@@ -103,6 +103,7 @@ func init() {
 	}
 
 	plan.RegisterProcedureSpec(SchemaMutationKind, newSchemaMutationProcedure, SchemaMutationOps...)
+	plan.RegisterLogicalRules(MergeSchemaMutationRule{})
 	execute.RegisterTransformation(SchemaMutationKind, createSchemaMutationTransformation)
 }
 
@@ -396,6 +397,42 @@ func newSchemaMutationProcedure(qs flux.OperationSpec, pa plan.Administration) (
 	}, nil
 }
 
+// MergeSchemaMutationRule merges consecutive schema mutations
+// into a single schema mutation.
+//
+// The mutations of the merged node are applied in order to the schema
+// of each table and the columns of the input are referenced by the
+// output without being copied, so a chain of rename, drop, keep
+// and duplicate reads each table once.
+type MergeSchemaMutationRule struct{}
+
+func (r MergeSchemaMutationRule) Name() string {
+	return "MergeSchemaMutationRule"
+}
+
+// Pattern returns the pattern that matches `drop |> rename`
+// and any other pair of schema mutations.
+func (r MergeSchemaMutationRule) Pattern() plan.Pattern {
+	return plan.MultiSuccessor(SchemaMutationKind, plan.SingleSuccessor(SchemaMutationKind))
+}
+
+func (r MergeSchemaMutationRule) Rewrite(ctx context.Context, last plan.Node) (plan.Node, bool, error) {
+	first := last.Predecessors()[0]
+	firstSpec := first.ProcedureSpec().(*SchemaMutationProcedureSpec)
+	lastSpec := last.ProcedureSpec().(*SchemaMutationProcedureSpec)
+
+	spec := firstSpec.Copy().(*SchemaMutationProcedureSpec)
+	for _, m := range lastSpec.Mutations {
+		spec.Mutations = append(spec.Mutations, m.Copy())
+	}
+
+	merged, err := plan.MergeToLogicalNode(last, first, spec)
+	if err != nil {
+		return nil, false, err
+	}
+	return merged, true, nil
+}
+
 type schemaMutationTransformation struct {
 	execute.ExecutionNode
 	d        execute.Dataset
@@ -13,6 +13,7 @@ import (
 	"github.com/influxdata/flux/interpreter"
 	"github.com/influxdata/flux/memory"
 	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/plan/plantest"
 	"github.com/influxdata/flux/querytest"
 	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
 	"github.com/influxdata/flux/stdlib/universe"
@@ -1518,39 +1519,131 @@ func TestDropRenameKeep_Process(t *testing.T) {
 	}
 }
 
-// TODO: determine SchemaMutationProcedureSpec pushdown/rewrite rules
-/*
-func TestRenameDrop_PushDown(t *testing.T) {
-	m1, _ := functions.NewRenameMutator(&functions.RenameOpSpec{
-		Cols: map[string]string{},
-	})
+func TestMergeSchemaMutationRule(t *testing.T) {
+	var (
+		from = &influxdb.FromProcedureSpec{}
+		drop = &universe.SchemaMutationProcedureSpec{
+			Mutations: []universe.SchemaMutation{
+				&universe.DropOpSpec{Columns: []string{"_start", "_stop"}},
+			},
+		}
+		rename = &universe.SchemaMutationProcedureSpec{
+			Mutations: []universe.SchemaMutation{
+				&universe.RenameOpSpec{Columns: map[string]string{"_value": "v"}},
+			},
+		}
+		duplicate = &universe.SchemaMutationProcedureSpec{
+			Mutations: []universe.SchemaMutation{
+				&universe.DuplicateOpSpec{Column: "v", As: "w"},
+			},
+		}
+		filter = &universe.FilterProcedureSpec{}
+	)
 
-	root := &plan.Procedure{
-		Spec: &functions.SchemaMutationProcedureSpec{
-			Mutations: []functions.SchemaMutator{m1},
+	tests := []plantest.RuleTestCase{
+		{
+			Name:  "single mutation",
+			Rules: []plan.Rule{universe.MergeSchemaMutationRule{}},
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreateLogicalNode("from", from),
+					plan.CreateLogicalNode("drop", drop),
+				},
+				Edges: [][2]int{
+					{0, 1},
+				},
+			},
+			NoChange: true,
+		},
+		{
+			Name:  "drop rename",
+			Rules: []plan.Rule{universe.MergeSchemaMutationRule{}},
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreateLogicalNode("from", from),
+					plan.CreateLogicalNode("drop", drop),
+					plan.CreateLogicalNode("rename", rename),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+				},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreateLogicalNode("from", from),
+					plan.CreateLogicalNode("merged_drop_rename", &universe.SchemaMutationProcedureSpec{
+						Mutations: []universe.SchemaMutation{
+							drop.Mutations[0],
+							rename.Mutations[0],
+						},
+					}),
+				},
+				Edges: [][2]int{
+					{0, 1},
+				},
+			},
+		},
+		{
+			Name:  "drop rename duplicate",
+			Rules: []plan.Rule{universe.MergeSchemaMutationRule{}},
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreateLogicalNode("from", from),
+					plan.CreateLogicalNode("drop", drop),
+					plan.CreateLogicalNode("rename", rename),
+					plan.CreateLogicalNode("duplicate", duplicate),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+					{2, 3},
+				},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreateLogicalNode("from", from),
+					plan.CreateLogicalNode("merged_drop_rename_duplicate", &universe.SchemaMutationProcedureSpec{
+						Mutations: []universe.SchemaMutation{
+							drop.Mutations[0],
+							rename.Mutations[0],
+							duplicate.Mutations[0],
+						},
+					}),
+				},
+				Edges: [][2]int{
+					{0, 1},
+				},
+			},
+		},
+		{
+			Name:  "mutation with multiple successors",
+			Rules: []plan.Rule{universe.MergeSchemaMutationRule{}},
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreateLogicalNode("from", from),
+					plan.CreateLogicalNode("drop", drop),
+					plan.CreateLogicalNode("rename", rename),
+					plan.CreateLogicalNode("filter", filter),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+					{1, 3},
+				},
+			},
+			NoChange: true,
 		},
 	}
 
-	m2, _ := functions.NewDropMutator(&functions.DropOpSpec{
-		Cols: []string{},
-	})
-
-	m3, _ := functions.NewKeepMutator(&functions.KeepOpSpec{
-		Cols: []string{},
-	})
-
-	spec := &functions.SchemaMutationProcedureSpec{
-		Mutations: []functions.SchemaMutator{m2, m3},
-	}
-
-	want := &plan.Procedure{
-		Spec: &functions.SchemaMutationProcedureSpec{
-			Mutations: []functions.SchemaMutator{m1, m2, m3},
-		},
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			plantest.LogicalRuleTestHelper(t, &tc)
+		})
 	}
-	plantest.PhysicalPlan_PushDown_TestHelper(t, spec, root, false, want)
 }
-*/
 
 func BenchmarkKeep_Values(b *testing.B) {
 	b.Run("1000", func(b *testing.B) {
@@ -351,19 +351,3 @@ func duplicate(col flux.ColMeta, dupName string) flux.ColMeta {
 		Label: dupName,
 	}
 }
-
-// TODO: determine pushdown rules
-/*
-func (s *SchemaMutationProcedureSpec) PushDownRules() []plan.PushDownRule {
-	return []plan.PushDownRule{{
-		Root:    SchemaMutationKind,
-		Through: nil,
-		Match:   nil,
-	}}
-}
-
-func (s *SchemaMutationProcedureSpec) PushDown(root *plan.Procedure, dup func() *plan.Procedure) {
-	rootSpec := root.Spec.(*SchemaMutationProcedureSpec)
-	rootSpec.Mutations = append(rootSpec.Mutations, s.Mutations...)
-}
-*/
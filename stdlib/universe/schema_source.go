@@ -0,0 +1,49 @@
+package universe
+
+import (
+	"context"
+
+	"github.com/influxdata/flux/plan"
+)
+
+func init() {
+	plan.RegisterPhysicalRules(SchemaSourceRule{})
+}
+
+// SchemaSourceRule replaces a source that is only read by a
+// transformation that inspects the schema of its input with
+// a source that reads the schema from metadata.
+//
+// The columns() and keys() transformations only look at the columns
+// and group keys of their input so the source does not need
+// to produce any rows.
+type SchemaSourceRule struct{}
+
+func (SchemaSourceRule) Name() string {
+	return "SchemaSourceRule"
+}
+
+func (SchemaSourceRule) Pattern() plan.Pattern {
+	return plan.AnySingleSuccessor()
+}
+
+func (SchemaSourceRule) Rewrite(ctx context.Context, node plan.Node) (plan.Node, bool, error) {
+	if len(node.Predecessors()) > 0 {
+		return node, false, nil
+	}
+	src, ok := node.ProcedureSpec().(plan.SchemaSource)
+	if !ok {
+		return node, false, nil
+	}
+	switch node.Successors()[0].Kind() {
+	case ColumnsKind, KeysKind:
+	default:
+		return node, false, nil
+	}
+
+	spec, ok := src.SchemaSpec(ctx)
+	if !ok {
+		return node, false, nil
+	}
+	return plan.CreatePhysicalNode(node.ID(), spec), true, nil
+}
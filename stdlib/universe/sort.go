@@ -35,6 +35,7 @@ func init() {
 	runtime.RegisterPackageValue("universe", SortKind, flux.MustValue(flux.FunctionValue(SortKind, createSortOpSpec, sortSignature)))
 	plan.RegisterProcedureSpec(SortKind, newSortProcedure, SortKind)
 	plan.RegisterPhysicalRules(RemoveRedundantSort{})
+	plan.RegisterStableOutputRules(StableOutputSortRule{})
 	execute.RegisterTransformation(SortKind, createSortTransformation)
 }
 
@@ -268,9 +269,18 @@ type sortTableMergeHeapItem struct {
 	i, offset int
 }
 
+// Len returns the number of rows to merge from the item.
+// When the item has indices, only the rows at the indices are merged.
+func (s *sortTableMergeHeapItem) Len() int {
+	if s.indices != nil {
+		return s.indices.Len()
+	}
+	return s.cr.Len()
+}
+
 func (s *sortTableMergeHeapItem) Next() bool {
 	s.i++
-	if s.i >= s.cr.Len() {
+	if s.i >= s.Len() {
 		return false
 	}
 	s.offset = s.i
@@ -332,7 +342,7 @@ func (s *sortTableMergeHeap) Pop() interface{} {
 func (s *sortTableMergeHeap) ValueLen() int {
 	var n int
 	for _, item := range s.items {
-		n += item.cr.Len() - item.i
+		n += item.Len() - item.i
 	}
 	return n
 }
@@ -511,3 +521,47 @@ func (r RemoveRedundantSort) Rewrite(ctx context.Context, node plan.Node) (plan.
 
 	return node, false, nil
 }
+
+// StableOutputSortRule is a planner rule that sorts the rows of the tables
+// of a result by time when the plan is created with plan.StableOutput.
+// A sort is only inserted before a yield when its input is not already
+// sorted by time.
+type StableOutputSortRule struct{}
+
+var _ plan.Rule = StableOutputSortRule{}
+
+func (r StableOutputSortRule) Name() string {
+	return "universe/StableOutputSortRule"
+}
+
+func (r StableOutputSortRule) Pattern() plan.Pattern {
+	return plan.AnyMultiSuccessor()
+}
+
+func (r StableOutputSortRule) Rewrite(ctx context.Context, node plan.Node) (plan.Node, bool, error) {
+	if _, ok := node.ProcedureSpec().(plan.YieldProcedureSpec); !ok || len(node.Predecessors()) != 1 {
+		return node, false, nil
+	}
+
+	pred := node.Predecessors()[0]
+	if _, ok := pred.ProcedureSpec().(plan.YieldProcedureSpec); ok {
+		// The preceding yield orders the rows for both results.
+		return node, false, nil
+	}
+
+	sortSpec := &SortProcedureSpec{
+		Columns: []string{execute.DefaultTimeColLabel},
+	}
+	inputCollation := plan.GetOutputAttribute(pred, plan.CollationKey)
+	if inputCollation != nil && sortSpec.OutputAttributes()[plan.CollationKey].SatisfiedBy(inputCollation) {
+		return node, false, nil // input to yield is already sorted
+	}
+
+	sortNode := plan.CreateUniquePhysicalNode(ctx, "sort_stable_output", sortSpec)
+	sortNode.AddPredecessors(pred)
+	sortNode.AddSuccessors(node)
+	succs := pred.Successors()
+	succs[plan.IndexOfNode(node, succs)] = sortNode
+	node.Predecessors()[0] = sortNode
+	return node, true, nil
+}
@@ -1,7 +1,9 @@
 package universe
 
 import (
+	"container/heap"
 	"context"
+	"sort"
 
 	"github.com/apache/arrow/go/v7/arrow/memory"
 	"github.com/influxdata/flux"
@@ -12,6 +14,7 @@ import (
 	"github.com/influxdata/flux/execute/table"
 	"github.com/influxdata/flux/internal/arrowutil"
 	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/internal/mutable"
 	"github.com/influxdata/flux/plan"
 )
 
@@ -113,13 +116,114 @@ func (s *sortLimitTransformation) appendChunk(mh *sortTableMergeHeap, chunk tabl
 
 	item := &sortTableMergeHeapItem{cr: &buffer}
 	if !s.isSorted(&buffer, mh.sortCols) {
-		item.indices = s.sort(&buffer, mh.sortCols)
-		item.offset = int(item.indices.Value(0))
+		if n := int(s.limit); n > 0 && n < buffer.Len() {
+			// Only keep the selected rows so the rest
+			// of the buffer can be released.
+			indices := s.topK(&buffer, mh.sortCols, n)
+			item.cr = s.take(&buffer, indices, mem)
+			indices.Release()
+			buffer.Release()
+		} else {
+			item.indices = s.sort(&buffer, mh.sortCols)
+			item.offset = int(item.indices.Value(0))
+		}
 	}
 	mh.items = append(mh.items, item)
 	return nil
 }
 
+// take copies the rows of the buffer at the indices into a new buffer.
+func (s *sortLimitTransformation) take(buffer *arrow.TableBuffer, indices *array.Int, mem memory.Allocator) *arrow.TableBuffer {
+	cpy := &arrow.TableBuffer{
+		GroupKey: buffer.GroupKey,
+		Columns:  buffer.Columns,
+		Values:   make([]array.Array, len(buffer.Values)),
+	}
+	for i, vs := range buffer.Values {
+		cpy.Values[i] = arrowutil.CopyByIndex(vs, indices, mem)
+	}
+	return cpy
+}
+
+// topK returns the indices of the first k rows of the buffer in sorted order.
+//
+// The rows are selected with a bounded heap instead of sorting the buffer,
+// so only k indices are kept while the buffer is scanned.
+func (s *sortLimitTransformation) topK(cr flux.ColReader, cols []int, k int) *array.Int {
+	h := &topKHeap{
+		offsets: make([]int64, 0, k),
+		cr:      cr,
+		cols:    cols,
+		compare: s.compare,
+	}
+	for i, n := 0, cr.Len(); i < n; i++ {
+		if len(h.offsets) < k {
+			heap.Push(h, int64(i))
+			continue
+		}
+		// The root is the last of the selected rows. A row that compares
+		// equal to it comes later in the buffer, so it is not selected.
+		if h.cmp(int64(i), h.offsets[0]) < 0 {
+			h.offsets[0] = int64(i)
+			heap.Fix(h, 0)
+		}
+	}
+
+	// Sort the selected rows in ascending order.
+	sort.Slice(h.offsets, func(i, j int) bool {
+		return h.Less(j, i)
+	})
+
+	indices := mutable.NewInt64Array(s.mem)
+	indices.AppendValues(h.offsets)
+	return indices.NewInt64Array()
+}
+
+// topKHeap is a heap of buffer offsets with the last row in sorted order at the root.
+// Rows that compare equal are ordered by their offset to keep the sort stable.
+type topKHeap struct {
+	offsets []int64
+	cr      flux.ColReader
+	cols    []int
+	compare arrowutil.CompareFunc
+}
+
+func (h *topKHeap) cmp(i, j int64) int {
+	for _, col := range h.cols {
+		arr := table.Values(h.cr, col)
+		if cmp := h.compare(arr, arr, int(i), int(j)); cmp != 0 {
+			return cmp
+		}
+	}
+	return 0
+}
+
+func (h *topKHeap) Len() int {
+	return len(h.offsets)
+}
+
+func (h *topKHeap) Less(i, j int) bool {
+	x, y := h.offsets[i], h.offsets[j]
+	if cmp := h.cmp(x, y); cmp != 0 {
+		return cmp > 0
+	}
+	return x > y
+}
+
+func (h *topKHeap) Swap(i, j int) {
+	h.offsets[i], h.offsets[j] = h.offsets[j], h.offsets[i]
+}
+
+func (h *topKHeap) Push(x interface{}) {
+	h.offsets = append(h.offsets, x.(int64))
+}
+
+func (h *topKHeap) Pop() interface{} {
+	x := h.offsets[len(h.offsets)-1]
+	h.offsets = h.offsets[:len(h.offsets)-1]
+	return x
+}
+
 func (s *sortLimitTransformation) reconcileSchema(mh *sortTableMergeHeap, buffer *arrow.TableBuffer, mem memory.Allocator) {
 	if len(buffer.Columns) == len(mh.cols) {
 		equivalent := true
@@ -2,11 +2,15 @@ package universe_test
 
 import (
 	"context"
+	"math/rand"
+	"sort"
 	"testing"
 
+	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/dependency"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/memory"
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/plan/plantest"
 	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
@@ -86,3 +90,140 @@ func TestSortLimitRule(t *testing.T) {
 		})
 	}
 }
+
+func TestSortLimit_Process(t *testing.T) {
+	// Values repeat so rows with equal values check that the sort is stable.
+	r := rand.New(rand.NewSource(0))
+	data := make([][]interface{}, 1000)
+	for i := range data {
+		var v interface{}
+		if i%97 != 0 {
+			v = float64(r.Intn(100))
+		}
+		data[i] = []interface{}{execute.Time(i), "a", v}
+	}
+
+	for _, desc := range []bool{false, true} {
+		for _, n := range []int{1, 10, 999, 1000, 2000} {
+			// The rows are expected in the order of a stable sort.
+			rows := make([][]interface{}, len(data))
+			copy(rows, data)
+			sort.SliceStable(rows, func(i, j int) bool {
+				x, y := rows[i][2], rows[j][2]
+				if x == nil || y == nil {
+					// Nulls are before every value in both orders.
+					return x == nil && y != nil
+				}
+				if desc {
+					return x.(float64) > y.(float64)
+				}
+				return x.(float64) < y.(float64)
+			})
+			if n < len(rows) {
+				rows = rows[:n]
+			}
+
+			cols := []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "t", Type: flux.TString},
+				{Label: "_value", Type: flux.TFloat},
+			}
+			executetest.ProcessTestHelper2(
+				t,
+				[]flux.Table{&executetest.Table{KeyCols: []string{"t"}, ColMeta: cols, Data: data}},
+				[]*executetest.Table{{KeyCols: []string{"t"}, ColMeta: cols, Data: rows}},
+				nil,
+				func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+					tr, d, err := universe.NewSortLimitTransformation(id, &universe.SortLimitProcedureSpec{
+						SortProcedureSpec: &universe.SortProcedureSpec{
+							Columns: []string{"_value"},
+							Desc:    desc,
+						},
+						N: int64(n),
+					}, alloc)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return tr, d
+				},
+			)
+		}
+	}
+}
+
+func TestSortLimit_MemoryBounded(t *testing.T) {
+	const (
+		n       = 10
+		tables  = 10
+		numRows = 10000
+	)
+
+	cols := []flux.ColMeta{
+		{Label: "_time", Type: flux.TTime},
+		{Label: "t", Type: flux.TString},
+		{Label: "_value", Type: flux.TFloat},
+	}
+	// The values decrease across all of the tables so
+	// the last rows of the last table are selected.
+	newTable := func(i int, alloc memory.Allocator) *executetest.Table {
+		rows := make([][]interface{}, numRows)
+		for j := range rows {
+			rows[j] = []interface{}{execute.Time(i*numRows + j), "a", float64((tables-i)*numRows - j)}
+		}
+		return &executetest.Table{KeyCols: []string{"t"}, ColMeta: cols, Data: rows, Alloc: alloc}
+	}
+
+	// Measure the memory of a single input table.
+	probe := &memory.ResourceAllocator{}
+	var tableSize int64
+	if err := newTable(0, probe).Do(func(flux.ColReader) error {
+		tableSize = probe.Allocated()
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The input tables are allocated separately from the transformation
+	// so the memory that the transformation holds can be measured.
+	in := &memory.ResourceAllocator{}
+	data := make([]flux.Table, 0, tables)
+	for i := 0; i < tables; i++ {
+		data = append(data, newTable(i, in))
+	}
+	want := make([][]interface{}, n)
+	for k := range want {
+		want[k] = []interface{}{execute.Time(tables*numRows - 1 - k), "a", float64(k + 1)}
+	}
+
+	mem := &memory.ResourceAllocator{}
+	executetest.ProcessTestHelper2(
+		t,
+		data,
+		[]*executetest.Table{{KeyCols: []string{"t"}, ColMeta: cols, Data: want}},
+		nil,
+		func(id execute.DatasetID, alloc memory.Allocator) (execute.Transformation, execute.Dataset) {
+			tr, d, err := universe.NewSortLimitTransformation(id, &universe.SortLimitProcedureSpec{
+				SortProcedureSpec: &universe.SortProcedureSpec{
+					Columns: []string{"_value"},
+				},
+				N: n,
+			}, mem)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return tr, d
+		},
+	)
+
+	// Each input table is released once its rows have been selected
+	// so the input tables are not held until the end.
+	if got := in.Allocated(); got != 0 {
+		t.Errorf("input tables were not released: %d bytes are still allocated", got)
+	}
+	if got, limit := in.MaxAllocated(), 2*tableSize; got > limit {
+		t.Errorf("input tables were held by sort limit: got %d bytes, want at most %d", got, limit)
+	}
+	if got, limit := mem.MaxAllocated(), tableSize/10; got > limit {
+		t.Errorf("sort limit held too much memory: got %d bytes, want at most %d", got, limit)
+	}
+}
@@ -7,6 +7,9 @@ import (
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/execute/executetest"
 	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/plan/plantest"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
 	"github.com/influxdata/flux/stdlib/universe"
 )
 
@@ -384,3 +387,98 @@ func TestSort_Process(t *testing.T) {
 		})
 	}
 }
+
+func TestStableOutputSortRule(t *testing.T) {
+	from := &influxdb.FromProcedureSpec{
+		Bucket: influxdb.NameOrID{Name: "testbucket"},
+	}
+	yield := &universe.YieldProcedureSpec{Name: "_result"}
+	sortTime := &universe.SortProcedureSpec{
+		Columns: []string{execute.DefaultTimeColLabel},
+	}
+
+	tests := []plantest.RuleTestCase{
+		{
+			Name:  "InsertSort",
+			Rules: []plan.Rule{universe.StableOutputSortRule{}},
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("from0", from),
+					plan.CreatePhysicalNode("yield1", yield),
+				},
+				Edges: [][2]int{
+					{0, 1},
+				},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("from0", from),
+					plan.CreatePhysicalNode("sort_stable_output", sortTime),
+					plan.CreatePhysicalNode("yield1", yield),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+				},
+			},
+			SkipValidation: true,
+		},
+		{
+			Name:  "InsertSortAfterOtherSort",
+			Rules: []plan.Rule{universe.StableOutputSortRule{}},
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("from0", from),
+					plan.CreatePhysicalNode("sort1", &universe.SortProcedureSpec{
+						Columns: []string{execute.DefaultValueColLabel},
+					}),
+					plan.CreatePhysicalNode("yield2", yield),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+				},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("from0", from),
+					plan.CreatePhysicalNode("sort1", &universe.SortProcedureSpec{
+						Columns: []string{execute.DefaultValueColLabel},
+					}),
+					plan.CreatePhysicalNode("sort_stable_output", sortTime),
+					plan.CreatePhysicalNode("yield2", yield),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+					{2, 3},
+				},
+			},
+			SkipValidation: true,
+		},
+		{
+			Name:  "AlreadySorted",
+			Rules: []plan.Rule{universe.StableOutputSortRule{}},
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("from0", from),
+					plan.CreatePhysicalNode("sort1", sortTime),
+					plan.CreatePhysicalNode("yield2", yield),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+				},
+			},
+			NoChange:       true,
+			SkipValidation: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			plantest.PhysicalRuleTestHelper(t, &tc)
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package universe
 
 import (
+	"time"
+
 	"github.com/apache/arrow/go/v7/arrow/math"
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/array"
@@ -9,6 +11,7 @@ import (
 	"github.com/influxdata/flux/internal/errors"
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/runtime"
+	"github.com/influxdata/flux/values"
 )
 
 const SumKind = "sum"
@@ -20,7 +23,8 @@ type SumOpSpec struct {
 func init() {
 	sumSignature := runtime.MustLookupBuiltinType("universe", "sum")
 
-	runtime.RegisterPackageValue("universe", SumKind, flux.MustValue(flux.FunctionValue(SumKind, CreateSumOpSpec, sumSignature)))
+	sumFunction = flux.MustValue(flux.FunctionValue(SumKind, CreateSumOpSpec, sumSignature))
+	runtime.RegisterPackageValue("universe", SumKind, sumFunction)
 	plan.RegisterProcedureSpec(SumKind, newSumProcedure, SumKind)
 	execute.RegisterTransformation(SumKind, createSumTransformation)
 }
@@ -101,6 +105,9 @@ func (a *SumAgg) NewFloatAgg() execute.DoFloatAgg {
 func (a *SumAgg) NewStringAgg() execute.DoStringAgg {
 	return nil
 }
+func (a *SumAgg) NewDurationAgg() execute.DoDurationAgg {
+	return new(SumDurationAgg)
+}
 
 type SumIntAgg struct {
 	sum int64
@@ -191,3 +198,20 @@ func (a *SumFloatAgg) ValueFloat() float64 {
 func (a *SumFloatAgg) IsNull() bool {
 	return !a.ok
 }
+
+type SumDurationAgg struct {
+	sum SumIntAgg
+}
+
+func (a *SumDurationAgg) DoDuration(vs *array.Int) {
+	a.sum.DoInt(vs)
+}
+func (a *SumDurationAgg) Type() flux.ColType {
+	return flux.TDuration
+}
+func (a *SumDurationAgg) ValueDuration() values.Duration {
+	return values.ConvertDurationNsecs(time.Duration(a.sum.ValueInt()))
+}
+func (a *SumDurationAgg) IsNull() bool {
+	return a.sum.IsNull()
+}
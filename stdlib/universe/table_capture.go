@@ -0,0 +1,235 @@
+package universe
+
+import (
+	"context"
+	"sync"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/values/objects"
+)
+
+// tableCaptureKind is the kind of the node that is planned
+// at the end of the stream read by tableFind, findColumn and findRecord.
+const tableCaptureKind = "tableCapture"
+
+func init() {
+	plan.RegisterProcedureSpec(tableCaptureKind, newTableCaptureProcedure, tableCaptureKind)
+	execute.RegisterTransformation(tableCaptureKind, createTableCaptureTransformation)
+}
+
+// tableCapture receives the first table of a stream whose group key
+// matches a predicate.
+//
+// The capture is shared between the function that starts the program
+// and the capture node of the program. The capture node cancels
+// the program as soon as the table has been read so the remainder
+// of the stream is never computed.
+type tableCapture struct {
+	fn     *execute.TablePredicateFn
+	cancel context.CancelFunc
+
+	mu    sync.Mutex
+	table *objects.Table
+	done  bool
+}
+
+// newTableCaptureObject returns a stream that captures the matching
+// table of the stream into the capture.
+func newTableCaptureObject(to *flux.TableObject, capture *tableCapture) *flux.TableObject {
+	return &flux.TableObject{
+		Kind:    tableCaptureKind,
+		Spec:    &tableCaptureOpSpec{capture: capture},
+		Parents: []*flux.TableObject{to},
+	}
+}
+
+// set completes the capture with the found table.
+// The table is nil if no table matched the predicate.
+func (c *tableCapture) set(tbl *objects.Table) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.done {
+		if tbl != nil {
+			tbl.Release()
+		}
+		return
+	}
+	c.table, c.done = tbl, true
+	if tbl != nil && c.cancel != nil {
+		c.cancel()
+	}
+}
+
+// result returns the captured table or nil if no table was captured.
+func (c *tableCapture) result() *objects.Table {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.table
+}
+
+type tableCaptureOpSpec struct {
+	capture *tableCapture
+}
+
+func (s *tableCaptureOpSpec) Kind() flux.OperationKind {
+	return tableCaptureKind
+}
+
+type tableCaptureProcedureSpec struct {
+	plan.DefaultCost
+	capture *tableCapture
+}
+
+func newTableCaptureProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*tableCaptureOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+	return &tableCaptureProcedureSpec{capture: spec.capture}, nil
+}
+
+func (s *tableCaptureProcedureSpec) Kind() plan.ProcedureKind {
+	return tableCaptureKind
+}
+
+func (s *tableCaptureProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+func createTableCaptureTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*tableCaptureProcedureSpec)
+	if !ok {
+		return nil, nil, errors.Newf(codes.Internal, "invalid spec type %T", spec)
+	}
+	t := &tableCaptureTransformation{
+		ctx:      a.Context(),
+		capture:  s.capture,
+		d:        execute.NewTransportDataset(id, a.Allocator()),
+		rejected: execute.NewGroupLookup(),
+	}
+	return execute.NewTransformationFromTransport(t), t.d, nil
+}
+
+// tableCaptureTransformation evaluates the predicate of the capture
+// once for each group key. The chunks of other tables are dropped
+// as they arrive and only the chunks of the matching table are
+// retained. The transformation produces no output.
+type tableCaptureTransformation struct {
+	ctx     context.Context
+	capture *tableCapture
+	d       *execute.TransportDataset
+
+	rejected *execute.GroupLookup
+	key      flux.GroupKey
+	cols     []flux.ColMeta
+	chunks   []table.Chunk
+	done     bool
+}
+
+func (t *tableCaptureTransformation) ProcessMessage(m execute.Message) error {
+	defer m.Ack()
+
+	switch m := m.(type) {
+	case execute.FinishMsg:
+		if err := m.Error(); err != nil {
+			t.release()
+			t.capture.set(nil)
+		} else if err := t.complete(); err != nil {
+			t.d.Finish(err)
+			return nil
+		}
+		t.d.Finish(m.Error())
+		return nil
+	case execute.ProcessChunkMsg:
+		return t.processChunk(m.TableChunk())
+	case execute.FlushKeyMsg:
+		if t.key != nil && t.key.Equal(m.Key()) {
+			return t.complete()
+		}
+		return nil
+	}
+	return nil
+}
+
+func (t *tableCaptureTransformation) processChunk(chunk table.Chunk) error {
+	if t.done {
+		return nil
+	}
+	key := chunk.Key()
+	if t.key == nil {
+		if _, ok := t.rejected.Lookup(key); ok {
+			return nil
+		}
+		found, err := t.match(key, chunk.Cols())
+		if err != nil {
+			return err
+		} else if !found {
+			t.rejected.Set(key, true)
+			return nil
+		}
+		t.key, t.cols = key, chunk.Cols()
+	} else if !t.key.Equal(key) {
+		return nil
+	}
+	chunk.Retain()
+	t.chunks = append(t.chunks, chunk)
+	return nil
+}
+
+func (t *tableCaptureTransformation) match(key flux.GroupKey, cols []flux.ColMeta) (bool, error) {
+	tbl := &table.BufferedTable{GroupKey: key, Columns: cols}
+	fn, err := t.capture.fn.Prepare(tbl)
+	if err != nil {
+		return false, err
+	}
+	found, err := fn.Eval(t.ctx, tbl)
+	if err != nil {
+		return false, errors.Wrap(err, codes.Inherit, "failed to evaluate group key predicate function")
+	}
+	return found, nil
+}
+
+// complete passes the matching table to the capture.
+func (t *tableCaptureTransformation) complete() error {
+	if t.done {
+		return nil
+	}
+	t.done = true
+	if t.key == nil {
+		t.capture.set(nil)
+		return nil
+	}
+
+	buffers := make([]flux.ColReader, len(t.chunks))
+	for i, c := range t.chunks {
+		buf := c.Buffer()
+		buffers[i] = &buf
+	}
+	t.chunks = nil
+	bt := &table.BufferedTable{
+		GroupKey: t.key,
+		Columns:  t.cols,
+		Buffers:  buffers,
+	}
+	tbl, err := objects.NewTable(bt)
+	bt.Done()
+	if err != nil {
+		return err
+	}
+	t.capture.set(tbl)
+	return nil
+}
+
+func (t *tableCaptureTransformation) release() {
+	for _, c := range t.chunks {
+		c.Release()
+	}
+	t.chunks = nil
+	t.rejected.Clear()
+}
@@ -28,12 +28,19 @@ const (
 	getRecordIndexArg            = "idx"
 )
 
+// findColumnFunction and findRecordFunction are the registered values
+// of findColumn and findRecord. Calls to them in filter predicates are
+// planned as subqueries.
+var findColumnFunction, findRecordFunction values.Function
+
 func init() {
+	findColumnFunction = NewFindColumnFunction().(values.Function)
+	findRecordFunction = NewFindRecordFunction().(values.Function)
 	runtime.RegisterPackageValue("universe", "tableFind", NewTableFindFunction())
 	runtime.RegisterPackageValue("universe", "getColumn", NewGetColumnFunction())
 	runtime.RegisterPackageValue("universe", "getRecord", NewGetRecordFunction())
-	runtime.RegisterPackageValue("universe", "findColumn", NewFindColumnFunction())
-	runtime.RegisterPackageValue("universe", "findRecord", NewFindRecordFunction())
+	runtime.RegisterPackageValue("universe", "findColumn", findColumnFunction)
+	runtime.RegisterPackageValue("universe", "findRecord", findRecordFunction)
 }
 
 func NewTableFindFunction() values.Value {
@@ -77,6 +84,10 @@ func tableFindCall(ctx context.Context, args values.Object) (values.Value, error
 
 // Returns an error in the second return value, or the found table in the first
 // return value, or nil to indicate that no table was found.
+//
+// The stream is planned with a capture node at its end. The capture node
+// retains only the chunks of the matching table and the program is cancelled
+// as soon as that table has been read.
 func tableFind(ctx context.Context, to *flux.TableObject, fn *execute.TablePredicateFn) (*objects.Table, error) {
 	if !execute.HaveExecutionDependencies(ctx) {
 		return nil, errors.New(codes.Internal, "no execution context for tableFind to use")
@@ -84,8 +95,12 @@ func tableFind(ctx context.Context, to *flux.TableObject, fn *execute.TablePredi
 
 	deps := execute.GetExecutionDependencies(ctx)
 
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	capture := &tableCapture{fn: fn, cancel: cancel}
 	c := lang.TableObjectCompiler{
-		Tables: to,
+		Tables: newTableCaptureObject(to, capture),
 		Now:    *deps.Now,
 	}
 
@@ -103,49 +118,30 @@ func tableFind(ctx context.Context, to *flux.TableObject, fn *execute.TablePredi
 		return nil, errors.Wrap(err, codes.Inherit, "error in table object start")
 	}
 
-	var t *objects.Table
-	var found bool
+	// The capture node produces no tables, but the results
+	// must be read for the program to complete.
 	for res := range q.Results() {
-		if err := res.Tables().Do(func(tbl flux.Table) error {
-			defer tbl.Done()
-			if found {
-				// the result is filled, you can skip other tables
-				return nil
-			}
-
-			preparedFn, err := fn.Prepare(tbl)
-			if err != nil {
-				return err
-			}
-
-			found, err = preparedFn.Eval(ctx, tbl)
-			if err != nil {
-				return errors.Wrap(err, codes.Inherit, "failed to evaluate group key predicate function")
-			}
-
-			if found {
-				t, err = objects.NewTable(tbl)
-				if err != nil {
-					return err
-				}
-			} else {
-				// TODO(jsternberg): Remove the Do call when Done
-				// is implemented for all table types.
-				_ = tbl.Do(func(flux.ColReader) error { return nil })
-			}
+		if e := res.Tables().Do(func(tbl flux.Table) error {
+			tbl.Done()
 			return nil
-		}); err != nil {
-			return nil, err
+		}); e != nil && err == nil {
+			err = e
 		}
 	}
+	q.Done()
 
 	deps.Metadata.Add("flux/query-plan",
 		fmt.Sprintf("%v", plan.Formatted(p.(*lang.Program).PlanSpec, plan.WithDetails())))
 
-	if !found {
-		return nil, nil
+	// Cancelling the program once the table has been found
+	// may surface as an error that can be ignored.
+	if t := capture.result(); t != nil {
+		return t, nil
 	}
-	return t, err
+	if err == nil {
+		err = q.Err()
+	}
+	return nil, err
 }
 
 func NewGetColumnFunction() values.Value {
@@ -223,7 +219,13 @@ func arrayFromColumn(idx int, tbl flux.Table) (values.Array, error) {
 				} else {
 					vsSlice = append(vsSlice, values.NewNull(semantic.BasicTime))
 				}
+			case flux.TBytes, flux.TDuration:
+				vsSlice = append(vsSlice, execute.ValueForRow(cr, i, idx))
 			default:
+				if typ.IsArray() {
+					vsSlice = append(vsSlice, execute.ValueForRow(cr, i, idx))
+					break
+				}
 				execute.PanicUnknownType(typ)
 			}
 		}
@@ -313,8 +315,13 @@ func objectFromRow(idx int, cr flux.ColReader) values.Object {
 			} else {
 				v = values.NewNull(semantic.BasicTime)
 			}
+		case flux.TBytes, flux.TDuration:
+			v = execute.ValueForRow(cr, idx, j)
 		default:
-			execute.PanicUnknownType(c.Type)
+			if !c.Type.IsArray() {
+				execute.PanicUnknownType(c.Type)
+			}
+			v = execute.ValueForRow(cr, idx, j)
 		}
 		vsMap[c.Label] = v
 	}
@@ -17,7 +17,15 @@ import (
 
 const UnionKind = "union"
 
+const (
+	// UnionModeStrict unions the tables without changing their schema.
+	UnionModeStrict = "strict"
+	// UnionModeAlign aligns the schema of the tables that share a group key.
+	UnionModeAlign = "align"
+)
+
 type UnionOpSpec struct {
+	AlignSchema bool `json:"alignSchema,omitempty"`
 }
 
 func (s *UnionOpSpec) Kind() flux.OperationKind {
@@ -56,11 +64,24 @@ func createUnionOpSpec(args flux.Arguments, a *flux.Administration) (flux.Operat
 		return nil, err
 	}
 
-	return &UnionOpSpec{}, nil
+	spec := new(UnionOpSpec)
+	if mode, ok, err := args.GetString("mode"); err != nil {
+		return nil, err
+	} else if ok {
+		switch mode {
+		case UnionModeStrict:
+		case UnionModeAlign:
+			spec.AlignSchema = true
+		default:
+			return nil, errors.Newf(codes.Invalid, "union mode must be %q or %q, got %q", UnionModeStrict, UnionModeAlign, mode)
+		}
+	}
+	return spec, nil
 }
 
 type UnionProcedureSpec struct {
 	plan.DefaultCost
+	AlignSchema bool
 }
 
 func (s *UnionProcedureSpec) Kind() plan.ProcedureKind {
@@ -68,11 +89,15 @@ func (s *UnionProcedureSpec) Kind() plan.ProcedureKind {
 }
 
 func (s *UnionProcedureSpec) Copy() plan.ProcedureSpec {
-	return &UnionProcedureSpec{}
+	return &UnionProcedureSpec{AlignSchema: s.AlignSchema}
 }
 
 func newUnionProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
-	return &UnionProcedureSpec{}, nil
+	spec, ok := qs.(*UnionOpSpec)
+	if !ok {
+		return nil, errors.Newf(codes.Internal, "invalid spec type %T", qs)
+	}
+	return &UnionProcedureSpec{AlignSchema: spec.AlignSchema}, nil
 }
 
 type unionTransformation struct {
@@ -97,6 +122,9 @@ func createUnionTransformation(id execute.DatasetID, mode execute.AccumulationMo
 		return nil, nil, errors.Newf(codes.Invalid, "invalid spec type %T", spec)
 	}
 
+	if s.AlignSchema {
+		return newUnionAlignTransformation(id, a.Parents(), a.Allocator())
+	}
 	if feature.OptimizeUnionTransformation().Enabled(a.Context()) {
 		return newUnionTransformation2(id, a.Parents(), a.Allocator())
 	}
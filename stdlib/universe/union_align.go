@@ -0,0 +1,198 @@
+package universe
+
+import (
+	"sync"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/array"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/table"
+	"github.com/influxdata/flux/internal/errors"
+	"github.com/influxdata/flux/memory"
+)
+
+// unionAlignTransformation unions its inputs and aligns the schema
+// of the tables that share a group key.
+//
+// The chunks of each group key are buffered until every input
+// has finished. The output table of a group key has every column
+// of the input tables with that group key. A column that is missing
+// from a chunk is filled with nulls and a column with different
+// numeric types is converted to a float.
+type unionAlignTransformation struct {
+	d       *execute.TransportDataset
+	mem     memory.Allocator
+	parents int
+	mu      sync.Mutex
+}
+
+type unionAlignState struct {
+	schema unionSchema
+	chunks []table.Chunk
+}
+
+func newUnionAlignTransformation(id execute.DatasetID, parents []execute.DatasetID, mem memory.Allocator) (execute.Transformation, execute.Dataset, error) {
+	t := &unionAlignTransformation{
+		d:       execute.NewTransportDataset(id, mem),
+		mem:     mem,
+		parents: len(parents),
+	}
+	return execute.NewTransformationFromTransport(t), t.d, nil
+}
+
+func (t *unionAlignTransformation) ProcessMessage(m execute.Message) error {
+	defer m.Ack()
+
+	// It is possible to receive messages from different parents concurrently.
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// If this transformation was already marked as finished
+	// from an error, do not accept new messages at all.
+	if t.parents == 0 {
+		return nil
+	}
+
+	switch m := m.(type) {
+	case execute.FinishMsg:
+		t.finish(m.Error())
+		return nil
+	case execute.ProcessChunkMsg:
+		return t.processChunk(m.TableChunk())
+	case execute.FlushKeyMsg:
+		return nil
+	}
+	return nil
+}
+
+func (t *unionAlignTransformation) processChunk(chunk table.Chunk) error {
+	state := t.d.LookupOrCreate(chunk.Key(), func() interface{} {
+		return &unionAlignState{}
+	}).(*unionAlignState)
+
+	schema, err := alignSchema(state.schema, chunk.Cols())
+	if err != nil {
+		return err
+	}
+	state.schema = schema
+
+	chunk.Retain()
+	state.chunks = append(state.chunks, chunk)
+	return nil
+}
+
+// alignSchema adds the columns to the schema. A column that exists
+// in the schema with a different numeric type becomes a float.
+func alignSchema(schema unionSchema, cols []flux.ColMeta) (unionSchema, error) {
+	for _, col := range cols {
+		idx := execute.ColIdx(col.Label, schema.cols)
+		if idx >= 0 && schema.cols[idx].Type == col.Type {
+			continue
+		}
+		if !schema.owned {
+			cpy := make([]flux.ColMeta, len(schema.cols), len(schema.cols)+1)
+			copy(cpy, schema.cols)
+			schema.cols, schema.owned = cpy, true
+		}
+		if idx < 0 {
+			schema.cols = append(schema.cols, col)
+			continue
+		}
+
+		cur := schema.cols[idx]
+		if !isNumericType(cur.Type) || !isNumericType(col.Type) {
+			return unionSchema{}, errors.Newf(codes.FailedPrecondition, "schema collision detected: column \"%s\" is both of type %s and %s", col.Label, col.Type, cur.Type)
+		}
+		schema.cols[idx].Type = flux.TFloat
+	}
+	return schema, nil
+}
+
+func isNumericType(typ flux.ColType) bool {
+	return typ == flux.TInt || typ == flux.TUInt || typ == flux.TFloat
+}
+
+func (t *unionAlignTransformation) finish(err error) {
+	t.parents--
+	if err == nil && t.parents > 0 {
+		return
+	}
+	t.parents = 0
+
+	if err == nil {
+		err = t.flush()
+	}
+	_ = t.d.Range(func(key flux.GroupKey, value interface{}) error {
+		for _, c := range value.(*unionAlignState).chunks {
+			c.Release()
+		}
+		return nil
+	})
+	t.d.Finish(err)
+}
+
+// flush sends the buffered chunks with the aligned schema of their group key.
+func (t *unionAlignTransformation) flush() error {
+	return t.d.Range(func(key flux.GroupKey, value interface{}) error {
+		state := value.(*unionAlignState)
+		chunks := state.chunks
+		state.chunks = nil
+		for i, c := range chunks {
+			out := t.align(c, state.schema.cols)
+			c.Release()
+			if err := t.d.Process(out); err != nil {
+				for _, c := range chunks[i+1:] {
+					c.Release()
+				}
+				return err
+			}
+		}
+		return t.d.FlushKey(key)
+	})
+}
+
+// align returns a chunk with the columns of the schema.
+func (t *unionAlignTransformation) align(chunk table.Chunk, cols []flux.ColMeta) table.Chunk {
+	buffer := arrow.TableBuffer{
+		GroupKey: chunk.Key(),
+		Columns:  cols,
+		Values:   make([]array.Array, len(cols)),
+	}
+	for j, col := range cols {
+		idx := chunk.Index(col.Label)
+		if idx < 0 {
+			buffer.Values[j] = arrow.Nulls(col.Type, chunk.Len(), t.mem)
+			continue
+		}
+
+		vs := chunk.Values(idx)
+		if typ := chunk.Col(idx).Type; typ != col.Type {
+			buffer.Values[j] = t.toFloat(vs, typ)
+			continue
+		}
+		vs.Retain()
+		buffer.Values[j] = vs
+	}
+	return table.ChunkFromBuffer(buffer)
+}
+
+// toFloat converts an integer or unsigned integer array to a float array.
+func (t *unionAlignTransformation) toFloat(vs array.Array, typ flux.ColType) array.Array {
+	b := arrow.NewFloatBuilder(t.mem)
+	b.Resize(vs.Len())
+	for i, n := 0, vs.Len(); i < n; i++ {
+		if vs.IsNull(i) {
+			b.AppendNull()
+			continue
+		}
+		switch typ {
+		case flux.TInt:
+			b.Append(float64(vs.(*array.Int).Value(i)))
+		case flux.TUInt:
+			b.Append(float64(vs.(*array.Uint).Value(i)))
+		}
+	}
+	return b.NewArray()
+}
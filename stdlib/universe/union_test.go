@@ -185,6 +185,44 @@ func TestUnion_NewQuery(t *testing.T) {
 				union(tables: [{a: "a"}, {a: "b"}])`,
 			WantErr: true,
 		},
+		{
+			Name: "align union",
+			Raw: `
+				a = from(bucket:"dbA")
+				b = from(bucket:"dbB")
+				union(tables: [a, b], mode: "align")`,
+			Want: &operation.Spec{Operations: []*operation.Node{
+				{
+					ID: "from0",
+					Spec: &influxdb.FromOpSpec{
+						Bucket: influxdb.NameOrID{Name: "dbA"},
+					},
+				},
+				{
+					ID: "from1",
+					Spec: &influxdb.FromOpSpec{
+						Bucket: influxdb.NameOrID{Name: "dbB"},
+					},
+				},
+				{
+					ID:   "union2",
+					Spec: &universe.UnionOpSpec{AlignSchema: true},
+				},
+			},
+				Edges: []operation.Edge{
+					{Parent: "from0", Child: "union2"},
+					{Parent: "from1", Child: "union2"},
+				},
+			},
+		},
+		{
+			Name: "invalid union mode",
+			Raw: `
+				a = from(bucket:"dbA")
+				b = from(bucket:"dbB")
+				union(tables: [a, b], mode: "loose")`,
+			WantErr: true,
+		},
 	}
 	for _, tc := range tests {
 		tc := tc
@@ -601,6 +601,9 @@ var binaryFuncLookup = map[BinaryFuncSignature]BinaryFunction{
 		r := rv.Time().Time()
 		return NewBool(!l.Equal(r)), nil
 	},
+	{Operator: ast.NotEqualOperator, Left: semantic.Bytes, Right: semantic.Bytes}: func(lv, rv Value) (Value, error) {
+		return NewBool(!lv.Equal(rv)), nil
+	},
 
 	{Operator: ast.RegexpMatchOperator, Left: semantic.String, Right: semantic.Regexp}: func(lv, rv Value) (Value, error) {
 		l := lv.Str()